@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// announcementDeliverer is the trips use case that sends announcements left for the background.
+type announcementDeliverer interface {
+	DeliverPendingAnnouncements(ctx context.Context) (int, error)
+}
+
+// runAnnouncementDelivery sends pending announcements every interval until ctx is done.
+func runAnnouncementDelivery(ctx context.Context, d announcementDeliverer, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		n, err := d.DeliverPendingAnnouncements(ctx)
+		if err != nil {
+			log.Printf("announcement delivery: %v", err)
+		}
+		if n > 0 {
+			log.Printf("announcement delivery: sent %d announcements", n)
+		}
+	}
+}
@@ -6,28 +6,77 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/filekeys"
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/mailnotifier"
+	memannouncementrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/announcementrepo"
+	memapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/apikeyrepo"
+	memattendancerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/attendancerepo"
+	memcommentrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/commentrepo"
+	mememergencyinforepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/emergencyinforepo"
+	memevents "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/events"
 	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	meminvitationrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/invitationrepo"
+	memitineraryrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/itineraryrepo"
+	memkeyprovider "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/keyprovider"
+	memmailer "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/mailer"
 	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memratelimit "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ratelimit"
+	memridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ridesharerepo"
 	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
 	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	memtripseriesrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/tripseriesrepo"
+	memtriptemplaterepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triptemplaterepo"
+	memwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/waiverrepo"
 	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	pgannouncementrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/announcementrepo"
+	pgapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/apikeyrepo"
+	pgattendancerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/attendancerepo"
+	pgcommentrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/commentrepo"
+	pgemergencyinforepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/emergencyinforepo"
 	pgidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/idempotency"
+	pginvitationrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/invitationrepo"
+	pgitineraryrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/itineraryrepo"
 	pgmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	pgratelimit "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/ratelimit"
+	pgridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/ridesharerepo"
 	pgrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/rsvprepo"
 	pgtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/triprepo"
+	pgtripseriesrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/tripseriesrepo"
+	pgtriptemplaterepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/triptemplaterepo"
+	pgwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/waiverrepo"
+	smtpmailer "github.com/BennettSmith/ebo-planner-backend/internal/adapters/smtp"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/apikeys"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/emergencyinfo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/invitations"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/waivers"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
 	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/config"
+	announcementrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+	apikeyrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+	attendancerepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+	commentrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	emergencyinforepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
 	idempotencyport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+	invitationrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+	itineraryrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
+	keyproviderport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+	mailerport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
 	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	ratelimitport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+	ridesharerepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
 	rsvprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
 	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	tripseriesrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+	triptemplaterepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+	waiverrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
 )
 
 func main() {
@@ -37,12 +86,16 @@ func main() {
 	// - Production: require JWT_* env vars and enforce bearer auth
 	// - Local dev: set AUTH_MODE=dev to bypass JWT verification and use X-Debug-Subject
 	authMode := getenv("AUTH_MODE", "jwt")
-	var authMW func(http.Handler) http.Handler
+	var (
+		authMW         func(http.Handler) http.Handler
+		identityTokens httpapi.IdentityTokenVerifier
+	)
 	authIssuer := ""
 	switch authMode {
 	case "dev":
-		authMW = httpapi.NewDevAuthMiddleware(getenv("DEV_SUBJECT", "dev|local"))
 		authIssuer = getenv("DEV_ISSUER", "dev")
+		authMW = httpapi.NewDevAuthMiddlewareWithIssuer(getenv("DEV_SUBJECT", "dev|local"), authIssuer)
+		identityTokens = httpapi.NewDevIdentityTokenVerifier(authIssuer)
 	default:
 		jwtCfg, err := config.LoadJWTConfigFromEnv()
 		if err != nil {
@@ -50,6 +103,7 @@ func main() {
 		}
 		verifier := jwtverifier.New(jwtCfg)
 		authMW = httpapi.NewAuthMiddleware(verifier)
+		identityTokens = verifier
 		authIssuer = jwtCfg.Issuer
 	}
 
@@ -61,6 +115,18 @@ func main() {
 		tripRepo   triprepoport.Repository
 		rsvpRepo   rsvprepoport.Repository
 		idemStore  idempotencyport.Store
+		rateStore  ratelimitport.Store
+		apiKeyRepo apikeyrepoport.Repository
+		inviteRepo invitationrepoport.Repository
+		rideRepo   ridesharerepoport.Repository
+		tmplRepo   triptemplaterepoport.Repository
+		seriesRepo tripseriesrepoport.Repository
+		itinRepo   itineraryrepoport.Repository
+		attendRepo attendancerepoport.Repository
+		emergRepo  emergencyinforepoport.Repository
+		waiverRepo waiverrepoport.Repository
+		commRepo   commentrepoport.Repository
+		annRepo    announcementrepoport.Repository
 		cleanup    func()
 	)
 
@@ -77,26 +143,214 @@ func main() {
 		tripRepo = pgtriprepo.NewRepo(pool)
 		rsvpRepo = pgrsvprepo.NewRepo(pool)
 		idemStore = pgidempotency.NewStore(pool, authIssuer)
+		rateStore = pgratelimit.NewStore(pool)
+		apiKeyRepo = pgapikeyrepo.NewRepo(pool)
+		inviteRepo = pginvitationrepo.NewRepo(pool)
+		rideRepo = pgridesharerepo.NewRepo(pool)
+		tmplRepo = pgtriptemplaterepo.NewRepo(pool)
+		seriesRepo = pgtripseriesrepo.NewRepo(pool)
+		itinRepo = pgitineraryrepo.NewRepo(pool)
+		attendRepo = pgattendancerepo.NewRepo(pool)
+		emergRepo = pgemergencyinforepo.NewRepo(pool)
+		waiverRepo = pgwaiverrepo.NewRepo(pool)
+		commRepo = pgcommentrepo.NewRepo(pool)
+		annRepo = pgannouncementrepo.NewRepo(pool)
 	default:
 		memberRepo = memmemberrepo.NewRepo()
 		tripRepo = memtriprepo.NewRepo()
 		rsvpRepo = memrsvprepo.NewRepo()
 		idemStore = memidempotency.NewStore()
+		rateStore = memratelimit.NewStore()
+		apiKeyRepo = memapikeyrepo.NewRepo()
+		inviteRepo = meminvitationrepo.NewRepo()
+		rideRepo = memridesharerepo.NewRepo()
+		tmplRepo = memtriptemplaterepo.NewRepo()
+		seriesRepo = memtripseriesrepo.NewRepo()
+		itinRepo = memitineraryrepo.NewRepo()
+		attendRepo = memattendancerepo.NewRepo()
+		emergRepo = mememergencyinforepo.NewRepo()
+		waiverRepo = memwaiverrepo.NewRepo()
+		commRepo = memcommentrepo.NewRepo()
+		annRepo = memannouncementrepo.NewRepo()
 	}
 
 	if cleanup != nil {
 		defer cleanup()
 	}
 
-	memberSvc := members.NewService(memberRepo, clk)
-	tripSvc := trips.NewService(tripRepo, memberRepo, rsvpRepo)
+	// Invite codes are issued with cmd/invites or by members; MEMBERSHIP_MODE=invite makes them mandatory.
+	membershipCfg, err := config.LoadMembershipConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid membership config: %v", err)
+	}
+
+	// Verification links are mailed on signup and whenever an address changes.
+	mailCfg, err := config.LoadMailConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid mail config: %v", err)
+	}
+	var mail mailerport.Mailer = memmailer.NewLogMailer()
+	if mailCfg.Mailer == "smtp" {
+		mail = smtpmailer.NewMailer(smtpmailer.Options{
+			Addr:     mailCfg.SMTPAddr,
+			From:     mailCfg.SMTPFrom,
+			Username: mailCfg.SMTPUsername,
+			Password: mailCfg.SMTPPassword,
+		})
+	}
+	verifyURL := mailCfg.VerificationURL
+	if verifyURL == "" {
+		verifyURL = strings.TrimRight(getenv("PUBLIC_BASE_URL", "http://localhost:"+port), "/") + httpapi.EmailVerificationConfirmPath
+	}
+
+	memberSvc := members.NewServiceWithOptions(memberRepo, clk, members.Options{
+		RequireInvite: membershipCfg.RequireInvite(),
+		Invitations:   inviteRepo,
+		Mailer:        mail,
+		Verification: members.VerificationOptions{
+			Secret:     []byte(mailCfg.VerificationSecret),
+			TTL:        mailCfg.VerificationTTL,
+			ConfirmURL: verifyURL,
+		},
+	})
+	// Members invite people at /members/me/invitations. cmd/invites needs postgres, so the memory
+	// backend issues a startup code (logged) for the first member to join with.
+	inviteSvc := invitations.NewService(inviteRepo, memberRepo, clk)
+	if storageBackend != "postgres" && membershipCfg.RequireInvite() {
+		created, err := inviteSvc.Create(context.Background(), invitations.CreateInput{CreatedByAdmin: "startup"})
+		if err != nil {
+			log.Fatalf("bootstrap invite: %v", err)
+		}
+		log.Printf("membership is invite-only: bootstrap invite code %s (expires %s)", created.Code, created.Invitation.ExpiresAt.Format(time.RFC3339))
+	}
+	// Difficulty ratings run 1..TRIP_DIFFICULTY_SCALE; series generate TRIP_SERIES_HORIZON_DAYS ahead.
+	tripCfg, err := config.LoadTripConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid trip config: %v", err)
+	}
+	// Domain events (e.g. new trip comments) fan out in-process to subscribers registered on
+	// eventBus.
+	eventBus := memevents.NewBus()
+	tripSvc := trips.NewServiceWithOptions(tripRepo, memberRepo, rsvpRepo, trips.Options{
+		RideShares:        rideRepo,
+		DifficultyScale:   tripCfg.DifficultyScale,
+		Templates:         tmplRepo,
+		Series:            seriesRepo,
+		SeriesHorizonDays: tripCfg.SeriesHorizonDays,
+		Itineraries:       itinRepo,
+		Attendance:        attendRepo,
+		Waivers:           waiverRepo,
+		Comments:          commRepo,
+		Events:            eventBus,
+		Announcements:     annRepo,
+		Notifier:          mailnotifier.New(mail),
+		Clock:             clk,
+		// Whole-club announcements are sent by runAnnouncementDelivery, not in the request.
+		BackgroundAnnouncements: tripCfg.AnnouncementDeliveryInterval > 0,
+	})
+	// Waivers are created and versioned with cmd/waivers (postgres backend).
+	waiverSvc := waivers.NewService(waiverRepo, clk)
+
+	// Emergency info is encrypted with keys from EMERGENCY_INFO_KEY_FILE. Without one the memory
+	// backend uses a throwaway key; the postgres backend leaves the feature off rather than
+	// store data it could not decrypt after a restart.
+	emergCfg, err := config.LoadEmergencyInfoConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid emergency info config: %v", err)
+	}
+	var emergKeys keyproviderport.Provider
+	switch {
+	case emergCfg.KeyFile != "":
+		if emergKeys, err = filekeys.NewProvider(emergCfg.KeyFile); err != nil {
+			log.Fatalf("invalid emergency info key file: %v", err)
+		}
+	case storageBackend != "postgres":
+		if emergKeys, err = memkeyprovider.NewEphemeralProvider(); err != nil {
+			log.Fatalf("emergency info keys: %v", err)
+		}
+	default:
+		log.Printf("emergency info disabled: EMERGENCY_INFO_KEY_FILE is not set")
+	}
+	var emergencyInfo httpapi.EmergencyInfo
+	if emergKeys != nil {
+		emergSvc := emergencyinfo.NewService(emergRepo, emergKeys, memberRepo, tripRepo, rsvpRepo, clk)
+		emergSvc.AccessWindowDays = emergCfg.AccessDays
+		emergencyInfo = emergSvc
+	}
+
+	apiKeyCfg, err := config.LoadAPIKeyConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid api key config: %v", err)
+	}
+
+	// Service accounts authenticate with `Authorization: ApiKey <token>`; everything else
+	// goes through member auth. Keys are issued with cmd/apikeys (postgres backend).
+	authMW = httpapi.NewAPIKeyAuthMiddleware(apikeys.NewService(apiKeyRepo, clk), authMW)
 
 	// Real server implementation for Members; other endpoints remain strict-unimplemented.
-	api := httpapi.NewServer(memberSvc, tripSvc, idemStore)
+	api := httpapi.NewServer(memberSvc, tripSvc)
+
+	idemCfg, err := config.LoadIdempotencyConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid idempotency config: %v", err)
+	}
+
+	rateCfg, err := config.LoadRateLimitConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid rate limit config: %v", err)
+	}
+	ratePolicy := httpapi.RateLimitPolicy{
+		Default:      ratelimitport.Limit(rateCfg.Default),
+		PerOperation: make(map[string]ratelimitport.Limit, len(rateCfg.PerOperation)),
+		DevAuth:      authMode == "dev",
+	}
+	for op, l := range rateCfg.PerOperation {
+		ratePolicy.PerOperation[op] = ratelimitport.Limit(l)
+	}
+
+	// CORS is optional in-app: leave CORS_ALLOWED_ORIGINS unset when a proxy handles it.
+	corsCfg, err := config.LoadCORSConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid cors config: %v", err)
+	}
+	var corsMW func(http.Handler) http.Handler
+	if corsCfg.Enabled() {
+		corsMW = httpapi.NewCORSMiddleware(httpapi.CORSOptions{
+			AllowedOrigins:   corsCfg.AllowedOrigins,
+			AllowCredentials: corsCfg.AllowCredentials,
+			MaxAge:           corsCfg.MaxAge,
+		})
+	}
 
 	handler := httpapi.NewRouterWithOptions(
 		api,
-		httpapi.RouterOptions{AuthMiddleware: authMW},
+		httpapi.RouterOptions{
+			CORSMiddleware:        corsMW,
+			AuthMiddleware:        authMW,
+			RateLimitMiddleware:   httpapi.NewRateLimitMiddleware(rateStore, clk, ratePolicy),
+			IdempotencyMiddleware: httpapi.NewIdempotencyMiddleware(idemStore, clk, idemCfg.TTL),
+			EmailVerification:     memberSvc,
+			MemberIdentities:      memberSvc,
+			IdentityTokens:        identityTokens,
+			MemberInvitations:     inviteSvc,
+			VehicleGarage:         memberSvc,
+			TripRigs:              tripSvc,
+			Members:               memberSvc,
+			TripSettings:          tripSvc,
+			RideShare:             tripSvc,
+			RequirementsRoster:    tripSvc,
+			TripTemplates:         tripSvc,
+			TripSeries:            tripSvc,
+			TripItinerary:         tripSvc,
+			MemberRSVPs:           tripSvc,
+			RSVPHistory:           tripSvc,
+			TripAttendance:        tripSvc,
+			EmergencyInfo:         emergencyInfo,
+			Waivers:               waiverSvc,
+			TripWaivers:           tripSvc,
+			TripComments:          tripSvc,
+			TripAnnouncements:     tripSvc,
+		},
 	)
 
 	srv := &http.Server{
@@ -109,6 +363,22 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if idemCfg.TTL > 0 && idemCfg.SweepInterval > 0 {
+		go runIdempotencySweeper(ctx, idemStore, clk, idemCfg.SweepInterval, idemCfg.SweepBatchSize)
+	}
+	if idle := ratePolicy.MaxPer(); rateCfg.SweepInterval > 0 && idle > 0 {
+		go runRateLimitSweeper(ctx, rateStore, clk, rateCfg.SweepInterval, idle)
+	}
+	if apiKeyCfg.UsageRetention > 0 && apiKeyCfg.UsageSweepInterval > 0 {
+		go runAPIKeyUsageSweeper(ctx, apiKeyRepo, clk, apiKeyCfg.UsageSweepInterval, apiKeyCfg.UsageRetention)
+	}
+	if tripCfg.SeriesGenerateInterval > 0 {
+		go runTripSeriesGenerator(ctx, tripSvc, tripCfg.SeriesGenerateInterval)
+	}
+	if tripCfg.AnnouncementDeliveryInterval > 0 {
+		go runAnnouncementDelivery(ctx, tripSvc, tripCfg.AnnouncementDeliveryInterval)
+	}
+
 	go func() {
 		log.Printf("api listening on :%s", port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// seriesGenerator is the trips use case that tops up recurring series.
+type seriesGenerator interface {
+	GenerateTripSeriesOccurrences(ctx context.Context) (int, error)
+}
+
+// runTripSeriesGenerator generates upcoming series occurrences now and then every interval
+// until ctx is done, so the horizon keeps rolling forward.
+func runTripSeriesGenerator(ctx context.Context, g seriesGenerator, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		n, err := g.GenerateTripSeriesOccurrences(ctx)
+		if err != nil {
+			log.Printf("trip series generator: %v", err)
+		}
+		if n > 0 {
+			log.Printf("trip series generator: created %d occurrences", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
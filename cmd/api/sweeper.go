@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+// sweepBatchSize bounds rows deleted per statement by sweepers without their own setting.
+const sweepBatchSize = 500
+
+// runIdempotencySweeper purges expired idempotency records every interval until ctx is done.
+func runIdempotencySweeper(ctx context.Context, store idempotency.Store, clk clock.Clock, interval time.Duration, batchSize int) {
+	runSweeper(ctx, "idempotency sweeper", "expired records", interval, batchSize, func(ctx context.Context, limit int) (int, error) {
+		return store.DeleteExpired(ctx, clk.Now(), limit)
+	})
+}
+
+// runRateLimitSweeper purges rate-limit buckets idle for at least idleAfter every interval
+// until ctx is done.
+func runRateLimitSweeper(ctx context.Context, store ratelimit.Store, clk clock.Clock, interval, idleAfter time.Duration) {
+	runSweeper(ctx, "rate limit sweeper", "idle buckets", interval, sweepBatchSize, func(ctx context.Context, limit int) (int, error) {
+		return store.DeleteIdle(ctx, clk.Now().Add(-idleAfter), limit)
+	})
+}
+
+// runAPIKeyUsageSweeper purges API key usage entries older than retention every interval until
+// ctx is done.
+func runAPIKeyUsageSweeper(ctx context.Context, repo apikeyrepo.Repository, clk clock.Clock, interval, retention time.Duration) {
+	runSweeper(ctx, "api key usage sweeper", "usage entries", interval, sweepBatchSize, func(ctx context.Context, limit int) (int, error) {
+		return repo.DeleteUsageBefore(ctx, clk.Now().Add(-retention), limit)
+	})
+}
+
+// runSweeper calls purge every interval until ctx is done.
+//
+// Each tick deletes in batches until a short batch signals the backlog is drained, so a large
+// backlog never holds one long-running delete.
+func runSweeper(ctx context.Context, name, what string, interval time.Duration, batchSize int, purge func(ctx context.Context, limit int) (int, error)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		total := 0
+		for ctx.Err() == nil {
+			n, err := purge(ctx, batchSize)
+			if err != nil {
+				log.Printf("%s: %v", name, err)
+				break
+			}
+			total += n
+			if n < batchSize {
+				break
+			}
+		}
+		if total > 0 {
+			log.Printf("%s: purged %d %s", name, total, what)
+		}
+	}
+}
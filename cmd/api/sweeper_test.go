@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	memapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/apikeyrepo"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	memratelimit "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ratelimit"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+// backlogStore reports a fixed backlog of expired records and records batch sizes.
+type backlogStore struct {
+	*memidempotency.Store
+
+	mu      sync.Mutex
+	backlog int
+	batches []int
+	drained chan struct{}
+}
+
+func (s *backlogStore) DeleteExpired(_ context.Context, _ time.Time, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := min(limit, s.backlog)
+	s.backlog -= n
+	s.batches = append(s.batches, n)
+	if s.backlog == 0 && s.drained != nil {
+		close(s.drained)
+		s.drained = nil
+	}
+	return n, nil
+}
+
+var _ idempotency.Store = (*backlogStore)(nil)
+
+func TestRunIdempotencySweeper_DrainsBacklogInBatches(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(1_000, 0).UTC())
+	drained := make(chan struct{})
+	store := &backlogStore{Store: memidempotency.NewStoreWithClock(clk), backlog: 5, drained: drained}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		runIdempotencySweeper(ctx, store, clk, time.Millisecond, 2)
+		close(done)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("sweeper did not drain backlog")
+	}
+	cancel()
+	<-done
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.batches) < 3 || store.batches[0] != 2 || store.batches[1] != 2 || store.batches[2] != 1 {
+		t.Fatalf("batches=%v, want [2 2 1 ...]", store.batches)
+	}
+}
+
+// sweptBuckets signals after its first DeleteIdle call.
+type sweptBuckets struct {
+	*memratelimit.Store
+	once  sync.Once
+	swept chan struct{}
+}
+
+func (s *sweptBuckets) DeleteIdle(ctx context.Context, before time.Time, limit int) (int, error) {
+	n, err := s.Store.DeleteIdle(ctx, before, limit)
+	s.once.Do(func() { close(s.swept) })
+	return n, err
+}
+
+var _ ratelimit.Store = (*sweptBuckets)(nil)
+
+func TestRunRateLimitSweeper_PurgesIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(1_000, 0).UTC())
+	store := &sweptBuckets{Store: memratelimit.NewStore(), swept: make(chan struct{})}
+	limit := ratelimit.Limit{Burst: 1, Per: time.Hour}
+	bg := context.Background()
+	if _, err := store.Take(bg, "idle", limit, clk.Now()); err != nil {
+		t.Fatalf("Take idle: %v", err)
+	}
+	clk.Add(time.Minute)
+	if _, err := store.Take(bg, "busy", limit, clk.Now()); err != nil {
+		t.Fatalf("Take busy: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(bg)
+	done := make(chan struct{})
+	go func() {
+		runRateLimitSweeper(ctx, store, clk, time.Millisecond, time.Minute)
+		close(done)
+	}()
+	select {
+	case <-store.swept:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("sweeper did not run")
+	}
+	cancel()
+	<-done
+
+	// The bucket idle for a minute was dropped and starts full; the busy one is still empty.
+	if d, err := store.Take(bg, "idle", limit, clk.Now()); err != nil || !d.Allowed {
+		t.Fatalf("idle bucket = %+v err=%v, want swept (allowed)", d, err)
+	}
+	if d, err := store.Take(bg, "busy", limit, clk.Now()); err != nil || d.Allowed {
+		t.Fatalf("busy bucket = %+v err=%v, want kept (denied)", d, err)
+	}
+}
+
+// sweptUsage signals after its first DeleteUsageBefore call.
+type sweptUsage struct {
+	*memapikeyrepo.Repo
+	once  sync.Once
+	swept chan struct{}
+}
+
+func (r *sweptUsage) DeleteUsageBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	n, err := r.Repo.DeleteUsageBefore(ctx, before, limit)
+	r.once.Do(func() { close(r.swept) })
+	return n, err
+}
+
+var _ apikeyrepo.Repository = (*sweptUsage)(nil)
+
+func TestRunAPIKeyUsageSweeper_PurgesDaysPastRetention(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	repo := &sweptUsage{Repo: memapikeyrepo.NewRepo(), swept: make(chan struct{})}
+	bg := context.Background()
+	key := apikeyrepo.APIKey{APIKey: domain.APIKey{ID: "key-1", Name: "bot", Prefix: "abc", CreatedBy: "admin", CreatedAt: clk.Now()}}
+	if err := repo.Create(bg, key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, at := range []time.Time{clk.Now(), clk.Now().Add(48 * time.Hour)} {
+		if err := repo.RecordUsage(bg, apikeyrepo.Usage{KeyID: key.ID, UsedAt: at, Method: "GET", Path: "/trips"}); err != nil {
+			t.Fatalf("RecordUsage: %v", err)
+		}
+	}
+	clk.Add(48 * time.Hour)
+
+	ctx, cancel := context.WithCancel(bg)
+	done := make(chan struct{})
+	go func() {
+		runAPIKeyUsageSweeper(ctx, repo, clk, time.Millisecond, 24*time.Hour)
+		close(done)
+	}()
+	select {
+	case <-repo.swept:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("sweeper did not run")
+	}
+	cancel()
+	<-done
+
+	usage, err := repo.ListUsage(bg, key.ID, 10)
+	if err != nil {
+		t.Fatalf("ListUsage: %v", err)
+	}
+	if len(usage) != 1 || !usage[0].Day.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("usage=%+v, want only the recent day", usage)
+	}
+}
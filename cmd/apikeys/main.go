@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	pgapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/apikeyrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/apikeys"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
+)
+
+// Admin CLI for service-account API keys.
+//
+// Keys are managed out-of-band (there is no HTTP operation for them) by operators with
+// database access. The token is printed exactly once on create; only its hash is stored.
+//
+//   apikeys -admin <name> create -name "trip digest bot" -scopes trips:read,rsvps:read
+//   apikeys -admin <name> revoke -id <key-id>
+//   apikeys list
+//   apikeys usage -id <key-id>
+
+func main() {
+	admin := flag.String("admin", os.Getenv("USER"), "admin identity recorded on create/revoke")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: apikeys [-admin name] <create|revoke|list|usage> [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.NewPool(ctx, os.Getenv("DATABASE_URL"), postgres.PoolOptions{})
+	if err != nil {
+		log.Fatalf("invalid postgres config: %v", err)
+	}
+	defer pool.Close()
+
+	svc := apikeys.NewService(pgapikeyrepo.NewRepo(pool), platformclock.NewSystemClock())
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		name := fs.String("name", "", "human-readable key name")
+		scopes := fs.String("scopes", "", "comma-separated scopes: "+knownScopes())
+		_ = fs.Parse(args)
+
+		var in []domain.APIKeyScope
+		for _, s := range strings.Split(*scopes, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				in = append(in, domain.APIKeyScope(s))
+			}
+		}
+		created, err := svc.Create(ctx, apikeys.CreateInput{Name: *name, Scopes: in, CreatedBy: *admin})
+		if err != nil {
+			log.Fatalf("create: %v", err)
+		}
+		fmt.Printf("id:     %s\n", created.Key.ID)
+		fmt.Printf("scopes: %s\n", joinScopes(created.Key.Scopes))
+		fmt.Printf("token:  %s\n", created.Token)
+		fmt.Println("Store the token now; it cannot be shown again. Send it as: Authorization: ApiKey <token>")
+	case "revoke":
+		fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+		id := fs.String("id", "", "key id")
+		_ = fs.Parse(args)
+
+		k, err := svc.Revoke(ctx, domain.APIKeyID(*id), *admin)
+		if err != nil {
+			log.Fatalf("revoke: %v", err)
+		}
+		fmt.Printf("revoked %s (%s) at %s by %s\n", k.ID, k.Name, k.RevokedAt.Format(time.RFC3339), *k.RevokedBy)
+	case "list":
+		ks, err := svc.List(ctx)
+		if err != nil {
+			log.Fatalf("list: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range ks {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				k.ID, k.Name, k.Prefix, joinScopes(k.Scopes), k.CreatedAt.Format(time.RFC3339), formatTime(k.LastUsedAt), formatTime(k.RevokedAt))
+		}
+		_ = tw.Flush()
+	case "usage":
+		fs := flag.NewFlagSet("usage", flag.ExitOnError)
+		id := fs.String("id", "", "key id")
+		_ = fs.Parse(args)
+
+		us, err := svc.ListUsage(ctx, domain.APIKeyID(*id))
+		if err != nil {
+			log.Fatalf("usage: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "DAY\tREQUESTS\tFIRST USED\tLAST USED\tMETHOD\tPATH\tCLIENT IP")
+		for _, u := range us {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				u.Day.Format(time.DateOnly), u.RequestCount, u.FirstUsedAt.Format(time.RFC3339), u.LastUsedAt.Format(time.RFC3339), u.Method, u.Path, u.ClientIP)
+		}
+		_ = tw.Flush()
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func knownScopes() string {
+	return joinScopes(domain.KnownAPIKeyScopes)
+}
+
+func joinScopes(scopes []domain.APIKeyScope) string {
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		out = append(out, string(s))
+	}
+	return strings.Join(out, ",")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	pginvitationrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/invitationrepo"
+	pgmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/invitations"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
+)
+
+// Admin CLI for member invitations (MEMBERSHIP_MODE=invite).
+//
+// Invitations are managed out-of-band (there is no HTTP operation for them). The code is
+// printed exactly once on create; only its hash is stored. Invitees send it as the
+// X-Invite-Code header on CreateMyMember.
+//
+//   invites -admin <name> create [-email a@b.c] [-uses 1] [-ttl 336h]
+//   invites create -member <member-id> [-email a@b.c]   (issued on behalf of an active member)
+//   invites revoke -id <invitation-id>
+//   invites list
+//   invites redemptions -id <invitation-id>
+
+func main() {
+	admin := flag.String("admin", os.Getenv("USER"), "admin identity recorded on create")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: invites [-admin name] <create|revoke|list|redemptions> [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.NewPool(ctx, os.Getenv("DATABASE_URL"), postgres.PoolOptions{})
+	if err != nil {
+		log.Fatalf("invalid postgres config: %v", err)
+	}
+	defer pool.Close()
+
+	svc := invitations.NewService(pginvitationrepo.NewRepo(pool), pgmemberrepo.NewRepo(pool, ""), platformclock.NewSystemClock())
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		email := fs.String("email", "", "restrict the invitation to this email address")
+		uses := fs.Int("uses", 1, "maximum number of members who may join with the code")
+		ttl := fs.Duration("ttl", svc.DefaultTTL, "how long the code stays valid")
+		member := fs.String("member", "", "issue on behalf of this member id instead of -admin")
+		_ = fs.Parse(args)
+
+		in := invitations.CreateInput{MaxUses: *uses, TTL: *ttl}
+		if *email != "" {
+			in.Email = email
+		}
+		if *member != "" {
+			id := domain.MemberID(*member)
+			in.CreatedByMemberID = &id
+		} else {
+			in.CreatedByAdmin = *admin
+		}
+		created, err := svc.Create(ctx, in)
+		if err != nil {
+			log.Fatalf("create: %v", err)
+		}
+		fmt.Printf("id:      %s\n", created.Invitation.ID)
+		fmt.Printf("uses:    %d\n", created.Invitation.MaxUses)
+		fmt.Printf("expires: %s\n", created.Invitation.ExpiresAt.Format(time.RFC3339))
+		fmt.Printf("code:    %s\n", created.Code)
+		fmt.Println("Share the code now; it cannot be shown again.")
+	case "revoke":
+		fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+		id := fs.String("id", "", "invitation id")
+		_ = fs.Parse(args)
+
+		inv, err := svc.Revoke(ctx, domain.InvitationID(*id))
+		if err != nil {
+			log.Fatalf("revoke: %v", err)
+		}
+		fmt.Printf("revoked %s at %s\n", inv.ID, inv.RevokedAt.Format(time.RFC3339))
+	case "list":
+		invs, err := svc.List(ctx)
+		if err != nil {
+			log.Fatalf("list: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tEMAIL\tUSES\tCREATED BY\tCREATED\tEXPIRES\tREVOKED")
+		for _, inv := range invs {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%s\t%s\t%s\t%s\n",
+				inv.ID, deref(inv.Email), inv.UseCount, inv.MaxUses, createdBy(inv),
+				inv.CreatedAt.Format(time.RFC3339), inv.ExpiresAt.Format(time.RFC3339), formatTime(inv.RevokedAt))
+		}
+		_ = tw.Flush()
+	case "redemptions":
+		fs := flag.NewFlagSet("redemptions", flag.ExitOnError)
+		id := fs.String("id", "", "invitation id")
+		_ = fs.Parse(args)
+
+		rs, err := svc.ListRedemptions(ctx, domain.InvitationID(*id))
+		if err != nil {
+			log.Fatalf("redemptions: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "MEMBER\tREDEEMED AT")
+		for _, r := range rs {
+			fmt.Fprintf(tw, "%s\t%s\n", r.MemberID, r.RedeemedAt.Format(time.RFC3339))
+		}
+		_ = tw.Flush()
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func createdBy(inv domain.Invitation) string {
+	if inv.CreatedByMemberID != nil {
+		return "member:" + string(*inv.CreatedByMemberID)
+	}
+	return "admin:" + strings.TrimSpace(inv.CreatedByAdmin)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	pgmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
+	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/config"
+)
+
+// Admin CLI for member login identities.
+//
+//   members identities -member <id>
+//   members link -member <id> -issuer <iss> -subject <sub>
+//   members unlink -member <id> -issuer <iss> -subject <sub>
+//   members link-tokens -current <jwt> -new <jwt>
+//
+// link/unlink are admin actions. link-tokens is the proof-based path: both tokens are
+// verified against the configured issuers (JWT_* env vars), and the login in -new is linked
+// to the member that -current resolves to.
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: members <identities|link|unlink|link-tokens> [flags]\n")
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.NewPool(ctx, os.Getenv("DATABASE_URL"), postgres.PoolOptions{})
+	if err != nil {
+		log.Fatalf("invalid postgres config: %v", err)
+	}
+	defer pool.Close()
+
+	// Every identity handled here carries an explicit issuer, so no default issuer is needed.
+	svc := members.NewService(pgmemberrepo.NewRepo(pool, ""), platformclock.NewSystemClock())
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "identities":
+		fs := flag.NewFlagSet("identities", flag.ExitOnError)
+		member := fs.String("member", "", "member id")
+		_ = fs.Parse(args)
+
+		ids, err := svc.ListIdentities(ctx, domain.MemberID(*member))
+		if err != nil {
+			log.Fatalf("identities: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ISSUER\tSUBJECT\tLINKED AT")
+		for _, id := range ids {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", id.Issuer, id.Subject, id.LinkedAt.Format(time.RFC3339))
+		}
+		_ = tw.Flush()
+	case "link", "unlink":
+		fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+		member := fs.String("member", "", "member id")
+		issuer := fs.String("issuer", "", "token issuer (iss)")
+		subject := fs.String("subject", "", "token subject (sub)")
+		_ = fs.Parse(args)
+
+		if cmd == "link" {
+			_, err = svc.LinkIdentity(ctx, domain.MemberID(*member), domain.MemberIdentity{Issuer: *issuer, Subject: domain.SubjectID(*subject)})
+		} else {
+			err = svc.UnlinkIdentity(ctx, domain.MemberID(*member), *issuer, domain.SubjectID(*subject))
+		}
+		if err != nil {
+			log.Fatalf("%s: %v", cmd, err)
+		}
+		fmt.Printf("%sed %s|%s for member %s\n", cmd, *issuer, *subject, *member)
+	case "link-tokens":
+		fs := flag.NewFlagSet("link-tokens", flag.ExitOnError)
+		current := fs.String("current", "", "token for the login the member already uses")
+		next := fs.String("new", "", "token for the login to link")
+		_ = fs.Parse(args)
+
+		jwtCfg, err := config.LoadJWTConfigFromEnv()
+		if err != nil {
+			log.Fatalf("invalid auth config: %v", err)
+		}
+		v := jwtverifier.New(jwtCfg)
+		cur, err := v.VerifyIdentity(ctx, *current)
+		if err != nil {
+			log.Fatalf("current token: %v", err)
+		}
+		proof, err := v.VerifyIdentity(ctx, *next)
+		if err != nil {
+			log.Fatalf("new token: %v", err)
+		}
+
+		linked, err := svc.LinkMyIdentity(authctx.WithIssuer(ctx, cur.Issuer), domain.SubjectID(cur.Subject), domain.MemberIdentity{
+			Issuer:  proof.Issuer,
+			Subject: domain.SubjectID(proof.Subject),
+		})
+		if err != nil {
+			log.Fatalf("link: %v", err)
+		}
+		fmt.Printf("linked %s|%s to the member of %s|%s\n", linked.Issuer, linked.Subject, cur.Issuer, cur.Subject)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
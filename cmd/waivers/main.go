@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	pgwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/waiverrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/waivers"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
+)
+
+// Admin CLI for liability waivers.
+//
+// Waivers are managed out-of-band (there is no HTTP operation for them). Each publish creates
+// a new version; members must acknowledge the current version before their next YES RSVP on a
+// trip that requires it. -all-trips makes a waiver club-wide (e.g. the season waiver);
+// organizers add others to individual trips.
+//
+//   waivers -admin <name> create -title "2026 Season" -file season.md [-all-trips]
+//   waivers publish -id <waiver-id> -file season-v2.md
+//   waivers list
+//   waivers acknowledgements -id <waiver-id>
+
+func main() {
+	admin := flag.String("admin", os.Getenv("USER"), "admin identity recorded on create")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: waivers [-admin name] <create|publish|list|acknowledgements> [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	pool, err := postgres.NewPool(ctx, os.Getenv("DATABASE_URL"), postgres.PoolOptions{})
+	if err != nil {
+		log.Fatalf("invalid postgres config: %v", err)
+	}
+	defer pool.Close()
+
+	svc := waivers.NewService(pgwaiverrepo.NewRepo(pool), platformclock.NewSystemClock())
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		title := fs.String("title", "", "waiver title")
+		file := fs.String("file", "", "file holding the waiver text")
+		allTrips := fs.Bool("all-trips", false, "require the waiver for every trip")
+		_ = fs.Parse(args)
+
+		w, err := svc.Create(ctx, waivers.CreateInput{Title: *title, Body: readBody(*file), RequiredForAllTrips: *allTrips, CreatedBy: *admin})
+		if err != nil {
+			log.Fatalf("create: %v", err)
+		}
+		printWaiver(w)
+	case "publish":
+		fs := flag.NewFlagSet("publish", flag.ExitOnError)
+		id := fs.String("id", "", "waiver id")
+		file := fs.String("file", "", "file holding the new waiver text")
+		_ = fs.Parse(args)
+
+		w, err := svc.PublishVersion(ctx, domain.WaiverID(*id), readBody(*file))
+		if err != nil {
+			log.Fatalf("publish: %v", err)
+		}
+		printWaiver(w)
+	case "list":
+		ws, err := svc.List(ctx)
+		if err != nil {
+			log.Fatalf("list: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tTITLE\tALL TRIPS\tVERSION\tHASH\tPUBLISHED")
+		for _, w := range ws {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%d\t%s\t%s\n",
+				w.ID, w.Title, w.RequiredForAllTrips, w.Version, w.VersionHash[:12], w.PublishedAt.Format(time.RFC3339))
+		}
+		_ = tw.Flush()
+	case "acknowledgements":
+		fs := flag.NewFlagSet("acknowledgements", flag.ExitOnError)
+		id := fs.String("id", "", "waiver id")
+		_ = fs.Parse(args)
+
+		acks, err := svc.ListAcknowledgements(ctx, domain.WaiverID(*id))
+		if err != nil {
+			log.Fatalf("acknowledgements: %v", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "MEMBER\tSUBJECT\tVERSION\tHASH\tACKNOWLEDGED AT")
+		for _, a := range acks {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", a.MemberID, a.Subject, a.Version, a.VersionHash[:12], a.AcknowledgedAt.Format(time.RFC3339))
+		}
+		_ = tw.Flush()
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func readBody(path string) string {
+	if path == "" {
+		log.Fatalf("-file is required")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("read waiver text: %v", err)
+	}
+	return string(b)
+}
+
+func printWaiver(w domain.Waiver) {
+	fmt.Printf("id:        %s\n", w.ID)
+	fmt.Printf("title:     %s\n", w.Title)
+	fmt.Printf("all trips: %t\n", w.RequiredForAllTrips)
+	fmt.Printf("version:   %d\n", w.Version)
+	fmt.Printf("hash:      %s\n", w.VersionHash)
+	fmt.Printf("published: %s\n", w.PublishedAt.Format(time.RFC3339))
+}
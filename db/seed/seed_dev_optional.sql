@@ -18,12 +18,20 @@ WHERE NOT EXISTS (
   SELECT 1 FROM members m WHERE m.external_id = v.external_id
 );
 
--- Vehicle profiles
-INSERT INTO member_vehicle_profiles (
-  member_id, make, model, tire_size, lift_lockers, fuel_range, recovery_gear, ham_radio_call_sign, notes
+-- Login identities (members resolve subjects through member_identities)
+INSERT INTO member_identities (member_id, subject_iss, subject_sub, linked_at)
+SELECT m.id, m.subject_iss, m.subject_sub, m.created_at
+FROM members m
+ON CONFLICT (subject_iss, subject_sub) DO NOTHING;
+
+-- Vehicles (each member's default rig)
+INSERT INTO member_vehicles (
+  member_id, name, is_default, make, model, tire_size, lift_lockers, fuel_range, recovery_gear, ham_radio_call_sign, notes
 )
 SELECT
   m.id AS member_id,
+  v.make || ' ' || v.model,
+  true,
   v.make,
   v.model,
   v.tire_size,
@@ -42,7 +50,7 @@ FROM (VALUES
 )
 JOIN members m ON m.external_id = v.member_external_id
 WHERE NOT EXISTS (
-  SELECT 1 FROM member_vehicle_profiles p WHERE p.member_id = m.id
+  SELECT 1 FROM member_vehicles p WHERE p.member_id = m.id
 );
 
 -- =========================================================================
@@ -2,16 +2,34 @@ package contracttest
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
+	announcementrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+	apikeyport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+	attendancerepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	commentrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	emergencyinforepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
 	idempotencyport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+	invitationport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+	itineraryrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
 	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	ratelimitport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+	ridesharerepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
 	rsvprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
 	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	tripseriesrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+	triptemplaterepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+	waiverrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
 )
 
 type CleanupFunc = func()
@@ -19,13 +37,28 @@ type CleanupFunc = func()
 type MemberRepoFactory func(t *testing.T) (memberrepoport.Repository, CleanupFunc)
 type TripRepoFactory func(t *testing.T) (triprepoport.Repository, CleanupFunc)
 type RSVPRepoFactory func(t *testing.T) (rsvprepoport.Repository, CleanupFunc)
-type IdemStoreFactory func(t *testing.T) (idempotencyport.Store, CleanupFunc)
+
+// IdemStoreFactory builds a store that evaluates expiry against clk.
+type IdemStoreFactory func(t *testing.T, clk clockport.Clock) (idempotencyport.Store, CleanupFunc)
+type RateLimitStoreFactory func(t *testing.T) (ratelimitport.Store, CleanupFunc)
+type APIKeyRepoFactory func(t *testing.T) (apikeyport.Repository, CleanupFunc)
+type InvitationRepoFactory func(t *testing.T) (invitationport.Repository, CleanupFunc)
+type RideShareRepoFactory func(t *testing.T) (ridesharerepoport.Repository, CleanupFunc)
+type TripTemplateRepoFactory func(t *testing.T) (triptemplaterepoport.Repository, CleanupFunc)
+type TripSeriesRepoFactory func(t *testing.T) (tripseriesrepoport.Repository, CleanupFunc)
+type ItineraryRepoFactory func(t *testing.T) (itineraryrepoport.Repository, CleanupFunc)
+type AttendanceRepoFactory func(t *testing.T) (attendancerepoport.Repository, CleanupFunc)
+type EmergencyInfoRepoFactory func(t *testing.T) (emergencyinforepoport.Repository, CleanupFunc)
+type WaiverRepoFactory func(t *testing.T) (waiverrepoport.Repository, CleanupFunc)
+type CommentRepoFactory func(t *testing.T) (commentrepoport.Repository, CleanupFunc)
+type AnnouncementRepoFactory func(t *testing.T) (announcementrepoport.Repository, CleanupFunc)
 
 func RunIdempotencyStore(t *testing.T, newStore IdemStoreFactory) {
 	t.Helper()
 	ctx := context.Background()
 
-	store, cleanup := newStore(t)
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	store, cleanup := newStore(t, clk)
 	if cleanup != nil {
 		t.Cleanup(cleanup)
 	}
@@ -67,6 +100,562 @@ func RunIdempotencyStore(t *testing.T, newStore IdemStoreFactory) {
 	if err != nil || !ok || string(got.Body) != "hash-def" {
 		t.Fatalf("expected overwritten record, got ok=%v err=%v body=%q", ok, err, string(got.Body))
 	}
+
+	// Reserve only succeeds for an absent fingerprint and returns the existing record otherwise.
+	existing, reserved, err := store.Reserve(ctx, fp, idempotencyport.Record{StatusCode: 0, ContentType: "text/plain", Body: []byte("other")})
+	if err != nil {
+		t.Fatalf("Reserve existing: %v", err)
+	}
+	if reserved || string(existing.Body) != "hash-def" {
+		t.Fatalf("Reserve existing: reserved=%v body=%q, want false/hash-def", reserved, string(existing.Body))
+	}
+
+	respFP := fp
+	respFP.BodyHash = "hash-def"
+	resp := idempotencyport.Record{
+		StatusCode:  201,
+		ContentType: "application/json",
+		Header:      map[string][]string{"Location": {"/trips/t1"}},
+		Body:        []byte(`{"ok":true}`),
+		CreatedAt:   time.Unix(124, 0).UTC(),
+	}
+	if _, reserved, err := store.Reserve(ctx, respFP, resp); err != nil || !reserved {
+		t.Fatalf("Reserve new: reserved=%v err=%v", reserved, err)
+	}
+	got, ok, err = store.Get(ctx, respFP)
+	if err != nil || !ok {
+		t.Fatalf("Get reserved: ok=%v err=%v", ok, err)
+	}
+	if got.StatusCode != 201 || string(got.Body) != `{"ok":true}` || len(got.Header["Location"]) != 1 || got.Header["Location"][0] != "/trips/t1" {
+		t.Fatalf("unexpected reserved record: %+v", got)
+	}
+
+	// CompareAndSwap only replaces the record version the caller read; the loser of a race sees false.
+	takeover := got
+	takeover.Body = []byte(`{"ok":"again"}`)
+	takeover.CreatedAt = time.Unix(125, 0).UTC()
+	if swapped, err := store.CompareAndSwap(ctx, respFP, got.CreatedAt, takeover); err != nil || !swapped {
+		t.Fatalf("CompareAndSwap current: swapped=%v err=%v", swapped, err)
+	}
+	if swapped, err := store.CompareAndSwap(ctx, respFP, got.CreatedAt, takeover); err != nil || swapped {
+		t.Fatalf("CompareAndSwap stale: swapped=%v err=%v, want false", swapped, err)
+	}
+	if got, ok, err := store.Get(ctx, respFP); err != nil || !ok || string(got.Body) != `{"ok":"again"}` {
+		t.Fatalf("Get after CompareAndSwap: ok=%v err=%v body=%q", ok, err, string(got.Body))
+	}
+	absent := fp
+	absent.Key = "k-absent"
+	if swapped, err := store.CompareAndSwap(ctx, absent, got.CreatedAt, takeover); err != nil || swapped {
+		t.Fatalf("CompareAndSwap absent: swapped=%v err=%v, want false", swapped, err)
+	}
+
+	// Records are scoped per issuer: the same fingerprint from another IdP is a different key.
+	otherIss := authctx.WithIssuer(ctx, "https://other-issuer.test")
+	if _, ok, err := store.Get(otherIss, fp); err != nil || ok {
+		t.Fatalf("Get other issuer: ok=%v err=%v, want absent", ok, err)
+	}
+	if _, reserved, err := store.Reserve(otherIss, fp, rec); err != nil || !reserved {
+		t.Fatalf("Reserve other issuer: reserved=%v err=%v", reserved, err)
+	}
+	if err := store.Delete(otherIss, fp); err != nil {
+		t.Fatalf("Delete other issuer: %v", err)
+	}
+	if got, ok, err := store.Get(ctx, fp); err != nil || !ok || string(got.Body) != "hash-def" {
+		t.Fatalf("Get after other-issuer delete: ok=%v err=%v body=%q", ok, err, string(got.Body))
+	}
+
+	// Delete frees the fingerprint; deleting again is not an error.
+	if err := store.Delete(ctx, fp); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete(ctx, fp); err != nil {
+		t.Fatalf("Delete absent: %v", err)
+	}
+	if _, ok, err := store.Get(ctx, fp); err != nil || ok {
+		t.Fatalf("Get after delete: ok=%v err=%v", ok, err)
+	}
+	if _, reserved, err := store.Reserve(ctx, fp, rec); err != nil || !reserved {
+		t.Fatalf("Reserve after delete: reserved=%v err=%v", reserved, err)
+	}
+
+	runIdempotencyStoreExpiry(t, store, clk)
+}
+
+func runIdempotencyStoreExpiry(t *testing.T, store idempotencyport.Store, clk *memclock.ManualClock) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := clk.Now()
+	expiring := idempotencyport.Fingerprint{Key: "k-exp", Subject: "sub-exp", Method: "PUT", Route: "/trips/{tripId}/rsvp", BodyHash: "h1"}
+	forever := expiring
+	forever.Key = "k-forever"
+	rec := idempotencyport.Record{
+		StatusCode:  200,
+		ContentType: "application/json",
+		Body:        []byte(`{}`),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	if err := store.Put(ctx, expiring, rec); err != nil {
+		t.Fatalf("Put expiring: %v", err)
+	}
+	noExpiry := rec
+	noExpiry.ExpiresAt = time.Time{}
+	if err := store.Put(ctx, forever, noExpiry); err != nil {
+		t.Fatalf("Put forever: %v", err)
+	}
+
+	if _, ok, err := store.Get(ctx, expiring); err != nil || !ok {
+		t.Fatalf("Get before expiry: ok=%v err=%v", ok, err)
+	}
+	if _, reserved, err := store.Reserve(ctx, expiring, rec); err != nil || reserved {
+		t.Fatalf("Reserve before expiry: reserved=%v err=%v", reserved, err)
+	}
+
+	clk.Add(time.Hour)
+
+	// Expired records read as absent, and can be reserved again.
+	if _, ok, err := store.Get(ctx, expiring); err != nil || ok {
+		t.Fatalf("Get after expiry: ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := store.Get(ctx, forever); err != nil || !ok {
+		t.Fatalf("Get record without expiry: ok=%v err=%v", ok, err)
+	}
+	renewed := rec
+	renewed.Body = []byte(`{"renewed":true}`)
+	renewed.ExpiresAt = clk.Now().Add(time.Hour)
+	if _, reserved, err := store.Reserve(ctx, expiring, renewed); err != nil || !reserved {
+		t.Fatalf("Reserve after expiry: reserved=%v err=%v", reserved, err)
+	}
+	got, ok, err := store.Get(ctx, expiring)
+	if err != nil || !ok || string(got.Body) != `{"renewed":true}` {
+		t.Fatalf("Get renewed: ok=%v err=%v body=%q", ok, err, string(got.Body))
+	}
+
+	// Sweeping deletes expired records in batches and leaves live ones alone.
+	for i := 0; i < 3; i++ {
+		fp := expiring
+		fp.Key = idempotencyport.Key("k-sweep-" + string(rune('a'+i)))
+		if err := store.Put(ctx, fp, rec); err != nil {
+			t.Fatalf("Put sweep %d: %v", i, err)
+		}
+	}
+	n, err := store.DeleteExpired(ctx, clk.Now(), 2)
+	if err != nil || n != 2 {
+		t.Fatalf("DeleteExpired batch 1: n=%d err=%v, want 2", n, err)
+	}
+	n, err = store.DeleteExpired(ctx, clk.Now(), 2)
+	if err != nil || n != 1 {
+		t.Fatalf("DeleteExpired batch 2: n=%d err=%v, want 1", n, err)
+	}
+	if _, ok, err := store.Get(ctx, expiring); err != nil || !ok {
+		t.Fatalf("renewed record swept: ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := store.Get(ctx, forever); err != nil || !ok {
+		t.Fatalf("record without expiry swept: ok=%v err=%v", ok, err)
+	}
+}
+
+func RunRateLimitStore(t *testing.T, newStore RateLimitStoreFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	store, cleanup := newStore(t)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	key := "SetMyRSVP|sub:" + uuid.NewString()
+	other := "SetMyRSVP|sub:" + uuid.NewString()
+	limit := ratelimitport.Limit{Burst: 2, Per: 2 * time.Second}
+	now := time.Unix(1_000, 0).UTC()
+
+	// A fresh bucket starts full.
+	for i, wantRemaining := range []int{1, 0} {
+		d, err := store.Take(ctx, key, limit, now)
+		if err != nil {
+			t.Fatalf("Take #%d: %v", i, err)
+		}
+		if !d.Allowed || d.Remaining != wantRemaining {
+			t.Fatalf("Take #%d = %+v, want allowed remaining=%d", i, d, wantRemaining)
+		}
+	}
+
+	// Peek reports the next decision without spending a token.
+	for i := 0; i < 2; i++ {
+		d, err := store.Peek(ctx, key, limit, now)
+		if err != nil || d.Allowed || d.RetryAfter != time.Second {
+			t.Fatalf("Peek #%d = %+v err=%v, want denied with RetryAfter=1s", i, d, err)
+		}
+	}
+	if d, err := store.Peek(ctx, "SetMyRSVP|sub:"+uuid.NewString(), limit, now); err != nil || !d.Allowed || d.Remaining != 1 {
+		t.Fatalf("Peek unknown key = %+v err=%v, want allowed remaining=1", d, err)
+	}
+
+	d, err := store.Take(ctx, key, limit, now)
+	if err != nil {
+		t.Fatalf("Take over limit: %v", err)
+	}
+	if d.Allowed || d.RetryAfter != time.Second {
+		t.Fatalf("over limit = %+v, want denied with RetryAfter=1s", d)
+	}
+
+	// Buckets are independent per key.
+	d, err = store.Take(ctx, other, limit, now)
+	if err != nil || !d.Allowed {
+		t.Fatalf("other key = %+v err=%v, want allowed", d, err)
+	}
+
+	// Tokens refill over time.
+	d, err = store.Take(ctx, key, limit, now.Add(time.Second))
+	if err != nil || !d.Allowed {
+		t.Fatalf("after refill = %+v err=%v, want allowed", d, err)
+	}
+
+	// Idle buckets are swept; the store may hold other tests' buckets, so count only ours.
+	n, err := store.DeleteIdle(ctx, now, 1_000_000)
+	if err != nil || n < 1 {
+		t.Fatalf("DeleteIdle = %d err=%v, want the idle bucket removed", n, err)
+	}
+	// key was used after the cutoff and keeps its state (empty after the refill).
+	d, err = store.Take(ctx, key, limit, now.Add(time.Second))
+	if err != nil || d.Allowed {
+		t.Fatalf("kept bucket = %+v err=%v, want denied", d, err)
+	}
+	// other was swept and starts full again.
+	for i := 0; i < limit.Burst; i++ {
+		if d, err := store.Take(ctx, other, limit, now.Add(time.Second)); err != nil || !d.Allowed {
+			t.Fatalf("swept bucket Take #%d = %+v err=%v, want allowed", i, d, err)
+		}
+	}
+}
+
+func RunAPIKeyRepo(t *testing.T, newRepo APIKeyRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	repo, cleanup := newRepo(t)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	now := time.Unix(2_000, 0).UTC()
+	aID := domain.APIKeyID(uuid.NewString())
+	bID := domain.APIKeyID(uuid.NewString())
+	aPrefix := uuid.NewString()[:8]
+	bPrefix := uuid.NewString()[:8]
+
+	a := apikeyport.APIKey{
+		APIKey: domain.APIKey{
+			ID:        aID,
+			Name:      "trip digest bot",
+			Prefix:    aPrefix,
+			Scopes:    []domain.APIKeyScope{domain.APIKeyScopeTripsRead, domain.APIKeyScopeRSVPSummariesRead},
+			CreatedBy: "admin-1",
+			CreatedAt: now,
+		},
+		SecretHash: "hash-a",
+	}
+	if err := repo.Create(ctx, a); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := repo.Create(ctx, apikeyport.APIKey{
+		APIKey: domain.APIKey{
+			ID:        domain.APIKeyID(uuid.NewString()),
+			Name:      "dup prefix",
+			Prefix:    aPrefix,
+			CreatedBy: "admin-1",
+			CreatedAt: now,
+		},
+		SecretHash: "hash-dup",
+	}); err != apikeyport.ErrAlreadyExists {
+		t.Fatalf("Create duplicate prefix err = %v, want ErrAlreadyExists", err)
+	}
+	if err := repo.Create(ctx, apikeyport.APIKey{
+		APIKey: domain.APIKey{
+			ID:        bID,
+			Name:      "announcer",
+			Prefix:    bPrefix,
+			Scopes:    []domain.APIKeyScope{domain.APIKeyScopeAnnouncementsWrite},
+			CreatedBy: "admin-2",
+			CreatedAt: now.Add(time.Second),
+		},
+		SecretHash: "hash-b",
+	}); err != nil {
+		t.Fatalf("Create b: %v", err)
+	}
+
+	got, err := repo.GetByPrefix(ctx, aPrefix)
+	if err != nil {
+		t.Fatalf("GetByPrefix: %v", err)
+	}
+	if got.ID != aID || got.SecretHash != "hash-a" || len(got.Scopes) != 2 || !got.HasScope(domain.APIKeyScopeRSVPSummariesRead) {
+		t.Fatalf("GetByPrefix = %+v", got)
+	}
+	if got.RevokedAt != nil || got.LastUsedAt != nil {
+		t.Fatalf("new key should be unrevoked and unused: %+v", got)
+	}
+	if _, err := repo.GetByPrefix(ctx, "missing"); err != apikeyport.ErrNotFound {
+		t.Fatalf("GetByPrefix missing err = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.GetByID(ctx, domain.APIKeyID(uuid.NewString())); err != apikeyport.ErrNotFound {
+		t.Fatalf("GetByID missing err = %v, want ErrNotFound", err)
+	}
+
+	// List includes both keys, in creation order.
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	ai, bi := -1, -1
+	for i, k := range all {
+		switch k.ID {
+		case aID:
+			ai = i
+		case bID:
+			bi = i
+		}
+	}
+	if ai < 0 || bi < 0 || ai > bi {
+		t.Fatalf("List positions a=%d b=%d, want both present with a first", ai, bi)
+	}
+
+	// Usage advances LastUsedAt and is counted per day, method, path and client, most recently
+	// used first.
+	for i, path := range []string{"/trips", "/trips/1/rsvps/summary", "/trips"} {
+		if err := repo.RecordUsage(ctx, apikeyport.Usage{
+			KeyID:    aID,
+			UsedAt:   now.Add(time.Duration(i+1) * time.Minute),
+			Method:   "GET",
+			Path:     path,
+			ClientIP: "192.0.2.10",
+		}); err != nil {
+			t.Fatalf("RecordUsage #%d: %v", i, err)
+		}
+	}
+	if err := repo.RecordUsage(ctx, apikeyport.Usage{KeyID: domain.APIKeyID(uuid.NewString()), UsedAt: now}); err != apikeyport.ErrNotFound {
+		t.Fatalf("RecordUsage unknown key err = %v, want ErrNotFound", err)
+	}
+	got, err = repo.GetByID(ctx, aID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.LastUsedAt == nil || !got.LastUsedAt.Equal(now.Add(3*time.Minute)) {
+		t.Fatalf("LastUsedAt = %v, want %v", got.LastUsedAt, now.Add(3*time.Minute))
+	}
+	usage, err := repo.ListUsage(ctx, aID, 10)
+	if err != nil {
+		t.Fatalf("ListUsage: %v", err)
+	}
+	if len(usage) != 2 || usage[0].Path != "/trips" || usage[0].ClientIP != "192.0.2.10" || usage[0].RequestCount != 2 ||
+		!usage[0].Day.Equal(apikeyport.UsageDay(now)) ||
+		!usage[0].FirstUsedAt.Equal(now.Add(time.Minute)) || !usage[0].LastUsedAt.Equal(now.Add(3*time.Minute)) ||
+		usage[1].Path != "/trips/1/rsvps/summary" || usage[1].RequestCount != 1 {
+		t.Fatalf("ListUsage = %+v", usage)
+	}
+	if usage, err = repo.ListUsage(ctx, aID, 1); err != nil || len(usage) != 1 || usage[0].Path != "/trips" {
+		t.Fatalf("ListUsage limit 1 = %+v, %v", usage, err)
+	}
+
+	// Retention deletes whole days before the cutoff's day, in batches.
+	nextDay := now.Add(24 * time.Hour)
+	if err := repo.RecordUsage(ctx, apikeyport.Usage{KeyID: aID, UsedAt: nextDay, Method: "GET", Path: "/trips", ClientIP: "192.0.2.10"}); err != nil {
+		t.Fatalf("RecordUsage next day: %v", err)
+	}
+	if n, err := repo.DeleteUsageBefore(ctx, nextDay, 1); err != nil || n != 1 {
+		t.Fatalf("DeleteUsageBefore limit 1 = %d, %v; want 1", n, err)
+	}
+	if n, err := repo.DeleteUsageBefore(ctx, nextDay, 10); err != nil || n != 1 {
+		t.Fatalf("DeleteUsageBefore = %d, %v; want 1", n, err)
+	}
+	usage, err = repo.ListUsage(ctx, aID, 10)
+	if err != nil {
+		t.Fatalf("ListUsage after purge: %v", err)
+	}
+	if len(usage) != 1 || !usage[0].Day.Equal(apikeyport.UsageDay(nextDay)) || usage[0].RequestCount != 1 {
+		t.Fatalf("ListUsage after purge = %+v", usage)
+	}
+
+	// Revoke is idempotent and keeps the first revocation.
+	if err := repo.Revoke(ctx, aID, "admin-2", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := repo.Revoke(ctx, aID, "admin-3", now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Revoke again: %v", err)
+	}
+	got, err = repo.GetByID(ctx, aID)
+	if err != nil {
+		t.Fatalf("GetByID after revoke: %v", err)
+	}
+	if got.RevokedAt == nil || !got.RevokedAt.Equal(now.Add(time.Hour)) || got.RevokedBy == nil || *got.RevokedBy != "admin-2" {
+		t.Fatalf("revocation = by %v at %v, want admin-2 at %v", got.RevokedBy, got.RevokedAt, now.Add(time.Hour))
+	}
+	if err := repo.Revoke(ctx, domain.APIKeyID(uuid.NewString()), "admin-2", now); err != apikeyport.ErrNotFound {
+		t.Fatalf("Revoke unknown err = %v, want ErrNotFound", err)
+	}
+}
+
+func RunInvitationRepo(t *testing.T, newRepo InvitationRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	repo, cleanup := newRepo(t)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	now := time.Unix(3_000, 0).UTC()
+	inviter := domain.MemberID(uuid.NewString())
+	email := "invitee@example.com"
+	aID := domain.InvitationID(uuid.NewString())
+	bID := domain.InvitationID(uuid.NewString())
+	aHash := domain.HashInviteCode(uuid.NewString())
+	bHash := domain.HashInviteCode(uuid.NewString())
+
+	a := invitationport.Invitation{
+		Invitation: domain.Invitation{
+			ID:                aID,
+			Email:             &email,
+			MaxUses:           1,
+			CreatedByMemberID: &inviter,
+			CreatedAt:         now,
+			ExpiresAt:         now.Add(24 * time.Hour),
+		},
+		CodeHash: aHash,
+	}
+	if err := repo.Create(ctx, a); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := repo.Create(ctx, invitationport.Invitation{
+		Invitation: domain.Invitation{
+			ID:             domain.InvitationID(uuid.NewString()),
+			MaxUses:        1,
+			CreatedByAdmin: "admin-1",
+			CreatedAt:      now,
+			ExpiresAt:      now.Add(time.Hour),
+		},
+		CodeHash: aHash,
+	}); err != invitationport.ErrAlreadyExists {
+		t.Fatalf("Create duplicate code err = %v, want ErrAlreadyExists", err)
+	}
+	if err := repo.Create(ctx, invitationport.Invitation{
+		Invitation: domain.Invitation{
+			ID:             bID,
+			MaxUses:        2,
+			CreatedByAdmin: "admin-1",
+			CreatedAt:      now.Add(time.Second),
+			ExpiresAt:      now.Add(time.Hour),
+		},
+		CodeHash: bHash,
+	}); err != nil {
+		t.Fatalf("Create b: %v", err)
+	}
+
+	got, err := repo.GetByCodeHash(ctx, aHash)
+	if err != nil {
+		t.Fatalf("GetByCodeHash: %v", err)
+	}
+	if got.ID != aID || got.Email == nil || *got.Email != email || got.CreatedByMemberID == nil || *got.CreatedByMemberID != inviter {
+		t.Fatalf("GetByCodeHash = %+v", got)
+	}
+	if got.UseCount != 0 || got.RevokedAt != nil || !got.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("new invitation state = %+v", got)
+	}
+	if _, err := repo.GetByCodeHash(ctx, "missing"); err != invitationport.ErrNotFound {
+		t.Fatalf("GetByCodeHash missing err = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.GetByID(ctx, domain.InvitationID(uuid.NewString())); err != invitationport.ErrNotFound {
+		t.Fatalf("GetByID missing err = %v, want ErrNotFound", err)
+	}
+
+	// List includes both invitations, in creation order.
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	ai, bi := -1, -1
+	for i, inv := range all {
+		switch inv.ID {
+		case aID:
+			ai = i
+		case bID:
+			bi = i
+		}
+	}
+	if ai < 0 || bi < 0 || ai > bi {
+		t.Fatalf("List positions a=%d b=%d, want both present with a first", ai, bi)
+	}
+
+	// Redeem consumes uses and records who joined.
+	m1 := domain.MemberID(uuid.NewString())
+	m2 := domain.MemberID(uuid.NewString())
+	m3 := domain.MemberID(uuid.NewString())
+	if err := repo.Redeem(ctx, bID, m1, now.Add(time.Minute)); err != nil {
+		t.Fatalf("Redeem #1: %v", err)
+	}
+	if err := repo.Redeem(ctx, bID, m1, now.Add(time.Minute)); err != invitationport.ErrAlreadyExists {
+		t.Fatalf("Redeem same member err = %v, want ErrAlreadyExists", err)
+	}
+	if err := repo.Redeem(ctx, bID, m2, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Redeem #2: %v", err)
+	}
+	if err := repo.Redeem(ctx, bID, m3, now.Add(3*time.Minute)); err != invitationport.ErrExhausted {
+		t.Fatalf("Redeem exhausted err = %v, want ErrExhausted", err)
+	}
+	reds, err := repo.ListRedemptions(ctx, bID)
+	if err != nil {
+		t.Fatalf("ListRedemptions: %v", err)
+	}
+	if len(reds) != 2 || reds[0].MemberID != m1 || reds[1].MemberID != m2 || !reds[0].RedeemedAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("ListRedemptions = %+v", reds)
+	}
+
+	// Releasing a redemption frees the use; releasing it again is a no-op.
+	if err := repo.ReleaseRedemption(ctx, bID, m2); err != nil {
+		t.Fatalf("ReleaseRedemption: %v", err)
+	}
+	if err := repo.ReleaseRedemption(ctx, bID, m2); err != nil {
+		t.Fatalf("ReleaseRedemption again: %v", err)
+	}
+	got, err = repo.GetByID(ctx, bID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.UseCount != 1 {
+		t.Fatalf("UseCount after release = %d, want 1", got.UseCount)
+	}
+	if err := repo.Redeem(ctx, bID, m3, now.Add(3*time.Minute)); err != nil {
+		t.Fatalf("Redeem after release: %v", err)
+	}
+
+	// Expiry is evaluated against the redemption time.
+	if err := repo.Redeem(ctx, aID, m1, now.Add(24*time.Hour)); err != invitationport.ErrExpired {
+		t.Fatalf("Redeem expired err = %v, want ErrExpired", err)
+	}
+
+	// Revoke is idempotent, keeps the first revocation, and blocks redemption.
+	if err := repo.Revoke(ctx, aID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := repo.Revoke(ctx, aID, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Revoke again: %v", err)
+	}
+	got, err = repo.GetByID(ctx, aID)
+	if err != nil {
+		t.Fatalf("GetByID after revoke: %v", err)
+	}
+	if got.RevokedAt == nil || !got.RevokedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("RevokedAt = %v, want %v", got.RevokedAt, now.Add(time.Hour))
+	}
+	if err := repo.Redeem(ctx, aID, m1, now.Add(time.Minute)); err != invitationport.ErrRevoked {
+		t.Fatalf("Redeem revoked err = %v, want ErrRevoked", err)
+	}
+	if err := repo.Revoke(ctx, domain.InvitationID(uuid.NewString()), now); err != invitationport.ErrNotFound {
+		t.Fatalf("Revoke unknown err = %v, want ErrNotFound", err)
+	}
+	if err := repo.Redeem(ctx, domain.InvitationID(uuid.NewString()), m1, now); err != invitationport.ErrNotFound {
+		t.Fatalf("Redeem unknown err = %v, want ErrNotFound", err)
+	}
 }
 
 func RunMemberRepo(t *testing.T, newRepo MemberRepoFactory) {
@@ -153,75 +742,396 @@ func RunMemberRepo(t *testing.T, newRepo MemberRepoFactory) {
 	if len(res) != 1 || res[0].ID != aID {
 		t.Fatalf("unexpected search result: %#v", res)
 	}
-}
 
-// RunTripAndRSVPRepos exercises minimal behaviors that require coordinated seeding.
-func RunTripAndRSVPRepos(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newRSVPRepo RSVPRepoFactory) {
-	t.Helper()
-	ctx := context.Background()
-
-	members, mCleanup := newMemberRepo(t)
-	if mCleanup != nil {
-		t.Cleanup(mCleanup)
-	}
-	trips, tCleanup := newTripRepo(t)
-	if tCleanup != nil {
-		t.Cleanup(tCleanup)
-	}
-	rsvps, rCleanup := newRSVPRepo(t)
-	if rCleanup != nil {
-		t.Cleanup(rCleanup)
+	// Subjects are scoped per issuer: the same `sub` from another IdP is a different identity.
+	otherIss := authctx.WithIssuer(ctx, "https://other-issuer.test/"+uuid.NewString())
+	if _, err := repo.GetBySubject(otherIss, sub); err != memberrepoport.ErrNotFound {
+		t.Fatalf("GetBySubject other issuer err = %v, want ErrNotFound", err)
 	}
-
-	now := time.Unix(2000, 0).UTC()
-	creatorID := domain.MemberID(uuid.NewString())
-	if err := members.Create(ctx, memberrepoport.Member{
-		ID:          creatorID,
-		Subject:     domain.SubjectID("sub-creator"),
-		DisplayName: "Creator",
-		Email:       "creator@example.com",
+	otherID := domain.MemberID(uuid.NewString())
+	if err := repo.Create(otherIss, memberrepoport.Member{
+		ID:          otherID,
+		Subject:     sub,
+		DisplayName: "Alice Elsewhere",
+		Email:       "alice-elsewhere@example.com",
 		IsActive:    true,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}); err != nil {
-		t.Fatalf("seed creator: %v", err)
+		t.Fatalf("Create same subject under other issuer: %v", err)
+	}
+	if got, err := repo.GetBySubject(otherIss, sub); err != nil || got.ID != otherID {
+		t.Fatalf("GetBySubject other issuer = %v err=%v, want %s", got.ID, err, otherID)
+	}
+	if got, err := repo.GetBySubject(ctx, sub); err != nil || got.ID != aID {
+		t.Fatalf("GetBySubject default issuer = %v err=%v, want %s", got.ID, err, aID)
 	}
 
-	tripID := domain.TripID(uuid.NewString())
-	name := "Test Trip"
-	if err := trips.Create(ctx, triprepoport.Trip{
-		ID:                 tripID,
-		Status:             triprepoport.StatusDraft,
-		Name:               &name,
-		CreatorMemberID:    creatorID,
-		OrganizerMemberIDs: []domain.MemberID{creatorID},
-		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
-		CreatedAt:          now,
-		UpdatedAt:          now,
-	}); err != nil {
-		t.Fatalf("Create trip: %v", err)
+	// Email verification timestamps round-trip through Update.
+	a, err := repo.GetByID(ctx, aID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
 	}
-	got, err := trips.GetByID(ctx, tripID)
+	if a.EmailVerifiedAt != nil || a.GroupAliasEmailVerifiedAt != nil {
+		t.Fatalf("new member should be unverified: %+v", a)
+	}
+	verifiedAt := now.Add(time.Hour)
+	alias := "alice-alias@example.com"
+	a.GroupAliasEmail = &alias
+	a.EmailVerifiedAt = &verifiedAt
+	a.UpdatedAt = verifiedAt
+	if err := repo.Update(ctx, a); err != nil {
+		t.Fatalf("Update verification: %v", err)
+	}
+	a, err = repo.GetBySubject(ctx, sub)
 	if err != nil {
-		t.Fatalf("GetByID trip: %v", err)
+		t.Fatalf("GetBySubject: %v", err)
 	}
-	if got.ID != tripID || got.Status != triprepoport.StatusDraft {
-		t.Fatalf("unexpected trip: %#v", got)
+	if a.EmailVerifiedAt == nil || !a.EmailVerifiedAt.Equal(verifiedAt) || a.GroupAliasEmailVerifiedAt != nil {
+		t.Fatalf("verification = %v / %v, want %v / nil", a.EmailVerifiedAt, a.GroupAliasEmailVerifiedAt, verifiedAt)
 	}
 
-	// Visibility: PRIVATE draft visible only to creator.
-	drafts, err := trips.ListDraftsVisibleTo(ctx, creatorID)
+	// MarkEmailVerified only sets the verified-at time, and only while the address matches.
+	if err := repo.MarkEmailVerified(ctx, aID, memberrepoport.EmailFieldGroupAlias, "someone-else@example.com", verifiedAt); !errors.Is(err, memberrepoport.ErrEmailChanged) {
+		t.Fatalf("MarkEmailVerified changed alias err=%v, want ErrEmailChanged", err)
+	}
+	if err := repo.MarkEmailVerified(ctx, "00000000-0000-0000-0000-000000000000", memberrepoport.EmailFieldPrimary, a.Email, verifiedAt); !errors.Is(err, memberrepoport.ErrNotFound) {
+		t.Fatalf("MarkEmailVerified missing member err=%v, want ErrNotFound", err)
+	}
+	aliasVerifiedAt := verifiedAt.Add(time.Hour)
+	if err := repo.MarkEmailVerified(ctx, aID, memberrepoport.EmailFieldGroupAlias, "ALICE-alias@example.com", aliasVerifiedAt); err != nil {
+		t.Fatalf("MarkEmailVerified alias: %v", err)
+	}
+	if err := repo.MarkEmailVerified(ctx, aID, memberrepoport.EmailFieldPrimary, a.Email, aliasVerifiedAt); err != nil {
+		t.Fatalf("MarkEmailVerified already verified: %v", err)
+	}
+	got, err := repo.GetByID(ctx, aID)
 	if err != nil {
-		t.Fatalf("ListDraftsVisibleTo: %v", err)
+		t.Fatalf("GetByID: %v", err)
 	}
-	if len(drafts) != 1 || drafts[0].ID != tripID {
-		t.Fatalf("unexpected drafts: %#v", drafts)
+	if got.GroupAliasEmailVerifiedAt == nil || !got.GroupAliasEmailVerifiedAt.Equal(aliasVerifiedAt) ||
+		got.EmailVerifiedAt == nil || !got.EmailVerifiedAt.Equal(verifiedAt) ||
+		got.DisplayName != a.DisplayName || !got.UpdatedAt.Equal(a.UpdatedAt) {
+		t.Fatalf("after MarkEmailVerified = %+v", got)
 	}
 
-	// RSVP basics.
-	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
-		TripID:    tripID,
-		MemberID:  creatorID,
+	runMemberIdentities(t, repo, aID, otherID)
+	runMemberVehicles(t, repo, bID)
+}
+
+// runMemberVehicles covers the vehicle garage. m must be an existing member with no vehicles.
+func runMemberVehicles(t *testing.T, repo memberrepoport.Repository, m domain.MemberID) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := time.Unix(6_000, 0).UTC()
+	mk := func(s string) *string { return &s }
+
+	// Setting a profile through the member creates a default vehicle.
+	mem, err := repo.GetByID(ctx, m)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if mem.VehicleProfile != nil {
+		t.Fatalf("VehicleProfile before garage = %+v, want nil", mem.VehicleProfile)
+	}
+	mem.VehicleProfile = &domain.VehicleProfile{Make: mk("Toyota")}
+	mem.UpdatedAt = now
+	if err := repo.Update(ctx, mem); err != nil {
+		t.Fatalf("Update profile: %v", err)
+	}
+	vs, err := repo.ListVehicles(ctx, m)
+	if err != nil {
+		t.Fatalf("ListVehicles: %v", err)
+	}
+	if len(vs) != 1 || !vs[0].IsDefault || vs[0].Name != domain.DefaultVehicleName || vs[0].Profile.Make == nil || *vs[0].Profile.Make != "Toyota" {
+		t.Fatalf("ListVehicles after profile = %+v", vs)
+	}
+	first := vs[0].ID
+
+	// A second vehicle is not the default unless asked; SetDefaultVehicle moves the default
+	// and the member profile follows it.
+	second := domain.VehicleID(uuid.NewString())
+	tires := 35
+	if err := repo.CreateVehicle(ctx, domain.Vehicle{
+		ID: second, MemberID: m, Name: "Trail rig", Profile: domain.VehicleProfile{Make: mk("Jeep")},
+		Specs: domain.VehicleSpecs{
+			TireSizeInches: &tires,
+			HasLockers:     true,
+			RecoveryGear:   []domain.RecoveryGearItem{domain.RecoveryGearStrap, domain.RecoveryGearWinch},
+			HamLicensed:    true,
+		},
+		CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("CreateVehicle: %v", err)
+	}
+	if got, err := repo.GetVehicle(ctx, m, second); err != nil || got.Specs.TireSizeInches == nil || *got.Specs.TireSizeInches != 35 ||
+		!got.Specs.HasLockers || !got.Specs.HamLicensed || len(got.Specs.RecoveryGear) != 2 || got.Specs.RecoveryGear[1] != domain.RecoveryGearWinch {
+		t.Fatalf("GetVehicle specs = %+v err=%v", got.Specs, err)
+	}
+	if err := repo.SetDefaultVehicle(ctx, m, second); err != nil {
+		t.Fatalf("SetDefaultVehicle: %v", err)
+	}
+	vs, err = repo.ListVehicles(ctx, m)
+	if err != nil {
+		t.Fatalf("ListVehicles: %v", err)
+	}
+	if len(vs) != 2 || vs[0].ID != second || !vs[0].IsDefault || vs[1].IsDefault {
+		t.Fatalf("ListVehicles after SetDefault = %+v", vs)
+	}
+	mem, err = repo.GetByID(ctx, m)
+	if err != nil || mem.VehicleProfile == nil || mem.VehicleProfile.Make == nil || *mem.VehicleProfile.Make != "Jeep" {
+		t.Fatalf("member VehicleProfile = %+v err=%v, want the default vehicle's", mem.VehicleProfile, err)
+	}
+
+	// Update a non-default vehicle; a nil member profile leaves the garage alone.
+	if err := repo.UpdateVehicle(ctx, domain.Vehicle{ID: first, MemberID: m, Name: "Daily", UpdatedAt: now.Add(2 * time.Minute)}); err != nil {
+		t.Fatalf("UpdateVehicle: %v", err)
+	}
+	got, err := repo.GetVehicle(ctx, m, first)
+	if err != nil || got.Name != "Daily" || got.Profile.Make != nil || got.IsDefault || got.Specs.TireSizeInches != nil || got.Specs.RecoveryGear != nil {
+		t.Fatalf("GetVehicle after update = %+v err=%v", got, err)
+	}
+	mem.VehicleProfile = nil
+	if err := repo.Update(ctx, mem); err != nil {
+		t.Fatalf("Update nil profile: %v", err)
+	}
+	if vs, _ := repo.ListVehicles(ctx, m); len(vs) != 2 {
+		t.Fatalf("ListVehicles after nil profile = %+v, want 2", vs)
+	}
+
+	// Deleting the default promotes the remaining vehicle.
+	if err := repo.DeleteVehicle(ctx, m, second); err != nil {
+		t.Fatalf("DeleteVehicle: %v", err)
+	}
+	vs, err = repo.ListVehicles(ctx, m)
+	if err != nil || len(vs) != 1 || vs[0].ID != first || !vs[0].IsDefault {
+		t.Fatalf("ListVehicles after delete = %+v err=%v", vs, err)
+	}
+
+	unknown := domain.VehicleID(uuid.NewString())
+	if _, err := repo.GetVehicle(ctx, m, unknown); err != memberrepoport.ErrVehicleNotFound {
+		t.Fatalf("GetVehicle unknown err = %v, want ErrVehicleNotFound", err)
+	}
+	if err := repo.DeleteVehicle(ctx, m, unknown); err != memberrepoport.ErrVehicleNotFound {
+		t.Fatalf("DeleteVehicle unknown err = %v, want ErrVehicleNotFound", err)
+	}
+	if err := repo.SetDefaultVehicle(ctx, m, unknown); err != memberrepoport.ErrVehicleNotFound {
+		t.Fatalf("SetDefaultVehicle unknown err = %v, want ErrVehicleNotFound", err)
+	}
+	if _, err := repo.ListVehicles(ctx, domain.MemberID(uuid.NewString())); err != memberrepoport.ErrNotFound {
+		t.Fatalf("ListVehicles unknown member err = %v, want ErrNotFound", err)
+	}
+}
+
+// runMemberIdentities covers linked login identities. a and b must be existing members.
+func runMemberIdentities(t *testing.T, repo memberrepoport.Repository, a domain.MemberID, b domain.MemberID) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := time.Unix(5_000, 0).UTC()
+	newIss := "https://new-idp.test/" + uuid.NewString()
+	newSub := domain.SubjectID("linked-" + uuid.NewString())
+
+	before, err := repo.ListIdentities(ctx, a)
+	if err != nil {
+		t.Fatalf("ListIdentities: %v", err)
+	}
+	if len(before) != 1 {
+		t.Fatalf("ListIdentities before link = %+v, want the creation identity", before)
+	}
+
+	if err := repo.LinkIdentity(ctx, a, domain.MemberIdentity{Issuer: newIss, Subject: newSub, LinkedAt: now}); err != nil {
+		t.Fatalf("LinkIdentity: %v", err)
+	}
+	// Re-linking to the same member is a no-op; linking to another member is rejected.
+	if err := repo.LinkIdentity(ctx, a, domain.MemberIdentity{Issuer: newIss, Subject: newSub, LinkedAt: now}); err != nil {
+		t.Fatalf("LinkIdentity again: %v", err)
+	}
+	if err := repo.LinkIdentity(ctx, b, domain.MemberIdentity{Issuer: newIss, Subject: newSub, LinkedAt: now}); err != memberrepoport.ErrSubjectAlreadyBound {
+		t.Fatalf("LinkIdentity to other member err = %v, want ErrSubjectAlreadyBound", err)
+	}
+	if err := repo.LinkIdentity(ctx, domain.MemberID(uuid.NewString()), domain.MemberIdentity{Issuer: newIss, Subject: "x", LinkedAt: now}); err != memberrepoport.ErrNotFound {
+		t.Fatalf("LinkIdentity unknown member err = %v, want ErrNotFound", err)
+	}
+
+	// GetBySubject resolves through linked identities.
+	got, err := repo.GetBySubject(authctx.WithIssuer(ctx, newIss), newSub)
+	if err != nil || got.ID != a {
+		t.Fatalf("GetBySubject linked = %v err=%v, want %s", got.ID, err, a)
+	}
+	ids, err := repo.ListIdentities(ctx, a)
+	if err != nil {
+		t.Fatalf("ListIdentities: %v", err)
+	}
+	if len(ids) != 2 || ids[1].Issuer != newIss || ids[1].Subject != newSub || !ids[1].LinkedAt.Equal(now) {
+		t.Fatalf("ListIdentities after link = %+v", ids)
+	}
+
+	// Unlink: unknown identity, then success, then the remaining identity is protected.
+	if err := repo.UnlinkIdentity(ctx, a, newIss, "nope"); err != memberrepoport.ErrIdentityNotFound {
+		t.Fatalf("UnlinkIdentity unknown err = %v, want ErrIdentityNotFound", err)
+	}
+	if err := repo.UnlinkIdentity(ctx, a, newIss, newSub); err != nil {
+		t.Fatalf("UnlinkIdentity: %v", err)
+	}
+	if _, err := repo.GetBySubject(authctx.WithIssuer(ctx, newIss), newSub); err != memberrepoport.ErrNotFound {
+		t.Fatalf("GetBySubject after unlink err = %v, want ErrNotFound", err)
+	}
+	if err := repo.UnlinkIdentity(ctx, a, ids[0].Issuer, ids[0].Subject); err != memberrepoport.ErrLastIdentity {
+		t.Fatalf("UnlinkIdentity last err = %v, want ErrLastIdentity", err)
+	}
+
+	// An unlinked identity is free to be linked elsewhere.
+	if err := repo.LinkIdentity(ctx, b, domain.MemberIdentity{Issuer: newIss, Subject: newSub, LinkedAt: now}); err != nil {
+		t.Fatalf("LinkIdentity after unlink: %v", err)
+	}
+}
+
+// RunTripAndRSVPRepos exercises minimal behaviors that require coordinated seeding.
+func RunTripAndRSVPRepos(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newRSVPRepo RSVPRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	rsvps, rCleanup := newRSVPRepo(t)
+	if rCleanup != nil {
+		t.Cleanup(rCleanup)
+	}
+
+	now := time.Unix(2000, 0).UTC()
+	creatorID := domain.MemberID(uuid.NewString())
+	if err := members.Create(ctx, memberrepoport.Member{
+		ID:          creatorID,
+		Subject:     domain.SubjectID("sub-creator"),
+		DisplayName: "Creator",
+		Email:       "creator@example.com",
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("seed creator: %v", err)
+	}
+
+	tripID := domain.TripID(uuid.NewString())
+	name := "Test Trip"
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    creatorID,
+		OrganizerMemberIDs: []domain.MemberID{creatorID},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+	got, err := trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID trip: %v", err)
+	}
+	if got.ID != tripID || got.Status != triprepoport.StatusDraft {
+		t.Fatalf("unexpected trip: %#v", got)
+	}
+
+	// Structured vehicle requirements round-trip through Save.
+	minTires := 33
+	got.Requirements = domain.TripRequirements{
+		MinTireSizeInches: &minTires,
+		LockersRequired:   true,
+		RecoveryGear:      []domain.RecoveryGearItem{domain.RecoveryGearShackles},
+		Strict:            true,
+	}
+	got.UpdatedAt = now.Add(time.Second)
+	if err := trips.Save(ctx, got); err != nil {
+		t.Fatalf("Save requirements: %v", err)
+	}
+	got, err = trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID after requirements: %v", err)
+	}
+	if r := got.Requirements; r.MinTireSizeInches == nil || *r.MinTireSizeInches != 33 || !r.LockersRequired || r.HamLicenseRequired ||
+		!r.Strict || len(r.RecoveryGear) != 1 || r.RecoveryGear[0] != domain.RecoveryGearShackles {
+		t.Fatalf("Requirements = %+v", r)
+	}
+
+	// Structured difficulty round-trips through Save and can be cleared.
+	minRating, maxRating := 2, 4
+	got.Difficulty = &domain.Difficulty{
+		Rating:    3,
+		MinRating: &minRating,
+		MaxRating: &maxRating,
+		Terrain:   []domain.TerrainTag{domain.TerrainRock, domain.TerrainWaterCrossing},
+	}
+	if err := trips.Save(ctx, got); err != nil {
+		t.Fatalf("Save difficulty: %v", err)
+	}
+	got, err = trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID after difficulty: %v", err)
+	}
+	if d := got.Difficulty; d == nil || d.Rating != 3 || d.MinRating == nil || *d.MinRating != 2 || d.MaxRating == nil || *d.MaxRating != 4 ||
+		len(d.Terrain) != 2 || d.Terrain[0] != domain.TerrainRock || d.Terrain[1] != domain.TerrainWaterCrossing {
+		t.Fatalf("Difficulty = %+v", d)
+	}
+	got.Difficulty = nil
+	if err := trips.Save(ctx, got); err != nil {
+		t.Fatalf("Save cleared difficulty: %v", err)
+	}
+	got, err = trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID after clearing difficulty: %v", err)
+	}
+	if got.Difficulty != nil {
+		t.Fatalf("Difficulty = %+v, want nil", got.Difficulty)
+	}
+
+	// The RSVP deadline round-trips through Save and can be cleared.
+	deadline := now.Add(72 * time.Hour)
+	got.RSVPDeadline = &deadline
+	if err := trips.Save(ctx, got); err != nil {
+		t.Fatalf("Save rsvp deadline: %v", err)
+	}
+	got, err = trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID after rsvp deadline: %v", err)
+	}
+	if got.RSVPDeadline == nil || !got.RSVPDeadline.Equal(deadline) {
+		t.Fatalf("RSVPDeadline = %v, want %v", got.RSVPDeadline, deadline)
+	}
+	got.RSVPDeadline = nil
+	if err := trips.Save(ctx, got); err != nil {
+		t.Fatalf("Save cleared rsvp deadline: %v", err)
+	}
+	got, err = trips.GetByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("GetByID after clearing rsvp deadline: %v", err)
+	}
+	if got.RSVPDeadline != nil {
+		t.Fatalf("RSVPDeadline = %v, want nil", got.RSVPDeadline)
+	}
+
+	// Visibility: PRIVATE draft visible only to creator.
+	drafts, err := trips.ListDraftsVisibleTo(ctx, creatorID)
+	if err != nil {
+		t.Fatalf("ListDraftsVisibleTo: %v", err)
+	}
+	if len(drafts) != 1 || drafts[0].ID != tripID {
+		t.Fatalf("unexpected drafts: %#v", drafts)
+	}
+
+	// RSVP basics.
+	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:    tripID,
+		MemberID:  creatorID,
 		Status:    rsvprepoport.StatusYes,
 		UpdatedAt: now,
 	}); err != nil {
@@ -230,4 +1140,1478 @@ func RunTripAndRSVPRepos(t *testing.T, newMemberRepo MemberRepoFactory, newTripR
 	if n, err := rsvps.CountYesByTrip(ctx, tripID); err != nil || n != 1 {
 		t.Fatalf("CountYesByTrip: n=%d err=%v", n, err)
 	}
+
+	// The RSVP records which of the member's vehicles they are bringing.
+	vehicleID := domain.VehicleID(uuid.NewString())
+	if err := members.CreateVehicle(ctx, domain.Vehicle{ID: vehicleID, MemberID: creatorID, Name: "Rig", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateVehicle: %v", err)
+	}
+	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:    tripID,
+		MemberID:  creatorID,
+		Status:    rsvprepoport.StatusYes,
+		VehicleID: &vehicleID,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("Upsert rsvp with vehicle: %v", err)
+	}
+	rec, err := rsvps.Get(ctx, tripID, creatorID)
+	if err != nil || rec.VehicleID == nil || *rec.VehicleID != vehicleID {
+		t.Fatalf("Get rsvp vehicle = %v err=%v, want %s", rec.VehicleID, err, vehicleID)
+	}
+
+	// Passengers round-trip and count toward the trip's headcount.
+	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:         tripID,
+		MemberID:       creatorID,
+		Status:         rsvprepoport.StatusYes,
+		PassengerCount: 2,
+		PassengerNames: []string{"Sam"},
+		UpdatedAt:      now,
+	}); err != nil {
+		t.Fatalf("Upsert rsvp with passengers: %v", err)
+	}
+	rec, err = rsvps.Get(ctx, tripID, creatorID)
+	if err != nil || rec.PassengerCount != 2 || len(rec.PassengerNames) != 1 || rec.PassengerNames[0] != "Sam" {
+		t.Fatalf("Get rsvp passengers = %d %v err=%v", rec.PassengerCount, rec.PassengerNames, err)
+	}
+	if n, err := rsvps.CountPeopleByTrip(ctx, tripID); err != nil || n != 3 {
+		t.Fatalf("CountPeopleByTrip: n=%d err=%v, want 3", n, err)
+	}
+
+	// Organizer changes record who made them, why, and any capacity override.
+	reason := "sweep driver"
+	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:           tripID,
+		MemberID:         creatorID,
+		Status:           rsvprepoport.StatusYes,
+		UpdatedAt:        now,
+		ChangedBy:        creatorID,
+		ChangeReason:     &reason,
+		CapacityOverride: true,
+	}); err != nil {
+		t.Fatalf("Upsert rsvp with change attribution: %v", err)
+	}
+	rec, err = rsvps.Get(ctx, tripID, creatorID)
+	if err != nil || rec.ChangedBy != creatorID || rec.ChangeReason == nil || *rec.ChangeReason != reason || !rec.CapacityOverride {
+		t.Fatalf("Get rsvp change = %q %v %v err=%v", rec.ChangedBy, rec.ChangeReason, rec.CapacityOverride, err)
+	}
+	list, err := rsvps.ListByTrip(ctx, tripID)
+	if err != nil || len(list) != 1 || list[0].ChangedBy != creatorID || !list[0].CapacityOverride {
+		t.Fatalf("ListByTrip change attribution = %+v err=%v", list, err)
+	}
+
+	// Every write is appended to the RSVP history with the response it replaced.
+	if err := rsvps.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:       tripID,
+		MemberID:     creatorID,
+		Status:       rsvprepoport.StatusUnset,
+		UpdatedAt:    now.Add(time.Minute),
+		ChangedBy:    creatorID,
+		ChangeReason: &reason,
+	}); err != nil {
+		t.Fatalf("Upsert rsvp unset: %v", err)
+	}
+	history, err := rsvps.ListHistoryByTrip(ctx, tripID)
+	if err != nil || len(history) != 5 {
+		t.Fatalf("ListHistoryByTrip = %+v err=%v, want 5 entries", history, err)
+	}
+	if first := history[0]; first.PreviousStatus != nil || first.Status != rsvprepoport.StatusYes || first.MemberID != creatorID {
+		t.Fatalf("first history entry = %+v", first)
+	}
+	last := history[4]
+	if last.PreviousStatus == nil || *last.PreviousStatus != rsvprepoport.StatusYes || last.Status != rsvprepoport.StatusUnset ||
+		last.ChangedBy != creatorID || last.ChangeReason == nil || *last.ChangeReason != reason || !last.ChangedAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("last history entry = %+v", last)
+	}
+	mine, err := rsvps.ListHistoryByMember(ctx, creatorID)
+	if err != nil || len(mine) != 5 || mine[4].TripID != tripID {
+		t.Fatalf("ListHistoryByMember = %+v err=%v", mine, err)
+	}
+	if other, err := rsvps.ListHistoryByMember(ctx, domain.MemberID(uuid.NewString())); err != nil || len(other) != 0 {
+		t.Fatalf("ListHistoryByMember(unknown) = %+v err=%v, want empty", other, err)
+	}
+}
+
+func RunRideShareRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newRideRepo RideShareRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	rides, rCleanup := newRideRepo(t)
+	if rCleanup != nil {
+		t.Cleanup(rCleanup)
+	}
+
+	now := time.Unix(4_000, 0).UTC()
+	seed := func(name string) domain.MemberID {
+		id := domain.MemberID(uuid.NewString())
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-ride-" + uuid.NewString()),
+			DisplayName: name,
+			Email:       uuid.NewString() + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("seed %s: %v", name, err)
+		}
+		return id
+	}
+	driver := seed("Driver")
+	riderA := seed("Rider A")
+	riderB := seed("Rider B")
+
+	tripID := domain.TripID(uuid.NewString())
+	name := "Ride Share Trip"
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    driver,
+		OrganizerMemberIDs: []domain.MemberID{driver},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+
+	// Offers.
+	if _, err := rides.GetOffer(ctx, tripID, driver); err != ridesharerepoport.ErrOfferNotFound {
+		t.Fatalf("GetOffer missing err = %v, want ErrOfferNotFound", err)
+	}
+	notes := "Leaving from the north lot"
+	if err := rides.PutOffer(ctx, ridesharerepoport.Offer{TripID: tripID, DriverMemberID: driver, Seats: 1, Notes: &notes, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("PutOffer: %v", err)
+	}
+	offer, err := rides.GetOffer(ctx, tripID, driver)
+	if err != nil || offer.Seats != 1 || offer.Notes == nil || *offer.Notes != notes || !offer.CreatedAt.Equal(now) {
+		t.Fatalf("GetOffer = %+v err=%v", offer, err)
+	}
+	offers, err := rides.ListOffersByTrip(ctx, tripID)
+	if err != nil || len(offers) != 1 || offers[0].DriverMemberID != driver {
+		t.Fatalf("ListOffersByTrip = %+v err=%v", offers, err)
+	}
+
+	// Requests: one open request per rider, and only against an existing offer.
+	if err := rides.CreateRequest(ctx, ridesharerepoport.Request{
+		ID: domain.RideRequestID(uuid.NewString()), TripID: tripID, DriverMemberID: riderB, RiderMemberID: riderA,
+		Status: ridesharerepoport.StatusPending, CreatedAt: now, UpdatedAt: now,
+	}); err != ridesharerepoport.ErrOfferNotFound {
+		t.Fatalf("CreateRequest without offer err = %v, want ErrOfferNotFound", err)
+	}
+	reqA := ridesharerepoport.Request{
+		ID: domain.RideRequestID(uuid.NewString()), TripID: tripID, DriverMemberID: driver, RiderMemberID: riderA,
+		Status: ridesharerepoport.StatusPending, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := rides.CreateRequest(ctx, reqA); err != nil {
+		t.Fatalf("CreateRequest a: %v", err)
+	}
+	dup := reqA
+	dup.ID = domain.RideRequestID(uuid.NewString())
+	if err := rides.CreateRequest(ctx, dup); err != ridesharerepoport.ErrActiveRequestExists {
+		t.Fatalf("CreateRequest second open err = %v, want ErrActiveRequestExists", err)
+	}
+	reqB := ridesharerepoport.Request{
+		ID: domain.RideRequestID(uuid.NewString()), TripID: tripID, DriverMemberID: driver, RiderMemberID: riderB,
+		Status: ridesharerepoport.StatusPending, CreatedAt: now.Add(time.Second), UpdatedAt: now.Add(time.Second),
+	}
+	if err := rides.CreateRequest(ctx, reqB); err != nil {
+		t.Fatalf("CreateRequest b: %v", err)
+	}
+	if _, err := rides.GetRequest(ctx, domain.RideRequestID(uuid.NewString())); err != ridesharerepoport.ErrRequestNotFound {
+		t.Fatalf("GetRequest missing err = %v, want ErrRequestNotFound", err)
+	}
+	reqs, err := rides.ListRequestsByTrip(ctx, tripID)
+	if err != nil || len(reqs) != 2 || reqs[0].ID != reqA.ID || reqs[1].ID != reqB.ID {
+		t.Fatalf("ListRequestsByTrip = %+v err=%v", reqs, err)
+	}
+
+	// Accepting respects seats.
+	later := now.Add(time.Minute)
+	if err := rides.AcceptRequest(ctx, reqA.ID, later); err != nil {
+		t.Fatalf("AcceptRequest a: %v", err)
+	}
+	if err := rides.AcceptRequest(ctx, reqA.ID, later); err != ridesharerepoport.ErrInvalidTransition {
+		t.Fatalf("AcceptRequest twice err = %v, want ErrInvalidTransition", err)
+	}
+	if err := rides.AcceptRequest(ctx, reqB.ID, later); err != ridesharerepoport.ErrNoSeatsAvailable {
+		t.Fatalf("AcceptRequest full err = %v, want ErrNoSeatsAvailable", err)
+	}
+	if n, err := rides.CountAcceptedByTrip(ctx, tripID); err != nil || n != 1 {
+		t.Fatalf("CountAcceptedByTrip = %d err=%v, want 1", n, err)
+	}
+	got, err := rides.GetRequest(ctx, reqA.ID)
+	if err != nil || got.Status != ridesharerepoport.StatusAccepted || !got.UpdatedAt.Equal(later) || !got.CreatedAt.Equal(now) {
+		t.Fatalf("GetRequest a = %+v err=%v", got, err)
+	}
+
+	// Seats cannot drop below accepted riders; updating keeps CreatedAt.
+	if err := rides.PutOffer(ctx, ridesharerepoport.Offer{TripID: tripID, DriverMemberID: driver, Seats: 0, CreatedAt: later, UpdatedAt: later}); err != ridesharerepoport.ErrSeatsBelowAccepted {
+		t.Fatalf("PutOffer below accepted err = %v, want ErrSeatsBelowAccepted", err)
+	}
+	if err := rides.PutOffer(ctx, ridesharerepoport.Offer{TripID: tripID, DriverMemberID: driver, Seats: 2, CreatedAt: later, UpdatedAt: later}); err != nil {
+		t.Fatalf("PutOffer grow: %v", err)
+	}
+	offer, err = rides.GetOffer(ctx, tripID, driver)
+	if err != nil || offer.Seats != 2 || offer.Notes != nil || !offer.CreatedAt.Equal(now) || !offer.UpdatedAt.Equal(later) {
+		t.Fatalf("GetOffer after update = %+v err=%v", offer, err)
+	}
+
+	// Closing: declined requests free the rider to ask again; closed requests cannot reopen.
+	if err := rides.CloseRequest(ctx, reqB.ID, ridesharerepoport.StatusDeclined, later); err != nil {
+		t.Fatalf("CloseRequest b: %v", err)
+	}
+	if err := rides.AcceptRequest(ctx, reqB.ID, later); err != ridesharerepoport.ErrInvalidTransition {
+		t.Fatalf("AcceptRequest declined err = %v, want ErrInvalidTransition", err)
+	}
+	if err := rides.CloseRequest(ctx, reqB.ID, ridesharerepoport.StatusCanceled, later); err != ridesharerepoport.ErrInvalidTransition {
+		t.Fatalf("CloseRequest closed err = %v, want ErrInvalidTransition", err)
+	}
+	reqB2 := reqB
+	reqB2.ID = domain.RideRequestID(uuid.NewString())
+	if err := rides.CreateRequest(ctx, reqB2); err != nil {
+		t.Fatalf("CreateRequest after decline: %v", err)
+	}
+
+	// Withdrawing the offer cancels its open requests.
+	if err := rides.WithdrawOffer(ctx, tripID, driver, later); err != nil {
+		t.Fatalf("WithdrawOffer: %v", err)
+	}
+	if err := rides.WithdrawOffer(ctx, tripID, driver, later); err != ridesharerepoport.ErrOfferNotFound {
+		t.Fatalf("WithdrawOffer twice err = %v, want ErrOfferNotFound", err)
+	}
+	for _, id := range []domain.RideRequestID{reqA.ID, reqB2.ID} {
+		got, err := rides.GetRequest(ctx, id)
+		if err != nil || got.Status != ridesharerepoport.StatusCanceled {
+			t.Fatalf("request %s after withdraw = %+v err=%v, want CANCELED", id, got, err)
+		}
+	}
+	if n, err := rides.CountAcceptedByTrip(ctx, tripID); err != nil || n != 0 {
+		t.Fatalf("CountAcceptedByTrip after withdraw = %d err=%v, want 0", n, err)
+	}
+}
+
+func RunTripTemplateRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTemplateRepo TripTemplateRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	templates, tCleanup := newTemplateRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+
+	now := time.Unix(5_000, 0).UTC()
+	creator := domain.MemberID(uuid.NewString())
+	if err := members.Create(ctx, memberrepoport.Member{
+		ID:          creator,
+		Subject:     domain.SubjectID("sub-tmpl-" + uuid.NewString()),
+		DisplayName: "Template Creator",
+		Email:       uuid.NewString() + "@example.com",
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("seed member: %v", err)
+	}
+
+	// Names are unique per repo; suffix them so shared databases don't collide across runs.
+	suffix := " " + uuid.NewString()
+	desc := "Sunrise run up the wash"
+	rigs := 6
+	minRating := 2
+	addr := "North lot"
+	lat, lng := 37.1, -112.2
+	full := triptemplaterepoport.Template{
+		ID:              domain.TripTemplateID(uuid.NewString()),
+		Name:            "Wash Run" + suffix,
+		CreatedByMember: creator,
+		Plan: domain.TripPlan{
+			Description:  &desc,
+			CapacityRigs: &rigs,
+			Difficulty: &domain.Difficulty{
+				Rating:    3,
+				MinRating: &minRating,
+				Terrain:   []domain.TerrainTag{domain.TerrainSand, domain.TerrainWaterCrossing},
+			},
+			MeetingLocation: &domain.Location{Label: "Trailhead", Address: &addr, Latitude: &lat, Longitude: &lng},
+			Requirements: domain.TripRequirements{
+				MinTireSizeInches: &rigs,
+				LockersRequired:   true,
+				RecoveryGear:      []domain.RecoveryGearItem{domain.RecoveryGearWinch},
+			},
+			Artifacts: []domain.TripArtifact{
+				{ArtifactID: "a1", Type: domain.ArtifactTypeGPX, Title: "Track", URL: "https://example.com/track.gpx"},
+				{ArtifactID: "a2", Type: domain.ArtifactTypeOther, Title: "Notes", URL: "https://example.com/notes"},
+			},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := templates.Create(ctx, full); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := templates.Create(ctx, full); err != triptemplaterepoport.ErrAlreadyExists {
+		t.Fatalf("Create duplicate id err = %v, want ErrAlreadyExists", err)
+	}
+	clash := triptemplaterepoport.Template{
+		ID:              domain.TripTemplateID(uuid.NewString()),
+		Name:            "WASH RUN" + suffix,
+		CreatedByMember: creator,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := templates.Create(ctx, clash); err != triptemplaterepoport.ErrNameTaken {
+		t.Fatalf("Create same name err = %v, want ErrNameTaken", err)
+	}
+
+	got, err := templates.GetByID(ctx, full.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	p := got.Plan
+	if got.Name != full.Name || got.CreatedByMember != creator || !got.CreatedAt.Equal(now) {
+		t.Fatalf("GetByID = %+v", got)
+	}
+	if p.Name != nil || p.Description == nil || *p.Description != desc || p.CapacityRigs == nil || *p.CapacityRigs != rigs || p.CapacityPeople != nil {
+		t.Fatalf("GetByID plan fields = %+v", p)
+	}
+	if p.Difficulty == nil || p.Difficulty.Rating != 3 || p.Difficulty.MinRating == nil || *p.Difficulty.MinRating != 2 || p.Difficulty.MaxRating != nil || len(p.Difficulty.Terrain) != 2 {
+		t.Fatalf("GetByID difficulty = %+v", p.Difficulty)
+	}
+	if p.MeetingLocation == nil || p.MeetingLocation.Label != "Trailhead" || p.MeetingLocation.Latitude == nil || *p.MeetingLocation.Latitude != lat {
+		t.Fatalf("GetByID meeting location = %+v", p.MeetingLocation)
+	}
+	if p.Requirements.MinTireSizeInches == nil || !p.Requirements.LockersRequired || len(p.Requirements.RecoveryGear) != 1 || p.Requirements.RecoveryGear[0] != domain.RecoveryGearWinch {
+		t.Fatalf("GetByID requirements = %+v", p.Requirements)
+	}
+	if len(p.Artifacts) != 2 || p.Artifacts[0].Title != "Track" || p.Artifacts[1].Type != domain.ArtifactTypeOther || p.Artifacts[1].URL != "https://example.com/notes" {
+		t.Fatalf("GetByID artifacts = %+v", p.Artifacts)
+	}
+	if _, err := templates.GetByID(ctx, domain.TripTemplateID(uuid.NewString())); err != triptemplaterepoport.ErrNotFound {
+		t.Fatalf("GetByID missing err = %v, want ErrNotFound", err)
+	}
+
+	bare := triptemplaterepoport.Template{
+		ID:              domain.TripTemplateID(uuid.NewString()),
+		Name:            "alpine loop" + suffix,
+		CreatedByMember: creator,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := templates.Create(ctx, bare); err != nil {
+		t.Fatalf("Create bare: %v", err)
+	}
+	list, err := templates.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var ours []triptemplaterepoport.Template
+	for _, tmpl := range list {
+		if tmpl.ID == full.ID || tmpl.ID == bare.ID {
+			ours = append(ours, tmpl)
+		}
+	}
+	if len(ours) != 2 || ours[0].ID != bare.ID || ours[1].ID != full.ID {
+		t.Fatalf("List order = %+v, want alpine loop before Wash Run", ours)
+	}
+	if ours[0].Plan.Difficulty != nil || len(ours[0].Plan.Artifacts) != 0 {
+		t.Fatalf("List bare plan = %+v", ours[0].Plan)
+	}
+
+	if err := templates.Delete(ctx, full.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := templates.Delete(ctx, full.ID); err != triptemplaterepoport.ErrNotFound {
+		t.Fatalf("Delete twice err = %v, want ErrNotFound", err)
+	}
+	if _, err := templates.GetByID(ctx, full.ID); err != triptemplaterepoport.ErrNotFound {
+		t.Fatalf("GetByID after delete err = %v, want ErrNotFound", err)
+	}
+	// The name is free again once the template is gone.
+	clash.Name = full.Name
+	if err := templates.Create(ctx, clash); err != nil {
+		t.Fatalf("Create reused name: %v", err)
+	}
+}
+
+// RunTripSeriesRepo exercises series persistence and the occurrence links kept by the trip repo.
+func RunTripSeriesRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newSeriesRepo TripSeriesRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	series, sCleanup := newSeriesRepo(t)
+	if sCleanup != nil {
+		t.Cleanup(sCleanup)
+	}
+
+	now := time.Unix(6_000, 0).UTC()
+	creator := domain.MemberID(uuid.NewString())
+	if err := members.Create(ctx, memberrepoport.Member{
+		ID:          creator,
+		Subject:     domain.SubjectID("sub-series-" + uuid.NewString()),
+		DisplayName: "Series Creator",
+		Email:       uuid.NewString() + "@example.com",
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("seed member: %v", err)
+	}
+
+	name := "Thursday Night Run"
+	rigs := 8
+	start := time.Date(2030, 3, 7, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2030, 6, 27, 0, 0, 0, 0, time.UTC)
+	full := tripseriesrepoport.Series{
+		ID:              domain.TripSeriesID(uuid.NewString()),
+		CreatedByMember: creator,
+		Recurrence:      domain.Recurrence{Frequency: domain.RecurrenceWeekly, Interval: 2, Weekday: time.Thursday},
+		StartDate:       start,
+		EndDate:         &end,
+		DurationDays:    2,
+		Publish:         true,
+		Plan: domain.TripPlan{
+			Name:         &name,
+			CapacityRigs: &rigs,
+			Difficulty:   &domain.Difficulty{Rating: 2, Terrain: []domain.TerrainTag{domain.TerrainSand}},
+			Requirements: domain.TripRequirements{LockersRequired: true},
+			Artifacts: []domain.TripArtifact{
+				{ArtifactID: "a1", Type: domain.ArtifactTypeGPX, Title: "Track", URL: "https://example.com/track.gpx"},
+			},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := series.Create(ctx, full); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := series.Create(ctx, full); err != tripseriesrepoport.ErrAlreadyExists {
+		t.Fatalf("Create duplicate err = %v, want ErrAlreadyExists", err)
+	}
+	got, err := series.GetByID(ctx, full.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.CreatedByMember != creator || got.Recurrence != full.Recurrence || !got.StartDate.Equal(start) || got.EndDate == nil || !got.EndDate.Equal(end) || got.DurationDays != 2 || !got.Publish || !got.CreatedAt.Equal(now) {
+		t.Fatalf("GetByID = %+v", got)
+	}
+	p := got.Plan
+	if p.Name == nil || *p.Name != name || p.CapacityRigs == nil || *p.CapacityRigs != rigs || p.Difficulty == nil || p.Difficulty.Rating != 2 || !p.Requirements.LockersRequired || len(p.Artifacts) != 1 || p.Artifacts[0].Title != "Track" {
+		t.Fatalf("GetByID plan = %+v", p)
+	}
+	if _, err := series.GetByID(ctx, domain.TripSeriesID(uuid.NewString())); err != tripseriesrepoport.ErrNotFound {
+		t.Fatalf("GetByID missing err = %v, want ErrNotFound", err)
+	}
+
+	// Save replaces the schedule and plan but keeps the creator and creation time.
+	later := now.Add(time.Hour)
+	edited := got
+	edited.CreatedByMember = domain.MemberID(uuid.NewString())
+	edited.CreatedAt = later
+	edited.UpdatedAt = later
+	edited.EndDate = nil
+	edited.Publish = false
+	edited.Plan.CapacityRigs = nil
+	edited.Plan.Difficulty = nil
+	edited.Plan.Artifacts = []domain.TripArtifact{
+		{ArtifactID: "a2", Type: domain.ArtifactTypeOther, Title: "Notes", URL: "https://example.com/notes"},
+		{ArtifactID: "a3", Type: domain.ArtifactTypeOther, Title: "Permit", URL: "https://example.com/permit"},
+	}
+	if err := series.Save(ctx, edited); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err = series.GetByID(ctx, full.ID)
+	if err != nil {
+		t.Fatalf("GetByID after save: %v", err)
+	}
+	if got.CreatedByMember != creator || !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(later) || got.EndDate != nil || got.Publish {
+		t.Fatalf("GetByID after save = %+v", got)
+	}
+	if got.Plan.CapacityRigs != nil || got.Plan.Difficulty != nil || len(got.Plan.Artifacts) != 2 || got.Plan.Artifacts[0].Title != "Notes" || got.Plan.Artifacts[1].Title != "Permit" {
+		t.Fatalf("GetByID plan after save = %+v", got.Plan)
+	}
+	missing := full
+	missing.ID = domain.TripSeriesID(uuid.NewString())
+	if err := series.Save(ctx, missing); err != tripseriesrepoport.ErrNotFound {
+		t.Fatalf("Save missing err = %v, want ErrNotFound", err)
+	}
+
+	second := tripseriesrepoport.Series{
+		ID:              domain.TripSeriesID(uuid.NewString()),
+		CreatedByMember: creator,
+		Recurrence:      domain.Recurrence{Frequency: domain.RecurrenceMonthly, Interval: 1, Weekday: time.Saturday, Week: -1},
+		StartDate:       start,
+		DurationDays:    1,
+		CreatedAt:       now.Add(time.Minute),
+		UpdatedAt:       now.Add(time.Minute),
+	}
+	if err := series.Create(ctx, second); err != nil {
+		t.Fatalf("Create second: %v", err)
+	}
+	list, err := series.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var ours []tripseriesrepoport.Series
+	for _, s := range list {
+		if s.ID == full.ID || s.ID == second.ID {
+			ours = append(ours, s)
+		}
+	}
+	if len(ours) != 2 || ours[0].ID != full.ID || ours[1].ID != second.ID || ours[1].Recurrence != second.Recurrence {
+		t.Fatalf("List = %+v, want series in creation order", ours)
+	}
+
+	// Occurrences are trips linked to the series by date; each date is used at most once.
+	occurrence := func(date time.Time) triprepoport.Trip {
+		return triprepoport.Trip{
+			ID:                 domain.TripID(uuid.NewString()),
+			Status:             triprepoport.StatusDraft,
+			Name:               &name,
+			CreatorMemberID:    creator,
+			OrganizerMemberIDs: []domain.MemberID{creator},
+			DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+			Series:             &triprepoport.SeriesOccurrence{SeriesID: full.ID, Date: date},
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+	}
+	secondDate := start.AddDate(0, 0, 14)
+	b := occurrence(secondDate)
+	a := occurrence(start)
+	for _, tr := range []triprepoport.Trip{b, a} {
+		if err := trips.Create(ctx, tr); err != nil {
+			t.Fatalf("Create occurrence: %v", err)
+		}
+	}
+	if err := trips.Create(ctx, occurrence(start)); err != triprepoport.ErrOccurrenceExists {
+		t.Fatalf("Create same occurrence date err = %v, want ErrOccurrenceExists", err)
+	}
+	gotTrip, err := trips.GetByID(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetByID occurrence: %v", err)
+	}
+	if gotTrip.Series == nil || gotTrip.Series.SeriesID != full.ID || !gotTrip.Series.Date.Equal(start) {
+		t.Fatalf("occurrence series = %+v", gotTrip.Series)
+	}
+	occs, err := trips.ListBySeries(ctx, full.ID)
+	if err != nil {
+		t.Fatalf("ListBySeries: %v", err)
+	}
+	if len(occs) != 2 || occs[0].ID != a.ID || occs[1].ID != b.ID || !occs[1].Series.Date.Equal(secondDate) {
+		t.Fatalf("ListBySeries = %+v, want occurrences in date order", occs)
+	}
+	if occs, err := trips.ListBySeries(ctx, second.ID); err != nil || len(occs) != 0 {
+		t.Fatalf("ListBySeries empty = %+v err=%v", occs, err)
+	}
+}
+
+// RunItineraryRepo exercises per-day itinerary persistence for a trip.
+func RunItineraryRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newItineraryRepo ItineraryRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	itineraries, iCleanup := newItineraryRepo(t)
+	if iCleanup != nil {
+		t.Cleanup(iCleanup)
+	}
+
+	now := time.Unix(7_000, 0).UTC()
+	organizer := domain.MemberID(uuid.NewString())
+	if err := members.Create(ctx, memberrepoport.Member{
+		ID:          organizer,
+		Subject:     domain.SubjectID("sub-itin-" + uuid.NewString()),
+		DisplayName: "Organizer",
+		Email:       uuid.NewString() + "@example.com",
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("seed member: %v", err)
+	}
+	start := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+	name := "Three Day Loop"
+	tripID := domain.TripID(uuid.NewString())
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		Name:               &name,
+		StartDate:          &start,
+		EndDate:            &end,
+		CreatorMemberID:    organizer,
+		OrganizerMemberIDs: []domain.MemberID{organizer},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+
+	if days, err := itineraries.ListByTrip(ctx, tripID); err != nil || len(days) != 0 {
+		t.Fatalf("ListByTrip empty = %+v err=%v", days, err)
+	}
+
+	title := "Into the canyon"
+	addr := "Main St"
+	lat, lng := 38.5, -109.6
+	meet := "07:30"
+	miles := 18.5
+	segNotes := "Slow going after rain"
+	day1 := itineraryrepoport.Day{
+		TripID: tripID,
+		ItineraryDay: domain.ItineraryDay{
+			Date:  start,
+			Title: &title,
+			Stops: []domain.ItineraryStop{
+				{Type: domain.ItineraryStopMeetingPoint, Location: domain.Location{Label: "Gas station", Address: &addr}, Time: &meet},
+				{Type: domain.ItineraryStopCampsite, Location: domain.Location{Label: "Mesa camp", Latitude: &lat, Longitude: &lng}},
+			},
+			Segments: []domain.RouteSegment{
+				{Name: "Shafer Trail", DistanceMiles: &miles, Notes: &segNotes},
+			},
+			UpdatedAt: now,
+		},
+	}
+	day3 := itineraryrepoport.Day{
+		TripID:       tripID,
+		ItineraryDay: domain.ItineraryDay{Date: end, UpdatedAt: now},
+	}
+	for _, d := range []itineraryrepoport.Day{day3, day1} {
+		if err := itineraries.PutDay(ctx, d); err != nil {
+			t.Fatalf("PutDay %s: %v", d.Date, err)
+		}
+	}
+
+	days, err := itineraries.ListByTrip(ctx, tripID)
+	if err != nil {
+		t.Fatalf("ListByTrip: %v", err)
+	}
+	if len(days) != 2 || !days[0].Date.Equal(start) || !days[1].Date.Equal(end) {
+		t.Fatalf("ListByTrip = %+v, want days in date order", days)
+	}
+	got := days[0]
+	if got.TripID != tripID || got.Title == nil || *got.Title != title || got.Notes != nil || !got.UpdatedAt.Equal(now) {
+		t.Fatalf("day 1 = %+v", got)
+	}
+	if len(got.Stops) != 2 || got.Stops[0].Type != domain.ItineraryStopMeetingPoint || got.Stops[0].Time == nil || *got.Stops[0].Time != meet ||
+		got.Stops[0].Location.Address == nil || *got.Stops[0].Location.Address != addr ||
+		got.Stops[1].Type != domain.ItineraryStopCampsite || got.Stops[1].Location.Latitude == nil || *got.Stops[1].Location.Latitude != lat {
+		t.Fatalf("day 1 stops = %+v", got.Stops)
+	}
+	if len(got.Segments) != 1 || got.Segments[0].Name != "Shafer Trail" || got.Segments[0].DistanceMiles == nil || *got.Segments[0].DistanceMiles != miles {
+		t.Fatalf("day 1 segments = %+v", got.Segments)
+	}
+	if len(days[1].Stops) != 0 || len(days[1].Segments) != 0 || days[1].Title != nil {
+		t.Fatalf("day 3 = %+v", days[1])
+	}
+
+	// PutDay replaces the whole day.
+	later := now.Add(time.Hour)
+	notes := "Fuel up before the trail"
+	day1.Title = nil
+	day1.Notes = &notes
+	day1.Stops = []domain.ItineraryStop{{Type: domain.ItineraryStopFuel, Location: domain.Location{Label: "Last gas"}}}
+	day1.Segments = nil
+	day1.UpdatedAt = later
+	if err := itineraries.PutDay(ctx, day1); err != nil {
+		t.Fatalf("PutDay replace: %v", err)
+	}
+	days, err = itineraries.ListByTrip(ctx, tripID)
+	if err != nil || len(days) != 2 {
+		t.Fatalf("ListByTrip after replace = %+v err=%v", days, err)
+	}
+	got = days[0]
+	if got.Title != nil || got.Notes == nil || *got.Notes != notes || len(got.Stops) != 1 || got.Stops[0].Type != domain.ItineraryStopFuel || len(got.Segments) != 0 || !got.UpdatedAt.Equal(later) {
+		t.Fatalf("day 1 after replace = %+v", got)
+	}
+
+	if err := itineraries.DeleteDay(ctx, tripID, end); err != nil {
+		t.Fatalf("DeleteDay: %v", err)
+	}
+	if err := itineraries.DeleteDay(ctx, tripID, end); err != itineraryrepoport.ErrDayNotFound {
+		t.Fatalf("DeleteDay twice err = %v, want ErrDayNotFound", err)
+	}
+	if days, err := itineraries.ListByTrip(ctx, tripID); err != nil || len(days) != 1 || !days[0].Date.Equal(start) {
+		t.Fatalf("ListByTrip after delete = %+v err=%v", days, err)
+	}
+	if days, err := itineraries.ListByTrip(ctx, domain.TripID(uuid.NewString())); err != nil || len(days) != 0 {
+		t.Fatalf("ListByTrip other trip = %+v err=%v", days, err)
+	}
+}
+
+func RunAttendanceRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newAttendanceRepo AttendanceRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	attendance, aCleanup := newAttendanceRepo(t)
+	if aCleanup != nil {
+		t.Cleanup(aCleanup)
+	}
+
+	now := time.Unix(8_000, 0).UTC()
+	seedMember := func(name string) domain.MemberID {
+		t.Helper()
+		id := domain.MemberID(uuid.NewString())
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-attendance-" + uuid.NewString()),
+			DisplayName: name,
+			Email:       uuid.NewString() + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("seed member: %v", err)
+		}
+		return id
+	}
+	organizer := seedMember("Organizer")
+	rider := seedMember("Rider")
+	seedTrip := func() domain.TripID {
+		t.Helper()
+		id := domain.TripID(uuid.NewString())
+		name := "Check-in Trip"
+		if err := trips.Create(ctx, triprepoport.Trip{
+			ID:                 id,
+			Status:             triprepoport.StatusDraft,
+			Name:               &name,
+			CreatorMemberID:    organizer,
+			OrganizerMemberIDs: []domain.MemberID{organizer},
+			DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}); err != nil {
+			t.Fatalf("Create trip: %v", err)
+		}
+		return id
+	}
+	tripA, tripB := seedTrip(), seedTrip()
+
+	if recs, err := attendance.ListByTrip(ctx, tripA); err != nil || len(recs) != 0 {
+		t.Fatalf("ListByTrip empty = %+v err=%v", recs, err)
+	}
+
+	checkedIn := now.Add(time.Hour)
+	for _, rec := range []attendancerepoport.Record{
+		{TripID: tripA, MemberID: rider, Status: attendancerepoport.StatusAbsent, RecordedBy: organizer, UpdatedAt: now},
+		{TripID: tripA, MemberID: organizer, Status: attendancerepoport.StatusPresent, CheckedInAt: &checkedIn, RecordedBy: organizer, UpdatedAt: checkedIn},
+		{TripID: tripB, MemberID: rider, Status: attendancerepoport.StatusPresent, WalkUp: true, CheckedInAt: &checkedIn, RecordedBy: organizer, UpdatedAt: checkedIn},
+	} {
+		if err := attendance.Put(ctx, rec); err != nil {
+			t.Fatalf("Put %+v: %v", rec, err)
+		}
+	}
+
+	// Put replaces the record: the absent rider turns up late.
+	late := now.Add(2 * time.Hour)
+	if err := attendance.Put(ctx, attendancerepoport.Record{TripID: tripA, MemberID: rider, Status: attendancerepoport.StatusPresent, CheckedInAt: &late, RecordedBy: organizer, UpdatedAt: late}); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+	recs, err := attendance.ListByTrip(ctx, tripA)
+	if err != nil || len(recs) != 2 {
+		t.Fatalf("ListByTrip = %+v err=%v, want 2", recs, err)
+	}
+	if recs[0].MemberID > recs[1].MemberID {
+		t.Fatalf("ListByTrip not ordered by member: %+v", recs)
+	}
+	for _, rec := range recs {
+		if rec.TripID != tripA || rec.Status != attendancerepoport.StatusPresent || rec.WalkUp || rec.CheckedInAt == nil || rec.RecordedBy != organizer {
+			t.Fatalf("record = %+v", rec)
+		}
+		if rec.MemberID == rider && (!rec.CheckedInAt.Equal(late) || !rec.UpdatedAt.Equal(late)) {
+			t.Fatalf("replaced record = %+v", rec)
+		}
+	}
+
+	mine, err := attendance.ListByMember(ctx, rider)
+	if err != nil || len(mine) != 2 || mine[0].TripID > mine[1].TripID {
+		t.Fatalf("ListByMember = %+v err=%v", mine, err)
+	}
+	for _, rec := range mine {
+		if rec.TripID == tripB && (!rec.WalkUp || rec.Status != attendancerepoport.StatusPresent) {
+			t.Fatalf("walk-up record = %+v", rec)
+		}
+	}
+	if recs, err := attendance.ListByMember(ctx, domain.MemberID(uuid.NewString())); err != nil || len(recs) != 0 {
+		t.Fatalf("ListByMember unknown = %+v err=%v", recs, err)
+	}
+}
+
+func RunEmergencyInfoRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newEmergencyInfoRepo EmergencyInfoRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	infos, eCleanup := newEmergencyInfoRepo(t)
+	if eCleanup != nil {
+		t.Cleanup(eCleanup)
+	}
+
+	now := time.Unix(9_000, 0).UTC()
+	seedMember := func(name string) domain.MemberID {
+		t.Helper()
+		id := domain.MemberID(uuid.NewString())
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-emergency-" + uuid.NewString()),
+			DisplayName: name,
+			Email:       uuid.NewString() + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("seed member: %v", err)
+		}
+		return id
+	}
+	member := seedMember("Member")
+	organizer := seedMember("Organizer")
+	tripID := domain.TripID(uuid.NewString())
+	name := "Backcountry Trip"
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    organizer,
+		OrganizerMemberIDs: []domain.MemberID{organizer},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+
+	if _, err := infos.Get(ctx, member); !errors.Is(err, emergencyinforepoport.ErrNotFound) {
+		t.Fatalf("Get missing err=%v, want ErrNotFound", err)
+	}
+	if err := infos.Delete(ctx, member); !errors.Is(err, emergencyinforepoport.ErrNotFound) {
+		t.Fatalf("Delete missing err=%v, want ErrNotFound", err)
+	}
+
+	sealed := emergencyinforepoport.Sealed{MemberID: member, KeyID: "k1", Nonce: []byte{1, 2, 3}, Ciphertext: []byte("opaque"), UpdatedAt: now}
+	if err := infos.Put(ctx, sealed); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	sealed.KeyID, sealed.Ciphertext, sealed.UpdatedAt = "k2", []byte("rotated"), now.Add(time.Minute)
+	if err := infos.Put(ctx, sealed); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+	got, err := infos.Get(ctx, member)
+	if err != nil || got.KeyID != "k2" || string(got.Ciphertext) != "rotated" || len(got.Nonce) != 3 || !got.UpdatedAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Get = %+v err=%v", got, err)
+	}
+
+	for i, e := range []domain.EmergencyInfoAccess{
+		{AccessedBy: member, Action: domain.EmergencyInfoUpdated, AccessedAt: now},
+		{AccessedBy: organizer, TripID: tripID, Action: domain.EmergencyInfoViewed, AccessedAt: now.Add(time.Hour)},
+	} {
+		if err := infos.AppendAccess(ctx, emergencyinforepoport.AccessEntry{MemberID: member, EmergencyInfoAccess: e}); err != nil {
+			t.Fatalf("AppendAccess %d: %v", i, err)
+		}
+	}
+	log, err := infos.ListAccess(ctx, member)
+	if err != nil || len(log) != 2 {
+		t.Fatalf("ListAccess = %+v err=%v, want 2 entries", log, err)
+	}
+	if log[0].AccessedBy != member || log[0].TripID != "" || log[0].Action != domain.EmergencyInfoUpdated {
+		t.Fatalf("first access = %+v", log[0])
+	}
+	if log[1].AccessedBy != organizer || log[1].TripID != tripID || log[1].Action != domain.EmergencyInfoViewed || !log[1].AccessedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("second access = %+v", log[1])
+	}
+	if other, err := infos.ListAccess(ctx, organizer); err != nil || len(other) != 0 {
+		t.Fatalf("ListAccess(organizer) = %+v err=%v, want empty", other, err)
+	}
+
+	if err := infos.Delete(ctx, member); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := infos.Get(ctx, member); !errors.Is(err, emergencyinforepoport.ErrNotFound) {
+		t.Fatalf("Get after delete err=%v, want ErrNotFound", err)
+	}
+	// The access log outlives the info it audits.
+	if log, err := infos.ListAccess(ctx, member); err != nil || len(log) != 2 {
+		t.Fatalf("ListAccess after delete = %+v err=%v", log, err)
+	}
+}
+
+func RunWaiverRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newWaiverRepo WaiverRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	waivers, wCleanup := newWaiverRepo(t)
+	if wCleanup != nil {
+		t.Cleanup(wCleanup)
+	}
+
+	now := time.Unix(10_000, 0).UTC()
+	member := domain.MemberID(uuid.NewString())
+	if err := members.Create(ctx, memberrepoport.Member{
+		ID:          member,
+		Subject:     domain.SubjectID("sub-waiver-" + uuid.NewString()),
+		DisplayName: "Signer",
+		Email:       uuid.NewString() + "@example.com",
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("seed member: %v", err)
+	}
+	tripID := domain.TripID(uuid.NewString())
+	name := "Waiver Trip"
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    member,
+		OrganizerMemberIDs: []domain.MemberID{member},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+
+	hash := func(c string) string { return strings.Repeat(c, 64) }
+	season := waiverrepoport.Waiver{ID: domain.WaiverID(uuid.NewString()), Title: "2026 Season", RequiredForAllTrips: true, CreatedBy: "ops", CreatedAt: now}
+	rock := waiverrepoport.Waiver{ID: domain.WaiverID(uuid.NewString()), Title: "Rock Crawling", CreatedBy: "ops", CreatedAt: now.Add(time.Minute)}
+	if err := waivers.Create(ctx, season, waiverrepoport.Version{Body: "season v1", Hash: hash("a"), PublishedAt: now}); err != nil {
+		t.Fatalf("Create season: %v", err)
+	}
+	if err := waivers.Create(ctx, rock, waiverrepoport.Version{Body: "rock v1", Hash: hash("b"), PublishedAt: now}); err != nil {
+		t.Fatalf("Create rock: %v", err)
+	}
+	if err := waivers.Create(ctx, season, waiverrepoport.Version{Body: "dup", Hash: hash("c"), PublishedAt: now}); !errors.Is(err, waiverrepoport.ErrAlreadyExists) {
+		t.Fatalf("Create duplicate err=%v, want ErrAlreadyExists", err)
+	}
+	missing := domain.WaiverID(uuid.NewString())
+	if _, err := waivers.Get(ctx, missing); !errors.Is(err, waiverrepoport.ErrNotFound) {
+		t.Fatalf("Get missing err=%v, want ErrNotFound", err)
+	}
+	got, err := waivers.Get(ctx, season.ID)
+	if err != nil || got.Title != "2026 Season" || !got.RequiredForAllTrips || got.CreatedBy != "ops" || !got.CreatedAt.Equal(now) {
+		t.Fatalf("Get = %+v err=%v", got, err)
+	}
+	list, err := waivers.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	seasonAt, rockAt := -1, -1
+	for i, w := range list {
+		switch w.ID {
+		case season.ID:
+			seasonAt = i
+		case rock.ID:
+			rockAt = i
+		}
+	}
+	if seasonAt < 0 || rockAt < seasonAt {
+		t.Fatalf("List = %+v, want season before rock", list)
+	}
+
+	v, err := waivers.LatestVersion(ctx, season.ID)
+	if err != nil || v.Version != 1 || v.Body != "season v1" || v.Hash != hash("a") {
+		t.Fatalf("LatestVersion = %+v err=%v", v, err)
+	}
+	if err := waivers.AddVersion(ctx, waiverrepoport.Version{WaiverID: season.ID, Version: 3, Body: "skip", Hash: hash("d"), PublishedAt: now}); !errors.Is(err, waiverrepoport.ErrVersionConflict) {
+		t.Fatalf("AddVersion skipping err=%v, want ErrVersionConflict", err)
+	}
+	if err := waivers.AddVersion(ctx, waiverrepoport.Version{WaiverID: missing, Version: 1, Body: "x", Hash: hash("d"), PublishedAt: now}); !errors.Is(err, waiverrepoport.ErrNotFound) {
+		t.Fatalf("AddVersion missing err=%v, want ErrNotFound", err)
+	}
+	if err := waivers.AddVersion(ctx, waiverrepoport.Version{WaiverID: season.ID, Version: 2, Body: "season v2", Hash: hash("e"), PublishedAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("AddVersion: %v", err)
+	}
+	if err := waivers.AddVersion(ctx, waiverrepoport.Version{WaiverID: season.ID, Version: 2, Body: "again", Hash: hash("f"), PublishedAt: now.Add(time.Hour)}); !errors.Is(err, waiverrepoport.ErrVersionConflict) {
+		t.Fatalf("AddVersion repeat err=%v, want ErrVersionConflict", err)
+	}
+	if v, err := waivers.LatestVersion(ctx, season.ID); err != nil || v.Version != 2 || v.Body != "season v2" || !v.PublishedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("LatestVersion after publish = %+v err=%v", v, err)
+	}
+
+	ack := waiverrepoport.Acknowledgement{WaiverID: season.ID, Version: 1, VersionHash: hash("a"), MemberID: member, Subject: "sub-one", AcknowledgedAt: now}
+	if err := waivers.Acknowledge(ctx, ack); err != nil {
+		t.Fatalf("Acknowledge: %v", err)
+	}
+	dup := ack
+	dup.Subject, dup.AcknowledgedAt = "sub-two", now.Add(time.Minute)
+	if err := waivers.Acknowledge(ctx, dup); err != nil {
+		t.Fatalf("Acknowledge again: %v", err)
+	}
+	if err := waivers.Acknowledge(ctx, waiverrepoport.Acknowledgement{WaiverID: season.ID, Version: 2, VersionHash: hash("e"), MemberID: member, Subject: "sub-one", AcknowledgedAt: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("Acknowledge v2: %v", err)
+	}
+	for _, bad := range []waiverrepoport.Acknowledgement{
+		{WaiverID: season.ID, Version: 9, VersionHash: hash("a"), MemberID: member, Subject: "s", AcknowledgedAt: now},
+		{WaiverID: missing, Version: 1, VersionHash: hash("a"), MemberID: member, Subject: "s", AcknowledgedAt: now},
+	} {
+		if err := waivers.Acknowledge(ctx, bad); !errors.Is(err, waiverrepoport.ErrNotFound) {
+			t.Fatalf("Acknowledge(%+v) err=%v, want ErrNotFound", bad, err)
+		}
+	}
+	acks, err := waivers.ListAcknowledgementsByMember(ctx, member)
+	if err != nil || len(acks) != 2 {
+		t.Fatalf("ListAcknowledgementsByMember = %+v err=%v, want 2", acks, err)
+	}
+	if acks[0].Version != 1 || acks[0].Subject != "sub-one" || !acks[0].AcknowledgedAt.Equal(now) || acks[0].VersionHash != hash("a") || acks[1].Version != 2 {
+		t.Fatalf("acknowledgements = %+v", acks)
+	}
+	if byWaiver, err := waivers.ListAcknowledgementsByWaiver(ctx, rock.ID); err != nil || len(byWaiver) != 0 {
+		t.Fatalf("ListAcknowledgementsByWaiver(rock) = %+v err=%v", byWaiver, err)
+	}
+	if byWaiver, err := waivers.ListAcknowledgementsByWaiver(ctx, season.ID); err != nil || len(byWaiver) != 2 || byWaiver[0].MemberID != member {
+		t.Fatalf("ListAcknowledgementsByWaiver(season) = %+v err=%v", byWaiver, err)
+	}
+
+	if ids, err := waivers.ListTripRequirements(ctx, tripID); err != nil || len(ids) != 0 {
+		t.Fatalf("ListTripRequirements empty = %v err=%v", ids, err)
+	}
+	if err := waivers.SetTripRequirements(ctx, tripID, []domain.WaiverID{rock.ID, season.ID, rock.ID}); err != nil {
+		t.Fatalf("SetTripRequirements: %v", err)
+	}
+	ids, err := waivers.ListTripRequirements(ctx, tripID)
+	if err != nil || len(ids) != 2 || ids[0] > ids[1] {
+		t.Fatalf("ListTripRequirements = %v err=%v, want both waivers by id", ids, err)
+	}
+	if err := waivers.SetTripRequirements(ctx, tripID, []domain.WaiverID{rock.ID, missing}); !errors.Is(err, waiverrepoport.ErrNotFound) {
+		t.Fatalf("SetTripRequirements unknown err=%v, want ErrNotFound", err)
+	}
+	if ids, err := waivers.ListTripRequirements(ctx, tripID); err != nil || len(ids) != 2 {
+		t.Fatalf("failed SetTripRequirements changed the list: %v err=%v", ids, err)
+	}
+	if err := waivers.SetTripRequirements(ctx, tripID, []domain.WaiverID{rock.ID}); err != nil {
+		t.Fatalf("SetTripRequirements replace: %v", err)
+	}
+	if ids, err := waivers.ListTripRequirements(ctx, tripID); err != nil || len(ids) != 1 || ids[0] != rock.ID {
+		t.Fatalf("ListTripRequirements after replace = %v err=%v", ids, err)
+	}
+	if err := waivers.SetTripRequirements(ctx, tripID, nil); err != nil {
+		t.Fatalf("SetTripRequirements clear: %v", err)
+	}
+	if ids, err := waivers.ListTripRequirements(ctx, tripID); err != nil || len(ids) != 0 {
+		t.Fatalf("ListTripRequirements after clear = %v err=%v", ids, err)
+	}
+}
+
+func RunCommentRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newCommentRepo CommentRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	comments, cCleanup := newCommentRepo(t)
+	if cCleanup != nil {
+		t.Cleanup(cCleanup)
+	}
+
+	now := time.Unix(9_000, 0).UTC()
+	seedMember := func(name string) domain.MemberID {
+		t.Helper()
+		id := domain.MemberID(uuid.NewString())
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-comment-" + uuid.NewString()),
+			DisplayName: name,
+			Email:       uuid.NewString() + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("seed member: %v", err)
+		}
+		return id
+	}
+	organizer := seedMember("Organizer")
+	member := seedMember("Member")
+	seedTrip := func() domain.TripID {
+		t.Helper()
+		id := domain.TripID(uuid.NewString())
+		name := "Discussion Trip"
+		if err := trips.Create(ctx, triprepoport.Trip{
+			ID:                 id,
+			Status:             triprepoport.StatusDraft,
+			Name:               &name,
+			CreatorMemberID:    organizer,
+			OrganizerMemberIDs: []domain.MemberID{organizer},
+			DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}); err != nil {
+			t.Fatalf("Create trip: %v", err)
+		}
+		return id
+	}
+	tripA, tripB := seedTrip(), seedTrip()
+
+	if cs, err := comments.ListByTrip(ctx, tripA, nil, 0); err != nil || len(cs) != 0 {
+		t.Fatalf("ListByTrip empty = %+v err=%v", cs, err)
+	}
+	if _, err := comments.Get(ctx, domain.TripCommentID(uuid.NewString())); !errors.Is(err, commentrepoport.ErrNotFound) {
+		t.Fatalf("Get unknown err=%v, want ErrNotFound", err)
+	}
+
+	create := func(tripID domain.TripID, author domain.MemberID, parent *domain.TripCommentID, body string, at time.Time) commentrepoport.Comment {
+		t.Helper()
+		c := commentrepoport.Comment{
+			ID:             domain.TripCommentID(uuid.NewString()),
+			TripID:         tripID,
+			AuthorMemberID: author,
+			ParentID:       parent,
+			Body:           body,
+			CreatedAt:      at,
+		}
+		if err := comments.Create(ctx, c); err != nil {
+			t.Fatalf("Create %q: %v", body, err)
+		}
+		return c
+	}
+	question := create(tripA, member, nil, "Is the creek crossing passable?", now)
+	reply := create(tripA, organizer, &question.ID, "Yes, it was low last week.", now.Add(time.Minute))
+	other := create(tripA, member, nil, "Bringing a spare tire.", now.Add(time.Minute))
+	create(tripB, member, nil, "Different trip.", now)
+
+	if err := comments.Create(ctx, question); !errors.Is(err, commentrepoport.ErrAlreadyExists) {
+		t.Fatalf("Create duplicate err=%v, want ErrAlreadyExists", err)
+	}
+	missing := domain.TripCommentID(uuid.NewString())
+	orphan := commentrepoport.Comment{ID: domain.TripCommentID(uuid.NewString()), TripID: tripA, AuthorMemberID: member, ParentID: &missing, Body: "orphan", CreatedAt: now}
+	if err := comments.Create(ctx, orphan); !errors.Is(err, commentrepoport.ErrNotFound) {
+		t.Fatalf("Create with unknown parent err=%v, want ErrNotFound", err)
+	}
+
+	got, err := comments.Get(ctx, reply.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.TripID != tripA || got.AuthorMemberID != organizer || got.ParentID == nil || *got.ParentID != question.ID || got.Body != reply.Body || !got.CreatedAt.Equal(reply.CreatedAt) || got.EditedAt != nil || got.DeletedAt != nil || got.Pinned {
+		t.Fatalf("Get = %+v", got)
+	}
+
+	// Comments posted at the same instant are ordered by ID.
+	second, third := reply, other
+	if third.ID < second.ID {
+		second, third = third, second
+	}
+	all, err := comments.ListByTrip(ctx, tripA, nil, 0)
+	if err != nil {
+		t.Fatalf("ListByTrip: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != question.ID || all[1].ID != second.ID || all[2].ID != third.ID {
+		t.Fatalf("ListByTrip = %+v", all)
+	}
+	page, err := comments.ListByTrip(ctx, tripA, nil, 2)
+	if err != nil || len(page) != 2 || page[1].ID != second.ID {
+		t.Fatalf("ListByTrip first page = %+v err=%v", page, err)
+	}
+	page, err = comments.ListByTrip(ctx, tripA, &commentrepoport.Cursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}, 2)
+	if err != nil || len(page) != 1 || page[0].ID != third.ID {
+		t.Fatalf("ListByTrip second page = %+v err=%v", page, err)
+	}
+
+	edited := now.Add(time.Hour)
+	question.Body = "Is the creek crossing passable after the rain?"
+	question.EditedAt = &edited
+	question.Pinned = true
+	if err := comments.Update(ctx, question); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	pinned, err := comments.ListPinned(ctx, tripA)
+	if err != nil || len(pinned) != 1 || pinned[0].ID != question.ID || pinned[0].Body != question.Body || pinned[0].EditedAt == nil || !pinned[0].EditedAt.Equal(edited) {
+		t.Fatalf("ListPinned = %+v err=%v", pinned, err)
+	}
+	if pinned, err := comments.ListPinned(ctx, tripB); err != nil || len(pinned) != 0 {
+		t.Fatalf("ListPinned other trip = %+v err=%v", pinned, err)
+	}
+
+	deleted := now.Add(2 * time.Hour)
+	other.Body = ""
+	other.DeletedAt = &deleted
+	other.DeletedBy = organizer
+	if err := comments.Update(ctx, other); err != nil {
+		t.Fatalf("Update delete: %v", err)
+	}
+	got, err = comments.Get(ctx, other.ID)
+	if err != nil || got.Body != "" || got.DeletedAt == nil || !got.DeletedAt.Equal(deleted) || got.DeletedBy != organizer {
+		t.Fatalf("Get deleted = %+v err=%v", got, err)
+	}
+
+	if err := comments.Update(ctx, commentrepoport.Comment{ID: domain.TripCommentID(uuid.NewString()), Body: "nope"}); !errors.Is(err, commentrepoport.ErrNotFound) {
+		t.Fatalf("Update unknown err=%v, want ErrNotFound", err)
+	}
+}
+
+func RunAnnouncementRepo(t *testing.T, newMemberRepo MemberRepoFactory, newTripRepo TripRepoFactory, newAnnouncementRepo AnnouncementRepoFactory) {
+	t.Helper()
+	ctx := context.Background()
+
+	members, mCleanup := newMemberRepo(t)
+	if mCleanup != nil {
+		t.Cleanup(mCleanup)
+	}
+	trips, tCleanup := newTripRepo(t)
+	if tCleanup != nil {
+		t.Cleanup(tCleanup)
+	}
+	announcements, aCleanup := newAnnouncementRepo(t)
+	if aCleanup != nil {
+		t.Cleanup(aCleanup)
+	}
+
+	now := time.Unix(10_000, 0).UTC()
+	seedMember := func(name string) domain.MemberID {
+		t.Helper()
+		id := domain.MemberID(uuid.NewString())
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-announcement-" + uuid.NewString()),
+			DisplayName: name,
+			Email:       uuid.NewString() + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("seed member: %v", err)
+		}
+		return id
+	}
+	organizer := seedMember("Organizer")
+	alice := seedMember("Alice")
+	bob := seedMember("Bob")
+	seedTrip := func() domain.TripID {
+		t.Helper()
+		id := domain.TripID(uuid.NewString())
+		name := "Announced Trip"
+		if err := trips.Create(ctx, triprepoport.Trip{
+			ID:                 id,
+			Status:             triprepoport.StatusDraft,
+			Name:               &name,
+			CreatorMemberID:    organizer,
+			OrganizerMemberIDs: []domain.MemberID{organizer},
+			DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}); err != nil {
+			t.Fatalf("Create trip: %v", err)
+		}
+		return id
+	}
+	tripA, tripB := seedTrip(), seedTrip()
+
+	if as, err := announcements.ListByTrip(ctx, tripA); err != nil || len(as) != 0 {
+		t.Fatalf("ListByTrip empty = %+v err=%v", as, err)
+	}
+	if _, err := announcements.Get(ctx, domain.TripAnnouncementID(uuid.NewString())); !errors.Is(err, announcementrepoport.ErrNotFound) {
+		t.Fatalf("Get unknown err=%v, want ErrNotFound", err)
+	}
+
+	create := func(tripID domain.TripID, subject string, audience domain.AnnouncementAudience, at time.Time, recipients ...domain.MemberID) announcementrepoport.Announcement {
+		t.Helper()
+		a := announcementrepoport.Announcement{
+			ID:             domain.TripAnnouncementID(uuid.NewString()),
+			TripID:         tripID,
+			AuthorMemberID: organizer,
+			Subject:        subject,
+			Body:           subject + " body",
+			Audience:       audience,
+			CreatedAt:      at,
+		}
+		if err := announcements.Create(ctx, a, recipients); err != nil {
+			t.Fatalf("Create %q: %v", subject, err)
+		}
+		return a
+	}
+	first := create(tripA, "Meet at 7", domain.AudienceAttendees, now, alice, bob)
+	second := create(tripA, "Spots open", domain.AudienceNotAttending, now.Add(time.Hour))
+	create(tripB, "Other trip", domain.AudienceEveryone, now, alice)
+
+	if err := announcements.Create(ctx, first, nil); !errors.Is(err, announcementrepoport.ErrAlreadyExists) {
+		t.Fatalf("Create duplicate err=%v, want ErrAlreadyExists", err)
+	}
+
+	got, err := announcements.Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.TripID != tripA || got.AuthorMemberID != organizer || got.Subject != first.Subject || got.Body != first.Body || got.Audience != domain.AudienceAttendees || !got.CreatedAt.Equal(now) {
+		t.Fatalf("Get = %+v", got)
+	}
+	list, err := announcements.ListByTrip(ctx, tripA)
+	if err != nil || len(list) != 2 || list[0].ID != second.ID || list[1].ID != first.ID {
+		t.Fatalf("ListByTrip = %+v err=%v, want newest first", list, err)
+	}
+
+	ds, err := announcements.ListDeliveries(ctx, first.ID)
+	if err != nil || len(ds) != 2 {
+		t.Fatalf("ListDeliveries = %+v err=%v", ds, err)
+	}
+	if ds[0].MemberID > ds[1].MemberID {
+		t.Fatalf("ListDeliveries not ordered by member: %+v", ds)
+	}
+	for _, d := range ds {
+		if d.AnnouncementID != first.ID || d.Status != announcementrepoport.StatusPending || d.AttemptedAt != nil || d.Error != "" {
+			t.Fatalf("new delivery = %+v, want PENDING", d)
+		}
+	}
+	if ds, err := announcements.ListDeliveries(ctx, second.ID); err != nil || len(ds) != 0 {
+		t.Fatalf("ListDeliveries without recipients = %+v err=%v", ds, err)
+	}
+
+	attempted := now.Add(time.Minute)
+	if err := announcements.UpdateDelivery(ctx, announcementrepoport.Delivery{AnnouncementID: first.ID, MemberID: alice, Status: announcementrepoport.StatusSent, AttemptedAt: &attempted}); err != nil {
+		t.Fatalf("UpdateDelivery sent: %v", err)
+	}
+	if err := announcements.UpdateDelivery(ctx, announcementrepoport.Delivery{AnnouncementID: first.ID, MemberID: bob, Status: announcementrepoport.StatusFailed, Error: "mailbox full", AttemptedAt: &attempted}); err != nil {
+		t.Fatalf("UpdateDelivery failed: %v", err)
+	}
+	ds, err = announcements.ListDeliveries(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	byMember := map[domain.MemberID]announcementrepoport.Delivery{}
+	for _, d := range ds {
+		byMember[d.MemberID] = d
+	}
+	if d := byMember[alice]; d.Status != announcementrepoport.StatusSent || d.AttemptedAt == nil || !d.AttemptedAt.Equal(attempted) {
+		t.Fatalf("alice delivery = %+v", d)
+	}
+	if d := byMember[bob]; d.Status != announcementrepoport.StatusFailed || d.Error != "mailbox full" {
+		t.Fatalf("bob delivery = %+v", d)
+	}
+
+	if err := announcements.UpdateDelivery(ctx, announcementrepoport.Delivery{AnnouncementID: second.ID, MemberID: alice, Status: announcementrepoport.StatusSent, AttemptedAt: &attempted}); !errors.Is(err, announcementrepoport.ErrNotFound) {
+		t.Fatalf("UpdateDelivery non-recipient err=%v, want ErrNotFound", err)
+	}
+
+	// Only one sender claims a delivery; sent deliveries are never claimed.
+	claimAt := attempted.Add(time.Minute)
+	if _, claimed, err := announcements.ClaimDelivery(ctx, first.ID, alice, claimAt, claimAt); err != nil || claimed {
+		t.Fatalf("ClaimDelivery sent claimed=%v err=%v, want not claimed", claimed, err)
+	}
+	d, claimed, err := announcements.ClaimDelivery(ctx, first.ID, bob, claimAt, claimAt)
+	if err != nil || !claimed || d.Status != announcementrepoport.StatusSending || d.Error != "" || d.AttemptedAt == nil || !d.AttemptedAt.Equal(claimAt) || len(d.SentTo) != 0 {
+		t.Fatalf("ClaimDelivery failed = %+v claimed=%v err=%v", d, claimed, err)
+	}
+	if _, claimed, err := announcements.ClaimDelivery(ctx, first.ID, bob, claimAt, claimAt); err != nil || claimed {
+		t.Fatalf("ClaimDelivery held claimed=%v err=%v, want not claimed", claimed, err)
+	}
+	d.SentTo = []string{"bob@example.com"}
+	if err := announcements.UpdateDelivery(ctx, d); err != nil {
+		t.Fatalf("UpdateDelivery sending: %v", err)
+	}
+	// A stale claim is taken over and keeps the addresses already sent to.
+	d, claimed, err = announcements.ClaimDelivery(ctx, first.ID, bob, claimAt.Add(time.Minute), claimAt.Add(time.Second))
+	if err != nil || !claimed || d.Status != announcementrepoport.StatusSending || !slices.Equal(d.SentTo, []string{"bob@example.com"}) {
+		t.Fatalf("ClaimDelivery stale = %+v claimed=%v err=%v", d, claimed, err)
+	}
+	if _, _, err := announcements.ClaimDelivery(ctx, second.ID, alice, claimAt, claimAt); !errors.Is(err, announcementrepoport.ErrNotFound) {
+		t.Fatalf("ClaimDelivery non-recipient err=%v, want ErrNotFound", err)
+	}
+
+	// Service accounts post without a member author.
+	bot := announcementrepoport.Announcement{
+		ID:                   domain.TripAnnouncementID(uuid.NewString()),
+		TripID:               tripB,
+		AuthorServiceAccount: "discord-bot",
+		Subject:              "Weather",
+		Body:                 "Rain expected.",
+		Audience:             domain.AudienceEveryone,
+		CreatedAt:            now.Add(2 * time.Hour),
+	}
+	if err := announcements.Create(ctx, bot, []domain.MemberID{bob}); err != nil {
+		t.Fatalf("Create service-account announcement: %v", err)
+	}
+	got, err = announcements.Get(ctx, bot.ID)
+	if err != nil || got.AuthorMemberID != "" || got.AuthorServiceAccount != "discord-bot" {
+		t.Fatalf("Get service-account announcement = %+v err=%v", got, err)
+	}
+	if list, err := announcements.ListByTrip(ctx, tripB); err != nil || len(list) != 2 || list[0].ID != bot.ID {
+		t.Fatalf("ListByTrip with service-account announcement = %+v err=%v", list, err)
+	}
+
+	// Undelivered announcements have a PENDING delivery or a stale SENDING one, oldest first.
+	// Other tests may share the store, so only this test's announcements are checked.
+	undelivered := func(staleBefore time.Time) []domain.TripAnnouncementID {
+		t.Helper()
+		as, err := announcements.ListUndelivered(ctx, staleBefore, 1000)
+		if err != nil {
+			t.Fatalf("ListUndelivered: %v", err)
+		}
+		var ids []domain.TripAnnouncementID
+		for _, a := range as {
+			if a.ID == first.ID || a.ID == second.ID || a.ID == bot.ID {
+				ids = append(ids, a.ID)
+			}
+		}
+		return ids
+	}
+	heldUntil := claimAt.Add(time.Minute)
+	if got := undelivered(heldUntil); !slices.Equal(got, []domain.TripAnnouncementID{bot.ID}) {
+		t.Fatalf("ListUndelivered with a live claim = %v, want only %s", got, bot.ID)
+	}
+	if got := undelivered(heldUntil.Add(time.Second)); !slices.Equal(got, []domain.TripAnnouncementID{first.ID, bot.ID}) {
+		t.Fatalf("ListUndelivered with a stale claim = %v, want %s then %s", got, first.ID, bot.ID)
+	}
+	if as, err := announcements.ListUndelivered(ctx, heldUntil.Add(time.Second), 1); err != nil || len(as) != 1 {
+		t.Fatalf("ListUndelivered limit 1 = %+v err=%v", as, err)
+	}
 }
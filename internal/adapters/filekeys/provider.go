@@ -0,0 +1,82 @@
+// Package filekeys provides encryption keys from a local key file.
+//
+// The file holds one key per line as "<key-id> <base64 32-byte key>"; blank lines and lines
+// starting with # are ignored. The last key is the current one, so keys are rotated by
+// appending a new line and keeping the old ones for data they encrypted. Generate a key with
+// `openssl rand -base64 32`. Keep the file readable only by the API process.
+package filekeys
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+)
+
+// Provider is a keyprovider.Provider backed by a key file. Keys are read once, at construction.
+type Provider struct {
+	keys []keyprovider.Key
+}
+
+// NewProvider reads the key file at path.
+func NewProvider(path string) (*Provider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open key file: %w", err)
+	}
+	defer f.Close()
+
+	var keys []keyprovider.Key
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("key file line %d: want \"<key-id> <base64 key>\"", n)
+		}
+		secret, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(secret) != keyprovider.KeySize {
+			return nil, fmt.Errorf("key file line %d: key must be %d bytes, base64-encoded", n, keyprovider.KeySize)
+		}
+		if seen[fields[0]] {
+			return nil, fmt.Errorf("key file line %d: duplicate key id %q", n, fields[0])
+		}
+		seen[fields[0]] = true
+		keys = append(keys, keyprovider.Key{ID: fields[0], Secret: secret})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key file %s has no keys", path)
+	}
+	return &Provider{keys: keys}, nil
+}
+
+func (p *Provider) Current(ctx context.Context) (keyprovider.Key, error) {
+	_ = ctx
+	return cloneKey(p.keys[len(p.keys)-1]), nil
+}
+
+func (p *Provider) Get(ctx context.Context, id string) (keyprovider.Key, error) {
+	_ = ctx
+	for _, k := range p.keys {
+		if k.ID == id {
+			return cloneKey(k), nil
+		}
+	}
+	return keyprovider.Key{}, keyprovider.ErrKeyNotFound
+}
+
+func cloneKey(k keyprovider.Key) keyprovider.Key {
+	return keyprovider.Key{ID: k.ID, Secret: bytes.Clone(k.Secret)}
+}
@@ -0,0 +1,62 @@
+package filekeys
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+)
+
+func writeKeyFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestProvider_LastKeyIsCurrentAndOlderKeysResolve(t *testing.T) {
+	old := bytes.Repeat([]byte{1}, keyprovider.KeySize)
+	cur := bytes.Repeat([]byte{2}, keyprovider.KeySize)
+	path := writeKeyFile(t, "# emergency info keys\n"+
+		"2025 "+base64.StdEncoding.EncodeToString(old)+"\n\n"+
+		"2026 "+base64.StdEncoding.EncodeToString(cur)+"\n")
+
+	p, err := NewProvider(path)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	ctx := context.Background()
+	if k, err := p.Current(ctx); err != nil || k.ID != "2026" || !bytes.Equal(k.Secret, cur) {
+		t.Fatalf("Current = %q err=%v", k.ID, err)
+	}
+	if k, err := p.Get(ctx, "2025"); err != nil || !bytes.Equal(k.Secret, old) {
+		t.Fatalf("Get(2025) = %q err=%v", k.ID, err)
+	}
+	if _, err := p.Get(ctx, "2024"); !errors.Is(err, keyprovider.ErrKeyNotFound) {
+		t.Fatalf("Get(unknown) err=%v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestProvider_RejectsBadFiles(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{3}, keyprovider.KeySize))
+	for name, content := range map[string]string{
+		"empty":     "# nothing here\n",
+		"short key": "k1 " + base64.StdEncoding.EncodeToString([]byte("too short")) + "\n",
+		"no id":     key + "\n",
+		"duplicate": "k1 " + key + "\nk1 " + key + "\n",
+	} {
+		if _, err := NewProvider(writeKeyFile(t, content)); err == nil {
+			t.Fatalf("%s: NewProvider succeeded, want error", name)
+		}
+	}
+	if _, err := NewProvider(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatalf("missing file: NewProvider succeeded, want error")
+	}
+}
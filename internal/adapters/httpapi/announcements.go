@@ -0,0 +1,197 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Trip announcement routes are out-of-spec: organizers post announcements to an audience and
+// follow per-recipient delivery; anyone who can see the trip can list them.
+const (
+	// TripAnnouncementsPath lists (GET) or posts (POST; organizers, or service accounts with
+	// the announcements:write scope) announcements.
+	TripAnnouncementsPath = "/trips/{tripId}/announcements"
+	// TripAnnouncementDeliveriesPath returns an announcement's delivery report; organizers only.
+	TripAnnouncementDeliveriesPath = "/trips/{tripId}/announcements/{announcementId}/deliveries"
+	// TripAnnouncementResendPath retries unsent deliveries (POST); organizers only.
+	TripAnnouncementResendPath = "/trips/{tripId}/announcements/{announcementId}/resend"
+)
+
+// TripAnnouncements is the trips use-case surface needed by the trip announcement routes.
+type TripAnnouncements interface {
+	ListTripAnnouncements(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.TripAnnouncement, error)
+	PostTripAnnouncement(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.TripAnnouncementInput) (domain.TripAnnouncementReport, error)
+	PostTripAnnouncementAsServiceAccount(ctx context.Context, serviceAccount string, tripID domain.TripID, in trips.TripAnnouncementInput) (domain.TripAnnouncementReport, error)
+	GetTripAnnouncementReport(ctx context.Context, caller domain.MemberID, tripID domain.TripID, id domain.TripAnnouncementID) (domain.TripAnnouncementReport, error)
+	ResendTripAnnouncement(ctx context.Context, caller domain.MemberID, tripID domain.TripID, id domain.TripAnnouncementID) (domain.TripAnnouncementReport, error)
+}
+
+type tripAnnouncementJSON struct {
+	AnnouncementID string `json:"announcementId"`
+	// Author is null and ServiceAccount names the API key for service-account posts.
+	Author         *memberRefJSON `json:"author"`
+	ServiceAccount *string        `json:"serviceAccount"`
+	Subject        string         `json:"subject"`
+	Body           string         `json:"body"`
+	Audience       string         `json:"audience"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+type announcementDeliveryJSON struct {
+	Recipient   memberRefJSON `json:"recipient"`
+	Status      string        `json:"status"`
+	Error       *string       `json:"error"`
+	AttemptedAt *time.Time    `json:"attemptedAt"`
+}
+
+type announcementDeliverySummaryJSON struct {
+	Pending int `json:"pending"`
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+}
+
+func tripAnnouncementToJSON(a domain.TripAnnouncement) tripAnnouncementJSON {
+	out := tripAnnouncementJSON{
+		AnnouncementID: string(a.ID),
+		Subject:        a.Subject,
+		Body:           a.Body,
+		Audience:       string(a.Audience),
+		CreatedAt:      a.CreatedAt.UTC(),
+	}
+	if a.ServiceAccount != "" {
+		name := a.ServiceAccount
+		out.ServiceAccount = &name
+	} else {
+		author := memberRefToJSON(a.Author)
+		out.Author = &author
+	}
+	return out
+}
+
+func tripAnnouncementsToJSON(as []domain.TripAnnouncement) []tripAnnouncementJSON {
+	out := make([]tripAnnouncementJSON, 0, len(as))
+	for _, a := range as {
+		out = append(out, tripAnnouncementToJSON(a))
+	}
+	return out
+}
+
+func tripAnnouncementReportToJSON(r domain.TripAnnouncementReport) map[string]any {
+	ds := make([]announcementDeliveryJSON, 0, len(r.Deliveries))
+	for _, d := range r.Deliveries {
+		dj := announcementDeliveryJSON{
+			Recipient:   memberRefToJSON(d.Recipient),
+			Status:      string(d.Status),
+			AttemptedAt: d.AttemptedAt,
+		}
+		if d.Error != "" {
+			e := d.Error
+			dj.Error = &e
+		}
+		ds = append(ds, dj)
+	}
+	return map[string]any{
+		"announcement": tripAnnouncementToJSON(r.TripAnnouncement),
+		"summary":      announcementDeliverySummaryJSON(r.Summary),
+		"deliveries":   ds,
+	}
+}
+
+// mountTripAnnouncements mounts the announcement routes. Posting and resending mail people,
+// so both honour Idempotency-Key when idempotency is configured.
+func mountTripAnnouncements(r chi.Router, m MemberResolver, ta TripAnnouncements, idempotency func(http.Handler) http.Handler) {
+	send := r
+	if idempotency != nil {
+		send = r.With(idempotency)
+	}
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+	announcementID := func(req *http.Request) domain.TripAnnouncementID {
+		return domain.TripAnnouncementID(chi.URLParam(req, "announcementId"))
+	}
+
+	r.Get(TripAnnouncementsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		as, err := ta.ListTripAnnouncements(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"announcements": tripAnnouncementsToJSON(as)})
+	}))
+
+	decodeAnnouncement := func(w http.ResponseWriter, req *http.Request) (trips.TripAnnouncementInput, bool) {
+		var body struct {
+			Subject  string `json:"subject"`
+			Body     string `json:"body"`
+			Audience string `json:"audience"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return trips.TripAnnouncementInput{}, false
+		}
+		return trips.TripAnnouncementInput{
+			Subject:  body.Subject,
+			Body:     body.Body,
+			Audience: domain.AnnouncementAudience(body.Audience),
+		}, true
+	}
+	writeReport := func(w http.ResponseWriter, req *http.Request, rep domain.TripAnnouncementReport, err error) {
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, tripAnnouncementReportToJSON(rep))
+	}
+	postAsMember := withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		in, ok := decodeAnnouncement(w, req)
+		if !ok {
+			return
+		}
+		rep, err := ta.PostTripAnnouncement(req.Context(), me.ID, tripID(req), in)
+		writeReport(w, req, rep, err)
+	})
+	send.Post(TripAnnouncementsPath, func(w http.ResponseWriter, req *http.Request) {
+		key, isService := ServiceAccountFromContext(req.Context())
+		if !isService {
+			postAsMember(w, req)
+			return
+		}
+		if !key.HasScope(domain.APIKeyScopeAnnouncementsWrite) {
+			writeOASError(w, req, http.StatusForbidden, "FORBIDDEN", "api key is missing a required scope", map[string]any{
+				"operation":     "POST " + TripAnnouncementsPath,
+				"requiredScope": string(domain.APIKeyScopeAnnouncementsWrite),
+			})
+			return
+		}
+		in, ok := decodeAnnouncement(w, req)
+		if !ok {
+			return
+		}
+		rep, err := ta.PostTripAnnouncementAsServiceAccount(req.Context(), key.Name, tripID(req), in)
+		writeReport(w, req, rep, err)
+	})
+
+	r.Get(TripAnnouncementDeliveriesPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		rep, err := ta.GetTripAnnouncementReport(req.Context(), me.ID, tripID(req), announcementID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, tripAnnouncementReportToJSON(rep))
+	}))
+
+	send.Post(TripAnnouncementResendPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		rep, err := ta.ResendTripAnnouncement(req.Context(), me.ID, tripID(req), announcementID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, tripAnnouncementReportToJSON(rep))
+	}))
+}
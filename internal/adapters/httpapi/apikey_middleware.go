@@ -0,0 +1,88 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/apikeys"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// APIKeyScheme is the Authorization scheme used by service accounts: `Authorization: ApiKey <token>`.
+// It is deliberately distinct from `Bearer` so member JWTs and API keys can never be confused.
+const APIKeyScheme = "ApiKey"
+
+// apiKeyOperationScopes lists the operations service accounts may call and the scope each requires.
+// Operations not listed here are member-only.
+var apiKeyOperationScopes = map[string]domain.APIKeyScope{
+	"ListVisibleTripsForMember": domain.APIKeyScopeTripsRead,
+	"GetTripDetails":            domain.APIKeyScopeTripsRead,
+	"GetTripRSVPSummary":        domain.APIKeyScopeRSVPSummariesRead,
+}
+
+// NewAPIKeyAuthMiddleware authenticates `Authorization: ApiKey <token>` requests as service accounts.
+//
+// Requests using any other scheme are handed to fallback (typically the member JWT middleware).
+// On success the key is stored in request context; its use is recorded in the key's audit trail.
+func NewAPIKeyAuthMiddleware(keys *apikeys.Service, fallback func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		other := next
+		if fallback != nil {
+			other = fallback(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			if isPublicPath(r.URL.Path) || !strings.EqualFold(scheme, APIKeyScheme) {
+				other.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := keys.Authenticate(r.Context(), token, apikeys.Usage{
+				Method:   r.Method,
+				Path:     r.URL.Path,
+				ClientIP: clientIP(r),
+			})
+			if err != nil {
+				if ae := (*apikeys.Error)(nil); errors.As(err, &ae) && ae.Status == http.StatusUnauthorized {
+					writeOASError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", ae.Message, nil)
+					return
+				}
+				writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithServiceAccount(r.Context(), key)))
+		})
+	}
+}
+
+// newAPIKeyScopeMiddleware rejects service-account requests for operations their key is not scoped for.
+// Member requests pass through untouched.
+func newAPIKeyScopeMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		required, allowed := apiKeyOperationScopes[operationID]
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			key, ok := ServiceAccountFromContext(ctx)
+			if !ok {
+				return f(ctx, w, r, request)
+			}
+			if !allowed {
+				writeOASError(w, r, http.StatusForbidden, "FORBIDDEN", "operation is not available to service accounts", map[string]any{
+					"operation": operationID,
+				})
+				return nil, nil
+			}
+			if !key.HasScope(required) {
+				writeOASError(w, r, http.StatusForbidden, "FORBIDDEN", "api key is missing a required scope", map[string]any{
+					"operation":     operationID,
+					"requiredScope": string(required),
+				})
+				return nil, nil
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
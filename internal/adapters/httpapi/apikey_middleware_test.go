@@ -0,0 +1,220 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	memannouncementrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/announcementrepo"
+	memapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/apikeyrepo"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memnotifier "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/notifier"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/apikeys"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func newTestAPIKeyRouter(t *testing.T) (http.Handler, *apikeys.Service) {
+	t.Helper()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	verifiedAt := clk.Now()
+	memberRepo := memmemberrepo.NewRepo()
+	if err := memberRepo.Create(context.Background(), memberrepo.Member{
+		ID: "m1", Subject: "organizer", DisplayName: "Org", Email: "org@example.com", EmailVerifiedAt: &verifiedAt, IsActive: true,
+	}); err != nil {
+		t.Fatalf("seed member: %v", err)
+	}
+	tripRepo := memtriprepo.NewRepo()
+	for _, tr := range []triprepo.Trip{
+		{ID: "trip-published", Status: triprepo.StatusPublished, Name: ptr("Moab"), CreatorMemberID: "m1", OrganizerMemberIDs: []domain.MemberID{"m1"}},
+		{ID: "trip-draft", Status: triprepo.StatusDraft, Name: ptr("Secret"), CreatorMemberID: "m1", OrganizerMemberIDs: []domain.MemberID{"m1"}, DraftVisibility: triprepo.DraftVisibilityPublic},
+	} {
+		if err := tripRepo.Create(context.Background(), tr); err != nil {
+			t.Fatalf("seed trip: %v", err)
+		}
+	}
+	memberSvc := members.NewService(memberRepo, clk)
+	tripSvc := trips.NewServiceWithOptions(tripRepo, memberRepo, memrsvprepo.NewRepo(), trips.Options{
+		Announcements: memannouncementrepo.NewRepo(),
+		Notifier:      memnotifier.NewRecorder(),
+		Clock:         clk,
+	})
+	keySvc := apikeys.NewService(memapikeyrepo.NewRepo(), clk)
+
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		AuthMiddleware:        NewAPIKeyAuthMiddleware(keySvc, NewDevAuthMiddleware("")),
+		Members:               memberSvc,
+		TripAnnouncements:     tripSvc,
+		IdempotencyMiddleware: NewIdempotencyMiddleware(memidempotency.NewStoreWithClock(clk), clk, 24*time.Hour),
+	})
+	return h, keySvc
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func issueTestKey(t *testing.T, svc *apikeys.Service, scopes ...domain.APIKeyScope) apikeys.Created {
+	t.Helper()
+	created, err := svc.Create(context.Background(), apikeys.CreateInput{Name: "bot", Scopes: scopes, CreatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Create key: %v", err)
+	}
+	return created
+}
+
+func doWithAPIKey(h http.Handler, method string, path string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "ApiKey "+token)
+	req.RemoteAddr = "192.0.2.7:5555"
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAPIKey_TripsRead_SeesOnlyPublishedTrips(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestAPIKeyRouter(t)
+	created := issueTestKey(t, svc, domain.APIKeyScopeTripsRead)
+
+	rr := doWithAPIKey(h, http.MethodGet, "/trips", created.Token)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var list oas.ListVisibleTripsForMember200JSONResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(list.Trips) != 1 || list.Trips[0].TripId != "trip-published" {
+		t.Fatalf("trips=%+v", list.Trips)
+	}
+
+	rr = doWithAPIKey(h, http.MethodGet, "/trips/trip-published", created.Token)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("details status=%d body=%s", rr.Code, rr.Body.String())
+	}
+	rr = doWithAPIKey(h, http.MethodGet, "/trips/trip-draft", created.Token)
+	requireOASErrorCode(t, rr, http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	// Usage is audited with the request path and client address.
+	usage, err := svc.ListUsage(context.Background(), created.Key.ID)
+	if err != nil {
+		t.Fatalf("ListUsage: %v", err)
+	}
+	if len(usage) != 3 || usage[0].Path != "/trips/trip-draft" || usage[0].ClientIP != "192.0.2.7" {
+		t.Fatalf("usage=%+v", usage)
+	}
+}
+
+func TestAPIKey_ScopeEnforcement(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestAPIKeyRouter(t)
+	tripsOnly := issueTestKey(t, svc, domain.APIKeyScopeTripsRead)
+	rsvps := issueTestKey(t, svc, domain.APIKeyScopeRSVPSummariesRead)
+
+	rr := doWithAPIKey(h, http.MethodGet, "/trips/trip-published/rsvps", tripsOnly.Token)
+	requireOASErrorCode(t, rr, http.StatusForbidden, "FORBIDDEN")
+
+	rr = doWithAPIKey(h, http.MethodGet, "/trips/trip-published/rsvps", rsvps.Token)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("rsvp summary status=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	// Member-only operations are never available to service accounts.
+	rr = doWithAPIKey(h, http.MethodGet, "/members", tripsOnly.Token)
+	requireOASErrorCode(t, rr, http.StatusForbidden, "FORBIDDEN")
+}
+
+func TestAPIKey_AnnouncementsWrite(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestAPIKeyRouter(t)
+	tripsOnly := issueTestKey(t, svc, domain.APIKeyScopeTripsRead)
+	writer := issueTestKey(t, svc, domain.APIKeyScopeAnnouncementsWrite)
+	post := func(path, token, idempotencyKey string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"subject":"Weather","body":"Rain expected.","audience":"EVERYONE"}`))
+		req.Header.Set("Authorization", "ApiKey "+token)
+		req.Header.Set("Content-Type", "application/json")
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := post("/trips/trip-published/announcements", tripsOnly.Token, "")
+	requireOASErrorCode(t, rr, http.StatusForbidden, "FORBIDDEN")
+	if !strings.Contains(rr.Body.String(), `"requiredScope":"announcements:write"`) {
+		t.Fatalf("forbidden body=%s", rr.Body.String())
+	}
+	requireOASErrorCode(t, post("/trips/trip-draft/announcements", writer.Token, ""), http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	// The key posts under its own name; organizers are recipients like everyone else. A retry
+	// with the same Idempotency-Key replays the first response.
+	first := post("/trips/trip-published/announcements", writer.Token, "weather-1")
+	if first.Code != http.StatusCreated {
+		t.Fatalf("post status=%d body=%s", first.Code, first.Body.String())
+	}
+	var report struct {
+		Announcement struct {
+			Author         *struct{} `json:"author"`
+			ServiceAccount *string   `json:"serviceAccount"`
+		} `json:"announcement"`
+		Summary struct {
+			Sent int `json:"sent"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(first.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Announcement.Author != nil || report.Announcement.ServiceAccount == nil || *report.Announcement.ServiceAccount != "bot" || report.Summary.Sent != 1 {
+		t.Fatalf("report=%s", first.Body.String())
+	}
+	if replay := post("/trips/trip-published/announcements", writer.Token, "weather-1"); replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Fatalf("replay status=%d body=%s", replay.Code, replay.Body.String())
+	}
+}
+
+func TestAPIKey_InvalidOrRevoked_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestAPIKeyRouter(t)
+	created := issueTestKey(t, svc, domain.APIKeyScopeTripsRead)
+
+	rr := doWithAPIKey(h, http.MethodGet, "/trips", "ebo_nope_nope")
+	requireOASErrorCode(t, rr, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	if _, err := svc.Revoke(context.Background(), created.Key.ID, "admin"); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	rr = doWithAPIKey(h, http.MethodGet, "/trips", created.Token)
+	requireOASErrorCode(t, rr, http.StatusUnauthorized, "UNAUTHORIZED")
+}
+
+func TestAPIKey_OtherSchemesFallBack(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestAPIKeyRouter(t)
+
+	// Without an ApiKey header the fallback (dev auth) handles the request.
+	req := httptest.NewRequest(http.MethodGet, "/trips", nil)
+	req.Header.Set("X-Debug-Subject", "alice")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	requireOASErrorCode(t, rr, http.StatusUnauthorized, "MEMBER_NOT_PROVISIONED")
+}
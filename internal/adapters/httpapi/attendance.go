@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Attendance routes are out-of-spec: organizers check members in on the day of a published
+// trip and review how reliably attendees show up.
+const (
+	// TripAttendancePath returns the trip's check-in sheet (GET).
+	TripAttendancePath = "/trips/{tripId}/attendance"
+	// TripAttendanceMemberPath marks (PUT) a member PRESENT or ABSENT.
+	TripAttendanceMemberPath = "/trips/{tripId}/attendance/{memberId}"
+	// TripAttendanceReliabilityPath lists (GET) attendance stats for the trip's attendees.
+	TripAttendanceReliabilityPath = "/trips/{tripId}/attendance/reliability"
+)
+
+// TripAttendance is the trips use-case surface needed by the attendance routes.
+type TripAttendance interface {
+	CheckInMember(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, status domain.AttendanceStatus) (domain.AttendanceRecord, error)
+	GetTripAttendance(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.TripAttendance, error)
+	GetTripAttendanceReliability(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.MemberReliability, error)
+}
+
+type attendanceSummaryJSON struct {
+	Present      int `json:"present"`
+	Absent       int `json:"absent"`
+	WalkUps      int `json:"walkUps"`
+	NotCheckedIn int `json:"notCheckedIn"`
+}
+
+type attendanceRecordJSON struct {
+	Member      memberRefJSON `json:"member"`
+	Status      string        `json:"status"`
+	WalkUp      bool          `json:"walkUp"`
+	CheckedInAt *time.Time    `json:"checkedInAt"`
+	RecordedBy  *string       `json:"recordedBy"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+type memberReliabilityJSON struct {
+	Member          memberRefJSON `json:"member"`
+	Attended        int           `json:"attended"`
+	NoShows         int           `json:"noShows"`
+	WalkUps         int           `json:"walkUps"`
+	ShowRatePercent *int          `json:"showRatePercent"`
+}
+
+func attendanceSummaryToJSON(s domain.AttendanceSummary) attendanceSummaryJSON {
+	return attendanceSummaryJSON{Present: s.Present, Absent: s.Absent, WalkUps: s.WalkUps, NotCheckedIn: s.NotCheckedIn}
+}
+
+func attendanceRecordToJSON(r domain.AttendanceRecord) attendanceRecordJSON {
+	out := attendanceRecordJSON{
+		Member:      memberRefToJSON(r.Member),
+		Status:      string(r.Status),
+		WalkUp:      r.WalkUp,
+		CheckedInAt: r.CheckedInAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.RecordedBy != "" {
+		by := string(r.RecordedBy)
+		out.RecordedBy = &by
+	}
+	return out
+}
+
+func mountTripAttendance(r chi.Router, m MemberResolver, a TripAttendance) {
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+
+	r.Get(TripAttendancePath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		sheet, err := a.GetTripAttendance(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		records := make([]attendanceRecordJSON, 0, len(sheet.Records))
+		for _, rec := range sheet.Records {
+			records = append(records, attendanceRecordToJSON(rec))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"attendance": map[string]any{
+			"checkInOpen": sheet.CheckInOpen,
+			"summary":     attendanceSummaryToJSON(sheet.Summary),
+			"records":     records,
+		}})
+	}))
+
+	r.Get(TripAttendanceReliabilityPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		stats, err := a.GetTripAttendanceReliability(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		out := make([]memberReliabilityJSON, 0, len(stats))
+		for _, s := range stats {
+			out = append(out, memberReliabilityJSON{
+				Member:          memberRefToJSON(s.Member),
+				Attended:        s.Attended,
+				NoShows:         s.NoShows,
+				WalkUps:         s.WalkUps,
+				ShowRatePercent: s.ShowRatePercent,
+			})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"members": out})
+	}))
+
+	r.Put(TripAttendanceMemberPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		rec, err := a.CheckInMember(req.Context(), me.ID, tripID(req), domain.MemberID(chi.URLParam(req, "memberId")), domain.AttendanceStatus(body.Status))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"record": attendanceRecordToJSON(rec)})
+	}))
+}
@@ -1,20 +1,23 @@
 package httpapi
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
 )
 
 // NewAuthMiddleware enforces Authorization: Bearer <JWT> for all in-spec endpoints.
 //
-// On success, it stores the authenticated subjectID (JWT `sub`) in request context.
+// On success, it stores the authenticated subjectID (JWT `sub`) and the verified issuer
+// (JWT `iss`) in request context; with several trusted issuers, subjects are scoped per issuer.
 func NewAuthMiddleware(v *jwtverifier.Verifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Health endpoint is deliberately out-of-spec and unauthenticated.
-			if r.URL.Path == "/healthz" {
+			// Health and email-confirmation endpoints are deliberately out-of-spec and unauthenticated.
+			if isPublicPath(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -35,17 +38,21 @@ func NewAuthMiddleware(v *jwtverifier.Verifier) func(http.Handler) http.Handler
 				return
 			}
 
-			sub, err := v.Verify(r.Context(), raw)
+			id, err := v.VerifyIdentity(r.Context(), raw)
 			if err != nil {
 				writeOASError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "invalid token", nil)
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(WithSubject(r.Context(), sub)))
+			ctx := authctx.WithIssuer(WithSubject(r.Context(), id.Subject), id.Issuer)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// debugSubjectHeader carries the caller's subject under dev auth.
+const debugSubjectHeader = "X-Debug-Subject"
+
 // NewDevAuthMiddleware is a local/dev-only auth shim.
 //
 // It accepts an explicit subject via X-Debug-Subject and stores it in request context.
@@ -54,15 +61,22 @@ func NewAuthMiddleware(v *jwtverifier.Verifier) func(http.Handler) http.Handler
 // This is intended for local Docker workflows where standing up an OIDC provider + JWKS
 // is overkill. Do NOT use this in production deployments.
 func NewDevAuthMiddleware(defaultSubject string) func(http.Handler) http.Handler {
+	return NewDevAuthMiddlewareWithIssuer(defaultSubject, "")
+}
+
+// NewDevAuthMiddlewareWithIssuer is NewDevAuthMiddleware that also stores issuer (when set) in
+// request context, so subjects are scoped like verified JWTs and logins linked through
+// NewDevIdentityTokenVerifier(issuer) resolve.
+func NewDevAuthMiddlewareWithIssuer(defaultSubject, issuer string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Health endpoint is deliberately out-of-spec and unauthenticated.
-			if r.URL.Path == "/healthz" {
+			// Health and email-confirmation endpoints are deliberately out-of-spec and unauthenticated.
+			if isPublicPath(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			sub := strings.TrimSpace(r.Header.Get("X-Debug-Subject"))
+			sub := strings.TrimSpace(r.Header.Get(debugSubjectHeader))
 			if sub == "" {
 				sub = strings.TrimSpace(defaultSubject)
 			}
@@ -71,7 +85,28 @@ func NewDevAuthMiddleware(defaultSubject string) func(http.Handler) http.Handler
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(WithSubject(r.Context(), sub)))
+			ctx := WithSubject(r.Context(), sub)
+			if issuer != "" {
+				ctx = authctx.WithIssuer(ctx, issuer)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// NewDevIdentityTokenVerifier is the dev-auth counterpart of the JWT verifier for linking
+// logins: any non-empty token is taken as a subject under issuer, as X-Debug-Subject is.
+// Do NOT use this in production deployments.
+func NewDevIdentityTokenVerifier(issuer string) IdentityTokenVerifier {
+	return devIdentityTokens{issuer: issuer}
+}
+
+type devIdentityTokens struct{ issuer string }
+
+func (d devIdentityTokens) VerifyIdentity(_ context.Context, token string) (jwtverifier.Identity, error) {
+	sub := strings.TrimSpace(token)
+	if sub == "" {
+		return jwtverifier.Identity{}, jwtverifier.ErrUnauthorized
+	}
+	return jwtverifier.Identity{Issuer: d.issuer, Subject: sub}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwks_testutil"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/config"
@@ -31,6 +32,12 @@ func (s authProbeServer) ListMembers(ctx context.Context, _ oas.ListMembersReque
 		er.Error.Message = "subject missing from context"
 		return oas.ListMembers500JSONResponse{InternalErrorJSONResponse: oas.InternalErrorJSONResponse(er)}, nil
 	}
+	if iss, ok := authctx.IssuerFromContext(ctx); !ok || iss != "test-iss" {
+		er := notImplementedError()
+		er.Error.Code = "MISSING_ISSUER"
+		er.Error.Message = "verified issuer missing from context"
+		return oas.ListMembers500JSONResponse{InternalErrorJSONResponse: oas.InternalErrorJSONResponse(er)}, nil
+	}
 	return oas.ListMembers200JSONResponse{Members: []oas.MemberDirectoryEntry{}}, nil
 }
 
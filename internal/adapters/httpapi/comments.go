@@ -0,0 +1,168 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Trip comment routes are out-of-spec: a discussion thread per trip, readable by anyone who
+// can see the trip. Authors edit and delete their own comments; organizers delete and pin any.
+const (
+	// TripCommentsPath lists (GET; ?cursor=&limit=) or posts (POST) comments on a trip.
+	TripCommentsPath = "/trips/{tripId}/comments"
+	// TripCommentPath edits (PATCH; author only) or deletes (DELETE) a comment.
+	TripCommentPath = "/trips/{tripId}/comments/{commentId}"
+	// TripCommentPinPath pins (PUT) or unpins (DELETE) a top-level comment; organizers only.
+	TripCommentPinPath = "/trips/{tripId}/comments/{commentId}/pin"
+
+	// CommentsCursorQuery and CommentsLimitQuery page through TripCommentsPath.
+	CommentsCursorQuery = "cursor"
+	CommentsLimitQuery  = "limit"
+)
+
+// TripComments is the trips use-case surface needed by the trip comment routes.
+type TripComments interface {
+	ListTripComments(ctx context.Context, caller domain.MemberID, tripID domain.TripID, cursor string, limit int) (domain.TripCommentPage, error)
+	PostTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.PostTripCommentInput) (domain.TripComment, error)
+	EditTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID, body string) (domain.TripComment, error)
+	DeleteTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID) error
+	SetTripCommentPinned(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID, pinned bool) (domain.TripComment, error)
+}
+
+type tripCommentJSON struct {
+	CommentID string        `json:"commentId"`
+	Author    memberRefJSON `json:"author"`
+	ParentID  *string       `json:"parentId"`
+	Body      string        `json:"body"`
+	Pinned    bool          `json:"pinned"`
+	Deleted   bool          `json:"deleted"`
+	CreatedAt time.Time     `json:"createdAt"`
+	EditedAt  *time.Time    `json:"editedAt"`
+}
+
+func tripCommentToJSON(c domain.TripComment) tripCommentJSON {
+	out := tripCommentJSON{
+		CommentID: string(c.ID),
+		Author:    memberRefToJSON(c.Author),
+		Body:      c.Body,
+		Pinned:    c.Pinned,
+		Deleted:   c.DeletedAt != nil,
+		CreatedAt: c.CreatedAt.UTC(),
+		EditedAt:  c.EditedAt,
+	}
+	if c.ParentID != nil {
+		id := string(*c.ParentID)
+		out.ParentID = &id
+	}
+	return out
+}
+
+func tripCommentsToJSON(cs []domain.TripComment) []tripCommentJSON {
+	out := make([]tripCommentJSON, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, tripCommentToJSON(c))
+	}
+	return out
+}
+
+func mountTripComments(r chi.Router, m MemberResolver, tc TripComments) {
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+	commentID := func(req *http.Request) domain.TripCommentID {
+		return domain.TripCommentID(chi.URLParam(req, "commentId"))
+	}
+
+	r.Get(TripCommentsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		q := req.URL.Query()
+		limit := 0
+		if raw := strings.TrimSpace(q.Get(CommentsLimitQuery)); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid limit", map[string]any{"limit": "must be a positive integer"})
+				return
+			}
+			limit = n
+		}
+		page, err := tc.ListTripComments(req.Context(), me.ID, tripID(req), q.Get(CommentsCursorQuery), limit)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		var next *string
+		if page.NextCursor != "" {
+			next = &page.NextCursor
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"pinned":     tripCommentsToJSON(page.Pinned),
+			"comments":   tripCommentsToJSON(page.Comments),
+			"nextCursor": next,
+		})
+	}))
+
+	r.Post(TripCommentsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Body     string  `json:"body"`
+			ParentID *string `json:"parentId"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in := trips.PostTripCommentInput{Body: body.Body}
+		if body.ParentID != nil {
+			id := domain.TripCommentID(*body.ParentID)
+			in.ParentID = &id
+		}
+		c, err := tc.PostTripComment(req.Context(), me.ID, tripID(req), in)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"comment": tripCommentToJSON(c)})
+	}))
+
+	r.Patch(TripCommentPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Body string `json:"body"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		c, err := tc.EditTripComment(req.Context(), me.ID, tripID(req), commentID(req), body.Body)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"comment": tripCommentToJSON(c)})
+	}))
+
+	r.Delete(TripCommentPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		if err := tc.DeleteTripComment(req.Context(), me.ID, tripID(req), commentID(req)); err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	pin := func(pinned bool) http.HandlerFunc {
+		return withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+			c, err := tc.SetTripCommentPinned(req.Context(), me.ID, tripID(req), commentID(req), pinned)
+			if err != nil {
+				writeTripsError(w, req, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"comment": tripCommentToJSON(c)})
+		})
+	}
+	r.Put(TripCommentPinPath, pin(true))
+	r.Delete(TripCommentPinPath, pin(false))
+}
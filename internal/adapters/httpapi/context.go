@@ -1,6 +1,10 @@
 package httpapi
 
-import "context"
+import (
+	"context"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
 
 type subjectKey struct{}
 
@@ -12,3 +16,15 @@ func SubjectFromContext(ctx context.Context) (string, bool) {
 	v, ok := ctx.Value(subjectKey{}).(string)
 	return v, ok && v != ""
 }
+
+type serviceAccountKey struct{}
+
+// WithServiceAccount marks the request as made by a service account (API key) rather than a member.
+func WithServiceAccount(ctx context.Context, key domain.APIKey) context.Context {
+	return context.WithValue(ctx, serviceAccountKey{}, key)
+}
+
+func ServiceAccountFromContext(ctx context.Context) (domain.APIKey, bool) {
+	v, ok := ctx.Value(serviceAccountKey{}).(domain.APIKey)
+	return v, ok && v.ID != ""
+}
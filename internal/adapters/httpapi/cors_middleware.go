@@ -0,0 +1,162 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures NewCORSMiddleware.
+//
+// AllowedOrigins is an explicit allow-list of exact origins (scheme://host[:port]).
+// "*" is accepted for local development but must not be combined with AllowCredentials.
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DefaultCORSAllowedMethods are the methods used by the API.
+var DefaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// DefaultCORSAllowedHeaders are the request headers the API reads.
+var DefaultCORSAllowedHeaders = []string{"Authorization", "Content-Type", "Idempotency-Key", "If-Match", "X-Debug-Subject", "X-Invite-Code", "X-Vehicle-Id", "X-Passenger-Count", "X-Passenger-Names", "X-Trip-Template-Id", "X-Series-Scope"}
+
+// DefaultCORSExposedHeaders are response headers browser clients need to read.
+var DefaultCORSExposedHeaders = []string{"ETag", "Retry-After", RequirementWarningsHeader, SeriesUpdatedTripsHeader, SeriesSkippedTripsHeader}
+
+// NewCORSMiddleware answers preflight requests and decorates responses for allow-listed origins.
+//
+// Requests from origins that are not allow-listed get no CORS headers (so browsers block them);
+// preflights from such origins are rejected with 403 CORS_ORIGIN_NOT_ALLOWED. It must run before
+// auth so preflights (which never carry credentials) are not rejected as unauthenticated.
+func NewCORSMiddleware(opts CORSOptions) func(http.Handler) http.Handler {
+	allowAny := false
+	origins := make(map[string]struct{}, len(opts.AllowedOrigins))
+	for _, o := range opts.AllowedOrigins {
+		o = strings.TrimRight(strings.TrimSpace(o), "/")
+		if o == "*" {
+			allowAny = true
+			continue
+		}
+		if o != "" {
+			origins[strings.ToLower(o)] = struct{}{}
+		}
+	}
+
+	methods := opts.AllowedMethods
+	if len(methods) == 0 {
+		methods = DefaultCORSAllowedMethods
+	}
+	headers := opts.AllowedHeaders
+	if len(headers) == 0 {
+		headers = DefaultCORSAllowedHeaders
+	}
+	exposed := opts.ExposedHeaders
+	if exposed == nil {
+		exposed = DefaultCORSExposedHeaders
+	}
+
+	allowedMethods := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		allowedMethods[strings.ToUpper(m)] = struct{}{}
+	}
+	allowedHeaders := make(map[string]struct{}, len(headers))
+	for _, h := range headers {
+		allowedHeaders[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+
+	methodsValue := strings.Join(methods, ", ")
+	headersValue := strings.Join(headers, ", ")
+	exposedValue := strings.Join(exposed, ", ")
+	maxAgeValue := ""
+	if opts.MaxAge > 0 {
+		maxAgeValue = strconv.Itoa(int(opts.MaxAge / time.Second))
+	}
+
+	originAllowed := func(origin string) bool {
+		if allowAny {
+			return true
+		}
+		_, ok := origins[strings.ToLower(origin)]
+		return ok
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if preflight {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if !originAllowed(origin) {
+				if preflight {
+					writeOASError(w, r, http.StatusForbidden, "CORS_ORIGIN_NOT_ALLOWED", "origin not allowed", map[string]any{
+						"origin": origin,
+					})
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAny && !opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposedValue != "" {
+					h.Set("Access-Control-Expose-Headers", exposedValue)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqMethod := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+			if _, ok := allowedMethods[reqMethod]; !ok {
+				writeOASError(w, r, http.StatusForbidden, "CORS_METHOD_NOT_ALLOWED", "method not allowed", map[string]any{
+					"method": reqMethod,
+				})
+				return
+			}
+			for _, rh := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+				rh = strings.TrimSpace(rh)
+				if rh == "" {
+					continue
+				}
+				if _, ok := allowedHeaders[http.CanonicalHeaderKey(rh)]; !ok {
+					writeOASError(w, r, http.StatusForbidden, "CORS_HEADER_NOT_ALLOWED", "header not allowed", map[string]any{
+						"header": rh,
+					})
+					return
+				}
+			}
+
+			h.Set("Access-Control-Allow-Methods", methodsValue)
+			h.Set("Access-Control-Allow-Headers", headersValue)
+			if maxAgeValue != "" {
+				h.Set("Access-Control-Max-Age", maxAgeValue)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+)
+
+func newTestCORSRouter(t *testing.T, opts CORSOptions) http.Handler {
+	t.Helper()
+	return NewRouterWithOptions(StrictUnimplemented{}, RouterOptions{
+		CORSMiddleware: NewCORSMiddleware(opts),
+		AuthMiddleware: NewDevAuthMiddleware(""),
+	})
+}
+
+func preflight(h http.Handler, origin, method, headers string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/trips/t1/rsvp", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func requireOASErrorCode(t *testing.T, rr *httptest.ResponseRecorder, wantStatus int, wantCode string) {
+	t.Helper()
+	if rr.Code != wantStatus {
+		t.Fatalf("status=%d want=%d body=%s", rr.Code, wantStatus, rr.Body.String())
+	}
+	var er oas.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &er); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if er.Error.Code != wantCode {
+		t.Fatalf("code=%q want=%q", er.Error.Code, wantCode)
+	}
+}
+
+func TestCORS_Preflight_AllowedOrigin(t *testing.T) {
+	t.Parallel()
+
+	h := newTestCORSRouter(t, CORSOptions{
+		AllowedOrigins:   []string{"https://app.example.org"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+
+	rr := preflight(h, "https://app.example.org", "PUT", "authorization, content-type, idempotency-key, if-match")
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status=%d want=204 body=%s", rr.Code, rr.Body.String())
+	}
+	hdr := rr.Header()
+	if got := hdr.Get("Access-Control-Allow-Origin"); got != "https://app.example.org" {
+		t.Fatalf("Allow-Origin=%q", got)
+	}
+	if got := hdr.Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Fatalf("Allow-Credentials=%q", got)
+	}
+	if got := hdr.Get("Access-Control-Max-Age"); got != "600" {
+		t.Fatalf("Max-Age=%q", got)
+	}
+	if got := hdr.Get("Access-Control-Allow-Headers"); got != "Authorization, Content-Type, Idempotency-Key, If-Match, X-Debug-Subject, X-Invite-Code, X-Vehicle-Id, X-Passenger-Count, X-Passenger-Names, X-Trip-Template-Id, X-Series-Scope" {
+		t.Fatalf("Allow-Headers=%q", got)
+	}
+}
+
+func TestCORS_Preflight_RejectedOrigin(t *testing.T) {
+	t.Parallel()
+
+	h := newTestCORSRouter(t, CORSOptions{AllowedOrigins: []string{"https://app.example.org"}})
+
+	for _, origin := range []string{"https://evil.example.org", "http://app.example.org", "https://app.example.org.evil.test"} {
+		rr := preflight(h, origin, "PUT", "")
+		requireOASErrorCode(t, rr, http.StatusForbidden, "CORS_ORIGIN_NOT_ALLOWED")
+		if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Fatalf("origin %q: Allow-Origin=%q, want empty", origin, got)
+		}
+	}
+}
+
+func TestCORS_Preflight_RejectedMethodAndHeader(t *testing.T) {
+	t.Parallel()
+
+	h := newTestCORSRouter(t, CORSOptions{AllowedOrigins: []string{"https://app.example.org"}})
+
+	requireOASErrorCode(t, preflight(h, "https://app.example.org", "TRACE", ""), http.StatusForbidden, "CORS_METHOD_NOT_ALLOWED")
+	requireOASErrorCode(t, preflight(h, "https://app.example.org", "PUT", "X-Custom"), http.StatusForbidden, "CORS_HEADER_NOT_ALLOWED")
+}
+
+func TestCORS_SimpleRequest_RejectedOriginGetsNoHeaders(t *testing.T) {
+	t.Parallel()
+
+	h := newTestCORSRouter(t, CORSOptions{AllowedOrigins: []string{"https://app.example.org"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set("Origin", "https://evil.example.org")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Allow-Origin=%q, want empty", got)
+	}
+	if got := rr.Header().Get("Vary"); got != "Origin" {
+		t.Fatalf("Vary=%q, want Origin", got)
+	}
+}
+
+func TestCORS_SimpleRequest_AllowedOriginExposesHeaders(t *testing.T) {
+	t.Parallel()
+
+	h := newTestCORSRouter(t, CORSOptions{AllowedOrigins: []string{"https://app.example.org"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/members/me", nil)
+	req.Header.Set("Origin", "https://app.example.org")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	// Auth still applies to actual requests; CORS headers are present so the browser can read the error.
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("status=%d want=401", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.org" {
+		t.Fatalf("Allow-Origin=%q", got)
+	}
+	if got := rr.Header().Get("Access-Control-Expose-Headers"); got != "ETag, Retry-After, X-Requirement-Warnings, X-Series-Updated-Trips, X-Series-Skipped-Trips" {
+		t.Fatalf("Expose-Headers=%q", got)
+	}
+}
@@ -0,0 +1,125 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Trip list filter query parameters for ListVisibleTripsForMember. They are read by a strict
+// middleware because the operation's parameters are owned by the OpenAPI contract.
+const (
+	// DifficultyMinQuery and DifficultyMaxQuery match trips whose rating range overlaps them.
+	DifficultyMinQuery = "difficultyMin"
+	DifficultyMaxQuery = "difficultyMax"
+	// TerrainQuery is a comma-separated (or repeated) list of terrain tags; trips must carry all of them.
+	TerrainQuery = "terrain"
+)
+
+type tripListFilterKey struct{}
+
+func WithTripListFilter(ctx context.Context, f trips.TripListFilter) context.Context {
+	return context.WithValue(ctx, tripListFilterKey{}, f)
+}
+
+// TripListFilterFromContext returns the trip list filter sent with the request (zero value when absent).
+func TripListFilterFromContext(ctx context.Context) trips.TripListFilter {
+	v, _ := ctx.Value(tripListFilterKey{}).(trips.TripListFilter)
+	return v
+}
+
+// newTripListFilterMiddleware copies the difficulty filter query parameters into the context of
+// ListVisibleTripsForMember requests. Malformed filters are rejected with 422, which the
+// operation's response schema does not list.
+func newTripListFilterMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "ListVisibleTripsForMember" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			filter, err := tripListFilterFromQuery(r)
+			if err != nil {
+				writeOASError(w, r, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+				return nil, nil
+			}
+			resp, err := f(WithTripListFilter(ctx, filter), w, r, request)
+			if ae := (*trips.Error)(nil); errors.As(err, &ae) && ae.Status == http.StatusUnprocessableEntity {
+				writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+				return nil, nil
+			}
+			return resp, err
+		}
+	}
+}
+
+func tripListFilterFromQuery(r *http.Request) (trips.TripListFilter, error) {
+	var f trips.TripListFilter
+	q := r.URL.Query()
+	rating := func(name string) (*int, error) {
+		raw := strings.TrimSpace(q.Get(name))
+		if raw == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, errors.New("invalid " + name + ": must be an integer")
+		}
+		return &n, nil
+	}
+	var err error
+	if f.MinRating, err = rating(DifficultyMinQuery); err != nil {
+		return f, err
+	}
+	if f.MaxRating, err = rating(DifficultyMaxQuery); err != nil {
+		return f, err
+	}
+	for _, v := range q[TerrainQuery] {
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				f.Terrain = append(f.Terrain, domain.TerrainTag(strings.ToUpper(tag)))
+			}
+		}
+	}
+	return f, nil
+}
+
+type difficultyJSON struct {
+	Rating    int      `json:"rating"`
+	MinRating *int     `json:"minRating"`
+	MaxRating *int     `json:"maxRating"`
+	Terrain   []string `json:"terrain"`
+}
+
+func difficultyToJSON(d *domain.Difficulty) *difficultyJSON {
+	if d == nil {
+		return nil
+	}
+	out := &difficultyJSON{
+		Rating:    d.Rating,
+		MinRating: d.MinRating,
+		MaxRating: d.MaxRating,
+		Terrain:   make([]string, 0, len(d.Terrain)),
+	}
+	for _, tag := range d.Terrain {
+		out.Terrain = append(out.Terrain, string(tag))
+	}
+	return out
+}
+
+func difficultyFromJSON(d difficultyJSON) domain.Difficulty {
+	out := domain.Difficulty{
+		Rating:    d.Rating,
+		MinRating: d.MinRating,
+		MaxRating: d.MaxRating,
+	}
+	for _, tag := range d.Terrain {
+		out.Terrain = append(out.Terrain, domain.TerrainTag(strings.TrimSpace(tag)))
+	}
+	return out
+}
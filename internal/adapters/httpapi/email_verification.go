@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Email verification routes are out-of-spec (like /healthz): the OpenAPI contract has no
+// operations for them yet.
+const (
+	// EmailVerificationConfirmPath is the link target in verification emails. It is
+	// unauthenticated; the signed token is the credential. Accepts GET and POST with `?token=`.
+	EmailVerificationConfirmPath = "/email-verifications/confirm"
+	// EmailVerificationRequestPath re-sends a link for the caller's `?kind=email|groupAliasEmail`.
+	EmailVerificationRequestPath = "/members/me/email-verifications"
+)
+
+// EmailVerifier is the members use-case surface needed by the verification routes.
+type EmailVerifier interface {
+	ConfirmEmailVerification(ctx context.Context, token string) (domain.Member, members.EmailKind, error)
+	RequestMyEmailVerification(ctx context.Context, subject domain.SubjectID, kind members.EmailKind) error
+}
+
+// isPublicPath reports whether path is served without authentication.
+func isPublicPath(path string) bool {
+	return path == "/healthz" || path == EmailVerificationConfirmPath
+}
+
+func mountEmailVerification(r chi.Router, v EmailVerifier) {
+	confirm := func(w http.ResponseWriter, req *http.Request) {
+		_, kind, err := v.ConfirmEmailVerification(req.Context(), req.URL.Query().Get("token"))
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"verified": string(kind)})
+	}
+	r.Get(EmailVerificationConfirmPath, confirm)
+	r.Post(EmailVerificationConfirmPath, confirm)
+
+	r.Post(EmailVerificationRequestPath, func(w http.ResponseWriter, req *http.Request) {
+		sub, ok := SubjectFromContext(req.Context())
+		if !ok {
+			writeOASError(w, req, http.StatusUnauthorized, "UNAUTHORIZED", "missing subject", nil)
+			return
+		}
+		kind := members.EmailKind(req.URL.Query().Get("kind"))
+		if kind == "" {
+			kind = members.EmailKindPrimary
+		}
+		if err := v.RequestMyEmailVerification(req.Context(), domain.SubjectID(sub), kind); err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func writeMembersError(w http.ResponseWriter, r *http.Request, err error) {
+	if ae := (*members.Error)(nil); errors.As(err, &ae) {
+		writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+		return
+	}
+	writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+}
@@ -0,0 +1,176 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/emergencyinfo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Emergency info routes are out-of-spec: members manage their own emergency contact and
+// medical notes, and organizers read them for a published trip's attendees around the trip
+// dates. Responses are never cached.
+const (
+	// MyEmergencyInfoPath reads (GET), replaces (PUT) or removes (DELETE) the caller's info.
+	MyEmergencyInfoPath = "/members/me/emergency-info"
+	// MyEmergencyInfoAccessLogPath lists (GET) every read of and change to the caller's info.
+	MyEmergencyInfoAccessLogPath = "/members/me/emergency-info/access-log"
+	// TripEmergencyInfoPath lists (GET) every attendee's info; organizers only.
+	TripEmergencyInfoPath = "/trips/{tripId}/emergency-info"
+	// TripEmergencyInfoMemberPath reads (GET) one attendee's info; organizers only.
+	TripEmergencyInfoMemberPath = "/trips/{tripId}/emergency-info/{memberId}"
+)
+
+// EmergencyInfo is the emergency info use-case surface needed by the emergency info routes.
+type EmergencyInfo interface {
+	GetMyEmergencyInfo(ctx context.Context, caller domain.MemberID) (domain.EmergencyInfo, error)
+	PutMyEmergencyInfo(ctx context.Context, caller domain.MemberID, in emergencyinfo.Input) (domain.EmergencyInfo, error)
+	DeleteMyEmergencyInfo(ctx context.Context, caller domain.MemberID) error
+	ListMyEmergencyInfoAccess(ctx context.Context, caller domain.MemberID) ([]domain.EmergencyInfoAccess, error)
+	GetAttendeeEmergencyInfo(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID) (domain.EmergencyInfo, error)
+	ListTripEmergencyInfo(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.AttendeeEmergencyInfo, error)
+}
+
+type emergencyInfoJSON struct {
+	ContactName  string    `json:"contactName"`
+	ContactPhone string    `json:"contactPhone"`
+	MedicalNotes *string   `json:"medicalNotes"`
+	BloodType    *string   `json:"bloodType"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type emergencyInfoAccessJSON struct {
+	AccessedBy *string   `json:"accessedBy"`
+	TripID     *string   `json:"tripId"`
+	Action     string    `json:"action"`
+	AccessedAt time.Time `json:"accessedAt"`
+}
+
+type attendeeEmergencyInfoJSON struct {
+	Member        memberRefJSON      `json:"member"`
+	EmergencyInfo *emergencyInfoJSON `json:"emergencyInfo"`
+}
+
+func emergencyInfoToJSON(info domain.EmergencyInfo) emergencyInfoJSON {
+	return emergencyInfoJSON{
+		ContactName:  info.ContactName,
+		ContactPhone: info.ContactPhone,
+		MedicalNotes: info.MedicalNotes,
+		BloodType:    info.BloodType,
+		UpdatedAt:    info.UpdatedAt.UTC(),
+	}
+}
+
+func mountEmergencyInfo(r chi.Router, m MemberResolver, e EmergencyInfo) {
+	noStore := func(w http.ResponseWriter) { w.Header().Set("Cache-Control", "no-store") }
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+
+	r.Get(MyEmergencyInfoPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		noStore(w)
+		info, err := e.GetMyEmergencyInfo(req.Context(), me.ID)
+		if err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"emergencyInfo": emergencyInfoToJSON(info)})
+	}))
+
+	r.Put(MyEmergencyInfoPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		noStore(w)
+		var body struct {
+			ContactName  string  `json:"contactName"`
+			ContactPhone string  `json:"contactPhone"`
+			MedicalNotes *string `json:"medicalNotes"`
+			BloodType    *string `json:"bloodType"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		info, err := e.PutMyEmergencyInfo(req.Context(), me.ID, emergencyinfo.Input{
+			ContactName:  body.ContactName,
+			ContactPhone: body.ContactPhone,
+			MedicalNotes: body.MedicalNotes,
+			BloodType:    body.BloodType,
+		})
+		if err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"emergencyInfo": emergencyInfoToJSON(info)})
+	}))
+
+	r.Delete(MyEmergencyInfoPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		if err := e.DeleteMyEmergencyInfo(req.Context(), me.ID); err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r.Get(MyEmergencyInfoAccessLogPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		noStore(w)
+		entries, err := e.ListMyEmergencyInfoAccess(req.Context(), me.ID)
+		if err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		out := make([]emergencyInfoAccessJSON, 0, len(entries))
+		for _, a := range entries {
+			j := emergencyInfoAccessJSON{Action: string(a.Action), AccessedAt: a.AccessedAt.UTC()}
+			if a.AccessedBy != "" {
+				by := string(a.AccessedBy)
+				j.AccessedBy = &by
+			}
+			if a.TripID != "" {
+				id := string(a.TripID)
+				j.TripID = &id
+			}
+			out = append(out, j)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"accesses": out})
+	}))
+
+	r.Get(TripEmergencyInfoPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		noStore(w)
+		attendees, err := e.ListTripEmergencyInfo(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		out := make([]attendeeEmergencyInfoJSON, 0, len(attendees))
+		for _, a := range attendees {
+			j := attendeeEmergencyInfoJSON{Member: memberRefToJSON(a.Member)}
+			if a.Info != nil {
+				info := emergencyInfoToJSON(*a.Info)
+				j.EmergencyInfo = &info
+			}
+			out = append(out, j)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"attendees": out})
+	}))
+
+	r.Get(TripEmergencyInfoMemberPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		noStore(w)
+		info, err := e.GetAttendeeEmergencyInfo(req.Context(), me.ID, tripID(req), domain.MemberID(chi.URLParam(req, "memberId")))
+		if err != nil {
+			writeEmergencyInfoError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"emergencyInfo": emergencyInfoToJSON(info)})
+	}))
+}
+
+func writeEmergencyInfoError(w http.ResponseWriter, r *http.Request, err error) {
+	if ae := (*emergencyinfo.Error)(nil); errors.As(err, &ae) {
+		writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+		return
+	}
+	writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+}
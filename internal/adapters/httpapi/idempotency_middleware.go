@@ -0,0 +1,361 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+)
+
+// idempotencySubject scopes idempotency keys to the caller: the token subject for members, the
+// API key ID for service accounts.
+func idempotencySubject(ctx context.Context) (string, bool) {
+	if sub, ok := SubjectFromContext(ctx); ok {
+		return sub, true
+	}
+	if key, ok := ServiceAccountFromContext(ctx); ok {
+		return "apikey:" + string(key.ID), true
+	}
+	return "", false
+}
+
+// idempotencyLockTimeout bounds how long an in-progress reservation blocks duplicates.
+// A reservation not renewed for this long is assumed abandoned (e.g. the replica crashed
+// mid-request).
+const idempotencyLockTimeout = 30 * time.Second
+
+// idempotencyLockRefresh is how often a running request renews its reservation, well within
+// idempotencyLockTimeout so slow handlers are never taken over.
+const idempotencyLockRefresh = idempotencyLockTimeout / 3
+
+// NewIdempotencyMiddleware makes mutating operations safe to retry with an Idempotency-Key.
+//
+// It is installed per operation (after routing), so the fingerprint route is the OpenAPI path
+// template. For each keyed request by an authenticated subject (service accounts are keyed by
+// their API key):
+//   - a meta record (BodyHash "") reserves the key and stores the payload hash; while the
+//     first request executes, duplicates get 409 IDEMPOTENCY_REQUEST_IN_PROGRESS
+//   - the same key with a different payload (path, body or input headers) gets 409
+//     IDEMPOTENCY_KEY_REUSE
+//   - the reservation is renewed while the handler runs; one not renewed for
+//     idempotencyLockTimeout is taken over by one retry
+//   - a successful (2xx) response is stored verbatim (status, headers, bytes) and replayed
+//   - a non-2xx response releases the key so the client can retry
+//   - if a 2xx response cannot be stored, the failure is logged and the key released, so a
+//     retry runs the operation again rather than waiting out the lock first
+//
+// Operations whose key is required are enforced by the generated parameter binding; this
+// layer applies uniformly whenever the header is present on a mutating method.
+//
+// Records expire after ttl (zero keeps them forever), after which the key may be reused.
+func NewIdempotencyMiddleware(store idempotency.Store, clk clock.Clock, ttl time.Duration) func(http.Handler) http.Handler {
+	return newIdempotencyMiddleware(store, clk, ttl, idempotencyLockRefresh)
+}
+
+func newIdempotencyMiddleware(store idempotency.Store, clk clock.Clock, ttl, refresh time.Duration) func(http.Handler) http.Handler {
+	expiresAt := func(now time.Time) time.Time {
+		if ttl <= 0 {
+			return time.Time{}
+		}
+		return now.Add(ttl)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+			sub, ok := idempotencySubject(r.Context())
+			if key == "" || !ok || !isMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			raw, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeOASError(w, r, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "unreadable request body", nil)
+				return
+			}
+			_ = r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(raw))
+
+			ctx := r.Context()
+			bodyHash := hashIdempotentRequest(r.URL.Path, r.Header, raw)
+			metaFP := idempotency.Fingerprint{
+				Key:      idempotency.Key(key),
+				Subject:  domain.SubjectID(sub),
+				Method:   r.Method,
+				Route:    routePattern(r),
+				BodyHash: "",
+			}
+			respFP := metaFP
+			respFP.BodyHash = bodyHash
+
+			now := clk.Now()
+			lock := idempotency.Record{
+				StatusCode:  0,
+				ContentType: "text/plain",
+				Body:        []byte(bodyHash),
+				CreatedAt:   now,
+				ExpiresAt:   expiresAt(now),
+			}
+			meta, reserved, err := store.Reserve(ctx, metaFP, lock)
+			if err != nil {
+				writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+				return
+			}
+			if !reserved {
+				if string(meta.Body) != bodyHash {
+					writeOASError(w, r, http.StatusConflict, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil)
+					return
+				}
+				rec, found, err := store.Get(ctx, respFP)
+				if err != nil {
+					writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+					return
+				}
+				if found {
+					replayIdempotentResponse(w, rec)
+					return
+				}
+				if now.Sub(meta.CreatedAt) < idempotencyLockTimeout {
+					w.Header().Set("Retry-After", "1")
+					writeOASError(w, r, http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS", "a request with this idempotency key is still in progress", nil)
+					return
+				}
+				// Abandoned reservation: take it over. Concurrent retries race on the reservation's
+				// CreatedAt so only one of them runs the handler.
+				abandonedAt := meta.CreatedAt
+				meta.CreatedAt = now
+				meta.ExpiresAt = expiresAt(now)
+				swapped, err := store.CompareAndSwap(ctx, metaFP, abandonedAt, meta)
+				if err != nil {
+					writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+					return
+				}
+				if !swapped {
+					w.Header().Set("Retry-After", "1")
+					writeOASError(w, r, http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS", "a request with this idempotency key is still in progress", nil)
+					return
+				}
+				lock = meta
+			}
+
+			cw := newCapturingResponseWriter()
+			func() {
+				// A panicking handler stops renewing, leaving the reservation to be taken over.
+				defer holdIdempotencyLock(ctx, store, clk, metaFP, lock, refresh)()
+				next.ServeHTTP(cw, r)
+			}()
+
+			release := cw.status < 200 || cw.status >= 300
+			if !release {
+				// The response shares the meta record's expiry so the pair ages out together.
+				err := store.Put(ctx, respFP, idempotency.Record{
+					StatusCode:  cw.status,
+					ContentType: cw.header.Get("Content-Type"),
+					Header:      cw.header,
+					Body:        cw.body.Bytes(),
+					CreatedAt:   clk.Now(),
+					ExpiresAt:   expiresAt(now),
+				})
+				if err != nil {
+					log.Printf("idempotency: store response for %s %s: %v (releasing key)", r.Method, metaFP.Route, err)
+					release = true
+				}
+			}
+			if release {
+				if err := store.Delete(ctx, metaFP); err != nil {
+					log.Printf("idempotency: release key for %s %s: %v", r.Method, metaFP.Route, err)
+				}
+			}
+			cw.flushTo(w)
+		})
+	}
+}
+
+// holdIdempotencyLock renews the reservation lock under fp every interval until the returned
+// stop function is called. Renewal ends early if the reservation was lost.
+func holdIdempotencyLock(ctx context.Context, store idempotency.Store, clk clock.Clock, fp idempotency.Fingerprint, lock idempotency.Record, every time.Duration) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			renewed := lock
+			renewed.CreatedAt = clk.Now()
+			swapped, err := store.CompareAndSwap(ctx, fp, lock.CreatedAt, renewed)
+			if err != nil {
+				log.Printf("idempotency: renew lock for %s: %v", fp.Route, err)
+				continue
+			}
+			if !swapped {
+				return
+			}
+			lock = renewed
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if p := rctx.RoutePattern(); p != "" {
+			return p
+		}
+	}
+	return r.URL.Path
+}
+
+// idempotentInputHeaders carry request inputs outside the body, so they are part of the payload
+// a retry must repeat.
+var idempotentInputHeaders = []string{
+	InviteCodeHeader,
+	VehicleIDHeader,
+	PassengerCountHeader,
+	PassengerNamesHeader,
+	TripTemplateIDHeader,
+	SeriesScopeHeader,
+}
+
+// hashIdempotentRequest hashes the concrete path, the input headers and a canonical form of the
+// JSON body, so retries that differ only in key order, whitespace between tokens or number
+// formatting still match. Absent headers add nothing, keeping hashes of header-less requests stable.
+func hashIdempotentRequest(path string, header http.Header, body []byte) string {
+	canon := body
+	if len(bytes.TrimSpace(body)) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err == nil {
+			if b, err := json.Marshal(canonicalizeIdempotentJSON(v)); err == nil {
+				canon = b
+			}
+		}
+	}
+	h := sha256.New()
+	_, _ = h.Write([]byte(path))
+	_, _ = h.Write([]byte{0})
+	for _, name := range idempotentInputHeaders {
+		vals := header.Values(name)
+		if len(vals) == 0 {
+			continue
+		}
+		_, _ = h.Write([]byte(name))
+		for _, v := range vals {
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write([]byte(strings.TrimSpace(v)))
+		}
+		_, _ = h.Write([]byte{0})
+	}
+	_, _ = h.Write(canon)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalizeIdempotentJSON rewrites numbers in their shortest form (1.0 and 1e0 hash as 1).
+// Object keys are sorted by json.Marshal. Strings are left exactly as sent: whitespace and case
+// can be meaningful, so a retry must repeat them.
+func canonicalizeIdempotentJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			t[k] = canonicalizeIdempotentJSON(child)
+		}
+		return t
+	case []any:
+		for i, child := range t {
+			t[i] = canonicalizeIdempotentJSON(child)
+		}
+		return t
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			return t
+		}
+		if f, err := t.Float64(); err == nil {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, rec idempotency.Record) {
+	for k, vs := range rec.Header {
+		w.Header()[k] = append([]string(nil), vs...)
+	}
+	if rec.ContentType != "" {
+		w.Header().Set("Content-Type", rec.ContentType)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(rec.StatusCode)
+	_, _ = w.Write(rec.Body)
+}
+
+// capturingResponseWriter buffers a handler's response so it can be stored before it is sent.
+type capturingResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newCapturingResponseWriter() *capturingResponseWriter {
+	return &capturingResponseWriter{header: make(http.Header)}
+}
+
+func (c *capturingResponseWriter) Header() http.Header { return c.header }
+
+func (c *capturingResponseWriter) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+func (c *capturingResponseWriter) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	return c.body.Write(b)
+}
+
+func (c *capturingResponseWriter) flushTo(w http.ResponseWriter) {
+	for k, vs := range c.header {
+		w.Header()[k] = vs
+	}
+	status := c.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(c.body.Bytes())
+}
@@ -0,0 +1,374 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
+)
+
+func newTestIdempotentHandler(t *testing.T, h http.HandlerFunc) (http.Handler, *memclock.ManualClock) {
+	t.Helper()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	r := chi.NewRouter()
+	r.Use(NewDevAuthMiddleware("sub-1"))
+	r.With(NewIdempotencyMiddleware(memidempotency.NewStoreWithClock(clk), clk, time.Hour)).Post("/things/{id}", h)
+	return r, clk
+}
+
+func doIdempotent(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdempotency_ReplaysRawResponse(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/things/a")
+		w.WriteHeader(http.StatusCreated)
+		// Not a valid JSON document on purpose: replay must not depend on unmarshalling.
+		_, _ = w.Write([]byte(`{"call":` + string(rune('0'+n)) + `} trailing`))
+	})
+
+	rr1 := doIdempotent(h, "/things/a", "k1", `{"name":"Snow Run","b":1.0}`)
+	rr2 := doIdempotent(h, "/things/a", "k1", `{ "b": 1, "name": "Snow Run" }`)
+
+	if calls.Load() != 1 {
+		t.Fatalf("handler calls=%d want=1", calls.Load())
+	}
+	if rr2.Code != http.StatusCreated || rr2.Body.String() != rr1.Body.String() {
+		t.Fatalf("replay status=%d body=%q want %d %q", rr2.Code, rr2.Body.String(), rr1.Code, rr1.Body.String())
+	}
+	if rr2.Header().Get("Location") != "/things/a" || rr2.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay headers=%v", rr2.Header())
+	}
+	if rr1.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("first response must not be marked as replayed")
+	}
+}
+
+func TestIdempotency_KeyReuseWithDifferentPayload(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if rr := doIdempotent(h, "/things/a", "k1", `{"v":1}`); rr.Code != http.StatusOK {
+		t.Fatalf("status=%d", rr.Code)
+	}
+	requireOASErrorCode(t, doIdempotent(h, "/things/a", "k1", `{"v":2}`), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+	// String values are compared exactly.
+	if rr := doIdempotent(h, "/things/a", "k2", `{"note":"Snow Run"}`); rr.Code != http.StatusOK {
+		t.Fatalf("status=%d", rr.Code)
+	}
+	requireOASErrorCode(t, doIdempotent(h, "/things/a", "k2", `{"note":" Snow  Run"}`), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+	// Same route template, different path parameter.
+	requireOASErrorCode(t, doIdempotent(h, "/things/b", "k1", `{"v":1}`), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+}
+
+func TestIdempotency_KeyReuseWithDifferentInputHeader(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	do := func(vehicleID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/things/a", bytes.NewBufferString(`{}`))
+		req.Header.Set("Idempotency-Key", "k1")
+		req.Header.Set(VehicleIDHeader, vehicleID)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := do("v-1"); rr.Code != http.StatusOK {
+		t.Fatalf("status=%d", rr.Code)
+	}
+	if rr := do(" v-1 "); rr.Code != http.StatusOK || rr.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("same header retry status=%d headers=%v, want replay", rr.Code, rr.Header())
+	}
+	requireOASErrorCode(t, do("v-2"), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+	// Dropping the header changes the payload too.
+	requireOASErrorCode(t, doIdempotent(h, "/things/a", "k1", `{}`), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+}
+
+func TestIdempotency_ConcurrentDuplicateIsRejectedWhileInProgress(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			close(entered)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- doIdempotent(h, "/things/a", "k1", `{}`) }()
+	<-entered
+
+	rr := doIdempotent(h, "/things/a", "k1", `{}`)
+	requireOASErrorCode(t, rr, http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+	if rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected Retry-After on in-progress response")
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusOK {
+		t.Fatalf("first status=%d", first.Code)
+	}
+	if rr := doIdempotent(h, "/things/a", "k1", `{}`); rr.Code != http.StatusOK || calls.Load() != 1 {
+		t.Fatalf("after completion status=%d calls=%d, want replay", rr.Code, calls.Load())
+	}
+}
+
+func TestIdempotency_AbandonedReservationIsTakenOver(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	var calls atomic.Int32
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Use(NewDevAuthMiddleware("sub-1"))
+	r.With(NewIdempotencyMiddleware(memidempotency.NewStoreWithClock(clk), clk, time.Hour)).Post("/things/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		// Simulate a replica dying mid-request: the reservation is never completed or released.
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if rr := doIdempotent(r, "/things/a", "k1", `{}`); rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status=%d want=500", rr.Code)
+	}
+	requireOASErrorCode(t, doIdempotent(r, "/things/a", "k1", `{}`), http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+
+	clk.Add(idempotencyLockTimeout)
+	if rr := doIdempotent(r, "/things/a", "k1", `{}`); rr.Code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("status=%d calls=%d, want stale reservation taken over", rr.Code, calls.Load())
+	}
+}
+
+// barrierStore makes the next two Reserve calls that find an existing record wait for each
+// other, so both callers see the same abandoned reservation.
+type barrierStore struct {
+	*memidempotency.Store
+	arrived sync.WaitGroup
+	armed   atomic.Bool
+}
+
+func (s *barrierStore) Reserve(ctx context.Context, fp idempotency.Fingerprint, rec idempotency.Record) (idempotency.Record, bool, error) {
+	existing, reserved, err := s.Store.Reserve(ctx, fp, rec)
+	if !reserved && s.armed.Load() {
+		s.arrived.Done()
+		s.arrived.Wait()
+	}
+	return existing, reserved, err
+}
+
+func TestIdempotency_ConcurrentTakeoverRunsHandlerOnce(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	store := &barrierStore{Store: memidempotency.NewStoreWithClock(clk)}
+	release := make(chan struct{})
+	var calls atomic.Int32
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Use(NewDevAuthMiddleware("sub-1"))
+	r.With(NewIdempotencyMiddleware(store, clk, time.Hour)).Post("/things/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		// Hold the takeover open so the other retry cannot replay its response.
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if rr := doIdempotent(r, "/things/a", "k1", `{}`); rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status=%d want=500", rr.Code)
+	}
+	clk.Add(idempotencyLockTimeout)
+
+	store.arrived.Add(2)
+	store.armed.Store(true)
+	results := make(chan *httptest.ResponseRecorder, 2)
+	for i := 0; i < 2; i++ {
+		go func() { results <- doIdempotent(r, "/things/a", "k1", `{}`) }()
+	}
+	select {
+	case rr := <-results:
+		requireOASErrorCode(t, rr, http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+	case <-time.After(2 * time.Second):
+		close(release)
+		t.Fatalf("both retries took over the reservation")
+	}
+	close(release)
+	if rr := <-results; rr.Code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("takeover status=%d calls=%d, want the handler run once more", rr.Code, calls.Load())
+	}
+}
+
+func TestIdempotency_ExpiredKeyCanBeReused(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	h, clk := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if rr := doIdempotent(h, "/things/a", "k1", `{"v":1}`); rr.Code != http.StatusOK {
+		t.Fatalf("status=%d", rr.Code)
+	}
+	clk.Add(time.Hour)
+	if rr := doIdempotent(h, "/things/a", "k1", `{"v":2}`); rr.Code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("status=%d calls=%d, want expired key to be reusable", rr.Code, calls.Load())
+	}
+}
+
+func TestIdempotency_FailedResponseReleasesKey(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if rr := doIdempotent(h, "/things/a", "k1", `{}`); rr.Code != http.StatusConflict {
+		t.Fatalf("status=%d want=409", rr.Code)
+	}
+	if rr := doIdempotent(h, "/things/a", "k1", `{}`); rr.Code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("retry status=%d calls=%d, want re-execution", rr.Code, calls.Load())
+	}
+}
+
+// renewalStore reports the time of every renewed reservation.
+type renewalStore struct {
+	*memidempotency.Store
+	renewed chan time.Time
+}
+
+func (s *renewalStore) CompareAndSwap(ctx context.Context, fp idempotency.Fingerprint, createdAt time.Time, rec idempotency.Record) (bool, error) {
+	swapped, err := s.Store.CompareAndSwap(ctx, fp, createdAt, rec)
+	if swapped {
+		select {
+		case s.renewed <- rec.CreatedAt:
+		default:
+		}
+	}
+	return swapped, err
+}
+
+func TestIdempotency_SlowRequestKeepsItsReservation(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	store := &renewalStore{Store: memidempotency.NewStoreWithClock(clk), renewed: make(chan time.Time, 1)}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	r := chi.NewRouter()
+	r.Use(NewDevAuthMiddleware("sub-1"))
+	r.With(newIdempotencyMiddleware(store, clk, time.Hour, time.Millisecond)).Post("/things/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() { done <- doIdempotent(r, "/things/a", "k1", `{}`) }()
+	<-started
+
+	// Renewals at +20s keep the reservation alive at +40s, past the lock timeout.
+	clk.Add(20 * time.Second)
+	for at := range store.renewed {
+		if at.Equal(clk.Now()) {
+			break
+		}
+	}
+	clk.Add(20 * time.Second)
+	requireOASErrorCode(t, doIdempotent(r, "/things/a", "k1", `{}`), http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+
+	close(release)
+	if first := <-done; first.Code != http.StatusOK || calls.Load() != 1 {
+		t.Fatalf("first status=%d calls=%d", first.Code, calls.Load())
+	}
+}
+
+// failingPutStore cannot store responses.
+type failingPutStore struct {
+	*memidempotency.Store
+}
+
+func (s failingPutStore) Put(context.Context, idempotency.Fingerprint, idempotency.Record) error {
+	return errors.New("store unavailable")
+}
+
+func TestIdempotency_UnstoredResponseReleasesKey(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	var calls atomic.Int32
+	r := chi.NewRouter()
+	r.Use(NewDevAuthMiddleware("sub-1"))
+	r.With(NewIdempotencyMiddleware(failingPutStore{memidempotency.NewStoreWithClock(clk)}, clk, time.Hour)).Post("/things/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if rr := doIdempotent(r, "/things/a", "k1", `{}`); rr.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", rr.Code)
+	}
+	// The key is not left locked until the reservation times out.
+	if rr := doIdempotent(r, "/things/a", "k1", `{}`); rr.Code != http.StatusCreated || calls.Load() != 2 {
+		t.Fatalf("retry status=%d calls=%d, want re-execution", rr.Code, calls.Load())
+	}
+}
+
+func TestIdempotency_NoKeyPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	h, _ := newTestIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	doIdempotent(h, "/things/a", "", `{}`)
+	doIdempotent(h, "/things/a", "", `{}`)
+	if calls.Load() != 2 {
+		t.Fatalf("calls=%d want=2", calls.Load())
+	}
+}
@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
+)
+
+// Login identity routes are out-of-spec: members list, link and unlink the logins that resolve
+// to their profile.
+const (
+	// MyIdentitiesPath lists (GET) the caller's logins, links (POST) another one, and unlinks
+	// (DELETE, `?issuer=&subject=`) one of them. The last login cannot be unlinked.
+	MyIdentitiesPath = "/members/me/identities"
+)
+
+// MemberIdentities is the members use-case surface needed by the login identity routes.
+type MemberIdentities interface {
+	ListMyIdentities(ctx context.Context, subject domain.SubjectID) ([]domain.MemberIdentity, error)
+	LinkMyIdentity(ctx context.Context, subject domain.SubjectID, proof domain.MemberIdentity) (domain.MemberIdentity, error)
+	UnlinkMyIdentity(ctx context.Context, subject domain.SubjectID, issuer string, identitySubject domain.SubjectID) error
+}
+
+// IdentityTokenVerifier verifies the token of the login being linked; *jwtverifier.Verifier
+// satisfies it.
+type IdentityTokenVerifier interface {
+	VerifyIdentity(ctx context.Context, token string) (jwtverifier.Identity, error)
+}
+
+type memberIdentityJSON struct {
+	Issuer   string    `json:"issuer"`
+	Subject  string    `json:"subject"`
+	LinkedAt time.Time `json:"linkedAt"`
+}
+
+func memberIdentityToJSON(id domain.MemberIdentity) memberIdentityJSON {
+	return memberIdentityJSON{Issuer: id.Issuer, Subject: string(id.Subject), LinkedAt: id.LinkedAt.UTC()}
+}
+
+// mountMemberIdentities mounts the identity routes. Linking takes `{"token": "..."}`, a token
+// of the login to link: together with the caller's own credential it proves control of both.
+// Without a verifier only listing and unlinking are mounted.
+func mountMemberIdentities(r chi.Router, ids MemberIdentities, tokens IdentityTokenVerifier) {
+	r.Get(MyIdentitiesPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		list, err := ids.ListMyIdentities(req.Context(), sub)
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		out := make([]memberIdentityJSON, 0, len(list))
+		for _, id := range list {
+			out = append(out, memberIdentityToJSON(id))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"identities": out})
+	}))
+
+	if tokens != nil {
+		r.Post(MyIdentitiesPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+			var body struct {
+				Token string `json:"token"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+				return
+			}
+			token := strings.TrimSpace(body.Token)
+			if token == "" {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid identity", map[string]any{"token": "required"})
+				return
+			}
+			proof, err := tokens.VerifyIdentity(req.Context(), token)
+			if err != nil {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "LINK_TOKEN_INVALID", "The token of the login to link is invalid or expired.", nil)
+				return
+			}
+			linked, err := ids.LinkMyIdentity(req.Context(), sub, domain.MemberIdentity{
+				Issuer:  proof.Issuer,
+				Subject: domain.SubjectID(proof.Subject),
+			})
+			if err != nil {
+				writeMembersError(w, req, err)
+				return
+			}
+			writeJSON(w, http.StatusCreated, map[string]any{"identity": memberIdentityToJSON(linked)})
+		}))
+	}
+
+	r.Delete(MyIdentitiesPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		q := req.URL.Query()
+		if err := ids.UnlinkMyIdentity(req.Context(), sub, q.Get("issuer"), domain.SubjectID(q.Get("subject"))); err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
@@ -0,0 +1,39 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+)
+
+// InviteCodeHeader carries the invite code for CreateMyMember when membership is invite-only.
+// It is a header (not a body field) because the request schema is owned by the OpenAPI contract.
+const InviteCodeHeader = "X-Invite-Code"
+
+type inviteCodeKey struct{}
+
+func WithInviteCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, inviteCodeKey{}, code)
+}
+
+func InviteCodeFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(inviteCodeKey{}).(string)
+	return v
+}
+
+// newInviteCodeMiddleware copies X-Invite-Code into the context of CreateMyMember requests.
+func newInviteCodeMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "CreateMyMember" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			if code := strings.TrimSpace(r.Header.Get(InviteCodeHeader)); code != "" {
+				ctx = WithInviteCode(ctx, code)
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
@@ -76,25 +76,28 @@ func newTestServer(t *testing.T, b backend) *testServer {
 		memberRepo = pgmemberrepo.NewRepo(pool, issuer)
 		tripRepo = pgtriprepo.NewRepo(pool)
 		rsvpRepo = pgrsvprepo.NewRepo(pool)
-		idemStore = pgidempotency.NewStore(pool, issuer)
+		idemStore = pgidempotency.NewStoreWithClock(pool, issuer, clk)
 	case backendMemory:
 		memberRepo = memmemberrepo.NewRepo()
 		tripRepo = memtriprepo.NewRepo()
 		rsvpRepo = memrsvprepo.NewRepo()
-		idemStore = memidempotency.NewStore()
+		idemStore = memidempotency.NewStoreWithClock(clk)
 	default:
 		t.Fatalf("unknown backend: %s", b)
 	}
 
 	memberSvc := members.NewService(memberRepo, clk)
 	tripSvc := trips.NewService(tripRepo, memberRepo, rsvpRepo)
-	api := httpapi.NewServer(memberSvc, tripSvc, idemStore)
+	api := httpapi.NewServer(memberSvc, tripSvc)
 
 	// Integration tests use the dev auth middleware to stay fully local and deterministic.
 	// We pass empty default subject to ensure requests MUST provide X-Debug-Subject, allowing
 	// auth-failure coverage.
 	authMW := httpapi.NewDevAuthMiddleware("")
-	handler := httpapi.NewRouterWithOptions(api, httpapi.RouterOptions{AuthMiddleware: authMW})
+	handler := httpapi.NewRouterWithOptions(api, httpapi.RouterOptions{
+		AuthMiddleware:        authMW,
+		IdempotencyMiddleware: httpapi.NewIdempotencyMiddleware(idemStore, clk, 24*time.Hour),
+	})
 
 	srv := httptest.NewServer(handler)
 	t.Cleanup(srv.Close)
@@ -0,0 +1,183 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Itinerary routes are out-of-spec: the OpenAPI contract has no per-day itinerary yet.
+const (
+	// TripItineraryPath returns the trip's itinerary (GET).
+	TripItineraryPath = "/trips/{tripId}/itinerary"
+	// TripItineraryDayPath creates/replaces (PUT) or removes (DELETE) the plan for one date
+	// (YYYY-MM-DD).
+	TripItineraryDayPath = "/trips/{tripId}/itinerary/{date}"
+)
+
+// itineraryDateLayout is the wire format of itinerary dates.
+const itineraryDateLayout = "2006-01-02"
+
+// TripItinerary is the trips use-case surface needed by the itinerary routes.
+type TripItinerary interface {
+	GetTripItinerary(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.ItineraryDay, error)
+	PutTripItineraryDay(ctx context.Context, caller domain.MemberID, tripID domain.TripID, date time.Time, in trips.ItineraryDayInput) (domain.ItineraryDay, error)
+	DeleteTripItineraryDay(ctx context.Context, caller domain.MemberID, tripID domain.TripID, date time.Time) error
+}
+
+type itineraryStopJSON struct {
+	Type     string       `json:"type"`
+	Location locationJSON `json:"location"`
+	Time     *string      `json:"time"`
+	Notes    *string      `json:"notes"`
+}
+
+type routeSegmentJSON struct {
+	Name          string   `json:"name"`
+	DistanceMiles *float64 `json:"distanceMiles"`
+	Notes         *string  `json:"notes"`
+}
+
+type itineraryDayJSON struct {
+	Date      string              `json:"date"`
+	Title     *string             `json:"title"`
+	Notes     *string             `json:"notes"`
+	Stops     []itineraryStopJSON `json:"stops"`
+	Segments  []routeSegmentJSON  `json:"segments"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+func itineraryDayToJSON(d domain.ItineraryDay) itineraryDayJSON {
+	out := itineraryDayJSON{
+		Date:      d.Date.UTC().Format(itineraryDateLayout),
+		Title:     d.Title,
+		Notes:     d.Notes,
+		Stops:     make([]itineraryStopJSON, 0, len(d.Stops)),
+		Segments:  make([]routeSegmentJSON, 0, len(d.Segments)),
+		UpdatedAt: d.UpdatedAt,
+	}
+	for _, s := range d.Stops {
+		l := s.Location
+		out.Stops = append(out.Stops, itineraryStopJSON{
+			Type:     string(s.Type),
+			Location: locationJSON{Label: l.Label, Address: l.Address, Latitude: l.Latitude, Longitude: l.Longitude},
+			Time:     s.Time,
+			Notes:    s.Notes,
+		})
+	}
+	for _, s := range d.Segments {
+		out.Segments = append(out.Segments, routeSegmentJSON{Name: s.Name, DistanceMiles: s.DistanceMiles, Notes: s.Notes})
+	}
+	return out
+}
+
+func itineraryToJSON(days []domain.ItineraryDay) []itineraryDayJSON {
+	out := make([]itineraryDayJSON, 0, len(days))
+	for _, d := range days {
+		out = append(out, itineraryDayToJSON(d))
+	}
+	return out
+}
+
+// tripDetailsExtendedJSON is the spec's trip details plus the out-of-spec itinerary and
+// announcements; each is omitted when empty.
+type tripDetailsExtendedJSON struct {
+	oas.TripDetails
+	Itinerary     []itineraryDayJSON     `json:"itinerary,omitempty"`
+	Announcements []tripAnnouncementJSON `json:"announcements,omitempty"`
+}
+
+// getTripDetailsExtended adds the itinerary and announcements to a successful GetTripDetails response.
+type getTripDetailsExtended struct {
+	oas.GetTripDetails200JSONResponse
+	itinerary     []domain.ItineraryDay
+	announcements []domain.TripAnnouncement
+}
+
+func (r getTripDetailsExtended) VisitGetTripDetailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(map[string]any{
+		"trip": tripDetailsExtendedJSON{
+			TripDetails:   r.Trip,
+			Itinerary:     itineraryToJSON(r.itinerary),
+			Announcements: tripAnnouncementsToJSON(r.announcements),
+		},
+	})
+}
+
+func mountTripItinerary(r chi.Router, m MemberResolver, it TripItinerary) {
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+	dateParam := func(w http.ResponseWriter, req *http.Request) (time.Time, bool) {
+		d, err := time.Parse(itineraryDateLayout, chi.URLParam(req, "date"))
+		if err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid date", map[string]any{"date": "must be YYYY-MM-DD"})
+			return time.Time{}, false
+		}
+		return d, true
+	}
+
+	r.Get(TripItineraryPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		days, err := it.GetTripItinerary(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"itinerary": itineraryToJSON(days)})
+	}))
+
+	r.Put(TripItineraryDayPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		date, ok := dateParam(w, req)
+		if !ok {
+			return
+		}
+		var body struct {
+			Title    *string             `json:"title"`
+			Notes    *string             `json:"notes"`
+			Stops    []itineraryStopJSON `json:"stops"`
+			Segments []routeSegmentJSON  `json:"segments"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in := trips.ItineraryDayInput{Title: body.Title, Notes: body.Notes}
+		for _, s := range body.Stops {
+			l := s.Location
+			in.Stops = append(in.Stops, domain.ItineraryStop{
+				Type:     domain.ItineraryStopType(s.Type),
+				Location: domain.Location{Label: l.Label, Address: l.Address, Latitude: l.Latitude, Longitude: l.Longitude},
+				Time:     s.Time,
+				Notes:    s.Notes,
+			})
+		}
+		for _, s := range body.Segments {
+			in.Segments = append(in.Segments, domain.RouteSegment{Name: s.Name, DistanceMiles: s.DistanceMiles, Notes: s.Notes})
+		}
+		d, err := it.PutTripItineraryDay(req.Context(), me.ID, tripID(req), date, in)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"day": itineraryDayToJSON(d)})
+	}))
+
+	r.Delete(TripItineraryDayPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		date, ok := dateParam(w, req)
+		if !ok {
+			return
+		}
+		if err := it.DeleteTripItineraryDay(req.Context(), me.ID, tripID(req), date); err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/invitations"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Member invitation routes are out-of-spec: active members invite people to join (admins use
+// cmd/invites).
+const (
+	// MyInvitationsPath lists (GET) the invitations the caller issued and issues (POST) a new one.
+	// The invite code is only returned by POST; it is not stored.
+	MyInvitationsPath = "/members/me/invitations"
+	// MyInvitationPath revokes (DELETE) an invitation the caller issued.
+	MyInvitationPath = "/members/me/invitations/{invitationId}"
+)
+
+// MemberInvitations is the invitations use-case surface needed by the member invitation routes.
+type MemberInvitations interface {
+	CreateMemberInvite(ctx context.Context, subject domain.SubjectID, in invitations.CreateInput) (invitations.Created, error)
+	ListMemberInvites(ctx context.Context, subject domain.SubjectID) ([]domain.Invitation, error)
+	RevokeMemberInvite(ctx context.Context, subject domain.SubjectID, id domain.InvitationID) (domain.Invitation, error)
+}
+
+type invitationJSON struct {
+	InvitationID string     `json:"invitationId"`
+	Email        *string    `json:"email"`
+	MaxUses      int        `json:"maxUses"`
+	UseCount     int        `json:"useCount"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	RevokedAt    *time.Time `json:"revokedAt"`
+}
+
+func invitationToJSON(inv domain.Invitation) invitationJSON {
+	return invitationJSON{
+		InvitationID: string(inv.ID),
+		Email:        inv.Email,
+		MaxUses:      inv.MaxUses,
+		UseCount:     inv.UseCount,
+		CreatedAt:    inv.CreatedAt.UTC(),
+		ExpiresAt:    inv.ExpiresAt.UTC(),
+		RevokedAt:    inv.RevokedAt,
+	}
+}
+
+func mountMemberInvitations(r chi.Router, inv MemberInvitations) {
+	r.Get(MyInvitationsPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		list, err := inv.ListMemberInvites(req.Context(), sub)
+		if err != nil {
+			writeInvitationsError(w, req, err)
+			return
+		}
+		out := make([]invitationJSON, 0, len(list))
+		for _, i := range list {
+			out = append(out, invitationToJSON(i))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"invitations": out})
+	}))
+
+	r.Post(MyInvitationsPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		// Codes are shown once, so the response must never be cached (or replayed).
+		w.Header().Set("Cache-Control", "no-store")
+		var body struct {
+			Email         *string `json:"email"`
+			MaxUses       int     `json:"maxUses"`
+			ExpiresInDays int     `json:"expiresInDays"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		if body.ExpiresInDays < 0 {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid invitation", map[string]any{"expiresInDays": "must be positive"})
+			return
+		}
+		created, err := inv.CreateMemberInvite(req.Context(), sub, invitations.CreateInput{
+			Email:   body.Email,
+			MaxUses: body.MaxUses,
+			TTL:     time.Duration(body.ExpiresInDays) * 24 * time.Hour,
+		})
+		if err != nil {
+			writeInvitationsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"invitation": invitationToJSON(created.Invitation),
+			"code":       created.Code,
+		})
+	}))
+
+	r.Delete(MyInvitationPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		revoked, err := inv.RevokeMemberInvite(req.Context(), sub, domain.InvitationID(chi.URLParam(req, "invitationId")))
+		if err != nil {
+			writeInvitationsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"invitation": invitationToJSON(revoked)})
+	}))
+}
+
+func writeInvitationsError(w http.ResponseWriter, r *http.Request, err error) {
+	if ae := (*invitations.Error)(nil); errors.As(err, &ae) {
+		writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+		return
+	}
+	writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+}
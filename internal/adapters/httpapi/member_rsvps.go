@@ -0,0 +1,153 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Organizer-managed RSVP routes are out-of-spec: the OpenAPI contract only has the
+// self-service SetMyRSVP. Organizers use them to change the roster on a member's behalf,
+// including after the trip's RSVP deadline. Every change needs a reason, and like the in-spec
+// mutations they require an Idempotency-Key.
+const (
+	// TripMemberRSVPPath sets (PUT) a member's RSVP.
+	TripMemberRSVPPath = "/trips/{tripId}/rsvps/{memberId}"
+	// TripMemberRSVPClearPath resets (POST) a member's RSVP to UNSET.
+	TripMemberRSVPClearPath = "/trips/{tripId}/rsvps/{memberId}/clear"
+	// TripMemberRSVPMovePath moves (POST) a member's RSVP to another trip.
+	TripMemberRSVPMovePath = "/trips/{tripId}/rsvps/{memberId}/move"
+)
+
+// MemberRSVPs is the trips use-case surface needed by the organizer-managed RSVP routes.
+type MemberRSVPs interface {
+	SetMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, in trips.MemberRSVPInput) (domain.MyRSVP, error)
+	ClearMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, reason string) (domain.MyRSVP, error)
+	MoveMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, in trips.MoveMemberRSVPInput) (domain.MyRSVP, error)
+}
+
+// memberRSVPJSON is the spec's MyRSVP plus who made the last change and why.
+type memberRSVPJSON struct {
+	oas.MyRSVP
+	ChangedBy        *string `json:"changedBy"`
+	ChangeReason     *string `json:"changeReason"`
+	CapacityOverride bool    `json:"capacityOverride"`
+}
+
+func memberRSVPToJSON(my domain.MyRSVP) memberRSVPJSON {
+	out := memberRSVPJSON{
+		MyRSVP:           myRSVPFromDomain(my),
+		ChangeReason:     my.ChangeReason,
+		CapacityOverride: my.CapacityOverride,
+	}
+	if my.ChangedBy != "" {
+		by := string(my.ChangedBy)
+		out.ChangedBy = &by
+	}
+	return out
+}
+
+func mountMemberRSVPs(r chi.Router, m MemberResolver, rs MemberRSVPs, idempotency func(http.Handler) http.Handler) {
+	if idempotency != nil {
+		r = r.With(idempotency)
+	}
+	ids := func(req *http.Request) (domain.TripID, domain.MemberID) {
+		return domain.TripID(chi.URLParam(req, "tripId")), domain.MemberID(chi.URLParam(req, "memberId"))
+	}
+	write := func(w http.ResponseWriter, req *http.Request, my domain.MyRSVP, err error) {
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		if len(my.RequirementWarnings) > 0 {
+			codes := make([]string, 0, len(my.RequirementWarnings))
+			for _, u := range my.RequirementWarnings {
+				codes = append(codes, string(u.Code))
+			}
+			w.Header().Set(RequirementWarningsHeader, strings.Join(codes, ", "))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"rsvp": memberRSVPToJSON(my)})
+	}
+
+	r.Put(TripMemberRSVPPath, withIdempotencyKey(withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Response       string   `json:"response"`
+			VehicleID      *string  `json:"vehicleId"`
+			PassengerCount *int     `json:"passengerCount"`
+			PassengerNames []string `json:"passengerNames"`
+			Reason         string   `json:"reason"`
+			BypassCapacity bool     `json:"bypassCapacity"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in := trips.MemberRSVPInput{
+			SetMyRSVPInput: trips.SetMyRSVPInput{
+				Response:       domain.RSVPResponse(body.Response),
+				PassengerCount: body.PassengerCount,
+				PassengerNames: body.PassengerNames,
+			},
+			Reason:         body.Reason,
+			BypassCapacity: body.BypassCapacity,
+		}
+		if body.VehicleID != nil {
+			id := domain.VehicleID(*body.VehicleID)
+			in.VehicleID = &id
+		}
+		tripID, memberID := ids(req)
+		my, err := rs.SetMemberRSVP(req.Context(), me.ID, tripID, memberID, in)
+		write(w, req, my, err)
+	})))
+
+	r.Post(TripMemberRSVPClearPath, withIdempotencyKey(withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		tripID, memberID := ids(req)
+		my, err := rs.ClearMemberRSVP(req.Context(), me.ID, tripID, memberID, body.Reason)
+		write(w, req, my, err)
+	})))
+
+	r.Post(TripMemberRSVPMovePath, withIdempotencyKey(withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			ToTripID       string `json:"toTripId"`
+			Reason         string `json:"reason"`
+			BypassCapacity bool   `json:"bypassCapacity"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		tripID, memberID := ids(req)
+		my, err := rs.MoveMemberRSVP(req.Context(), me.ID, tripID, memberID, trips.MoveMemberRSVPInput{
+			ToTripID:       domain.TripID(body.ToTripID),
+			Reason:         body.Reason,
+			BypassCapacity: body.BypassCapacity,
+		})
+		write(w, req, my, err)
+	})))
+}
+
+// withIdempotencyKey rejects requests without an Idempotency-Key, matching the in-spec
+// mutations whose key is required.
+func withIdempotencyKey(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimSpace(r.Header.Get("Idempotency-Key")) == "" {
+			writeOASError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "missing Idempotency-Key header", map[string]any{"Idempotency-Key": "is required"})
+			return
+		}
+		h(w, r)
+	}
+}
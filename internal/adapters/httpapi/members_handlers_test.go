@@ -2,18 +2,23 @@ package httpapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
 	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
 	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	meminvitationrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/invitationrepo"
+	memmailer "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/mailer"
 	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
 	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
 	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/invitations"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwks_testutil"
@@ -49,14 +54,17 @@ func newTestMemberRouter(t *testing.T) (http.Handler, func(now time.Time, kid st
 
 	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
 	repo := memmemberrepo.NewRepo()
-	idem := memidempotency.NewStore()
+	idem := memidempotency.NewStoreWithClock(clk)
 	memberSvc := members.NewService(repo, clk)
 
 	tripRepo := memtriprepo.NewRepo()
 	rsvpRepo := memrsvprepo.NewRepo()
 	tripSvc := trips.NewService(tripRepo, repo, rsvpRepo)
-	api := NewServer(memberSvc, tripSvc, idem)
-	h := NewRouterWithOptions(api, RouterOptions{AuthMiddleware: NewAuthMiddleware(v)})
+	api := NewServer(memberSvc, tripSvc)
+	h := NewRouterWithOptions(api, RouterOptions{
+		AuthMiddleware:        NewAuthMiddleware(v),
+		IdempotencyMiddleware: NewIdempotencyMiddleware(idem, clk, 24*time.Hour),
+	})
 
 	mint := func(now time.Time, kid string) string {
 		jwt, err := jwks_testutil.MintRS256JWT(
@@ -152,8 +160,8 @@ func TestMembers_UpdateMe_IdempotentReplayAndConflictOnReuse(t *testing.T) {
 		t.Fatalf("patch1 status=%d body=%s", rec1.Code, rec1.Body.String())
 	}
 
-	// Same key + same semantic payload (after normalization) should replay.
-	body2 := `{"displayName":"Alice   Smith"}`
+	// Same key + same payload (formatted differently) should replay.
+	body2 := `{ "displayName": "  Alice   Smith " }`
 	req2 := httptest.NewRequest(http.MethodPatch, "/members/me", bytes.NewBufferString(body2))
 	req2.Header.Set("Authorization", authz)
 	req2.Header.Set("Content-Type", "application/json")
@@ -176,3 +184,270 @@ func TestMembers_UpdateMe_IdempotentReplayAndConflictOnReuse(t *testing.T) {
 		t.Fatalf("patch3 status=%d body=%s", rec3.Code, rec3.Body.String())
 	}
 }
+
+func TestMembers_Create_InviteOnlyUsesInviteCodeHeader(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	repo := memmemberrepo.NewRepo()
+	invRepo := meminvitationrepo.NewRepo()
+	memberSvc := members.NewServiceWithOptions(repo, clk, members.Options{RequireInvite: true, Invitations: invRepo})
+	tripSvc := trips.NewService(memtriprepo.NewRepo(), repo, memrsvprepo.NewRepo())
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		AuthMiddleware: NewDevAuthMiddleware(""),
+	})
+
+	created, err := invitations.NewService(invRepo, repo, clk).Create(context.Background(), invitations.CreateInput{CreatedByAdmin: "ops"})
+	if err != nil {
+		t.Fatalf("Create invite: %v", err)
+	}
+
+	create := func(code string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/members", bytes.NewBufferString(`{"displayName":"Alice Smith","email":"alice@example.com"}`))
+		req.Header.Set("X-Debug-Subject", "sub-1")
+		req.Header.Set("Content-Type", "application/json")
+		if code != "" {
+			req.Header.Set(InviteCodeHeader, code)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	requireOASErrorCode(t, create(""), http.StatusUnprocessableEntity, "INVITE_CODE_REQUIRED")
+	requireOASErrorCode(t, create("WRONG-CODE"), http.StatusUnprocessableEntity, "INVITE_CODE_INVALID")
+	if rec := create(created.Code); rec.Code != http.StatusCreated {
+		t.Fatalf("create with invite status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMembers_EmailVerificationRoutes(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	repo := memmemberrepo.NewRepo()
+	outbox := memmailer.NewOutbox()
+	memberSvc := members.NewServiceWithOptions(repo, clk, members.Options{
+		Mailer:       outbox,
+		Verification: members.VerificationOptions{ConfirmURL: "https://api.example.org" + EmailVerificationConfirmPath},
+	})
+	tripSvc := trips.NewService(memtriprepo.NewRepo(), repo, memrsvprepo.NewRepo())
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		// No default subject: anything not public must carry X-Debug-Subject.
+		AuthMiddleware:    NewDevAuthMiddleware(""),
+		EmailVerification: memberSvc,
+	})
+
+	if _, err := memberSvc.CreateMyMember(context.Background(), "sub-1", members.CreateMyMemberInput{DisplayName: "Alice", Email: "alice@example.com"}); err != nil {
+		t.Fatalf("CreateMyMember: %v", err)
+	}
+	sent := outbox.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("sent = %+v, want one verification email", sent)
+	}
+	_, query, ok := strings.Cut(sent[0].Body, EmailVerificationConfirmPath)
+	if !ok {
+		t.Fatalf("email has no confirm link: %q", sent[0].Body)
+	}
+	query, _, _ = strings.Cut(query, "\n")
+
+	// The confirm link works without credentials.
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, EmailVerificationConfirmPath+query, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("confirm status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, EmailVerificationConfirmPath+"?token=bogus", nil))
+	requireOASErrorCode(t, rec, http.StatusUnprocessableEntity, "VERIFICATION_TOKEN_INVALID")
+
+	// Re-sending requires authentication.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, EmailVerificationRequestPath, nil))
+	requireOASErrorCode(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	req := httptest.NewRequest(http.MethodPost, EmailVerificationRequestPath+"?kind=email", nil)
+	req.Header.Set("X-Debug-Subject", "sub-1")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireOASErrorCode(t, rec, http.StatusConflict, "EMAIL_ALREADY_VERIFIED")
+}
+
+func TestMembers_IdentityRoutes(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	repo := memmemberrepo.NewRepo()
+	memberSvc := members.NewService(repo, clk)
+	tripSvc := trips.NewService(memtriprepo.NewRepo(), repo, memrsvprepo.NewRepo())
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		AuthMiddleware:   NewDevAuthMiddlewareWithIssuer("", "dev"),
+		MemberIdentities: memberSvc,
+		IdentityTokens:   NewDevIdentityTokenVerifier("dev"),
+	})
+	do := func(method, target, sub, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if sub != "" {
+			req.Header.Set("X-Debug-Subject", sub)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	type identitiesJSON struct {
+		Identities []memberIdentityJSON `json:"identities"`
+	}
+
+	requireOASErrorCode(t, do(http.MethodGet, MyIdentitiesPath, "", ""), http.StatusUnauthorized, "UNAUTHORIZED")
+	requireOASErrorCode(t, do(http.MethodGet, MyIdentitiesPath, "sub-1", ""), http.StatusNotFound, "MEMBER_NOT_PROVISIONED")
+
+	rec := do(http.MethodPost, "/members", "sub-1", `{"displayName":"Alice","email":"alice@example.com"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("provision status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodPost, "/members", "sub-2", `{"displayName":"Bob","email":"bob@example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("provision bob status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	// Linking needs a token for the other login; one already bound to another member is refused.
+	requireOASErrorCode(t, do(http.MethodPost, MyIdentitiesPath, "sub-1", `{"token":" "}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodPost, MyIdentitiesPath, "sub-1", `{"token":"sub-2"}`), http.StatusConflict, "IDENTITY_ALREADY_LINKED")
+	rec = do(http.MethodPost, MyIdentitiesPath, "sub-1", `{"token":"sub-1-phone"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("link status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var linked struct {
+		Identity memberIdentityJSON `json:"identity"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &linked); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if linked.Identity.Issuer != "dev" || linked.Identity.Subject != "sub-1-phone" {
+		t.Fatalf("linked=%+v", linked.Identity)
+	}
+
+	// The new login resolves to the same member and sees both logins.
+	rec = do(http.MethodGet, MyIdentitiesPath, "sub-1-phone", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var list identitiesJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(list.Identities) != 2 {
+		t.Fatalf("identities=%+v", list.Identities)
+	}
+
+	// Unlinking the original login leaves the linked one; the last login stays.
+	if rec = do(http.MethodDelete, MyIdentitiesPath+"?issuer=dev&subject=sub-1", "sub-1-phone", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("unlink status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodGet, MyIdentitiesPath, "sub-1", ""), http.StatusNotFound, "MEMBER_NOT_PROVISIONED")
+	requireOASErrorCode(t, do(http.MethodDelete, MyIdentitiesPath+"?issuer=dev&subject=sub-1", "sub-1-phone", ""), http.StatusNotFound, "IDENTITY_NOT_FOUND")
+	requireOASErrorCode(t, do(http.MethodDelete, MyIdentitiesPath+"?issuer=dev&subject=sub-1-phone", "sub-1-phone", ""), http.StatusConflict, "LAST_IDENTITY")
+	requireOASErrorCode(t, do(http.MethodDelete, MyIdentitiesPath, "sub-1-phone", ""), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+}
+
+func TestMembers_MemberInvitationRoutes(t *testing.T) {
+	t.Parallel()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	repo := memmemberrepo.NewRepo()
+	invRepo := meminvitationrepo.NewRepo()
+	inviteSvc := invitations.NewService(invRepo, repo, clk)
+	memberSvc := members.NewServiceWithOptions(repo, clk, members.Options{RequireInvite: true, Invitations: invRepo})
+	tripSvc := trips.NewService(memtriprepo.NewRepo(), repo, memrsvprepo.NewRepo())
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		AuthMiddleware:    NewDevAuthMiddleware(""),
+		MemberInvitations: inviteSvc,
+	})
+	do := func(method, target, sub, code, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-Debug-Subject", sub)
+		req.Header.Set("Content-Type", "application/json")
+		if code != "" {
+			req.Header.Set(InviteCodeHeader, code)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	requireOASErrorCode(t, do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{}`), http.StatusNotFound, "MEMBER_NOT_PROVISIONED")
+
+	first, err := inviteSvc.Create(context.Background(), invitations.CreateInput{CreatedByAdmin: "ops"})
+	if err != nil {
+		t.Fatalf("Create invite: %v", err)
+	}
+	if rec := do(http.MethodPost, "/members", "sub-1", first.Code, `{"displayName":"Alice","email":"alice@example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create alice status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{"expiresInDays":-1}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{"email":"not an email"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	// Alice invites Bob; the code is only in the create response.
+	rec := do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{"email":"bob@example.com","expiresInDays":7}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("invite status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Fatalf("Cache-Control=%q, want no-store", got)
+	}
+	var created struct {
+		Invitation invitationJSON `json:"invitation"`
+		Code       string         `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if created.Code == "" || created.Invitation.MaxUses != 1 || !created.Invitation.ExpiresAt.Equal(clk.Now().Add(7*24*time.Hour)) {
+		t.Fatalf("created=%+v", created)
+	}
+	if rec := do(http.MethodPost, "/members", "sub-2", created.Code, `{"displayName":"Bob","email":"bob@example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create bob status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	// Alice lists her invitations (not the admin's); Bob has issued none.
+	rec = do(http.MethodGet, MyInvitationsPath, "sub-1", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var list struct {
+		Invitations []invitationJSON `json:"invitations"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(list.Invitations) != 1 || list.Invitations[0].InvitationID != created.Invitation.InvitationID || list.Invitations[0].UseCount != 1 {
+		t.Fatalf("invitations=%+v", list.Invitations)
+	}
+	if strings.Contains(rec.Body.String(), created.Code) {
+		t.Fatalf("list leaks the invite code: %s", rec.Body.String())
+	}
+	rec = do(http.MethodGet, MyInvitationsPath, "sub-2", "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"invitations":[]`) {
+		t.Fatalf("bob list status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	// Member invitations are capped.
+	requireOASErrorCode(t, do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{"maxUses":10}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{"expiresInDays":30}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	// Only the issuer revokes an invitation.
+	rec = do(http.MethodPost, MyInvitationsPath, "sub-1", "", `{}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("invite status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	path := strings.Replace(MyInvitationPath, "{invitationId}", created.Invitation.InvitationID, 1)
+	requireOASErrorCode(t, do(http.MethodDelete, path, "sub-2", "", ""), http.StatusNotFound, "INVITATION_NOT_FOUND")
+	rec = do(http.MethodDelete, path, "sub-1", "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"revokedAt":"`) {
+		t.Fatalf("revoke status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodPost, "/members", "sub-3", created.Code, `{"displayName":"Carol","email":"carol@example.com"}`), http.StatusUnprocessableEntity, "INVITE_CODE_INVALID")
+}
@@ -0,0 +1,73 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+)
+
+// Passenger headers describe who rides along with the caller on SetMyRSVP. Like X-Vehicle-Id,
+// they are headers because the request schema is owned by the OpenAPI contract.
+const (
+	// PassengerCountHeader is the number of passengers (not counting the member).
+	PassengerCountHeader = "X-Passenger-Count"
+	// PassengerNamesHeader is a comma-separated list of passenger names.
+	PassengerNamesHeader = "X-Passenger-Names"
+)
+
+// Passengers is the passenger information sent with a SetMyRSVP request.
+// Count is nil when X-Passenger-Count was not sent; Names is nil when X-Passenger-Names was not sent.
+type Passengers struct {
+	Count *int
+	Names []string
+}
+
+type passengersKey struct{}
+
+func WithPassengers(ctx context.Context, p Passengers) context.Context {
+	return context.WithValue(ctx, passengersKey{}, p)
+}
+
+// PassengersFromContext returns the passenger headers sent with the request (zero value when absent).
+func PassengersFromContext(ctx context.Context) Passengers {
+	v, _ := ctx.Value(passengersKey{}).(Passengers)
+	return v
+}
+
+// newPassengersMiddleware copies X-Passenger-Count and X-Passenger-Names into the context of
+// SetMyRSVP requests. A count that is not an integer is rejected before the handler runs.
+func newPassengersMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "SetMyRSVP" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			var p Passengers
+			if raw := strings.TrimSpace(r.Header.Get(PassengerCountHeader)); raw != "" {
+				n, err := strconv.Atoi(raw)
+				if err != nil {
+					writeOASError(w, r, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid "+PassengerCountHeader, map[string]any{"passengerCount": "must be an integer"})
+					return nil, nil
+				}
+				p.Count = &n
+			}
+			if vals := r.Header.Values(PassengerNamesHeader); len(vals) > 0 {
+				p.Names = make([]string, 0)
+				for _, v := range vals {
+					for _, name := range strings.Split(v, ",") {
+						if name = strings.TrimSpace(name); name != "" {
+							p.Names = append(p.Names, name)
+						}
+					}
+				}
+			}
+			if p.Count != nil || p.Names != nil {
+				ctx = WithPassengers(ctx, p)
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package httpapi
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+// RateLimitPolicy selects the token bucket applied to each operation.
+//
+// PerOperation is keyed by operationId for in-spec routes and by "METHOD pattern" for
+// out-of-spec routes (e.g. "POST /trips/{tripId}/announcements"), and overrides Default; a
+// disabled (zero) limit turns limiting off for that operation.
+type RateLimitPolicy struct {
+	Default      ratelimit.Limit
+	PerOperation map[string]ratelimit.Limit
+	// DevAuth is set when dev auth is active; only then does X-Debug-Subject count as a
+	// credential.
+	DevAuth bool
+}
+
+func (p RateLimitPolicy) limitFor(operation string) ratelimit.Limit {
+	if l, ok := p.PerOperation[operation]; ok {
+		return l
+	}
+	return p.Default
+}
+
+// MaxPer returns the longest refill period of any enabled limit. A bucket idle that long is
+// full again, which makes it the idle cutoff for sweeping buckets.
+func (p RateLimitPolicy) MaxPer() time.Duration {
+	max := time.Duration(0)
+	if p.Default.Enabled() {
+		max = p.Default.Per
+	}
+	for _, l := range p.PerOperation {
+		if l.Enabled() && l.Per > max {
+			max = l.Per
+		}
+	}
+	return max
+}
+
+// RateLimitMiddleware throttles every route, in-spec or not, with a token bucket per operation
+// and caller. It runs in two stages around auth:
+//   - before auth, requests without a credential (and every request to a public route) are
+//     limited by client IP, so public routes and anonymous probing are limited too
+//   - after auth, callers are limited by their verified identity: the token's issuer and
+//     subject, or the API key ID for service accounts
+//
+// A credential that auth rejects is charged to the client IP, and an IP whose bucket is empty
+// is turned away before its credentials are checked, so forged or rotating credentials never
+// escape the IP limit.
+type RateLimitMiddleware struct {
+	store  ratelimit.Store
+	clk    clock.Clock
+	policy RateLimitPolicy
+}
+
+// NewRateLimitMiddleware builds the rate limiter; NewRouterWithOptions mounts both stages.
+//
+// Client IPs come from RemoteAddr (RealIP middleware runs first, so it reflects proxy headers).
+// Over-limit requests get 429 RATE_LIMITED with a Retry-After header (seconds).
+func NewRateLimitMiddleware(store ratelimit.Store, clk clock.Clock, policy RateLimitPolicy) *RateLimitMiddleware {
+	return &RateLimitMiddleware{store: store, clk: clk, policy: policy}
+}
+
+type rateLimitStateKey struct{}
+
+// rateLimitState carries a request's operation from the pre-auth stage to the post-auth stage.
+type rateLimitState struct {
+	operation string
+	limit     ratelimit.Limit
+	// ipLimited is set when the request was already limited by client IP.
+	ipLimited bool
+	// verified is set once auth has identified the caller.
+	verified bool
+}
+
+// handler is the pre-auth stage. It resolves each request's route on mx before it is served;
+// operationIDs maps in-spec routes ("METHOD pattern") to their operationId and is filled in once
+// all routes are mounted.
+func (m *RateLimitMiddleware) handler(mx *chi.Mux, operationIDs map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.RawPath
+			if path == "" {
+				path = r.URL.Path
+			}
+			route := r.Method + " " + mx.Find(chi.NewRouteContext(), r.Method, path)
+			if strings.HasSuffix(route, " ") {
+				// Unknown routes share one bucket per caller, so probing for paths is limited too.
+				route = r.Method + " *"
+			}
+			operation := route
+			if id, ok := operationIDs[route]; ok {
+				operation = id
+			}
+			limit := m.policy.limitFor(operation)
+			if !limit.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ipKey := operation + "|ip:" + clientIP(r)
+			state := &rateLimitState{operation: operation, limit: limit}
+			if isPublicPath(r.URL.Path) || !m.presentsCredential(r) {
+				state.ipLimited = true
+				if !m.take(w, r, ipKey, state, false) {
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rateLimitStateKey{}, state)))
+				return
+			}
+
+			// Credentials are checked by auth; only an IP that keeps failing is stopped here.
+			if !m.take(w, r, ipKey, state, true) {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rateLimitStateKey{}, state)))
+			if !state.verified {
+				_, _ = m.store.Take(r.Context(), ipKey, limit, m.clk.Now())
+			}
+		})
+	}
+}
+
+// afterAuth is the post-auth stage: it limits verified callers by identity.
+func (m *RateLimitMiddleware) afterAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, ok := r.Context().Value(rateLimitStateKey{}).(*rateLimitState)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity := verifiedRateLimitIdentity(r.Context())
+		if identity == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		state.verified = true
+		if !state.ipLimited && !m.take(w, r, state.operation+"|"+identity, state, false) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take spends a token from key (or only checks it when peek is set) and writes the 429 when the
+// bucket is empty. It reports whether the request may proceed.
+func (m *RateLimitMiddleware) take(w http.ResponseWriter, r *http.Request, key string, state *rateLimitState, peek bool) bool {
+	decide := m.store.Take
+	if peek {
+		decide = m.store.Peek
+	}
+	d, err := decide(r.Context(), key, state.limit, m.clk.Now())
+	if err != nil {
+		writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+		return false
+	}
+	if d.Allowed {
+		return true
+	}
+	retryAfter := int(math.Ceil(d.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	writeOASError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", "too many requests", map[string]any{
+		"operation":  state.operation,
+		"retryAfter": retryAfter,
+	})
+	return false
+}
+
+// presentsCredential reports whether auth will try to verify the request rather than treat it
+// as anonymous.
+func (m *RateLimitMiddleware) presentsCredential(r *http.Request) bool {
+	if strings.TrimSpace(r.Header.Get("Authorization")) != "" {
+		return true
+	}
+	return m.policy.DevAuth && strings.TrimSpace(r.Header.Get(debugSubjectHeader)) != ""
+}
+
+// oasOperationIDs maps the generated in-spec routes on r ("METHOD pattern") to their operationId.
+// The generated router registers each operation as a method value of oas.ServerInterfaceWrapper
+// named after the operationId, which is the only place route and operationId meet.
+func oasOperationIDs(r chi.Routes, into map[string]string) {
+	_ = chi.Walk(r, func(method, route string, h http.Handler, _ ...func(http.Handler) http.Handler) error {
+		fn, ok := h.(http.HandlerFunc)
+		if !ok {
+			return nil
+		}
+		name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+		if !strings.Contains(name, ".(*ServerInterfaceWrapper).") {
+			return nil
+		}
+		name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+		into[method+" "+route] = name
+		return nil
+	})
+}
+
+// verifiedRateLimitIdentity identifies the caller auth verified, or "" for anonymous requests.
+func verifiedRateLimitIdentity(ctx context.Context) string {
+	if key, ok := ServiceAccountFromContext(ctx); ok {
+		return "apikey:" + string(key.ID)
+	}
+	if sub, ok := SubjectFromContext(ctx); ok {
+		return "sub:" + authctx.IssuerOr(ctx, "") + "|" + sub
+	}
+	return ""
+}
+
+// clientIP returns the caller's address without port. RealIP middleware runs first,
+// so RemoteAddr already reflects trusted proxy headers.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,269 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memratelimit "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ratelimit"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwks_testutil"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/config"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+func newTestRateLimitedRouter(t *testing.T, authMW func(http.Handler) http.Handler, policy RateLimitPolicy) (http.Handler, *memclock.ManualClock) {
+	t.Helper()
+
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	memberRepo := memmemberrepo.NewRepo()
+	memberSvc := members.NewService(memberRepo, clk)
+	tripSvc := trips.NewService(memtriprepo.NewRepo(), memberRepo, memrsvprepo.NewRepo())
+	api := NewServer(memberSvc, tripSvc)
+
+	h := NewRouterWithOptions(api, RouterOptions{
+		AuthMiddleware:      authMW,
+		RateLimitMiddleware: NewRateLimitMiddleware(memratelimit.NewStore(), clk, policy),
+		EmailVerification:   memberSvc,
+	})
+	return h, clk
+}
+
+func doRateLimited(h http.Handler, path string, subject string, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if subject != "" {
+		req.Header.Set("X-Debug-Subject", subject)
+	}
+	if remoteAddr != "" {
+		req.RemoteAddr = remoteAddr
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimit_PerSubject_429WithRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	h, clk := newTestRateLimitedRouter(t, NewDevAuthMiddleware(""), RateLimitPolicy{
+		DevAuth: true,
+		Default: ratelimit.Limit{Burst: 1, Per: 10 * time.Second},
+	})
+
+	// First call is admitted (and fails downstream because alice is not provisioned).
+	rr := doRateLimited(h, "/members", "alice", "")
+	if rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("first request unexpectedly limited: %s", rr.Body.String())
+	}
+
+	rr = doRateLimited(h, "/members", "alice", "")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429 body=%s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Retry-After"); got != "10" {
+		t.Fatalf("Retry-After=%q want=10", got)
+	}
+	var er oas.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &er); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if er.Error.Code != "RATE_LIMITED" {
+		t.Fatalf("code=%q want=RATE_LIMITED", er.Error.Code)
+	}
+	details, err := er.Error.Details.Get()
+	if err != nil || details["retryAfter"] != float64(10) || details["operation"] != "ListMembers" {
+		t.Fatalf("details=%v err=%v", details, err)
+	}
+
+	// Another subject has its own bucket.
+	if rr := doRateLimited(h, "/members", "bob", ""); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("bob unexpectedly limited")
+	}
+
+	// The bucket refills.
+	clk.Add(10 * time.Second)
+	if rr := doRateLimited(h, "/members", "alice", ""); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("alice still limited after refill")
+	}
+}
+
+func TestRateLimit_PerOperationOverride(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestRateLimitedRouter(t, NewDevAuthMiddleware(""), RateLimitPolicy{
+		DevAuth: true,
+		Default: ratelimit.Limit{Burst: 1, Per: time.Minute},
+		PerOperation: map[string]ratelimit.Limit{
+			"GetMyMemberProfile": {},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		if rr := doRateLimited(h, "/members/me", "alice", ""); rr.Code == http.StatusTooManyRequests {
+			t.Fatalf("request %d limited; operation override should disable limiting", i)
+		}
+	}
+
+	// Buckets are per operation, so ListMembers still has its own token.
+	if rr := doRateLimited(h, "/members", "alice", ""); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("ListMembers limited by another operation's bucket")
+	}
+	if rr := doRateLimited(h, "/members", "alice", ""); rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429", rr.Code)
+	}
+}
+
+func TestRateLimit_FallsBackToClientIP(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestRateLimitedRouter(t, nil, RateLimitPolicy{
+		Default: ratelimit.Limit{Burst: 1, Per: time.Minute},
+	})
+
+	if rr := doRateLimited(h, "/members", "", "192.0.2.1:1234"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("first request unexpectedly limited")
+	}
+	// Same IP, different source port.
+	if rr := doRateLimited(h, "/members", "", "192.0.2.1:5678"); rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429", rr.Code)
+	}
+	if rr := doRateLimited(h, "/members", "", "192.0.2.2:1234"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("different IP unexpectedly limited")
+	}
+}
+
+func TestRateLimit_RunsBeforeAuthOnOutOfSpecRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestRateLimitedRouter(t, NewDevAuthMiddleware(""), RateLimitPolicy{
+		DevAuth: true,
+		Default: ratelimit.Limit{Burst: 1, Per: time.Minute},
+		PerOperation: map[string]ratelimit.Limit{
+			"GET /healthz": {},
+		},
+	})
+
+	// The public confirmation link is limited per client IP under its route pattern.
+	confirm := EmailVerificationConfirmPath + "?token=bogus"
+	if rr := doRateLimited(h, confirm, "", "192.0.2.1:1234"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("first confirm unexpectedly limited")
+	}
+	rr := doRateLimited(h, confirm, "", "192.0.2.1:1234")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429 body=%s", rr.Code, rr.Body.String())
+	}
+	var er oas.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &er); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if details, err := er.Error.Details.Get(); err != nil || details["operation"] != "GET "+EmailVerificationConfirmPath {
+		t.Fatalf("details=%v err=%v", details, err)
+	}
+
+	// Requests that auth would reject are limited before auth runs.
+	if rr := doRateLimited(h, "/members", "", "192.0.2.9:1234"); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("status=%d want=401", rr.Code)
+	}
+	if rr := doRateLimited(h, "/members", "", "192.0.2.9:1234"); rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429", rr.Code)
+	}
+
+	// Operations can be exempted by route pattern.
+	for i := 0; i < 3; i++ {
+		if rr := doRateLimited(h, "/healthz", "", "192.0.2.1:1234"); rr.Code != http.StatusOK {
+			t.Fatalf("healthz #%d status=%d", i, rr.Code)
+		}
+	}
+}
+
+func TestRateLimit_DebugSubjectIgnoredWithoutDevAuth(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestRateLimitedRouter(t, NewDevAuthMiddleware("dev|local"), RateLimitPolicy{
+		Default: ratelimit.Limit{Burst: 1, Per: time.Minute},
+	})
+
+	if rr := doRateLimited(h, "/members", "alice", "192.0.2.1:1234"); rr.Code == http.StatusTooManyRequests {
+		t.Fatalf("first request unexpectedly limited")
+	}
+	// A new X-Debug-Subject does not buy a fresh bucket.
+	if rr := doRateLimited(h, "/members", "bob", "192.0.2.1:1234"); rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status=%d want=429", rr.Code)
+	}
+}
+
+func TestRateLimit_KeyedByVerifiedSubject(t *testing.T) {
+	t.Parallel()
+
+	kp, err := jwks_testutil.GenerateRSAKeypair("kid-1")
+	if err != nil {
+		t.Fatalf("GenerateRSAKeypair: %v", err)
+	}
+	jwksSrv, setKeys := jwks_testutil.NewRotatingJWKSServer()
+	t.Cleanup(jwksSrv.Close)
+	setKeys([]jwks_testutil.Keypair{kp})
+	jwtCfg := config.JWTConfig{
+		Issuer:                 "test-iss",
+		Audience:               "test-aud",
+		JWKSURL:                jwksSrv.URL,
+		JWKSRefreshInterval:    10 * time.Minute,
+		JWKSMinRefreshInterval: time.Second,
+		HTTPTimeout:            2 * time.Second,
+	}
+	now := time.Unix(1700000000, 0)
+	v := jwtverifier.NewWithOptions(jwtCfg, nil, fixedClockTrips{t: now})
+	mint := func(sub string, issuedAt time.Time) string {
+		tok, err := jwks_testutil.MintRS256JWT(kp, jwtCfg.Issuer, jwtCfg.Audience, sub, issuedAt, 10*time.Minute, nil)
+		if err != nil {
+			t.Fatalf("MintRS256JWT: %v", err)
+		}
+		return tok
+	}
+
+	h, _ := newTestRateLimitedRouter(t, NewAuthMiddleware(v), RateLimitPolicy{
+		Default: ratelimit.Limit{Burst: 2, Per: time.Minute},
+	})
+	do := func(authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, "/members", nil)
+		req.Header.Set("Authorization", authorization)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// Refreshing the token keeps the subject's bucket.
+	if code := do("Bearer " + mint("alice", now)); code == http.StatusTooManyRequests {
+		t.Fatalf("first request unexpectedly limited")
+	}
+	if code := do("Bearer " + mint("alice", now.Add(-time.Second))); code == http.StatusTooManyRequests {
+		t.Fatalf("second request unexpectedly limited")
+	}
+	if code := do("Bearer " + mint("alice", now.Add(-2*time.Second))); code != http.StatusTooManyRequests {
+		t.Fatalf("refreshed token: status=%d want=429", code)
+	}
+	// Verified callers behind the same IP keep their own buckets.
+	if code := do("Bearer " + mint("bob", now)); code == http.StatusTooManyRequests {
+		t.Fatalf("bob unexpectedly limited")
+	}
+
+	// Rotating forged tokens are charged to the client IP.
+	for i := 0; i < 2; i++ {
+		if code := do(fmt.Sprintf("Bearer junk-%d", i)); code != http.StatusUnauthorized {
+			t.Fatalf("forged #%d status=%d want=401", i, code)
+		}
+	}
+	if code := do("Bearer junk-2"); code != http.StatusTooManyRequests {
+		t.Fatalf("forged status=%d want=429", code)
+	}
+}
@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// RequirementWarningsHeader lists, comma-separated, the codes of trip requirements the
+// caller's vehicle does not meet. It is set on successful SetMyRSVP responses because the
+// MyRSVP schema is owned by the OpenAPI contract.
+const RequirementWarningsHeader = "X-Requirement-Warnings"
+
+// TripRequirementsRosterPath lists attending rigs against the trip's vehicle requirements
+// (GET, organizers only). Requirements themselves are edited via TripSettingsPath.
+const TripRequirementsRosterPath = "/trips/{tripId}/requirements/roster"
+
+// RequirementsRosterLister is the trips use-case surface needed by TripRequirementsRosterPath.
+type RequirementsRosterLister interface {
+	GetTripRequirementsRoster(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.RequirementsRoster, error)
+}
+
+type vehicleSpecsJSON struct {
+	TireSizeInches *int     `json:"tireSizeInches"`
+	HasLockers     bool     `json:"hasLockers"`
+	RecoveryGear   []string `json:"recoveryGear"`
+	HamLicensed    bool     `json:"hamLicensed"`
+}
+
+type tripRequirementsJSON struct {
+	MinTireSizeInches  *int     `json:"minTireSizeInches"`
+	LockersRequired    bool     `json:"lockersRequired"`
+	RecoveryGear       []string `json:"recoveryGear"`
+	HamLicenseRequired bool     `json:"hamLicenseRequired"`
+	Strict             bool     `json:"strict"`
+}
+
+type unmetRequirementJSON struct {
+	Code        string   `json:"code"`
+	Message     string   `json:"message"`
+	MissingGear []string `json:"missingGear,omitempty"`
+}
+
+type rosterEntryJSON struct {
+	MemberID          string                 `json:"memberId"`
+	DisplayName       string                 `json:"displayName"`
+	Vehicle           *vehicleJSON           `json:"vehicle"`
+	MeetsRequirements bool                   `json:"meetsRequirements"`
+	Unmet             []unmetRequirementJSON `json:"unmet"`
+}
+
+func vehicleSpecsToJSON(s domain.VehicleSpecs) vehicleSpecsJSON {
+	return vehicleSpecsJSON{
+		TireSizeInches: s.TireSizeInches,
+		HasLockers:     s.HasLockers,
+		RecoveryGear:   gearToJSON(s.RecoveryGear),
+		HamLicensed:    s.HamLicensed,
+	}
+}
+
+func vehicleSpecsFromJSON(s vehicleSpecsJSON) domain.VehicleSpecs {
+	return domain.VehicleSpecs{
+		TireSizeInches: s.TireSizeInches,
+		HasLockers:     s.HasLockers,
+		RecoveryGear:   gearFromJSON(s.RecoveryGear),
+		HamLicensed:    s.HamLicensed,
+	}
+}
+
+func tripRequirementsToJSON(r domain.TripRequirements) tripRequirementsJSON {
+	return tripRequirementsJSON{
+		MinTireSizeInches:  r.MinTireSizeInches,
+		LockersRequired:    r.LockersRequired,
+		RecoveryGear:       gearToJSON(r.RecoveryGear),
+		HamLicenseRequired: r.HamLicenseRequired,
+		Strict:             r.Strict,
+	}
+}
+
+func tripRequirementsFromJSON(r tripRequirementsJSON) domain.TripRequirements {
+	return domain.TripRequirements{
+		MinTireSizeInches:  r.MinTireSizeInches,
+		LockersRequired:    r.LockersRequired,
+		RecoveryGear:       gearFromJSON(r.RecoveryGear),
+		HamLicenseRequired: r.HamLicenseRequired,
+		Strict:             r.Strict,
+	}
+}
+
+func unmetRequirementsToJSON(us []domain.UnmetRequirement) []unmetRequirementJSON {
+	out := make([]unmetRequirementJSON, 0, len(us))
+	for _, u := range us {
+		var missing []string
+		if len(u.MissingGear) > 0 {
+			missing = gearToJSON(u.MissingGear)
+		}
+		out = append(out, unmetRequirementJSON{Code: string(u.Code), Message: u.Message, MissingGear: missing})
+	}
+	return out
+}
+
+func gearToJSON(items []domain.RecoveryGearItem) []string {
+	out := make([]string, 0, len(items))
+	for _, g := range items {
+		out = append(out, string(g))
+	}
+	return out
+}
+
+func gearFromJSON(items []string) []domain.RecoveryGearItem {
+	var out []domain.RecoveryGearItem
+	for _, g := range items {
+		out = append(out, domain.RecoveryGearItem(strings.TrimSpace(g)))
+	}
+	return out
+}
+
+// setMyRSVPWithWarnings adds RequirementWarningsHeader to a successful SetMyRSVP response.
+type setMyRSVPWithWarnings struct {
+	oas.SetMyRSVP200JSONResponse
+	warnings []domain.UnmetRequirement
+}
+
+func (r setMyRSVPWithWarnings) VisitSetMyRSVPResponse(w http.ResponseWriter) error {
+	codes := make([]string, 0, len(r.warnings))
+	for _, u := range r.warnings {
+		codes = append(codes, string(u.Code))
+	}
+	w.Header().Set(RequirementWarningsHeader, strings.Join(codes, ", "))
+	return r.SetMyRSVP200JSONResponse.VisitSetMyRSVPResponse(w)
+}
+
+func mountRequirementsRoster(r chi.Router, m MemberResolver, l RequirementsRosterLister) {
+	r.Get(TripRequirementsRosterPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		roster, err := l.GetTripRequirementsRoster(req.Context(), me.ID, domain.TripID(chi.URLParam(req, "tripId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		out := make([]rosterEntryJSON, 0, len(roster.Entries))
+		for _, e := range roster.Entries {
+			entry := rosterEntryJSON{
+				MemberID:          string(e.Member.ID),
+				DisplayName:       e.Member.DisplayName,
+				MeetsRequirements: len(e.Unmet) == 0,
+				Unmet:             unmetRequirementsToJSON(e.Unmet),
+			}
+			if e.Vehicle != nil {
+				v := vehicleToJSON(*e.Vehicle)
+				entry.Vehicle = &v
+			}
+			out = append(out, entry)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"requirements": tripRequirementsToJSON(roster.Requirements),
+			"roster":       out,
+		})
+	}))
+}
@@ -0,0 +1,170 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Ride-share routes are out-of-spec: the OpenAPI contract has no ride-share board yet.
+const (
+	// RideSharePath returns the trip's ride-share board (GET).
+	RideSharePath = "/trips/{tripId}/rideshare"
+	// RideOfferPath creates/updates (PUT) or withdraws (DELETE) the caller's seat offer.
+	RideOfferPath = "/trips/{tripId}/rideshare/offer"
+	// RideRequestsPath requests a seat from a driver (POST).
+	RideRequestsPath = "/trips/{tripId}/rideshare/requests"
+	// RideRequestPath is acted on with POST .../accept, .../decline (driver) or .../cancel (rider).
+	RideRequestPath = "/trips/{tripId}/rideshare/requests/{requestId}"
+)
+
+// RideShareService is the trips use-case surface needed by the ride-share routes.
+type RideShareService interface {
+	GetRideShareBoard(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.RideShareBoard, error)
+	OfferRideSeats(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.RideOfferInput) (domain.RideOffer, error)
+	WithdrawRideOffer(ctx context.Context, caller domain.MemberID, tripID domain.TripID) error
+	RequestRide(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.RequestRideInput) (domain.RideRequest, error)
+	AcceptRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error)
+	DeclineRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error)
+	CancelRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error)
+}
+
+type memberRefJSON struct {
+	MemberID    string `json:"memberId"`
+	DisplayName string `json:"displayName"`
+}
+
+type rideOfferJSON struct {
+	Driver     memberRefJSON `json:"driver"`
+	Seats      int           `json:"seats"`
+	SeatsTaken int           `json:"seatsTaken"`
+	Notes      *string       `json:"notes"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+}
+
+type rideRequestJSON struct {
+	ID        string        `json:"id"`
+	Driver    memberRefJSON `json:"driver"`
+	Rider     memberRefJSON `json:"rider"`
+	Status    string        `json:"status"`
+	Note      *string       `json:"note"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+func memberRefToJSON(m domain.MemberSummary) memberRefJSON {
+	return memberRefJSON{MemberID: string(m.ID), DisplayName: m.DisplayName}
+}
+
+func rideOfferToJSON(o domain.RideOffer) rideOfferJSON {
+	return rideOfferJSON{
+		Driver:     memberRefToJSON(o.Driver),
+		Seats:      o.Seats,
+		SeatsTaken: o.SeatsTaken,
+		Notes:      o.Notes,
+		CreatedAt:  o.CreatedAt,
+		UpdatedAt:  o.UpdatedAt,
+	}
+}
+
+func rideRequestToJSON(r domain.RideRequest) rideRequestJSON {
+	return rideRequestJSON{
+		ID:        string(r.ID),
+		Driver:    memberRefToJSON(r.Driver),
+		Rider:     memberRefToJSON(r.Rider),
+		Status:    string(r.Status),
+		Note:      r.Note,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func mountRideShare(r chi.Router, m MemberResolver, rs RideShareService) {
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+	requestID := func(req *http.Request) domain.RideRequestID {
+		return domain.RideRequestID(chi.URLParam(req, "requestId"))
+	}
+
+	r.Get(RideSharePath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		board, err := rs.GetRideShareBoard(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		offers := make([]rideOfferJSON, 0, len(board.Offers))
+		for _, o := range board.Offers {
+			offers = append(offers, rideOfferToJSON(o))
+		}
+		mine := make([]rideRequestJSON, 0, len(board.MyRequests))
+		for _, rr := range board.MyRequests {
+			mine = append(mine, rideRequestToJSON(rr))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"offers": offers, "myRequests": mine})
+	}))
+
+	r.Put(RideOfferPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			Seats int     `json:"seats"`
+			Notes *string `json:"notes"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		o, err := rs.OfferRideSeats(req.Context(), me.ID, tripID(req), trips.RideOfferInput{Seats: body.Seats, Notes: body.Notes})
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"offer": rideOfferToJSON(o)})
+	}))
+
+	r.Delete(RideOfferPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		if err := rs.WithdrawRideOffer(req.Context(), me.ID, tripID(req)); err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r.Post(RideRequestsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			DriverMemberID string  `json:"driverMemberId"`
+			Note           *string `json:"note"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		rr, err := rs.RequestRide(req.Context(), me.ID, tripID(req), trips.RequestRideInput{DriverMemberID: domain.MemberID(body.DriverMemberID), Note: body.Note})
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"request": rideRequestToJSON(rr)})
+	}))
+
+	actions := map[string]func(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error){
+		"/accept":  rs.AcceptRideRequest,
+		"/decline": rs.DeclineRideRequest,
+		"/cancel":  rs.CancelRideRequest,
+	}
+	for suffix, act := range actions {
+		act := act
+		r.Post(RideRequestPath+suffix, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+			rr, err := act(req.Context(), me.ID, tripID(req), requestID(req))
+			if err != nil {
+				writeTripsError(w, req, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"request": rideRequestToJSON(rr)})
+		}))
+	}
+}
@@ -10,7 +10,63 @@ import (
 )
 
 type RouterOptions struct {
+	// CORSMiddleware runs before auth so preflight requests are answered without credentials.
+	CORSMiddleware func(http.Handler) http.Handler
 	AuthMiddleware func(http.Handler) http.Handler
+
+	// RateLimitMiddleware runs around auth on every route (by client IP before, by verified
+	// caller after); in-spec routes are limited by operationId.
+	RateLimitMiddleware *RateLimitMiddleware
+
+	// IdempotencyMiddleware wraps each in-spec operation after routing (route template is known)
+	// and before the strict handler decodes the body (raw bytes are available).
+	IdempotencyMiddleware func(http.Handler) http.Handler
+
+	// EmailVerification, when set, mounts the out-of-spec email verification routes.
+	EmailVerification EmailVerifier
+
+	// MemberIdentities, when set, mounts the out-of-spec login identity routes; linking also
+	// needs IdentityTokens to verify the token of the login being linked.
+	MemberIdentities MemberIdentities
+	IdentityTokens   IdentityTokenVerifier
+
+	// MemberInvitations, when set, mounts the out-of-spec member invitation routes.
+	MemberInvitations MemberInvitations
+
+	// VehicleGarage, when set, mounts the out-of-spec vehicle garage routes; TripRigs, when also
+	// set, adds the per-trip attendee rig listing.
+	VehicleGarage VehicleGarage
+	TripRigs      TripRigLister
+
+	// TripSettings, RideShare, RequirementsRoster, TripTemplates, TripSeries, TripItinerary,
+	// MemberRSVPs, RSVPHistory and TripAttendance, when set together with Members, mount the
+	// out-of-spec trip settings, ride-share, requirements roster, cloning/template, series,
+	// itinerary, organizer-managed RSVP, RSVP history and check-in routes.
+	Members            MemberResolver
+	TripSettings       TripSettingsEditor
+	RideShare          RideShareService
+	RequirementsRoster RequirementsRosterLister
+	TripTemplates      TripTemplates
+	TripSeries         TripSeries
+	TripItinerary      TripItinerary
+	MemberRSVPs        MemberRSVPs
+	RSVPHistory        RSVPHistory
+	TripAttendance     TripAttendance
+
+	// EmergencyInfo, when set together with Members, mounts the out-of-spec emergency info routes.
+	EmergencyInfo EmergencyInfo
+
+	// Waivers and TripWaivers, when set together with Members, mount the out-of-spec waiver
+	// acknowledgement and per-trip required waiver routes.
+	Waivers     Waivers
+	TripWaivers TripWaivers
+
+	// TripComments, when set together with Members, mounts the out-of-spec trip discussion routes.
+	TripComments TripComments
+
+	// TripAnnouncements, when set together with Members, mounts the out-of-spec organizer
+	// announcement routes.
+	TripAnnouncements TripAnnouncements
 }
 
 // NewRouter constructs the API HTTP router.
@@ -30,20 +86,87 @@ func NewRouterWithOptions(ssi oas.StrictServerInterface, opts RouterOptions) htt
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
 
+	if opts.CORSMiddleware != nil {
+		r.Use(opts.CORSMiddleware)
+	}
+	operationIDs := make(map[string]string)
+	if opts.RateLimitMiddleware != nil {
+		r.Use(opts.RateLimitMiddleware.handler(r, operationIDs))
+	}
 	if opts.AuthMiddleware != nil {
 		r.Use(opts.AuthMiddleware)
 	}
+	if opts.RateLimitMiddleware != nil {
+		r.Use(opts.RateLimitMiddleware.afterAuth)
+	}
 
 	// Health endpoint is deliberately out-of-spec (used for infra checks).
 	r.Get("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
+	if opts.EmailVerification != nil {
+		mountEmailVerification(r, opts.EmailVerification)
+	}
+	if opts.MemberIdentities != nil {
+		mountMemberIdentities(r, opts.MemberIdentities, opts.IdentityTokens)
+	}
+	if opts.MemberInvitations != nil {
+		mountMemberInvitations(r, opts.MemberInvitations)
+	}
+	if opts.VehicleGarage != nil {
+		mountVehicleGarage(r, opts.VehicleGarage, opts.TripRigs)
+	}
+	if opts.Members != nil && opts.TripSettings != nil {
+		mountTripSettings(r, opts.Members, opts.TripSettings, opts.TripSeries)
+	}
+	if opts.Members != nil && opts.RideShare != nil {
+		mountRideShare(r, opts.Members, opts.RideShare)
+	}
+	if opts.Members != nil && opts.RequirementsRoster != nil {
+		mountRequirementsRoster(r, opts.Members, opts.RequirementsRoster)
+	}
+	if opts.Members != nil && opts.TripTemplates != nil {
+		mountTripTemplates(r, opts.Members, opts.TripTemplates)
+	}
+	if opts.Members != nil && opts.TripSeries != nil {
+		mountTripSeries(r, opts.Members, opts.TripSeries)
+	}
+	if opts.Members != nil && opts.TripItinerary != nil {
+		mountTripItinerary(r, opts.Members, opts.TripItinerary)
+	}
+	if opts.Members != nil && opts.MemberRSVPs != nil {
+		mountMemberRSVPs(r, opts.Members, opts.MemberRSVPs, opts.IdempotencyMiddleware)
+	}
+	if opts.Members != nil && opts.RSVPHistory != nil {
+		mountRSVPHistory(r, opts.Members, opts.RSVPHistory)
+	}
+	if opts.Members != nil && opts.TripAttendance != nil {
+		mountTripAttendance(r, opts.Members, opts.TripAttendance)
+	}
+	if opts.Members != nil && opts.EmergencyInfo != nil {
+		mountEmergencyInfo(r, opts.Members, opts.EmergencyInfo)
+	}
+	if opts.Members != nil && opts.Waivers != nil {
+		mountWaivers(r, opts.Members, opts.Waivers)
+	}
+	if opts.Members != nil && opts.TripWaivers != nil {
+		mountTripWaivers(r, opts.Members, opts.TripWaivers)
+	}
+	if opts.Members != nil && opts.TripComments != nil {
+		mountTripComments(r, opts.Members, opts.TripComments)
+	}
+	if opts.Members != nil && opts.TripAnnouncements != nil {
+		mountTripAnnouncements(r, opts.Members, opts.TripAnnouncements, opts.IdempotencyMiddleware)
+	}
 
 	// Strict handler wiring:
 	// - app/adapter implements `oas.StrictServerInterface`
 	// - generated strict handler adapts it to the legacy `oas.ServerInterface`
-	sh := oas.NewStrictHandlerWithOptions(ssi, nil, oas.StrictHTTPServerOptions{
+	strictMiddlewares := []oas.StrictMiddlewareFunc{newInviteCodeMiddleware(), newVehicleIDMiddleware(), newPassengersMiddleware(), newTripListFilterMiddleware(), newTripTemplateIDMiddleware(), newSeriesScopeMiddleware()}
+	// Applied last so it is outermost: out-of-scope service-account calls are rejected first.
+	strictMiddlewares = append(strictMiddlewares, newAPIKeyScopeMiddleware())
+	sh := oas.NewStrictHandlerWithOptions(ssi, strictMiddlewares, oas.StrictHTTPServerOptions{
 		RequestErrorHandlerFunc: func(w http.ResponseWriter, req *http.Request, err error) {
 			// JSON decode / parameter coercion errors (client input).
 			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
@@ -55,6 +178,14 @@ func NewRouterWithOptions(ssi oas.StrictServerInterface, opts RouterOptions) htt
 			})
 		},
 	})
-	_ = oas.HandlerFromMux(sh, r)
+	var opMiddlewares []oas.MiddlewareFunc
+	if opts.IdempotencyMiddleware != nil {
+		opMiddlewares = append(opMiddlewares, opts.IdempotencyMiddleware)
+	}
+	_ = oas.HandlerWithOptions(sh, oas.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: opMiddlewares,
+	})
+	oasOperationIDs(r, operationIDs)
 	return r
 }
@@ -0,0 +1,79 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// RSVP history routes are out-of-spec: organizers read a trip's RSVP timeline and members read
+// the history of their own RSVPs. Both list changes oldest first.
+const (
+	// TripRSVPHistoryPath lists (GET) every RSVP change on a trip; organizers only.
+	TripRSVPHistoryPath = "/trips/{tripId}/rsvp-history"
+	// MyRSVPHistoryPath lists (GET) every change to the caller's RSVPs.
+	MyRSVPHistoryPath = "/members/me/rsvp-history"
+)
+
+// RSVPHistory is the trips use-case surface needed by the RSVP history routes.
+type RSVPHistory interface {
+	GetTripRSVPHistory(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.RSVPChange, error)
+	ListMyRSVPHistory(ctx context.Context, caller domain.MemberID) ([]domain.RSVPChange, error)
+}
+
+type rsvpChangeJSON struct {
+	TripID           string    `json:"tripId"`
+	MemberID         string    `json:"memberId"`
+	PreviousResponse *string   `json:"previousResponse"`
+	Response         string    `json:"response"`
+	ChangedBy        *string   `json:"changedBy"`
+	ChangeReason     *string   `json:"changeReason"`
+	ChangedAt        time.Time `json:"changedAt"`
+}
+
+func rsvpChangesToJSON(changes []domain.RSVPChange) []rsvpChangeJSON {
+	out := make([]rsvpChangeJSON, 0, len(changes))
+	for _, c := range changes {
+		j := rsvpChangeJSON{
+			TripID:       string(c.TripID),
+			MemberID:     string(c.MemberID),
+			Response:     string(c.Response),
+			ChangeReason: c.ChangeReason,
+			ChangedAt:    c.ChangedAt.UTC(),
+		}
+		if c.PreviousResponse != nil {
+			prev := string(*c.PreviousResponse)
+			j.PreviousResponse = &prev
+		}
+		if c.ChangedBy != "" {
+			by := string(c.ChangedBy)
+			j.ChangedBy = &by
+		}
+		out = append(out, j)
+	}
+	return out
+}
+
+func mountRSVPHistory(r chi.Router, m MemberResolver, h RSVPHistory) {
+	r.Get(TripRSVPHistoryPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		changes, err := h.GetTripRSVPHistory(req.Context(), me.ID, domain.TripID(chi.URLParam(req, "tripId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"changes": rsvpChangesToJSON(changes)})
+	}))
+
+	r.Get(MyRSVPHistoryPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		changes, err := h.ListMyRSVPHistory(req.Context(), me.ID)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"changes": rsvpChangesToJSON(changes)})
+	}))
+}
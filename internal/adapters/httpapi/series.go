@@ -0,0 +1,232 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+const (
+	// SeriesScopeHeader chooses what an UpdateTrip (or trip settings PATCH) on a series
+	// occurrence changes: THIS (the default) edits only the occurrence, FUTURE also edits every
+	// later occurrence and the series itself.
+	SeriesScopeHeader = "X-Series-Scope"
+	// SeriesUpdatedTripsHeader lists, comma-separated, the later occurrences a FUTURE edit changed.
+	SeriesUpdatedTripsHeader = "X-Series-Updated-Trips"
+	// SeriesSkippedTripsHeader lists, comma-separated, the later occurrences a FUTURE edit could
+	// not be applied to.
+	SeriesSkippedTripsHeader = "X-Series-Skipped-Trips"
+)
+
+// Series routes are out-of-spec: the OpenAPI contract has no recurring trips yet.
+const (
+	// TripSeriesPath creates (POST) a series from a template trip.
+	TripSeriesPath = "/trip-series"
+	// TripSeriesItemPath reads (GET) a series and its occurrences.
+	TripSeriesItemPath = "/trip-series/{seriesId}"
+)
+
+// seriesDateLayout is the wire format of series and occurrence dates.
+const seriesDateLayout = "2006-01-02"
+
+// TripSeries is the trips use-case surface needed by the series routes and FUTURE-scoped edits.
+type TripSeries interface {
+	CreateTripSeries(ctx context.Context, caller domain.MemberID, in trips.CreateTripSeriesInput) (trips.TripSeriesDetails, error)
+	GetTripSeries(ctx context.Context, caller domain.MemberID, id domain.TripSeriesID) (trips.TripSeriesDetails, error)
+	UpdateTripSeriesFromOccurrence(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.UpdateTripInput) (trips.SeriesUpdateResult, error)
+}
+
+type seriesScopeKey struct{}
+
+// WithFutureSeriesScope marks the request as an "all future occurrences" edit.
+func WithFutureSeriesScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, seriesScopeKey{}, true)
+}
+
+// FutureSeriesScopeFromContext reports whether the request asked for X-Series-Scope: FUTURE.
+func FutureSeriesScopeFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(seriesScopeKey{}).(bool)
+	return v
+}
+
+// parseSeriesScope reports whether the X-Series-Scope header asks for FUTURE. ok is false for
+// unknown values.
+func parseSeriesScope(r *http.Request) (future bool, ok bool) {
+	switch strings.ToUpper(strings.TrimSpace(r.Header.Get(SeriesScopeHeader))) {
+	case "", "THIS":
+		return false, true
+	case "FUTURE":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func writeInvalidSeriesScope(w http.ResponseWriter, r *http.Request) {
+	writeOASError(w, r, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid "+SeriesScopeHeader, map[string]any{"scope": "must be THIS or FUTURE"})
+}
+
+// newSeriesScopeMiddleware copies X-Series-Scope into the context of UpdateTrip requests.
+func newSeriesScopeMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "UpdateTrip" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			future, ok := parseSeriesScope(r)
+			if !ok {
+				writeInvalidSeriesScope(w, r)
+				return nil, nil
+			}
+			if future {
+				ctx = WithFutureSeriesScope(ctx)
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+// setSeriesUpdateHeaders reports the later occurrences a FUTURE edit touched.
+func setSeriesUpdateHeaders(w http.ResponseWriter, res trips.SeriesUpdateResult) {
+	w.Header().Set(SeriesUpdatedTripsHeader, joinTripIDs(res.Updated))
+	w.Header().Set(SeriesSkippedTripsHeader, joinTripIDs(res.Skipped))
+}
+
+func joinTripIDs(ids []domain.TripID) string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, string(id))
+	}
+	return strings.Join(out, ", ")
+}
+
+// updateTripForSeries adds the series headers to a successful FUTURE-scoped UpdateTrip response.
+type updateTripForSeries struct {
+	oas.UpdateTrip200JSONResponse
+	result trips.SeriesUpdateResult
+}
+
+func (r updateTripForSeries) VisitUpdateTripResponse(w http.ResponseWriter) error {
+	setSeriesUpdateHeaders(w, r.result)
+	return r.UpdateTrip200JSONResponse.VisitUpdateTripResponse(w)
+}
+
+type seriesOccurrenceJSON struct {
+	Date      string  `json:"date"`
+	TripID    string  `json:"tripId"`
+	Name      *string `json:"name"`
+	StartDate *string `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	Status    string  `json:"status"`
+}
+
+type tripSeriesJSON struct {
+	ID                string       `json:"id"`
+	Recurrence        string       `json:"recurrence"`
+	StartDate         string       `json:"startDate"`
+	EndDate           *string      `json:"endDate"`
+	DurationDays      int          `json:"durationDays"`
+	Publish           bool         `json:"publish"`
+	CreatedByMemberID string       `json:"createdByMemberId"`
+	Plan              tripPlanJSON `json:"plan"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+func formatSeriesDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.Format(seriesDateLayout)
+	return &v
+}
+
+func tripSeriesDetailsToJSON(d trips.TripSeriesDetails) map[string]any {
+	s := d.Series
+	series := tripSeriesJSON{
+		ID:                string(s.ID),
+		Recurrence:        s.Recurrence.String(),
+		StartDate:         s.StartDate.Format(seriesDateLayout),
+		EndDate:           formatSeriesDate(s.EndDate),
+		DurationDays:      s.DurationDays,
+		Publish:           s.Publish,
+		CreatedByMemberID: string(s.CreatedByMember),
+		Plan:              tripPlanToJSON(s.Plan),
+		CreatedAt:         s.CreatedAt,
+		UpdatedAt:         s.UpdatedAt,
+	}
+	occs := make([]seriesOccurrenceJSON, 0, len(d.Occurrences))
+	for _, o := range d.Occurrences {
+		occs = append(occs, seriesOccurrenceJSON{
+			Date:      o.Date.Format(seriesDateLayout),
+			TripID:    string(o.Trip.ID),
+			Name:      o.Trip.Name,
+			StartDate: formatSeriesDate(o.Trip.StartDate),
+			EndDate:   formatSeriesDate(o.Trip.EndDate),
+			Status:    string(o.Trip.Status),
+		})
+	}
+	return map[string]any{"series": series, "occurrences": occs}
+}
+
+func mountTripSeries(r chi.Router, m MemberResolver, ts TripSeries) {
+	r.Post(TripSeriesPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			TemplateTripID string  `json:"templateTripId"`
+			Recurrence     string  `json:"recurrence"`
+			StartDate      string  `json:"startDate"`
+			EndDate        *string `json:"endDate"`
+			DurationDays   int     `json:"durationDays"`
+			Publish        bool    `json:"publish"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in := trips.CreateTripSeriesInput{
+			TemplateTripID: domain.TripID(body.TemplateTripID),
+			Recurrence:     body.Recurrence,
+			DurationDays:   body.DurationDays,
+			Publish:        body.Publish,
+		}
+		if body.StartDate != "" {
+			d, err := time.Parse(seriesDateLayout, body.StartDate)
+			if err != nil {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid startDate", map[string]any{"startDate": "must be YYYY-MM-DD"})
+				return
+			}
+			in.StartDate = d
+		}
+		if body.EndDate != nil {
+			d, err := time.Parse(seriesDateLayout, *body.EndDate)
+			if err != nil {
+				writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "invalid endDate", map[string]any{"endDate": "must be YYYY-MM-DD"})
+				return
+			}
+			in.EndDate = &d
+		}
+		d, err := ts.CreateTripSeries(req.Context(), me.ID, in)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, tripSeriesDetailsToJSON(d))
+	}))
+
+	r.Get(TripSeriesItemPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		d, err := ts.GetTripSeries(req.Context(), me.ID, domain.TripSeriesID(chi.URLParam(req, "seriesId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, tripSeriesDetailsToJSON(d))
+	}))
+}
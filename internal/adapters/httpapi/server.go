@@ -2,9 +2,6 @@ package httpapi
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
@@ -18,24 +15,23 @@ import (
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
-	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
 )
 
 // Server is the real HTTP adapter implementation. For endpoints not yet implemented,
 // it embeds StrictUnimplemented.
+//
+// Idempotency-Key handling lives in NewIdempotencyMiddleware, not in the handlers.
 type Server struct {
 	StrictUnimplemented
 
 	Members *members.Service
 	Trips   *trips.Service
-	Idem    idempotency.Store
 }
 
-func NewServer(membersSvc *members.Service, tripsSvc *trips.Service, idem idempotency.Store) *Server {
+func NewServer(membersSvc *members.Service, tripsSvc *trips.Service) *Server {
 	return &Server{
 		Members: membersSvc,
 		Trips:   tripsSvc,
-		Idem:    idem,
 	}
 }
 
@@ -117,6 +113,7 @@ func (s *Server) CreateMyMember(ctx context.Context, req oas.CreateMyMemberReque
 	in := members.CreateMyMemberInput{
 		DisplayName: req.Body.DisplayName,
 		Email:       string(req.Body.Email),
+		InviteCode:  InviteCodeFromContext(ctx),
 	}
 	if req.Body.GroupAliasEmail.IsSpecified() {
 		if req.Body.GroupAliasEmail.IsNull() {
@@ -182,49 +179,6 @@ func (s *Server) UpdateMyMemberProfile(ctx context.Context, req oas.UpdateMyMemb
 		return oas.UpdateMyMemberProfile422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	// Idempotency handling (v1):
-	// - Replay if same actor+key+route+bodyHash
-	// - Reject if same actor+key+route with different bodyHash (409)
-	bodyHash, err := hashUpdateMyMemberProfileBody(*req.Body)
-	if err != nil {
-		return nil, err
-	}
-	idemKey := idempotency.Key(req.Params.IdempotencyKey)
-	metaFP := idempotency.Fingerprint{
-		Key:      idemKey,
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPatch,
-		Route:    "/members/me",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.UpdateMyMemberProfile409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.UpdateMyMemberProfileResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.UpdateMyMemberProfile200JSONResponse(payload), nil
-			}
-		}
-	}
-
 	in := updateMyMemberProfileInputFromOAS(*req.Body)
 	m, err := s.Members.UpdateMyMemberProfile(ctx, domain.SubjectID(sub), in)
 	if err != nil {
@@ -247,42 +201,27 @@ func (s *Server) UpdateMyMemberProfile(ctx context.Context, req oas.UpdateMyMemb
 		Member: memberProfileFromDomain(m),
 	}
 
-	// Store successful response for replay.
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPatch,
-			Route:    "/members/me",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.UpdateMyMemberProfile200JSONResponse(resp), nil
 }
 
 func (s *Server) ListVisibleTripsForMember(ctx context.Context, _ oas.ListVisibleTripsForMemberRequestObject) (oas.ListVisibleTripsForMemberResponseObject, error) {
-	sub, ok := SubjectFromContext(ctx)
-	if !ok {
-		return oas.ListVisibleTripsForMember401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
-	}
-	me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
-	if err != nil {
-		if isMemberNotProvisioned(err) {
-			return oas.ListVisibleTripsForMember401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+	caller := serviceAccountCaller
+	if _, isService := ServiceAccountFromContext(ctx); !isService {
+		sub, ok := SubjectFromContext(ctx)
+		if !ok {
+			return oas.ListVisibleTripsForMember401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
 		}
-		return nil, err
+		me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
+		if err != nil {
+			if isMemberNotProvisioned(err) {
+				return oas.ListVisibleTripsForMember401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+			}
+			return nil, err
+		}
+		caller = me.ID
 	}
 
-	ts, err := s.Trips.ListVisibleTripsForMember(ctx, me.ID)
+	ts, err := s.Trips.ListVisibleTripsForMember(ctx, caller, TripListFilterFromContext(ctx))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -332,19 +271,23 @@ func (s *Server) ListMyDraftTrips(ctx context.Context, _ oas.ListMyDraftTripsReq
 }
 
 func (s *Server) GetTripDetails(ctx context.Context, req oas.GetTripDetailsRequestObject) (oas.GetTripDetailsResponseObject, error) {
-	sub, ok := SubjectFromContext(ctx)
-	if !ok {
-		return oas.GetTripDetails401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
-	}
-	me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
-	if err != nil {
-		if isMemberNotProvisioned(err) {
-			return oas.GetTripDetails401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+	caller := serviceAccountCaller
+	if _, isService := ServiceAccountFromContext(ctx); !isService {
+		sub, ok := SubjectFromContext(ctx)
+		if !ok {
+			return oas.GetTripDetails401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
 		}
-		return nil, err
+		me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
+		if err != nil {
+			if isMemberNotProvisioned(err) {
+				return oas.GetTripDetails401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+			}
+			return nil, err
+		}
+		caller = me.ID
 	}
 
-	td, err := s.Trips.GetTripDetails(ctx, me.ID, domain.TripID(req.TripId))
+	td, err := s.Trips.GetTripDetails(ctx, caller, domain.TripID(req.TripId))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -356,7 +299,11 @@ func (s *Server) GetTripDetails(ctx context.Context, req oas.GetTripDetailsReque
 		}
 		return nil, err
 	}
-	return oas.GetTripDetails200JSONResponse{Trip: tripDetailsFromDomain(td)}, nil
+	resp := oas.GetTripDetails200JSONResponse{Trip: tripDetailsFromDomain(td)}
+	if len(td.Itinerary) > 0 || len(td.Announcements) > 0 {
+		return getTripDetailsExtended{GetTripDetails200JSONResponse: resp, itinerary: td.Itinerary, announcements: td.Announcements}, nil
+	}
+	return resp, nil
 }
 
 func (s *Server) CreateTripDraft(ctx context.Context, req oas.CreateTripDraftRequestObject) (oas.CreateTripDraftResponseObject, error) {
@@ -375,49 +322,7 @@ func (s *Server) CreateTripDraft(ctx context.Context, req oas.CreateTripDraftReq
 		return oas.CreateTripDraft422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	// Idempotency handling (v1):
-	// - Replay if same actor+key+route+bodyHash
-	// - Reject if same actor+key+route with different bodyHash (409)
-	bodyHash, err := hashCreateTripDraftBody(*req.Body)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPost,
-		Route:    "/trips",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.CreateTripDraft409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusCreated && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.CreateTripDraftResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.CreateTripDraft201JSONResponse(payload), nil
-			}
-		}
-	}
-
-	created, err := s.Trips.CreateTripDraft(ctx, me.ID, trips.CreateTripDraftInput{Name: req.Body.Name})
+	created, err := s.Trips.CreateTripDraft(ctx, me.ID, trips.CreateTripDraftInput{Name: req.Body.Name, TemplateID: TripTemplateIDFromContext(ctx)})
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -443,24 +348,6 @@ func (s *Server) CreateTripDraft(ctx context.Context, req oas.CreateTripDraftReq
 		},
 	}
 
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPost,
-			Route:    "/trips",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusCreated,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.CreateTripDraft201JSONResponse(resp), nil
 }
 
@@ -480,47 +367,18 @@ func (s *Server) UpdateTrip(ctx context.Context, req oas.UpdateTripRequestObject
 		return oas.UpdateTrip422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	bodyHash, err := hashUpdateTripBody(req.TripId, *req.Body)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPatch,
-		Route:    "/trips/{tripId}",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.UpdateTrip409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.TripResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.UpdateTrip200JSONResponse(payload), nil
-			}
-		}
-	}
-
 	in := updateTripInputFromOAS(*req.Body)
-	td, err := s.Trips.UpdateTrip(ctx, me.ID, domain.TripID(req.TripId), in)
+	var (
+		td     domain.TripDetails
+		series *trips.SeriesUpdateResult
+	)
+	if FutureSeriesScopeFromContext(ctx) {
+		var res trips.SeriesUpdateResult
+		res, err = s.Trips.UpdateTripSeriesFromOccurrence(ctx, me.ID, domain.TripID(req.TripId), in)
+		td, series = res.Trip, &res
+	} else {
+		td, err = s.Trips.UpdateTrip(ctx, me.ID, domain.TripID(req.TripId), in)
+	}
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -538,24 +396,9 @@ func (s *Server) UpdateTrip(ctx context.Context, req oas.UpdateTripRequestObject
 	}
 
 	resp := oas.TripResponse{Trip: tripDetailsFromDomain(td)}
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPatch,
-			Route:    "/trips/{tripId}",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
+	if series != nil {
+		return updateTripForSeries{UpdateTrip200JSONResponse: oas.UpdateTrip200JSONResponse(resp), result: *series}, nil
 	}
-
 	return oas.UpdateTrip200JSONResponse(resp), nil
 }
 
@@ -575,45 +418,6 @@ func (s *Server) SetTripDraftVisibility(ctx context.Context, req oas.SetTripDraf
 		return oas.SetTripDraftVisibility422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	bodyHash, err := hashSetTripDraftVisibilityBody(req.TripId, *req.Body)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPut,
-		Route:    "/trips/{tripId}/draft-visibility",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.SetTripDraftVisibility409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.TripResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.SetTripDraftVisibility200JSONResponse(payload), nil
-			}
-		}
-	}
-
 	td, err := s.Trips.SetTripDraftVisibility(ctx, me.ID, domain.TripID(req.TripId), domain.DraftVisibility(req.Body.DraftVisibility))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
@@ -632,24 +436,6 @@ func (s *Server) SetTripDraftVisibility(ctx context.Context, req oas.SetTripDraf
 	}
 
 	resp := oas.TripResponse{Trip: tripDetailsFromDomain(td)}
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPut,
-			Route:    "/trips/{tripId}/draft-visibility",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.SetTripDraftVisibility200JSONResponse(resp), nil
 }
 
@@ -699,52 +485,6 @@ func (s *Server) CancelTrip(ctx context.Context, req oas.CancelTripRequestObject
 		return nil, err
 	}
 
-	var bodyHash string
-	var idemKey string
-	if req.Params.IdempotencyKey != nil {
-		idemKey = *req.Params.IdempotencyKey
-		var err error
-		bodyHash, err = hashCancelTripBody(req.TripId)
-		if err != nil {
-			return nil, err
-		}
-
-		metaFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(idemKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPost,
-			Route:    "/trips/{tripId}/cancel",
-			BodyHash: "",
-		}
-		if s.Idem != nil {
-			if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-				return nil, err
-			} else if ok {
-				if string(meta.Body) != bodyHash {
-					return oas.CancelTrip409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-				}
-			} else {
-				_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-					StatusCode:  0,
-					ContentType: "text/plain",
-					Body:        []byte(bodyHash),
-					CreatedAt:   time.Now().UTC(),
-				})
-			}
-
-			respFP := metaFP
-			respFP.BodyHash = bodyHash
-			if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-				return nil, err
-			} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-				var payload oas.TripResponse
-				if err := json.Unmarshal(rec.Body, &payload); err == nil {
-					return oas.CancelTrip200JSONResponse(payload), nil
-				}
-			}
-		}
-	}
-
 	td, err := s.Trips.CancelTrip(ctx, me.ID, domain.TripID(req.TripId))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
@@ -763,24 +503,6 @@ func (s *Server) CancelTrip(ctx context.Context, req oas.CancelTripRequestObject
 	}
 
 	resp := oas.TripResponse{Trip: tripDetailsFromDomain(td)}
-	if s.Idem != nil && idemKey != "" {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(idemKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPost,
-			Route:    "/trips/{tripId}/cancel",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.CancelTrip200JSONResponse(resp), nil
 }
 
@@ -800,45 +522,6 @@ func (s *Server) AddTripOrganizer(ctx context.Context, req oas.AddTripOrganizerR
 		return oas.AddTripOrganizer422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	bodyHash, err := hashAddTripOrganizerBody(req.TripId, *req.Body)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPost,
-		Route:    "/trips/{tripId}/organizers",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.AddTripOrganizer409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.TripResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.AddTripOrganizer200JSONResponse(payload), nil
-			}
-		}
-	}
-
 	td, err := s.Trips.AddTripOrganizer(ctx, me.ID, domain.TripID(req.TripId), domain.MemberID(req.Body.MemberId))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
@@ -857,24 +540,6 @@ func (s *Server) AddTripOrganizer(ctx context.Context, req oas.AddTripOrganizerR
 	}
 
 	resp := oas.TripResponse{Trip: tripDetailsFromDomain(td)}
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPost,
-			Route:    "/trips/{tripId}/organizers",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.AddTripOrganizer200JSONResponse(resp), nil
 }
 
@@ -891,45 +556,6 @@ func (s *Server) RemoveTripOrganizer(ctx context.Context, req oas.RemoveTripOrga
 		return nil, err
 	}
 
-	bodyHash, err := hashRemoveTripOrganizerBody(req.TripId, req.MemberId)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodDelete,
-		Route:    "/trips/{tripId}/organizers/{memberId}",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.RemoveTripOrganizer409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.TripResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.RemoveTripOrganizer200JSONResponse(payload), nil
-			}
-		}
-	}
-
 	td, err := s.Trips.RemoveTripOrganizer(ctx, me.ID, domain.TripID(req.TripId), domain.MemberID(req.MemberId))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
@@ -948,24 +574,6 @@ func (s *Server) RemoveTripOrganizer(ctx context.Context, req oas.RemoveTripOrga
 	}
 
 	resp := oas.TripResponse{Trip: tripDetailsFromDomain(td)}
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodDelete,
-			Route:    "/trips/{tripId}/organizers/{memberId}",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-	}
-
 	return oas.RemoveTripOrganizer200JSONResponse(resp), nil
 }
 
@@ -985,46 +593,13 @@ func (s *Server) SetMyRSVP(ctx context.Context, req oas.SetMyRSVPRequestObject)
 		return oas.SetMyRSVP422JSONResponse{UnprocessableEntityJSONResponse: oas.UnprocessableEntityJSONResponse(oasError(ctx, "VALIDATION_ERROR", "missing request body", nil))}, nil
 	}
 
-	bodyHash, err := hashSetMyRSVPBody(req.TripId, *req.Body)
-	if err != nil {
-		return nil, err
-	}
-	metaFP := idempotency.Fingerprint{
-		Key:      idempotency.Key(req.Params.IdempotencyKey),
-		Subject:  domain.SubjectID(sub),
-		Method:   http.MethodPut,
-		Route:    "/trips/{tripId}/rsvp",
-		BodyHash: "",
-	}
-	if s.Idem != nil {
-		if meta, ok, err := s.Idem.Get(ctx, metaFP); err != nil {
-			return nil, err
-		} else if ok {
-			if string(meta.Body) != bodyHash {
-				return oas.SetMyRSVP409JSONResponse{ConflictJSONResponse: oas.ConflictJSONResponse(oasError(ctx, "IDEMPOTENCY_KEY_REUSE", "idempotency key reuse with different payload", nil))}, nil
-			}
-		} else {
-			_ = s.Idem.Put(ctx, metaFP, idempotency.Record{
-				StatusCode:  0,
-				ContentType: "text/plain",
-				Body:        []byte(bodyHash),
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
-
-		respFP := metaFP
-		respFP.BodyHash = bodyHash
-		if rec, ok, err := s.Idem.Get(ctx, respFP); err != nil {
-			return nil, err
-		} else if ok && rec.StatusCode == http.StatusOK && strings.HasPrefix(rec.ContentType, "application/json") {
-			var payload oas.SetMyRSVPResponse
-			if err := json.Unmarshal(rec.Body, &payload); err == nil {
-				return oas.SetMyRSVP200JSONResponse(payload), nil
-			}
-		}
-	}
-
-	my, err := s.Trips.SetMyRSVP(ctx, me.ID, domain.TripID(req.TripId), domain.RSVPResponse(req.Body.Response))
+	passengers := PassengersFromContext(ctx)
+	my, err := s.Trips.SetMyRSVP(ctx, me.ID, domain.TripID(req.TripId), trips.SetMyRSVPInput{
+		Response:       domain.RSVPResponse(req.Body.Response),
+		VehicleID:      VehicleIDFromContext(ctx),
+		PassengerCount: passengers.Count,
+		PassengerNames: passengers.Names,
+	})
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -1042,22 +617,8 @@ func (s *Server) SetMyRSVP(ctx context.Context, req oas.SetMyRSVPRequestObject)
 	}
 
 	resp := oas.SetMyRSVPResponse{MyRsvp: myRSVPFromDomain(my)}
-	if s.Idem != nil {
-		respFP := idempotency.Fingerprint{
-			Key:      idempotency.Key(req.Params.IdempotencyKey),
-			Subject:  domain.SubjectID(sub),
-			Method:   http.MethodPut,
-			Route:    "/trips/{tripId}/rsvp",
-			BodyHash: bodyHash,
-		}
-		if b, err := json.Marshal(resp); err == nil {
-			_ = s.Idem.Put(ctx, respFP, idempotency.Record{
-				StatusCode:  http.StatusOK,
-				ContentType: "application/json",
-				Body:        b,
-				CreatedAt:   time.Now().UTC(),
-			})
-		}
+	if len(my.RequirementWarnings) > 0 {
+		return setMyRSVPWithWarnings{SetMyRSVP200JSONResponse: oas.SetMyRSVP200JSONResponse(resp), warnings: my.RequirementWarnings}, nil
 	}
 	return oas.SetMyRSVP200JSONResponse(resp), nil
 }
@@ -1093,19 +654,23 @@ func (s *Server) GetMyRSVPForTrip(ctx context.Context, req oas.GetMyRSVPForTripR
 }
 
 func (s *Server) GetTripRSVPSummary(ctx context.Context, req oas.GetTripRSVPSummaryRequestObject) (oas.GetTripRSVPSummaryResponseObject, error) {
-	sub, ok := SubjectFromContext(ctx)
-	if !ok {
-		return oas.GetTripRSVPSummary401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
-	}
-	me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
-	if err != nil {
-		if isMemberNotProvisioned(err) {
-			return oas.GetTripRSVPSummary401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+	caller := serviceAccountCaller
+	if _, isService := ServiceAccountFromContext(ctx); !isService {
+		sub, ok := SubjectFromContext(ctx)
+		if !ok {
+			return oas.GetTripRSVPSummary401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "UNAUTHORIZED", "missing subject", nil))}, nil
 		}
-		return nil, err
+		me, err := s.Members.GetMyMemberProfile(ctx, domain.SubjectID(sub))
+		if err != nil {
+			if isMemberNotProvisioned(err) {
+				return oas.GetTripRSVPSummary401JSONResponse{UnauthorizedJSONResponse: oas.UnauthorizedJSONResponse(oasError(ctx, "MEMBER_NOT_PROVISIONED", "No member profile exists for the authenticated subject.", nil))}, nil
+			}
+			return nil, err
+		}
+		caller = me.ID
 	}
 
-	sum, err := s.Trips.GetTripRSVPSummary(ctx, me.ID, domain.TripID(req.TripId))
+	sum, err := s.Trips.GetTripRSVPSummary(ctx, caller, domain.TripID(req.TripId))
 	if err != nil {
 		if ae := (*trips.Error)(nil); errors.As(err, &ae) {
 			switch ae.Status {
@@ -1122,6 +687,10 @@ func (s *Server) GetTripRSVPSummary(ctx context.Context, req oas.GetTripRSVPSumm
 	return oas.GetTripRSVPSummary200JSONResponse{RsvpSummary: tripRSVPSummaryFromDomain(sum)}, nil
 }
 
+// serviceAccountCaller is the member identity used for service-account (API key) reads.
+// It matches no member, so only published and canceled trips are visible and no personal RSVP is attached.
+const serviceAccountCaller domain.MemberID = ""
+
 func isMemberNotProvisioned(err error) bool {
 	ae := (*members.Error)(nil)
 	if errors.As(err, &ae) {
@@ -1389,140 +958,6 @@ func optionalStringFromNullable(n nullable.Nullable[string]) members.Optional[st
 	return members.Some(v)
 }
 
-func hashUpdateMyMemberProfileBody(b oas.UpdateMyMemberProfileRequest) (string, error) {
-	// Canonicalize fields that have normalization semantics before hashing (UC-16).
-	canon := b
-	if canon.DisplayName.IsSpecified() && !canon.DisplayName.IsNull() {
-		if v, err := canon.DisplayName.Get(); err == nil {
-			var n nullable.Nullable[string]
-			n.Set(domain.NormalizeHumanName(v))
-			canon.DisplayName = n
-		}
-	}
-	if canon.Email != nil {
-		e := openapi_types.Email(strings.TrimSpace(string(*canon.Email)))
-		canon.Email = &e
-	}
-	if canon.GroupAliasEmail.IsSpecified() && !canon.GroupAliasEmail.IsNull() {
-		if v, err := canon.GroupAliasEmail.Get(); err == nil {
-			var n nullable.Nullable[openapi_types.Email]
-			n.Set(openapi_types.Email(strings.TrimSpace(string(v))))
-			canon.GroupAliasEmail = n
-		}
-	}
-
-	raw, err := json.Marshal(canon)
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashCreateTripDraftBody(b oas.CreateTripDraftRequest) (string, error) {
-	canon := b
-	canon.Name = domain.NormalizeHumanName(canon.Name)
-	raw, err := json.Marshal(canon)
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashUpdateTripBody(tripID string, b oas.UpdateTripRequest) (string, error) {
-	canon := b
-	if canon.Name != nil {
-		n := domain.NormalizeHumanName(*canon.Name)
-		canon.Name = &n
-	}
-	raw, err := json.Marshal(struct {
-		TripId string                `json:"tripId"`
-		Body   oas.UpdateTripRequest `json:"body"`
-	}{
-		TripId: tripID,
-		Body:   canon,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashSetTripDraftVisibilityBody(tripID string, b oas.SetDraftVisibilityRequest) (string, error) {
-	raw, err := json.Marshal(struct {
-		TripId string                        `json:"tripId"`
-		Body   oas.SetDraftVisibilityRequest `json:"body"`
-	}{
-		TripId: tripID,
-		Body:   b,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashAddTripOrganizerBody(tripID string, b oas.AddOrganizerRequest) (string, error) {
-	raw, err := json.Marshal(struct {
-		TripId string                  `json:"tripId"`
-		Body   oas.AddOrganizerRequest `json:"body"`
-	}{
-		TripId: tripID,
-		Body:   b,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashRemoveTripOrganizerBody(tripID string, memberID string) (string, error) {
-	raw, err := json.Marshal(struct {
-		TripId   string `json:"tripId"`
-		MemberId string `json:"memberId"`
-	}{
-		TripId:   tripID,
-		MemberId: memberID,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashCancelTripBody(tripID string) (string, error) {
-	raw, err := json.Marshal(struct {
-		TripId string `json:"tripId"`
-	}{
-		TripId: tripID,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
-func hashSetMyRSVPBody(tripID string, b oas.SetMyRSVPRequest) (string, error) {
-	raw, err := json.Marshal(struct {
-		TripId string               `json:"tripId"`
-		Body   oas.SetMyRSVPRequest `json:"body"`
-	}{
-		TripId: tripID,
-		Body:   b,
-	})
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(raw)
-	return hex.EncodeToString(sum[:]), nil
-}
-
 func updateTripInputFromOAS(b oas.UpdateTripRequest) trips.UpdateTripInput {
 	out := trips.UpdateTripInput{}
 
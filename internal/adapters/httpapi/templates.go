@@ -0,0 +1,187 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// TripTemplateIDHeader names the saved template a CreateTripDraft request starts from. Like
+// X-Vehicle-Id, it is a header because the request schema is owned by the OpenAPI contract.
+const TripTemplateIDHeader = "X-Trip-Template-Id"
+
+// Cloning and template routes are out-of-spec: the OpenAPI contract has neither yet.
+const (
+	// TripClonePath creates a new PRIVATE draft from a visible trip (POST).
+	TripClonePath = "/trips/{tripId}/clone"
+	// TripTemplatesPath lists (GET) the club's templates and saves (POST) a trip as one.
+	TripTemplatesPath = "/trip-templates"
+	// TripTemplatePath deletes (DELETE) a template.
+	TripTemplatePath = "/trip-templates/{templateId}"
+)
+
+// TripTemplates is the trips use-case surface needed by the cloning and template routes.
+type TripTemplates interface {
+	CloneTrip(ctx context.Context, caller domain.MemberID, sourceID domain.TripID) (trips.TripCreated, error)
+	SaveTripTemplate(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.SaveTripTemplateInput) (domain.TripTemplate, error)
+	ListTripTemplates(ctx context.Context, caller domain.MemberID) ([]domain.TripTemplate, error)
+	DeleteTripTemplate(ctx context.Context, caller domain.MemberID, id domain.TripTemplateID) error
+}
+
+type tripTemplateIDKey struct{}
+
+func WithTripTemplateID(ctx context.Context, id domain.TripTemplateID) context.Context {
+	return context.WithValue(ctx, tripTemplateIDKey{}, id)
+}
+
+// TripTemplateIDFromContext returns the X-Trip-Template-Id sent with the request, or nil.
+func TripTemplateIDFromContext(ctx context.Context) *domain.TripTemplateID {
+	v, ok := ctx.Value(tripTemplateIDKey{}).(domain.TripTemplateID)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// newTripTemplateIDMiddleware copies X-Trip-Template-Id into the context of CreateTripDraft requests.
+func newTripTemplateIDMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "CreateTripDraft" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			if id := strings.TrimSpace(r.Header.Get(TripTemplateIDHeader)); id != "" {
+				ctx = WithTripTemplateID(ctx, domain.TripTemplateID(id))
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+type locationJSON struct {
+	Label     string   `json:"label"`
+	Address   *string  `json:"address"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+type templateArtifactJSON struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type tripPlanJSON struct {
+	Name                        *string                `json:"name"`
+	Description                 *string                `json:"description"`
+	CapacityRigs                *int                   `json:"capacityRigs"`
+	CapacityPeople              *int                   `json:"capacityPeople"`
+	Difficulty                  *difficultyJSON        `json:"difficulty"`
+	DifficultyText              *string                `json:"difficultyText"`
+	MeetingLocation             *locationJSON          `json:"meetingLocation"`
+	CommsRequirementsText       *string                `json:"commsRequirementsText"`
+	RecommendedRequirementsText *string                `json:"recommendedRequirementsText"`
+	Requirements                tripRequirementsJSON   `json:"requirements"`
+	Artifacts                   []templateArtifactJSON `json:"artifacts"`
+}
+
+type tripTemplateJSON struct {
+	ID                string       `json:"id"`
+	Name              string       `json:"name"`
+	CreatedByMemberID string       `json:"createdByMemberId"`
+	Plan              tripPlanJSON `json:"plan"`
+	CreatedAt         time.Time    `json:"createdAt"`
+}
+
+func tripTemplateToJSON(t domain.TripTemplate) tripTemplateJSON {
+	return tripTemplateJSON{
+		ID:                string(t.ID),
+		Name:              t.Name,
+		CreatedByMemberID: string(t.CreatedByMember),
+		Plan:              tripPlanToJSON(t.Plan),
+		CreatedAt:         t.CreatedAt,
+	}
+}
+
+func tripPlanToJSON(p domain.TripPlan) tripPlanJSON {
+	plan := tripPlanJSON{
+		Name:                        p.Name,
+		Description:                 p.Description,
+		CapacityRigs:                p.CapacityRigs,
+		CapacityPeople:              p.CapacityPeople,
+		Difficulty:                  difficultyToJSON(p.Difficulty),
+		DifficultyText:              p.DifficultyText,
+		CommsRequirementsText:       p.CommsRequirementsText,
+		RecommendedRequirementsText: p.RecommendedRequirementsText,
+		Requirements:                tripRequirementsToJSON(p.Requirements),
+		Artifacts:                   make([]templateArtifactJSON, 0, len(p.Artifacts)),
+	}
+	if l := p.MeetingLocation; l != nil {
+		plan.MeetingLocation = &locationJSON{Label: l.Label, Address: l.Address, Latitude: l.Latitude, Longitude: l.Longitude}
+	}
+	for _, a := range p.Artifacts {
+		plan.Artifacts = append(plan.Artifacts, templateArtifactJSON{Type: string(a.Type), Title: a.Title, URL: a.URL})
+	}
+	return plan
+}
+
+func mountTripTemplates(r chi.Router, m MemberResolver, tt TripTemplates) {
+	r.Post(TripClonePath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		created, err := tt.CloneTrip(req.Context(), me.ID, domain.TripID(chi.URLParam(req, "tripId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, oas.CreateTripDraftResponse{Trip: oas.TripCreated{
+			TripId:          string(created.ID),
+			Status:          oas.TripStatus(created.Status),
+			DraftVisibility: oas.DraftVisibility(created.DraftVisibility),
+		}})
+	}))
+
+	r.Get(TripTemplatesPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		ts, err := tt.ListTripTemplates(req.Context(), me.ID)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		out := make([]tripTemplateJSON, 0, len(ts))
+		for _, t := range ts {
+			out = append(out, tripTemplateToJSON(t))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"templates": out})
+	}))
+
+	r.Post(TripTemplatesPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			TripID string `json:"tripId"`
+			Name   string `json:"name"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		t, err := tt.SaveTripTemplate(req.Context(), me.ID, domain.TripID(body.TripID), trips.SaveTripTemplateInput{Name: body.Name})
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"template": tripTemplateToJSON(t)})
+	}))
+
+	r.Delete(TripTemplatePath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		if err := tt.DeleteTripTemplate(req.Context(), me.ID, domain.TripTemplateID(chi.URLParam(req, "templateId"))); err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
@@ -0,0 +1,163 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// TripSettingsPath reads (GET) and patches (PATCH) trip settings the OpenAPI Trip schema does
+// not carry: the people capacity, structured difficulty, structured vehicle requirements and
+// RSVP deadline.
+// It is out-of-spec, like the vehicle garage routes; organizers use it the same way they use
+// UpdateTrip.
+const TripSettingsPath = "/trips/{tripId}/settings"
+
+// MemberResolver maps the authenticated subject to its member profile.
+type MemberResolver interface {
+	GetMyMemberProfile(ctx context.Context, subject domain.SubjectID) (domain.Member, error)
+}
+
+// TripSettingsEditor is the trips use-case surface needed by TripSettingsPath.
+type TripSettingsEditor interface {
+	GetTripDetails(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.TripDetails, error)
+	UpdateTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in trips.UpdateTripInput) (domain.TripDetails, error)
+}
+
+type tripSettingsJSON struct {
+	CapacityPeople *int                 `json:"capacityPeople"`
+	Difficulty     *difficultyJSON      `json:"difficulty"`
+	Requirements   tripRequirementsJSON `json:"requirements"`
+	RSVPDeadline   *time.Time           `json:"rsvpDeadline"`
+}
+
+func tripSettingsToJSON(td domain.TripDetails) tripSettingsJSON {
+	return tripSettingsJSON{
+		CapacityPeople: td.CapacityPeople,
+		Difficulty:     difficultyToJSON(td.Difficulty),
+		Requirements:   tripRequirementsToJSON(td.Requirements),
+		RSVPDeadline:   td.RSVPDeadline,
+	}
+}
+
+func mountTripSettings(r chi.Router, m MemberResolver, t TripSettingsEditor, series TripSeries) {
+	r.Get(TripSettingsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		td, err := t.GetTripDetails(req.Context(), me.ID, domain.TripID(chi.URLParam(req, "tripId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"settings": tripSettingsToJSON(td)})
+	}))
+
+	r.Patch(TripSettingsPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in, err := updateTripSettingsInputFromJSON(body)
+		if err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		future, ok := parseSeriesScope(req)
+		if !ok {
+			writeInvalidSeriesScope(w, req)
+			return
+		}
+		tripID := domain.TripID(chi.URLParam(req, "tripId"))
+		if future && series != nil {
+			res, err := series.UpdateTripSeriesFromOccurrence(req.Context(), me.ID, tripID, in)
+			if err != nil {
+				writeTripsError(w, req, err)
+				return
+			}
+			setSeriesUpdateHeaders(w, res)
+			writeJSON(w, http.StatusOK, map[string]any{"settings": tripSettingsToJSON(res.Trip)})
+			return
+		}
+		td, err := t.UpdateTrip(req.Context(), me.ID, tripID, in)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"settings": tripSettingsToJSON(td)})
+	}))
+}
+
+func updateTripSettingsInputFromJSON(body map[string]json.RawMessage) (trips.UpdateTripInput, error) {
+	var in trips.UpdateTripInput
+	if raw, ok := body["capacityPeople"]; ok {
+		if isJSONNull(raw) {
+			in.CapacityPeople = trips.Null[int]()
+		} else {
+			var n int
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return in, errors.New("capacityPeople: must be an integer")
+			}
+			in.CapacityPeople = trips.Some(n)
+		}
+	}
+	if raw, ok := body["difficulty"]; ok {
+		if isJSONNull(raw) {
+			in.Difficulty = trips.Null[domain.Difficulty]()
+		} else {
+			var d difficultyJSON
+			if err := json.Unmarshal(raw, &d); err != nil {
+				return in, errors.New("difficulty: must be an object")
+			}
+			in.Difficulty = trips.Some(difficultyFromJSON(d))
+		}
+	}
+	if raw, ok := body["requirements"]; ok {
+		if isJSONNull(raw) {
+			in.Requirements = trips.Null[domain.TripRequirements]()
+		} else {
+			var reqs tripRequirementsJSON
+			if err := json.Unmarshal(raw, &reqs); err != nil {
+				return in, errors.New("requirements: must be an object")
+			}
+			in.Requirements = trips.Some(tripRequirementsFromJSON(reqs))
+		}
+	}
+	if raw, ok := body["rsvpDeadline"]; ok {
+		if isJSONNull(raw) {
+			in.RSVPDeadline = trips.Null[time.Time]()
+		} else {
+			var at time.Time
+			if err := json.Unmarshal(raw, &at); err != nil {
+				return in, errors.New("rsvpDeadline: must be an RFC 3339 timestamp")
+			}
+			in.RSVPDeadline = trips.Some(at)
+		}
+	}
+	return in, nil
+}
+
+// withMember rejects requests without an authenticated, provisioned member.
+func withMember(m MemberResolver, h func(w http.ResponseWriter, r *http.Request, me domain.Member)) http.HandlerFunc {
+	return withSubject(func(w http.ResponseWriter, r *http.Request, sub domain.SubjectID) {
+		me, err := m.GetMyMemberProfile(r.Context(), sub)
+		if err != nil {
+			writeMembersError(w, r, err)
+			return
+		}
+		h(w, r, me)
+	})
+}
+
+func writeTripsError(w http.ResponseWriter, r *http.Request, err error) {
+	if ae := (*trips.Error)(nil); errors.As(err, &ae) {
+		writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+		return
+	}
+	writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+}
@@ -6,17 +6,31 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	memannouncementrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/announcementrepo"
+	memattendancerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/attendancerepo"
 	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memcommentrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/commentrepo"
+	mememergencyinforepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/emergencyinforepo"
 	memidempotency "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/idempotency"
+	memitineraryrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/itineraryrepo"
+	memkeyprovider "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/keyprovider"
 	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memnotifier "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/notifier"
+	memridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ridesharerepo"
 	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
 	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	memtripseriesrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/tripseriesrepo"
+	memtriptemplaterepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triptemplaterepo"
+	memwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/waiverrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/emergencyinfo"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
 	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/waivers"
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwks_testutil"
 	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/jwtverifier"
@@ -55,12 +69,45 @@ func newTestTripRouter(t *testing.T) (http.Handler, func(now time.Time, kid stri
 	memberRepo := memmemberrepo.NewRepo()
 	tripRepo := memtriprepo.NewRepo()
 	rsvpRepo := memrsvprepo.NewRepo()
-	idem := memidempotency.NewStore()
+	idem := memidempotency.NewStoreWithClock(clk)
 	memberSvc := members.NewService(memberRepo, clk)
-	tripSvc := trips.NewService(tripRepo, memberRepo, rsvpRepo)
+	tripSvc := trips.NewServiceWithOptions(tripRepo, memberRepo, rsvpRepo, trips.Options{
+		RideShares:    memridesharerepo.NewRepo(),
+		Templates:     memtriptemplaterepo.NewRepo(),
+		Series:        memtripseriesrepo.NewRepo(),
+		Itineraries:   memitineraryrepo.NewRepo(),
+		Attendance:    memattendancerepo.NewRepo(),
+		Comments:      memcommentrepo.NewRepo(),
+		Announcements: memannouncementrepo.NewRepo(),
+		Notifier:      memnotifier.NewRecorder(),
+	})
+
+	keys, err := memkeyprovider.NewEphemeralProvider()
+	if err != nil {
+		t.Fatalf("NewEphemeralProvider: %v", err)
+	}
+	emergencySvc := emergencyinfo.NewService(mememergencyinforepo.NewRepo(), keys, memberRepo, tripRepo, rsvpRepo, clk)
 
-	api := NewServer(memberSvc, tripSvc, idem)
-	h := NewRouterWithOptions(api, RouterOptions{AuthMiddleware: NewAuthMiddleware(v)})
+	api := NewServer(memberSvc, tripSvc)
+	h := NewRouterWithOptions(api, RouterOptions{
+		AuthMiddleware:        NewAuthMiddleware(v),
+		IdempotencyMiddleware: NewIdempotencyMiddleware(idem, clk, 24*time.Hour),
+		VehicleGarage:         memberSvc,
+		TripRigs:              tripSvc,
+		Members:               memberSvc,
+		TripSettings:          tripSvc,
+		RideShare:             tripSvc,
+		RequirementsRoster:    tripSvc,
+		TripTemplates:         tripSvc,
+		TripSeries:            tripSvc,
+		TripItinerary:         tripSvc,
+		MemberRSVPs:           tripSvc,
+		RSVPHistory:           tripSvc,
+		TripAttendance:        tripSvc,
+		EmergencyInfo:         emergencySvc,
+		TripComments:          tripSvc,
+		TripAnnouncements:     tripSvc,
+	})
 
 	mint := func(now time.Time, kid string, sub string) string {
 		jwt, err := jwks_testutil.MintRS256JWT(
@@ -351,8 +398,8 @@ func TestTrips_CreateTripDraft_Idempotency(t *testing.T) {
 		t.Fatalf("resp=%+v", resp1.Trip)
 	}
 
-	// Same key + same payload (after normalization) should replay.
-	body2 := bytes.NewBufferString(`{"name":"Snow Run"}`)
+	// Same key + same payload (formatted differently) should replay.
+	body2 := bytes.NewBufferString(`{ "name": "  Snow   Run  " }`)
 	req2 := httptest.NewRequest(http.MethodPost, "/trips", body2)
 	req2.Header.Set("Authorization", authz)
 	req2.Header.Set("Content-Type", "application/json")
@@ -591,3 +638,1550 @@ func TestTrips_RSVP_Flow_SetGetSummary_IdempotencyAndCapacity(t *testing.T) {
 		t.Fatalf("expected rsvpSummary and myRsvp in trip details for m1")
 	}
 }
+
+func TestTrips_RSVP_VehicleFromGarageShowsInRigs(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	authz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-1")
+	m1 := provisionCaller(t, h, authz, "alice1@example.com")
+
+	do := func(method, path, body string, hdr map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	type vehicleResp struct {
+		Vehicle struct {
+			ID        string `json:"id"`
+			Name      string `json:"name"`
+			IsDefault bool   `json:"isDefault"`
+		} `json:"vehicle"`
+	}
+	addVehicle := func(body string) vehicleResp {
+		t.Helper()
+		rec := do(http.MethodPost, VehiclesPath, body, nil)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("add vehicle status=%d body=%s", rec.Code, rec.Body.String())
+		}
+		var out vehicleResp
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode vehicle: %v", err)
+		}
+		return out
+	}
+
+	daily := addVehicle(`{"name":"Daily","vehicleProfile":{"make":"Subaru"}}`)
+	trail := addVehicle(`{"name":"Trail rig","vehicleProfile":{"make":"Jeep"}}`)
+	if !daily.Vehicle.IsDefault || trail.Vehicle.IsDefault {
+		t.Fatalf("first vehicle should be the default: daily=%+v trail=%+v", daily, trail)
+	}
+	requireOASErrorCode(t, do(http.MethodPost, VehiclesPath, `{"name":""}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	name := "Rig Trip"
+	cap := 2
+	att := 0
+	now := time.Unix(10, 0).UTC()
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &cap,
+		AttendingRigs:      &att,
+		CreatorMemberID:    m1,
+		OrganizerMemberIDs: []domain.MemberID{m1},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	rigs := func() string {
+		t.Helper()
+		rec := do(http.MethodGet, "/trips/tr/rigs", "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("rigs status=%d body=%s", rec.Code, rec.Body.String())
+		}
+		var out struct {
+			Rigs []struct {
+				MemberID string `json:"memberId"`
+				Vehicle  *struct {
+					ID string `json:"id"`
+				} `json:"vehicle"`
+			} `json:"rigs"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode rigs: %v", err)
+		}
+		if len(out.Rigs) != 1 || out.Rigs[0].MemberID != string(m1) || out.Rigs[0].Vehicle == nil {
+			t.Fatalf("rigs=%s", rec.Body.String())
+		}
+		return out.Rigs[0].Vehicle.ID
+	}
+
+	// Without X-Vehicle-Id the default vehicle is assumed.
+	if rec := do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, map[string]string{"Idempotency-Key": "rsvp-1"}); rec.Code != http.StatusOK {
+		t.Fatalf("set status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if got := rigs(); got != daily.Vehicle.ID {
+		t.Fatalf("rig=%s, want default %s", got, daily.Vehicle.ID)
+	}
+
+	// Naming another vehicle switches the rig without changing attendance.
+	hdr := map[string]string{"Idempotency-Key": "rsvp-2", VehicleIDHeader: trail.Vehicle.ID}
+	if rec := do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, hdr); rec.Code != http.StatusOK {
+		t.Fatalf("set with vehicle status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if got := rigs(); got != trail.Vehicle.ID {
+		t.Fatalf("rig=%s, want %s", got, trail.Vehicle.ID)
+	}
+
+	hdr = map[string]string{"Idempotency-Key": "rsvp-3", VehicleIDHeader: "not-mine"}
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, hdr), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+}
+
+func TestTrips_RSVP_PassengerHeadersAndPeopleCapacity(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	authz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-1")
+	m1 := provisionCaller(t, h, authz, "alice1@example.com")
+
+	do := func(method, path, body string, hdr map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	name := "Family Trip"
+	cap := 2
+	att := 0
+	now := time.Unix(10, 0).UTC()
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &cap,
+		AttendingRigs:      &att,
+		CreatorMemberID:    m1,
+		OrganizerMemberIDs: []domain.MemberID{m1},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	if rec := do(http.MethodPatch, "/trips/tr/settings", `{"capacityPeople":3}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodPatch, "/trips/tr/settings", `{"capacityPeople":0}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	hdr := map[string]string{"Idempotency-Key": "rsvp-1", PassengerCountHeader: "many"}
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, hdr), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	hdr = map[string]string{"Idempotency-Key": "rsvp-2", PassengerCountHeader: "3"}
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, hdr), http.StatusConflict, "TRIP_AT_CAPACITY")
+
+	hdr = map[string]string{"Idempotency-Key": "rsvp-3", PassengerNamesHeader: "Sam, Kit"}
+	if rec := do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, hdr); rec.Code != http.StatusOK {
+		t.Fatalf("set status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(http.MethodGet, "/trips/tr/rigs", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rigs status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var out struct {
+		AttendingPeople int  `json:"attendingPeople"`
+		CapacityPeople  *int `json:"capacityPeople"`
+		Rigs            []struct {
+			PassengerCount int      `json:"passengerCount"`
+			PassengerNames []string `json:"passengerNames"`
+		} `json:"rigs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("decode rigs: %v", err)
+	}
+	if out.AttendingPeople != 3 || out.CapacityPeople == nil || *out.CapacityPeople != 3 ||
+		len(out.Rigs) != 1 || out.Rigs[0].PassengerCount != 2 || len(out.Rigs[0].PassengerNames) != 2 {
+		t.Fatalf("rigs=%s", rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/trips/tr/settings", "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"capacityPeople":3`) {
+		t.Fatalf("get settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_RideShare_OfferRequestAccept(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	driverAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-1")
+	riderAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-2")
+	driver := provisionCaller(t, h, driverAuthz, "driver@example.com")
+	rider := provisionCaller(t, h, riderAuthz, "rider@example.com")
+
+	do := func(authz, method, path, body string, hdr map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	name := "Carpool Trip"
+	cap := 3
+	att := 0
+	now := time.Unix(10, 0).UTC()
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &cap,
+		AttendingRigs:      &att,
+		CreatorMemberID:    driver,
+		OrganizerMemberIDs: []domain.MemberID{driver},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	requireOASErrorCode(t, do(driverAuthz, http.MethodPut, "/trips/tr/rideshare/offer", `{"seats":2}`, nil), http.StatusConflict, "RSVP_REQUIRED")
+	if rec := do(driverAuthz, http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, map[string]string{"Idempotency-Key": "rsvp-1"}); rec.Code != http.StatusOK {
+		t.Fatalf("rsvp status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(driverAuthz, http.MethodPut, "/trips/tr/rideshare/offer", `{"seats":0}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	if rec := do(driverAuthz, http.MethodPut, "/trips/tr/rideshare/offer", `{"seats":2,"notes":"Room for a cooler"}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("offer status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(riderAuthz, http.MethodPost, "/trips/tr/rideshare/requests", `{"driverMemberId":"`+string(driver)+`"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("request status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var created struct {
+		Request struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || created.Request.Status != "PENDING" {
+		t.Fatalf("request body=%s err=%v", rec.Body.String(), err)
+	}
+
+	// Riders cannot accept their own request.
+	requireOASErrorCode(t, do(riderAuthz, http.MethodPost, "/trips/tr/rideshare/requests/"+created.Request.ID+"/accept", "", nil), http.StatusNotFound, "RIDE_REQUEST_NOT_FOUND")
+	if rec := do(driverAuthz, http.MethodPost, "/trips/tr/rideshare/requests/"+created.Request.ID+"/accept", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("accept status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(riderAuthz, http.MethodGet, "/trips/tr/rideshare", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("board status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var board struct {
+		Offers []struct {
+			Seats      int `json:"seats"`
+			SeatsTaken int `json:"seatsTaken"`
+		} `json:"offers"`
+		MyRequests []struct {
+			Status string `json:"status"`
+		} `json:"myRequests"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &board); err != nil {
+		t.Fatalf("decode board: %v", err)
+	}
+	if len(board.Offers) != 1 || board.Offers[0].SeatsTaken != 1 || len(board.MyRequests) != 1 || board.MyRequests[0].Status != "ACCEPTED" {
+		t.Fatalf("board=%s", rec.Body.String())
+	}
+
+	rec = do(driverAuthz, http.MethodGet, "/trips/tr/rigs", "", nil)
+	var rigs struct {
+		AttendingPeople int `json:"attendingPeople"`
+		Rigs            []struct {
+			Riders []struct {
+				MemberID string `json:"memberId"`
+			} `json:"riders"`
+		} `json:"rigs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &rigs); err != nil {
+		t.Fatalf("decode rigs: %v", err)
+	}
+	if rigs.AttendingPeople != 2 || len(rigs.Rigs) != 1 || len(rigs.Rigs[0].Riders) != 1 || rigs.Rigs[0].Riders[0].MemberID != string(rider) {
+		t.Fatalf("rigs=%s", rec.Body.String())
+	}
+
+	if rec := do(riderAuthz, http.MethodPost, "/trips/tr/rideshare/requests/"+created.Request.ID+"/cancel", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("cancel status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(driverAuthz, http.MethodDelete, "/trips/tr/rideshare/offer", "", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("withdraw status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(driverAuthz, http.MethodDelete, "/trips/tr/rideshare/offer", "", nil), http.StatusNotFound, "RIDE_OFFER_NOT_FOUND")
+}
+
+func TestTrips_Requirements_WarningHeaderAndRoster(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	authz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-1")
+	m1 := provisionCaller(t, h, authz, "alice1@example.com")
+
+	do := func(method, path, body string, hdr map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	name := "Rocky Trail"
+	cap := 4
+	att := 0
+	now := time.Unix(10, 0).UTC()
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &cap,
+		AttendingRigs:      &att,
+		CreatorMemberID:    m1,
+		OrganizerMemberIDs: []domain.MemberID{m1},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	rec := do(http.MethodPost, "/members/me/vehicles", `{"name":"Stock","specs":{"tireSizeInches":31,"recoveryGear":["RECOVERY_STRAP"]}}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add vehicle status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var added struct {
+		Vehicle struct {
+			ID    string `json:"id"`
+			Specs struct {
+				TireSizeInches *int     `json:"tireSizeInches"`
+				RecoveryGear   []string `json:"recoveryGear"`
+			} `json:"specs"`
+		} `json:"vehicle"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &added); err != nil || added.Vehicle.Specs.TireSizeInches == nil || *added.Vehicle.Specs.TireSizeInches != 31 {
+		t.Fatalf("add vehicle body=%s err=%v", rec.Body.String(), err)
+	}
+
+	requireOASErrorCode(t, do(http.MethodPatch, "/trips/tr/settings", `{"requirements":{"recoveryGear":["ROPE"]}}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	rec = do(http.MethodPatch, "/trips/tr/settings", `{"requirements":{"minTireSizeInches":33,"lockersRequired":true}}`, nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"minTireSizeInches":33`) {
+		t.Fatalf("settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, map[string]string{"Idempotency-Key": "rsvp-1", VehicleIDHeader: added.Vehicle.ID})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rsvp status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(RequirementWarningsHeader); got != "TIRE_SIZE_BELOW_MINIMUM, LOCKERS_REQUIRED" {
+		t.Fatalf("%s=%q", RequirementWarningsHeader, got)
+	}
+
+	rec = do(http.MethodGet, "/trips/tr/requirements/roster", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("roster status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var roster struct {
+		Roster []struct {
+			MemberID          string `json:"memberId"`
+			MeetsRequirements bool   `json:"meetsRequirements"`
+			Unmet             []struct {
+				Code string `json:"code"`
+			} `json:"unmet"`
+		} `json:"roster"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &roster); err != nil {
+		t.Fatalf("decode roster: %v", err)
+	}
+	if len(roster.Roster) != 1 || roster.Roster[0].MemberID != string(m1) || roster.Roster[0].MeetsRequirements || len(roster.Roster[0].Unmet) != 2 {
+		t.Fatalf("roster=%s", rec.Body.String())
+	}
+
+	// Strict trips turn the warnings into a 409.
+	if rec := do(http.MethodPatch, "/trips/tr/settings", `{"requirements":{"minTireSizeInches":33,"lockersRequired":true,"strict":true}}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("settings strict status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPut, "/trips/tr/rsvp", `{"response":"NO"}`, map[string]string{"Idempotency-Key": "rsvp-2"}); rec.Code != http.StatusOK {
+		t.Fatalf("rsvp no status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`, map[string]string{"Idempotency-Key": "rsvp-3"}), http.StatusConflict, "VEHICLE_REQUIREMENTS_UNMET")
+}
+
+func TestTrips_Difficulty_SettingsAndListFilter(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	authz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-1")
+	m1 := provisionCaller(t, h, authz, "alice1@example.com")
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	for i, id := range []domain.TripID{"dunes", "rocks"} {
+		name := string(id)
+		_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+			ID:                 id,
+			Status:             porttriprepo.StatusPublished,
+			Name:               &name,
+			CreatorMemberID:    m1,
+			OrganizerMemberIDs: []domain.MemberID{m1},
+			CreatedAt:          now.Add(time.Duration(i) * time.Second),
+			UpdatedAt:          now,
+		})
+	}
+
+	requireOASErrorCode(t, do(http.MethodPatch, "/trips/dunes/settings", `{"difficulty":{"rating":9}}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	rec := do(http.MethodPatch, "/trips/dunes/settings", `{"difficulty":{"rating":2,"terrain":["SAND"]}}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"difficulty":{"rating":2,"minRating":null,"maxRating":null,"terrain":["SAND"]}`) {
+		t.Fatalf("settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodPatch, "/trips/rocks/settings", `{"difficulty":{"rating":4,"minRating":3,"terrain":["ROCK","WATER_CROSSING"]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	list := func(query string) []string {
+		t.Helper()
+		rec := do(http.MethodGet, "/trips"+query, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list %q status=%d body=%s", query, rec.Code, rec.Body.String())
+		}
+		var body oas.ListVisibleTripsForMember200JSONResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var ids []string
+		for _, tr := range body.Trips {
+			ids = append(ids, tr.TripId)
+		}
+		return ids
+	}
+	if got := list(""); len(got) != 2 {
+		t.Fatalf("unfiltered = %v", got)
+	}
+	if got := list("?difficultyMax=3"); len(got) != 2 {
+		t.Fatalf("max 3 = %v", got)
+	}
+	if got := list("?difficultyMin=4&terrain=rock,water_crossing"); len(got) != 1 || got[0] != "rocks" {
+		t.Fatalf("min 4 rock+water = %v", got)
+	}
+	if got := list("?terrain=SNOW"); len(got) != 0 {
+		t.Fatalf("snow = %v", got)
+	}
+	requireOASErrorCode(t, do(http.MethodGet, "/trips?difficultyMin=hard", ""), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodGet, "/trips?terrain=LAVA", ""), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+}
+
+func TestTrips_CloneAndTemplates(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	otherAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-other")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	provisionCaller(t, h, otherAuthz, "other@example.com")
+
+	do := func(authz, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Canyon Run"
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		StartDate:          &start,
+		Difficulty:         &domain.Difficulty{Rating: 3},
+		Artifacts: []domain.TripArtifact{
+			{ArtifactID: "art-1", Type: domain.ArtifactTypeGPX, Title: "Track", URL: "https://example.com/track.gpx"},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+
+	rec := do(otherAuthz, http.MethodPost, "/trips/tr/clone", "", nil)
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"status":"DRAFT"`) || !strings.Contains(rec.Body.String(), `"draftVisibility":"PRIVATE"`) {
+		t.Fatalf("clone status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(otherAuthz, http.MethodPost, "/trips/missing/clone", "", nil), http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPost, "/trip-templates", `{"tripId":"tr","name":""}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	rec = do(orgAuthz, http.MethodPost, "/trip-templates", `{"tripId":"tr","name":"Canyon weekend"}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("save template status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var saved struct {
+		Template struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Plan struct {
+				Name       *string `json:"name"`
+				Difficulty *struct {
+					Rating int `json:"rating"`
+				} `json:"difficulty"`
+				Artifacts []struct {
+					URL string `json:"url"`
+				} `json:"artifacts"`
+			} `json:"plan"`
+		} `json:"template"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if saved.Template.ID == "" || saved.Template.Plan.Name == nil || *saved.Template.Plan.Name != name ||
+		saved.Template.Plan.Difficulty == nil || saved.Template.Plan.Difficulty.Rating != 3 || len(saved.Template.Plan.Artifacts) != 1 {
+		t.Fatalf("saved template = %+v", saved.Template)
+	}
+	requireOASErrorCode(t, do(otherAuthz, http.MethodPost, "/trip-templates", `{"tripId":"tr","name":"CANYON WEEKEND"}`, nil), http.StatusConflict, "TRIP_TEMPLATE_NAME_TAKEN")
+
+	rec = do(otherAuthz, http.MethodGet, "/trip-templates", "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"Canyon weekend"`) {
+		t.Fatalf("list templates status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(otherAuthz, http.MethodPost, "/trips", `{"name":"Spring canyon"}`, map[string]string{
+		"Idempotency-Key":    "tmpl-bad",
+		TripTemplateIDHeader: "nope",
+	}), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	rec = do(otherAuthz, http.MethodPost, "/trips", `{"name":"Spring canyon"}`, map[string]string{
+		"Idempotency-Key":    "tmpl-ok",
+		TripTemplateIDHeader: saved.Template.ID,
+	})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create from template status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var created oas.CreateTripDraftResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got, err := tripRepo.GetByID(context.Background(), domain.TripID(created.Trip.TripId))
+	if err != nil || got.Name == nil || *got.Name != "Spring canyon" || got.Difficulty == nil || got.StartDate != nil || len(got.Artifacts) != 1 {
+		t.Fatalf("draft from template = %+v err=%v", got, err)
+	}
+
+	requireOASErrorCode(t, do(otherAuthz, http.MethodDelete, "/trip-templates/"+saved.Template.ID, "", nil), http.StatusForbidden, "FORBIDDEN")
+	if rec := do(orgAuthz, http.MethodDelete, "/trip-templates/"+saved.Template.ID, "", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete template status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(orgAuthz, http.MethodDelete, "/trip-templates/"+saved.Template.ID, "", nil), http.StatusNotFound, "TRIP_TEMPLATE_NOT_FOUND")
+}
+
+func TestTrips_SeriesRoutesAndFutureScope(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	otherAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-other")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	provisionCaller(t, h, otherAuthz, "other@example.com")
+
+	do := func(authz, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Canyon Run"
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		DraftVisibility:    porttriprepo.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	startDate := time.Now().UTC().Format("2006-01-02")
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPost, "/trip-series", `{"templateTripId":"tr","recurrence":"FREQ=WEEKLY;BYDAY=MO","startDate":"someday"}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPost, "/trip-series", `{"templateTripId":"tr","recurrence":"FREQ=YEARLY","startDate":"`+startDate+`"}`, nil), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(otherAuthz, http.MethodPost, "/trip-series", `{"templateTripId":"tr","recurrence":"FREQ=WEEKLY;BYDAY=MO","startDate":"`+startDate+`"}`, nil), http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	rec := do(orgAuthz, http.MethodPost, "/trip-series", `{"templateTripId":"tr","recurrence":"FREQ=WEEKLY;BYDAY=MO","startDate":"`+startDate+`","durationDays":2}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create series status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var created struct {
+		Series struct {
+			ID           string `json:"id"`
+			Recurrence   string `json:"recurrence"`
+			DurationDays int    `json:"durationDays"`
+			Plan         struct {
+				Name *string `json:"name"`
+			} `json:"plan"`
+		} `json:"series"`
+		Occurrences []struct {
+			Date   string `json:"date"`
+			TripID string `json:"tripId"`
+			Status string `json:"status"`
+		} `json:"occurrences"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.Series.ID == "" || created.Series.Recurrence != "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO" || created.Series.DurationDays != 2 ||
+		created.Series.Plan.Name == nil || *created.Series.Plan.Name != name || len(created.Occurrences) < 3 {
+		t.Fatalf("created series = %+v", created)
+	}
+	for _, o := range created.Occurrences {
+		if o.Status != "DRAFT" {
+			t.Fatalf("occurrence = %+v", o)
+		}
+	}
+
+	rec = do(orgAuthz, http.MethodGet, "/trip-series/"+created.Series.ID, "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), created.Occurrences[0].TripID) {
+		t.Fatalf("get series status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(orgAuthz, http.MethodGet, "/trip-series/missing", "", nil), http.StatusNotFound, "TRIP_SERIES_NOT_FOUND")
+
+	first, second := created.Occurrences[0].TripID, created.Occurrences[1].TripID
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPatch, "/trips/"+first, `{"name":"x"}`, map[string]string{"Idempotency-Key": "series-1", SeriesScopeHeader: "ALL"}), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPatch, "/trips/tr", `{"name":"x"}`, map[string]string{"Idempotency-Key": "series-2", SeriesScopeHeader: "FUTURE"}), http.StatusConflict, "TRIP_NOT_IN_SERIES")
+
+	// THIS (the default) edits only the occurrence.
+	rec = do(orgAuthz, http.MethodPatch, "/trips/"+first, `{"name":"Just this one"}`, map[string]string{"Idempotency-Key": "series-3"})
+	if rec.Code != http.StatusOK || rec.Header().Get(SeriesUpdatedTripsHeader) != "" {
+		t.Fatalf("this-scope update status=%d headers=%v body=%s", rec.Code, rec.Header(), rec.Body.String())
+	}
+
+	rec = do(orgAuthz, http.MethodPatch, "/trips/"+second, `{"name":"Weekly canyon"}`, map[string]string{"Idempotency-Key": "series-4", SeriesScopeHeader: "future"})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"Weekly canyon"`) {
+		t.Fatalf("future-scope update status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	updated := rec.Header().Get(SeriesUpdatedTripsHeader)
+	if strings.Contains(updated, first) || strings.Contains(updated, second) || !strings.Contains(updated, created.Occurrences[2].TripID) || rec.Header().Get(SeriesSkippedTripsHeader) != "" {
+		t.Fatalf("series headers updated=%q skipped=%q", updated, rec.Header().Get(SeriesSkippedTripsHeader))
+	}
+	got, _ := tripRepo.GetByID(context.Background(), domain.TripID(first))
+	if got.Name == nil || *got.Name != "Just this one" {
+		t.Fatalf("first occurrence name = %v", got.Name)
+	}
+
+	// The settings route honors the same scope.
+	rec = do(orgAuthz, http.MethodPatch, "/trips/"+second+"/settings", `{"capacityPeople":12}`, map[string]string{SeriesScopeHeader: "FUTURE"})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get(SeriesUpdatedTripsHeader), created.Occurrences[2].TripID) {
+		t.Fatalf("settings future-scope status=%d headers=%v body=%s", rec.Code, rec.Header(), rec.Body.String())
+	}
+	rec = do(orgAuthz, http.MethodGet, "/trip-series/"+created.Series.ID, "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"Weekly canyon"`) || !strings.Contains(rec.Body.String(), `"capacityPeople":12`) {
+		t.Fatalf("series after edits status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_ItineraryRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	otherAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-other")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	provisionCaller(t, h, otherAuthz, "other@example.com")
+
+	do := func(authz, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Canyon Run"
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		StartDate:          &start,
+		EndDate:            &end,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	if rec := do(otherAuthz, http.MethodGet, "/trips/tr", ""); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"itinerary"`) {
+		t.Fatalf("details without itinerary status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	day := `{"title":"Into the canyon","stops":[{"type":"MEETING_POINT","location":{"label":"Gas station"},"time":"07:30"},{"type":"CAMPSITE","location":{"label":"Mesa camp","latitude":38.5,"longitude":-109.6}}],"segments":[{"name":"Shafer Trail","distanceMiles":18.5}]}`
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPut, "/trips/tr/itinerary/May-1", day), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPut, "/trips/tr/itinerary/2026-05-04", day), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(otherAuthz, http.MethodPut, "/trips/tr/itinerary/2026-05-01", day), http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	rec := do(orgAuthz, http.MethodPut, "/trips/tr/itinerary/2026-05-01", day)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"date":"2026-05-01"`) || !strings.Contains(rec.Body.String(), `"type":"CAMPSITE"`) {
+		t.Fatalf("put day status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(otherAuthz, http.MethodGet, "/trips/tr/itinerary", "")
+	var got struct {
+		Itinerary []struct {
+			Date  string `json:"date"`
+			Stops []struct {
+				Location struct {
+					Label    string   `json:"label"`
+					Latitude *float64 `json:"latitude"`
+				} `json:"location"`
+				Time *string `json:"time"`
+			} `json:"stops"`
+			Segments []struct {
+				DistanceMiles *float64 `json:"distanceMiles"`
+			} `json:"segments"`
+		} `json:"itinerary"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("get itinerary status=%d body=%s err=%v", rec.Code, rec.Body.String(), err)
+	}
+	if len(got.Itinerary) != 1 || len(got.Itinerary[0].Stops) != 2 || got.Itinerary[0].Stops[0].Time == nil || *got.Itinerary[0].Stops[0].Time != "07:30" ||
+		got.Itinerary[0].Stops[1].Location.Latitude == nil || len(got.Itinerary[0].Segments) != 1 || *got.Itinerary[0].Segments[0].DistanceMiles != 18.5 {
+		t.Fatalf("itinerary = %+v", got.Itinerary)
+	}
+
+	rec = do(otherAuthz, http.MethodGet, "/trips/tr", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"itinerary":[{"date":"2026-05-01"`) || !strings.Contains(rec.Body.String(), `"tripId":"tr"`) {
+		t.Fatalf("details with itinerary status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(orgAuthz, http.MethodDelete, "/trips/tr/itinerary/2026-05-01", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete day status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(orgAuthz, http.MethodDelete, "/trips/tr/itinerary/2026-05-01", ""), http.StatusNotFound, "ITINERARY_DAY_NOT_FOUND")
+}
+
+func TestTrips_RSVPDeadline_SettingsAndOrganizerOverride(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(authz, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "idem-"+method+path+body)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Canyon Run"
+	rigs := 4
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	requireOASErrorCode(t, do(orgAuthz, http.MethodPatch, "/trips/tr/settings", `{"rsvpDeadline":"next week"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	rec := do(orgAuthz, http.MethodPatch, "/trips/tr/settings", `{"rsvpDeadline":"2020-01-01T00:00:00Z"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"rsvpDeadline":"2020-01-01T00:00:00Z"`) {
+		t.Fatalf("patch settings status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(memberAuthz, http.MethodGet, "/trips/tr", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"rsvpActionsEnabled":false`) {
+		t.Fatalf("details status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(memberAuthz, http.MethodPut, "/trips/tr/rsvp", `{"response":"YES"}`), http.StatusConflict, "RSVP_CLOSED")
+
+	path := "/trips/tr/rsvps/" + string(member)
+	requireOASErrorCode(t, do(memberAuthz, http.MethodPut, path, `{"response":"YES","reason":"late"}`), http.StatusForbidden, "FORBIDDEN")
+	rec = do(orgAuthz, http.MethodPut, path, `{"response":"YES","reason":"late"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"memberId":"`+string(member)+`"`) || !strings.Contains(rec.Body.String(), `"response":"YES"`) {
+		t.Fatalf("organizer rsvp status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_MemberRSVPRoutes_IdempotentSetClearMove(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", orgAuthz)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	rigs := 1
+	for _, id := range []domain.TripID{"sat", "sun"} {
+		name := "Trip " + string(id)
+		_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+			ID:                 id,
+			Status:             porttriprepo.StatusPublished,
+			Name:               &name,
+			CreatorMemberID:    org,
+			OrganizerMemberIDs: []domain.MemberID{org},
+			CapacityRigs:       &rigs,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		})
+	}
+
+	path := "/trips/sat/rsvps/" + string(member)
+	set := `{"response":"YES","reason":"texted the leader"}`
+	requireOASErrorCode(t, do(http.MethodPut, path, "", set), http.StatusBadRequest, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodPut, path, "k-missing-reason", `{"response":"YES"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	rec := do(http.MethodPut, path, "k-set", set)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"changedBy":"`+string(org)+`"`) || !strings.Contains(rec.Body.String(), `"changeReason":"texted the leader"`) {
+		t.Fatalf("set status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPut, path, "k-set", set); rec.Code != http.StatusOK || rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay status=%d replayed=%q", rec.Code, rec.Header().Get("Idempotent-Replayed"))
+	}
+	requireOASErrorCode(t, do(http.MethodPut, path, "k-set", `{"response":"NO","reason":"texted the leader"}`), http.StatusConflict, "IDEMPOTENCY_KEY_REUSE")
+
+	// Sunday is full; the move only succeeds when the organizer bypasses capacity.
+	_ = do(http.MethodPut, "/trips/sun/rsvps/"+string(org), "k-org", `{"response":"YES","reason":"leading"}`)
+	requireOASErrorCode(t, do(http.MethodPost, path+"/move", "k-move-1", `{"toTripId":"sun","reason":"wrong day"}`), http.StatusConflict, "TRIP_AT_CAPACITY")
+	rec = do(http.MethodPost, path+"/move", "k-move-2", `{"toTripId":"sun","reason":"wrong day","bypassCapacity":true}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"tripId":"sun"`) || !strings.Contains(rec.Body.String(), `"capacityOverride":true`) {
+		t.Fatalf("move status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodPost, "/trips/sun/rsvps/"+string(member)+"/clear", "k-clear", `{"reason":"sick"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"response":"UNSET"`) || !strings.Contains(rec.Body.String(), `"capacityOverride":false`) {
+		t.Fatalf("clear status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_RSVPHistoryRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(method, path, authz, key, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "History Trip"
+	rigs := 4
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	if rec := do(http.MethodPut, "/trips/t1/rsvp", memberAuthz, "k-yes", `{"response":"YES"}`); rec.Code != http.StatusOK {
+		t.Fatalf("SetMyRSVP status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, "/trips/t1/rsvps/"+string(member)+"/clear", orgAuthz, "k-clear", `{"reason":"sick"}`); rec.Code != http.StatusOK {
+		t.Fatalf("clear status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(http.MethodGet, "/trips/t1/rsvp-history", memberAuthz, "", ""), http.StatusForbidden, "FORBIDDEN")
+
+	rec := do(http.MethodGet, "/trips/t1/rsvp-history", orgAuthz, "", "")
+	var body struct {
+		Changes []struct {
+			MemberID         string  `json:"memberId"`
+			PreviousResponse *string `json:"previousResponse"`
+			Response         string  `json:"response"`
+			ChangedBy        *string `json:"changedBy"`
+			ChangeReason     *string `json:"changeReason"`
+		} `json:"changes"`
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("trip history status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Changes) != 2 {
+		t.Fatalf("changes=%+v, want 2", body.Changes)
+	}
+	first, last := body.Changes[0], body.Changes[1]
+	if first.MemberID != string(member) || first.PreviousResponse != nil || first.Response != "YES" || first.ChangedBy == nil || *first.ChangedBy != string(member) {
+		t.Fatalf("first change=%+v", first)
+	}
+	if last.PreviousResponse == nil || *last.PreviousResponse != "YES" || last.Response != "UNSET" ||
+		last.ChangedBy == nil || *last.ChangedBy != string(org) || last.ChangeReason == nil || *last.ChangeReason != "sick" {
+		t.Fatalf("last change=%+v", last)
+	}
+
+	rec = do(http.MethodGet, "/members/me/rsvp-history", memberAuthz, "", "")
+	if rec.Code != http.StatusOK || strings.Count(rec.Body.String(), `"tripId":"t1"`) != 2 {
+		t.Fatalf("my history status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodGet, "/members/me/rsvp-history", orgAuthz, "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"changes":[]`) {
+		t.Fatalf("organizer's own history status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_AttendanceRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(method, path, authz, key, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The trip is running today, so check-in is open.
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	name := "Today's Trip"
+	rigs := 4
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		StartDate:          &today,
+		EndDate:            &today,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+	if rec := do(http.MethodPut, "/trips/t1/rsvp", memberAuthz, "k-yes", `{"response":"YES"}`); rec.Code != http.StatusOK {
+		t.Fatalf("SetMyRSVP status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/t1/attendance/"+string(org), memberAuthz, "", `{"status":"PRESENT"}`), http.StatusForbidden, "FORBIDDEN")
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/t1/attendance/"+string(member), orgAuthz, "", `{"status":"MAYBE"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	rec := do(http.MethodPut, "/trips/t1/attendance/"+string(member), orgAuthz, "", `{"status":"PRESENT"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"walkUp":false`) || !strings.Contains(rec.Body.String(), `"recordedBy":"`+string(org)+`"`) {
+		t.Fatalf("check-in status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodPut, "/trips/t1/attendance/"+string(org), orgAuthz, "", `{"status":"PRESENT"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"walkUp":true`) {
+		t.Fatalf("walk-up status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(http.MethodGet, "/trips/t1/attendance", memberAuthz, "", ""), http.StatusForbidden, "FORBIDDEN")
+	rec = do(http.MethodGet, "/trips/t1/attendance", orgAuthz, "", "")
+	var sheet struct {
+		Attendance struct {
+			CheckInOpen bool `json:"checkInOpen"`
+			Summary     struct {
+				Present int `json:"present"`
+				WalkUps int `json:"walkUps"`
+			} `json:"summary"`
+			Records []struct {
+				Member struct {
+					MemberID string `json:"memberId"`
+				} `json:"member"`
+				Status      string  `json:"status"`
+				CheckedInAt *string `json:"checkedInAt"`
+			} `json:"records"`
+		} `json:"attendance"`
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("attendance status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &sheet); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	a := sheet.Attendance
+	if !a.CheckInOpen || a.Summary.Present != 2 || a.Summary.WalkUps != 1 || len(a.Records) != 2 || a.Records[0].CheckedInAt == nil {
+		t.Fatalf("attendance=%+v", a)
+	}
+
+	// The attendee rig listing carries the check-in counts for everyone.
+	rec = do(http.MethodGet, "/trips/t1/rigs", memberAuthz, "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"attendance":{"present":2,"absent":0,"walkUps":1,"notCheckedIn":0}`) {
+		t.Fatalf("rigs status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/trips/t1/attendance/reliability", orgAuthz, "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"attended":1,"noShows":0,"walkUps":0,"showRatePercent":100`) {
+		t.Fatalf("reliability status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_EmergencyInfoRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(method, path, authz, key, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The test router's emergency info clock sits at the start of 1970-01-01.
+	now := time.Unix(10, 0).UTC()
+	name := "Emergency Trip"
+	rigs := 4
+	start := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		StartDate:          &start,
+		EndDate:            &end,
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	requireOASErrorCode(t, do(http.MethodGet, "/members/me/emergency-info", memberAuthz, "", ""), http.StatusNotFound, "EMERGENCY_INFO_NOT_FOUND")
+	requireOASErrorCode(t, do(http.MethodPut, "/members/me/emergency-info", memberAuthz, "", `{"contactName":"Carol","contactPhone":"nope"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	rec := do(http.MethodPut, "/members/me/emergency-info", memberAuthz, "", `{"contactName":"Carol","contactPhone":"555-010-0199","bloodType":"o-"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("put status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Fatalf("Cache-Control=%q, want no-store", got)
+	}
+
+	if rec := do(http.MethodPut, "/trips/t1/rsvp", memberAuthz, "k-yes", `{"response":"YES"}`); rec.Code != http.StatusOK {
+		t.Fatalf("SetMyRSVP status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	requireOASErrorCode(t, do(http.MethodGet, "/trips/t1/emergency-info/"+string(org), memberAuthz, "", ""), http.StatusForbidden, "FORBIDDEN")
+
+	rec = do(http.MethodGet, "/trips/t1/emergency-info/"+string(member), orgAuthz, "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("attendee info status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var one struct {
+		EmergencyInfo struct {
+			ContactName string  `json:"contactName"`
+			BloodType   *string `json:"bloodType"`
+		} `json:"emergencyInfo"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &one); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if one.EmergencyInfo.ContactName != "Carol" || one.EmergencyInfo.BloodType == nil || *one.EmergencyInfo.BloodType != "O-" {
+		t.Fatalf("body=%s", rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/trips/t1/emergency-info", orgAuthz, "", "")
+	var roster struct {
+		Attendees []struct {
+			Member struct {
+				MemberID string `json:"memberId"`
+			} `json:"member"`
+			EmergencyInfo *struct{} `json:"emergencyInfo"`
+		} `json:"attendees"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &roster); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(roster.Attendees) != 1 || roster.Attendees[0].Member.MemberID != string(member) || roster.Attendees[0].EmergencyInfo == nil {
+		t.Fatalf("roster status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/members/me/emergency-info/access-log", memberAuthz, "", "")
+	var log struct {
+		Accesses []struct {
+			AccessedBy *string `json:"accessedBy"`
+			TripID     *string `json:"tripId"`
+			Action     string  `json:"action"`
+		} `json:"accesses"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &log); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(log.Accesses) != 3 || log.Accesses[0].Action != "UPDATE" || log.Accesses[0].TripID != nil ||
+		log.Accesses[1].AccessedBy == nil || *log.Accesses[1].AccessedBy != string(org) || log.Accesses[1].TripID == nil || *log.Accesses[1].TripID != "t1" {
+		t.Fatalf("access log body=%s", rec.Body.String())
+	}
+
+	if rec := do(http.MethodDelete, "/members/me/emergency-info", memberAuthz, "", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodGet, "/members/me/emergency-info", memberAuthz, "", ""), http.StatusNotFound, "EMERGENCY_INFO_NOT_FOUND")
+}
+
+func TestTrips_WaiverRoutes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	memberRepo := memmemberrepo.NewRepo()
+	tripRepo := memtriprepo.NewRepo()
+	waiverRepo := memwaiverrepo.NewRepo()
+	memberSvc := members.NewService(memberRepo, clk)
+	tripSvc := trips.NewServiceWithOptions(tripRepo, memberRepo, memrsvprepo.NewRepo(), trips.Options{Waivers: waiverRepo})
+	waiverSvc := waivers.NewService(waiverRepo, clk)
+	h := NewRouterWithOptions(NewServer(memberSvc, tripSvc), RouterOptions{
+		AuthMiddleware: NewDevAuthMiddleware(""),
+		Members:        memberSvc,
+		Waivers:        waiverSvc,
+		TripWaivers:    tripSvc,
+	})
+
+	org, err := memberSvc.CreateMyMember(ctx, "sub-org", members.CreateMyMemberInput{DisplayName: "Olive", Email: "org@example.com"})
+	if err != nil {
+		t.Fatalf("CreateMyMember org: %v", err)
+	}
+	if _, err := memberSvc.CreateMyMember(ctx, "sub-member", members.CreateMyMemberInput{DisplayName: "Mo", Email: "member@example.com"}); err != nil {
+		t.Fatalf("CreateMyMember member: %v", err)
+	}
+	name := "Waiver Trip"
+	rigs := 4
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	_ = tripRepo.Create(ctx, porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org.ID,
+		OrganizerMemberIDs: []domain.MemberID{org.ID},
+		StartDate:          &start,
+		EndDate:            &end,
+		CapacityRigs:       &rigs,
+		CreatedAt:          clk.Now(),
+		UpdatedAt:          clk.Now(),
+	})
+	season, err := waiverSvc.Create(ctx, waivers.CreateInput{Title: "Season", Body: "Wheeling is risky.", RequiredForAllTrips: true, CreatedBy: "ops"})
+	if err != nil {
+		t.Fatalf("Create season: %v", err)
+	}
+	rock, err := waiverSvc.Create(ctx, waivers.CreateInput{Title: "Rock Crawling", Body: "Rocks are hard.", CreatedBy: "ops"})
+	if err != nil {
+		t.Fatalf("Create rock: %v", err)
+	}
+
+	do := func(method, path, sub, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("X-Debug-Subject", sub)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "k-"+method+path+body)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/t1/required-waivers", "sub-member", `{"waiverIds":["`+string(rock.ID)+`"]}`), http.StatusForbidden, "FORBIDDEN")
+	requireOASErrorCode(t, do(http.MethodPut, "/trips/t1/required-waivers", "sub-org", `{"waiverIds":["nope"]}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	if rec := do(http.MethodPut, "/trips/t1/required-waivers", "sub-org", `{"waiverIds":["`+string(rock.ID)+`"]}`); rec.Code != http.StatusOK {
+		t.Fatalf("set required status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(http.MethodPut, "/trips/t1/rsvp", "sub-member", `{"response":"YES"}`)
+	requireOASErrorCode(t, rec, http.StatusConflict, "WAIVER_REQUIRED")
+	for _, id := range []domain.WaiverID{season.ID, rock.ID} {
+		if !strings.Contains(rec.Body.String(), string(id)) {
+			t.Fatalf("WAIVER_REQUIRED body %s does not list %s", rec.Body.String(), id)
+		}
+	}
+
+	rec = do(http.MethodGet, "/waivers/"+string(season.ID), "sub-member", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("get waiver status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var got struct {
+		Waiver struct {
+			VersionHash    string     `json:"versionHash"`
+			Body           string     `json:"body"`
+			AcknowledgedAt *time.Time `json:"acknowledgedAt"`
+		} `json:"waiver"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode waiver: %v", err)
+	}
+	if got.Waiver.Body != "Wheeling is risky." || got.Waiver.VersionHash != season.VersionHash || got.Waiver.AcknowledgedAt != nil {
+		t.Fatalf("waiver = %+v", got.Waiver)
+	}
+
+	requireOASErrorCode(t, do(http.MethodPost, "/waivers/"+string(season.ID)+"/acknowledgements", "sub-member", `{"versionHash":"stale"}`), http.StatusConflict, "WAIVER_VERSION_CHANGED")
+	for _, w := range []domain.Waiver{season, rock} {
+		if rec := do(http.MethodPost, "/waivers/"+string(w.ID)+"/acknowledgements", "sub-member", `{"versionHash":"`+w.VersionHash+`"}`); rec.Code != http.StatusOK {
+			t.Fatalf("acknowledge %s status=%d body=%s", w.ID, rec.Code, rec.Body.String())
+		}
+	}
+	acks, err := waiverSvc.ListAcknowledgements(ctx, season.ID)
+	if err != nil || len(acks) != 1 || acks[0].Subject != "sub-member" {
+		t.Fatalf("acknowledgements = %+v err=%v, want one from sub-member", acks, err)
+	}
+
+	rec = do(http.MethodGet, "/trips/t1/required-waivers", "sub-member", "")
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"acknowledgedAt":null`) {
+		t.Fatalf("trip waivers status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPut, "/trips/t1/rsvp", "sub-member", `{"response":"YES"}`); rec.Code != http.StatusOK {
+		t.Fatalf("SetMyRSVP status=%d body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrips_CommentRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, _ := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	provisionCaller(t, h, memberAuthz, "member@example.com")
+
+	do := func(method, path, authz, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Creek Trip"
+	rigs := 4
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		StartDate:          &start,
+		EndDate:            &end,
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	type commentResp struct {
+		Comment struct {
+			CommentID string  `json:"commentId"`
+			ParentID  *string `json:"parentId"`
+			Body      string  `json:"body"`
+			Pinned    bool    `json:"pinned"`
+			Deleted   bool    `json:"deleted"`
+			Author    struct {
+				MemberID string `json:"memberId"`
+			} `json:"author"`
+		} `json:"comment"`
+	}
+	post := func(authz, body string) commentResp {
+		t.Helper()
+		rec := do(http.MethodPost, "/trips/t1/comments", authz, body)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("post status=%d body=%s", rec.Code, rec.Body.String())
+		}
+		var out commentResp
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("decode comment: %v", err)
+		}
+		return out
+	}
+
+	requireOASErrorCode(t, do(http.MethodPost, "/trips/t1/comments", memberAuthz, `{"body":"   "}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+	requireOASErrorCode(t, do(http.MethodPost, "/trips/missing/comments", memberAuthz, `{"body":"Hello"}`), http.StatusNotFound, "TRIP_NOT_FOUND")
+
+	q := post(memberAuthz, `{"body":"Is the creek crossing passable?"}`)
+	reply := post(orgAuthz, `{"body":"Yes.","parentId":"`+q.Comment.CommentID+`"}`)
+	if reply.Comment.ParentID == nil || *reply.Comment.ParentID != q.Comment.CommentID || reply.Comment.Author.MemberID != string(org) {
+		t.Fatalf("reply = %+v", reply.Comment)
+	}
+	post(memberAuthz, `{"body":"Third"}`)
+
+	commentPath := "/trips/t1/comments/" + q.Comment.CommentID
+	requireOASErrorCode(t, do(http.MethodPatch, commentPath, orgAuthz, `{"body":"Edited by someone else"}`), http.StatusForbidden, "FORBIDDEN")
+	if rec := do(http.MethodPatch, commentPath, memberAuthz, `{"body":"Is the creek passable after rain?"}`); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"editedAt":"`) {
+		t.Fatalf("edit status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodPut, commentPath+"/pin", memberAuthz, ""), http.StatusForbidden, "FORBIDDEN")
+	if rec := do(http.MethodPut, commentPath+"/pin", orgAuthz, ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"pinned":true`) {
+		t.Fatalf("pin status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	var page struct {
+		Pinned     []json.RawMessage `json:"pinned"`
+		Comments   []json.RawMessage `json:"comments"`
+		NextCursor *string           `json:"nextCursor"`
+	}
+	rec := do(http.MethodGet, "/trips/t1/comments?limit=2", memberAuthz, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decode page: %v", err)
+	}
+	if len(page.Pinned) != 1 || len(page.Comments) != 2 || page.NextCursor == nil {
+		t.Fatalf("first page = %s", rec.Body.String())
+	}
+	rec = do(http.MethodGet, "/trips/t1/comments?limit=2&cursor="+*page.NextCursor, memberAuthz, "")
+	page.Pinned, page.Comments, page.NextCursor = nil, nil, nil
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decode page: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(page.Pinned) != 0 || len(page.Comments) != 1 || page.NextCursor != nil {
+		t.Fatalf("second page status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodGet, "/trips/t1/comments?limit=abc", memberAuthz, ""), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	// Organizers moderate: deleting someone else's comment leaves a placeholder.
+	if rec := do(http.MethodDelete, commentPath, orgAuthz, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodGet, "/trips/t1/comments", memberAuthz, "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"deleted":true`) || strings.Contains(rec.Body.String(), "creek") {
+		t.Fatalf("list after delete status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	requireOASErrorCode(t, do(http.MethodDelete, "/trips/t1/comments/"+reply.Comment.CommentID, memberAuthz, ""), http.StatusForbidden, "FORBIDDEN")
+}
+
+func TestTrips_AnnouncementRoutes(t *testing.T) {
+	t.Parallel()
+
+	h, mint, tripRepo, memberRepo := newTestTripRouter(t)
+	orgAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-org")
+	memberAuthz := "Bearer " + mint(time.Unix(1700000000, 0), "kid-1", "sub-member")
+	org := provisionCaller(t, h, orgAuthz, "org@example.com")
+	member := provisionCaller(t, h, memberAuthz, "member@example.com")
+	// Announcements are only mailed to verified addresses.
+	m, err := memberRepo.GetByID(context.Background(), member)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	verifiedAt := time.Unix(20, 0).UTC()
+	m.EmailVerifiedAt = &verifiedAt
+	if err := memberRepo.Update(context.Background(), m); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	do := func(method, path, authz, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", authz)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	now := time.Unix(10, 0).UTC()
+	name := "Creek Trip"
+	rigs := 4
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	_ = tripRepo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 "t1",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CreatorMemberID:    org,
+		OrganizerMemberIDs: []domain.MemberID{org},
+		StartDate:          &start,
+		EndDate:            &end,
+		CapacityRigs:       &rigs,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	requireOASErrorCode(t, do(http.MethodPost, "/trips/t1/announcements", memberAuthz, `{"subject":"Hi","body":"Hi","audience":"EVERYONE"}`), http.StatusForbidden, "FORBIDDEN")
+	requireOASErrorCode(t, do(http.MethodPost, "/trips/t1/announcements", orgAuthz, `{"subject":"Hi","body":"Hi","audience":"ORGANIZERS"}`), http.StatusUnprocessableEntity, "VALIDATION_ERROR")
+
+	rec := do(http.MethodPost, "/trips/t1/announcements", orgAuthz, `{"subject":"Trail update","body":"The upper gate is closed.","audience":"EVERYONE"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("post status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var report struct {
+		Announcement struct {
+			AnnouncementID string `json:"announcementId"`
+			Audience       string `json:"audience"`
+		} `json:"announcement"`
+		Summary struct {
+			Sent int `json:"sent"`
+		} `json:"summary"`
+		Deliveries []struct {
+			Recipient struct {
+				MemberID string `json:"memberId"`
+			} `json:"recipient"`
+			Status string `json:"status"`
+		} `json:"deliveries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Announcement.Audience != "EVERYONE" || report.Summary.Sent != 1 || len(report.Deliveries) != 1 ||
+		report.Deliveries[0].Recipient.MemberID != string(member) || report.Deliveries[0].Status != "SENT" {
+		t.Fatalf("report = %s", rec.Body.String())
+	}
+
+	deliveriesPath := "/trips/t1/announcements/" + report.Announcement.AnnouncementID + "/deliveries"
+	requireOASErrorCode(t, do(http.MethodGet, deliveriesPath, memberAuthz, ""), http.StatusForbidden, "FORBIDDEN")
+	if rec := do(http.MethodGet, deliveriesPath, orgAuthz, ""); rec.Code != http.StatusOK {
+		t.Fatalf("deliveries status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/trips/t1", memberAuthz, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("trip details status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var details struct {
+		Trip struct {
+			TripID        string `json:"tripId"`
+			Announcements []struct {
+				Subject string `json:"subject"`
+			} `json:"announcements"`
+		} `json:"trip"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &details); err != nil {
+		t.Fatalf("decode details: %v", err)
+	}
+	if details.Trip.TripID != "t1" || len(details.Trip.Announcements) != 1 || details.Trip.Announcements[0].Subject != "Trail update" {
+		t.Fatalf("trip details = %s", rec.Body.String())
+	}
+
+	// A retried post with the same Idempotency-Key replays the first response instead of
+	// mailing everyone again.
+	postOnce := func() *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/trips/t1/announcements", bytes.NewBufferString(`{"subject":"Gate open","body":"Never mind.","audience":"EVERYONE"}`))
+		req.Header.Set("Authorization", orgAuthz)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "announce-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	first, second := postOnce(), postOnce()
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated || first.Body.String() != second.Body.String() {
+		t.Fatalf("replay: first=%d %s second=%d %s", first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	rec = do(http.MethodGet, "/trips/t1/announcements", memberAuthz, "")
+	var list struct {
+		Announcements []struct {
+			Subject string `json:"subject"`
+		} `json:"announcements"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list.Announcements) != 2 {
+		t.Fatalf("announcements after replay = %s", rec.Body.String())
+	}
+}
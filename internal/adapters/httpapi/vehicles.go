@@ -0,0 +1,290 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/httpapi/oas"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// VehicleIDHeader names the garage vehicle the caller is bringing on SetMyRSVP.
+// It is a header (not a body field) because the request schema is owned by the OpenAPI contract.
+const VehicleIDHeader = "X-Vehicle-Id"
+
+// Vehicle garage routes are out-of-spec (like the email verification routes): the OpenAPI
+// contract only knows the single vehicleProfile on the member profile, which mirrors the
+// default vehicle.
+const (
+	// VehiclesPath lists (GET) and adds (POST) the caller's vehicles.
+	VehiclesPath = "/members/me/vehicles"
+	// VehiclePath updates (PATCH) or deletes (DELETE) one vehicle; POST .../default makes it the default.
+	VehiclePath = "/members/me/vehicles/{vehicleId}"
+	// TripRigsPath lists the vehicle each attendee of a trip is bringing and who rides along.
+	TripRigsPath = "/trips/{tripId}/rigs"
+)
+
+// VehicleGarage is the members use-case surface needed by the garage routes.
+type VehicleGarage interface {
+	MemberResolver
+	ListMyVehicles(ctx context.Context, subject domain.SubjectID) ([]domain.Vehicle, error)
+	AddMyVehicle(ctx context.Context, subject domain.SubjectID, in members.AddVehicleInput) (domain.Vehicle, error)
+	UpdateMyVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID, in members.UpdateVehicleInput) (domain.Vehicle, error)
+	DeleteMyVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID) error
+	SetMyDefaultVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID) (domain.Vehicle, error)
+}
+
+// TripRigLister is the trips use-case surface needed by TripRigsPath.
+type TripRigLister interface {
+	GetTripRSVPSummary(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.TripRSVPSummary, error)
+}
+
+type vehicleIDKey struct{}
+
+func WithVehicleID(ctx context.Context, id domain.VehicleID) context.Context {
+	return context.WithValue(ctx, vehicleIDKey{}, id)
+}
+
+// VehicleIDFromContext returns the X-Vehicle-Id sent with the request, or nil.
+func VehicleIDFromContext(ctx context.Context) *domain.VehicleID {
+	v, ok := ctx.Value(vehicleIDKey{}).(domain.VehicleID)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// newVehicleIDMiddleware copies X-Vehicle-Id into the context of SetMyRSVP requests.
+func newVehicleIDMiddleware() oas.StrictMiddlewareFunc {
+	return func(f oas.StrictHandlerFunc, operationID string) oas.StrictHandlerFunc {
+		if operationID != "SetMyRSVP" {
+			return f
+		}
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			if id := strings.TrimSpace(r.Header.Get(VehicleIDHeader)); id != "" {
+				ctx = WithVehicleID(ctx, domain.VehicleID(id))
+			}
+			return f(ctx, w, r, request)
+		}
+	}
+}
+
+type vehicleJSON struct {
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	IsDefault      bool                `json:"isDefault"`
+	VehicleProfile *oas.VehicleProfile `json:"vehicleProfile"`
+	Specs          vehicleSpecsJSON    `json:"specs"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+type attendeeRigJSON struct {
+	MemberID       string          `json:"memberId"`
+	DisplayName    string          `json:"displayName"`
+	Vehicle        *vehicleJSON    `json:"vehicle"`
+	PassengerCount int             `json:"passengerCount"`
+	PassengerNames []string        `json:"passengerNames"`
+	Riders         []memberRefJSON `json:"riders"`
+}
+
+func vehicleToJSON(v domain.Vehicle) vehicleJSON {
+	return vehicleJSON{
+		ID:             string(v.ID),
+		Name:           v.Name,
+		IsDefault:      v.IsDefault,
+		VehicleProfile: vehicleProfileFromDomain(v.Profile),
+		Specs:          vehicleSpecsToJSON(v.Specs),
+		CreatedAt:      v.CreatedAt,
+		UpdatedAt:      v.UpdatedAt,
+	}
+}
+
+func mountVehicleGarage(r chi.Router, g VehicleGarage, rigs TripRigLister) {
+	r.Get(VehiclesPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		vs, err := g.ListMyVehicles(req.Context(), sub)
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		out := make([]vehicleJSON, 0, len(vs))
+		for _, v := range vs {
+			out = append(out, vehicleToJSON(v))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"vehicles": out})
+	}))
+
+	r.Post(VehiclesPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		var body struct {
+			Name           string              `json:"name"`
+			IsDefault      bool                `json:"isDefault"`
+			VehicleProfile *oas.VehicleProfile `json:"vehicleProfile"`
+			Specs          *vehicleSpecsJSON   `json:"specs"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in := members.AddVehicleInput{Name: body.Name, IsDefault: body.IsDefault}
+		if body.VehicleProfile != nil {
+			in.Profile = vehicleProfilePatchFromOAS(*body.VehicleProfile)
+		}
+		if body.Specs != nil {
+			specs := vehicleSpecsFromJSON(*body.Specs)
+			in.Specs = &specs
+		}
+		v, err := g.AddMyVehicle(req.Context(), sub, in)
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"vehicle": vehicleToJSON(v)})
+	}))
+
+	r.Patch(VehiclePath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		in, err := updateVehicleInputFromJSON(body)
+		if err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		v, err := g.UpdateMyVehicle(req.Context(), sub, domain.VehicleID(chi.URLParam(req, "vehicleId")), in)
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"vehicle": vehicleToJSON(v)})
+	}))
+
+	r.Delete(VehiclePath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		if err := g.DeleteMyVehicle(req.Context(), sub, domain.VehicleID(chi.URLParam(req, "vehicleId"))); err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r.Post(VehiclePath+"/default", withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		v, err := g.SetMyDefaultVehicle(req.Context(), sub, domain.VehicleID(chi.URLParam(req, "vehicleId")))
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"vehicle": vehicleToJSON(v)})
+	}))
+
+	if rigs == nil {
+		return
+	}
+	r.Get(TripRigsPath, withMember(g, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		sum, err := rigs.GetTripRSVPSummary(req.Context(), me.ID, domain.TripID(chi.URLParam(req, "tripId")))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		out := make([]attendeeRigJSON, 0, len(sum.AttendeeRigs))
+		for _, rig := range sum.AttendeeRigs {
+			a := attendeeRigJSON{
+				MemberID:       string(rig.Member.ID),
+				DisplayName:    rig.Member.DisplayName,
+				PassengerCount: rig.PassengerCount,
+				PassengerNames: rig.PassengerNames,
+			}
+			if a.PassengerNames == nil {
+				a.PassengerNames = []string{}
+			}
+			a.Riders = make([]memberRefJSON, 0, len(rig.Riders))
+			for _, m := range rig.Riders {
+				a.Riders = append(a.Riders, memberRefToJSON(m))
+			}
+			if rig.Vehicle != nil {
+				v := vehicleToJSON(*rig.Vehicle)
+				a.Vehicle = &v
+			}
+			out = append(out, a)
+		}
+		var attendance *attendanceSummaryJSON
+		if sum.Attendance != nil {
+			a := attendanceSummaryToJSON(*sum.Attendance)
+			attendance = &a
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"rigs":            out,
+			"attendingPeople": sum.AttendingPeople,
+			"capacityPeople":  sum.CapacityPeople,
+			"attendance":      attendance,
+		})
+	}))
+}
+
+func updateVehicleInputFromJSON(body map[string]json.RawMessage) (members.UpdateVehicleInput, error) {
+	var in members.UpdateVehicleInput
+	if raw, ok := body["name"]; ok {
+		if isJSONNull(raw) {
+			in.Name = members.Null[string]()
+		} else {
+			var name string
+			if err := json.Unmarshal(raw, &name); err != nil {
+				return in, errors.New("name: must be a string")
+			}
+			in.Name = members.Some(name)
+		}
+	}
+	if raw, ok := body["vehicleProfile"]; ok {
+		if isJSONNull(raw) {
+			in.Profile = members.Null[members.VehicleProfilePatch]()
+		} else {
+			var vp oas.VehicleProfile
+			if err := json.Unmarshal(raw, &vp); err != nil {
+				return in, errors.New("vehicleProfile: must be an object")
+			}
+			in.Profile = members.Some(*vehicleProfilePatchFromOAS(vp))
+		}
+	}
+	if raw, ok := body["specs"]; ok {
+		if isJSONNull(raw) {
+			in.Specs = members.Null[domain.VehicleSpecs]()
+		} else {
+			var specs vehicleSpecsJSON
+			if err := json.Unmarshal(raw, &specs); err != nil {
+				return in, errors.New("specs: must be an object")
+			}
+			in.Specs = members.Some(vehicleSpecsFromJSON(specs))
+		}
+	}
+	return in, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// withSubject rejects requests without an authenticated subject.
+func withSubject(h func(w http.ResponseWriter, r *http.Request, sub domain.SubjectID)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sub, ok := SubjectFromContext(r.Context())
+		if !ok {
+			writeOASError(w, r, http.StatusUnauthorized, "UNAUTHORIZED", "missing subject", nil)
+			return
+		}
+		h(w, r, domain.SubjectID(sub))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
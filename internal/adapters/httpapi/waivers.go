@@ -0,0 +1,169 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/waivers"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Waiver routes are out-of-spec: members read liability waivers and acknowledge their current
+// version, and organizers choose which waivers a trip requires on top of club-wide ones.
+// SetMyRSVP rejects a YES with 409 WAIVER_REQUIRED until every required waiver is acknowledged.
+const (
+	// WaiversPath lists (GET) every waiver with the caller's acknowledgement status.
+	WaiversPath = "/waivers"
+	// WaiverPath returns (GET) a waiver's current text.
+	WaiverPath = "/waivers/{waiverId}"
+	// WaiverAcknowledgementsPath records (POST) the caller's acknowledgement of the current version.
+	WaiverAcknowledgementsPath = "/waivers/{waiverId}/acknowledgements"
+	// TripWaiversPath lists (GET) or replaces (PUT; organizers only) the waivers a trip requires.
+	TripWaiversPath = "/trips/{tripId}/required-waivers"
+)
+
+// Waivers is the waivers use-case surface needed by the member waiver routes.
+type Waivers interface {
+	ListMyWaivers(ctx context.Context, caller domain.MemberID) ([]domain.MemberWaiver, error)
+	GetMyWaiver(ctx context.Context, caller domain.MemberID, id domain.WaiverID) (domain.MemberWaiver, error)
+	AcknowledgeWaiver(ctx context.Context, caller domain.MemberID, subject domain.SubjectID, id domain.WaiverID, versionHash string) (domain.WaiverAcknowledgement, error)
+}
+
+// TripWaivers is the trips use-case surface needed by the trip waiver routes.
+type TripWaivers interface {
+	GetTripWaivers(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.MemberWaiver, error)
+	SetTripWaivers(ctx context.Context, caller domain.MemberID, tripID domain.TripID, ids []domain.WaiverID) ([]domain.MemberWaiver, error)
+}
+
+type waiverJSON struct {
+	WaiverID            string     `json:"waiverId"`
+	Title               string     `json:"title"`
+	RequiredForAllTrips bool       `json:"requiredForAllTrips"`
+	Version             int        `json:"version"`
+	VersionHash         string     `json:"versionHash"`
+	Body                *string    `json:"body,omitempty"`
+	PublishedAt         time.Time  `json:"publishedAt"`
+	AcknowledgedAt      *time.Time `json:"acknowledgedAt"`
+}
+
+// memberWaiverToJSON renders w; lists leave out the text, which GET WaiverPath returns.
+func memberWaiverToJSON(w domain.MemberWaiver, withBody bool) waiverJSON {
+	out := waiverJSON{
+		WaiverID:            string(w.ID),
+		Title:               w.Title,
+		RequiredForAllTrips: w.RequiredForAllTrips,
+		Version:             w.Version,
+		VersionHash:         w.VersionHash,
+		PublishedAt:         w.PublishedAt.UTC(),
+		AcknowledgedAt:      w.AcknowledgedAt,
+	}
+	if withBody {
+		body := w.Body
+		out.Body = &body
+	}
+	return out
+}
+
+func memberWaiversToJSON(ws []domain.MemberWaiver) []waiverJSON {
+	out := make([]waiverJSON, 0, len(ws))
+	for _, w := range ws {
+		out = append(out, memberWaiverToJSON(w, false))
+	}
+	return out
+}
+
+func mountWaivers(r chi.Router, m MemberResolver, wv Waivers) {
+	waiverID := func(req *http.Request) domain.WaiverID { return domain.WaiverID(chi.URLParam(req, "waiverId")) }
+
+	r.Get(WaiversPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		ws, err := wv.ListMyWaivers(req.Context(), me.ID)
+		if err != nil {
+			writeWaiversError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"waivers": memberWaiversToJSON(ws)})
+	}))
+
+	r.Get(WaiverPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		mw, err := wv.GetMyWaiver(req.Context(), me.ID, waiverID(req))
+		if err != nil {
+			writeWaiversError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"waiver": memberWaiverToJSON(mw, true)})
+	}))
+
+	// The acknowledgement records the subject the caller is signed in as, which may be a
+	// linked identity rather than the member's primary one.
+	r.Post(WaiverAcknowledgementsPath, withSubject(func(w http.ResponseWriter, req *http.Request, sub domain.SubjectID) {
+		me, err := m.GetMyMemberProfile(req.Context(), sub)
+		if err != nil {
+			writeMembersError(w, req, err)
+			return
+		}
+		var body struct {
+			VersionHash string `json:"versionHash"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		ack, err := wv.AcknowledgeWaiver(req.Context(), me.ID, sub, waiverID(req), body.VersionHash)
+		if err != nil {
+			writeWaiversError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"acknowledgement": map[string]any{
+			"waiverId":       string(ack.WaiverID),
+			"version":        ack.Version,
+			"versionHash":    ack.VersionHash,
+			"acknowledgedAt": ack.AcknowledgedAt.UTC(),
+		}})
+	}))
+}
+
+func mountTripWaivers(r chi.Router, m MemberResolver, tw TripWaivers) {
+	tripID := func(req *http.Request) domain.TripID { return domain.TripID(chi.URLParam(req, "tripId")) }
+
+	r.Get(TripWaiversPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		ws, err := tw.GetTripWaivers(req.Context(), me.ID, tripID(req))
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"waivers": memberWaiversToJSON(ws)})
+	}))
+
+	r.Put(TripWaiversPath, withMember(m, func(w http.ResponseWriter, req *http.Request, me domain.Member) {
+		var body struct {
+			WaiverIDs []string `json:"waiverIds"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeOASError(w, req, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error(), nil)
+			return
+		}
+		ids := make([]domain.WaiverID, 0, len(body.WaiverIDs))
+		for _, id := range body.WaiverIDs {
+			ids = append(ids, domain.WaiverID(id))
+		}
+		ws, err := tw.SetTripWaivers(req.Context(), me.ID, tripID(req), ids)
+		if err != nil {
+			writeTripsError(w, req, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"waivers": memberWaiversToJSON(ws)})
+	}))
+}
+
+func writeWaiversError(w http.ResponseWriter, r *http.Request, err error) {
+	if ae := (*waivers.Error)(nil); errors.As(err, &ae) {
+		writeOASError(w, r, ae.Status, ae.Code, ae.Message, ae.Details)
+		return
+	}
+	writeOASError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error", nil)
+}
@@ -0,0 +1,32 @@
+// Package mailnotifier delivers member notifications as plain-text email.
+package mailnotifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/notifier"
+)
+
+// Notifier is a notifier.Notifier that emails each recipient's primary address.
+type Notifier struct {
+	mailer mailer.Mailer
+}
+
+func New(m mailer.Mailer) *Notifier {
+	return &Notifier{mailer: m}
+}
+
+func (n *Notifier) Notify(ctx context.Context, msg notifier.Message) error {
+	to := strings.TrimSpace(msg.To.Email)
+	if to == "" {
+		return errors.New("recipient has no email address")
+	}
+	return n.mailer.Send(ctx, mailer.Message{
+		To:      to,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+	})
+}
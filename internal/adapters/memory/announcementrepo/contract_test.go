@@ -0,0 +1,30 @@
+package announcementrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	announcementrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_AnnouncementRepo(t *testing.T) {
+	contracttest.RunAnnouncementRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memmemberrepo.NewRepo(), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return memtriprepo.NewRepo(), nil
+		},
+		func(t *testing.T) (announcementrepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(), nil
+		},
+	)
+}
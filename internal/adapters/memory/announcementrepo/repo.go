@@ -0,0 +1,159 @@
+package announcementrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+)
+
+// Repo is an in-memory implementation of announcementrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu            sync.RWMutex
+	announcements map[domain.TripAnnouncementID]announcementrepo.Announcement
+	deliveries    map[domain.TripAnnouncementID]map[domain.MemberID]announcementrepo.Delivery
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		announcements: make(map[domain.TripAnnouncementID]announcementrepo.Announcement),
+		deliveries:    make(map[domain.TripAnnouncementID]map[domain.MemberID]announcementrepo.Delivery),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, a announcementrepo.Announcement, recipients []domain.MemberID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.announcements[a.ID]; ok {
+		return announcementrepo.ErrAlreadyExists
+	}
+	a.CreatedAt = a.CreatedAt.UTC()
+	r.announcements[a.ID] = a
+	ds := make(map[domain.MemberID]announcementrepo.Delivery, len(recipients))
+	for _, id := range recipients {
+		ds[id] = announcementrepo.Delivery{AnnouncementID: a.ID, MemberID: id, Status: announcementrepo.StatusPending}
+	}
+	r.deliveries[a.ID] = ds
+	return nil
+}
+
+func (r *Repo) Get(ctx context.Context, id domain.TripAnnouncementID) (announcementrepo.Announcement, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.announcements[id]
+	if !ok {
+		return announcementrepo.Announcement{}, announcementrepo.ErrNotFound
+	}
+	return a, nil
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]announcementrepo.Announcement, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]announcementrepo.Announcement, 0)
+	for _, a := range r.announcements {
+		if a.TripID == tripID {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out, nil
+}
+
+func (r *Repo) ListUndelivered(ctx context.Context, staleBefore time.Time, limit int) ([]announcementrepo.Announcement, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]announcementrepo.Announcement, 0)
+	for id, ds := range r.deliveries {
+		for _, d := range ds {
+			if d.Status == announcementrepo.StatusPending || (d.Status == announcementrepo.StatusSending && d.AttemptedAt.Before(staleBefore)) {
+				out = append(out, r.announcements[id])
+				break
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *Repo) ListDeliveries(ctx context.Context, id domain.TripAnnouncementID) ([]announcementrepo.Delivery, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]announcementrepo.Delivery, 0, len(r.deliveries[id]))
+	for _, d := range r.deliveries[id] {
+		out = append(out, cloneDelivery(d))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].MemberID < out[j].MemberID })
+	return out, nil
+}
+
+func (r *Repo) ClaimDelivery(ctx context.Context, id domain.TripAnnouncementID, memberID domain.MemberID, at, staleBefore time.Time) (announcementrepo.Delivery, bool, error) {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id][memberID]
+	if !ok {
+		return announcementrepo.Delivery{}, false, announcementrepo.ErrNotFound
+	}
+	switch d.Status {
+	case announcementrepo.StatusPending, announcementrepo.StatusFailed:
+	case announcementrepo.StatusSending:
+		if !d.AttemptedAt.Before(staleBefore) {
+			return announcementrepo.Delivery{}, false, nil
+		}
+	default:
+		return announcementrepo.Delivery{}, false, nil
+	}
+	at = at.UTC()
+	d.Status, d.Error, d.AttemptedAt = announcementrepo.StatusSending, "", &at
+	r.deliveries[id][memberID] = cloneDelivery(d)
+	return cloneDelivery(d), true, nil
+}
+
+func (r *Repo) UpdateDelivery(ctx context.Context, d announcementrepo.Delivery) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.deliveries[d.AnnouncementID][d.MemberID]
+	if !ok {
+		return announcementrepo.ErrNotFound
+	}
+	existing.Status = d.Status
+	existing.Error = d.Error
+	existing.AttemptedAt = d.AttemptedAt
+	existing.SentTo = d.SentTo
+	r.deliveries[d.AnnouncementID][d.MemberID] = cloneDelivery(existing)
+	return nil
+}
+
+func cloneDelivery(d announcementrepo.Delivery) announcementrepo.Delivery {
+	out := d
+	if d.AttemptedAt != nil {
+		v := d.AttemptedAt.UTC()
+		out.AttemptedAt = &v
+	}
+	out.SentTo = append([]string(nil), d.SentTo...)
+	return out
+}
@@ -0,0 +1,15 @@
+package apikeyrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	apikeyrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+)
+
+func TestContract_APIKeyRepo(t *testing.T) {
+	contracttest.RunAPIKeyRepo(t, func(t *testing.T) (apikeyrepoport.Repository, func()) {
+		t.Helper()
+		return NewRepo(), nil
+	})
+}
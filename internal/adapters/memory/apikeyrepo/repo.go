@@ -0,0 +1,200 @@
+package apikeyrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+)
+
+// Repo is an in-memory implementation of apikeyrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu sync.RWMutex
+
+	byID       map[domain.APIKeyID]apikeyrepo.APIKey
+	idByPrefix map[string]domain.APIKeyID
+	usage      map[domain.APIKeyID][]domain.APIKeyUsage
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		byID:       make(map[domain.APIKeyID]apikeyrepo.APIKey),
+		idByPrefix: make(map[string]domain.APIKeyID),
+		usage:      make(map[domain.APIKeyID][]domain.APIKeyUsage),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, k apikeyrepo.APIKey) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[k.ID]; ok || k.ID == "" {
+		return apikeyrepo.ErrAlreadyExists
+	}
+	if _, ok := r.idByPrefix[k.Prefix]; ok {
+		return apikeyrepo.ErrAlreadyExists
+	}
+	r.byID[k.ID] = cloneKey(k)
+	r.idByPrefix[k.Prefix] = k.ID
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.APIKeyID) (apikeyrepo.APIKey, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	k, ok := r.byID[id]
+	if !ok {
+		return apikeyrepo.APIKey{}, apikeyrepo.ErrNotFound
+	}
+	return cloneKey(k), nil
+}
+
+func (r *Repo) GetByPrefix(ctx context.Context, prefix string) (apikeyrepo.APIKey, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.idByPrefix[prefix]
+	if !ok {
+		return apikeyrepo.APIKey{}, apikeyrepo.ErrNotFound
+	}
+	return cloneKey(r.byID[id]), nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]apikeyrepo.APIKey, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]apikeyrepo.APIKey, 0, len(r.byID))
+	for _, k := range r.byID {
+		out = append(out, cloneKey(k))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) Revoke(ctx context.Context, id domain.APIKeyID, revokedBy string, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k, ok := r.byID[id]
+	if !ok {
+		return apikeyrepo.ErrNotFound
+	}
+	if k.RevokedAt != nil {
+		return nil
+	}
+	by := revokedBy
+	at = at.UTC()
+	k.RevokedBy = &by
+	k.RevokedAt = &at
+	r.byID[id] = k
+	return nil
+}
+
+func (r *Repo) RecordUsage(ctx context.Context, u apikeyrepo.Usage) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k, ok := r.byID[u.KeyID]
+	if !ok {
+		return apikeyrepo.ErrNotFound
+	}
+	usedAt := u.UsedAt.UTC()
+	if k.LastUsedAt == nil || usedAt.After(*k.LastUsedAt) {
+		k.LastUsedAt = &usedAt
+		r.byID[u.KeyID] = k
+	}
+
+	// Entries are kept in the order they were last touched, so ListUsage can walk backwards.
+	day := apikeyrepo.UsageDay(usedAt)
+	entries := r.usage[u.KeyID]
+	entry := domain.APIKeyUsage{KeyID: u.KeyID, Day: day, Method: u.Method, Path: u.Path, ClientIP: u.ClientIP, FirstUsedAt: usedAt, LastUsedAt: usedAt}
+	for i, e := range entries {
+		if e.Day.Equal(day) && e.Method == u.Method && e.Path == u.Path && e.ClientIP == u.ClientIP {
+			entry = e
+			if usedAt.Before(entry.FirstUsedAt) {
+				entry.FirstUsedAt = usedAt
+			}
+			if usedAt.After(entry.LastUsedAt) {
+				entry.LastUsedAt = usedAt
+			}
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	entry.RequestCount++
+	r.usage[u.KeyID] = append(entries, entry)
+	return nil
+}
+
+func (r *Repo) ListUsage(ctx context.Context, id domain.APIKeyID, limit int) ([]domain.APIKeyUsage, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.byID[id]; !ok {
+		return nil, apikeyrepo.ErrNotFound
+	}
+	all := r.usage[id]
+	out := make([]domain.APIKeyUsage, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		out = append(out, all[i])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].LastUsedAt.After(out[j].LastUsedAt) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *Repo) DeleteUsageBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cutoff := apikeyrepo.UsageDay(before)
+	deleted := 0
+	for id, entries := range r.usage {
+		kept := entries[:0]
+		for _, e := range entries {
+			if deleted < limit && e.Day.Before(cutoff) {
+				deleted++
+				continue
+			}
+			kept = append(kept, e)
+		}
+		if len(kept) == 0 {
+			delete(r.usage, id)
+		} else {
+			r.usage[id] = kept
+		}
+	}
+	return deleted, nil
+}
+
+func cloneKey(k apikeyrepo.APIKey) apikeyrepo.APIKey {
+	out := k
+	out.Scopes = append([]domain.APIKeyScope(nil), k.Scopes...)
+	if k.RevokedBy != nil {
+		v := *k.RevokedBy
+		out.RevokedBy = &v
+	}
+	if k.RevokedAt != nil {
+		v := *k.RevokedAt
+		out.RevokedAt = &v
+	}
+	if k.LastUsedAt != nil {
+		v := *k.LastUsedAt
+		out.LastUsedAt = &v
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package attendancerepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+)
+
+type recordKey struct {
+	tripID   domain.TripID
+	memberID domain.MemberID
+}
+
+// Repo is an in-memory implementation of attendancerepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu      sync.RWMutex
+	records map[recordKey]attendancerepo.Record
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		records: make(map[recordKey]attendancerepo.Record),
+	}
+}
+
+func (r *Repo) Put(ctx context.Context, rec attendancerepo.Record) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[recordKey{tripID: rec.TripID, memberID: rec.MemberID}] = cloneRecord(rec)
+	return nil
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]attendancerepo.Record, error) {
+	_ = ctx
+	out := r.list(func(k recordKey) bool { return k.tripID == tripID })
+	sort.Slice(out, func(i, j int) bool { return out[i].MemberID < out[j].MemberID })
+	return out, nil
+}
+
+func (r *Repo) ListByMember(ctx context.Context, memberID domain.MemberID) ([]attendancerepo.Record, error) {
+	_ = ctx
+	out := r.list(func(k recordKey) bool { return k.memberID == memberID })
+	sort.Slice(out, func(i, j int) bool { return out[i].TripID < out[j].TripID })
+	return out, nil
+}
+
+func (r *Repo) list(match func(recordKey) bool) []attendancerepo.Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]attendancerepo.Record, 0)
+	for k, rec := range r.records {
+		if match(k) {
+			out = append(out, cloneRecord(rec))
+		}
+	}
+	return out
+}
+
+func cloneRecord(rec attendancerepo.Record) attendancerepo.Record {
+	out := rec
+	if rec.CheckedInAt != nil {
+		v := rec.CheckedInAt.UTC()
+		out.CheckedInAt = &v
+	}
+	out.UpdatedAt = rec.UpdatedAt.UTC()
+	return out
+}
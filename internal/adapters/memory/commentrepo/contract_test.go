@@ -0,0 +1,30 @@
+package commentrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	commentrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_CommentRepo(t *testing.T) {
+	contracttest.RunCommentRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memmemberrepo.NewRepo(), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return memtriprepo.NewRepo(), nil
+		},
+		func(t *testing.T) (commentrepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(), nil
+		},
+	)
+}
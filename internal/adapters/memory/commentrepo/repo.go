@@ -0,0 +1,126 @@
+package commentrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+)
+
+// Repo is an in-memory implementation of commentrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu       sync.RWMutex
+	comments map[domain.TripCommentID]commentrepo.Comment
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		comments: make(map[domain.TripCommentID]commentrepo.Comment),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, c commentrepo.Comment) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.comments[c.ID]; ok {
+		return commentrepo.ErrAlreadyExists
+	}
+	if c.ParentID != nil {
+		if _, ok := r.comments[*c.ParentID]; !ok {
+			return commentrepo.ErrNotFound
+		}
+	}
+	r.comments[c.ID] = cloneComment(c)
+	return nil
+}
+
+func (r *Repo) Get(ctx context.Context, id domain.TripCommentID) (commentrepo.Comment, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.comments[id]
+	if !ok {
+		return commentrepo.Comment{}, commentrepo.ErrNotFound
+	}
+	return cloneComment(c), nil
+}
+
+func (r *Repo) Update(ctx context.Context, c commentrepo.Comment) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.comments[c.ID]
+	if !ok {
+		return commentrepo.ErrNotFound
+	}
+	existing.Body = c.Body
+	existing.Pinned = c.Pinned
+	existing.EditedAt = c.EditedAt
+	existing.DeletedAt = c.DeletedAt
+	existing.DeletedBy = c.DeletedBy
+	r.comments[c.ID] = cloneComment(existing)
+	return nil
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID, after *commentrepo.Cursor, limit int) ([]commentrepo.Comment, error) {
+	_ = ctx
+	out := r.list(func(c commentrepo.Comment) bool {
+		if c.TripID != tripID {
+			return false
+		}
+		if after == nil {
+			return true
+		}
+		at := after.CreatedAt.UTC()
+		return c.CreatedAt.After(at) || (c.CreatedAt.Equal(at) && c.ID > after.ID)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *Repo) ListPinned(ctx context.Context, tripID domain.TripID) ([]commentrepo.Comment, error) {
+	_ = ctx
+	return r.list(func(c commentrepo.Comment) bool { return c.TripID == tripID && c.Pinned }), nil
+}
+
+func (r *Repo) list(match func(commentrepo.Comment) bool) []commentrepo.Comment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]commentrepo.Comment, 0)
+	for _, c := range r.comments {
+		if match(c) {
+			out = append(out, cloneComment(c))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func cloneComment(c commentrepo.Comment) commentrepo.Comment {
+	out := c
+	if c.ParentID != nil {
+		v := *c.ParentID
+		out.ParentID = &v
+	}
+	out.CreatedAt = c.CreatedAt.UTC()
+	if c.EditedAt != nil {
+		v := c.EditedAt.UTC()
+		out.EditedAt = &v
+	}
+	if c.DeletedAt != nil {
+		v := c.DeletedAt.UTC()
+		out.DeletedAt = &v
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package emergencyinforepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	emergencyinforepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_EmergencyInfoRepo(t *testing.T) {
+	contracttest.RunEmergencyInfoRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memmemberrepo.NewRepo(), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return memtriprepo.NewRepo(), nil
+		},
+		func(t *testing.T) (emergencyinforepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(), nil
+		},
+	)
+}
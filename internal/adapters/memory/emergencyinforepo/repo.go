@@ -0,0 +1,86 @@
+package emergencyinforepo
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
+)
+
+// Repo is an in-memory implementation of emergencyinforepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu     sync.RWMutex
+	sealed map[domain.MemberID]emergencyinforepo.Sealed
+	access []emergencyinforepo.AccessEntry
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		sealed: make(map[domain.MemberID]emergencyinforepo.Sealed),
+	}
+}
+
+func (r *Repo) Get(ctx context.Context, memberID domain.MemberID) (emergencyinforepo.Sealed, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sealed[memberID]
+	if !ok {
+		return emergencyinforepo.Sealed{}, emergencyinforepo.ErrNotFound
+	}
+	return cloneSealed(s), nil
+}
+
+func (r *Repo) Put(ctx context.Context, s emergencyinforepo.Sealed) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sealed[s.MemberID] = cloneSealed(s)
+	return nil
+}
+
+func (r *Repo) Delete(ctx context.Context, memberID domain.MemberID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sealed[memberID]; !ok {
+		return emergencyinforepo.ErrNotFound
+	}
+	delete(r.sealed, memberID)
+	return nil
+}
+
+func (r *Repo) AppendAccess(ctx context.Context, e emergencyinforepo.AccessEntry) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.AccessedAt = e.AccessedAt.UTC()
+	r.access = append(r.access, e)
+	return nil
+}
+
+func (r *Repo) ListAccess(ctx context.Context, memberID domain.MemberID) ([]emergencyinforepo.AccessEntry, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]emergencyinforepo.AccessEntry, 0)
+	for _, e := range r.access {
+		if e.MemberID == memberID {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AccessedAt.Before(out[j].AccessedAt) })
+	return out, nil
+}
+
+func cloneSealed(s emergencyinforepo.Sealed) emergencyinforepo.Sealed {
+	out := s
+	out.Nonce = bytes.Clone(s.Nonce)
+	out.Ciphertext = bytes.Clone(s.Ciphertext)
+	out.UpdatedAt = s.UpdatedAt.UTC()
+	return out
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/events"
+)
+
+// Handler receives published events. Handlers run synchronously on the publisher's
+// goroutine, so slow work belongs on a queue of the handler's own.
+type Handler func(ctx context.Context, ev events.Event)
+
+// Bus is an in-process events.Publisher that fans events out to subscribers.
+// It is safe for concurrent use.
+type Bus struct {
+	mu        sync.Mutex
+	handlers  []Handler
+	record    bool
+	published []events.Event
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// NewRecordingBus also keeps every event for Published; it is meant for tests.
+func NewRecordingBus() *Bus {
+	return &Bus{record: true}
+}
+
+// Subscribe registers h for every event published after the call.
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+func (b *Bus) Publish(ctx context.Context, ev events.Event) error {
+	b.mu.Lock()
+	if b.record {
+		b.published = append(b.published, ev)
+	}
+	handlers := append([]Handler(nil), b.handlers...)
+	b.mu.Unlock()
+
+	for _, h := range handlers {
+		h(ctx, ev)
+	}
+	return nil
+}
+
+// Published returns a copy of every event published so far, oldest first. It is empty
+// unless the bus was built with NewRecordingBus.
+func (b *Bus) Published() []events.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]events.Event(nil), b.published...)
+}
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
 	idempotencyport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
 )
 
 func TestContract_IdempotencyStore(t *testing.T) {
-	contracttest.RunIdempotencyStore(t, func(t *testing.T) (idempotencyport.Store, func()) {
+	contracttest.RunIdempotencyStore(t, func(t *testing.T, clk clock.Clock) (idempotencyport.Store, func()) {
 		t.Helper()
-		return NewStore(), nil
+		return NewStoreWithClock(clk), nil
 	})
 }
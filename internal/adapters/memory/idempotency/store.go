@@ -3,35 +3,123 @@ package idempotency
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
 )
 
 // Store is an in-memory implementation of idempotency.Store.
 // It is safe for concurrent use.
+//
+// Like the Postgres adapter, keys are scoped by the caller's issuer (read from the request context).
 type Store struct {
-	mu sync.RWMutex
-	m  map[idempotency.Fingerprint]idempotency.Record
+	mu  sync.RWMutex
+	m   map[recordKey]idempotency.Record
+	clk clock.Clock
+}
+
+type recordKey struct {
+	issuer string
+	fp     idempotency.Fingerprint
+}
+
+func keyFor(ctx context.Context, fp idempotency.Fingerprint) recordKey {
+	return recordKey{issuer: authctx.IssuerOr(ctx, ""), fp: fp}
 }
 
 func NewStore() *Store {
+	return NewStoreWithClock(nil)
+}
+
+// NewStoreWithClock is like NewStore but evaluates expiry against clk (nil means wall clock).
+func NewStoreWithClock(clk clock.Clock) *Store {
 	return &Store{
-		m: make(map[idempotency.Fingerprint]idempotency.Record),
+		m:   make(map[recordKey]idempotency.Record),
+		clk: clk,
 	}
 }
 
 func (s *Store) Get(ctx context.Context, fp idempotency.Fingerprint) (idempotency.Record, bool, error) {
-	_ = ctx
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	rec, ok := s.m[fp]
-	return rec, ok, nil
+	rec, ok := s.m[keyFor(ctx, fp)]
+	if !ok || rec.Expired(s.now()) {
+		return idempotency.Record{}, false, nil
+	}
+	return cloneRecord(rec), true, nil
 }
 
 func (s *Store) Put(ctx context.Context, fp idempotency.Fingerprint, rec idempotency.Record) error {
-	_ = ctx
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.m[fp] = rec
+	s.m[keyFor(ctx, fp)] = cloneRecord(rec)
 	return nil
 }
+
+func (s *Store) Reserve(ctx context.Context, fp idempotency.Fingerprint, rec idempotency.Record) (idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.m[keyFor(ctx, fp)]; ok && !existing.Expired(s.now()) {
+		return cloneRecord(existing), false, nil
+	}
+	s.m[keyFor(ctx, fp)] = cloneRecord(rec)
+	return idempotency.Record{}, true, nil
+}
+
+func (s *Store) CompareAndSwap(ctx context.Context, fp idempotency.Fingerprint, createdAt time.Time, rec idempotency.Record) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.m[keyFor(ctx, fp)]
+	if !ok || existing.Expired(s.now()) || !existing.CreatedAt.Equal(createdAt) {
+		return false, nil
+	}
+	s.m[keyFor(ctx, fp)] = cloneRecord(rec)
+	return true, nil
+}
+
+func (s *Store) Delete(ctx context.Context, fp idempotency.Fingerprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, keyFor(ctx, fp))
+	return nil
+}
+
+func (s *Store) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for k, rec := range s.m {
+		if n >= limit {
+			break
+		}
+		if rec.Expired(before) {
+			delete(s.m, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *Store) now() time.Time {
+	if s.clk == nil {
+		return time.Now().UTC()
+	}
+	return s.clk.Now()
+}
+
+func cloneRecord(rec idempotency.Record) idempotency.Record {
+	out := rec
+	if rec.Body != nil {
+		out.Body = append([]byte(nil), rec.Body...)
+	}
+	if rec.Header != nil {
+		out.Header = make(map[string][]string, len(rec.Header))
+		for k, v := range rec.Header {
+			out.Header[k] = append([]string(nil), v...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,15 @@
+package invitationrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	invitationrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+)
+
+func TestContract_InvitationRepo(t *testing.T) {
+	contracttest.RunInvitationRepo(t, func(t *testing.T) (invitationrepoport.Repository, func()) {
+		t.Helper()
+		return NewRepo(), nil
+	})
+}
@@ -0,0 +1,179 @@
+package invitationrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+)
+
+// Repo is an in-memory implementation of invitationrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu sync.RWMutex
+
+	byID        map[domain.InvitationID]invitationrepo.Invitation
+	idByHash    map[string]domain.InvitationID
+	redemptions map[domain.InvitationID][]domain.InvitationRedemption
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		byID:        make(map[domain.InvitationID]invitationrepo.Invitation),
+		idByHash:    make(map[string]domain.InvitationID),
+		redemptions: make(map[domain.InvitationID][]domain.InvitationRedemption),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, inv invitationrepo.Invitation) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[inv.ID]; ok || inv.ID == "" {
+		return invitationrepo.ErrAlreadyExists
+	}
+	if _, ok := r.idByHash[inv.CodeHash]; ok {
+		return invitationrepo.ErrAlreadyExists
+	}
+	r.byID[inv.ID] = cloneInvitation(inv)
+	r.idByHash[inv.CodeHash] = inv.ID
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.InvitationID) (invitationrepo.Invitation, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inv, ok := r.byID[id]
+	if !ok {
+		return invitationrepo.Invitation{}, invitationrepo.ErrNotFound
+	}
+	return cloneInvitation(inv), nil
+}
+
+func (r *Repo) GetByCodeHash(ctx context.Context, codeHash string) (invitationrepo.Invitation, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.idByHash[codeHash]
+	if !ok {
+		return invitationrepo.Invitation{}, invitationrepo.ErrNotFound
+	}
+	return cloneInvitation(r.byID[id]), nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]invitationrepo.Invitation, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]invitationrepo.Invitation, 0, len(r.byID))
+	for _, inv := range r.byID {
+		out = append(out, cloneInvitation(inv))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) Revoke(ctx context.Context, id domain.InvitationID, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv, ok := r.byID[id]
+	if !ok {
+		return invitationrepo.ErrNotFound
+	}
+	if inv.RevokedAt != nil {
+		return nil
+	}
+	at = at.UTC()
+	inv.RevokedAt = &at
+	r.byID[id] = inv
+	return nil
+}
+
+func (r *Repo) Redeem(ctx context.Context, id domain.InvitationID, memberID domain.MemberID, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv, ok := r.byID[id]
+	if !ok {
+		return invitationrepo.ErrNotFound
+	}
+	switch {
+	case inv.RevokedAt != nil:
+		return invitationrepo.ErrRevoked
+	case !at.Before(inv.ExpiresAt):
+		return invitationrepo.ErrExpired
+	case inv.UseCount >= inv.MaxUses:
+		return invitationrepo.ErrExhausted
+	}
+	for _, red := range r.redemptions[id] {
+		if red.MemberID == memberID {
+			return invitationrepo.ErrAlreadyExists
+		}
+	}
+	inv.UseCount++
+	r.byID[id] = inv
+	r.redemptions[id] = append(r.redemptions[id], domain.InvitationRedemption{
+		InvitationID: id,
+		MemberID:     memberID,
+		RedeemedAt:   at.UTC(),
+	})
+	return nil
+}
+
+func (r *Repo) ReleaseRedemption(ctx context.Context, id domain.InvitationID, memberID domain.MemberID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv, ok := r.byID[id]
+	if !ok {
+		return invitationrepo.ErrNotFound
+	}
+	rs := r.redemptions[id]
+	for i, red := range rs {
+		if red.MemberID == memberID {
+			r.redemptions[id] = append(rs[:i:i], rs[i+1:]...)
+			inv.UseCount--
+			r.byID[id] = inv
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *Repo) ListRedemptions(ctx context.Context, id domain.InvitationID) ([]domain.InvitationRedemption, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.byID[id]; !ok {
+		return nil, invitationrepo.ErrNotFound
+	}
+	return append([]domain.InvitationRedemption(nil), r.redemptions[id]...), nil
+}
+
+func cloneInvitation(inv invitationrepo.Invitation) invitationrepo.Invitation {
+	out := inv
+	if inv.Email != nil {
+		v := *inv.Email
+		out.Email = &v
+	}
+	if inv.CreatedByMemberID != nil {
+		v := *inv.CreatedByMemberID
+		out.CreatedByMemberID = &v
+	}
+	if inv.RevokedAt != nil {
+		v := *inv.RevokedAt
+		out.RevokedAt = &v
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package itineraryrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	itineraryrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_ItineraryRepo(t *testing.T) {
+	contracttest.RunItineraryRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memmemberrepo.NewRepo(), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return memtriprepo.NewRepo(), nil
+		},
+		func(t *testing.T) (itineraryrepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(), nil
+		},
+	)
+}
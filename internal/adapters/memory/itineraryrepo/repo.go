@@ -0,0 +1,107 @@
+package itineraryrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
+)
+
+type dayKey struct {
+	tripID domain.TripID
+	date   string
+}
+
+// Repo is an in-memory implementation of itineraryrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu   sync.RWMutex
+	days map[dayKey]itineraryrepo.Day
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		days: make(map[dayKey]itineraryrepo.Day),
+	}
+}
+
+func keyFor(tripID domain.TripID, date time.Time) dayKey {
+	return dayKey{tripID: tripID, date: date.UTC().Format("2006-01-02")}
+}
+
+func (r *Repo) PutDay(ctx context.Context, d itineraryrepo.Day) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := d.Date.UTC()
+	d.Date = time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+	r.days[keyFor(d.TripID, d.Date)] = cloneDay(d)
+	return nil
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]itineraryrepo.Day, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]itineraryrepo.Day, 0)
+	for k, d := range r.days {
+		if k.tripID == tripID {
+			out = append(out, cloneDay(d))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
+
+func (r *Repo) DeleteDay(ctx context.Context, tripID domain.TripID, date time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := keyFor(tripID, date)
+	if _, ok := r.days[k]; !ok {
+		return itineraryrepo.ErrDayNotFound
+	}
+	delete(r.days, k)
+	return nil
+}
+
+func cloneDay(d itineraryrepo.Day) itineraryrepo.Day {
+	out := d
+	out.Title = cloneStringPtr(d.Title)
+	out.Notes = cloneStringPtr(d.Notes)
+	out.Stops = nil
+	for _, s := range d.Stops {
+		s.Location.Address = cloneStringPtr(s.Location.Address)
+		s.Location.Latitude = cloneFloatPtr(s.Location.Latitude)
+		s.Location.Longitude = cloneFloatPtr(s.Location.Longitude)
+		s.Time = cloneStringPtr(s.Time)
+		s.Notes = cloneStringPtr(s.Notes)
+		out.Stops = append(out.Stops, s)
+	}
+	out.Segments = nil
+	for _, s := range d.Segments {
+		s.DistanceMiles = cloneFloatPtr(s.DistanceMiles)
+		s.Notes = cloneStringPtr(s.Notes)
+		out.Segments = append(out.Segments, s)
+	}
+	return out
+}
+
+func cloneStringPtr(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneFloatPtr(p *float64) *float64 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,60 @@
+package keyprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+)
+
+// Provider is a keyprovider.Provider over a fixed set of keys held in memory.
+// The last key is the current one.
+type Provider struct {
+	keys []keyprovider.Key
+}
+
+// NewProvider returns a provider for keys; it needs at least one key of keyprovider.KeySize bytes.
+func NewProvider(keys ...keyprovider.Key) (*Provider, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+	out := make([]keyprovider.Key, 0, len(keys))
+	for _, k := range keys {
+		if k.ID == "" || len(k.Secret) != keyprovider.KeySize {
+			return nil, fmt.Errorf("key %q: id required and secret must be %d bytes", k.ID, keyprovider.KeySize)
+		}
+		out = append(out, keyprovider.Key{ID: k.ID, Secret: bytes.Clone(k.Secret)})
+	}
+	return &Provider{keys: out}, nil
+}
+
+// NewEphemeralProvider returns a provider with one random key. Data encrypted with it cannot
+// be read after the process exits, so it only suits the in-memory backend and tests.
+func NewEphemeralProvider() (*Provider, error) {
+	secret := make([]byte, keyprovider.KeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return NewProvider(keyprovider.Key{ID: "ephemeral", Secret: secret})
+}
+
+func (p *Provider) Current(ctx context.Context) (keyprovider.Key, error) {
+	_ = ctx
+	return cloneKey(p.keys[len(p.keys)-1]), nil
+}
+
+func (p *Provider) Get(ctx context.Context, id string) (keyprovider.Key, error) {
+	_ = ctx
+	for _, k := range p.keys {
+		if k.ID == id {
+			return cloneKey(k), nil
+		}
+	}
+	return keyprovider.Key{}, keyprovider.ErrKeyNotFound
+}
+
+func cloneKey(k keyprovider.Key) keyprovider.Key {
+	return keyprovider.Key{ID: k.ID, Secret: bytes.Clone(k.Secret)}
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"regexp"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
+)
+
+// linkQuery matches the query string or fragment of a link, where emailed tokens live.
+var linkQuery = regexp.MustCompile(`(https?://[^\s?#]*)[?#]\S*`)
+
+// LogMailer is a mailer.Mailer that only logs messages. It keeps nothing, so it is safe as the
+// default for long-running servers, and it redacts link query strings so tokens never reach logs.
+type LogMailer struct{}
+
+func NewLogMailer() LogMailer {
+	return LogMailer{}
+}
+
+func (LogMailer) Send(ctx context.Context, msg mailer.Message) error {
+	_ = ctx
+	log.Printf("mail to=%s subject=%q\n%s", msg.To, msg.Subject, RedactLinks(msg.Body))
+	return nil
+}
+
+// RedactLinks replaces the query string and fragment of every link in body.
+func RedactLinks(body string) string {
+	return linkQuery.ReplaceAllString(body, "$1?[redacted]")
+}
@@ -0,0 +1,13 @@
+package mailer
+
+import "testing"
+
+func TestRedactLinks(t *testing.T) {
+	t.Parallel()
+
+	body := "Confirm: https://api.example.org/email-verifications/confirm?token=secret\nSee https://example.org/trips and http://x.test/a#frag."
+	want := "Confirm: https://api.example.org/email-verifications/confirm?[redacted]\nSee https://example.org/trips and http://x.test/a?[redacted]"
+	if got := RedactLinks(body); got != want {
+		t.Fatalf("RedactLinks() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
+)
+
+// Outbox is an in-memory mailer.Mailer that records messages instead of delivering them.
+// It keeps every message, so it is for tests only. It is safe for concurrent use.
+type Outbox struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+}
+
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+func (o *Outbox) Send(ctx context.Context, msg mailer.Message) error {
+	_ = ctx
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+// Sent returns a copy of every message sent so far, oldest first.
+func (o *Outbox) Sent() []mailer.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]mailer.Message(nil), o.sent...)
+}
@@ -2,32 +2,51 @@ package memberrepo
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
 )
 
 // Repo is an in-memory implementation of memberrepo.Repository.
 // It is safe for concurrent use.
+//
+// Like the Postgres adapter, subjects are bound per issuer (read from the request context).
 type Repo struct {
 	mu sync.RWMutex
 
-	byID    map[domain.MemberID]memberrepo.Member
-	idBySub map[domain.SubjectID]domain.MemberID
+	byID       map[domain.MemberID]memberrepo.Member
+	idBySub    map[subjectKey]domain.MemberID
+	identities map[domain.MemberID][]domain.MemberIdentity
+	vehicles   map[domain.MemberID][]domain.Vehicle
+}
+
+type subjectKey struct {
+	issuer  string
+	subject domain.SubjectID
 }
 
 func NewRepo() *Repo {
 	return &Repo{
-		byID:    make(map[domain.MemberID]memberrepo.Member),
-		idBySub: make(map[domain.SubjectID]domain.MemberID),
+		byID:       make(map[domain.MemberID]memberrepo.Member),
+		idBySub:    make(map[subjectKey]domain.MemberID),
+		identities: make(map[domain.MemberID][]domain.MemberIdentity),
+		vehicles:   make(map[domain.MemberID][]domain.Vehicle),
 	}
 }
 
+func subjectKeyFor(ctx context.Context, subject domain.SubjectID) subjectKey {
+	return subjectKey{issuer: authctx.IssuerOr(ctx, ""), subject: subject}
+}
+
 func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
-	_ = ctx
 	if m.ID == "" {
 		return memberrepo.ErrAlreadyExists // treat empty ID as invalid; app/domain will validate later
 	}
@@ -37,12 +56,14 @@ func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
 	if _, ok := r.byID[m.ID]; ok {
 		return memberrepo.ErrAlreadyExists
 	}
-	if existingID, ok := r.idBySub[m.Subject]; ok && existingID != "" {
+	key := subjectKeyFor(ctx, m.Subject)
+	if existingID, ok := r.idBySub[key]; ok && existingID != "" {
 		return memberrepo.ErrSubjectAlreadyBound
 	}
 
-	r.byID[m.ID] = cloneMember(m)
-	r.idBySub[m.Subject] = m.ID
+	r.byID[m.ID] = r.storeMember(m)
+	r.idBySub[key] = m.ID
+	r.identities[m.ID] = []domain.MemberIdentity{{Issuer: key.issuer, Subject: m.Subject, LinkedAt: m.CreatedAt.UTC()}}
 	return nil
 }
 
@@ -60,7 +81,37 @@ func (r *Repo) Update(ctx context.Context, m memberrepo.Member) error {
 		return memberrepo.ErrSubjectAlreadyBound
 	}
 
-	r.byID[m.ID] = cloneMember(m)
+	r.byID[m.ID] = r.storeMember(m)
+	return nil
+}
+
+func (r *Repo) MarkEmailVerified(ctx context.Context, id domain.MemberID, field memberrepo.EmailField, addr string, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.byID[id]
+	if !ok {
+		return memberrepo.ErrNotFound
+	}
+	var current *string
+	var verifiedAt **time.Time
+	switch field {
+	case memberrepo.EmailFieldPrimary:
+		current, verifiedAt = &m.Email, &m.EmailVerifiedAt
+	case memberrepo.EmailFieldGroupAlias:
+		current, verifiedAt = m.GroupAliasEmail, &m.GroupAliasEmailVerifiedAt
+	default:
+		return fmt.Errorf("unknown email field %q", field)
+	}
+	if current == nil || !strings.EqualFold(*current, addr) {
+		return memberrepo.ErrEmailChanged
+	}
+	if *verifiedAt == nil {
+		t := at.UTC()
+		*verifiedAt = &t
+		r.byID[id] = m
+	}
 	return nil
 }
 
@@ -72,14 +123,13 @@ func (r *Repo) GetByID(ctx context.Context, id domain.MemberID) (memberrepo.Memb
 	if !ok {
 		return memberrepo.Member{}, memberrepo.ErrNotFound
 	}
-	return cloneMember(m), nil
+	return r.loadMember(m), nil
 }
 
 func (r *Repo) GetBySubject(ctx context.Context, subject domain.SubjectID) (memberrepo.Member, error) {
-	_ = ctx
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	id, ok := r.idBySub[subject]
+	id, ok := r.idBySub[subjectKeyFor(ctx, subject)]
 	if !ok {
 		return memberrepo.Member{}, memberrepo.ErrNotFound
 	}
@@ -87,7 +137,68 @@ func (r *Repo) GetBySubject(ctx context.Context, subject domain.SubjectID) (memb
 	if !ok {
 		return memberrepo.Member{}, memberrepo.ErrNotFound
 	}
-	return cloneMember(m), nil
+	return r.loadMember(m), nil
+}
+
+func (r *Repo) LinkIdentity(ctx context.Context, id domain.MemberID, identity domain.MemberIdentity) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	key := subjectKey{issuer: identity.Issuer, subject: identity.Subject}
+	if existingID, ok := r.idBySub[key]; ok {
+		if existingID == id {
+			return nil
+		}
+		return memberrepo.ErrSubjectAlreadyBound
+	}
+	identity.LinkedAt = identity.LinkedAt.UTC()
+	r.idBySub[key] = id
+	r.identities[id] = append(r.identities[id], identity)
+	return nil
+}
+
+func (r *Repo) UnlinkIdentity(ctx context.Context, id domain.MemberID, issuer string, subject domain.SubjectID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	key := subjectKey{issuer: issuer, subject: subject}
+	if r.idBySub[key] != id {
+		return memberrepo.ErrIdentityNotFound
+	}
+	ids := r.identities[id]
+	if len(ids) <= 1 {
+		return memberrepo.ErrLastIdentity
+	}
+	out := make([]domain.MemberIdentity, 0, len(ids)-1)
+	for _, mi := range ids {
+		if mi.Issuer != issuer || mi.Subject != subject {
+			out = append(out, mi)
+		}
+	}
+	r.identities[id] = out
+	delete(r.idBySub, key)
+	return nil
+}
+
+func (r *Repo) ListIdentities(ctx context.Context, id domain.MemberID) ([]domain.MemberIdentity, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return nil, memberrepo.ErrNotFound
+	}
+	out := append([]domain.MemberIdentity(nil), r.identities[id]...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].LinkedAt.Before(out[j].LinkedAt) })
+	return out, nil
 }
 
 func (r *Repo) List(ctx context.Context, includeInactive bool) ([]memberrepo.Member, error) {
@@ -100,7 +211,7 @@ func (r *Repo) List(ctx context.Context, includeInactive bool) ([]memberrepo.Mem
 		if !includeInactive && !m.IsActive {
 			continue
 		}
-		out = append(out, cloneMember(m))
+		out = append(out, r.loadMember(m))
 	}
 	sortMembersByDisplayName(out)
 	return out, nil
@@ -123,7 +234,7 @@ func (r *Repo) SearchActiveByDisplayName(ctx context.Context, query string, limi
 			continue
 		}
 		if matchesAllTokens(m.DisplayName, qTokens) {
-			out = append(out, cloneMember(m))
+			out = append(out, r.loadMember(m))
 		}
 	}
 	sortMembersByDisplayName(out)
@@ -133,6 +244,212 @@ func (r *Repo) SearchActiveByDisplayName(ctx context.Context, query string, limi
 	return out, nil
 }
 
+func (r *Repo) ListVehicles(ctx context.Context, id domain.MemberID) ([]domain.Vehicle, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return nil, memberrepo.ErrNotFound
+	}
+	out := make([]domain.Vehicle, 0, len(r.vehicles[id]))
+	for _, v := range r.vehicles[id] {
+		out = append(out, cloneVehicle(v))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].IsDefault != out[j].IsDefault {
+			return out[i].IsDefault
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) GetVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) (domain.Vehicle, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return domain.Vehicle{}, memberrepo.ErrNotFound
+	}
+	i := r.vehicleIndex(id, vehicleID)
+	if i < 0 {
+		return domain.Vehicle{}, memberrepo.ErrVehicleNotFound
+	}
+	return cloneVehicle(r.vehicles[id][i]), nil
+}
+
+func (r *Repo) CreateVehicle(ctx context.Context, v domain.Vehicle) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[v.MemberID]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	for _, vs := range r.vehicles {
+		for _, existing := range vs {
+			if existing.ID == v.ID {
+				return memberrepo.ErrAlreadyExists
+			}
+		}
+	}
+	v = cloneVehicle(v)
+	if len(r.vehicles[v.MemberID]) == 0 {
+		v.IsDefault = true
+	}
+	if v.IsDefault {
+		r.clearDefault(v.MemberID)
+	}
+	r.vehicles[v.MemberID] = append(r.vehicles[v.MemberID], v)
+	return nil
+}
+
+func (r *Repo) UpdateVehicle(ctx context.Context, v domain.Vehicle) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[v.MemberID]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	i := r.vehicleIndex(v.MemberID, v.ID)
+	if i < 0 {
+		return memberrepo.ErrVehicleNotFound
+	}
+	cur := &r.vehicles[v.MemberID][i]
+	cur.Name = v.Name
+	cur.Profile = *cloneVehicleProfile(&v.Profile)
+	cur.Specs = cloneVehicleSpecs(v.Specs)
+	cur.UpdatedAt = v.UpdatedAt.UTC()
+	return nil
+}
+
+func (r *Repo) DeleteVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	i := r.vehicleIndex(id, vehicleID)
+	if i < 0 {
+		return memberrepo.ErrVehicleNotFound
+	}
+	vs := r.vehicles[id]
+	wasDefault := vs[i].IsDefault
+	vs = append(vs[:i:i], vs[i+1:]...)
+	if wasDefault && len(vs) > 0 {
+		oldest := 0
+		for j := range vs {
+			if vs[j].CreatedAt.Before(vs[oldest].CreatedAt) {
+				oldest = j
+			}
+		}
+		vs[oldest].IsDefault = true
+	}
+	r.vehicles[id] = vs
+	return nil
+}
+
+func (r *Repo) SetDefaultVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[id]; !ok {
+		return memberrepo.ErrNotFound
+	}
+	i := r.vehicleIndex(id, vehicleID)
+	if i < 0 {
+		return memberrepo.ErrVehicleNotFound
+	}
+	r.clearDefault(id)
+	r.vehicles[id][i].IsDefault = true
+	return nil
+}
+
+func (r *Repo) vehicleIndex(id domain.MemberID, vehicleID domain.VehicleID) int {
+	for i, v := range r.vehicles[id] {
+		if v.ID == vehicleID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Repo) clearDefault(id domain.MemberID) {
+	for i := range r.vehicles[id] {
+		r.vehicles[id][i].IsDefault = false
+	}
+}
+
+// storeMember writes m.VehicleProfile through to the default vehicle and returns the member
+// record to keep (without the profile, which is derived from the garage on read).
+// Callers must hold the write lock.
+func (r *Repo) storeMember(m memberrepo.Member) memberrepo.Member {
+	if m.VehicleProfile != nil {
+		vs := r.vehicles[m.ID]
+		updated := false
+		for i := range vs {
+			if vs[i].IsDefault {
+				vs[i].Profile = *cloneVehicleProfile(m.VehicleProfile)
+				vs[i].UpdatedAt = m.UpdatedAt.UTC()
+				updated = true
+			}
+		}
+		if !updated {
+			r.vehicles[m.ID] = append(vs, domain.Vehicle{
+				ID:        domain.VehicleID(uuid.NewString()),
+				MemberID:  m.ID,
+				Name:      domain.DefaultVehicleName,
+				IsDefault: true,
+				Profile:   *cloneVehicleProfile(m.VehicleProfile),
+				CreatedAt: m.UpdatedAt.UTC(),
+				UpdatedAt: m.UpdatedAt.UTC(),
+			})
+		}
+	}
+	out := cloneMember(m)
+	out.VehicleProfile = nil
+	return out
+}
+
+// loadMember returns a copy of m with VehicleProfile taken from the default vehicle.
+// Callers must hold the lock.
+func (r *Repo) loadMember(m memberrepo.Member) memberrepo.Member {
+	out := cloneMember(m)
+	for _, v := range r.vehicles[m.ID] {
+		if v.IsDefault {
+			out.VehicleProfile = cloneVehicleProfile(&v.Profile)
+		}
+	}
+	return out
+}
+
+func cloneVehicle(v domain.Vehicle) domain.Vehicle {
+	out := v
+	out.Profile = *cloneVehicleProfile(&v.Profile)
+	out.Specs = cloneVehicleSpecs(v.Specs)
+	out.CreatedAt = v.CreatedAt.UTC()
+	out.UpdatedAt = v.UpdatedAt.UTC()
+	return out
+}
+
+func cloneVehicleSpecs(s domain.VehicleSpecs) domain.VehicleSpecs {
+	out := s
+	if s.TireSizeInches != nil {
+		v := *s.TireSizeInches
+		out.TireSizeInches = &v
+	}
+	if s.RecoveryGear != nil {
+		out.RecoveryGear = append([]domain.RecoveryGearItem(nil), s.RecoveryGear...)
+	}
+	return out
+}
+
 func cloneMember(m memberrepo.Member) memberrepo.Member {
 	out := m
 	if m.GroupAliasEmail != nil {
@@ -142,9 +459,19 @@ func cloneMember(m memberrepo.Member) memberrepo.Member {
 	if m.VehicleProfile != nil {
 		out.VehicleProfile = cloneVehicleProfile(m.VehicleProfile)
 	}
+	out.EmailVerifiedAt = cloneTimePtr(m.EmailVerifiedAt)
+	out.GroupAliasEmailVerifiedAt = cloneTimePtr(m.GroupAliasEmailVerifiedAt)
 	return out
 }
 
+func cloneTimePtr(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+	v := p.UTC()
+	return &v
+}
+
 func cloneVehicleProfile(vp *domain.VehicleProfile) *domain.VehicleProfile {
 	if vp == nil {
 		return nil
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/notifier"
+)
+
+// ErrUndeliverable is returned for recipients marked with FailFor or FailForEmail.
+var ErrUndeliverable = errors.New("recipient is undeliverable")
+
+// Recorder is an in-memory notifier.Notifier that records notifications instead of
+// delivering them. It is used in tests. It is safe for concurrent use.
+type Recorder struct {
+	mu   sync.Mutex
+	sent []notifier.Message
+	fail map[domain.MemberID]bool
+	// failEmail holds addresses marked with FailForEmail.
+	failEmail map[string]bool
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{fail: make(map[domain.MemberID]bool), failEmail: make(map[string]bool)}
+}
+
+// FailFor makes every later notification to id fail with ErrUndeliverable.
+func (r *Recorder) FailFor(id domain.MemberID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fail[id] = true
+}
+
+// FailForEmail makes every later notification to email fail with ErrUndeliverable.
+func (r *Recorder) FailForEmail(email string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failEmail[email] = true
+}
+
+// ClearFailures makes every recipient deliverable again.
+func (r *Recorder) ClearFailures() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.fail)
+	clear(r.failEmail)
+}
+
+func (r *Recorder) Notify(ctx context.Context, msg notifier.Message) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail[msg.To.MemberID] || r.failEmail[msg.To.Email] {
+		return ErrUndeliverable
+	}
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+// Sent returns a copy of every notification delivered so far, oldest first.
+func (r *Recorder) Sent() []notifier.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]notifier.Message(nil), r.sent...)
+}
@@ -0,0 +1,15 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	ratelimitport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+func TestContract_RateLimitStore(t *testing.T) {
+	contracttest.RunRateLimitStore(t, func(t *testing.T) (ratelimitport.Store, func()) {
+		t.Helper()
+		return NewStore(), nil
+	})
+}
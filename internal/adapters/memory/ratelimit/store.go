@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+// Store is an in-memory implementation of ratelimit.Store.
+// It is safe for concurrent use, but limits are per-process (not shared across replicas).
+type Store struct {
+	mu sync.Mutex
+	m  map[string]ratelimit.Bucket
+}
+
+func NewStore() *Store {
+	return &Store{
+		m: make(map[string]ratelimit.Bucket),
+	}
+}
+
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Decision, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, d := ratelimit.Take(s.m[key], limit, now)
+	s.m[key] = b
+	return d, nil
+}
+
+func (s *Store) Peek(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Decision, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, d := ratelimit.Take(s.m[key], limit, now)
+	return d, nil
+}
+
+func (s *Store) DeleteIdle(ctx context.Context, before time.Time, limit int) (int, error) {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for k, b := range s.m {
+		if n >= limit {
+			break
+		}
+		if !b.UpdatedAt.After(before) {
+			delete(s.m, k)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,236 @@
+package ridesharerepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
+)
+
+type offerKey struct {
+	tripID   domain.TripID
+	driverID domain.MemberID
+}
+
+// Repo is an in-memory implementation of ridesharerepo.Repository.
+// It is safe for concurrent use; seat checks happen under the write lock.
+type Repo struct {
+	mu sync.RWMutex
+
+	offers   map[offerKey]ridesharerepo.Offer
+	requests map[domain.RideRequestID]ridesharerepo.Request
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		offers:   make(map[offerKey]ridesharerepo.Offer),
+		requests: make(map[domain.RideRequestID]ridesharerepo.Request),
+	}
+}
+
+func (r *Repo) PutOffer(ctx context.Context, o ridesharerepo.Offer) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := offerKey{tripID: o.TripID, driverID: o.DriverMemberID}
+	if o.Seats < r.acceptedLocked(k) {
+		return ridesharerepo.ErrSeatsBelowAccepted
+	}
+	if existing, ok := r.offers[k]; ok {
+		o.CreatedAt = existing.CreatedAt
+	}
+	r.offers[k] = cloneOffer(o)
+	return nil
+}
+
+func (r *Repo) GetOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID) (ridesharerepo.Offer, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.offers[offerKey{tripID: tripID, driverID: driverID}]
+	if !ok {
+		return ridesharerepo.Offer{}, ridesharerepo.ErrOfferNotFound
+	}
+	return cloneOffer(o), nil
+}
+
+func (r *Repo) ListOffersByTrip(ctx context.Context, tripID domain.TripID) ([]ridesharerepo.Offer, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]ridesharerepo.Offer, 0)
+	for k, o := range r.offers {
+		if k.tripID == tripID {
+			out = append(out, cloneOffer(o))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].DriverMemberID < out[j].DriverMemberID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) WithdrawOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := offerKey{tripID: tripID, driverID: driverID}
+	if _, ok := r.offers[k]; !ok {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	delete(r.offers, k)
+	for id, req := range r.requests {
+		if req.TripID == tripID && req.DriverMemberID == driverID && isOpen(req.Status) {
+			req.Status = ridesharerepo.StatusCanceled
+			req.UpdatedAt = at
+			r.requests[id] = req
+		}
+	}
+	return nil
+}
+
+func (r *Repo) CreateRequest(ctx context.Context, req ridesharerepo.Request) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.requests[req.ID]; ok || req.ID == "" {
+		return ridesharerepo.ErrAlreadyExists
+	}
+	if _, ok := r.offers[offerKey{tripID: req.TripID, driverID: req.DriverMemberID}]; !ok {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	for _, existing := range r.requests {
+		if existing.TripID == req.TripID && existing.RiderMemberID == req.RiderMemberID && isOpen(existing.Status) {
+			return ridesharerepo.ErrActiveRequestExists
+		}
+	}
+	req.Status = ridesharerepo.StatusPending
+	r.requests[req.ID] = cloneRequest(req)
+	return nil
+}
+
+func (r *Repo) GetRequest(ctx context.Context, id domain.RideRequestID) (ridesharerepo.Request, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	req, ok := r.requests[id]
+	if !ok {
+		return ridesharerepo.Request{}, ridesharerepo.ErrRequestNotFound
+	}
+	return cloneRequest(req), nil
+}
+
+func (r *Repo) ListRequestsByTrip(ctx context.Context, tripID domain.TripID) ([]ridesharerepo.Request, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]ridesharerepo.Request, 0)
+	for _, req := range r.requests {
+		if req.TripID == tripID {
+			out = append(out, cloneRequest(req))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) AcceptRequest(ctx context.Context, id domain.RideRequestID, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, ok := r.requests[id]
+	if !ok {
+		return ridesharerepo.ErrRequestNotFound
+	}
+	if req.Status != ridesharerepo.StatusPending {
+		return ridesharerepo.ErrInvalidTransition
+	}
+	k := offerKey{tripID: req.TripID, driverID: req.DriverMemberID}
+	o, ok := r.offers[k]
+	if !ok {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	if r.acceptedLocked(k) >= o.Seats {
+		return ridesharerepo.ErrNoSeatsAvailable
+	}
+	req.Status = ridesharerepo.StatusAccepted
+	req.UpdatedAt = at
+	r.requests[id] = req
+	return nil
+}
+
+func (r *Repo) CloseRequest(ctx context.Context, id domain.RideRequestID, status ridesharerepo.Status, at time.Time) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, ok := r.requests[id]
+	if !ok {
+		return ridesharerepo.ErrRequestNotFound
+	}
+	if !isOpen(req.Status) || (status != ridesharerepo.StatusDeclined && status != ridesharerepo.StatusCanceled) {
+		return ridesharerepo.ErrInvalidTransition
+	}
+	req.Status = status
+	req.UpdatedAt = at
+	r.requests[id] = req
+	return nil
+}
+
+func (r *Repo) CountAcceptedByTrip(ctx context.Context, tripID domain.TripID) (int, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n := 0
+	for _, req := range r.requests {
+		if req.TripID == tripID && req.Status == ridesharerepo.StatusAccepted {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *Repo) acceptedLocked(k offerKey) int {
+	n := 0
+	for _, req := range r.requests {
+		if req.TripID == k.tripID && req.DriverMemberID == k.driverID && req.Status == ridesharerepo.StatusAccepted {
+			n++
+		}
+	}
+	return n
+}
+
+func isOpen(s ridesharerepo.Status) bool {
+	return s == ridesharerepo.StatusPending || s == ridesharerepo.StatusAccepted
+}
+
+func cloneOffer(o ridesharerepo.Offer) ridesharerepo.Offer {
+	if o.Notes != nil {
+		v := *o.Notes
+		o.Notes = &v
+	}
+	return o
+}
+
+func cloneRequest(req ridesharerepo.Request) ridesharerepo.Request {
+	if req.Note != nil {
+		v := *req.Note
+		req.Note = &v
+	}
+	return req
+}
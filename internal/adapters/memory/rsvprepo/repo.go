@@ -19,6 +19,8 @@ type key struct {
 type Repo struct {
 	mu sync.RWMutex
 	m  map[key]rsvprepo.RSVP
+	// history is append-only, in write order.
+	history []rsvprepo.HistoryEntry
 }
 
 func NewRepo() *Repo {
@@ -33,14 +35,28 @@ func (r *Repo) Get(ctx context.Context, tripID domain.TripID, memberID domain.Me
 	if !ok {
 		return rsvprepo.RSVP{}, rsvprepo.ErrNotFound
 	}
-	return v, nil
+	return cloneRSVP(v), nil
 }
 
 func (r *Repo) Upsert(ctx context.Context, rec rsvprepo.RSVP) error {
 	_ = ctx
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.m[key{tripID: rec.TripID, memberID: rec.MemberID}] = rec
+	k := key{tripID: rec.TripID, memberID: rec.MemberID}
+	entry := rsvprepo.HistoryEntry{
+		TripID:       rec.TripID,
+		MemberID:     rec.MemberID,
+		Status:       rec.Status,
+		ChangedBy:    rec.ChangedBy,
+		ChangeReason: cloneStringPtr(rec.ChangeReason),
+		ChangedAt:    rec.UpdatedAt,
+	}
+	if prev, ok := r.m[k]; ok {
+		s := prev.Status
+		entry.PreviousStatus = &s
+	}
+	r.m[k] = cloneRSVP(rec)
+	r.history = append(r.history, entry)
 	return nil
 }
 
@@ -51,7 +67,7 @@ func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo
 	out := make([]rsvprepo.RSVP, 0)
 	for k, v := range r.m {
 		if k.tripID == tripID {
-			out = append(out, v)
+			out = append(out, cloneRSVP(v))
 		}
 	}
 	sort.Slice(out, func(i, j int) bool {
@@ -78,3 +94,69 @@ func (r *Repo) CountYesByTrip(ctx context.Context, tripID domain.TripID) (int, e
 	}
 	return n, nil
 }
+
+func (r *Repo) CountPeopleByTrip(ctx context.Context, tripID domain.TripID) (int, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n := 0
+	for k, v := range r.m {
+		if k.tripID != tripID {
+			continue
+		}
+		if v.Status == rsvprepo.StatusYes {
+			n += 1 + v.PassengerCount
+		}
+	}
+	return n, nil
+}
+
+func (r *Repo) ListHistoryByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo.HistoryEntry, error) {
+	_ = ctx
+	return r.listHistory(func(e rsvprepo.HistoryEntry) bool { return e.TripID == tripID }), nil
+}
+
+func (r *Repo) ListHistoryByMember(ctx context.Context, memberID domain.MemberID) ([]rsvprepo.HistoryEntry, error) {
+	_ = ctx
+	return r.listHistory(func(e rsvprepo.HistoryEntry) bool { return e.MemberID == memberID }), nil
+}
+
+func (r *Repo) listHistory(match func(rsvprepo.HistoryEntry) bool) []rsvprepo.HistoryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]rsvprepo.HistoryEntry, 0)
+	for _, e := range r.history {
+		if !match(e) {
+			continue
+		}
+		if e.PreviousStatus != nil {
+			s := *e.PreviousStatus
+			e.PreviousStatus = &s
+		}
+		e.ChangeReason = cloneStringPtr(e.ChangeReason)
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ChangedAt.Before(out[j].ChangedAt) })
+	return out
+}
+
+func cloneStringPtr(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneRSVP(rec rsvprepo.RSVP) rsvprepo.RSVP {
+	out := rec
+	if rec.VehicleID != nil {
+		v := *rec.VehicleID
+		out.VehicleID = &v
+	}
+	if rec.PassengerNames != nil {
+		out.PassengerNames = append([]string(nil), rec.PassengerNames...)
+	}
+	out.ChangeReason = cloneStringPtr(rec.ChangeReason)
+	return out
+}
@@ -33,6 +33,13 @@ func (r *Repo) Create(ctx context.Context, t triprepo.Trip) error {
 	if _, ok := r.byID[t.ID]; ok {
 		return triprepo.ErrAlreadyExists
 	}
+	if t.Series != nil {
+		for _, existing := range r.byID {
+			if existing.Series != nil && existing.Series.SeriesID == t.Series.SeriesID && existing.Series.Date.Equal(t.Series.Date) {
+				return triprepo.ErrOccurrenceExists
+			}
+		}
+	}
 	r.byID[t.ID] = cloneTrip(t)
 	return nil
 }
@@ -90,6 +97,22 @@ func (r *Repo) ListDraftsVisibleTo(ctx context.Context, caller domain.MemberID)
 	return out, nil
 }
 
+func (r *Repo) ListBySeries(ctx context.Context, seriesID domain.TripSeriesID) ([]triprepo.Trip, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]triprepo.Trip, 0)
+	for _, t := range r.byID {
+		if t.Series != nil && t.Series.SeriesID == seriesID {
+			out = append(out, cloneTrip(t))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Series.Date.Before(out[j].Series.Date)
+	})
+	return out, nil
+}
+
 func cloneTrip(t triprepo.Trip) triprepo.Trip {
 	cp := t
 	if t.OrganizerMemberIDs != nil {
@@ -98,12 +121,16 @@ func cloneTrip(t triprepo.Trip) triprepo.Trip {
 	cp.Name = cloneStringPtr(t.Name)
 	cp.Description = cloneStringPtr(t.Description)
 	cp.EndDate = cloneTimePtr(t.EndDate)
+	cp.RSVPDeadline = cloneTimePtr(t.RSVPDeadline)
 	cp.CapacityRigs = cloneIntPtr(t.CapacityRigs)
+	cp.CapacityPeople = cloneIntPtr(t.CapacityPeople)
 	cp.AttendingRigs = cloneIntPtr(t.AttendingRigs)
+	cp.Difficulty = cloneDifficulty(t.Difficulty)
 	cp.DifficultyText = cloneStringPtr(t.DifficultyText)
 	cp.CommsRequirementsText = cloneStringPtr(t.CommsRequirementsText)
 	cp.RecommendedRequirementsText = cloneStringPtr(t.RecommendedRequirementsText)
 	cp.MeetingLocation = cloneLocation(t.MeetingLocation)
+	cp.Requirements = cloneRequirements(t.Requirements)
 	if t.Artifacts != nil {
 		cp.Artifacts = append([]domain.TripArtifact(nil), t.Artifacts...)
 	}
@@ -111,9 +138,35 @@ func cloneTrip(t triprepo.Trip) triprepo.Trip {
 		sd := *t.StartDate
 		cp.StartDate = &sd
 	}
+	if t.Series != nil {
+		so := *t.Series
+		cp.Series = &so
+	}
 	return cp
 }
 
+func cloneRequirements(r domain.TripRequirements) domain.TripRequirements {
+	out := r
+	out.MinTireSizeInches = cloneIntPtr(r.MinTireSizeInches)
+	if r.RecoveryGear != nil {
+		out.RecoveryGear = append([]domain.RecoveryGearItem(nil), r.RecoveryGear...)
+	}
+	return out
+}
+
+func cloneDifficulty(d *domain.Difficulty) *domain.Difficulty {
+	if d == nil {
+		return nil
+	}
+	out := *d
+	out.MinRating = cloneIntPtr(d.MinRating)
+	out.MaxRating = cloneIntPtr(d.MaxRating)
+	if d.Terrain != nil {
+		out.Terrain = append([]domain.TerrainTag(nil), d.Terrain...)
+	}
+	return &out
+}
+
 func cloneStringPtr(p *string) *string {
 	if p == nil {
 		return nil
@@ -0,0 +1,141 @@
+package tripseriesrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+)
+
+// Repo is an in-memory implementation of tripseriesrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu   sync.RWMutex
+	byID map[domain.TripSeriesID]tripseriesrepo.Series
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		byID: make(map[domain.TripSeriesID]tripseriesrepo.Series),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, s tripseriesrepo.Series) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[s.ID]; ok {
+		return tripseriesrepo.ErrAlreadyExists
+	}
+	r.byID[s.ID] = cloneSeries(s)
+	return nil
+}
+
+func (r *Repo) Save(ctx context.Context, s tripseriesrepo.Series) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.byID[s.ID]
+	if !ok {
+		return tripseriesrepo.ErrNotFound
+	}
+	s.CreatedByMember = existing.CreatedByMember
+	s.CreatedAt = existing.CreatedAt
+	r.byID[s.ID] = cloneSeries(s)
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.TripSeriesID) (tripseriesrepo.Series, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.byID[id]
+	if !ok {
+		return tripseriesrepo.Series{}, tripseriesrepo.ErrNotFound
+	}
+	return cloneSeries(s), nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]tripseriesrepo.Series, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]tripseriesrepo.Series, 0, len(r.byID))
+	for _, s := range r.byID {
+		out = append(out, cloneSeries(s))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func cloneSeries(s tripseriesrepo.Series) tripseriesrepo.Series {
+	cp := s
+	if s.EndDate != nil {
+		v := *s.EndDate
+		cp.EndDate = &v
+	}
+	cp.Plan = clonePlan(s.Plan)
+	return cp
+}
+
+func clonePlan(p domain.TripPlan) domain.TripPlan {
+	cp := p
+	cp.Name = cloneStringPtr(p.Name)
+	cp.Description = cloneStringPtr(p.Description)
+	cp.CapacityRigs = cloneIntPtr(p.CapacityRigs)
+	cp.CapacityPeople = cloneIntPtr(p.CapacityPeople)
+	cp.DifficultyText = cloneStringPtr(p.DifficultyText)
+	cp.CommsRequirementsText = cloneStringPtr(p.CommsRequirementsText)
+	cp.RecommendedRequirementsText = cloneStringPtr(p.RecommendedRequirementsText)
+	if p.Difficulty != nil {
+		d := *p.Difficulty
+		d.MinRating = cloneIntPtr(d.MinRating)
+		d.MaxRating = cloneIntPtr(d.MaxRating)
+		d.Terrain = append([]domain.TerrainTag(nil), d.Terrain...)
+		cp.Difficulty = &d
+	}
+	if p.MeetingLocation != nil {
+		l := *p.MeetingLocation
+		l.Address = cloneStringPtr(l.Address)
+		if l.Latitude != nil {
+			v := *l.Latitude
+			l.Latitude = &v
+		}
+		if l.Longitude != nil {
+			v := *l.Longitude
+			l.Longitude = &v
+		}
+		cp.MeetingLocation = &l
+	}
+	cp.Requirements.MinTireSizeInches = cloneIntPtr(p.Requirements.MinTireSizeInches)
+	if p.Requirements.RecoveryGear != nil {
+		cp.Requirements.RecoveryGear = append([]domain.RecoveryGearItem(nil), p.Requirements.RecoveryGear...)
+	}
+	if p.Artifacts != nil {
+		cp.Artifacts = append([]domain.TripArtifact(nil), p.Artifacts...)
+	}
+	return cp
+}
+
+func cloneStringPtr(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
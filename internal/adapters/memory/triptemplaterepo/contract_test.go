@@ -0,0 +1,24 @@
+package triptemplaterepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triptemplaterepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+)
+
+func TestContract_TripTemplateRepo(t *testing.T) {
+	contracttest.RunTripTemplateRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memmemberrepo.NewRepo(), nil
+		},
+		func(t *testing.T) (triptemplaterepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(), nil
+		},
+	)
+}
@@ -0,0 +1,136 @@
+package triptemplaterepo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+)
+
+// Repo is an in-memory implementation of triptemplaterepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu   sync.RWMutex
+	byID map[domain.TripTemplateID]triptemplaterepo.Template
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		byID: make(map[domain.TripTemplateID]triptemplaterepo.Template),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, t triptemplaterepo.Template) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[t.ID]; ok {
+		return triptemplaterepo.ErrAlreadyExists
+	}
+	for _, existing := range r.byID {
+		if strings.EqualFold(existing.Name, t.Name) {
+			return triptemplaterepo.ErrNameTaken
+		}
+	}
+	r.byID[t.ID] = cloneTemplate(t)
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.TripTemplateID) (triptemplaterepo.Template, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.byID[id]
+	if !ok {
+		return triptemplaterepo.Template{}, triptemplaterepo.ErrNotFound
+	}
+	return cloneTemplate(t), nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]triptemplaterepo.Template, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]triptemplaterepo.Template, 0, len(r.byID))
+	for _, t := range r.byID {
+		out = append(out, cloneTemplate(t))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := strings.ToLower(out[i].Name), strings.ToLower(out[j].Name)
+		if a != b {
+			return a < b
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id domain.TripTemplateID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[id]; !ok {
+		return triptemplaterepo.ErrNotFound
+	}
+	delete(r.byID, id)
+	return nil
+}
+
+func cloneTemplate(t triptemplaterepo.Template) triptemplaterepo.Template {
+	cp := t
+	p := t.Plan
+	cp.Plan.Name = cloneStringPtr(p.Name)
+	cp.Plan.Description = cloneStringPtr(p.Description)
+	cp.Plan.CapacityRigs = cloneIntPtr(p.CapacityRigs)
+	cp.Plan.CapacityPeople = cloneIntPtr(p.CapacityPeople)
+	cp.Plan.DifficultyText = cloneStringPtr(p.DifficultyText)
+	cp.Plan.CommsRequirementsText = cloneStringPtr(p.CommsRequirementsText)
+	cp.Plan.RecommendedRequirementsText = cloneStringPtr(p.RecommendedRequirementsText)
+	if p.Difficulty != nil {
+		d := *p.Difficulty
+		d.MinRating = cloneIntPtr(d.MinRating)
+		d.MaxRating = cloneIntPtr(d.MaxRating)
+		d.Terrain = append([]domain.TerrainTag(nil), d.Terrain...)
+		cp.Plan.Difficulty = &d
+	}
+	if p.MeetingLocation != nil {
+		l := *p.MeetingLocation
+		l.Address = cloneStringPtr(l.Address)
+		if l.Latitude != nil {
+			v := *l.Latitude
+			l.Latitude = &v
+		}
+		if l.Longitude != nil {
+			v := *l.Longitude
+			l.Longitude = &v
+		}
+		cp.Plan.MeetingLocation = &l
+	}
+	cp.Plan.Requirements.MinTireSizeInches = cloneIntPtr(p.Requirements.MinTireSizeInches)
+	if p.Requirements.RecoveryGear != nil {
+		cp.Plan.Requirements.RecoveryGear = append([]domain.RecoveryGearItem(nil), p.Requirements.RecoveryGear...)
+	}
+	if p.Artifacts != nil {
+		cp.Plan.Artifacts = append([]domain.TripArtifact(nil), p.Artifacts...)
+	}
+	return cp
+}
+
+func cloneStringPtr(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
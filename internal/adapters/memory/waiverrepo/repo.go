@@ -0,0 +1,176 @@
+package waiverrepo
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
+)
+
+type ackKey struct {
+	waiverID domain.WaiverID
+	version  int
+	memberID domain.MemberID
+}
+
+// Repo is an in-memory implementation of waiverrepo.Repository.
+// It is safe for concurrent use.
+type Repo struct {
+	mu       sync.RWMutex
+	waivers  map[domain.WaiverID]waiverrepo.Waiver
+	versions map[domain.WaiverID][]waiverrepo.Version
+	acks     map[ackKey]waiverrepo.Acknowledgement
+	ackOrder []ackKey
+	trips    map[domain.TripID][]domain.WaiverID
+}
+
+func NewRepo() *Repo {
+	return &Repo{
+		waivers:  make(map[domain.WaiverID]waiverrepo.Waiver),
+		versions: make(map[domain.WaiverID][]waiverrepo.Version),
+		acks:     make(map[ackKey]waiverrepo.Acknowledgement),
+		trips:    make(map[domain.TripID][]domain.WaiverID),
+	}
+}
+
+func (r *Repo) Create(ctx context.Context, w waiverrepo.Waiver, first waiverrepo.Version) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.waivers[w.ID]; ok {
+		return waiverrepo.ErrAlreadyExists
+	}
+	w.CreatedAt = w.CreatedAt.UTC()
+	first.WaiverID, first.Version = w.ID, 1
+	first.PublishedAt = first.PublishedAt.UTC()
+	r.waivers[w.ID] = w
+	r.versions[w.ID] = []waiverrepo.Version{first}
+	return nil
+}
+
+func (r *Repo) Get(ctx context.Context, id domain.WaiverID) (waiverrepo.Waiver, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	w, ok := r.waivers[id]
+	if !ok {
+		return waiverrepo.Waiver{}, waiverrepo.ErrNotFound
+	}
+	return w, nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]waiverrepo.Waiver, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]waiverrepo.Waiver, 0, len(r.waivers))
+	for _, w := range r.waivers {
+		out = append(out, w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (r *Repo) AddVersion(ctx context.Context, v waiverrepo.Version) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	versions, ok := r.versions[v.WaiverID]
+	if !ok {
+		return waiverrepo.ErrNotFound
+	}
+	if v.Version != len(versions)+1 {
+		return waiverrepo.ErrVersionConflict
+	}
+	v.PublishedAt = v.PublishedAt.UTC()
+	r.versions[v.WaiverID] = append(versions, v)
+	return nil
+}
+
+func (r *Repo) LatestVersion(ctx context.Context, id domain.WaiverID) (waiverrepo.Version, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions, ok := r.versions[id]
+	if !ok {
+		return waiverrepo.Version{}, waiverrepo.ErrNotFound
+	}
+	return versions[len(versions)-1], nil
+}
+
+func (r *Repo) Acknowledge(ctx context.Context, a waiverrepo.Acknowledgement) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a.Version < 1 || a.Version > len(r.versions[a.WaiverID]) {
+		return waiverrepo.ErrNotFound
+	}
+	k := ackKey{waiverID: a.WaiverID, version: a.Version, memberID: a.MemberID}
+	if _, ok := r.acks[k]; ok {
+		return nil
+	}
+	a.AcknowledgedAt = a.AcknowledgedAt.UTC()
+	r.acks[k] = a
+	r.ackOrder = append(r.ackOrder, k)
+	return nil
+}
+
+func (r *Repo) ListAcknowledgementsByMember(ctx context.Context, memberID domain.MemberID) ([]waiverrepo.Acknowledgement, error) {
+	_ = ctx
+	return r.listAcks(func(k ackKey) bool { return k.memberID == memberID }), nil
+}
+
+func (r *Repo) ListAcknowledgementsByWaiver(ctx context.Context, id domain.WaiverID) ([]waiverrepo.Acknowledgement, error) {
+	_ = ctx
+	return r.listAcks(func(k ackKey) bool { return k.waiverID == id }), nil
+}
+
+func (r *Repo) listAcks(match func(ackKey) bool) []waiverrepo.Acknowledgement {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]waiverrepo.Acknowledgement, 0)
+	for _, k := range r.ackOrder {
+		if match(k) {
+			out = append(out, r.acks[k])
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AcknowledgedAt.Before(out[j].AcknowledgedAt) })
+	return out
+}
+
+func (r *Repo) SetTripRequirements(ctx context.Context, tripID domain.TripID, ids []domain.WaiverID) error {
+	_ = ctx
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	set := make([]domain.WaiverID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := r.waivers[id]; !ok {
+			return waiverrepo.ErrNotFound
+		}
+		if !slices.Contains(set, id) {
+			set = append(set, id)
+		}
+	}
+	if len(set) == 0 {
+		delete(r.trips, tripID)
+		return nil
+	}
+	slices.Sort(set)
+	r.trips[tripID] = set
+	return nil
+}
+
+func (r *Repo) ListTripRequirements(ctx context.Context, tripID domain.TripID) ([]domain.WaiverID, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]domain.WaiverID{}, r.trips[tripID]...), nil
+}
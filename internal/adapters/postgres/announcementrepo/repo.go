@@ -0,0 +1,342 @@
+package announcementrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+)
+
+// Repo is a Postgres implementation of announcementrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) Create(ctx context.Context, a announcementrepo.Announcement, recipients []domain.MemberID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(a.ID))
+	if err != nil {
+		return fmt.Errorf("invalid announcement id: %w", err)
+	}
+	tid, err := uuid.Parse(string(a.TripID))
+	if err != nil {
+		return fmt.Errorf("invalid trip id: %w", err)
+	}
+	var aid *uuid.UUID
+	if a.AuthorMemberID != "" {
+		v, err := uuid.Parse(string(a.AuthorMemberID))
+		if err != nil {
+			return fmt.Errorf("invalid author member id: %w", err)
+		}
+		aid = &v
+	}
+	var serviceAccount *string
+	if a.AuthorServiceAccount != "" {
+		serviceAccount = &a.AuthorServiceAccount
+	}
+	rids := make([]uuid.UUID, 0, len(recipients))
+	for _, m := range recipients {
+		v, err := uuid.Parse(string(m))
+		if err != nil {
+			return fmt.Errorf("invalid recipient member id: %w", err)
+		}
+		rids = append(rids, v)
+	}
+
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var pk int64
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO trip_announcements (external_id, trip_id, author_member_id, author_service_account, subject, body, audience, created_at)
+			VALUES (
+				$1,
+				(SELECT id FROM trips WHERE external_id = $2),
+				(SELECT id FROM members WHERE external_id = $3),
+				$4,
+				$5,
+				$6,
+				$7,
+				$8
+			)
+			RETURNING id
+		`, id, tid, aid, serviceAccount, a.Subject, a.Body, string(a.Audience), a.CreatedAt.UTC()).Scan(&pk); err != nil {
+			return err
+		}
+		if len(rids) == 0 {
+			return nil
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO trip_announcement_deliveries (announcement_id, member_id)
+			SELECT $1::bigint, m.id
+			FROM members m
+			WHERE m.external_id = ANY($2::uuid[])
+		`, pk, rids)
+		return err
+	})
+	if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+		return announcementrepo.ErrAlreadyExists
+	}
+	return err
+}
+
+const selectAnnouncement = `
+	SELECT a.external_id, t.external_id, m.external_id, a.author_service_account, a.subject, a.body, a.audience, a.created_at
+	FROM trip_announcements a
+	JOIN trips t ON t.id = a.trip_id
+	LEFT JOIN members m ON m.id = a.author_member_id
+`
+
+func (r *Repo) Get(ctx context.Context, id domain.TripAnnouncementID) (announcementrepo.Announcement, error) {
+	if r.pool == nil {
+		return announcementrepo.Announcement{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return announcementrepo.Announcement{}, announcementrepo.ErrNotFound
+	}
+	a, err := scanAnnouncement(r.pool.QueryRow(ctx, selectAnnouncement+` WHERE a.external_id = $1`, uid))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return announcementrepo.Announcement{}, announcementrepo.ErrNotFound
+	}
+	return a, err
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]announcementrepo.Announcement, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []announcementrepo.Announcement{}, nil
+	}
+	rows, err := r.pool.Query(ctx, selectAnnouncement+`
+		WHERE t.external_id = $1
+		ORDER BY a.created_at DESC, a.external_id DESC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]announcementrepo.Announcement, 0)
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) ListUndelivered(ctx context.Context, staleBefore time.Time, limit int) ([]announcementrepo.Announcement, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	rows, err := r.pool.Query(ctx, selectAnnouncement+`
+		WHERE EXISTS (
+			SELECT 1 FROM trip_announcement_deliveries d
+			WHERE d.announcement_id = a.id
+			  AND (d.status = 'PENDING' OR (d.status = 'SENDING' AND d.attempted_at < $1))
+		)
+		ORDER BY a.created_at, a.external_id
+		LIMIT $2
+	`, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]announcementrepo.Announcement, 0)
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) ListDeliveries(ctx context.Context, id domain.TripAnnouncementID) ([]announcementrepo.Delivery, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return []announcementrepo.Delivery{}, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT m.external_id, d.status, d.error, d.attempted_at, d.sent_to
+		FROM trip_announcement_deliveries d
+		JOIN trip_announcements a ON a.id = d.announcement_id
+		JOIN members m ON m.id = d.member_id
+		WHERE a.external_id = $1
+		ORDER BY m.external_id
+	`, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]announcementrepo.Delivery, 0)
+	for rows.Next() {
+		var memberID uuid.UUID
+		d, err := scanDelivery(rows, &memberID)
+		if err != nil {
+			return nil, err
+		}
+		d.AnnouncementID = id
+		d.MemberID = domain.MemberID(memberID.String())
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) ClaimDelivery(ctx context.Context, id domain.TripAnnouncementID, memberID domain.MemberID, at, staleBefore time.Time) (announcementrepo.Delivery, bool, error) {
+	if r.pool == nil {
+		return announcementrepo.Delivery{}, false, errors.New("nil postgres pool")
+	}
+	aid, err := uuid.Parse(string(id))
+	if err != nil {
+		return announcementrepo.Delivery{}, false, announcementrepo.ErrNotFound
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return announcementrepo.Delivery{}, false, announcementrepo.ErrNotFound
+	}
+	// The status condition makes the claim atomic: of several senders, one updates the row.
+	var claimedMember uuid.UUID
+	d, err := scanDelivery(r.pool.QueryRow(ctx, `
+		UPDATE trip_announcement_deliveries d
+		SET status = 'SENDING', error = NULL, attempted_at = $3
+		FROM trip_announcements a, members m
+		WHERE a.id = d.announcement_id AND m.id = d.member_id
+		  AND a.external_id = $1 AND m.external_id = $2
+		  AND (d.status IN ('PENDING', 'FAILED') OR (d.status = 'SENDING' AND d.attempted_at < $4))
+		RETURNING m.external_id, d.status, d.error, d.attempted_at, d.sent_to
+	`, aid, mid, at.UTC(), staleBefore.UTC()), &claimedMember)
+	if err == nil {
+		d.AnnouncementID = id
+		d.MemberID = memberID
+		return d, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return announcementrepo.Delivery{}, false, err
+	}
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM trip_announcement_deliveries d
+			JOIN trip_announcements a ON a.id = d.announcement_id
+			JOIN members m ON m.id = d.member_id
+			WHERE a.external_id = $1 AND m.external_id = $2
+		)
+	`, aid, mid).Scan(&exists); err != nil {
+		return announcementrepo.Delivery{}, false, err
+	}
+	if !exists {
+		return announcementrepo.Delivery{}, false, announcementrepo.ErrNotFound
+	}
+	return announcementrepo.Delivery{}, false, nil
+}
+
+// scanDelivery scans (member external_id, status, error, attempted_at, sent_to).
+func scanDelivery(row pgx.Row, memberID *uuid.UUID) (announcementrepo.Delivery, error) {
+	var status string
+	var errText *string
+	var attemptedAt *time.Time
+	var sentTo []string
+	if err := row.Scan(memberID, &status, &errText, &attemptedAt, &sentTo); err != nil {
+		return announcementrepo.Delivery{}, err
+	}
+	d := announcementrepo.Delivery{Status: announcementrepo.Status(status), SentTo: sentTo}
+	if errText != nil {
+		d.Error = *errText
+	}
+	if attemptedAt != nil {
+		v := attemptedAt.UTC()
+		d.AttemptedAt = &v
+	}
+	return d, nil
+}
+
+func (r *Repo) UpdateDelivery(ctx context.Context, d announcementrepo.Delivery) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	aid, err := uuid.Parse(string(d.AnnouncementID))
+	if err != nil {
+		return announcementrepo.ErrNotFound
+	}
+	mid, err := uuid.Parse(string(d.MemberID))
+	if err != nil {
+		return announcementrepo.ErrNotFound
+	}
+	var errText *string
+	if d.Error != "" {
+		errText = &d.Error
+	}
+	var attemptedAt *time.Time
+	if d.AttemptedAt != nil {
+		v := d.AttemptedAt.UTC()
+		attemptedAt = &v
+	}
+	sentTo := d.SentTo
+	if sentTo == nil {
+		sentTo = []string{}
+	}
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE trip_announcement_deliveries
+		SET status = $3, error = $4, attempted_at = $5, sent_to = $6
+		WHERE announcement_id = (SELECT id FROM trip_announcements WHERE external_id = $1)
+		  AND member_id = (SELECT id FROM members WHERE external_id = $2)
+	`, aid, mid, string(d.Status), errText, attemptedAt, sentTo)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return announcementrepo.ErrNotFound
+	}
+	return nil
+}
+
+func scanAnnouncement(row pgx.Row) (announcementrepo.Announcement, error) {
+	var id, tripID uuid.UUID
+	var authorID *uuid.UUID
+	var serviceAccount *string
+	var subject, body, audience string
+	var createdAt time.Time
+	if err := row.Scan(&id, &tripID, &authorID, &serviceAccount, &subject, &body, &audience, &createdAt); err != nil {
+		return announcementrepo.Announcement{}, err
+	}
+	a := announcementrepo.Announcement{
+		ID:        domain.TripAnnouncementID(id.String()),
+		TripID:    domain.TripID(tripID.String()),
+		Subject:   subject,
+		Body:      body,
+		Audience:  domain.AnnouncementAudience(audience),
+		CreatedAt: createdAt.UTC(),
+	}
+	if authorID != nil {
+		a.AuthorMemberID = domain.MemberID(authorID.String())
+	}
+	if serviceAccount != nil {
+		a.AuthorServiceAccount = *serviceAccount
+	}
+	return a, nil
+}
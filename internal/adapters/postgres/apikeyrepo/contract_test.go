@@ -0,0 +1,18 @@
+package apikeyrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	apikeyrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+)
+
+func TestContract_PostgresAPIKeyRepo(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+
+	contracttest.RunAPIKeyRepo(t, func(t *testing.T) (apikeyrepoport.Repository, func()) {
+		t.Helper()
+		return NewRepo(pool), nil
+	})
+}
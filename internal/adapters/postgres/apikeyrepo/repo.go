@@ -0,0 +1,266 @@
+package apikeyrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+)
+
+// Repo is a Postgres implementation of apikeyrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+const selectAPIKey = `
+	SELECT
+		external_id,
+		name,
+		prefix,
+		secret_hash,
+		scopes,
+		created_by,
+		created_at,
+		revoked_by,
+		revoked_at,
+		last_used_at
+	FROM api_keys
+`
+
+func (r *Repo) Create(ctx context.Context, k apikeyrepo.APIKey) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(k.ID))
+	if err != nil {
+		return fmt.Errorf("invalid api key id: %w", err)
+	}
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, s := range k.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO api_keys (external_id, name, prefix, secret_hash, scopes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, id, k.Name, k.Prefix, k.SecretHash, scopes, k.CreatedBy, k.CreatedAt.UTC())
+	if err != nil {
+		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+			return apikeyrepo.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.APIKeyID) (apikeyrepo.APIKey, error) {
+	if r.pool == nil {
+		return apikeyrepo.APIKey{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return apikeyrepo.APIKey{}, apikeyrepo.ErrNotFound
+	}
+	return scanAPIKey(r.pool.QueryRow(ctx, selectAPIKey+` WHERE external_id = $1`, uid))
+}
+
+func (r *Repo) GetByPrefix(ctx context.Context, prefix string) (apikeyrepo.APIKey, error) {
+	if r.pool == nil {
+		return apikeyrepo.APIKey{}, errors.New("nil postgres pool")
+	}
+	return scanAPIKey(r.pool.QueryRow(ctx, selectAPIKey+` WHERE prefix = $1`, prefix))
+}
+
+func (r *Repo) List(ctx context.Context) ([]apikeyrepo.APIKey, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	rows, err := r.pool.Query(ctx, selectAPIKey+` ORDER BY created_at ASC, external_id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]apikeyrepo.APIKey, 0)
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) Revoke(ctx context.Context, id domain.APIKeyID, revokedBy string, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return apikeyrepo.ErrNotFound
+	}
+	ct, err := r.pool.Exec(ctx, `
+		UPDATE api_keys
+		SET revoked_by = COALESCE(revoked_by, $2),
+		    revoked_at = COALESCE(revoked_at, $3)
+		WHERE external_id = $1
+	`, uid, revokedBy, at.UTC())
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return apikeyrepo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repo) RecordUsage(ctx context.Context, u apikeyrepo.Usage) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(u.KeyID))
+	if err != nil {
+		return apikeyrepo.ErrNotFound
+	}
+	usedAt := u.UsedAt.UTC()
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var keyID int64
+		err := tx.QueryRow(ctx, `
+			UPDATE api_keys
+			SET last_used_at = GREATEST(COALESCE(last_used_at, $2), $2)
+			WHERE external_id = $1
+			RETURNING id
+		`, uid, usedAt).Scan(&keyID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apikeyrepo.ErrNotFound
+			}
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO api_key_usage_daily (api_key_id, usage_date, method, path, client_ip, request_count, first_used_at, last_used_at)
+			VALUES ($1, $2, $3, $4, $5, 1, $6, $6)
+			ON CONFLICT (api_key_id, usage_date, method, path, client_ip) DO UPDATE SET
+				request_count = api_key_usage_daily.request_count + 1,
+				first_used_at = LEAST(api_key_usage_daily.first_used_at, EXCLUDED.first_used_at),
+				last_used_at = GREATEST(api_key_usage_daily.last_used_at, EXCLUDED.last_used_at)
+		`, keyID, apikeyrepo.UsageDay(usedAt), u.Method, u.Path, u.ClientIP, usedAt)
+		return err
+	})
+}
+
+func (r *Repo) ListUsage(ctx context.Context, id domain.APIKeyID, limit int) ([]domain.APIKeyUsage, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	uid, _ := uuid.Parse(string(id))
+	rows, err := r.pool.Query(ctx, `
+		SELECT u.usage_date, u.method, u.path, u.client_ip, u.request_count, u.first_used_at, u.last_used_at
+		FROM api_key_usage_daily u
+		JOIN api_keys k ON k.id = u.api_key_id
+		WHERE k.external_id = $1
+		ORDER BY u.last_used_at DESC, u.id DESC
+		LIMIT $2
+	`, uid, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.APIKeyUsage, 0)
+	for rows.Next() {
+		var count int64
+		u := domain.APIKeyUsage{KeyID: id}
+		if err := rows.Scan(&u.Day, &u.Method, &u.Path, &u.ClientIP, &count, &u.FirstUsedAt, &u.LastUsedAt); err != nil {
+			return nil, err
+		}
+		u.Day = apikeyrepo.UsageDay(u.Day)
+		u.RequestCount = int(count)
+		u.FirstUsedAt = u.FirstUsedAt.UTC()
+		u.LastUsedAt = u.LastUsedAt.UTC()
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) DeleteUsageBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	if r.pool == nil {
+		return 0, errors.New("nil postgres pool")
+	}
+	// Batch by ctid so large backlogs are purged in short transactions.
+	ct, err := r.pool.Exec(ctx, `
+		DELETE FROM api_key_usage_daily
+		WHERE ctid IN (
+			SELECT ctid
+			FROM api_key_usage_daily
+			WHERE usage_date < $1
+			LIMIT $2
+		)
+	`, apikeyrepo.UsageDay(before), limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(ct.RowsAffected()), nil
+}
+
+func scanAPIKey(row pgx.Row) (apikeyrepo.APIKey, error) {
+	var (
+		k         apikeyrepo.APIKey
+		id        uuid.UUID
+		scopes    []string
+		revokedAt *time.Time
+		lastUsed  *time.Time
+	)
+	if err := row.Scan(
+		&id,
+		&k.Name,
+		&k.Prefix,
+		&k.SecretHash,
+		&scopes,
+		&k.CreatedBy,
+		&k.CreatedAt,
+		&k.RevokedBy,
+		&revokedAt,
+		&lastUsed,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apikeyrepo.APIKey{}, apikeyrepo.ErrNotFound
+		}
+		return apikeyrepo.APIKey{}, err
+	}
+	k.ID = domain.APIKeyID(id.String())
+	k.CreatedAt = k.CreatedAt.UTC()
+	for _, s := range scopes {
+		k.Scopes = append(k.Scopes, domain.APIKeyScope(s))
+	}
+	if revokedAt != nil {
+		v := revokedAt.UTC()
+		k.RevokedAt = &v
+	}
+	if lastUsed != nil {
+		v := lastUsed.UTC()
+		k.LastUsedAt = &v
+	}
+	return k, nil
+}
@@ -0,0 +1,34 @@
+package attendancerepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/triprepo"
+	attendancerepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_PostgresAttendanceRepo(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+	issuer := "https://issuer.test"
+
+	contracttest.RunAttendanceRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memberrepo.NewRepo(pool, issuer), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return triprepo.NewRepo(pool), nil
+		},
+		func(t *testing.T) (attendancerepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(pool), nil
+		},
+	)
+}
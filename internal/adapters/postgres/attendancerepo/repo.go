@@ -0,0 +1,137 @@
+package attendancerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+)
+
+// Repo is a Postgres implementation of attendancerepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) Put(ctx context.Context, rec attendancerepo.Record) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(rec.TripID))
+	if err != nil {
+		return fmt.Errorf("invalid trip id: %w", err)
+	}
+	mid, err := uuid.Parse(string(rec.MemberID))
+	if err != nil {
+		return fmt.Errorf("invalid member id: %w", err)
+	}
+	var recordedBy *uuid.UUID
+	if rec.RecordedBy != "" {
+		id, err := uuid.Parse(string(rec.RecordedBy))
+		if err != nil {
+			return fmt.Errorf("invalid recorded-by member id: %w", err)
+		}
+		recordedBy = &id
+	}
+	var checkedInAt *time.Time
+	if rec.CheckedInAt != nil {
+		v := rec.CheckedInAt.UTC()
+		checkedInAt = &v
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO trip_attendance (trip_id, member_id, status, walk_up, checked_in_at, recorded_by_member_id, updated_at)
+		VALUES (
+			(SELECT id FROM trips WHERE external_id = $1),
+			(SELECT id FROM members WHERE external_id = $2),
+			$3,
+			$4,
+			$5,
+			(SELECT id FROM members WHERE external_id = $6),
+			$7
+		)
+		ON CONFLICT (trip_id, member_id) DO UPDATE
+		SET status = EXCLUDED.status,
+		    walk_up = EXCLUDED.walk_up,
+		    checked_in_at = EXCLUDED.checked_in_at,
+		    recorded_by_member_id = EXCLUDED.recorded_by_member_id,
+		    updated_at = EXCLUDED.updated_at
+	`, tid, mid, string(rec.Status), rec.WalkUp, checkedInAt, recordedBy, rec.UpdatedAt.UTC())
+	return err
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]attendancerepo.Record, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []attendancerepo.Record{}, nil
+	}
+	return r.list(ctx, `t.external_id = $1`, `m.external_id`, tid)
+}
+
+func (r *Repo) ListByMember(ctx context.Context, memberID domain.MemberID) ([]attendancerepo.Record, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return []attendancerepo.Record{}, nil
+	}
+	return r.list(ctx, `m.external_id = $1`, `t.external_id`, mid)
+}
+
+func (r *Repo) list(ctx context.Context, where, orderBy string, arg uuid.UUID) ([]attendancerepo.Record, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT t.external_id, m.external_id, a.status, a.walk_up, a.checked_in_at, recorder.external_id, a.updated_at
+		FROM trip_attendance a
+		JOIN trips t ON t.id = a.trip_id
+		JOIN members m ON m.id = a.member_id
+		LEFT JOIN members recorder ON recorder.id = a.recorded_by_member_id
+		WHERE `+where+`
+		ORDER BY `+orderBy+`
+	`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]attendancerepo.Record, 0)
+	for rows.Next() {
+		var tripID, memberID uuid.UUID
+		var status string
+		var walkUp bool
+		var checkedInAt *time.Time
+		var recordedBy *uuid.UUID
+		var updatedAt time.Time
+		if err := rows.Scan(&tripID, &memberID, &status, &walkUp, &checkedInAt, &recordedBy, &updatedAt); err != nil {
+			return nil, err
+		}
+		rec := attendancerepo.Record{
+			TripID:    domain.TripID(tripID.String()),
+			MemberID:  domain.MemberID(memberID.String()),
+			Status:    attendancerepo.Status(status),
+			WalkUp:    walkUp,
+			UpdatedAt: updatedAt.UTC(),
+		}
+		if checkedInAt != nil {
+			v := checkedInAt.UTC()
+			rec.CheckedInAt = &v
+		}
+		if recordedBy != nil {
+			rec.RecordedBy = domain.MemberID(recordedBy.String())
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,34 @@
+package commentrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/triprepo"
+	commentrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_PostgresCommentRepo(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+	issuer := "https://issuer.test"
+
+	contracttest.RunCommentRepo(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memberrepo.NewRepo(pool, issuer), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return triprepo.NewRepo(pool), nil
+		},
+		func(t *testing.T) (commentrepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(pool), nil
+		},
+	)
+}
@@ -0,0 +1,236 @@
+package commentrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+)
+
+// Repo is a Postgres implementation of commentrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) Create(ctx context.Context, c commentrepo.Comment) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(c.ID))
+	if err != nil {
+		return fmt.Errorf("invalid comment id: %w", err)
+	}
+	tid, err := uuid.Parse(string(c.TripID))
+	if err != nil {
+		return commentrepo.ErrNotFound
+	}
+	aid, err := uuid.Parse(string(c.AuthorMemberID))
+	if err != nil {
+		return commentrepo.ErrNotFound
+	}
+	var parent *uuid.UUID
+	if c.ParentID != nil {
+		pid, err := uuid.Parse(string(*c.ParentID))
+		if err != nil {
+			return commentrepo.ErrNotFound
+		}
+		parent = &pid
+	}
+
+	// Selecting from the referenced rows turns an unknown trip, author or parent into zero
+	// inserted rows rather than a NULL foreign key.
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO trip_comments (external_id, trip_id, author_member_id, parent_id, body, pinned, created_at)
+		SELECT $1::uuid, t.id, m.id, p.id, $5::text, $6::boolean, $7::timestamptz
+		FROM trips t
+		JOIN members m ON m.external_id = $3::uuid
+		LEFT JOIN trip_comments p ON p.external_id = $4::uuid
+		WHERE t.external_id = $2::uuid
+		  AND ($4::uuid IS NULL OR p.id IS NOT NULL)
+	`, id, tid, aid, parent, c.Body, c.Pinned, c.CreatedAt.UTC())
+	if pe, ok := postgres.AsPgError(err); ok {
+		switch pe.Code {
+		case postgres.UniqueViolationCode:
+			return commentrepo.ErrAlreadyExists
+		case postgres.ForeignKeyViolationCode:
+			return commentrepo.ErrNotFound
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return commentrepo.ErrNotFound
+	}
+	return nil
+}
+
+const selectComment = `
+	SELECT c.external_id, t.external_id, a.external_id, p.external_id, c.body, c.pinned,
+	       c.created_at, c.edited_at, c.deleted_at, d.external_id
+	FROM trip_comments c
+	JOIN trips t ON t.id = c.trip_id
+	JOIN members a ON a.id = c.author_member_id
+	LEFT JOIN trip_comments p ON p.id = c.parent_id
+	LEFT JOIN members d ON d.id = c.deleted_by_member_id
+`
+
+func (r *Repo) Get(ctx context.Context, id domain.TripCommentID) (commentrepo.Comment, error) {
+	if r.pool == nil {
+		return commentrepo.Comment{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return commentrepo.Comment{}, commentrepo.ErrNotFound
+	}
+	c, err := scanComment(r.pool.QueryRow(ctx, selectComment+` WHERE c.external_id = $1`, uid))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return commentrepo.Comment{}, commentrepo.ErrNotFound
+	}
+	return c, err
+}
+
+func (r *Repo) Update(ctx context.Context, c commentrepo.Comment) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(c.ID))
+	if err != nil {
+		return commentrepo.ErrNotFound
+	}
+	var deletedBy *uuid.UUID
+	if c.DeletedBy != "" {
+		v, err := uuid.Parse(string(c.DeletedBy))
+		if err != nil {
+			return fmt.Errorf("invalid deleted-by member id: %w", err)
+		}
+		deletedBy = &v
+	}
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE trip_comments
+		SET body = $2,
+		    pinned = $3,
+		    edited_at = $4,
+		    deleted_at = $5,
+		    deleted_by_member_id = (SELECT id FROM members WHERE external_id = $6)
+		WHERE external_id = $1
+	`, id, c.Body, c.Pinned, utcPtr(c.EditedAt), utcPtr(c.DeletedAt), deletedBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return commentrepo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID, after *commentrepo.Cursor, limit int) ([]commentrepo.Comment, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []commentrepo.Comment{}, nil
+	}
+	var afterAt *time.Time
+	var afterID *uuid.UUID
+	if after != nil {
+		at := after.CreatedAt.UTC()
+		afterAt = &at
+		// A cursor naming a non-UUID comment sorts before every real one.
+		id, _ := uuid.Parse(string(after.ID))
+		afterID = &id
+	}
+	var lim *int
+	if limit > 0 {
+		lim = &limit
+	}
+	return r.list(ctx, selectComment+`
+		WHERE t.external_id = $1
+		  AND ($2::timestamptz IS NULL OR (c.created_at, c.external_id) > ($2::timestamptz, $3::uuid))
+		ORDER BY c.created_at ASC, c.external_id ASC
+		LIMIT $4
+	`, tid, afterAt, afterID, lim)
+}
+
+func (r *Repo) ListPinned(ctx context.Context, tripID domain.TripID) ([]commentrepo.Comment, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []commentrepo.Comment{}, nil
+	}
+	return r.list(ctx, selectComment+`
+		WHERE t.external_id = $1 AND c.pinned
+		ORDER BY c.created_at ASC, c.external_id ASC
+	`, tid)
+}
+
+func (r *Repo) list(ctx context.Context, query string, args ...any) ([]commentrepo.Comment, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]commentrepo.Comment, 0)
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func scanComment(row pgx.Row) (commentrepo.Comment, error) {
+	var id, tripID, authorID uuid.UUID
+	var parentID, deletedBy *uuid.UUID
+	var body string
+	var pinned bool
+	var createdAt time.Time
+	var editedAt, deletedAt *time.Time
+	if err := row.Scan(&id, &tripID, &authorID, &parentID, &body, &pinned, &createdAt, &editedAt, &deletedAt, &deletedBy); err != nil {
+		return commentrepo.Comment{}, err
+	}
+	c := commentrepo.Comment{
+		ID:             domain.TripCommentID(id.String()),
+		TripID:         domain.TripID(tripID.String()),
+		AuthorMemberID: domain.MemberID(authorID.String()),
+		Body:           body,
+		Pinned:         pinned,
+		CreatedAt:      createdAt.UTC(),
+		EditedAt:       utcPtr(editedAt),
+		DeletedAt:      utcPtr(deletedAt),
+	}
+	if parentID != nil {
+		v := domain.TripCommentID(parentID.String())
+		c.ParentID = &v
+	}
+	if deletedBy != nil {
+		c.DeletedBy = domain.MemberID(deletedBy.String())
+	}
+	return c, nil
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
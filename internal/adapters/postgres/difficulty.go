@@ -0,0 +1,37 @@
+package postgres
+
+import "github.com/BennettSmith/ebo-planner-backend/internal/domain"
+
+// DifficultyForDB maps a structured difficulty to its columns. An unrated trip has a NULL
+// rating and an empty terrain array (never NULL).
+func DifficultyForDB(d *domain.Difficulty) (rating, minRating, maxRating *int, terrain []string) {
+	terrain = []string{}
+	if d == nil {
+		return nil, nil, nil, terrain
+	}
+	r := d.Rating
+	for _, tag := range d.Terrain {
+		terrain = append(terrain, string(tag))
+	}
+	return &r, d.MinRating, d.MaxRating, terrain
+}
+
+// DifficultyFromDB maps difficulty columns back; a NULL rating reads as unrated (nil).
+func DifficultyFromDB(rating, minRating, maxRating *int, terrain []string) *domain.Difficulty {
+	if rating == nil {
+		return nil
+	}
+	d := &domain.Difficulty{Rating: *rating}
+	if minRating != nil {
+		v := *minRating
+		d.MinRating = &v
+	}
+	if maxRating != nil {
+		v := *maxRating
+		d.MaxRating = &v
+	}
+	for _, tag := range terrain {
+		d.Terrain = append(d.Terrain, domain.TerrainTag(tag))
+	}
+	return d
+}
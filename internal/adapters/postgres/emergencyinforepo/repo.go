@@ -0,0 +1,170 @@
+package emergencyinforepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
+)
+
+// Repo is a Postgres implementation of emergencyinforepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) Get(ctx context.Context, memberID domain.MemberID) (emergencyinforepo.Sealed, error) {
+	if r.pool == nil {
+		return emergencyinforepo.Sealed{}, errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return emergencyinforepo.Sealed{}, emergencyinforepo.ErrNotFound
+	}
+	out := emergencyinforepo.Sealed{MemberID: memberID}
+	err = r.pool.QueryRow(ctx, `
+		SELECT e.key_id, e.nonce, e.ciphertext, e.updated_at
+		FROM member_emergency_info e
+		JOIN members m ON m.id = e.member_id
+		WHERE m.external_id = $1
+	`, mid).Scan(&out.KeyID, &out.Nonce, &out.Ciphertext, &out.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return emergencyinforepo.Sealed{}, emergencyinforepo.ErrNotFound
+		}
+		return emergencyinforepo.Sealed{}, err
+	}
+	out.UpdatedAt = out.UpdatedAt.UTC()
+	return out, nil
+}
+
+func (r *Repo) Put(ctx context.Context, s emergencyinforepo.Sealed) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(s.MemberID))
+	if err != nil {
+		return fmt.Errorf("invalid member id: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO member_emergency_info (member_id, key_id, nonce, ciphertext, updated_at)
+		VALUES ((SELECT id FROM members WHERE external_id = $1), $2, $3, $4, $5)
+		ON CONFLICT (member_id) DO UPDATE
+		SET key_id = EXCLUDED.key_id,
+		    nonce = EXCLUDED.nonce,
+		    ciphertext = EXCLUDED.ciphertext,
+		    updated_at = EXCLUDED.updated_at
+	`, mid, s.KeyID, s.Nonce, s.Ciphertext, s.UpdatedAt.UTC())
+	return err
+}
+
+func (r *Repo) Delete(ctx context.Context, memberID domain.MemberID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return emergencyinforepo.ErrNotFound
+	}
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM member_emergency_info
+		WHERE member_id = (SELECT id FROM members WHERE external_id = $1)
+	`, mid)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return emergencyinforepo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repo) AppendAccess(ctx context.Context, e emergencyinforepo.AccessEntry) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(e.MemberID))
+	if err != nil {
+		return fmt.Errorf("invalid member id: %w", err)
+	}
+	var by, trip *uuid.UUID
+	if e.AccessedBy != "" {
+		id, err := uuid.Parse(string(e.AccessedBy))
+		if err != nil {
+			return fmt.Errorf("invalid accessed-by member id: %w", err)
+		}
+		by = &id
+	}
+	if e.TripID != "" {
+		id, err := uuid.Parse(string(e.TripID))
+		if err != nil {
+			return fmt.Errorf("invalid trip id: %w", err)
+		}
+		trip = &id
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO member_emergency_info_access (member_id, accessed_by_member_id, trip_id, action, accessed_at)
+		VALUES (
+			(SELECT id FROM members WHERE external_id = $1),
+			(SELECT id FROM members WHERE external_id = $2),
+			(SELECT id FROM trips WHERE external_id = $3),
+			$4,
+			$5
+		)
+	`, mid, by, trip, string(e.Action), e.AccessedAt.UTC())
+	return err
+}
+
+func (r *Repo) ListAccess(ctx context.Context, memberID domain.MemberID) ([]emergencyinforepo.AccessEntry, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return []emergencyinforepo.AccessEntry{}, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT accessor.external_id, t.external_id, a.action, a.accessed_at
+		FROM member_emergency_info_access a
+		JOIN members m ON m.id = a.member_id
+		LEFT JOIN members accessor ON accessor.id = a.accessed_by_member_id
+		LEFT JOIN trips t ON t.id = a.trip_id
+		WHERE m.external_id = $1
+		ORDER BY a.accessed_at ASC, a.id ASC
+	`, mid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]emergencyinforepo.AccessEntry, 0)
+	for rows.Next() {
+		var by, trip *uuid.UUID
+		var action string
+		var at time.Time
+		if err := rows.Scan(&by, &trip, &action, &at); err != nil {
+			return nil, err
+		}
+		e := emergencyinforepo.AccessEntry{MemberID: memberID}
+		e.Action = domain.EmergencyInfoAction(action)
+		e.AccessedAt = at.UTC()
+		if by != nil {
+			e.AccessedBy = domain.MemberID(by.String())
+		}
+		if trip != nil {
+			e.TripID = domain.TripID(trip.String())
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
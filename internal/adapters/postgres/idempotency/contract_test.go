@@ -5,6 +5,7 @@ import (
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
 	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
 	idempotencyport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
 )
 
@@ -12,8 +13,8 @@ func TestContract_PostgresIdempotencyStore(t *testing.T) {
 	pool := testutil.OpenMigratedPool(t)
 	issuer := "https://issuer.test"
 
-	contracttest.RunIdempotencyStore(t, func(t *testing.T) (idempotencyport.Store, func()) {
+	contracttest.RunIdempotencyStore(t, func(t *testing.T, clk clock.Clock) (idempotencyport.Store, func()) {
 		t.Helper()
-		return NewStore(pool, issuer), nil
+		return NewStoreWithClock(pool, issuer, clk), nil
 	})
 }
@@ -2,24 +2,35 @@ package idempotency
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
-	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/idempotency"
 )
 
 // Store is a Postgres implementation of idempotency.Store.
+//
+// Keys are scoped by the caller's verified issuer (see authctx); defaultIssuer is used when the
+// request context carries none.
 type Store struct {
-	pool   *pgxpool.Pool
-	issuer string
+	pool          *pgxpool.Pool
+	defaultIssuer string
+	clk           clock.Clock
 }
 
 func NewStore(pool *pgxpool.Pool, jwtIssuer string) *Store {
-	return &Store{pool: pool, issuer: jwtIssuer}
+	return NewStoreWithClock(pool, jwtIssuer, nil)
+}
+
+// NewStoreWithClock is like NewStore but evaluates expiry against clk (nil means wall clock).
+func NewStoreWithClock(pool *pgxpool.Pool, jwtIssuer string, clk clock.Clock) *Store {
+	return &Store{pool: pool, defaultIssuer: jwtIssuer, clk: clk}
 }
 
 func (s *Store) Get(ctx context.Context, fp idempotency.Fingerprint) (idempotency.Record, bool, error) {
@@ -27,7 +38,7 @@ func (s *Store) Get(ctx context.Context, fp idempotency.Fingerprint) (idempotenc
 		return idempotency.Record{}, false, errors.New("nil postgres pool")
 	}
 	row := s.pool.QueryRow(ctx, `
-		SELECT status_code, content_type, body, created_at
+		SELECT status_code, content_type, headers, body, created_at, expires_at
 		FROM idempotency_keys
 		WHERE idempotency_key = $1
 		  AND subject_iss = $2
@@ -35,22 +46,15 @@ func (s *Store) Get(ctx context.Context, fp idempotency.Fingerprint) (idempotenc
 		  AND method = $4
 		  AND route = $5
 		  AND body_hash = $6
-	`,
-		string(fp.Key),
-		s.issuer,
-		string(fp.Subject),
-		fp.Method,
-		fp.Route,
-		fp.BodyHash,
-	)
-	var rec idempotency.Record
-	if err := row.Scan(&rec.StatusCode, &rec.ContentType, &rec.Body, &rec.CreatedAt); err != nil {
+		  AND (expires_at IS NULL OR expires_at > $7)
+	`, append(s.keyArgs(ctx, fp), s.now())...)
+	rec, err := scanRecord(row)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return idempotency.Record{}, false, nil
 		}
 		return idempotency.Record{}, false, err
 	}
-	rec.CreatedAt = rec.CreatedAt.UTC()
 	return rec, true, nil
 }
 
@@ -58,11 +62,11 @@ func (s *Store) Put(ctx context.Context, fp idempotency.Fingerprint, rec idempot
 	if s.pool == nil {
 		return errors.New("nil postgres pool")
 	}
-	createdAt := rec.CreatedAt
-	if createdAt.IsZero() {
-		createdAt = time.Now().UTC()
+	args, err := s.insertArgs(ctx, fp, rec)
+	if err != nil {
+		return err
 	}
-	_, err := s.pool.Exec(ctx, `
+	_, err = s.pool.Exec(ctx, `
 		INSERT INTO idempotency_keys (
 			idempotency_key,
 			subject_iss,
@@ -72,33 +76,212 @@ func (s *Store) Put(ctx context.Context, fp idempotency.Fingerprint, rec idempot
 			body_hash,
 			status_code,
 			content_type,
+			headers,
 			body,
-			created_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+			created_at,
+			expires_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
 		ON CONFLICT (idempotency_key, subject_iss, subject_sub, method, route, body_hash)
 		DO UPDATE SET
 			status_code = EXCLUDED.status_code,
 			content_type = EXCLUDED.content_type,
+			headers = EXCLUDED.headers,
 			body = EXCLUDED.body,
-			created_at = EXCLUDED.created_at
-	`,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+	`, args...)
+	return err
+}
+
+func (s *Store) Reserve(ctx context.Context, fp idempotency.Fingerprint, rec idempotency.Record) (idempotency.Record, bool, error) {
+	if s.pool == nil {
+		return idempotency.Record{}, false, errors.New("nil postgres pool")
+	}
+	args, err := s.insertArgs(ctx, fp, rec)
+	if err != nil {
+		return idempotency.Record{}, false, err
+	}
+
+	// The existing row may be deleted between the conflicting insert and the read; retry briefly.
+	for attempt := 0; attempt < 3; attempt++ {
+		ct, err := s.pool.Exec(ctx, `
+			INSERT INTO idempotency_keys (
+				idempotency_key,
+				subject_iss,
+				subject_sub,
+				method,
+				route,
+				body_hash,
+				status_code,
+				content_type,
+				headers,
+				body,
+				created_at,
+				expires_at
+			) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+			ON CONFLICT (idempotency_key, subject_iss, subject_sub, method, route, body_hash)
+			DO UPDATE SET
+				status_code = EXCLUDED.status_code,
+				content_type = EXCLUDED.content_type,
+				headers = EXCLUDED.headers,
+				body = EXCLUDED.body,
+				created_at = EXCLUDED.created_at,
+				expires_at = EXCLUDED.expires_at
+			-- Only an expired record may be replaced.
+			WHERE idempotency_keys.expires_at IS NOT NULL AND idempotency_keys.expires_at <= $13
+		`, append(args, s.now())...)
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		if ct.RowsAffected() == 1 {
+			return idempotency.Record{}, true, nil
+		}
+		existing, ok, err := s.Get(ctx, fp)
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		if ok {
+			return existing, false, nil
+		}
+	}
+	return idempotency.Record{}, false, errors.New("idempotency reserve: record contended")
+}
+
+func (s *Store) CompareAndSwap(ctx context.Context, fp idempotency.Fingerprint, createdAt time.Time, rec idempotency.Record) (bool, error) {
+	if s.pool == nil {
+		return false, errors.New("nil postgres pool")
+	}
+	args, err := s.insertArgs(ctx, fp, rec)
+	if err != nil {
+		return false, err
+	}
+	ct, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = $7,
+		    content_type = $8,
+		    headers = $9,
+		    body = $10,
+		    created_at = $11,
+		    expires_at = $12
+		WHERE idempotency_key = $1
+		  AND subject_iss = $2
+		  AND subject_sub = $3
+		  AND method = $4
+		  AND route = $5
+		  AND body_hash = $6
+		  AND created_at = $13
+		  AND (expires_at IS NULL OR expires_at > $14)
+	`, append(args, createdAt.UTC(), s.now())...)
+	if err != nil {
+		return false, err
+	}
+	return ct.RowsAffected() == 1, nil
+}
+
+func (s *Store) Delete(ctx context.Context, fp idempotency.Fingerprint) error {
+	if s.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	_, err := s.pool.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = $1
+		  AND subject_iss = $2
+		  AND subject_sub = $3
+		  AND method = $4
+		  AND route = $5
+		  AND body_hash = $6
+	`, s.keyArgs(ctx, fp)...)
+	return err
+}
+
+func (s *Store) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	if s.pool == nil {
+		return 0, errors.New("nil postgres pool")
+	}
+	// Batch by ctid so large backlogs are purged in short transactions.
+	ct, err := s.pool.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE ctid IN (
+			SELECT ctid
+			FROM idempotency_keys
+			WHERE expires_at IS NOT NULL AND expires_at <= $1
+			LIMIT $2
+		)
+	`, before.UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(ct.RowsAffected()), nil
+}
+
+func (s *Store) now() time.Time {
+	if s.clk == nil {
+		return time.Now().UTC()
+	}
+	return s.clk.Now().UTC()
+}
+
+func (s *Store) keyArgs(ctx context.Context, fp idempotency.Fingerprint) []any {
+	return []any{
 		string(fp.Key),
-		s.issuer,
+		authctx.IssuerOr(ctx, s.defaultIssuer),
 		string(fp.Subject),
 		fp.Method,
 		fp.Route,
 		fp.BodyHash,
+	}
+}
+
+func (s *Store) insertArgs(ctx context.Context, fp idempotency.Fingerprint, rec idempotency.Record) ([]any, error) {
+	createdAt := rec.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = s.now()
+	}
+	var expiresAt *time.Time
+	if !rec.ExpiresAt.IsZero() {
+		e := rec.ExpiresAt.UTC()
+		expiresAt = &e
+	}
+	header := rec.Header
+	if header == nil {
+		header = map[string][]string{}
+	}
+	headers, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	body := rec.Body
+	if body == nil {
+		body = []byte{}
+	}
+	return append(s.keyArgs(ctx, fp),
 		rec.StatusCode,
 		rec.ContentType,
-		rec.Body,
+		headers,
+		body,
 		createdAt.UTC(),
-	)
-	if err != nil {
-		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.ForeignKeyViolationCode {
-			// No FK constraints exist for subject-based idempotency in v1; treat as generic error.
-			return err
+		expiresAt,
+	), nil
+}
+
+func scanRecord(row pgx.Row) (idempotency.Record, error) {
+	var rec idempotency.Record
+	var headers []byte
+	var expiresAt *time.Time
+	if err := row.Scan(&rec.StatusCode, &rec.ContentType, &headers, &rec.Body, &rec.CreatedAt, &expiresAt); err != nil {
+		return idempotency.Record{}, err
+	}
+	if expiresAt != nil {
+		rec.ExpiresAt = expiresAt.UTC()
+	}
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &rec.Header); err != nil {
+			return idempotency.Record{}, err
 		}
-		return err
 	}
-	return nil
+	if len(rec.Header) == 0 {
+		rec.Header = nil
+	}
+	rec.CreatedAt = rec.CreatedAt.UTC()
+	return rec, nil
 }
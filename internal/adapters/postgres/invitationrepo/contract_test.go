@@ -0,0 +1,18 @@
+package invitationrepo
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	invitationrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+)
+
+func TestContract_PostgresInvitationRepo(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+
+	contracttest.RunInvitationRepo(t, func(t *testing.T) (invitationrepoport.Repository, func()) {
+		t.Helper()
+		return NewRepo(pool), nil
+	})
+}
@@ -0,0 +1,303 @@
+package invitationrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+)
+
+// Repo is a Postgres implementation of invitationrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+const selectInvitation = `
+	SELECT
+		external_id,
+		code_hash,
+		email,
+		max_uses,
+		use_count,
+		created_by_member_external_id,
+		created_by_admin,
+		created_at,
+		expires_at,
+		revoked_at
+	FROM invitations
+`
+
+func (r *Repo) Create(ctx context.Context, inv invitationrepo.Invitation) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(inv.ID))
+	if err != nil {
+		return fmt.Errorf("invalid invitation id: %w", err)
+	}
+	var createdBy *uuid.UUID
+	if inv.CreatedByMemberID != nil {
+		mid, err := uuid.Parse(string(*inv.CreatedByMemberID))
+		if err != nil {
+			return fmt.Errorf("invalid member id: %w", err)
+		}
+		createdBy = &mid
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO invitations (
+			external_id, code_hash, email, max_uses, use_count,
+			created_by_member_external_id, created_by_admin, created_at, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, id, inv.CodeHash, inv.Email, inv.MaxUses, inv.UseCount, createdBy, inv.CreatedByAdmin, inv.CreatedAt.UTC(), inv.ExpiresAt.UTC())
+	if err != nil {
+		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+			return invitationrepo.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.InvitationID) (invitationrepo.Invitation, error) {
+	if r.pool == nil {
+		return invitationrepo.Invitation{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return invitationrepo.Invitation{}, invitationrepo.ErrNotFound
+	}
+	return scanInvitation(r.pool.QueryRow(ctx, selectInvitation+` WHERE external_id = $1`, uid))
+}
+
+func (r *Repo) GetByCodeHash(ctx context.Context, codeHash string) (invitationrepo.Invitation, error) {
+	if r.pool == nil {
+		return invitationrepo.Invitation{}, errors.New("nil postgres pool")
+	}
+	return scanInvitation(r.pool.QueryRow(ctx, selectInvitation+` WHERE code_hash = $1`, codeHash))
+}
+
+func (r *Repo) List(ctx context.Context) ([]invitationrepo.Invitation, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	rows, err := r.pool.Query(ctx, selectInvitation+` ORDER BY created_at ASC, external_id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]invitationrepo.Invitation, 0)
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) Revoke(ctx context.Context, id domain.InvitationID, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return invitationrepo.ErrNotFound
+	}
+	ct, err := r.pool.Exec(ctx, `
+		UPDATE invitations
+		SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE external_id = $1
+	`, uid, at.UTC())
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return invitationrepo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repo) Redeem(ctx context.Context, id domain.InvitationID, memberID domain.MemberID, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return invitationrepo.ErrNotFound
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return fmt.Errorf("invalid member id: %w", err)
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			pk        int64
+			maxUses   int
+			useCount  int
+			expiresAt time.Time
+			revokedAt *time.Time
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT id, max_uses, use_count, expires_at, revoked_at
+			FROM invitations
+			WHERE external_id = $1
+			FOR UPDATE
+		`, uid).Scan(&pk, &maxUses, &useCount, &expiresAt, &revokedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return invitationrepo.ErrNotFound
+			}
+			return err
+		}
+		switch {
+		case revokedAt != nil:
+			return invitationrepo.ErrRevoked
+		case !at.Before(expiresAt):
+			return invitationrepo.ErrExpired
+		case useCount >= maxUses:
+			return invitationrepo.ErrExhausted
+		}
+		ct, err := tx.Exec(ctx, `
+			INSERT INTO invitation_redemptions (invitation_id, member_external_id, redeemed_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, pk, mid, at.UTC())
+		if err != nil {
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return invitationrepo.ErrAlreadyExists
+		}
+		_, err = tx.Exec(ctx, `UPDATE invitations SET use_count = use_count + 1 WHERE id = $1`, pk)
+		return err
+	})
+}
+
+func (r *Repo) ReleaseRedemption(ctx context.Context, id domain.InvitationID, memberID domain.MemberID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return invitationrepo.ErrNotFound
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return nil
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var pk int64
+		err := tx.QueryRow(ctx, `SELECT id FROM invitations WHERE external_id = $1 FOR UPDATE`, uid).Scan(&pk)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return invitationrepo.ErrNotFound
+			}
+			return err
+		}
+		ct, err := tx.Exec(ctx, `
+			DELETE FROM invitation_redemptions
+			WHERE invitation_id = $1 AND member_external_id = $2
+		`, pk, mid)
+		if err != nil {
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return nil
+		}
+		_, err = tx.Exec(ctx, `UPDATE invitations SET use_count = use_count - 1 WHERE id = $1`, pk)
+		return err
+	})
+}
+
+func (r *Repo) ListRedemptions(ctx context.Context, id domain.InvitationID) ([]domain.InvitationRedemption, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	uid, _ := uuid.Parse(string(id))
+	rows, err := r.pool.Query(ctx, `
+		SELECT r.member_external_id, r.redeemed_at
+		FROM invitation_redemptions r
+		JOIN invitations i ON i.id = r.invitation_id
+		WHERE i.external_id = $1
+		ORDER BY r.redeemed_at ASC, r.member_external_id ASC
+	`, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.InvitationRedemption, 0)
+	for rows.Next() {
+		var mid uuid.UUID
+		red := domain.InvitationRedemption{InvitationID: id}
+		if err := rows.Scan(&mid, &red.RedeemedAt); err != nil {
+			return nil, err
+		}
+		red.MemberID = domain.MemberID(mid.String())
+		red.RedeemedAt = red.RedeemedAt.UTC()
+		out = append(out, red)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scanInvitation(row pgx.Row) (invitationrepo.Invitation, error) {
+	var (
+		inv       invitationrepo.Invitation
+		id        uuid.UUID
+		createdBy *uuid.UUID
+		revokedAt *time.Time
+	)
+	if err := row.Scan(
+		&id,
+		&inv.CodeHash,
+		&inv.Email,
+		&inv.MaxUses,
+		&inv.UseCount,
+		&createdBy,
+		&inv.CreatedByAdmin,
+		&inv.CreatedAt,
+		&inv.ExpiresAt,
+		&revokedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invitationrepo.Invitation{}, invitationrepo.ErrNotFound
+		}
+		return invitationrepo.Invitation{}, err
+	}
+	inv.ID = domain.InvitationID(id.String())
+	inv.CreatedAt = inv.CreatedAt.UTC()
+	inv.ExpiresAt = inv.ExpiresAt.UTC()
+	if createdBy != nil {
+		v := domain.MemberID(createdBy.String())
+		inv.CreatedByMemberID = &v
+	}
+	if revokedAt != nil {
+		v := revokedAt.UTC()
+		inv.RevokedAt = &v
+	}
+	return inv, nil
+}
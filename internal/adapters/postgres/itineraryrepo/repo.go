@@ -0,0 +1,212 @@
+package itineraryrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
+)
+
+// Repo is a Postgres implementation of itineraryrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) PutDay(ctx context.Context, d itineraryrepo.Day) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(d.TripID))
+	if err != nil {
+		return fmt.Errorf("invalid trip id: %w", err)
+	}
+	date := dateForDB(d.Date)
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var tripPK int64
+		if err := tx.QueryRow(ctx, `SELECT id FROM trips WHERE external_id = $1`, tid).Scan(&tripPK); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("trip %s not found", d.TripID)
+			}
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO trip_itinerary_days (trip_id, day_date, title, notes, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (trip_id, day_date) DO UPDATE
+			SET title = EXCLUDED.title,
+			    notes = EXCLUDED.notes,
+			    updated_at = EXCLUDED.updated_at
+		`, tripPK, date, d.Title, d.Notes, d.UpdatedAt.UTC()); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM trip_itinerary_stops WHERE trip_id = $1 AND day_date = $2`, tripPK, date); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM trip_itinerary_segments WHERE trip_id = $1 AND day_date = $2`, tripPK, date); err != nil {
+			return err
+		}
+		for i, s := range d.Stops {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO trip_itinerary_stops (
+					trip_id, day_date, sort_order, type,
+					label, address, latitude, longitude, stop_time, notes
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			`, tripPK, date, i, string(s.Type),
+				s.Location.Label, s.Location.Address, s.Location.Latitude, s.Location.Longitude, s.Time, s.Notes); err != nil {
+				return err
+			}
+		}
+		for i, s := range d.Segments {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO trip_itinerary_segments (trip_id, day_date, sort_order, name, distance_miles, notes)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, tripPK, date, i, s.Name, s.DistanceMiles, s.Notes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]itineraryrepo.Day, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []itineraryrepo.Day{}, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT d.day_date, d.title, d.notes, d.updated_at
+		FROM trip_itinerary_days d
+		JOIN trips t ON t.id = d.trip_id
+		WHERE t.external_id = $1
+		ORDER BY d.day_date ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]itineraryrepo.Day, 0)
+	byDate := make(map[time.Time]int)
+	for rows.Next() {
+		var (
+			date      pgtype.Date
+			updatedAt time.Time
+			d         = itineraryrepo.Day{TripID: tripID}
+		)
+		if err := rows.Scan(&date, &d.Title, &d.Notes, &updatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		d.Date = date.Time.UTC()
+		d.UpdatedAt = updatedAt.UTC()
+		byDate[d.Date] = len(out)
+		out = append(out, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return out, nil
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT s.day_date, s.type, s.label, s.address, s.latitude, s.longitude, s.stop_time, s.notes
+		FROM trip_itinerary_stops s
+		JOIN trips t ON t.id = s.trip_id
+		WHERE t.external_id = $1
+		ORDER BY s.day_date ASC, s.sort_order ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			date pgtype.Date
+			typ  string
+			s    domain.ItineraryStop
+		)
+		if err := rows.Scan(&date, &typ, &s.Location.Label, &s.Location.Address, &s.Location.Latitude, &s.Location.Longitude, &s.Time, &s.Notes); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		s.Type = domain.ItineraryStopType(typ)
+		if i, ok := byDate[date.Time.UTC()]; ok {
+			out[i].Stops = append(out[i].Stops, s)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT s.day_date, s.name, s.distance_miles, s.notes
+		FROM trip_itinerary_segments s
+		JOIN trips t ON t.id = s.trip_id
+		WHERE t.external_id = $1
+		ORDER BY s.day_date ASC, s.sort_order ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			date pgtype.Date
+			s    domain.RouteSegment
+		)
+		if err := rows.Scan(&date, &s.Name, &s.DistanceMiles, &s.Notes); err != nil {
+			return nil, err
+		}
+		if i, ok := byDate[date.Time.UTC()]; ok {
+			out[i].Segments = append(out[i].Segments, s)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) DeleteDay(ctx context.Context, tripID domain.TripID, date time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return itineraryrepo.ErrDayNotFound
+	}
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM trip_itinerary_days d
+		USING trips t
+		WHERE t.id = d.trip_id AND t.external_id = $1 AND d.day_date = $2
+	`, tid, dateForDB(date))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return itineraryrepo.ErrDayNotFound
+	}
+	return nil
+}
+
+func dateForDB(t time.Time) pgtype.Date {
+	u := t.UTC()
+	return pgtype.Date{Time: time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
@@ -14,17 +14,25 @@ import (
 
 	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
 )
 
 // Repo is a Postgres implementation of memberrepo.Repository.
+//
+// Subjects are bound per issuer: the caller's verified issuer is read from the request
+// context (see authctx), falling back to defaultIssuer when the context carries none.
 type Repo struct {
-	pool   *pgxpool.Pool
-	issuer string
+	pool          *pgxpool.Pool
+	defaultIssuer string
 }
 
 func NewRepo(pool *pgxpool.Pool, jwtIssuer string) *Repo {
-	return &Repo{pool: pool, issuer: jwtIssuer}
+	return &Repo{pool: pool, defaultIssuer: jwtIssuer}
+}
+
+func (r *Repo) issuer(ctx context.Context) string {
+	return authctx.IssuerOr(ctx, r.defaultIssuer)
 }
 
 func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
@@ -45,17 +53,21 @@ func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
 				display_name,
 				email,
 				group_alias_email,
+				email_verified_at,
+				group_alias_email_verified_at,
 				is_active,
 				created_at,
 				updated_at
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		`,
 			id,
-			r.issuer,
+			r.issuer(ctx),
 			string(m.Subject),
 			m.DisplayName,
 			m.Email,
 			m.GroupAliasEmail,
+			utcPtr(m.EmailVerifiedAt),
+			utcPtr(m.GroupAliasEmailVerifiedAt),
 			m.IsActive,
 			m.CreatedAt.UTC(),
 			m.UpdatedAt.UTC(),
@@ -64,7 +76,7 @@ func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
 			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
 				// Determine which unique constraint was violated.
 				switch pe.ConstraintName {
-				case "members_subject_unique":
+				case "members_subject_unique", "member_identities_subject_unique":
 					return memberrepo.ErrSubjectAlreadyBound
 				case "members_external_id_unique":
 					return memberrepo.ErrAlreadyExists
@@ -76,8 +88,19 @@ func (r *Repo) Create(ctx context.Context, m memberrepo.Member) error {
 			return err
 		}
 
+		_, err = tx.Exec(ctx, `
+			INSERT INTO member_identities (member_id, subject_iss, subject_sub, linked_at)
+			VALUES ((SELECT id FROM members WHERE external_id = $1), $2, $3, $4)
+		`, id, r.issuer(ctx), string(m.Subject), m.CreatedAt.UTC())
+		if err != nil {
+			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+				return memberrepo.ErrSubjectAlreadyBound
+			}
+			return err
+		}
+
 		if m.VehicleProfile != nil {
-			if err := upsertVehicleProfile(ctx, tx, id, m.VehicleProfile); err != nil {
+			if err := upsertVehicleProfile(ctx, tx, id, m.VehicleProfile, m.CreatedAt); err != nil {
 				return err
 			}
 		}
@@ -111,14 +134,18 @@ func (r *Repo) Update(ctx context.Context, m memberrepo.Member) error {
 			SET display_name = $2,
 			    email = $3,
 			    group_alias_email = $4,
-			    is_active = $5,
-			    updated_at = $6
+			    email_verified_at = $5,
+			    group_alias_email_verified_at = $6,
+			    is_active = $7,
+			    updated_at = $8
 			WHERE external_id = $1
 		`,
 			id,
 			m.DisplayName,
 			m.Email,
 			m.GroupAliasEmail,
+			utcPtr(m.EmailVerifiedAt),
+			utcPtr(m.GroupAliasEmailVerifiedAt),
 			m.IsActive,
 			m.UpdatedAt.UTC(),
 		)
@@ -129,21 +156,58 @@ func (r *Repo) Update(ctx context.Context, m memberrepo.Member) error {
 			return memberrepo.ErrNotFound
 		}
 
+		// A nil profile leaves the garage untouched; vehicles are managed through the garage methods.
 		if m.VehicleProfile != nil {
-			if err := upsertVehicleProfile(ctx, tx, id, m.VehicleProfile); err != nil {
+			if err := upsertVehicleProfile(ctx, tx, id, m.VehicleProfile, m.UpdatedAt); err != nil {
 				return err
 			}
-		} else {
-			// Remove profile if unset.
-			_, _ = tx.Exec(ctx, `
-				DELETE FROM member_vehicle_profiles
-				WHERE member_id = (SELECT id FROM members WHERE external_id = $1)
-			`, id)
 		}
 		return nil
 	})
 }
 
+func (r *Repo) MarkEmailVerified(ctx context.Context, id domain.MemberID, field memberrepo.EmailField, addr string, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+	var q string
+	switch field {
+	case memberrepo.EmailFieldPrimary:
+		q = `
+			UPDATE members
+			SET email_verified_at = COALESCE(email_verified_at, $3)
+			WHERE external_id = $1 AND lower(email) = lower($2)
+		`
+	case memberrepo.EmailFieldGroupAlias:
+		q = `
+			UPDATE members
+			SET group_alias_email_verified_at = COALESCE(group_alias_email_verified_at, $3)
+			WHERE external_id = $1 AND lower(group_alias_email) = lower($2)
+		`
+	default:
+		return fmt.Errorf("unknown email field %q", field)
+	}
+	ct, err := r.pool.Exec(ctx, q, uid, addr, at.UTC())
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() > 0 {
+		return nil
+	}
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM members WHERE external_id = $1)`, uid).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return memberrepo.ErrNotFound
+	}
+	return memberrepo.ErrEmailChanged
+}
+
 func (r *Repo) GetByID(ctx context.Context, id domain.MemberID) (memberrepo.Member, error) {
 	if r.pool == nil {
 		return memberrepo.Member{}, errors.New("nil postgres pool")
@@ -166,6 +230,8 @@ func (r *Repo) GetBySubject(ctx context.Context, subject domain.SubjectID) (memb
 			m.display_name,
 			m.email,
 			m.group_alias_email,
+			m.email_verified_at,
+			m.group_alias_email_verified_at,
 			m.is_active,
 			m.created_at,
 			m.updated_at,
@@ -176,15 +242,139 @@ func (r *Repo) GetBySubject(ctx context.Context, subject domain.SubjectID) (memb
 			v.fuel_range,
 			v.recovery_gear,
 			v.ham_radio_call_sign,
-			v.notes
-		FROM members m
-		LEFT JOIN member_vehicle_profiles v ON v.member_id = m.id
-		WHERE m.subject_iss = $1 AND m.subject_sub = $2
-	`, r.issuer, string(subject))
+			v.notes,
+			v.id IS NOT NULL
+		FROM member_identities i
+		JOIN members m ON m.id = i.member_id
+		LEFT JOIN member_vehicles v ON v.member_id = m.id AND v.is_default
+		WHERE i.subject_iss = $1 AND i.subject_sub = $2
+	`, r.issuer(ctx), string(subject))
 
 	return scanMember(row)
 }
 
+func (r *Repo) LinkIdentity(ctx context.Context, id domain.MemberID, identity domain.MemberIdentity) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var memberPK int64
+		if err := tx.QueryRow(ctx, `SELECT id FROM members WHERE external_id = $1`, uid).Scan(&memberPK); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return memberrepo.ErrNotFound
+			}
+			return err
+		}
+
+		var boundTo int64
+		err := tx.QueryRow(ctx, `
+			INSERT INTO member_identities (member_id, subject_iss, subject_sub, linked_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (subject_iss, subject_sub) DO UPDATE
+			SET subject_iss = member_identities.subject_iss
+			RETURNING member_id
+		`, memberPK, identity.Issuer, string(identity.Subject), identity.LinkedAt.UTC()).Scan(&boundTo)
+		if err != nil {
+			return err
+		}
+		if boundTo != memberPK {
+			return memberrepo.ErrSubjectAlreadyBound
+		}
+		return nil
+	})
+}
+
+func (r *Repo) UnlinkIdentity(ctx context.Context, id domain.MemberID, issuer string, subject domain.SubjectID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var memberPK int64
+		// Lock the member row so concurrent unlinks cannot remove the last two identities at once.
+		if err := tx.QueryRow(ctx, `SELECT id FROM members WHERE external_id = $1 FOR UPDATE`, uid).Scan(&memberPK); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return memberrepo.ErrNotFound
+			}
+			return err
+		}
+
+		var total int
+		var found bool
+		if err := tx.QueryRow(ctx, `
+			SELECT
+				count(*),
+				coalesce(bool_or(subject_iss = $2 AND subject_sub = $3), false)
+			FROM member_identities
+			WHERE member_id = $1
+		`, memberPK, issuer, string(subject)).Scan(&total, &found); err != nil {
+			return err
+		}
+		if !found {
+			return memberrepo.ErrIdentityNotFound
+		}
+		if total <= 1 {
+			return memberrepo.ErrLastIdentity
+		}
+
+		_, err := tx.Exec(ctx, `
+			DELETE FROM member_identities
+			WHERE member_id = $1 AND subject_iss = $2 AND subject_sub = $3
+		`, memberPK, issuer, string(subject))
+		return err
+	})
+}
+
+func (r *Repo) ListIdentities(ctx context.Context, id domain.MemberID) ([]domain.MemberIdentity, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return nil, memberrepo.ErrNotFound
+	}
+	if _, err := getMemberByExternalID(ctx, r.pool, uid); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT i.subject_iss, i.subject_sub, i.linked_at
+		FROM member_identities i
+		JOIN members m ON m.id = i.member_id
+		WHERE m.external_id = $1
+		ORDER BY i.linked_at ASC, i.id ASC
+	`, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.MemberIdentity, 0)
+	for rows.Next() {
+		var mi domain.MemberIdentity
+		var sub string
+		if err := rows.Scan(&mi.Issuer, &sub, &mi.LinkedAt); err != nil {
+			return nil, err
+		}
+		mi.Subject = domain.SubjectID(sub)
+		mi.LinkedAt = mi.LinkedAt.UTC()
+		out = append(out, mi)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (r *Repo) List(ctx context.Context, includeInactive bool) ([]memberrepo.Member, error) {
 	if r.pool == nil {
 		return nil, errors.New("nil postgres pool")
@@ -202,6 +392,8 @@ func (r *Repo) List(ctx context.Context, includeInactive bool) ([]memberrepo.Mem
 			m.display_name,
 			m.email,
 			m.group_alias_email,
+			m.email_verified_at,
+			m.group_alias_email_verified_at,
 			m.is_active,
 			m.created_at,
 			m.updated_at,
@@ -212,9 +404,10 @@ func (r *Repo) List(ctx context.Context, includeInactive bool) ([]memberrepo.Mem
 			v.fuel_range,
 			v.recovery_gear,
 			v.ham_radio_call_sign,
-			v.notes
+			v.notes,
+			v.id IS NOT NULL
 		FROM members m
-		LEFT JOIN member_vehicle_profiles v ON v.member_id = m.id
+		LEFT JOIN member_vehicles v ON v.member_id = m.id AND v.is_default
 		`+where+`
 		ORDER BY lower(m.display_name) ASC, m.external_id ASC
 	`, args...)
@@ -254,6 +447,8 @@ func (r *Repo) SearchActiveByDisplayName(ctx context.Context, query string, limi
 			m.display_name,
 			m.email,
 			m.group_alias_email,
+			m.email_verified_at,
+			m.group_alias_email_verified_at,
 			m.is_active,
 			m.created_at,
 			m.updated_at,
@@ -264,9 +459,10 @@ func (r *Repo) SearchActiveByDisplayName(ctx context.Context, query string, limi
 			v.fuel_range,
 			v.recovery_gear,
 			v.ham_radio_call_sign,
-			v.notes
+			v.notes,
+			v.id IS NOT NULL
 		FROM members m
-		LEFT JOIN member_vehicle_profiles v ON v.member_id = m.id
+		LEFT JOIN member_vehicles v ON v.member_id = m.id AND v.is_default
 		WHERE m.is_active = true
 	`)
 	args := make([]any, 0, len(qTokens)+1)
@@ -335,6 +531,8 @@ func scanMember(row interface {
 		displayName     string
 		email           string
 		groupAliasEmail *string
+		emailVerifiedAt *time.Time
+		aliasVerifiedAt *time.Time
 		isActive        bool
 		createdAt       time.Time
 		updatedAt       time.Time
@@ -347,6 +545,7 @@ func scanMember(row interface {
 		recoveryGear     *string
 		hamRadioCallSign *string
 		notes            *string
+		hasVehicle       bool
 	)
 	if err := row.Scan(
 		&externalID,
@@ -354,6 +553,8 @@ func scanMember(row interface {
 		&displayName,
 		&email,
 		&groupAliasEmail,
+		&emailVerifiedAt,
+		&aliasVerifiedAt,
 		&isActive,
 		&createdAt,
 		&updatedAt,
@@ -365,6 +566,7 @@ func scanMember(row interface {
 		&recoveryGear,
 		&hamRadioCallSign,
 		&notes,
+		&hasVehicle,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return memberrepo.Member{}, memberrepo.ErrNotFound
@@ -372,7 +574,7 @@ func scanMember(row interface {
 		return memberrepo.Member{}, err
 	}
 	var vp *domain.VehicleProfile
-	if make != nil || model != nil || tireSize != nil || liftLockers != nil || fuelRange != nil || recoveryGear != nil || hamRadioCallSign != nil || notes != nil {
+	if hasVehicle {
 		vp = &domain.VehicleProfile{
 			Make:             cloneStringPtr(make),
 			Model:            cloneStringPtr(model),
@@ -394,9 +596,20 @@ func scanMember(row interface {
 		IsActive:        isActive,
 		CreatedAt:       createdAt.UTC(),
 		UpdatedAt:       updatedAt.UTC(),
+
+		EmailVerifiedAt:           utcPtr(emailVerifiedAt),
+		GroupAliasEmailVerifiedAt: utcPtr(aliasVerifiedAt),
 	}, nil
 }
 
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
+
 func cloneStringPtr(p *string) *string {
 	if p == nil {
 		return nil
@@ -415,6 +628,8 @@ func getMemberByExternalID(ctx context.Context, q interface {
 			m.display_name,
 			m.email,
 			m.group_alias_email,
+			m.email_verified_at,
+			m.group_alias_email_verified_at,
 			m.is_active,
 			m.created_at,
 			m.updated_at,
@@ -425,18 +640,53 @@ func getMemberByExternalID(ctx context.Context, q interface {
 			v.fuel_range,
 			v.recovery_gear,
 			v.ham_radio_call_sign,
-			v.notes
+			v.notes,
+			v.id IS NOT NULL
 		FROM members m
-		LEFT JOIN member_vehicle_profiles v ON v.member_id = m.id
+		LEFT JOIN member_vehicles v ON v.member_id = m.id AND v.is_default
 		WHERE m.external_id = $1
 	`, id)
 	return scanMember(row)
 }
 
-func upsertVehicleProfile(ctx context.Context, tx pgx.Tx, memberExternalID uuid.UUID, vp *domain.VehicleProfile) error {
-	_, err := tx.Exec(ctx, `
-		INSERT INTO member_vehicle_profiles (
+// upsertVehicleProfile writes vp to the member's default vehicle, creating it when the
+// garage is empty.
+func upsertVehicleProfile(ctx context.Context, tx pgx.Tx, memberExternalID uuid.UUID, vp *domain.VehicleProfile, at time.Time) error {
+	ct, err := tx.Exec(ctx, `
+		UPDATE member_vehicles
+		SET make = $2,
+		    model = $3,
+		    tire_size = $4,
+		    lift_lockers = $5,
+		    fuel_range = $6,
+		    recovery_gear = $7,
+		    ham_radio_call_sign = $8,
+		    notes = $9,
+		    updated_at = $10
+		WHERE member_id = (SELECT id FROM members WHERE external_id = $1) AND is_default
+	`,
+		memberExternalID,
+		vp.Make,
+		vp.Model,
+		vp.TireSize,
+		vp.LiftLockers,
+		vp.FuelRange,
+		vp.RecoveryGear,
+		vp.HamRadioCallSign,
+		vp.Notes,
+		at.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() > 0 {
+		return nil
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO member_vehicles (
 			member_id,
+			name,
+			is_default,
 			make,
 			model,
 			tire_size,
@@ -445,25 +695,17 @@ func upsertVehicleProfile(ctx context.Context, tx pgx.Tx, memberExternalID uuid.
 			recovery_gear,
 			ham_radio_call_sign,
 			notes,
+			created_at,
 			updated_at
 		)
 		VALUES (
 			(SELECT id FROM members WHERE external_id = $1),
-			$2, $3, $4, $5, $6, $7, $8, $9,
-			now()
+			$2, true, $3, $4, $5, $6, $7, $8, $9, $10,
+			$11, $11
 		)
-		ON CONFLICT (member_id) DO UPDATE SET
-			make = EXCLUDED.make,
-			model = EXCLUDED.model,
-			tire_size = EXCLUDED.tire_size,
-			lift_lockers = EXCLUDED.lift_lockers,
-			fuel_range = EXCLUDED.fuel_range,
-			recovery_gear = EXCLUDED.recovery_gear,
-			ham_radio_call_sign = EXCLUDED.ham_radio_call_sign,
-			notes = EXCLUDED.notes,
-			updated_at = now()
 	`,
 		memberExternalID,
+		domain.DefaultVehicleName,
 		vp.Make,
 		vp.Model,
 		vp.TireSize,
@@ -472,6 +714,7 @@ func upsertVehicleProfile(ctx context.Context, tx pgx.Tx, memberExternalID uuid.
 		vp.RecoveryGear,
 		vp.HamRadioCallSign,
 		vp.Notes,
+		at.UTC(),
 	)
 	return err
 }
@@ -0,0 +1,388 @@
+package memberrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+const vehicleColumns = `
+	v.external_id,
+	v.name,
+	v.is_default,
+	v.make,
+	v.model,
+	v.tire_size,
+	v.lift_lockers,
+	v.fuel_range,
+	v.recovery_gear,
+	v.ham_radio_call_sign,
+	v.notes,
+	v.tire_size_inches,
+	v.has_lockers,
+	v.recovery_gear_items,
+	v.ham_licensed,
+	v.created_at,
+	v.updated_at
+`
+
+func (r *Repo) ListVehicles(ctx context.Context, id domain.MemberID) ([]domain.Vehicle, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return nil, memberrepo.ErrNotFound
+	}
+	if _, err := memberPK(ctx, r.pool, uid, false); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+vehicleColumns+`
+		FROM member_vehicles v
+		JOIN members m ON m.id = v.member_id
+		WHERE m.external_id = $1
+		ORDER BY v.is_default DESC, v.created_at ASC, v.id ASC
+	`, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.Vehicle, 0)
+	for rows.Next() {
+		v, err := scanVehicle(rows, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) GetVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) (domain.Vehicle, error) {
+	if r.pool == nil {
+		return domain.Vehicle{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return domain.Vehicle{}, memberrepo.ErrNotFound
+	}
+	if _, err := memberPK(ctx, r.pool, uid, false); err != nil {
+		return domain.Vehicle{}, err
+	}
+	vid, err := uuid.Parse(string(vehicleID))
+	if err != nil {
+		return domain.Vehicle{}, memberrepo.ErrVehicleNotFound
+	}
+
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+vehicleColumns+`
+		FROM member_vehicles v
+		JOIN members m ON m.id = v.member_id
+		WHERE m.external_id = $1 AND v.external_id = $2
+	`, uid, vid)
+	v, err := scanVehicle(row, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Vehicle{}, memberrepo.ErrVehicleNotFound
+	}
+	return v, err
+}
+
+func (r *Repo) CreateVehicle(ctx context.Context, v domain.Vehicle) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(v.MemberID))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+	vid, err := uuid.Parse(string(v.ID))
+	if err != nil {
+		return fmt.Errorf("invalid vehicle id: %w", err)
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		// Lock the member row so concurrent creates agree on whether this is the first vehicle.
+		pk, err := memberPK(ctx, tx, uid, true)
+		if err != nil {
+			return err
+		}
+		var hasAny bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM member_vehicles WHERE member_id = $1)`, pk).Scan(&hasAny); err != nil {
+			return err
+		}
+		isDefault := v.IsDefault || !hasAny
+		if isDefault {
+			if _, err := tx.Exec(ctx, `UPDATE member_vehicles SET is_default = false WHERE member_id = $1 AND is_default`, pk); err != nil {
+				return err
+			}
+		}
+
+		p := v.Profile
+		_, err = tx.Exec(ctx, `
+			INSERT INTO member_vehicles (
+				external_id,
+				member_id,
+				name,
+				is_default,
+				make,
+				model,
+				tire_size,
+				lift_lockers,
+				fuel_range,
+				recovery_gear,
+				ham_radio_call_sign,
+				notes,
+				tire_size_inches,
+				has_lockers,
+				recovery_gear_items,
+				ham_licensed,
+				created_at,
+				updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		`,
+			vid,
+			pk,
+			v.Name,
+			isDefault,
+			p.Make,
+			p.Model,
+			p.TireSize,
+			p.LiftLockers,
+			p.FuelRange,
+			p.RecoveryGear,
+			p.HamRadioCallSign,
+			p.Notes,
+			v.Specs.TireSizeInches,
+			v.Specs.HasLockers,
+			postgres.RecoveryGearForDB(v.Specs.RecoveryGear),
+			v.Specs.HamLicensed,
+			v.CreatedAt.UTC(),
+			v.UpdatedAt.UTC(),
+		)
+		if err != nil {
+			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode && pe.ConstraintName == "member_vehicles_external_id_unique" {
+				return memberrepo.ErrAlreadyExists
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *Repo) UpdateVehicle(ctx context.Context, v domain.Vehicle) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(v.MemberID))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+	if _, err := memberPK(ctx, r.pool, uid, false); err != nil {
+		return err
+	}
+	vid, err := uuid.Parse(string(v.ID))
+	if err != nil {
+		return memberrepo.ErrVehicleNotFound
+	}
+
+	p := v.Profile
+	ct, err := r.pool.Exec(ctx, `
+		UPDATE member_vehicles
+		SET name = $3,
+		    make = $4,
+		    model = $5,
+		    tire_size = $6,
+		    lift_lockers = $7,
+		    fuel_range = $8,
+		    recovery_gear = $9,
+		    ham_radio_call_sign = $10,
+		    notes = $11,
+		    tire_size_inches = $12,
+		    has_lockers = $13,
+		    recovery_gear_items = $14,
+		    ham_licensed = $15,
+		    updated_at = $16
+		WHERE external_id = $2
+		  AND member_id = (SELECT id FROM members WHERE external_id = $1)
+	`,
+		uid,
+		vid,
+		v.Name,
+		p.Make,
+		p.Model,
+		p.TireSize,
+		p.LiftLockers,
+		p.FuelRange,
+		p.RecoveryGear,
+		p.HamRadioCallSign,
+		p.Notes,
+		v.Specs.TireSizeInches,
+		v.Specs.HasLockers,
+		postgres.RecoveryGearForDB(v.Specs.RecoveryGear),
+		v.Specs.HamLicensed,
+		v.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return memberrepo.ErrVehicleNotFound
+	}
+	return nil
+}
+
+func (r *Repo) DeleteVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		pk, err := memberPK(ctx, tx, uid, true)
+		if err != nil {
+			return err
+		}
+		vid, err := uuid.Parse(string(vehicleID))
+		if err != nil {
+			return memberrepo.ErrVehicleNotFound
+		}
+
+		var wasDefault bool
+		err = tx.QueryRow(ctx, `
+			DELETE FROM member_vehicles
+			WHERE member_id = $1 AND external_id = $2
+			RETURNING is_default
+		`, pk, vid).Scan(&wasDefault)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return memberrepo.ErrVehicleNotFound
+			}
+			return err
+		}
+		if !wasDefault {
+			return nil
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE member_vehicles
+			SET is_default = true
+			WHERE id = (
+				SELECT id FROM member_vehicles
+				WHERE member_id = $1
+				ORDER BY created_at ASC, id ASC
+				LIMIT 1
+			)
+		`, pk)
+		return err
+	})
+}
+
+func (r *Repo) SetDefaultVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return memberrepo.ErrNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		pk, err := memberPK(ctx, tx, uid, true)
+		if err != nil {
+			return err
+		}
+		vid, err := uuid.Parse(string(vehicleID))
+		if err != nil {
+			return memberrepo.ErrVehicleNotFound
+		}
+
+		var exists bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM member_vehicles WHERE member_id = $1 AND external_id = $2)
+		`, pk, vid).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return memberrepo.ErrVehicleNotFound
+		}
+		// Clear first: the partial unique index allows only one default at a time.
+		if _, err := tx.Exec(ctx, `UPDATE member_vehicles SET is_default = false WHERE member_id = $1 AND is_default`, pk); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE member_vehicles SET is_default = true WHERE member_id = $1 AND external_id = $2`, pk, vid)
+		return err
+	})
+}
+
+// memberPK resolves a member's internal id, optionally locking the row for the transaction.
+func memberPK(ctx context.Context, q interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}, id uuid.UUID, forUpdate bool) (int64, error) {
+	sql := `SELECT id FROM members WHERE external_id = $1`
+	if forUpdate {
+		sql += ` FOR UPDATE`
+	}
+	var pk int64
+	if err := q.QueryRow(ctx, sql, id).Scan(&pk); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, memberrepo.ErrNotFound
+		}
+		return 0, err
+	}
+	return pk, nil
+}
+
+func scanVehicle(row interface {
+	Scan(dest ...any) error
+}, memberID domain.MemberID) (domain.Vehicle, error) {
+	var (
+		externalID uuid.UUID
+		v          domain.Vehicle
+		gear       []string
+		createdAt  time.Time
+		updatedAt  time.Time
+	)
+	if err := row.Scan(
+		&externalID,
+		&v.Name,
+		&v.IsDefault,
+		&v.Profile.Make,
+		&v.Profile.Model,
+		&v.Profile.TireSize,
+		&v.Profile.LiftLockers,
+		&v.Profile.FuelRange,
+		&v.Profile.RecoveryGear,
+		&v.Profile.HamRadioCallSign,
+		&v.Profile.Notes,
+		&v.Specs.TireSizeInches,
+		&v.Specs.HasLockers,
+		&gear,
+		&v.Specs.HamLicensed,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return domain.Vehicle{}, err
+	}
+	v.ID = domain.VehicleID(externalID.String())
+	v.MemberID = memberID
+	v.Specs.RecoveryGear = postgres.RecoveryGearFromDB(gear)
+	v.CreatedAt = createdAt.UTC()
+	v.UpdatedAt = updatedAt.UTC()
+	return v, nil
+}
@@ -13,6 +13,8 @@ const (
 	ForeignKeyViolationCode = "23503"
 	// CheckViolationCode indicates a check constraint violation.
 	CheckViolationCode = "23514"
+	// NotNullViolationCode indicates a NULL written to a NOT NULL column.
+	NotNullViolationCode = "23502"
 )
 
 func AsPgError(err error) (*pgconn.PgError, bool) {
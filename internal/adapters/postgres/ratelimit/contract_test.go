@@ -0,0 +1,18 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	ratelimitport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+func TestContract_PostgresRateLimitStore(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+
+	contracttest.RunRateLimitStore(t, func(t *testing.T) (ratelimitport.Store, func()) {
+		t.Helper()
+		return NewStore(pool), nil
+	})
+}
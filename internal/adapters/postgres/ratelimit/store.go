@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ratelimit"
+)
+
+// Store is a Postgres implementation of ratelimit.Store.
+//
+// Buckets live in a shared table so limits hold across API replicas. Each Take runs in a
+// transaction holding a row lock on the bucket, which serializes concurrent requests per key.
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+func NewStore(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool}
+}
+
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Decision, error) {
+	if s.pool == nil {
+		return ratelimit.Decision{}, errors.New("nil postgres pool")
+	}
+	now = now.UTC()
+
+	var out ratelimit.Decision
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// Insert a full bucket or lock the existing one. Unlike SELECT ... FOR UPDATE this also
+		// serializes concurrent first hits: the losers wait on the winner's row.
+		var b ratelimit.Bucket
+		row := tx.QueryRow(ctx, `
+			INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (bucket_key) DO UPDATE
+			SET bucket_key = rate_limit_buckets.bucket_key
+			RETURNING tokens, updated_at
+		`, key, float64(limit.Burst), now)
+		if err := row.Scan(&b.Tokens, &b.UpdatedAt); err != nil {
+			return err
+		}
+		b.UpdatedAt = b.UpdatedAt.UTC()
+
+		next, d := ratelimit.Take(b, limit, now)
+		_, err := tx.Exec(ctx, `
+			UPDATE rate_limit_buckets
+			SET tokens = $2,
+			    updated_at = $3
+			WHERE bucket_key = $1
+		`, key, next.Tokens, next.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		out = d
+		return nil
+	})
+	if err != nil {
+		return ratelimit.Decision{}, err
+	}
+	return out, nil
+}
+
+func (s *Store) Peek(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Decision, error) {
+	if s.pool == nil {
+		return ratelimit.Decision{}, errors.New("nil postgres pool")
+	}
+	var b ratelimit.Bucket
+	err := s.pool.QueryRow(ctx, `
+		SELECT tokens, updated_at
+		FROM rate_limit_buckets
+		WHERE bucket_key = $1
+	`, key).Scan(&b.Tokens, &b.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ratelimit.Decision{}, err
+	}
+	b.UpdatedAt = b.UpdatedAt.UTC()
+	_, d := ratelimit.Take(b, limit, now.UTC())
+	return d, nil
+}
+
+func (s *Store) DeleteIdle(ctx context.Context, before time.Time, limit int) (int, error) {
+	if s.pool == nil {
+		return 0, errors.New("nil postgres pool")
+	}
+	// Batch by ctid so large backlogs are purged in short transactions.
+	ct, err := s.pool.Exec(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE ctid IN (
+			SELECT ctid
+			FROM rate_limit_buckets
+			WHERE updated_at <= $1
+			LIMIT $2
+		)
+	`, before.UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(ct.RowsAffected()), nil
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import "github.com/BennettSmith/ebo-planner-backend/internal/domain"
+
+// RecoveryGearForDB maps a recovery gear checklist to a text[] value (never NULL).
+func RecoveryGearForDB(items []domain.RecoveryGearItem) []string {
+	out := make([]string, 0, len(items))
+	for _, g := range items {
+		out = append(out, string(g))
+	}
+	return out
+}
+
+// RecoveryGearFromDB maps a text[] column back to a checklist; empty arrays read as nil.
+func RecoveryGearFromDB(items []string) []domain.RecoveryGearItem {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]domain.RecoveryGearItem, 0, len(items))
+	for _, g := range items {
+		out = append(out, domain.RecoveryGearItem(g))
+	}
+	return out
+}
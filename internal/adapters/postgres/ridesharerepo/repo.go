@@ -0,0 +1,459 @@
+package ridesharerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
+)
+
+// Repo is a Postgres implementation of ridesharerepo.Repository.
+//
+// Seat checks lock the offer row (FOR UPDATE); the enforce_ride_request_rules and
+// enforce_ride_offer_seats triggers re-check the same invariants.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+const offerColumns = `
+	t.external_id,
+	d.external_id,
+	o.seats,
+	o.notes,
+	o.created_at,
+	o.updated_at
+`
+
+const requestColumns = `
+	rr.external_id,
+	t.external_id,
+	d.external_id,
+	rd.external_id,
+	rr.status,
+	rr.note,
+	rr.created_at,
+	rr.updated_at
+`
+
+func (r *Repo) PutOffer(ctx context.Context, o ridesharerepo.Offer) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(o.TripID))
+	if err != nil {
+		return fmt.Errorf("invalid trip id: %w", err)
+	}
+	did, err := uuid.Parse(string(o.DriverMemberID))
+	if err != nil {
+		return fmt.Errorf("invalid driver member id: %w", err)
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var offerID *int64
+		var taken int
+		err := tx.QueryRow(ctx, `
+			SELECT o.id,
+			       (SELECT count(*) FROM ride_requests rr
+			        WHERE rr.trip_id = o.trip_id AND rr.driver_member_id = o.driver_member_id AND rr.status = 'ACCEPTED')
+			FROM ride_offers o
+			JOIN trips t ON t.id = o.trip_id
+			JOIN members d ON d.id = o.driver_member_id
+			WHERE t.external_id = $1 AND d.external_id = $2
+			FOR UPDATE OF o
+		`, tid, did).Scan(&offerID, &taken)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if o.Seats < taken {
+			return ridesharerepo.ErrSeatsBelowAccepted
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO ride_offers (trip_id, driver_member_id, seats, notes, created_at, updated_at)
+			VALUES (
+				(SELECT id FROM trips WHERE external_id = $1),
+				(SELECT id FROM members WHERE external_id = $2),
+				$3, $4, $5, $6
+			)
+			ON CONFLICT (trip_id, driver_member_id) DO UPDATE
+			SET seats = EXCLUDED.seats,
+			    notes = EXCLUDED.notes,
+			    updated_at = EXCLUDED.updated_at
+		`, tid, did, o.Seats, o.Notes, o.CreatedAt.UTC(), o.UpdatedAt.UTC())
+		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.CheckViolationCode {
+			return ridesharerepo.ErrSeatsBelowAccepted
+		}
+		return err
+	})
+}
+
+func (r *Repo) GetOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID) (ridesharerepo.Offer, error) {
+	if r.pool == nil {
+		return ridesharerepo.Offer{}, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return ridesharerepo.Offer{}, ridesharerepo.ErrOfferNotFound
+	}
+	did, err := uuid.Parse(string(driverID))
+	if err != nil {
+		return ridesharerepo.Offer{}, ridesharerepo.ErrOfferNotFound
+	}
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+offerColumns+`
+		FROM ride_offers o
+		JOIN trips t ON t.id = o.trip_id
+		JOIN members d ON d.id = o.driver_member_id
+		WHERE t.external_id = $1 AND d.external_id = $2
+	`, tid, did)
+	o, err := scanOffer(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ridesharerepo.Offer{}, ridesharerepo.ErrOfferNotFound
+	}
+	return o, err
+}
+
+func (r *Repo) ListOffersByTrip(ctx context.Context, tripID domain.TripID) ([]ridesharerepo.Offer, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []ridesharerepo.Offer{}, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+offerColumns+`
+		FROM ride_offers o
+		JOIN trips t ON t.id = o.trip_id
+		JOIN members d ON d.id = o.driver_member_id
+		WHERE t.external_id = $1
+		ORDER BY o.created_at ASC, d.external_id ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]ridesharerepo.Offer, 0)
+	for rows.Next() {
+		o, err := scanOffer(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) WithdrawOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	did, err := uuid.Parse(string(driverID))
+	if err != nil {
+		return ridesharerepo.ErrOfferNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var tripPK, driverPK int64
+		err := tx.QueryRow(ctx, `
+			DELETE FROM ride_offers o
+			USING trips t, members d
+			WHERE t.id = o.trip_id AND d.id = o.driver_member_id
+			  AND t.external_id = $1 AND d.external_id = $2
+			RETURNING o.trip_id, o.driver_member_id
+		`, tid, did).Scan(&tripPK, &driverPK)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ridesharerepo.ErrOfferNotFound
+			}
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE ride_requests
+			SET status = 'CANCELED', updated_at = $3
+			WHERE trip_id = $1 AND driver_member_id = $2 AND status IN ('PENDING', 'ACCEPTED')
+		`, tripPK, driverPK, at.UTC())
+		return err
+	})
+}
+
+func (r *Repo) CreateRequest(ctx context.Context, req ridesharerepo.Request) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	rid, err := uuid.Parse(string(req.ID))
+	if err != nil {
+		return fmt.Errorf("invalid ride request id: %w", err)
+	}
+	tid, err := uuid.Parse(string(req.TripID))
+	if err != nil {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	did, err := uuid.Parse(string(req.DriverMemberID))
+	if err != nil {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	riderID, err := uuid.Parse(string(req.RiderMemberID))
+	if err != nil {
+		return fmt.Errorf("invalid rider member id: %w", err)
+	}
+
+	// Selecting through the offer makes a missing offer insert no row.
+	ct, err := r.pool.Exec(ctx, `
+		INSERT INTO ride_requests (external_id, trip_id, driver_member_id, rider_member_id, status, note, created_at, updated_at)
+		SELECT $1, o.trip_id, o.driver_member_id, rd.id, 'PENDING', $5, $6, $7
+		FROM ride_offers o
+		JOIN trips t ON t.id = o.trip_id
+		JOIN members d ON d.id = o.driver_member_id
+		JOIN members rd ON rd.external_id = $4
+		WHERE t.external_id = $2 AND d.external_id = $3
+	`, rid, tid, did, riderID, req.Note, req.CreatedAt.UTC(), req.UpdatedAt.UTC())
+	if err != nil {
+		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+			switch pe.ConstraintName {
+			case "ride_requests_external_id_unique":
+				return ridesharerepo.ErrAlreadyExists
+			case "ride_requests_active_rider_unique":
+				return ridesharerepo.ErrActiveRequestExists
+			}
+		}
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ridesharerepo.ErrOfferNotFound
+	}
+	return nil
+}
+
+func (r *Repo) GetRequest(ctx context.Context, id domain.RideRequestID) (ridesharerepo.Request, error) {
+	if r.pool == nil {
+		return ridesharerepo.Request{}, errors.New("nil postgres pool")
+	}
+	rid, err := uuid.Parse(string(id))
+	if err != nil {
+		return ridesharerepo.Request{}, ridesharerepo.ErrRequestNotFound
+	}
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+requestColumns+`
+		FROM ride_requests rr
+		JOIN trips t ON t.id = rr.trip_id
+		JOIN members d ON d.id = rr.driver_member_id
+		JOIN members rd ON rd.id = rr.rider_member_id
+		WHERE rr.external_id = $1
+	`, rid)
+	req, err := scanRequest(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ridesharerepo.Request{}, ridesharerepo.ErrRequestNotFound
+	}
+	return req, err
+}
+
+func (r *Repo) ListRequestsByTrip(ctx context.Context, tripID domain.TripID) ([]ridesharerepo.Request, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []ridesharerepo.Request{}, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+requestColumns+`
+		FROM ride_requests rr
+		JOIN trips t ON t.id = rr.trip_id
+		JOIN members d ON d.id = rr.driver_member_id
+		JOIN members rd ON rd.id = rr.rider_member_id
+		WHERE t.external_id = $1
+		ORDER BY rr.created_at ASC, rr.external_id ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]ridesharerepo.Request, 0)
+	for rows.Next() {
+		req, err := scanRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, req)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repo) AcceptRequest(ctx context.Context, id domain.RideRequestID, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	rid, err := uuid.Parse(string(id))
+	if err != nil {
+		return ridesharerepo.ErrRequestNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			pk       int64
+			tripPK   int64
+			driverPK int64
+			status   string
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT id, trip_id, driver_member_id, status
+			FROM ride_requests
+			WHERE external_id = $1
+			FOR UPDATE
+		`, rid).Scan(&pk, &tripPK, &driverPK, &status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ridesharerepo.ErrRequestNotFound
+			}
+			return err
+		}
+		if ridesharerepo.Status(status) != ridesharerepo.StatusPending {
+			return ridesharerepo.ErrInvalidTransition
+		}
+
+		// Lock the offer so concurrent accepts for the same driver serialize on the seat count.
+		var seats int
+		err = tx.QueryRow(ctx, `
+			SELECT seats FROM ride_offers
+			WHERE trip_id = $1 AND driver_member_id = $2
+			FOR UPDATE
+		`, tripPK, driverPK).Scan(&seats)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ridesharerepo.ErrOfferNotFound
+			}
+			return err
+		}
+		var taken int
+		if err := tx.QueryRow(ctx, `
+			SELECT count(*) FROM ride_requests
+			WHERE trip_id = $1 AND driver_member_id = $2 AND status = 'ACCEPTED'
+		`, tripPK, driverPK).Scan(&taken); err != nil {
+			return err
+		}
+		if taken >= seats {
+			return ridesharerepo.ErrNoSeatsAvailable
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE ride_requests SET status = 'ACCEPTED', updated_at = $2 WHERE id = $1`, pk, at.UTC())
+		if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.CheckViolationCode {
+			return ridesharerepo.ErrNoSeatsAvailable
+		}
+		return err
+	})
+}
+
+func (r *Repo) CloseRequest(ctx context.Context, id domain.RideRequestID, status ridesharerepo.Status, at time.Time) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	if status != ridesharerepo.StatusDeclined && status != ridesharerepo.StatusCanceled {
+		return ridesharerepo.ErrInvalidTransition
+	}
+	rid, err := uuid.Parse(string(id))
+	if err != nil {
+		return ridesharerepo.ErrRequestNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var pk int64
+		var current string
+		err := tx.QueryRow(ctx, `SELECT id, status FROM ride_requests WHERE external_id = $1 FOR UPDATE`, rid).Scan(&pk, &current)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ridesharerepo.ErrRequestNotFound
+			}
+			return err
+		}
+		if s := ridesharerepo.Status(current); s != ridesharerepo.StatusPending && s != ridesharerepo.StatusAccepted {
+			return ridesharerepo.ErrInvalidTransition
+		}
+		_, err = tx.Exec(ctx, `UPDATE ride_requests SET status = $2, updated_at = $3 WHERE id = $1`, pk, string(status), at.UTC())
+		return err
+	})
+}
+
+func (r *Repo) CountAcceptedByTrip(ctx context.Context, tripID domain.TripID) (int, error) {
+	if r.pool == nil {
+		return 0, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return 0, nil
+	}
+	var n int
+	err = r.pool.QueryRow(ctx, `
+		SELECT count(*)
+		FROM ride_requests rr
+		JOIN trips t ON t.id = rr.trip_id
+		WHERE t.external_id = $1 AND rr.status = 'ACCEPTED'
+	`, tid).Scan(&n)
+	return n, err
+}
+
+func scanOffer(row pgx.Row) (ridesharerepo.Offer, error) {
+	var (
+		tripID    uuid.UUID
+		driverID  uuid.UUID
+		o         ridesharerepo.Offer
+		createdAt time.Time
+		updatedAt time.Time
+	)
+	if err := row.Scan(&tripID, &driverID, &o.Seats, &o.Notes, &createdAt, &updatedAt); err != nil {
+		return ridesharerepo.Offer{}, err
+	}
+	o.TripID = domain.TripID(tripID.String())
+	o.DriverMemberID = domain.MemberID(driverID.String())
+	o.CreatedAt = createdAt.UTC()
+	o.UpdatedAt = updatedAt.UTC()
+	return o, nil
+}
+
+func scanRequest(row pgx.Row) (ridesharerepo.Request, error) {
+	var (
+		id        uuid.UUID
+		tripID    uuid.UUID
+		driverID  uuid.UUID
+		riderID   uuid.UUID
+		status    string
+		req       ridesharerepo.Request
+		createdAt time.Time
+		updatedAt time.Time
+	)
+	if err := row.Scan(&id, &tripID, &driverID, &riderID, &status, &req.Note, &createdAt, &updatedAt); err != nil {
+		return ridesharerepo.Request{}, err
+	}
+	req.ID = domain.RideRequestID(id.String())
+	req.TripID = domain.TripID(tripID.String())
+	req.DriverMemberID = domain.MemberID(driverID.String())
+	req.RiderMemberID = domain.MemberID(riderID.String())
+	req.Status = ridesharerepo.Status(status)
+	req.CreatedAt = createdAt.UTC()
+	req.UpdatedAt = updatedAt.UTC()
+	return req, nil
+}
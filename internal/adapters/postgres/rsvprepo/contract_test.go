@@ -0,0 +1,126 @@
+package rsvprepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/contracttest"
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/testutil"
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	memberrepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	rsvprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	triprepoport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestContract_PostgresRSVPRepo(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+	issuer := "https://issuer.test"
+
+	contracttest.RunTripAndRSVPRepos(
+		t,
+		func(t *testing.T) (memberrepoport.Repository, func()) {
+			t.Helper()
+			return memberrepo.NewRepo(pool, issuer), nil
+		},
+		func(t *testing.T) (triprepoport.Repository, func()) {
+			t.Helper()
+			return triprepo.NewRepo(pool), nil
+		},
+		func(t *testing.T) (rsvprepoport.Repository, func()) {
+			t.Helper()
+			return NewRepo(pool), nil
+		},
+	)
+}
+
+// History is append-only in the database itself: rows cannot be edited or deleted directly,
+// but the foreign keys may still null out the changer and cascade deletes of the trip.
+func TestPostgres_RSVPHistoryIsAppendOnly(t *testing.T) {
+	pool := testutil.OpenMigratedPool(t)
+	ctx := context.Background()
+	members := memberrepo.NewRepo(pool, "https://issuer.test")
+	trips := triprepo.NewRepo(pool)
+	repo := NewRepo(pool)
+
+	now := time.Unix(2000, 0).UTC()
+	memberIDs := []domain.MemberID{domain.MemberID(uuid.NewString()), domain.MemberID(uuid.NewString()), domain.MemberID(uuid.NewString())}
+	for i, id := range memberIDs {
+		if err := members.Create(ctx, memberrepoport.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-history-" + string(rune('a'+i))),
+			DisplayName: "Member",
+			Email:       string(rune('a'+i)) + "@example.com",
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			t.Fatalf("Create member: %v", err)
+		}
+	}
+	attendee, organizer, changer := memberIDs[0], memberIDs[1], memberIDs[2]
+	tripID := domain.TripID(uuid.NewString())
+	if err := trips.Create(ctx, triprepoport.Trip{
+		ID:                 tripID,
+		Status:             triprepoport.StatusDraft,
+		CreatorMemberID:    organizer,
+		OrganizerMemberIDs: []domain.MemberID{organizer},
+		DraftVisibility:    triprepoport.DraftVisibilityPrivate,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}); err != nil {
+		t.Fatalf("Create trip: %v", err)
+	}
+	reason := "texted me"
+	if err := repo.Upsert(ctx, rsvprepoport.RSVP{
+		TripID:       tripID,
+		MemberID:     attendee,
+		Status:       rsvprepoport.StatusYes,
+		UpdatedAt:    now,
+		ChangedBy:    changer,
+		ChangeReason: &reason,
+	}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	requireCheckViolation := func(what string, err error) {
+		t.Helper()
+		pgErr, ok := postgres.AsPgError(err)
+		if !ok || pgErr.Code != postgres.CheckViolationCode {
+			t.Fatalf("%s err=%v, want check violation", what, err)
+		}
+	}
+	_, err := pool.Exec(ctx, `UPDATE trip_rsvp_history SET response = 'NO'`)
+	requireCheckViolation("UPDATE", err)
+	_, err = pool.Exec(ctx, `DELETE FROM trip_rsvp_history`)
+	requireCheckViolation("DELETE", err)
+
+	// Deleting the member who made the change clears ChangedBy; the entry stays.
+	if _, err := pool.Exec(ctx, `DELETE FROM members WHERE external_id = $1`, uuid.MustParse(string(changer))); err != nil {
+		t.Fatalf("delete changer: %v", err)
+	}
+	hist, err := repo.ListHistoryByTrip(ctx, tripID)
+	if err != nil {
+		t.Fatalf("ListHistoryByTrip: %v", err)
+	}
+	if len(hist) != 1 || hist[0].ChangedBy != "" || hist[0].Status != rsvprepoport.StatusYes {
+		t.Fatalf("history after changer delete = %+v", hist)
+	}
+
+	// Deleting the trip takes its history with it.
+	if _, err := pool.Exec(ctx, `DELETE FROM trips WHERE external_id = $1`, uuid.MustParse(string(tripID))); err != nil {
+		t.Fatalf("delete trip: %v", err)
+	}
+	var n int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM trip_rsvp_history`).Scan(&n); err != nil {
+		t.Fatalf("count history: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("history rows after trip delete = %d, want 0", n)
+	}
+}
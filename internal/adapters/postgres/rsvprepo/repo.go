@@ -37,25 +37,40 @@ func (r *Repo) Get(ctx context.Context, tripID domain.TripID, memberID domain.Me
 	}
 
 	row := r.pool.QueryRow(ctx, `
-		SELECT r.response, r.updated_at
+		SELECT r.response, v.external_id, r.passenger_count, r.passenger_names, r.updated_at,
+			changer.external_id, r.change_reason, r.capacity_override
 		FROM trip_rsvps r
 		JOIN trips t ON t.id = r.trip_id
 		JOIN members m ON m.id = r.member_id
+		LEFT JOIN member_vehicles v ON v.id = r.vehicle_id
+		LEFT JOIN members changer ON changer.id = r.changed_by_member_id
 		WHERE t.external_id = $1 AND m.external_id = $2
 	`, tid, mid)
 	var status string
+	var vehicleID *uuid.UUID
+	var passengers int
+	var names []string
 	var updatedAt time.Time
-	if err := row.Scan(&status, &updatedAt); err != nil {
+	var changedBy *uuid.UUID
+	var reason *string
+	var override bool
+	if err := row.Scan(&status, &vehicleID, &passengers, &names, &updatedAt, &changedBy, &reason, &override); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return rsvprepo.RSVP{}, rsvprepo.ErrNotFound
 		}
 		return rsvprepo.RSVP{}, err
 	}
 	return rsvprepo.RSVP{
-		TripID:    tripID,
-		MemberID:  memberID,
-		Status:    rsvprepo.Status(status),
-		UpdatedAt: updatedAt.UTC(),
+		TripID:           tripID,
+		MemberID:         memberID,
+		Status:           rsvprepo.Status(status),
+		VehicleID:        vehicleIDPtr(vehicleID),
+		PassengerCount:   passengers,
+		PassengerNames:   passengerNamesFromDB(names),
+		UpdatedAt:        updatedAt.UTC(),
+		ChangedBy:        memberIDFromDB(changedBy),
+		ChangeReason:     reason,
+		CapacityOverride: override,
 	}, nil
 }
 
@@ -71,20 +86,87 @@ func (r *Repo) Upsert(ctx context.Context, rec rsvprepo.RSVP) error {
 	if err != nil {
 		return fmt.Errorf("invalid member id: %w", err)
 	}
+	var changedBy *uuid.UUID
+	if rec.ChangedBy != "" {
+		c, err := uuid.Parse(string(rec.ChangedBy))
+		if err != nil {
+			return fmt.Errorf("invalid changed-by member id: %w", err)
+		}
+		changedBy = &c
+	}
+	var vid *uuid.UUID
+	if rec.VehicleID != nil {
+		v, err := uuid.Parse(string(*rec.VehicleID))
+		if err != nil {
+			return fmt.Errorf("invalid vehicle id: %w", err)
+		}
+		vid = &v
+	}
 
-	_, err = r.pool.Exec(ctx, `
-		INSERT INTO trip_rsvps (trip_id, member_id, response, updated_at)
-		VALUES (
-			(SELECT id FROM trips WHERE external_id = $1),
-			(SELECT id FROM members WHERE external_id = $2),
-			$3,
-			$4
-		)
-		ON CONFLICT (trip_id, member_id) DO UPDATE
-		SET response = EXCLUDED.response,
-		    updated_at = EXCLUDED.updated_at
-	`, tid, mid, string(rec.Status), rec.UpdatedAt.UTC())
-	return err
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		// Lock the current row (if any) so the history records the response it replaces.
+		var previous *string
+		err := tx.QueryRow(ctx, `
+			SELECT r.response
+			FROM trip_rsvps r
+			JOIN trips t ON t.id = r.trip_id
+			JOIN members m ON m.id = r.member_id
+			WHERE t.external_id = $1 AND m.external_id = $2
+			FOR UPDATE OF r
+		`, tid, mid).Scan(&previous)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		// The vehicle must belong to the RSVPing member; an unknown vehicle is stored as NULL.
+		_, err = tx.Exec(ctx, `
+			INSERT INTO trip_rsvps (trip_id, member_id, response, vehicle_id, passenger_count, passenger_names, updated_at,
+				changed_by_member_id, change_reason, capacity_override)
+			VALUES (
+				(SELECT id FROM trips WHERE external_id = $1),
+				(SELECT id FROM members WHERE external_id = $2),
+				$3,
+				(
+					SELECT v.id FROM member_vehicles v
+					JOIN members m ON m.id = v.member_id
+					WHERE v.external_id = $4 AND m.external_id = $2
+				),
+				$5,
+				$6,
+				$7,
+				(SELECT id FROM members WHERE external_id = $8),
+				$9,
+				$10
+			)
+			ON CONFLICT (trip_id, member_id) DO UPDATE
+			SET response = EXCLUDED.response,
+			    vehicle_id = EXCLUDED.vehicle_id,
+			    passenger_count = EXCLUDED.passenger_count,
+			    passenger_names = EXCLUDED.passenger_names,
+			    updated_at = EXCLUDED.updated_at,
+			    changed_by_member_id = EXCLUDED.changed_by_member_id,
+			    change_reason = EXCLUDED.change_reason,
+			    capacity_override = EXCLUDED.capacity_override
+		`, tid, mid, string(rec.Status), vid, rec.PassengerCount, passengerNamesForDB(rec.PassengerNames), rec.UpdatedAt.UTC(),
+			changedBy, rec.ChangeReason, rec.CapacityOverride)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO trip_rsvp_history (trip_id, member_id, previous_response, response, changed_by_member_id, change_reason, changed_at)
+			VALUES (
+				(SELECT id FROM trips WHERE external_id = $1),
+				(SELECT id FROM members WHERE external_id = $2),
+				$3,
+				$4,
+				(SELECT id FROM members WHERE external_id = $5),
+				$6,
+				$7
+			)
+		`, tid, mid, previous, string(rec.Status), changedBy, rec.ChangeReason, rec.UpdatedAt.UTC())
+		return err
+	})
 }
 
 func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo.RSVP, error) {
@@ -96,10 +178,13 @@ func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo
 		return []rsvprepo.RSVP{}, nil
 	}
 	rows, err := r.pool.Query(ctx, `
-		SELECT m.external_id, r.response, r.updated_at
+		SELECT m.external_id, r.response, v.external_id, r.passenger_count, r.passenger_names, r.updated_at,
+			changer.external_id, r.change_reason, r.capacity_override
 		FROM trip_rsvps r
 		JOIN trips t ON t.id = r.trip_id
 		JOIN members m ON m.id = r.member_id
+		LEFT JOIN member_vehicles v ON v.id = r.vehicle_id
+		LEFT JOIN members changer ON changer.id = r.changed_by_member_id
 		WHERE t.external_id = $1
 		ORDER BY m.external_id ASC, r.updated_at ASC
 	`, tid)
@@ -112,15 +197,27 @@ func (r *Repo) ListByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo
 	for rows.Next() {
 		var mid uuid.UUID
 		var status string
+		var vehicleID *uuid.UUID
+		var passengers int
+		var names []string
 		var updatedAt time.Time
-		if err := rows.Scan(&mid, &status, &updatedAt); err != nil {
+		var changedBy *uuid.UUID
+		var reason *string
+		var override bool
+		if err := rows.Scan(&mid, &status, &vehicleID, &passengers, &names, &updatedAt, &changedBy, &reason, &override); err != nil {
 			return nil, err
 		}
 		out = append(out, rsvprepo.RSVP{
-			TripID:    tripID,
-			MemberID:  domain.MemberID(mid.String()),
-			Status:    rsvprepo.Status(status),
-			UpdatedAt: updatedAt.UTC(),
+			TripID:           tripID,
+			MemberID:         domain.MemberID(mid.String()),
+			Status:           rsvprepo.Status(status),
+			VehicleID:        vehicleIDPtr(vehicleID),
+			PassengerCount:   passengers,
+			PassengerNames:   passengerNamesFromDB(names),
+			UpdatedAt:        updatedAt.UTC(),
+			ChangedBy:        memberIDFromDB(changedBy),
+			ChangeReason:     reason,
+			CapacityOverride: override,
 		})
 	}
 	if err := rows.Err(); err != nil {
@@ -149,3 +246,119 @@ func (r *Repo) CountYesByTrip(ctx context.Context, tripID domain.TripID) (int, e
 	}
 	return n, nil
 }
+
+func (r *Repo) CountPeopleByTrip(ctx context.Context, tripID domain.TripID) (int, error) {
+	if r.pool == nil {
+		return 0, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return 0, nil
+	}
+	row := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(sum(1 + r.passenger_count), 0)
+		FROM trip_rsvps r
+		JOIN trips t ON t.id = r.trip_id
+		WHERE t.external_id = $1 AND r.response = 'YES'
+	`, tid)
+	var n int
+	if err := row.Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (r *Repo) ListHistoryByTrip(ctx context.Context, tripID domain.TripID) ([]rsvprepo.HistoryEntry, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []rsvprepo.HistoryEntry{}, nil
+	}
+	return r.listHistory(ctx, `t.external_id = $1`, tid)
+}
+
+func (r *Repo) ListHistoryByMember(ctx context.Context, memberID domain.MemberID) ([]rsvprepo.HistoryEntry, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return []rsvprepo.HistoryEntry{}, nil
+	}
+	return r.listHistory(ctx, `m.external_id = $1`, mid)
+}
+
+func (r *Repo) listHistory(ctx context.Context, where string, arg uuid.UUID) ([]rsvprepo.HistoryEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT t.external_id, m.external_id, h.previous_response, h.response, changer.external_id, h.change_reason, h.changed_at
+		FROM trip_rsvp_history h
+		JOIN trips t ON t.id = h.trip_id
+		JOIN members m ON m.id = h.member_id
+		LEFT JOIN members changer ON changer.id = h.changed_by_member_id
+		WHERE `+where+`
+		ORDER BY h.changed_at ASC, h.id ASC
+	`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]rsvprepo.HistoryEntry, 0)
+	for rows.Next() {
+		var tripID, memberID uuid.UUID
+		var previous *string
+		var status string
+		var changedBy *uuid.UUID
+		var reason *string
+		var changedAt time.Time
+		if err := rows.Scan(&tripID, &memberID, &previous, &status, &changedBy, &reason, &changedAt); err != nil {
+			return nil, err
+		}
+		e := rsvprepo.HistoryEntry{
+			TripID:       domain.TripID(tripID.String()),
+			MemberID:     domain.MemberID(memberID.String()),
+			Status:       rsvprepo.Status(status),
+			ChangedBy:    memberIDFromDB(changedBy),
+			ChangeReason: reason,
+			ChangedAt:    changedAt.UTC(),
+		}
+		if previous != nil {
+			p := rsvprepo.Status(*previous)
+			e.PreviousStatus = &p
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// passengerNamesForDB maps a nil name list to an empty array (the column is NOT NULL).
+func passengerNamesForDB(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+func passengerNamesFromDB(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+func memberIDFromDB(id *uuid.UUID) domain.MemberID {
+	if id == nil {
+		return ""
+	}
+	return domain.MemberID(id.String())
+}
+
+func vehicleIDPtr(id *uuid.UUID) *domain.VehicleID {
+	if id == nil {
+		return nil
+	}
+	v := domain.VehicleID(id.String())
+	return &v
+}
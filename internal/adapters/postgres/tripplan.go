@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// tripPlanColumns are the planning columns shared by trip_templates and trip_series, in the
+// order used by TripPlanArgs and TripPlanRow.Dest.
+var tripPlanColumns = []string{
+	"trip_name",
+	"description",
+	"capacity_rigs",
+	"capacity_people",
+	"difficulty_rating",
+	"difficulty_min_rating",
+	"difficulty_max_rating",
+	"difficulty_terrain",
+	"difficulty_text",
+	"meeting_location_label",
+	"meeting_location_address",
+	"meeting_location_latitude",
+	"meeting_location_longitude",
+	"comms_requirements_text",
+	"recommended_requirements_text",
+	"req_min_tire_size_inches",
+	"req_lockers",
+	"req_recovery_gear",
+	"req_ham_license",
+	"req_strict",
+}
+
+// TripPlanColumns returns the plan column list qualified with alias (when non-empty), for
+// SELECT and INSERT statements.
+func TripPlanColumns(alias string) string {
+	if alias == "" {
+		return strings.Join(tripPlanColumns, ", ")
+	}
+	cols := make([]string, len(tripPlanColumns))
+	for i, c := range tripPlanColumns {
+		cols[i] = alias + "." + c
+	}
+	return strings.Join(cols, ", ")
+}
+
+// TripPlanPlaceholders returns the VALUES placeholders for the plan columns, numbered from
+// $first.
+func TripPlanPlaceholders(first int) string {
+	ph := make([]string, len(tripPlanColumns))
+	for i := range ph {
+		ph[i] = "$" + strconv.Itoa(first+i)
+	}
+	return strings.Join(ph, ",")
+}
+
+// TripPlanArgs returns p's column values in TripPlanColumns order.
+func TripPlanArgs(p domain.TripPlan) []any {
+	rating, minRating, maxRating, terrain := DifficultyForDB(p.Difficulty)
+	var (
+		mlLabel, mlAddr *string
+		mlLat, mlLon    *float64
+	)
+	if l := p.MeetingLocation; l != nil {
+		label := l.Label
+		mlLabel, mlAddr, mlLat, mlLon = &label, l.Address, l.Latitude, l.Longitude
+	}
+	return []any{
+		p.Name,
+		p.Description,
+		p.CapacityRigs,
+		p.CapacityPeople,
+		rating,
+		minRating,
+		maxRating,
+		terrain,
+		p.DifficultyText,
+		mlLabel,
+		mlAddr,
+		mlLat,
+		mlLon,
+		p.CommsRequirementsText,
+		p.RecommendedRequirementsText,
+		p.Requirements.MinTireSizeInches,
+		p.Requirements.LockersRequired,
+		RecoveryGearForDB(p.Requirements.RecoveryGear),
+		p.Requirements.HamLicenseRequired,
+		p.Requirements.Strict,
+	}
+}
+
+// TripPlanRow receives the plan columns of a scanned row. Artifacts are stored separately and
+// are not part of it.
+type TripPlanRow struct {
+	plan      domain.TripPlan
+	rating    *int
+	minRating *int
+	maxRating *int
+	terrain   []string
+	mlLabel   *string
+	mlAddr    *string
+	mlLat     *float64
+	mlLon     *float64
+	reqGear   []string
+}
+
+// Dest returns scan destinations in TripPlanColumns order.
+func (r *TripPlanRow) Dest() []any {
+	p := &r.plan
+	return []any{
+		&p.Name,
+		&p.Description,
+		&p.CapacityRigs,
+		&p.CapacityPeople,
+		&r.rating,
+		&r.minRating,
+		&r.maxRating,
+		&r.terrain,
+		&p.DifficultyText,
+		&r.mlLabel,
+		&r.mlAddr,
+		&r.mlLat,
+		&r.mlLon,
+		&p.CommsRequirementsText,
+		&p.RecommendedRequirementsText,
+		&p.Requirements.MinTireSizeInches,
+		&p.Requirements.LockersRequired,
+		&r.reqGear,
+		&p.Requirements.HamLicenseRequired,
+		&p.Requirements.Strict,
+	}
+}
+
+// Plan returns the scanned plan.
+func (r *TripPlanRow) Plan() domain.TripPlan {
+	p := r.plan
+	p.Difficulty = DifficultyFromDB(r.rating, r.minRating, r.maxRating, r.terrain)
+	p.Requirements.RecoveryGear = RecoveryGearFromDB(r.reqGear)
+	if r.mlLabel != nil || r.mlAddr != nil || r.mlLat != nil || r.mlLon != nil {
+		l := &domain.Location{Address: r.mlAddr, Latitude: r.mlLat, Longitude: r.mlLon}
+		if r.mlLabel != nil {
+			l.Label = *r.mlLabel
+		}
+		p.MeetingLocation = l
+	}
+	return p
+}
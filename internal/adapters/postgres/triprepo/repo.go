@@ -41,6 +41,18 @@ func (r *Repo) Create(ctx context.Context, t triprepo.Trip) error {
 
 	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
 		sd, ed := datePtr(t.StartDate), datePtr(t.EndDate)
+		rating, minRating, maxRating, terrain := postgres.DifficultyForDB(t.Difficulty)
+		var (
+			seriesUUID *uuid.UUID
+			seriesDate pgtype.Date
+		)
+		if t.Series != nil {
+			id, err := uuid.Parse(string(t.Series.SeriesID))
+			if err != nil {
+				return fmt.Errorf("invalid series id: %w", err)
+			}
+			seriesUUID, seriesDate = &id, datePtr(&t.Series.Date)
+		}
 
 		_, err := tx.Exec(ctx, `
 			INSERT INTO trips (
@@ -61,11 +73,26 @@ func (r *Repo) Create(ctx context.Context, t triprepo.Trip) error {
 				recommended_requirements_text,
 				created_by_member_id,
 				created_at,
-				updated_at
+				updated_at,
+				capacity_people,
+				req_min_tire_size_inches,
+				req_lockers,
+				req_recovery_gear,
+				req_ham_license,
+				req_strict,
+				difficulty_rating,
+				difficulty_min_rating,
+				difficulty_max_rating,
+				difficulty_terrain,
+				series_id,
+				series_date,
+				rsvp_deadline
 			) VALUES (
 				$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,
 				(SELECT id FROM members WHERE external_id = $16),
-				$17,$18
+				$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,
+				(SELECT id FROM trip_series WHERE external_id = $29),
+				$30,$31
 			)
 		`,
 			tripUUID,
@@ -86,10 +113,28 @@ func (r *Repo) Create(ctx context.Context, t triprepo.Trip) error {
 			creatorUUID,
 			t.CreatedAt.UTC(),
 			t.UpdatedAt.UTC(),
+			t.CapacityPeople,
+			t.Requirements.MinTireSizeInches,
+			t.Requirements.LockersRequired,
+			postgres.RecoveryGearForDB(t.Requirements.RecoveryGear),
+			t.Requirements.HamLicenseRequired,
+			t.Requirements.Strict,
+			rating,
+			minRating,
+			maxRating,
+			terrain,
+			seriesUUID,
+			seriesDate,
+			timestamptzPtr(t.RSVPDeadline),
 		)
 		if err != nil {
-			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode && pe.ConstraintName == "trips_external_id_unique" {
-				return triprepo.ErrAlreadyExists
+			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+				switch pe.ConstraintName {
+				case "trips_external_id_unique":
+					return triprepo.ErrAlreadyExists
+				case "trips_series_date_unique":
+					return triprepo.ErrOccurrenceExists
+				}
 			}
 			return err
 		}
@@ -138,6 +183,7 @@ func (r *Repo) Save(ctx context.Context, t triprepo.Trip) error {
 		}
 
 		sd, ed := datePtr(t.StartDate), datePtr(t.EndDate)
+		rating, minRating, maxRating, terrain := postgres.DifficultyForDB(t.Difficulty)
 
 		_, err = tx.Exec(ctx, `
 			UPDATE trips
@@ -155,7 +201,18 @@ func (r *Repo) Save(ctx context.Context, t triprepo.Trip) error {
 			    meeting_location_longitude = $13,
 			    comms_requirements_text = $14,
 			    recommended_requirements_text = $15,
-			    updated_at = $16
+			    updated_at = $16,
+			    capacity_people = $17,
+			    req_min_tire_size_inches = $18,
+			    req_lockers = $19,
+			    req_recovery_gear = $20,
+			    req_ham_license = $21,
+			    req_strict = $22,
+			    difficulty_rating = $23,
+			    difficulty_min_rating = $24,
+			    difficulty_max_rating = $25,
+			    difficulty_terrain = $26,
+			    rsvp_deadline = $27
 			WHERE external_id = $1
 		`,
 			tripUUID,
@@ -174,6 +231,17 @@ func (r *Repo) Save(ctx context.Context, t triprepo.Trip) error {
 			t.CommsRequirementsText,
 			t.RecommendedRequirementsText,
 			t.UpdatedAt.UTC(),
+			t.CapacityPeople,
+			t.Requirements.MinTireSizeInches,
+			t.Requirements.LockersRequired,
+			postgres.RecoveryGearForDB(t.Requirements.RecoveryGear),
+			t.Requirements.HamLicenseRequired,
+			t.Requirements.Strict,
+			rating,
+			minRating,
+			maxRating,
+			terrain,
+			timestamptzPtr(t.RSVPDeadline),
 		)
 		if err != nil {
 			return err
@@ -218,9 +286,23 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 			tr.recommended_requirements_text,
 			creator.external_id,
 			tr.created_at,
-			tr.updated_at
+			tr.updated_at,
+			tr.capacity_people,
+			tr.req_min_tire_size_inches,
+			tr.req_lockers,
+			tr.req_recovery_gear,
+			tr.req_ham_license,
+			tr.req_strict,
+			tr.difficulty_rating,
+			tr.difficulty_min_rating,
+			tr.difficulty_max_rating,
+			tr.difficulty_terrain,
+			series.external_id,
+			tr.series_date,
+			tr.rsvp_deadline
 		FROM trips tr
 		JOIN members creator ON creator.id = tr.created_by_member_id
+		LEFT JOIN trip_series series ON series.id = tr.series_id
 		WHERE tr.external_id = $1
 	`, tripUUID)
 
@@ -243,6 +325,16 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 		creatorID  uuid.UUID
 		createdAt  time.Time
 		updatedAt  time.Time
+		capPeople  *int
+		reqs       domain.TripRequirements
+		reqGear    []string
+		rating     *int
+		minRating  *int
+		maxRating  *int
+		terrain    []string
+		seriesID   *uuid.UUID
+		seriesDate pgtype.Date
+		deadline   *time.Time
 	)
 
 	if err := row.Scan(
@@ -264,6 +356,19 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 		&creatorID,
 		&createdAt,
 		&updatedAt,
+		&capPeople,
+		&reqs.MinTireSizeInches,
+		&reqs.LockersRequired,
+		&reqGear,
+		&reqs.HamLicenseRequired,
+		&reqs.Strict,
+		&rating,
+		&minRating,
+		&maxRating,
+		&terrain,
+		&seriesID,
+		&seriesDate,
+		&deadline,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return triprepo.Trip{}, triprepo.ErrNotFound
@@ -275,6 +380,7 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 	if err != nil {
 		return triprepo.Trip{}, err
 	}
+	reqs.RecoveryGear = postgres.RecoveryGearFromDB(reqGear)
 	arts, err := loadArtifacts(ctx, r.pool, tripUUID)
 	if err != nil {
 		return triprepo.Trip{}, err
@@ -289,6 +395,11 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 		attending = &n
 	}
 
+	var series *triprepo.SeriesOccurrence
+	if seriesID != nil && seriesDate.Valid {
+		series = &triprepo.SeriesOccurrence{SeriesID: domain.TripSeriesID(seriesID.String()), Date: *dateToTimePtr(seriesDate)}
+	}
+
 	return triprepo.Trip{
 		ID:                          domain.TripID(extID.String()),
 		Status:                      triprepo.Status(status),
@@ -299,13 +410,18 @@ func (r *Repo) GetByID(ctx context.Context, id domain.TripID) (triprepo.Trip, er
 		DraftVisibility:             triprepo.DraftVisibility(derefString(dv)),
 		StartDate:                   dateToTimePtr(startDate),
 		EndDate:                     dateToTimePtr(endDate),
+		RSVPDeadline:                timestamptzPtr(deadline),
 		CapacityRigs:                cloneIntPtr(capacity),
+		CapacityPeople:              cloneIntPtr(capPeople),
 		AttendingRigs:               attending,
+		Difficulty:                  postgres.DifficultyFromDB(rating, minRating, maxRating, terrain),
 		DifficultyText:              cloneStringPtr(difficulty),
 		MeetingLocation:             meetingFromColumns(mlLabel, mlAddr, mlLat, mlLon),
 		CommsRequirementsText:       cloneStringPtr(comms),
 		RecommendedRequirementsText: cloneStringPtr(reco),
+		Requirements:                reqs,
 		Artifacts:                   arts,
+		Series:                      series,
 		CreatedAt:                   createdAt.UTC(),
 		UpdatedAt:                   updatedAt.UTC(),
 	}, nil
@@ -316,7 +432,8 @@ func (r *Repo) ListPublishedAndCanceled(ctx context.Context) ([]triprepo.Trip, e
 		return nil, errors.New("nil postgres pool")
 	}
 	rows, err := r.pool.Query(ctx, `
-		SELECT trip_id, name, start_date, end_date, status, capacity_rigs, attending_rigs, created_at, updated_at
+		SELECT trip_id, name, start_date, end_date, status, capacity_rigs, capacity_people, attending_rigs, created_at, updated_at,
+			difficulty_rating, difficulty_min_rating, difficulty_max_rating, difficulty_terrain
 		FROM v_trip_summary
 		WHERE status IN ('PUBLISHED', 'CANCELED')
 		ORDER BY
@@ -338,11 +455,17 @@ func (r *Repo) ListPublishedAndCanceled(ctx context.Context) ([]triprepo.Trip, e
 			endDate   pgtype.Date
 			status    string
 			capacity  *int
+			capPeople *int
 			attending int
 			createdAt time.Time
 			updatedAt time.Time
+			rating    *int
+			minRating *int
+			maxRating *int
+			terrain   []string
 		)
-		if err := rows.Scan(&tripID, &name, &startDate, &endDate, &status, &capacity, &attending, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&tripID, &name, &startDate, &endDate, &status, &capacity, &capPeople, &attending, &createdAt, &updatedAt,
+			&rating, &minRating, &maxRating, &terrain); err != nil {
 			return nil, err
 		}
 		var attendingPtr *int
@@ -351,15 +474,17 @@ func (r *Repo) ListPublishedAndCanceled(ctx context.Context) ([]triprepo.Trip, e
 			attendingPtr = &v
 		}
 		out = append(out, triprepo.Trip{
-			ID:            domain.TripID(tripID.String()),
-			Status:        triprepo.Status(status),
-			Name:          cloneStringPtr(name),
-			StartDate:     dateToTimePtr(startDate),
-			EndDate:       dateToTimePtr(endDate),
-			CapacityRigs:  cloneIntPtr(capacity),
-			AttendingRigs: attendingPtr,
-			CreatedAt:     createdAt.UTC(),
-			UpdatedAt:     updatedAt.UTC(),
+			ID:             domain.TripID(tripID.String()),
+			Status:         triprepo.Status(status),
+			Name:           cloneStringPtr(name),
+			StartDate:      dateToTimePtr(startDate),
+			EndDate:        dateToTimePtr(endDate),
+			CapacityRigs:   cloneIntPtr(capacity),
+			CapacityPeople: cloneIntPtr(capPeople),
+			AttendingRigs:  attendingPtr,
+			Difficulty:     postgres.DifficultyFromDB(rating, minRating, maxRating, terrain),
+			CreatedAt:      createdAt.UTC(),
+			UpdatedAt:      updatedAt.UTC(),
 		})
 	}
 	if err := rows.Err(); err != nil {
@@ -434,6 +559,50 @@ func (r *Repo) ListDraftsVisibleTo(ctx context.Context, caller domain.MemberID)
 	return out, nil
 }
 
+func (r *Repo) ListBySeries(ctx context.Context, seriesID domain.TripSeriesID) ([]triprepo.Trip, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	seriesUUID, err := uuid.Parse(string(seriesID))
+	if err != nil {
+		return []triprepo.Trip{}, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT tr.external_id
+		FROM trips tr
+		JOIN trip_series series ON series.id = tr.series_id
+		WHERE series.external_id = $1
+		ORDER BY tr.series_date ASC
+	`, seriesUUID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []domain.TripID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, domain.TripID(id.String()))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]triprepo.Trip, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
 // --- helpers ---
 
 func datePtr(t *time.Time) pgtype.Date {
@@ -456,6 +625,15 @@ func dateToTimePtr(d pgtype.Date) *time.Time {
 	return &t
 }
 
+// timestamptzPtr copies an optional instant, normalized to UTC.
+func timestamptzPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
+
 func draftVisibilityForDB(t triprepo.Trip) *string {
 	if t.Status != triprepo.StatusDraft {
 		// DB invariant: non-drafts must have NULL draft_visibility.
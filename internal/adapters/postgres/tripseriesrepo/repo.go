@@ -0,0 +1,290 @@
+package tripseriesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+)
+
+// Repo is a Postgres implementation of tripseriesrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+var seriesColumns = `
+	ts.external_id,
+	creator.external_id,
+	ts.recurrence,
+	ts.start_date,
+	ts.end_date,
+	ts.duration_days,
+	ts.publish,
+	ts.created_at,
+	ts.updated_at,
+	` + postgres.TripPlanColumns("ts")
+
+func (r *Repo) Create(ctx context.Context, s tripseriesrepo.Series) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	seriesUUID, err := uuid.Parse(string(s.ID))
+	if err != nil {
+		return fmt.Errorf("invalid series id: %w", err)
+	}
+	creatorUUID, err := uuid.Parse(string(s.CreatedByMember))
+	if err != nil {
+		return fmt.Errorf("invalid creator member id: %w", err)
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var id int64
+		args := append([]any{
+			seriesUUID,
+			creatorUUID,
+			s.Recurrence.String(),
+			dateForDB(&s.StartDate),
+			dateForDB(s.EndDate),
+			s.DurationDays,
+			s.Publish,
+			s.CreatedAt.UTC(),
+			s.UpdatedAt.UTC(),
+		}, postgres.TripPlanArgs(s.Plan)...)
+		err := tx.QueryRow(ctx, `
+			INSERT INTO trip_series (
+				external_id,
+				created_by_member_id,
+				recurrence,
+				start_date,
+				end_date,
+				duration_days,
+				publish,
+				created_at,
+				updated_at,
+				`+postgres.TripPlanColumns("")+`
+			) VALUES (
+				$1,
+				(SELECT id FROM members WHERE external_id = $2),
+				$3,$4,$5,$6,$7,$8,$9,`+postgres.TripPlanPlaceholders(10)+`
+			)
+			RETURNING id
+		`, args...).Scan(&id)
+		if err != nil {
+			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode && pe.ConstraintName == "trip_series_external_id_unique" {
+				return tripseriesrepo.ErrAlreadyExists
+			}
+			return err
+		}
+		return insertArtifacts(ctx, tx, id, s.Plan.Artifacts)
+	})
+}
+
+func (r *Repo) Save(ctx context.Context, s tripseriesrepo.Series) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	seriesUUID, err := uuid.Parse(string(s.ID))
+	if err != nil {
+		return tripseriesrepo.ErrNotFound
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var id int64
+		args := append([]any{
+			seriesUUID,
+			s.Recurrence.String(),
+			dateForDB(&s.StartDate),
+			dateForDB(s.EndDate),
+			s.DurationDays,
+			s.Publish,
+			s.UpdatedAt.UTC(),
+		}, postgres.TripPlanArgs(s.Plan)...)
+		err := tx.QueryRow(ctx, `
+			UPDATE trip_series
+			SET (
+				recurrence,
+				start_date,
+				end_date,
+				duration_days,
+				publish,
+				updated_at,
+				`+postgres.TripPlanColumns("")+`
+			) = (
+				$2,$3,$4,$5,$6,$7,`+postgres.TripPlanPlaceholders(8)+`
+			)
+			WHERE external_id = $1
+			RETURNING id
+		`, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return tripseriesrepo.ErrNotFound
+			}
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM trip_series_artifacts WHERE series_id = $1`, id); err != nil {
+			return err
+		}
+		return insertArtifacts(ctx, tx, id, s.Plan.Artifacts)
+	})
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.TripSeriesID) (tripseriesrepo.Series, error) {
+	if r.pool == nil {
+		return tripseriesrepo.Series{}, errors.New("nil postgres pool")
+	}
+	seriesUUID, err := uuid.Parse(string(id))
+	if err != nil {
+		return tripseriesrepo.Series{}, tripseriesrepo.ErrNotFound
+	}
+	out, err := r.query(ctx, `WHERE ts.external_id = $1`, seriesUUID)
+	if err != nil {
+		return tripseriesrepo.Series{}, err
+	}
+	if len(out) == 0 {
+		return tripseriesrepo.Series{}, tripseriesrepo.ErrNotFound
+	}
+	return out[0], nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]tripseriesrepo.Series, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	return r.query(ctx, ``)
+}
+
+// query loads the series matching where (with their artifacts) ordered by creation, then ID.
+func (r *Repo) query(ctx context.Context, where string, args ...any) ([]tripseriesrepo.Series, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+seriesColumns+`
+		FROM trip_series ts
+		JOIN members creator ON creator.id = ts.created_by_member_id
+		`+where+`
+		ORDER BY ts.created_at ASC, ts.external_id ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]tripseriesrepo.Series, 0)
+	for rows.Next() {
+		s, err := scanSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		seriesUUID, err := uuid.Parse(string(out[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		arts, err := r.loadArtifacts(ctx, seriesUUID)
+		if err != nil {
+			return nil, err
+		}
+		out[i].Plan.Artifacts = arts
+	}
+	return out, nil
+}
+
+func (r *Repo) loadArtifacts(ctx context.Context, seriesUUID uuid.UUID) ([]domain.TripArtifact, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.type, a.title, a.url
+		FROM trip_series_artifacts a
+		JOIN trip_series ts ON ts.id = a.series_id
+		WHERE ts.external_id = $1
+		ORDER BY a.sort_order ASC
+	`, seriesUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.TripArtifact
+	for rows.Next() {
+		var typ, title, url string
+		if err := rows.Scan(&typ, &title, &url); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.TripArtifact{Type: domain.ArtifactType(typ), Title: title, URL: url})
+	}
+	return out, rows.Err()
+}
+
+func insertArtifacts(ctx context.Context, tx pgx.Tx, seriesID int64, arts []domain.TripArtifact) error {
+	for i, a := range arts {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO trip_series_artifacts (series_id, sort_order, type, title, url)
+			VALUES ($1, $2, $3, $4, $5)
+		`, seriesID, i, string(a.Type), a.Title, a.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanSeries(row pgx.Row) (tripseriesrepo.Series, error) {
+	var (
+		extID        uuid.UUID
+		creatorID    uuid.UUID
+		rule         string
+		startDate    pgtype.Date
+		endDate      pgtype.Date
+		durationDays int
+		publish      bool
+		createdAt    time.Time
+		updatedAt    time.Time
+		plan         postgres.TripPlanRow
+	)
+	dest := append([]any{&extID, &creatorID, &rule, &startDate, &endDate, &durationDays, &publish, &createdAt, &updatedAt}, plan.Dest()...)
+	if err := row.Scan(dest...); err != nil {
+		return tripseriesrepo.Series{}, err
+	}
+	rec, err := domain.ParseRecurrence(rule)
+	if err != nil {
+		return tripseriesrepo.Series{}, fmt.Errorf("series %s: invalid recurrence %q: %w", extID, rule, err)
+	}
+	s := tripseriesrepo.Series{
+		ID:              domain.TripSeriesID(extID.String()),
+		CreatedByMember: domain.MemberID(creatorID.String()),
+		Recurrence:      rec,
+		StartDate:       startDate.Time.UTC(),
+		DurationDays:    durationDays,
+		Publish:         publish,
+		Plan:            plan.Plan(),
+		CreatedAt:       createdAt.UTC(),
+		UpdatedAt:       updatedAt.UTC(),
+	}
+	if endDate.Valid {
+		d := endDate.Time.UTC()
+		s.EndDate = &d
+	}
+	return s, nil
+}
+
+func dateForDB(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	tt := t.UTC()
+	return pgtype.Date{Time: time.Date(tt.Year(), tt.Month(), tt.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
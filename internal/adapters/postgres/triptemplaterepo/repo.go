@@ -0,0 +1,218 @@
+package triptemplaterepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+)
+
+// Repo is a Postgres implementation of triptemplaterepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+var templateColumns = `
+	tt.external_id,
+	tt.name,
+	creator.external_id,
+	tt.created_at,
+	tt.updated_at,
+	` + postgres.TripPlanColumns("tt")
+
+func (r *Repo) Create(ctx context.Context, t triptemplaterepo.Template) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	templateUUID, err := uuid.Parse(string(t.ID))
+	if err != nil {
+		return fmt.Errorf("invalid template id: %w", err)
+	}
+	creatorUUID, err := uuid.Parse(string(t.CreatedByMember))
+	if err != nil {
+		return fmt.Errorf("invalid creator member id: %w", err)
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var id int64
+		args := append([]any{templateUUID, t.Name, creatorUUID, t.CreatedAt.UTC(), t.UpdatedAt.UTC()}, postgres.TripPlanArgs(t.Plan)...)
+		err := tx.QueryRow(ctx, `
+			INSERT INTO trip_templates (
+				external_id,
+				name,
+				created_by_member_id,
+				created_at,
+				updated_at,
+				`+postgres.TripPlanColumns("")+`
+			) VALUES (
+				$1,$2,
+				(SELECT id FROM members WHERE external_id = $3),
+				$4,$5,`+postgres.TripPlanPlaceholders(6)+`
+			)
+			RETURNING id
+		`, args...).Scan(&id)
+		if err != nil {
+			if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+				switch pe.ConstraintName {
+				case "trip_templates_external_id_unique":
+					return triptemplaterepo.ErrAlreadyExists
+				case "trip_templates_name_unique":
+					return triptemplaterepo.ErrNameTaken
+				}
+			}
+			return err
+		}
+
+		for i, a := range t.Plan.Artifacts {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO trip_template_artifacts (template_id, sort_order, type, title, url)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, i, string(a.Type), a.Title, a.URL); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repo) GetByID(ctx context.Context, id domain.TripTemplateID) (triptemplaterepo.Template, error) {
+	if r.pool == nil {
+		return triptemplaterepo.Template{}, errors.New("nil postgres pool")
+	}
+	templateUUID, err := uuid.Parse(string(id))
+	if err != nil {
+		return triptemplaterepo.Template{}, triptemplaterepo.ErrNotFound
+	}
+	out, err := r.query(ctx, `WHERE tt.external_id = $1`, templateUUID)
+	if err != nil {
+		return triptemplaterepo.Template{}, err
+	}
+	if len(out) == 0 {
+		return triptemplaterepo.Template{}, triptemplaterepo.ErrNotFound
+	}
+	return out[0], nil
+}
+
+func (r *Repo) List(ctx context.Context) ([]triptemplaterepo.Template, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	return r.query(ctx, ``)
+}
+
+func (r *Repo) Delete(ctx context.Context, id domain.TripTemplateID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	templateUUID, err := uuid.Parse(string(id))
+	if err != nil {
+		return triptemplaterepo.ErrNotFound
+	}
+	tag, err := r.pool.Exec(ctx, `DELETE FROM trip_templates WHERE external_id = $1`, templateUUID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return triptemplaterepo.ErrNotFound
+	}
+	return nil
+}
+
+// query loads the templates matching where (with their artifacts) ordered by name, then ID.
+func (r *Repo) query(ctx context.Context, where string, args ...any) ([]triptemplaterepo.Template, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+templateColumns+`
+		FROM trip_templates tt
+		JOIN members creator ON creator.id = tt.created_by_member_id
+		`+where+`
+		ORDER BY lower(tt.name) ASC, tt.external_id ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]triptemplaterepo.Template, 0)
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		templateUUID, err := uuid.Parse(string(out[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		arts, err := r.loadArtifacts(ctx, templateUUID)
+		if err != nil {
+			return nil, err
+		}
+		out[i].Plan.Artifacts = arts
+	}
+	return out, nil
+}
+
+func (r *Repo) loadArtifacts(ctx context.Context, templateUUID uuid.UUID) ([]domain.TripArtifact, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.type, a.title, a.url
+		FROM trip_template_artifacts a
+		JOIN trip_templates tt ON tt.id = a.template_id
+		WHERE tt.external_id = $1
+		ORDER BY a.sort_order ASC
+	`, templateUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.TripArtifact
+	for rows.Next() {
+		var typ, title, url string
+		if err := rows.Scan(&typ, &title, &url); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.TripArtifact{Type: domain.ArtifactType(typ), Title: title, URL: url})
+	}
+	return out, rows.Err()
+}
+
+func scanTemplate(row pgx.Row) (triptemplaterepo.Template, error) {
+	var (
+		extID     uuid.UUID
+		name      string
+		creatorID uuid.UUID
+		createdAt time.Time
+		updatedAt time.Time
+		plan      postgres.TripPlanRow
+	)
+	dest := append([]any{&extID, &name, &creatorID, &createdAt, &updatedAt}, plan.Dest()...)
+	if err := row.Scan(dest...); err != nil {
+		return triptemplaterepo.Template{}, err
+	}
+	return triptemplaterepo.Template{
+		ID:              domain.TripTemplateID(extID.String()),
+		Name:            name,
+		CreatedByMember: domain.MemberID(creatorID.String()),
+		Plan:            plan.Plan(),
+		CreatedAt:       createdAt.UTC(),
+		UpdatedAt:       updatedAt.UTC(),
+	}, nil
+}
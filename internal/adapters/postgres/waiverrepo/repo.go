@@ -0,0 +1,348 @@
+package waiverrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/adapters/postgres"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
+)
+
+// Repo is a Postgres implementation of waiverrepo.Repository.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepo(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+func (r *Repo) Create(ctx context.Context, w waiverrepo.Waiver, first waiverrepo.Version) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	id, err := uuid.Parse(string(w.ID))
+	if err != nil {
+		return fmt.Errorf("invalid waiver id: %w", err)
+	}
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var pk int64
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO waivers (external_id, title, required_for_all_trips, created_by, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, id, w.Title, w.RequiredForAllTrips, w.CreatedBy, w.CreatedAt.UTC()).Scan(&pk); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO waiver_versions (waiver_id, version, body, version_hash, published_at)
+			VALUES ($1, 1, $2, $3, $4)
+		`, pk, first.Body, first.Hash, first.PublishedAt.UTC())
+		return err
+	})
+	if pe, ok := postgres.AsPgError(err); ok && pe.Code == postgres.UniqueViolationCode {
+		return waiverrepo.ErrAlreadyExists
+	}
+	return err
+}
+
+const selectWaiver = `
+	SELECT external_id, title, required_for_all_trips, created_by, created_at
+	FROM waivers
+`
+
+func (r *Repo) Get(ctx context.Context, id domain.WaiverID) (waiverrepo.Waiver, error) {
+	if r.pool == nil {
+		return waiverrepo.Waiver{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return waiverrepo.Waiver{}, waiverrepo.ErrNotFound
+	}
+	w, err := scanWaiver(r.pool.QueryRow(ctx, selectWaiver+` WHERE external_id = $1`, uid))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return waiverrepo.Waiver{}, waiverrepo.ErrNotFound
+	}
+	return w, err
+}
+
+func (r *Repo) List(ctx context.Context) ([]waiverrepo.Waiver, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	rows, err := r.pool.Query(ctx, selectWaiver+` ORDER BY created_at ASC, external_id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]waiverrepo.Waiver, 0)
+	for rows.Next() {
+		w, err := scanWaiver(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+func scanWaiver(row pgx.Row) (waiverrepo.Waiver, error) {
+	var (
+		id  uuid.UUID
+		out waiverrepo.Waiver
+	)
+	if err := row.Scan(&id, &out.Title, &out.RequiredForAllTrips, &out.CreatedBy, &out.CreatedAt); err != nil {
+		return waiverrepo.Waiver{}, err
+	}
+	out.ID = domain.WaiverID(id.String())
+	out.CreatedAt = out.CreatedAt.UTC()
+	return out, nil
+}
+
+func (r *Repo) AddVersion(ctx context.Context, v waiverrepo.Version) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(v.WaiverID))
+	if err != nil {
+		return waiverrepo.ErrNotFound
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		// Lock the waiver so concurrent publishers agree on the latest version.
+		var (
+			pk     int64
+			latest int
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT w.id, (SELECT COALESCE(MAX(version), 0) FROM waiver_versions WHERE waiver_id = w.id)
+			FROM waivers w
+			WHERE w.external_id = $1
+			FOR UPDATE
+		`, uid).Scan(&pk, &latest)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return waiverrepo.ErrNotFound
+			}
+			return err
+		}
+		if v.Version != latest+1 {
+			return waiverrepo.ErrVersionConflict
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO waiver_versions (waiver_id, version, body, version_hash, published_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, pk, v.Version, v.Body, v.Hash, v.PublishedAt.UTC())
+		return err
+	})
+}
+
+func (r *Repo) LatestVersion(ctx context.Context, id domain.WaiverID) (waiverrepo.Version, error) {
+	if r.pool == nil {
+		return waiverrepo.Version{}, errors.New("nil postgres pool")
+	}
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return waiverrepo.Version{}, waiverrepo.ErrNotFound
+	}
+	out := waiverrepo.Version{WaiverID: id}
+	err = r.pool.QueryRow(ctx, `
+		SELECT v.version, v.body, v.version_hash, v.published_at
+		FROM waiver_versions v
+		JOIN waivers w ON w.id = v.waiver_id
+		WHERE w.external_id = $1
+		ORDER BY v.version DESC
+		LIMIT 1
+	`, uid).Scan(&out.Version, &out.Body, &out.Hash, &out.PublishedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return waiverrepo.Version{}, waiverrepo.ErrNotFound
+		}
+		return waiverrepo.Version{}, err
+	}
+	out.PublishedAt = out.PublishedAt.UTC()
+	return out, nil
+}
+
+func (r *Repo) Acknowledge(ctx context.Context, a waiverrepo.Acknowledgement) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	wid, err := uuid.Parse(string(a.WaiverID))
+	if err != nil {
+		return waiverrepo.ErrNotFound
+	}
+	mid, err := uuid.Parse(string(a.MemberID))
+	if err != nil {
+		return fmt.Errorf("invalid member id: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO waiver_acknowledgements (waiver_id, version, member_id, version_hash, subject, acknowledged_at)
+		VALUES (
+			(SELECT id FROM waivers WHERE external_id = $1),
+			$2,
+			(SELECT id FROM members WHERE external_id = $3),
+			$4,
+			$5,
+			$6
+		)
+		ON CONFLICT (waiver_id, version, member_id) DO NOTHING
+	`, wid, a.Version, mid, a.VersionHash, string(a.Subject), a.AcknowledgedAt.UTC())
+	if pe, ok := postgres.AsPgError(err); ok && (pe.Code == postgres.ForeignKeyViolationCode || pe.Code == postgres.NotNullViolationCode) {
+		// A missing version fails the foreign key; a missing waiver leaves waiver_id NULL.
+		return waiverrepo.ErrNotFound
+	}
+	return err
+}
+
+const selectAcknowledgement = `
+	SELECT w.external_id, a.version, m.external_id, a.version_hash, a.subject, a.acknowledged_at
+	FROM waiver_acknowledgements a
+	JOIN waivers w ON w.id = a.waiver_id
+	JOIN members m ON m.id = a.member_id
+`
+
+func (r *Repo) ListAcknowledgementsByMember(ctx context.Context, memberID domain.MemberID) ([]waiverrepo.Acknowledgement, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	mid, err := uuid.Parse(string(memberID))
+	if err != nil {
+		return []waiverrepo.Acknowledgement{}, nil
+	}
+	return r.listAcknowledgements(ctx, selectAcknowledgement+`
+		WHERE m.external_id = $1
+		ORDER BY a.acknowledged_at ASC, w.external_id ASC, a.version ASC
+	`, mid)
+}
+
+func (r *Repo) ListAcknowledgementsByWaiver(ctx context.Context, id domain.WaiverID) ([]waiverrepo.Acknowledgement, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	wid, err := uuid.Parse(string(id))
+	if err != nil {
+		return []waiverrepo.Acknowledgement{}, nil
+	}
+	return r.listAcknowledgements(ctx, selectAcknowledgement+`
+		WHERE w.external_id = $1
+		ORDER BY a.acknowledged_at ASC, m.external_id ASC, a.version ASC
+	`, wid)
+}
+
+func (r *Repo) listAcknowledgements(ctx context.Context, query string, arg uuid.UUID) ([]waiverrepo.Acknowledgement, error) {
+	rows, err := r.pool.Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]waiverrepo.Acknowledgement, 0)
+	for rows.Next() {
+		var (
+			wid, mid uuid.UUID
+			subject  string
+			at       time.Time
+			a        waiverrepo.Acknowledgement
+		)
+		if err := rows.Scan(&wid, &a.Version, &mid, &a.VersionHash, &subject, &at); err != nil {
+			return nil, err
+		}
+		a.WaiverID = domain.WaiverID(wid.String())
+		a.MemberID = domain.MemberID(mid.String())
+		a.Subject = domain.SubjectID(subject)
+		a.AcknowledgedAt = at.UTC()
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) SetTripRequirements(ctx context.Context, tripID domain.TripID, ids []domain.WaiverID) error {
+	if r.pool == nil {
+		return errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return fmt.Errorf("invalid trip id: %w", err)
+	}
+	wids := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		wid, err := uuid.Parse(string(id))
+		if err != nil {
+			return waiverrepo.ErrNotFound
+		}
+		wids = append(wids, wid)
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var known int
+		if err := tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM waivers WHERE external_id = ANY($1::uuid[])
+		`, wids).Scan(&known); err != nil {
+			return err
+		}
+		if known != countDistinct(wids) {
+			return waiverrepo.ErrNotFound
+		}
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM trip_required_waivers
+			WHERE trip_id = (SELECT id FROM trips WHERE external_id = $1)
+		`, tid); err != nil {
+			return err
+		}
+		if len(wids) == 0 {
+			return nil
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO trip_required_waivers (trip_id, waiver_id)
+			SELECT t.id, w.id
+			FROM trips t, waivers w
+			WHERE t.external_id = $1 AND w.external_id = ANY($2::uuid[])
+			ON CONFLICT DO NOTHING
+		`, tid, wids)
+		return err
+	})
+}
+
+func countDistinct(ids []uuid.UUID) int {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
+
+func (r *Repo) ListTripRequirements(ctx context.Context, tripID domain.TripID) ([]domain.WaiverID, error) {
+	if r.pool == nil {
+		return nil, errors.New("nil postgres pool")
+	}
+	tid, err := uuid.Parse(string(tripID))
+	if err != nil {
+		return []domain.WaiverID{}, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT w.external_id
+		FROM trip_required_waivers rw
+		JOIN trips t ON t.id = rw.trip_id
+		JOIN waivers w ON w.id = rw.waiver_id
+		WHERE t.external_id = $1
+		ORDER BY w.external_id::text ASC
+	`, tid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make([]domain.WaiverID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.WaiverID(id.String()))
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,59 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
+)
+
+// Options configures SMTP delivery. Username/Password enable PLAIN auth (the server must offer TLS).
+type Options struct {
+	Addr     string // host:port
+	From     string
+	Username string
+	Password string
+}
+
+// Mailer is an SMTP implementation of mailer.Mailer.
+type Mailer struct {
+	opts Options
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewMailer(opts Options) *Mailer {
+	return &Mailer{opts: opts, send: smtp.SendMail}
+}
+
+func (m *Mailer) Send(ctx context.Context, msg mailer.Message) error {
+	_ = ctx
+	if m.opts.Addr == "" || m.opts.From == "" {
+		return errors.New("smtp mailer is not configured")
+	}
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return errors.New("smtp: header values must not contain line breaks")
+	}
+	var auth smtp.Auth
+	if m.opts.Username != "" {
+		host, _, err := net.SplitHostPort(m.opts.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp: invalid addr: %w", err)
+		}
+		auth = smtp.PlainAuth("", m.opts.Username, m.opts.Password, host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.opts.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return m.send(m.opts.Addr, auth, m.opts.From, []string{msg.To}, []byte(b.String()))
+}
@@ -0,0 +1,248 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/apikeyrepo"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+)
+
+// TokenPrefix marks every API key token so leaked keys are easy to recognise (and scan for).
+const TokenPrefix = "ebo"
+
+const (
+	prefixBytes = 6
+	secretBytes = 32
+)
+
+// Service manages service-account API keys: issuing, revoking, and authenticating them.
+type Service struct {
+	repo apikeyrepo.Repository
+	clk  clockport.Clock
+
+	newKeyID func() domain.APIKeyID
+	random   func(n int) (string, error)
+
+	// UsageLimit bounds ListUsage result size.
+	UsageLimit int
+}
+
+func NewService(repo apikeyrepo.Repository, clk clockport.Clock) *Service {
+	return &Service{
+		repo: repo,
+		clk:  clk,
+		newKeyID: func() domain.APIKeyID {
+			return domain.APIKeyID(uuid.NewString())
+		},
+		random:     randomHex,
+		UsageLimit: 100,
+	}
+}
+
+type CreateInput struct {
+	Name      string
+	Scopes    []domain.APIKeyScope
+	CreatedBy string
+}
+
+// Created is returned once when a key is issued. Token is the only time the secret is available.
+type Created struct {
+	Key   domain.APIKey
+	Token string
+}
+
+// Create issues a new API key. The returned token must be handed to the service owner;
+// only its hash is persisted.
+func (s *Service) Create(ctx context.Context, in CreateInput) (Created, error) {
+	name := strings.TrimSpace(in.Name)
+	createdBy := strings.TrimSpace(in.CreatedBy)
+	details := map[string]any{}
+	if name == "" {
+		details["name"] = "required"
+	}
+	if createdBy == "" {
+		details["createdBy"] = "required"
+	}
+	scopes, bad := normalizeScopes(in.Scopes)
+	if len(bad) > 0 {
+		details["scopes"] = "unknown scopes: " + strings.Join(bad, ", ")
+	} else if len(scopes) == 0 {
+		details["scopes"] = "at least one scope is required"
+	}
+	if len(details) > 0 {
+		return Created{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid api key", Details: details}
+	}
+
+	prefix, err := s.random(prefixBytes)
+	if err != nil {
+		return Created{}, err
+	}
+	secret, err := s.random(secretBytes)
+	if err != nil {
+		return Created{}, err
+	}
+
+	k := domain.APIKey{
+		ID:        s.newKeyID(),
+		Name:      name,
+		Prefix:    prefix,
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: s.clk.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, apikeyrepo.APIKey{APIKey: k, SecretHash: hashSecret(secret)}); err != nil {
+		return Created{}, err
+	}
+	return Created{Key: k, Token: TokenPrefix + "_" + prefix + "_" + secret}, nil
+}
+
+// Revoke disables a key immediately. Revoking an already revoked key is a no-op.
+func (s *Service) Revoke(ctx context.Context, id domain.APIKeyID, revokedBy string) (domain.APIKey, error) {
+	revokedBy = strings.TrimSpace(revokedBy)
+	if revokedBy == "" {
+		return domain.APIKey{}, &Error{
+			Status:  422,
+			Code:    "VALIDATION_ERROR",
+			Message: "invalid revocation",
+			Details: map[string]any{"revokedBy": "required"},
+		}
+	}
+	if err := s.repo.Revoke(ctx, id, revokedBy, s.clk.Now().UTC()); err != nil {
+		if errors.Is(err, apikeyrepo.ErrNotFound) {
+			return domain.APIKey{}, &Error{Status: 404, Code: "API_KEY_NOT_FOUND", Message: "api key not found"}
+		}
+		return domain.APIKey{}, err
+	}
+	k, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	return k.APIKey, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]domain.APIKey, error) {
+	ks, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.APIKey, 0, len(ks))
+	for _, k := range ks {
+		out = append(out, k.APIKey)
+	}
+	return out, nil
+}
+
+// ListUsage returns the key's audit trail, one entry per UTC day, method, path and client, most
+// recently used first.
+func (s *Service) ListUsage(ctx context.Context, id domain.APIKeyID) ([]domain.APIKeyUsage, error) {
+	us, err := s.repo.ListUsage(ctx, id, s.UsageLimit)
+	if err != nil {
+		if errors.Is(err, apikeyrepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "API_KEY_NOT_FOUND", Message: "api key not found"}
+		}
+		return nil, err
+	}
+	return us, nil
+}
+
+// Usage describes the request an API key is being presented for; it is counted in the audit trail.
+type Usage struct {
+	Method   string
+	Path     string
+	ClientIP string
+}
+
+// Authenticate resolves a presented token to an active key and records its use.
+// All failures (malformed, unknown, wrong secret, revoked) surface as the same 401 error.
+func (s *Service) Authenticate(ctx context.Context, token string, usage Usage) (domain.APIKey, error) {
+	unauthorized := &Error{Status: 401, Code: "UNAUTHORIZED", Message: "invalid api key"}
+
+	prefix, secret, ok := parseToken(token)
+	if !ok {
+		return domain.APIKey{}, unauthorized
+	}
+	k, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, apikeyrepo.ErrNotFound) {
+			return domain.APIKey{}, unauthorized
+		}
+		return domain.APIKey{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.SecretHash)) != 1 {
+		return domain.APIKey{}, unauthorized
+	}
+	if k.RevokedAt != nil {
+		return domain.APIKey{}, unauthorized
+	}
+
+	now := s.clk.Now().UTC()
+	if err := s.repo.RecordUsage(ctx, apikeyrepo.Usage{
+		KeyID:    k.ID,
+		UsedAt:   now,
+		Method:   usage.Method,
+		Path:     usage.Path,
+		ClientIP: usage.ClientIP,
+	}); err != nil {
+		return domain.APIKey{}, err
+	}
+	k.LastUsedAt = &now
+	return k.APIKey, nil
+}
+
+func parseToken(token string) (prefix string, secret string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(token), "_")
+	if len(parts) != 3 || parts[0] != TokenPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func normalizeScopes(in []domain.APIKeyScope) (scopes []domain.APIKeyScope, unknown []string) {
+	seen := make(map[domain.APIKeyScope]bool, len(in))
+	for _, raw := range in {
+		sc := domain.APIKeyScope(strings.TrimSpace(string(raw)))
+		if seen[sc] {
+			continue
+		}
+		seen[sc] = true
+		if !isKnownScope(sc) {
+			unknown = append(unknown, string(sc))
+			continue
+		}
+		scopes = append(scopes, sc)
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i] < scopes[j] })
+	return scopes, unknown
+}
+
+func isKnownScope(sc domain.APIKeyScope) bool {
+	for _, k := range domain.KnownAPIKeyScopes {
+		if k == sc {
+			return true
+		}
+	}
+	return false
+}
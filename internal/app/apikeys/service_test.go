@@ -0,0 +1,132 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	memapikeyrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/apikeyrepo"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+func requireAppError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != status || ae.Code != code {
+		t.Fatalf("err=%v (type=%T), want %s %d", err, err, code, status)
+	}
+}
+
+func TestService_CreateAuthenticateRevoke(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repo := memapikeyrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(repo, clk)
+
+	created, err := svc.Create(ctx, CreateInput{
+		Name:      "  digest bot ",
+		Scopes:    []domain.APIKeyScope{domain.APIKeyScopeTripsRead, domain.APIKeyScopeTripsRead},
+		CreatedBy: "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.Key.Name != "digest bot" || len(created.Key.Scopes) != 1 {
+		t.Fatalf("created key = %+v", created.Key)
+	}
+	if !strings.HasPrefix(created.Token, "ebo_"+created.Key.Prefix+"_") {
+		t.Fatalf("token %q does not carry prefix %q", created.Token, created.Key.Prefix)
+	}
+	stored, err := repo.GetByID(ctx, created.Key.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if strings.Contains(created.Token, stored.SecretHash) {
+		t.Fatalf("secret hash must not equal the secret")
+	}
+
+	clk.Add(time.Minute)
+	k, err := svc.Authenticate(ctx, created.Token, Usage{Method: "GET", Path: "/trips", ClientIP: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if k.ID != created.Key.ID || k.LastUsedAt == nil || !k.LastUsedAt.Equal(clk.Now()) {
+		t.Fatalf("authenticated key = %+v", k)
+	}
+	usage, err := svc.ListUsage(ctx, k.ID)
+	if err != nil {
+		t.Fatalf("ListUsage: %v", err)
+	}
+	if len(usage) != 1 || usage[0].Path != "/trips" || usage[0].ClientIP != "192.0.2.1" || usage[0].RequestCount != 1 {
+		t.Fatalf("usage = %+v", usage)
+	}
+
+	// Repeat requests on the same day are counted on the same entry.
+	clk.Add(time.Minute)
+	if _, err := svc.Authenticate(ctx, created.Token, Usage{Method: "GET", Path: "/trips", ClientIP: "192.0.2.1"}); err != nil {
+		t.Fatalf("Authenticate again: %v", err)
+	}
+	usage, err = svc.ListUsage(ctx, k.ID)
+	if err != nil {
+		t.Fatalf("ListUsage: %v", err)
+	}
+	if len(usage) != 1 || usage[0].RequestCount != 2 || !usage[0].LastUsedAt.Equal(clk.Now()) {
+		t.Fatalf("usage after repeat = %+v", usage)
+	}
+
+	revoked, err := svc.Revoke(ctx, k.ID, "admin-2")
+	if err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if revoked.RevokedAt == nil || revoked.RevokedBy == nil || *revoked.RevokedBy != "admin-2" {
+		t.Fatalf("revoked key = %+v", revoked)
+	}
+	_, err = svc.Authenticate(ctx, created.Token, Usage{Method: "GET", Path: "/trips"})
+	requireAppError(t, err, 401, "UNAUTHORIZED")
+}
+
+func TestService_Authenticate_RejectsBadTokens(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := NewService(memapikeyrepo.NewRepo(), memclock.NewManualClock(time.Unix(100, 0).UTC()))
+	created, err := svc.Create(ctx, CreateInput{Name: "bot", Scopes: []domain.APIKeyScope{domain.APIKeyScopeTripsRead}, CreatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for _, tok := range []string{
+		"",
+		"garbage",
+		"ebo_" + created.Key.Prefix,
+		"ebo_" + created.Key.Prefix + "_wrongsecret",
+		"xyz_" + strings.TrimPrefix(created.Token, "ebo_"),
+		"ebo_000000000000_" + strings.Repeat("a", 64),
+	} {
+		_, err := svc.Authenticate(ctx, tok, Usage{})
+		requireAppError(t, err, 401, "UNAUTHORIZED")
+	}
+}
+
+func TestService_Create_Validation(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(memapikeyrepo.NewRepo(), memclock.NewManualClock(time.Unix(100, 0).UTC()))
+	_, err := svc.Create(context.Background(), CreateInput{Name: " ", Scopes: []domain.APIKeyScope{"trips:delete"}})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+	ae := (*Error)(nil)
+	_ = errors.As(err, &ae)
+	for _, f := range []string{"name", "createdBy", "scopes"} {
+		if _, ok := ae.Details[f]; !ok {
+			t.Fatalf("details missing %q: %+v", f, ae.Details)
+		}
+	}
+
+	_, err = svc.Revoke(context.Background(), domain.APIKeyID("missing"), "admin")
+	requireAppError(t, err, 404, "API_KEY_NOT_FOUND")
+}
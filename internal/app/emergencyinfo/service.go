@@ -0,0 +1,376 @@
+package emergencyinfo
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/emergencyinforepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+const (
+	maxContactNameLen  = 100
+	maxMedicalNotesLen = 2000
+	minPhoneDigits     = 7
+	maxPhoneLen        = 25
+
+	// defaultAccessWindowDays is how many days before a trip starts organizers can read
+	// attendees' emergency info.
+	defaultAccessWindowDays = 2
+	// accessMargin keeps the window open through the last trip day wherever the trip runs,
+	// since trip dates carry no time zone.
+	accessMargin = 14 * time.Hour
+)
+
+// Service manages members' emergency info. Members manage their own; organizers of a
+// published trip can read it for members attending that trip, from AccessWindowDays before
+// the trip starts through its last day. The info is encrypted with AES-256-GCM under the key
+// provider's current key, and every read and change is written to the access log.
+type Service struct {
+	repo    emergencyinforepo.Repository
+	keys    keyprovider.Provider
+	members memberrepo.Repository
+	trips   triprepo.Repository
+	rsvps   rsvprepo.Repository
+	clk     clockport.Clock
+
+	// AccessWindowDays is how many days before a trip's start date its organizers can read
+	// attendees' emergency info.
+	AccessWindowDays int
+}
+
+func NewService(repo emergencyinforepo.Repository, keys keyprovider.Provider, members memberrepo.Repository, trips triprepo.Repository, rsvps rsvprepo.Repository, clk clockport.Clock) *Service {
+	return &Service{
+		repo:             repo,
+		keys:             keys,
+		members:          members,
+		trips:            trips,
+		rsvps:            rsvps,
+		clk:              clk,
+		AccessWindowDays: defaultAccessWindowDays,
+	}
+}
+
+// Input is a member's emergency info as entered. Empty optional fields are cleared.
+type Input struct {
+	ContactName  string
+	ContactPhone string
+	MedicalNotes *string
+	BloodType    *string
+}
+
+// GetMyEmergencyInfo returns the caller's emergency info.
+func (s *Service) GetMyEmergencyInfo(ctx context.Context, caller domain.MemberID) (domain.EmergencyInfo, error) {
+	info, err := s.open(ctx, caller)
+	if err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	if err := s.audit(ctx, caller, caller, "", domain.EmergencyInfoViewed); err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	return info, nil
+}
+
+// PutMyEmergencyInfo creates or replaces the caller's emergency info.
+func (s *Service) PutMyEmergencyInfo(ctx context.Context, caller domain.MemberID, in Input) (domain.EmergencyInfo, error) {
+	info, err := normalizeInput(in)
+	if err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	info.UpdatedAt = s.clk.Now()
+	if err := s.seal(ctx, caller, info); err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	if err := s.audit(ctx, caller, caller, "", domain.EmergencyInfoUpdated); err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	return info, nil
+}
+
+// DeleteMyEmergencyInfo removes the caller's emergency info. The access log is kept.
+func (s *Service) DeleteMyEmergencyInfo(ctx context.Context, caller domain.MemberID) error {
+	if err := s.repo.Delete(ctx, caller); err != nil {
+		if errors.Is(err, emergencyinforepo.ErrNotFound) {
+			return &Error{Status: 404, Code: "EMERGENCY_INFO_NOT_FOUND", Message: "emergency info not found"}
+		}
+		return err
+	}
+	return s.audit(ctx, caller, caller, "", domain.EmergencyInfoDeleted)
+}
+
+// ListMyEmergencyInfoAccess returns who read or changed the caller's emergency info, oldest first.
+func (s *Service) ListMyEmergencyInfoAccess(ctx context.Context, caller domain.MemberID) ([]domain.EmergencyInfoAccess, error) {
+	entries, err := s.repo.ListAccess(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.EmergencyInfoAccess, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e.EmergencyInfoAccess)
+	}
+	return out, nil
+}
+
+// GetAttendeeEmergencyInfo returns the emergency info of a member attending the trip. Only the
+// trip's organizers may read it, and only within the access window.
+func (s *Service) GetAttendeeEmergencyInfo(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID) (domain.EmergencyInfo, error) {
+	if _, err := s.organizerTrip(ctx, caller, tripID); err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	rec, err := s.rsvps.Get(ctx, tripID, memberID)
+	if err != nil && !errors.Is(err, rsvprepo.ErrNotFound) {
+		return domain.EmergencyInfo{}, err
+	}
+	if err != nil || rec.Status != rsvprepo.StatusYes {
+		return domain.EmergencyInfo{}, &Error{Status: 403, Code: "MEMBER_NOT_ATTENDING", Message: "emergency info is only available for members attending the trip"}
+	}
+	info, err := s.open(ctx, memberID)
+	if err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	if err := s.audit(ctx, memberID, caller, tripID, domain.EmergencyInfoViewed); err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	return info, nil
+}
+
+// ListTripEmergencyInfo returns every attending member's emergency info, sorted by display
+// name, for the trip leader's roster. The same rules as GetAttendeeEmergencyInfo apply.
+func (s *Service) ListTripEmergencyInfo(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.AttendeeEmergencyInfo, error) {
+	if _, err := s.organizerTrip(ctx, caller, tripID); err != nil {
+		return nil, err
+	}
+	recs, err := s.rsvps.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.AttendeeEmergencyInfo, 0, len(recs))
+	for _, r := range recs {
+		if r.Status != rsvprepo.StatusYes {
+			continue
+		}
+		m, err := s.members.GetByID(ctx, r.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		a := domain.AttendeeEmergencyInfo{Member: domain.MemberSummary{ID: m.ID, DisplayName: m.DisplayName, Email: m.Email, GroupAliasEmail: m.GroupAliasEmail}}
+		info, err := s.open(ctx, r.MemberID)
+		var ae *Error
+		switch {
+		case err == nil:
+			a.Info = &info
+			if err := s.audit(ctx, r.MemberID, caller, tripID, domain.EmergencyInfoViewed); err != nil {
+				return nil, err
+			}
+		case !errors.As(err, &ae) || ae.Code != "EMERGENCY_INFO_NOT_FOUND":
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		di, dj := strings.ToLower(out[i].Member.DisplayName), strings.ToLower(out[j].Member.DisplayName)
+		if di == dj {
+			return out[i].Member.ID < out[j].Member.ID
+		}
+		return di < dj
+	})
+	return out, nil
+}
+
+// organizerTrip loads a published trip the caller organizes and checks the access window.
+func (s *Service) organizerTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	if t.Status == triprepo.StatusDraft && !slices.Contains(t.OrganizerMemberIDs, caller) {
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if t.Status != triprepo.StatusPublished {
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "emergency info is only available for published trips"}
+	}
+	if !slices.Contains(t.OrganizerMemberIDs, caller) {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can read attendees' emergency info"}
+	}
+	if t.StartDate == nil || t.EndDate == nil {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "EMERGENCY_INFO_WINDOW_CLOSED", Message: "emergency info is only available around the trip's dates"}
+	}
+	opens, closes := s.accessWindow(*t.StartDate, *t.EndDate)
+	if now := s.clk.Now(); now.Before(opens) || !now.Before(closes) {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "EMERGENCY_INFO_WINDOW_CLOSED", Message: "emergency info is only available around the trip's dates",
+			Details: map[string]any{"opensAt": opens.Format(time.RFC3339), "closesAt": closes.Format(time.RFC3339)}}
+	}
+	return t, nil
+}
+
+// accessWindow returns when organizers can start and must stop reading emergency info.
+func (s *Service) accessWindow(start, end time.Time) (time.Time, time.Time) {
+	opens := calendarDate(start).AddDate(0, 0, -s.AccessWindowDays).Add(-accessMargin)
+	closes := calendarDate(end).AddDate(0, 0, 1).Add(accessMargin)
+	return opens, closes
+}
+
+func (s *Service) audit(ctx context.Context, memberID, by domain.MemberID, tripID domain.TripID, action domain.EmergencyInfoAction) error {
+	return s.repo.AppendAccess(ctx, emergencyinforepo.AccessEntry{
+		MemberID: memberID,
+		EmergencyInfoAccess: domain.EmergencyInfoAccess{
+			AccessedBy: by,
+			TripID:     tripID,
+			Action:     action,
+			AccessedAt: s.clk.Now(),
+		},
+	})
+}
+
+// sealedInfo is the plaintext encrypted at rest.
+type sealedInfo struct {
+	ContactName  string  `json:"contactName"`
+	ContactPhone string  `json:"contactPhone"`
+	MedicalNotes *string `json:"medicalNotes,omitempty"`
+	BloodType    *string `json:"bloodType,omitempty"`
+}
+
+// seal encrypts info under the current key. The member ID is authenticated with it, so a
+// ciphertext copied onto another member fails to open.
+func (s *Service) seal(ctx context.Context, memberID domain.MemberID, info domain.EmergencyInfo) error {
+	key, err := s.keys.Current(ctx)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(sealedInfo{ContactName: info.ContactName, ContactPhone: info.ContactPhone, MedicalNotes: info.MedicalNotes, BloodType: info.BloodType})
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return s.repo.Put(ctx, emergencyinforepo.Sealed{
+		MemberID:   memberID,
+		KeyID:      key.ID,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plain, []byte(memberID)),
+		UpdatedAt:  info.UpdatedAt,
+	})
+}
+
+func (s *Service) open(ctx context.Context, memberID domain.MemberID) (domain.EmergencyInfo, error) {
+	sealed, err := s.repo.Get(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, emergencyinforepo.ErrNotFound) {
+			return domain.EmergencyInfo{}, &Error{Status: 404, Code: "EMERGENCY_INFO_NOT_FOUND", Message: "emergency info not found"}
+		}
+		return domain.EmergencyInfo{}, err
+	}
+	key, err := s.keys.Get(ctx, sealed.KeyID)
+	if err != nil {
+		return domain.EmergencyInfo{}, fmt.Errorf("emergency info key %q: %w", sealed.KeyID, err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return domain.EmergencyInfo{}, err
+	}
+	plain, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(memberID))
+	if err != nil {
+		return domain.EmergencyInfo{}, fmt.Errorf("decrypt emergency info: %w", err)
+	}
+	var si sealedInfo
+	if err := json.Unmarshal(plain, &si); err != nil {
+		return domain.EmergencyInfo{}, fmt.Errorf("decode emergency info: %w", err)
+	}
+	return domain.EmergencyInfo{
+		ContactName:  si.ContactName,
+		ContactPhone: si.ContactPhone,
+		MedicalNotes: si.MedicalNotes,
+		BloodType:    si.BloodType,
+		UpdatedAt:    sealed.UpdatedAt,
+	}, nil
+}
+
+func newGCM(key keyprovider.Key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("emergency info key %q: %w", key.ID, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func normalizeInput(in Input) (domain.EmergencyInfo, error) {
+	details := map[string]any{}
+	out := domain.EmergencyInfo{
+		ContactName:  domain.NormalizeHumanName(in.ContactName),
+		ContactPhone: strings.TrimSpace(in.ContactPhone),
+	}
+	if out.ContactName == "" || utf8.RuneCountInString(out.ContactName) > maxContactNameLen {
+		details["contactName"] = fmt.Sprintf("must be 1-%d characters", maxContactNameLen)
+	}
+	if !validPhone(out.ContactPhone) {
+		details["contactPhone"] = "must be a phone number"
+	}
+	if in.MedicalNotes != nil {
+		if notes := strings.TrimSpace(*in.MedicalNotes); notes != "" {
+			if utf8.RuneCountInString(notes) > maxMedicalNotesLen {
+				details["medicalNotes"] = fmt.Sprintf("must be at most %d characters", maxMedicalNotesLen)
+			}
+			out.MedicalNotes = &notes
+		}
+	}
+	if in.BloodType != nil {
+		if bt := strings.ToUpper(strings.TrimSpace(*in.BloodType)); bt != "" {
+			if !slices.Contains(domain.BloodTypes, bt) {
+				details["bloodType"] = "must be one of " + strings.Join(domain.BloodTypes, ", ")
+			}
+			out.BloodType = &bt
+		}
+	}
+	if len(details) > 0 {
+		return domain.EmergencyInfo{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid emergency info", Details: details}
+	}
+	return out, nil
+}
+
+// validPhone accepts digits with the usual separators (+ - . ( ) and spaces).
+func validPhone(p string) bool {
+	if p == "" || len(p) > maxPhoneLen {
+		return false
+	}
+	digits := 0
+	for _, r := range p {
+		switch {
+		case unicode.IsDigit(r) && r < utf8.RuneSelf:
+			digits++
+		case strings.ContainsRune("+-.() ", r):
+		default:
+			return false
+		}
+	}
+	return digits >= minPhoneDigits
+}
+
+func calendarDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,289 @@
+package emergencyinfo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	mememergencyinforepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/emergencyinforepo"
+	memkeyprovider "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/keyprovider"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/keyprovider"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func requireAppError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != status || ae.Code != code {
+		t.Fatalf("err=%v (type=%T), want %s %d", err, err, code, status)
+	}
+}
+
+func testKey(id string, b byte) keyprovider.Key {
+	return keyprovider.Key{ID: id, Secret: bytes.Repeat([]byte{b}, keyprovider.KeySize)}
+}
+
+type fixture struct {
+	svc   *Service
+	repo  *mememergencyinforepo.Repo
+	trips *memtriprepo.Repo
+	rsvps *memrsvprepo.Repo
+	clk   *memclock.ManualClock
+}
+
+func newFixture(t *testing.T, keys ...keyprovider.Key) fixture {
+	t.Helper()
+	ctx := context.Background()
+	members := memmemberrepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "alice", "bob"} {
+		if err := members.Create(ctx, memberrepo.Member{
+			ID:          id,
+			Subject:     domain.SubjectID("sub-" + string(id)),
+			DisplayName: "Member " + string(id),
+			Email:       string(id) + "@example.com",
+			IsActive:    true,
+		}); err != nil {
+			t.Fatalf("create member: %v", err)
+		}
+	}
+	if len(keys) == 0 {
+		keys = []keyprovider.Key{testKey("k1", 1)}
+	}
+	provider, err := memkeyprovider.NewProvider(keys...)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	f := fixture{
+		repo:  mememergencyinforepo.NewRepo(),
+		trips: memtriprepo.NewRepo(),
+		rsvps: memrsvprepo.NewRepo(),
+		clk:   memclock.NewManualClock(time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = NewService(f.repo, provider, members, f.trips, f.rsvps, f.clk)
+
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	if err := f.trips.Create(ctx, triprepo.Trip{
+		ID:                 "t1",
+		Status:             triprepo.StatusPublished,
+		CreatorMemberID:    "org",
+		OrganizerMemberIDs: []domain.MemberID{"org"},
+		StartDate:          &start,
+		EndDate:            &end,
+	}); err != nil {
+		t.Fatalf("create trip: %v", err)
+	}
+	return f
+}
+
+func (f fixture) rsvp(t *testing.T, member domain.MemberID, status rsvprepo.Status) {
+	t.Helper()
+	if err := f.rsvps.Upsert(context.Background(), rsvprepo.RSVP{TripID: "t1", MemberID: member, Status: status, ChangedBy: member, UpdatedAt: f.clk.Now()}); err != nil {
+		t.Fatalf("Upsert rsvp: %v", err)
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestService_ManageOwnEmergencyInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+
+	_, err := f.svc.GetMyEmergencyInfo(ctx, "alice")
+	requireAppError(t, err, 404, "EMERGENCY_INFO_NOT_FOUND")
+
+	_, err = f.svc.PutMyEmergencyInfo(ctx, "alice", Input{ContactName: " ", ContactPhone: "call me", BloodType: strPtr("Z+")})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+	if ae := (*Error)(nil); errors.As(err, &ae) && len(ae.Details) != 3 {
+		t.Fatalf("details=%v, want contactName, contactPhone and bloodType", ae.Details)
+	}
+
+	info, err := f.svc.PutMyEmergencyInfo(ctx, "alice", Input{
+		ContactName:  "  Carol   Smith ",
+		ContactPhone: "+1 (555) 010-0199",
+		MedicalNotes: strPtr("Allergic to bees; carries an EpiPen"),
+		BloodType:    strPtr("ab+"),
+	})
+	if err != nil {
+		t.Fatalf("PutMyEmergencyInfo: %v", err)
+	}
+	if info.ContactName != "Carol Smith" || *info.BloodType != "AB+" {
+		t.Fatalf("info=%+v, want normalized name and blood type", info)
+	}
+
+	sealed, err := f.repo.Get(ctx, "alice")
+	if err != nil {
+		t.Fatalf("repo.Get: %v", err)
+	}
+	if sealed.KeyID != "k1" || bytes.Contains(sealed.Ciphertext, []byte("EpiPen")) || bytes.Contains(sealed.Ciphertext, []byte("Carol")) {
+		t.Fatalf("stored info is not encrypted: %+v", sealed)
+	}
+
+	got, err := f.svc.GetMyEmergencyInfo(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetMyEmergencyInfo: %v", err)
+	}
+	if got.ContactPhone != "+1 (555) 010-0199" || *got.MedicalNotes != "Allergic to bees; carries an EpiPen" {
+		t.Fatalf("got=%+v", got)
+	}
+
+	// Clearing optional fields drops them.
+	info, err = f.svc.PutMyEmergencyInfo(ctx, "alice", Input{ContactName: "Carol Smith", ContactPhone: "555-010-0199", MedicalNotes: strPtr(" ")})
+	if err != nil {
+		t.Fatalf("PutMyEmergencyInfo: %v", err)
+	}
+	if info.MedicalNotes != nil || info.BloodType != nil {
+		t.Fatalf("info=%+v, want optional fields cleared", info)
+	}
+
+	if err := f.svc.DeleteMyEmergencyInfo(ctx, "alice"); err != nil {
+		t.Fatalf("DeleteMyEmergencyInfo: %v", err)
+	}
+	requireAppError(t, f.svc.DeleteMyEmergencyInfo(ctx, "alice"), 404, "EMERGENCY_INFO_NOT_FOUND")
+
+	log, err := f.svc.ListMyEmergencyInfoAccess(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ListMyEmergencyInfoAccess: %v", err)
+	}
+	want := []domain.EmergencyInfoAction{domain.EmergencyInfoUpdated, domain.EmergencyInfoViewed, domain.EmergencyInfoUpdated, domain.EmergencyInfoDeleted}
+	if len(log) != len(want) {
+		t.Fatalf("log=%+v, want %v", log, want)
+	}
+	for i, a := range log {
+		if a.Action != want[i] || a.AccessedBy != "alice" || a.TripID != "" {
+			t.Fatalf("log[%d]=%+v, want %s by alice", i, a, want[i])
+		}
+	}
+}
+
+func TestService_OrganizerAccessWindowAndAudit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	f.rsvp(t, "alice", rsvprepo.StatusYes)
+	f.rsvp(t, "bob", rsvprepo.StatusNo)
+	if _, err := f.svc.PutMyEmergencyInfo(ctx, "alice", Input{ContactName: "Carol", ContactPhone: "5550100199"}); err != nil {
+		t.Fatalf("PutMyEmergencyInfo: %v", err)
+	}
+
+	// A month out the window is still closed.
+	_, err := f.svc.GetAttendeeEmergencyInfo(ctx, "org", "t1", "alice")
+	requireAppError(t, err, 403, "EMERGENCY_INFO_WINDOW_CLOSED")
+
+	f.clk.Set(time.Date(2026, 4, 29, 12, 0, 0, 0, time.UTC))
+	_, err = f.svc.GetAttendeeEmergencyInfo(ctx, "alice", "t1", "alice")
+	requireAppError(t, err, 403, "FORBIDDEN")
+	_, err = f.svc.GetAttendeeEmergencyInfo(ctx, "org", "t1", "bob")
+	requireAppError(t, err, 403, "MEMBER_NOT_ATTENDING")
+	_, err = f.svc.GetAttendeeEmergencyInfo(ctx, "org", "missing", "alice")
+	requireAppError(t, err, 404, "TRIP_NOT_FOUND")
+
+	info, err := f.svc.GetAttendeeEmergencyInfo(ctx, "org", "t1", "alice")
+	if err != nil {
+		t.Fatalf("GetAttendeeEmergencyInfo: %v", err)
+	}
+	if info.ContactName != "Carol" {
+		t.Fatalf("info=%+v", info)
+	}
+
+	f.rsvp(t, "org", rsvprepo.StatusYes)
+	roster, err := f.svc.ListTripEmergencyInfo(ctx, "org", "t1")
+	if err != nil {
+		t.Fatalf("ListTripEmergencyInfo: %v", err)
+	}
+	if len(roster) != 2 || roster[0].Member.ID != "alice" || roster[0].Info == nil || roster[1].Member.ID != "org" || roster[1].Info != nil {
+		t.Fatalf("roster=%+v, want alice with info and org without", roster)
+	}
+
+	// The window stays open through the last trip day and closes after it.
+	f.clk.Set(time.Date(2026, 5, 3, 12, 0, 0, 0, time.UTC))
+	if _, err := f.svc.GetAttendeeEmergencyInfo(ctx, "org", "t1", "alice"); err != nil {
+		t.Fatalf("GetAttendeeEmergencyInfo on the evening of the last day: %v", err)
+	}
+	f.clk.Set(time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC))
+	_, err = f.svc.ListTripEmergencyInfo(ctx, "org", "t1")
+	requireAppError(t, err, 403, "EMERGENCY_INFO_WINDOW_CLOSED")
+
+	log, err := f.svc.ListMyEmergencyInfoAccess(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ListMyEmergencyInfoAccess: %v", err)
+	}
+	var organizerViews int
+	for _, a := range log {
+		if a.AccessedBy == "org" {
+			if a.TripID != "t1" || a.Action != domain.EmergencyInfoViewed {
+				t.Fatalf("organizer access=%+v, want VIEW on t1", a)
+			}
+			organizerViews++
+		}
+	}
+	if organizerViews != 3 {
+		t.Fatalf("organizer views=%d, want 3 (log=%+v)", organizerViews, log)
+	}
+}
+
+func TestService_OrganizerAccessRequiresPublishedTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	f.rsvp(t, "alice", rsvprepo.StatusYes)
+	f.clk.Set(time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC))
+
+	tr, err := f.trips.GetByID(ctx, "t1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	tr.Status = triprepo.StatusCanceled
+	if err := f.trips.Save(ctx, tr); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	_, err = f.svc.ListTripEmergencyInfo(ctx, "org", "t1")
+	requireAppError(t, err, 409, "TRIP_NOT_PUBLISHED")
+}
+
+func TestService_ReadsInfoSealedWithRetiredKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t, testKey("k1", 1))
+	if _, err := f.svc.PutMyEmergencyInfo(ctx, "alice", Input{ContactName: "Carol", ContactPhone: "5550100199"}); err != nil {
+		t.Fatalf("PutMyEmergencyInfo: %v", err)
+	}
+
+	rotated, err := memkeyprovider.NewProvider(testKey("k1", 1), testKey("k2", 2))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	f.svc.keys = rotated
+	if _, err := f.svc.GetMyEmergencyInfo(ctx, "alice"); err != nil {
+		t.Fatalf("GetMyEmergencyInfo after rotation: %v", err)
+	}
+	if _, err := f.svc.PutMyEmergencyInfo(ctx, "alice", Input{ContactName: "Carol", ContactPhone: "5550100199"}); err != nil {
+		t.Fatalf("PutMyEmergencyInfo: %v", err)
+	}
+	sealed, err := f.repo.Get(ctx, "alice")
+	if err != nil {
+		t.Fatalf("repo.Get: %v", err)
+	}
+	if sealed.KeyID != "k2" {
+		t.Fatalf("key id=%q, want re-sealed with k2", sealed.KeyID)
+	}
+
+	// Ciphertext moved onto another member does not open.
+	sealed.MemberID = "bob"
+	if err := f.repo.Put(ctx, sealed); err != nil {
+		t.Fatalf("repo.Put: %v", err)
+	}
+	if _, err := f.svc.GetMyEmergencyInfo(ctx, "bob"); err == nil {
+		t.Fatalf("expected decrypt failure for info copied onto another member")
+	}
+}
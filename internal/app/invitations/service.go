@@ -0,0 +1,287 @@
+package invitations
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+// codeAlphabet omits easily confused characters (0/O, 1/I/L) since codes are read aloud and retyped.
+const codeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+const (
+	codeGroups    = 3
+	codeGroupSize = 4
+)
+
+// Service issues and manages member invitations. Redemption happens in members.Service.CreateMyMember.
+type Service struct {
+	repo    invitationrepo.Repository
+	members memberrepo.Repository
+	clk     clockport.Clock
+
+	newInvitationID func() domain.InvitationID
+	newCode         func() (string, error)
+
+	// DefaultTTL applies when CreateInput.TTL is zero.
+	DefaultTTL time.Duration
+	// MaxTTL bounds CreateInput.TTL.
+	MaxTTL time.Duration
+	// MemberMaxUses and MemberMaxTTL bound member-issued invitations, which are not reviewed by
+	// an admin.
+	MemberMaxUses int
+	MemberMaxTTL  time.Duration
+}
+
+func NewService(repo invitationrepo.Repository, members memberrepo.Repository, clk clockport.Clock) *Service {
+	return &Service{
+		repo:    repo,
+		members: members,
+		clk:     clk,
+		newInvitationID: func() domain.InvitationID {
+			return domain.InvitationID(uuid.NewString())
+		},
+		newCode:       randomCode,
+		DefaultTTL:    14 * 24 * time.Hour,
+		MaxTTL:        90 * 24 * time.Hour,
+		MemberMaxUses: 1,
+		MemberMaxTTL:  14 * 24 * time.Hour,
+	}
+}
+
+// CreateInput describes a new invitation. Exactly one of CreatedByAdmin or CreatedByMemberID is required;
+// member-issued invitations are capped by Service.MemberMaxUses and Service.MemberMaxTTL.
+type CreateInput struct {
+	// Email optionally restricts the invitation to one address.
+	Email *string
+	// MaxUses defaults to 1 (single-use).
+	MaxUses int
+	// TTL defaults to Service.DefaultTTL.
+	TTL time.Duration
+
+	CreatedByAdmin    string
+	CreatedByMemberID *domain.MemberID
+}
+
+// Created is returned once when an invitation is issued. Code is the only time the invite code is available.
+type Created struct {
+	Invitation domain.Invitation
+	Code       string
+}
+
+// Create issues an invitation. Member-issued invitations require the issuing member to be active.
+func (s *Service) Create(ctx context.Context, in CreateInput) (Created, error) {
+	admin := strings.TrimSpace(in.CreatedByAdmin)
+	details := map[string]any{}
+	switch {
+	case admin == "" && in.CreatedByMemberID == nil:
+		details["createdBy"] = "an admin or member is required"
+	case admin != "" && in.CreatedByMemberID != nil:
+		details["createdBy"] = "only one of admin or member may be set"
+	}
+	var email *string
+	if in.Email != nil {
+		e := strings.TrimSpace(*in.Email)
+		if a, err := mail.ParseAddress(e); err != nil || a.Address != e {
+			details["email"] = "must be a valid email address"
+		} else {
+			email = &e
+		}
+	}
+	maxUses := in.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 0 {
+		details["maxUses"] = "must be positive"
+	}
+	maxTTL := s.MaxTTL
+	if in.CreatedByMemberID != nil {
+		maxTTL = min(maxTTL, s.MemberMaxTTL)
+		if maxUses > s.MemberMaxUses {
+			details["maxUses"] = "must be at most " + strconv.Itoa(s.MemberMaxUses) + " for member invitations"
+		}
+	}
+	ttl := in.TTL
+	if ttl == 0 {
+		ttl = min(s.DefaultTTL, maxTTL)
+	}
+	if ttl < 0 || ttl > maxTTL {
+		details["ttl"] = "must be positive and at most " + maxTTL.String()
+	}
+	if len(details) > 0 {
+		return Created{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid invitation", Details: details}
+	}
+
+	var createdBy *domain.MemberID
+	if in.CreatedByMemberID != nil {
+		m, err := s.members.GetByID(ctx, *in.CreatedByMemberID)
+		if err != nil {
+			if errors.Is(err, memberrepo.ErrNotFound) {
+				return Created{}, &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+			}
+			return Created{}, err
+		}
+		if !m.IsActive {
+			return Created{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "inactive members cannot issue invitations"}
+		}
+		id := m.ID
+		createdBy = &id
+	}
+
+	code, err := s.newCode()
+	if err != nil {
+		return Created{}, err
+	}
+	now := s.clk.Now().UTC()
+	inv := domain.Invitation{
+		ID:                s.newInvitationID(),
+		Email:             email,
+		MaxUses:           maxUses,
+		CreatedByMemberID: createdBy,
+		CreatedByAdmin:    admin,
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(ttl),
+	}
+	if err := s.repo.Create(ctx, invitationrepo.Invitation{Invitation: inv, CodeHash: domain.HashInviteCode(code)}); err != nil {
+		return Created{}, err
+	}
+	return Created{Invitation: inv, Code: code}, nil
+}
+
+// CreateMemberInvite issues an invitation on behalf of the member bound to subject.
+func (s *Service) CreateMemberInvite(ctx context.Context, subject domain.SubjectID, in CreateInput) (Created, error) {
+	m, err := s.memberBySubject(ctx, subject)
+	if err != nil {
+		return Created{}, err
+	}
+	in.CreatedByAdmin = ""
+	in.CreatedByMemberID = &m.ID
+	return s.Create(ctx, in)
+}
+
+// ListMemberInvites returns the invitations issued by the member bound to subject (including
+// revoked/expired ones), oldest first.
+func (s *Service) ListMemberInvites(ctx context.Context, subject domain.SubjectID) ([]domain.Invitation, error) {
+	m, err := s.memberBySubject(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	invs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Invitation, 0)
+	for _, inv := range invs {
+		if inv.CreatedByMemberID != nil && *inv.CreatedByMemberID == m.ID {
+			out = append(out, inv.Invitation)
+		}
+	}
+	return out, nil
+}
+
+// RevokeMemberInvite revokes an invitation issued by the member bound to subject. Invitations
+// issued by anyone else are reported as not found.
+func (s *Service) RevokeMemberInvite(ctx context.Context, subject domain.SubjectID, id domain.InvitationID) (domain.Invitation, error) {
+	m, err := s.memberBySubject(ctx, subject)
+	if err != nil {
+		return domain.Invitation{}, err
+	}
+	inv, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, invitationrepo.ErrNotFound) {
+			return domain.Invitation{}, notFound()
+		}
+		return domain.Invitation{}, err
+	}
+	if inv.CreatedByMemberID == nil || *inv.CreatedByMemberID != m.ID {
+		return domain.Invitation{}, notFound()
+	}
+	return s.Revoke(ctx, id)
+}
+
+func (s *Service) memberBySubject(ctx context.Context, subject domain.SubjectID) (memberrepo.Member, error) {
+	m, err := s.members.GetBySubject(ctx, subject)
+	if err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return memberrepo.Member{}, &Error{
+				Status:  404,
+				Code:    "MEMBER_NOT_PROVISIONED",
+				Message: "No member profile exists for the authenticated subject.",
+			}
+		}
+		return memberrepo.Member{}, err
+	}
+	return m, nil
+}
+
+// Revoke disables an invitation immediately. Revoking an already revoked invitation is a no-op.
+func (s *Service) Revoke(ctx context.Context, id domain.InvitationID) (domain.Invitation, error) {
+	if err := s.repo.Revoke(ctx, id, s.clk.Now().UTC()); err != nil {
+		if errors.Is(err, invitationrepo.ErrNotFound) {
+			return domain.Invitation{}, notFound()
+		}
+		return domain.Invitation{}, err
+	}
+	inv, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Invitation{}, err
+	}
+	return inv.Invitation, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]domain.Invitation, error) {
+	invs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Invitation, 0, len(invs))
+	for _, inv := range invs {
+		out = append(out, inv.Invitation)
+	}
+	return out, nil
+}
+
+// ListRedemptions reports who joined with the invitation.
+func (s *Service) ListRedemptions(ctx context.Context, id domain.InvitationID) ([]domain.InvitationRedemption, error) {
+	rs, err := s.repo.ListRedemptions(ctx, id)
+	if err != nil {
+		if errors.Is(err, invitationrepo.ErrNotFound) {
+			return nil, notFound()
+		}
+		return nil, err
+	}
+	return rs, nil
+}
+
+func notFound() *Error {
+	return &Error{Status: 404, Code: "INVITATION_NOT_FOUND", Message: "invitation not found"}
+}
+
+// randomCode returns a code like "ABCD-EFGH-JKMN" (~59 bits of entropy).
+func randomCode() (string, error) {
+	b := make([]byte, codeGroups*codeGroupSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for i, v := range b {
+		if i > 0 && i%codeGroupSize == 0 {
+			sb.WriteByte('-')
+		}
+		// Modulo bias over a 31-symbol alphabet is negligible for invite codes.
+		sb.WriteByte(codeAlphabet[int(v)%len(codeAlphabet)])
+	}
+	return sb.String(), nil
+}
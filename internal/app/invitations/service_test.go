@@ -0,0 +1,239 @@
+package invitations
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	meminvitationrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/invitationrepo"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/members"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+func requireAppError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != status || ae.Code != code {
+		t.Fatalf("err=%v (type=%T), want %s %d", err, err, code, status)
+	}
+}
+
+func requireMembersError(t *testing.T, err error, code string, reason string) {
+	t.Helper()
+	ae := (*members.Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != code {
+		t.Fatalf("err=%v (type=%T), want %s 422", err, err, code)
+	}
+	if reason != "" && ae.Details["reason"] != reason {
+		t.Fatalf("reason=%v, want %s", ae.Details["reason"], reason)
+	}
+}
+
+func TestService_InviteOnlyMembership(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	memberRepo := memmemberrepo.NewRepo()
+	invRepo := meminvitationrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(invRepo, memberRepo, clk)
+	memberSvc := members.NewServiceWithOptions(memberRepo, clk, members.Options{RequireInvite: true, Invitations: invRepo})
+
+	_, err := memberSvc.CreateMyMember(ctx, "sub-alice", members.CreateMyMemberInput{DisplayName: "Alice", Email: "alice@example.com"})
+	requireMembersError(t, err, "INVITE_CODE_REQUIRED", "")
+
+	adminInvite, err := svc.Create(ctx, CreateInput{CreatedByAdmin: "ops"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}$`).MatchString(adminInvite.Code) {
+		t.Fatalf("code %q has unexpected shape", adminInvite.Code)
+	}
+	if adminInvite.Invitation.MaxUses != 1 || !adminInvite.Invitation.ExpiresAt.Equal(clk.Now().Add(14*24*time.Hour)) {
+		t.Fatalf("defaults = %+v", adminInvite.Invitation)
+	}
+
+	// Codes are matched regardless of case and separators.
+	alice, err := memberSvc.CreateMyMember(ctx, "sub-alice", members.CreateMyMemberInput{
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+		InviteCode:  " " + regexp.MustCompile(`-`).ReplaceAllString(adminInvite.Code, ""),
+	})
+	if err != nil {
+		t.Fatalf("CreateMyMember with invite: %v", err)
+	}
+
+	// Single-use: a second redemption is rejected.
+	_, err = memberSvc.CreateMyMember(ctx, "sub-bob", members.CreateMyMemberInput{DisplayName: "Bob", Email: "bob@example.com", InviteCode: adminInvite.Code})
+	requireMembersError(t, err, "INVITE_CODE_INVALID", "exhausted")
+
+	// Alice invites Bob, bound to Bob's email.
+	bobEmail := "Bob@Example.com"
+	memberInvite, err := svc.CreateMemberInvite(ctx, "sub-alice", CreateInput{Email: &bobEmail, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("CreateMemberInvite: %v", err)
+	}
+	if memberInvite.Invitation.CreatedByMemberID == nil || *memberInvite.Invitation.CreatedByMemberID != alice.ID {
+		t.Fatalf("CreatedByMemberID = %v, want %s", memberInvite.Invitation.CreatedByMemberID, alice.ID)
+	}
+	_, err = memberSvc.CreateMyMember(ctx, "sub-mallory", members.CreateMyMemberInput{DisplayName: "Mallory", Email: "mallory@example.com", InviteCode: memberInvite.Code})
+	requireMembersError(t, err, "INVITE_CODE_INVALID", "email_mismatch")
+
+	bob, err := memberSvc.CreateMyMember(ctx, "sub-bob", members.CreateMyMemberInput{DisplayName: "Bob", Email: "bob@example.com", InviteCode: memberInvite.Code})
+	if err != nil {
+		t.Fatalf("CreateMyMember bound invite: %v", err)
+	}
+	mine, err := svc.ListMemberInvites(ctx, "sub-alice")
+	if err != nil {
+		t.Fatalf("ListMemberInvites: %v", err)
+	}
+	if len(mine) != 1 || mine[0].ID != memberInvite.Invitation.ID || mine[0].UseCount != 1 {
+		t.Fatalf("alice's invites = %+v, want only the one she issued", mine)
+	}
+	if theirs, err := svc.ListMemberInvites(ctx, "sub-bob"); err != nil || len(theirs) != 0 {
+		t.Fatalf("bob's invites = %+v err=%v, want none", theirs, err)
+	}
+	_, err = svc.ListMemberInvites(ctx, "sub-nobody")
+	requireAppError(t, err, 404, "MEMBER_NOT_PROVISIONED")
+
+	reds, err := svc.ListRedemptions(ctx, memberInvite.Invitation.ID)
+	if err != nil {
+		t.Fatalf("ListRedemptions: %v", err)
+	}
+	if len(reds) != 1 || reds[0].MemberID != bob.ID {
+		t.Fatalf("redemptions = %+v, want bob", reds)
+	}
+
+	// Expired and revoked invitations are rejected.
+	expiring, err := svc.Create(ctx, CreateInput{CreatedByAdmin: "ops", MaxUses: 5, TTL: time.Minute})
+	if err != nil {
+		t.Fatalf("Create expiring: %v", err)
+	}
+	clk.Add(time.Minute)
+	_, err = memberSvc.CreateMyMember(ctx, "sub-carol", members.CreateMyMemberInput{DisplayName: "Carol", Email: "carol@example.com", InviteCode: expiring.Code})
+	requireMembersError(t, err, "INVITE_CODE_INVALID", "expired")
+
+	revoked, err := svc.Create(ctx, CreateInput{CreatedByAdmin: "ops"})
+	if err != nil {
+		t.Fatalf("Create revoked: %v", err)
+	}
+	if _, err := svc.Revoke(ctx, revoked.Invitation.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	_, err = memberSvc.CreateMyMember(ctx, "sub-carol", members.CreateMyMemberInput{DisplayName: "Carol", Email: "carol@example.com", InviteCode: revoked.Code})
+	requireMembersError(t, err, "INVITE_CODE_INVALID", "revoked")
+
+	_, err = memberSvc.CreateMyMember(ctx, "sub-carol", members.CreateMyMemberInput{DisplayName: "Carol", Email: "carol@example.com", InviteCode: "NOPE-NOPE-NOPE"})
+	requireMembersError(t, err, "INVITE_CODE_INVALID", "not_found")
+}
+
+var errCreateFailed = errors.New("create failed")
+
+// failingCreateRepo simulates storage failing after the invitation was redeemed.
+type failingCreateRepo struct {
+	memberrepo.Repository
+}
+
+func (failingCreateRepo) Create(context.Context, memberrepo.Member) error { return errCreateFailed }
+
+func TestService_FailedProvisioningReleasesInvite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	memberRepo := failingCreateRepo{Repository: memmemberrepo.NewRepo()}
+	invRepo := meminvitationrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(invRepo, memberRepo, clk)
+	memberSvc := members.NewServiceWithOptions(memberRepo, clk, members.Options{RequireInvite: true, Invitations: invRepo})
+
+	created, err := svc.Create(ctx, CreateInput{CreatedByAdmin: "ops"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	_, err = memberSvc.CreateMyMember(ctx, "sub-1", members.CreateMyMemberInput{DisplayName: "Alice", Email: "alice@example.com", InviteCode: created.Code})
+	if !errors.Is(err, errCreateFailed) {
+		t.Fatalf("err=%v, want errCreateFailed", err)
+	}
+	inv, err := invRepo.GetByID(ctx, created.Invitation.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if inv.UseCount != 0 {
+		t.Fatalf("UseCount = %d, want 0 after failed provisioning", inv.UseCount)
+	}
+}
+
+func TestService_CreateValidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	memberRepo := memmemberrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(meminvitationrepo.NewRepo(), memberRepo, clk)
+
+	_, err := svc.Create(ctx, CreateInput{})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+
+	bad := "not-an-email"
+	_, err = svc.Create(ctx, CreateInput{CreatedByAdmin: "ops", Email: &bad, MaxUses: -1, TTL: 365 * 24 * time.Hour})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+
+	missing := domain.MemberID("missing")
+	_, err = svc.Create(ctx, CreateInput{CreatedByMemberID: &missing})
+	requireAppError(t, err, 404, "MEMBER_NOT_FOUND")
+
+	_, err = svc.CreateMemberInvite(ctx, "sub-nobody", CreateInput{})
+	requireAppError(t, err, 404, "MEMBER_NOT_PROVISIONED")
+
+	_, err = svc.Revoke(ctx, "missing")
+	requireAppError(t, err, 404, "INVITATION_NOT_FOUND")
+}
+
+func TestService_MemberInvites(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	memberRepo := memmemberrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(meminvitationrepo.NewRepo(), memberRepo, clk)
+	for _, m := range []memberrepo.Member{
+		{ID: "m1", Subject: "sub-1", DisplayName: "Alice", Email: "alice@example.com", IsActive: true},
+		{ID: "m2", Subject: "sub-2", DisplayName: "Bob", Email: "bob@example.com", IsActive: true},
+	} {
+		if err := memberRepo.Create(ctx, m); err != nil {
+			t.Fatalf("Create member: %v", err)
+		}
+	}
+
+	// Member invitations are single-use and last at most 14 days; admins are not capped.
+	_, err := svc.CreateMemberInvite(ctx, "sub-1", CreateInput{MaxUses: 5})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+	_, err = svc.CreateMemberInvite(ctx, "sub-1", CreateInput{TTL: 15 * 24 * time.Hour})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+	if _, err := svc.Create(ctx, CreateInput{CreatedByAdmin: "ops", MaxUses: 5, TTL: 30 * 24 * time.Hour}); err != nil {
+		t.Fatalf("admin Create: %v", err)
+	}
+	created, err := svc.CreateMemberInvite(ctx, "sub-1", CreateInput{})
+	if err != nil {
+		t.Fatalf("CreateMemberInvite: %v", err)
+	}
+	if created.Invitation.MaxUses != 1 || !created.Invitation.ExpiresAt.Equal(clk.Now().Add(14*24*time.Hour)) {
+		t.Fatalf("invitation = %+v", created.Invitation)
+	}
+
+	// Only the issuer can revoke it.
+	_, err = svc.RevokeMemberInvite(ctx, "sub-2", created.Invitation.ID)
+	requireAppError(t, err, 404, "INVITATION_NOT_FOUND")
+	revoked, err := svc.RevokeMemberInvite(ctx, "sub-1", created.Invitation.ID)
+	if err != nil {
+		t.Fatalf("RevokeMemberInvite: %v", err)
+	}
+	if revoked.RevokedAt == nil {
+		t.Fatalf("invitation not revoked: %+v", revoked)
+	}
+}
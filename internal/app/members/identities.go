@@ -0,0 +1,112 @@
+package members
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+// LinkMyIdentity binds an additional login to the caller's member profile.
+//
+// proof must come from a second token the caller has just presented and the adapter has
+// verified (holding both tokens proves control of both logins). The caller's own subject is
+// resolved under the issuer carried in ctx, as for the other "My" use cases.
+func (s *Service) LinkMyIdentity(ctx context.Context, subject domain.SubjectID, proof domain.MemberIdentity) (domain.MemberIdentity, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return domain.MemberIdentity{}, err
+	}
+	return s.LinkIdentity(ctx, me.ID, proof)
+}
+
+// LinkIdentity binds a login to a member. It is the admin path (no proof from the member);
+// LinkMyIdentity is the self-service path.
+func (s *Service) LinkIdentity(ctx context.Context, memberID domain.MemberID, identity domain.MemberIdentity) (domain.MemberIdentity, error) {
+	identity.Issuer = strings.TrimSpace(identity.Issuer)
+	identity.Subject = domain.SubjectID(strings.TrimSpace(string(identity.Subject)))
+	if err := validateIdentity(identity.Issuer, identity.Subject); err != nil {
+		return domain.MemberIdentity{}, err
+	}
+	identity.LinkedAt = s.clk.Now().UTC()
+
+	if err := s.repo.LinkIdentity(ctx, memberID, identity); err != nil {
+		switch {
+		case errors.Is(err, memberrepo.ErrNotFound):
+			return domain.MemberIdentity{}, &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+		case errors.Is(err, memberrepo.ErrSubjectAlreadyBound):
+			return domain.MemberIdentity{}, &Error{
+				Status:  409,
+				Code:    "IDENTITY_ALREADY_LINKED",
+				Message: "This login is already linked to another member.",
+			}
+		default:
+			return domain.MemberIdentity{}, err
+		}
+	}
+	return identity, nil
+}
+
+// UnlinkMyIdentity removes one of the caller's logins. The last remaining login cannot be removed.
+func (s *Service) UnlinkMyIdentity(ctx context.Context, subject domain.SubjectID, issuer string, identitySubject domain.SubjectID) error {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return err
+	}
+	return s.UnlinkIdentity(ctx, me.ID, issuer, identitySubject)
+}
+
+// UnlinkIdentity removes a login from a member (admin path).
+func (s *Service) UnlinkIdentity(ctx context.Context, memberID domain.MemberID, issuer string, identitySubject domain.SubjectID) error {
+	if err := validateIdentity(issuer, identitySubject); err != nil {
+		return err
+	}
+	if err := s.repo.UnlinkIdentity(ctx, memberID, issuer, identitySubject); err != nil {
+		switch {
+		case errors.Is(err, memberrepo.ErrNotFound):
+			return &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+		case errors.Is(err, memberrepo.ErrIdentityNotFound):
+			return &Error{Status: 404, Code: "IDENTITY_NOT_FOUND", Message: "This login is not linked to the member."}
+		case errors.Is(err, memberrepo.ErrLastIdentity):
+			return &Error{Status: 409, Code: "LAST_IDENTITY", Message: "A member must keep at least one login."}
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) ListMyIdentities(ctx context.Context, subject domain.SubjectID) ([]domain.MemberIdentity, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListIdentities(ctx, me.ID)
+}
+
+func (s *Service) ListIdentities(ctx context.Context, memberID domain.MemberID) ([]domain.MemberIdentity, error) {
+	ids, err := s.repo.ListIdentities(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+		}
+		return nil, err
+	}
+	return ids, nil
+}
+
+func validateIdentity(issuer string, subject domain.SubjectID) error {
+	details := map[string]any{}
+	if strings.TrimSpace(issuer) == "" {
+		details["issuer"] = "must be non-empty"
+	}
+	if strings.TrimSpace(string(subject)) == "" {
+		details["subject"] = "must be non-empty"
+	}
+	if len(details) > 0 {
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid identity", Details: details}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package members
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+)
+
+// lookupInvite resolves and pre-validates the invite code for a new member.
+// It returns nil when no code was given and membership is open.
+func (s *Service) lookupInvite(ctx context.Context, code string, email string) (*invitationrepo.Invitation, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		if s.requireInvite {
+			return nil, &Error{
+				Status:  422,
+				Code:    "INVITE_CODE_REQUIRED",
+				Message: "Membership is invite-only; an invite code is required.",
+			}
+		}
+		return nil, nil
+	}
+	if s.invitations == nil {
+		if s.requireInvite {
+			return nil, errors.New("invite-only membership requires an invitation repository")
+		}
+		// Invitations are not configured: an unsolicited code is ignored in open mode.
+		return nil, nil
+	}
+
+	inv, err := s.invitations.GetByCodeHash(ctx, domain.HashInviteCode(code))
+	if err != nil {
+		if errors.Is(err, invitationrepo.ErrNotFound) {
+			return nil, inviteInvalid("not_found")
+		}
+		return nil, err
+	}
+	if inv.Email != nil && !strings.EqualFold(*inv.Email, email) {
+		return nil, inviteInvalid("email_mismatch")
+	}
+	return &inv, nil
+}
+
+// redeemInvite consumes one use of the invitation for the member about to be created.
+func (s *Service) redeemInvite(ctx context.Context, inv invitationrepo.Invitation, id domain.MemberID, now time.Time) error {
+	err := s.invitations.Redeem(ctx, inv.ID, id, now)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, invitationrepo.ErrRevoked):
+		return inviteInvalid("revoked")
+	case errors.Is(err, invitationrepo.ErrExpired):
+		return inviteInvalid("expired")
+	case errors.Is(err, invitationrepo.ErrExhausted):
+		return inviteInvalid("exhausted")
+	case errors.Is(err, invitationrepo.ErrNotFound):
+		return inviteInvalid("not_found")
+	default:
+		return err
+	}
+}
+
+func inviteInvalid(reason string) *Error {
+	return &Error{
+		Status:  422,
+		Code:    "INVITE_CODE_INVALID",
+		Message: "The invite code is not valid.",
+		Details: map[string]any{"reason": reason},
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"net/mail"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
 	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/invitationrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
 )
 
@@ -18,7 +21,14 @@ type Service struct {
 	repo memberrepo.Repository
 	clk  clockport.Clock
 
-	newMemberID func() domain.MemberID
+	invitations   invitationrepo.Repository
+	requireInvite bool
+
+	mailer       mailer.Mailer
+	verification VerificationOptions
+
+	newMemberID  func() domain.MemberID
+	newVehicleID func() domain.VehicleID
 
 	// SearchLimit bounds search result size.
 	SearchLimit int
@@ -31,10 +41,47 @@ func NewService(repo memberrepo.Repository, clk clockport.Clock) *Service {
 		newMemberID: func() domain.MemberID {
 			return domain.MemberID(uuid.NewString())
 		},
+		newVehicleID: func() domain.VehicleID {
+			return domain.VehicleID(uuid.NewString())
+		},
 		SearchLimit: 50,
+		verification: VerificationOptions{
+			Secret: randomSecret(),
+			TTL:    24 * time.Hour,
+		},
 	}
 }
 
+// Options configures optional member provisioning behaviour.
+type Options struct {
+	// RequireInvite makes CreateMyMember reject requests without a valid invite code
+	// (MEMBERSHIP_MODE=invite). Requires Invitations.
+	RequireInvite bool
+
+	// Invitations, when set, lets CreateMyMember redeem invite codes (always validated when supplied).
+	Invitations invitationrepo.Repository
+
+	// Mailer delivers email verification links; nil disables sending (addresses stay unverified).
+	Mailer mailer.Mailer
+	// Verification overrides token defaults; zero fields keep the defaults.
+	Verification VerificationOptions
+}
+
+func NewServiceWithOptions(repo memberrepo.Repository, clk clockport.Clock, opts Options) *Service {
+	s := NewService(repo, clk)
+	s.invitations = opts.Invitations
+	s.requireInvite = opts.RequireInvite
+	s.mailer = opts.Mailer
+	if len(opts.Verification.Secret) > 0 {
+		s.verification.Secret = opts.Verification.Secret
+	}
+	if opts.Verification.TTL > 0 {
+		s.verification.TTL = opts.Verification.TTL
+	}
+	s.verification.ConfirmURL = opts.Verification.ConfirmURL
+	return s
+}
+
 func (s *Service) ListMembers(ctx context.Context, subject domain.SubjectID, includeInactive bool) ([]domain.Member, error) {
 	ms, err := s.repo.List(ctx, includeInactive)
 	if err != nil {
@@ -127,9 +174,18 @@ func (s *Service) CreateMyMember(ctx context.Context, subject domain.SubjectID,
 	if err := s.ensureEmailUnique(ctx, email, ""); err != nil {
 		return domain.Member{}, err
 	}
+	inv, err := s.lookupInvite(ctx, in.InviteCode, email)
+	if err != nil {
+		return domain.Member{}, err
+	}
 
 	now := s.clk.Now()
 	id := s.newMemberID()
+	if inv != nil {
+		if err := s.redeemInvite(ctx, *inv, id, now); err != nil {
+			return domain.Member{}, err
+		}
+	}
 	m := memberrepo.Member{
 		ID:              id,
 		Subject:         subject,
@@ -142,6 +198,10 @@ func (s *Service) CreateMyMember(ctx context.Context, subject domain.SubjectID,
 		UpdatedAt:       now,
 	}
 	if err := s.repo.Create(ctx, m); err != nil {
+		if inv != nil {
+			// Give the use back; the invitee never became a member.
+			_ = s.invitations.ReleaseRedemption(ctx, inv.ID, id)
+		}
 		if errors.Is(err, memberrepo.ErrSubjectAlreadyBound) {
 			return domain.Member{}, &Error{
 				Status:  409,
@@ -151,6 +211,11 @@ func (s *Service) CreateMyMember(ctx context.Context, subject domain.SubjectID,
 		}
 		return domain.Member{}, err
 	}
+	if m.GroupAliasEmail != nil {
+		s.sendVerifications(ctx, m, EmailKindPrimary, EmailKindGroupAlias)
+	} else {
+		s.sendVerifications(ctx, m, EmailKindPrimary)
+	}
 	return toDomain(m), nil
 }
 
@@ -167,6 +232,9 @@ func (s *Service) UpdateMyMemberProfile(ctx context.Context, subject domain.Subj
 		return domain.Member{}, err
 	}
 
+	// Changed addresses lose their verification and get a fresh link.
+	var reverify []EmailKind
+
 	if in.DisplayName.IsSpecified() {
 		if in.DisplayName.IsNull() {
 			return domain.Member{}, &Error{
@@ -209,12 +277,17 @@ func (s *Service) UpdateMyMemberProfile(ctx context.Context, subject domain.Subj
 		if err := s.ensureEmailUnique(ctx, email, string(m.ID)); err != nil {
 			return domain.Member{}, err
 		}
+		if !strings.EqualFold(m.Email, email) {
+			m.EmailVerifiedAt = nil
+			reverify = append(reverify, EmailKindPrimary)
+		}
 		m.Email = email
 	}
 
 	if in.GroupAliasEmail.IsSpecified() {
 		if in.GroupAliasEmail.IsNull() {
 			m.GroupAliasEmail = nil
+			m.GroupAliasEmailVerifiedAt = nil
 		} else {
 			gae := strings.TrimSpace(in.GroupAliasEmail.Value())
 			if err := validateEmail(gae); err != nil {
@@ -225,16 +298,23 @@ func (s *Service) UpdateMyMemberProfile(ctx context.Context, subject domain.Subj
 					Details: map[string]any{"groupAliasEmail": err.Error()},
 				}
 			}
+			if m.GroupAliasEmail == nil || !strings.EqualFold(*m.GroupAliasEmail, gae) {
+				m.GroupAliasEmailVerifiedAt = nil
+				reverify = append(reverify, EmailKindGroupAlias)
+			}
 			m.GroupAliasEmail = &gae
 		}
 	}
 
+	removeDefaultVehicle := false
 	if in.VehicleProfile.IsSpecified() {
 		if in.VehicleProfile.IsNull() {
 			// NOTE: We cannot reliably represent `vehicleProfile: null` at the HTTP layer when the
 			// field is a `$ref`ed object and generated as `*VehicleProfile`. We still keep the
 			// app-layer behavior for completeness if a caller can express this input.
+			// The profile is the default vehicle's, so null removes that vehicle from the garage.
 			m.VehicleProfile = nil
+			removeDefaultVehicle = true
 		} else {
 			m.VehicleProfile = applyVehicleProfilePatch(m.VehicleProfile, in.VehicleProfile.Value())
 		}
@@ -244,6 +324,16 @@ func (s *Service) UpdateMyMemberProfile(ctx context.Context, subject domain.Subj
 	if err := s.repo.Update(ctx, m); err != nil {
 		return domain.Member{}, err
 	}
+	if removeDefaultVehicle {
+		if err := s.deleteDefaultVehicle(ctx, m.ID); err != nil {
+			return domain.Member{}, err
+		}
+		// Another vehicle may have been promoted to default.
+		if m, err = s.repo.GetByID(ctx, m.ID); err != nil {
+			return domain.Member{}, err
+		}
+	}
+	s.sendVerifications(ctx, m, reverify...)
 	return toDomain(m), nil
 }
 
@@ -344,7 +434,18 @@ func toDomain(m memberrepo.Member) domain.Member {
 		IsActive:        m.IsActive,
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
+
+		EmailVerifiedAt:           cloneTimePtr(m.EmailVerifiedAt),
+		GroupAliasEmailVerifiedAt: cloneTimePtr(m.GroupAliasEmailVerifiedAt),
+	}
+}
+
+func cloneTimePtr(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
 	}
+	v := *p
+	return &v
 }
 
 func cloneStringPtr(p *string) *string {
@@ -3,12 +3,16 @@ package members
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memmailer "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/mailer"
 	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/platform/auth/authctx"
 )
 
 func TestService_GetMyMemberProfile_NotProvisioned(t *testing.T) {
@@ -130,3 +134,264 @@ func TestService_SearchMembers_ValidatesMinLength(t *testing.T) {
 		t.Fatalf("err=%v, want 422 validation error", err)
 	}
 }
+
+func TestService_LinkMyIdentity_ThenResolveAndUnlink(t *testing.T) {
+	t.Parallel()
+
+	repo := memmemberrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(repo, clk)
+	kc := authctx.WithIssuer(context.Background(), "https://keycloak.test/realms/ebo")
+	other := authctx.WithIssuer(context.Background(), "https://other-idp.test")
+
+	created, err := svc.CreateMyMember(kc, "kc-sub", CreateMyMemberInput{DisplayName: "Alice", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("CreateMyMember: %v", err)
+	}
+
+	// Before linking, the second login is not provisioned.
+	_, err = svc.GetMyMemberProfile(other, "other-sub")
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Code != "MEMBER_NOT_PROVISIONED" {
+		t.Fatalf("err=%v, want MEMBER_NOT_PROVISIONED", err)
+	}
+
+	clk.Add(time.Hour)
+	linked, err := svc.LinkMyIdentity(kc, "kc-sub", domain.MemberIdentity{Issuer: "https://other-idp.test", Subject: "other-sub"})
+	if err != nil {
+		t.Fatalf("LinkMyIdentity: %v", err)
+	}
+	if !linked.LinkedAt.Equal(clk.Now()) {
+		t.Fatalf("LinkedAt=%v want %v", linked.LinkedAt, clk.Now())
+	}
+	got, err := svc.GetMyMemberProfile(other, "other-sub")
+	if err != nil || got.ID != created.ID {
+		t.Fatalf("GetMyMemberProfile via linked login = %v err=%v, want %s", got.ID, err, created.ID)
+	}
+	ids, err := svc.ListMyIdentities(other, "other-sub")
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("ListMyIdentities = %+v err=%v", ids, err)
+	}
+
+	// The login cannot be linked to a second member.
+	bob, err := svc.CreateMyMember(kc, "bob-sub", CreateMyMemberInput{DisplayName: "Bob", Email: "bob@example.com"})
+	if err != nil {
+		t.Fatalf("CreateMyMember bob: %v", err)
+	}
+	_, err = svc.LinkIdentity(context.Background(), bob.ID, domain.MemberIdentity{Issuer: "https://other-idp.test", Subject: "other-sub"})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "IDENTITY_ALREADY_LINKED" {
+		t.Fatalf("err=%v, want IDENTITY_ALREADY_LINKED 409", err)
+	}
+
+	// Unlinking the original login leaves the linked one; the last login is protected.
+	if err := svc.UnlinkMyIdentity(other, "other-sub", "https://keycloak.test/realms/ebo", "kc-sub"); err != nil {
+		t.Fatalf("UnlinkMyIdentity: %v", err)
+	}
+	err = svc.UnlinkMyIdentity(other, "other-sub", "https://other-idp.test", "other-sub")
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "LAST_IDENTITY" {
+		t.Fatalf("err=%v, want LAST_IDENTITY 409", err)
+	}
+}
+
+func TestService_EmailVerification(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repo := memmemberrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	outbox := memmailer.NewOutbox()
+	svc := NewServiceWithOptions(repo, clk, Options{
+		Mailer: outbox,
+		Verification: VerificationOptions{
+			Secret:     []byte("test-secret"),
+			TTL:        time.Hour,
+			ConfirmURL: "https://api.example.org/email-verifications/confirm",
+		},
+	})
+	tokenFrom := func(i int) string {
+		t.Helper()
+		sent := outbox.Sent()
+		if len(sent) <= i {
+			t.Fatalf("sent %d messages, want > %d", len(sent), i)
+		}
+		_, tok, ok := strings.Cut(sent[i].Body, "?token=")
+		if !ok {
+			t.Fatalf("message %d has no link: %q", i, sent[i].Body)
+		}
+		tok, _, _ = strings.Cut(tok, "\n")
+		v, err := url.QueryUnescape(tok)
+		if err != nil {
+			t.Fatalf("unescape: %v", err)
+		}
+		return v
+	}
+
+	alias := "alice-group@example.com"
+	created, err := svc.CreateMyMember(ctx, "sub-1", CreateMyMemberInput{DisplayName: "Alice", Email: "alice@example.com", GroupAliasEmail: &alias})
+	if err != nil {
+		t.Fatalf("CreateMyMember: %v", err)
+	}
+	if created.EmailVerifiedAt != nil || len(created.NotificationEmails()) != 0 {
+		t.Fatalf("new member must be unverified: %+v", created)
+	}
+	if sent := outbox.Sent(); len(sent) != 2 || sent[0].To != "alice@example.com" || sent[1].To != alias {
+		t.Fatalf("sent = %+v, want links to both addresses", sent)
+	}
+
+	m, kind, err := svc.ConfirmEmailVerification(ctx, tokenFrom(0))
+	if err != nil {
+		t.Fatalf("ConfirmEmailVerification: %v", err)
+	}
+	if kind != EmailKindPrimary || m.EmailVerifiedAt == nil || m.GroupAliasEmailVerifiedAt != nil {
+		t.Fatalf("confirmed %s: %+v", kind, m)
+	}
+	if got := m.NotificationEmails(); len(got) != 1 || got[0] != "alice@example.com" {
+		t.Fatalf("NotificationEmails = %v, want only the verified address", got)
+	}
+
+	// Tampered and expired tokens are rejected.
+	aliasToken := tokenFrom(1)
+	_, _, err = svc.ConfirmEmailVerification(ctx, aliasToken+"x")
+	requireVerificationInvalid(t, err, "signature")
+	clk.Add(time.Hour)
+	_, _, err = svc.ConfirmEmailVerification(ctx, aliasToken)
+	requireVerificationInvalid(t, err, "expired")
+
+	// Re-sending issues a fresh link; already verified addresses are refused.
+	if err := svc.RequestMyEmailVerification(ctx, "sub-1", EmailKindGroupAlias); err != nil {
+		t.Fatalf("RequestMyEmailVerification: %v", err)
+	}
+	err = svc.RequestMyEmailVerification(ctx, "sub-1", EmailKindPrimary)
+	if ae := (*Error)(nil); !errors.As(err, &ae) || ae.Code != "EMAIL_ALREADY_VERIFIED" {
+		t.Fatalf("err=%v, want EMAIL_ALREADY_VERIFIED", err)
+	}
+	freshAlias := tokenFrom(2)
+
+	// Changing the email resets its verification and invalidates links for the old address.
+	staleLink := freshAlias
+	newAlias := "alice-other@example.com"
+	updated, err := svc.UpdateMyMemberProfile(ctx, "sub-1", UpdateMyMemberProfileInput{
+		Email:           Some("alice@new.example.com"),
+		GroupAliasEmail: Some(newAlias),
+	})
+	if err != nil {
+		t.Fatalf("UpdateMyMemberProfile: %v", err)
+	}
+	if updated.EmailVerifiedAt != nil || updated.GroupAliasEmailVerifiedAt != nil {
+		t.Fatalf("changed addresses must be unverified: %+v", updated)
+	}
+	if sent := outbox.Sent(); len(sent) != 5 || sent[3].To != "alice@new.example.com" || sent[4].To != newAlias {
+		t.Fatalf("sent = %+v, want new links for both changed addresses", sent)
+	}
+	_, _, err = svc.ConfirmEmailVerification(ctx, staleLink)
+	requireVerificationInvalid(t, err, "stale")
+
+	// Saving the same address (different case) keeps verification.
+	if _, _, err := svc.ConfirmEmailVerification(ctx, tokenFrom(3)); err != nil {
+		t.Fatalf("ConfirmEmailVerification new email: %v", err)
+	}
+	updated, err = svc.UpdateMyMemberProfile(ctx, "sub-1", UpdateMyMemberProfileInput{Email: Some("Alice@New.Example.com")})
+	if err != nil {
+		t.Fatalf("UpdateMyMemberProfile same email: %v", err)
+	}
+	if updated.EmailVerifiedAt == nil {
+		t.Fatalf("re-saving the same address must keep verification")
+	}
+}
+
+func requireVerificationInvalid(t *testing.T, err error, reason string) {
+	t.Helper()
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != "VERIFICATION_TOKEN_INVALID" || ae.Details["reason"] != reason {
+		t.Fatalf("err=%v, want VERIFICATION_TOKEN_INVALID (%s)", err, reason)
+	}
+}
+
+func TestService_VehicleGarage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := memmemberrepo.NewRepo()
+	clk := memclock.NewManualClock(time.Unix(100, 0).UTC())
+	svc := NewService(repo, clk)
+
+	sub := domain.SubjectID("sub-1")
+	if _, err := svc.CreateMyMember(ctx, sub, CreateMyMemberInput{
+		DisplayName:    "Alice",
+		Email:          "alice@example.com",
+		VehicleProfile: &VehicleProfilePatch{Make: Some("Toyota")},
+	}); err != nil {
+		t.Fatalf("CreateMyMember err=%v", err)
+	}
+
+	// The profile given at sign-up becomes the default vehicle.
+	vs, err := svc.ListMyVehicles(ctx, sub)
+	if err != nil || len(vs) != 1 || !vs[0].IsDefault || vs[0].Name != domain.DefaultVehicleName {
+		t.Fatalf("ListMyVehicles = %+v err=%v", vs, err)
+	}
+
+	if _, err := svc.AddMyVehicle(ctx, sub, AddVehicleInput{Name: "  "}); !isErrorCode(err, "VALIDATION_ERROR") {
+		t.Fatalf("AddMyVehicle blank name err=%v, want VALIDATION_ERROR", err)
+	}
+	clk.Add(time.Minute)
+	jeep, err := svc.AddMyVehicle(ctx, sub, AddVehicleInput{
+		Name:      " Trail rig ",
+		Profile:   &VehicleProfilePatch{Make: Some("Jeep")},
+		IsDefault: true,
+	})
+	if err != nil || jeep.Name != "Trail rig" || !jeep.IsDefault {
+		t.Fatalf("AddMyVehicle = %+v err=%v", jeep, err)
+	}
+	me, err := svc.GetMyMemberProfile(ctx, sub)
+	if err != nil || me.VehicleProfile == nil || *me.VehicleProfile.Make != "Jeep" {
+		t.Fatalf("member profile should follow the default vehicle: %+v err=%v", me.VehicleProfile, err)
+	}
+
+	// Patching the member's vehicle profile edits the default vehicle only.
+	if _, err := svc.UpdateMyMemberProfile(ctx, sub, UpdateMyMemberProfileInput{
+		VehicleProfile: Some(VehicleProfilePatch{Model: Some("Rubicon")}),
+	}); err != nil {
+		t.Fatalf("UpdateMyMemberProfile err=%v", err)
+	}
+	got, err := svc.UpdateMyVehicle(ctx, sub, jeep.ID, UpdateVehicleInput{Name: Some("Rubi")})
+	if err != nil || got.Name != "Rubi" || got.Profile.Model == nil || *got.Profile.Model != "Rubicon" {
+		t.Fatalf("UpdateMyVehicle = %+v err=%v", got, err)
+	}
+
+	// Specs are validated and the gear checklist is de-duplicated into canonical order.
+	big := 99
+	if _, err := svc.UpdateMyVehicle(ctx, sub, jeep.ID, UpdateVehicleInput{Specs: Some(domain.VehicleSpecs{TireSizeInches: &big})}); !isErrorCode(err, "VALIDATION_ERROR") {
+		t.Fatalf("UpdateMyVehicle tire size err=%v, want VALIDATION_ERROR", err)
+	}
+	if _, err := svc.UpdateMyVehicle(ctx, sub, jeep.ID, UpdateVehicleInput{Specs: Some(domain.VehicleSpecs{RecoveryGear: []domain.RecoveryGearItem{"ROPE"}})}); !isErrorCode(err, "VALIDATION_ERROR") {
+		t.Fatalf("UpdateMyVehicle gear err=%v, want VALIDATION_ERROR", err)
+	}
+	tires := 35
+	got, err = svc.UpdateMyVehicle(ctx, sub, jeep.ID, UpdateVehicleInput{Specs: Some(domain.VehicleSpecs{
+		TireSizeInches: &tires,
+		RecoveryGear:   []domain.RecoveryGearItem{domain.RecoveryGearWinch, domain.RecoveryGearStrap, domain.RecoveryGearWinch},
+	})})
+	if err != nil || got.Specs.TireSizeInches == nil || *got.Specs.TireSizeInches != 35 ||
+		len(got.Specs.RecoveryGear) != 2 || got.Specs.RecoveryGear[0] != domain.RecoveryGearStrap {
+		t.Fatalf("UpdateMyVehicle specs = %+v err=%v", got.Specs, err)
+	}
+
+	if _, err := svc.SetMyDefaultVehicle(ctx, sub, vs[0].ID); err != nil {
+		t.Fatalf("SetMyDefaultVehicle err=%v", err)
+	}
+	if err := svc.DeleteMyVehicle(ctx, sub, vs[0].ID); err != nil {
+		t.Fatalf("DeleteMyVehicle err=%v", err)
+	}
+	vs, err = svc.ListMyVehicles(ctx, sub)
+	if err != nil || len(vs) != 1 || vs[0].ID != jeep.ID || !vs[0].IsDefault {
+		t.Fatalf("after deleting the default, remaining vehicle should be default: %+v err=%v", vs, err)
+	}
+	if err := svc.DeleteMyVehicle(ctx, sub, "missing"); !isErrorCode(err, "VEHICLE_NOT_FOUND") {
+		t.Fatalf("DeleteMyVehicle missing err=%v, want VEHICLE_NOT_FOUND", err)
+	}
+}
+
+func isErrorCode(err error, code string) bool {
+	ae := (*Error)(nil)
+	return errors.As(err, &ae) && ae.Code == code
+}
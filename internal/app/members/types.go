@@ -41,4 +41,7 @@ type CreateMyMemberInput struct {
 	Email           string
 	GroupAliasEmail *string
 	VehicleProfile  *VehicleProfilePatch // treated as a full object on create
+
+	// InviteCode is required when membership is invite-only (sent as the X-Invite-Code header).
+	InviteCode string
 }
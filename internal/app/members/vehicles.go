@@ -0,0 +1,222 @@
+package members
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+// maxVehicleNameLen bounds vehicle names (counted in runes).
+const maxVehicleNameLen = 100
+
+// maxTireSizeInches bounds VehicleSpecs.TireSizeInches (and trip minimums).
+const maxTireSizeInches = 60
+
+// AddVehicleInput describes a new garage vehicle.
+type AddVehicleInput struct {
+	Name    string
+	Profile *VehicleProfilePatch // treated as a full object, as on member create
+	Specs   *domain.VehicleSpecs
+	// IsDefault makes the new vehicle the default. A member's first vehicle is always the default.
+	IsDefault bool
+}
+
+// UpdateVehicleInput patches a garage vehicle.
+type UpdateVehicleInput struct {
+	Name    Optional[string] // cannot be null
+	Profile Optional[VehicleProfilePatch]
+	// Specs replaces the structured specs as a whole; null clears them.
+	Specs Optional[domain.VehicleSpecs]
+}
+
+// ListMyVehicles returns the caller's garage, default vehicle first.
+func (s *Service) ListMyVehicles(ctx context.Context, subject domain.SubjectID) ([]domain.Vehicle, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	vs, err := s.repo.ListVehicles(ctx, me.ID)
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+	return vs, nil
+}
+
+// AddMyVehicle adds a vehicle to the caller's garage.
+func (s *Service) AddMyVehicle(ctx context.Context, subject domain.SubjectID, in AddVehicleInput) (domain.Vehicle, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return domain.Vehicle{}, err
+	}
+	name, err := validateVehicleName(in.Name)
+	if err != nil {
+		return domain.Vehicle{}, err
+	}
+
+	now := s.clk.Now().UTC()
+	v := domain.Vehicle{
+		ID:        s.newVehicleID(),
+		MemberID:  me.ID,
+		Name:      name,
+		IsDefault: in.IsDefault,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if p := createVehicleProfile(in.Profile); p != nil {
+		v.Profile = *p
+	}
+	if in.Specs != nil {
+		if v.Specs, err = validateVehicleSpecs(*in.Specs); err != nil {
+			return domain.Vehicle{}, err
+		}
+	}
+	if err := s.repo.CreateVehicle(ctx, v); err != nil {
+		return domain.Vehicle{}, vehicleError(err)
+	}
+	// Re-read: the repository decides whether the vehicle became the default.
+	return s.getVehicle(ctx, me.ID, v.ID)
+}
+
+// UpdateMyVehicle renames a vehicle and/or patches its profile.
+func (s *Service) UpdateMyVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID, in UpdateVehicleInput) (domain.Vehicle, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return domain.Vehicle{}, err
+	}
+	v, err := s.getVehicle(ctx, me.ID, vehicleID)
+	if err != nil {
+		return domain.Vehicle{}, err
+	}
+
+	if in.Name.IsSpecified() {
+		if in.Name.IsNull() {
+			return domain.Vehicle{}, &Error{
+				Status:  422,
+				Code:    "VALIDATION_ERROR",
+				Message: "invalid name",
+				Details: map[string]any{"name": "must not be null"},
+			}
+		}
+		name, err := validateVehicleName(in.Name.Value())
+		if err != nil {
+			return domain.Vehicle{}, err
+		}
+		v.Name = name
+	}
+	if in.Profile.IsSpecified() {
+		if in.Profile.IsNull() {
+			v.Profile = domain.VehicleProfile{}
+		} else {
+			v.Profile = *applyVehicleProfilePatch(&v.Profile, in.Profile.Value())
+		}
+	}
+	if in.Specs.IsSpecified() {
+		if in.Specs.IsNull() {
+			v.Specs = domain.VehicleSpecs{}
+		} else if v.Specs, err = validateVehicleSpecs(in.Specs.Value()); err != nil {
+			return domain.Vehicle{}, err
+		}
+	}
+
+	v.UpdatedAt = s.clk.Now().UTC()
+	if err := s.repo.UpdateVehicle(ctx, v); err != nil {
+		return domain.Vehicle{}, vehicleError(err)
+	}
+	return v, nil
+}
+
+// DeleteMyVehicle removes a vehicle. If it was the default, the oldest remaining vehicle
+// becomes the default. RSVPs that named it no longer report a vehicle.
+func (s *Service) DeleteMyVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID) error {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteVehicle(ctx, me.ID, vehicleID); err != nil {
+		return vehicleError(err)
+	}
+	return nil
+}
+
+// SetMyDefaultVehicle makes a vehicle the caller's default.
+func (s *Service) SetMyDefaultVehicle(ctx context.Context, subject domain.SubjectID, vehicleID domain.VehicleID) (domain.Vehicle, error) {
+	me, err := s.GetMyMemberProfile(ctx, subject)
+	if err != nil {
+		return domain.Vehicle{}, err
+	}
+	if err := s.repo.SetDefaultVehicle(ctx, me.ID, vehicleID); err != nil {
+		return domain.Vehicle{}, vehicleError(err)
+	}
+	return s.getVehicle(ctx, me.ID, vehicleID)
+}
+
+func (s *Service) getVehicle(ctx context.Context, memberID domain.MemberID, vehicleID domain.VehicleID) (domain.Vehicle, error) {
+	v, err := s.repo.GetVehicle(ctx, memberID, vehicleID)
+	if err != nil {
+		return domain.Vehicle{}, vehicleError(err)
+	}
+	return v, nil
+}
+
+func (s *Service) deleteDefaultVehicle(ctx context.Context, memberID domain.MemberID) error {
+	vs, err := s.repo.ListVehicles(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	for _, v := range vs {
+		if v.IsDefault {
+			return s.repo.DeleteVehicle(ctx, memberID, v.ID)
+		}
+	}
+	return nil
+}
+
+func validateVehicleName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be non-empty"}}
+	case len([]rune(name)) > maxVehicleNameLen:
+		return "", &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be at most 100 characters"}}
+	}
+	return name, nil
+}
+
+// validateVehicleSpecs checks tire size and gear codes, and de-duplicates the gear checklist
+// into its canonical order.
+func validateVehicleSpecs(in domain.VehicleSpecs) (domain.VehicleSpecs, error) {
+	out := domain.VehicleSpecs{HasLockers: in.HasLockers, HamLicensed: in.HamLicensed}
+	if in.TireSizeInches != nil {
+		n := *in.TireSizeInches
+		if n < 1 || n > maxTireSizeInches {
+			return domain.VehicleSpecs{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid tireSizeInches", Details: map[string]any{"tireSizeInches": "must be between 1 and 60"}}
+		}
+		out.TireSizeInches = &n
+	}
+	for _, g := range in.RecoveryGear {
+		if !g.Valid() {
+			return domain.VehicleSpecs{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid recoveryGear", Details: map[string]any{"recoveryGear": "unknown item " + string(g)}}
+		}
+	}
+	for _, g := range domain.RecoveryGearItems {
+		if slices.Contains(in.RecoveryGear, g) {
+			out.RecoveryGear = append(out.RecoveryGear, g)
+		}
+	}
+	return out, nil
+}
+
+func vehicleError(err error) error {
+	switch {
+	case errors.Is(err, memberrepo.ErrVehicleNotFound):
+		return &Error{Status: 404, Code: "VEHICLE_NOT_FOUND", Message: "vehicle not found"}
+	case errors.Is(err, memberrepo.ErrNotFound):
+		return &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+	default:
+		return err
+	}
+}
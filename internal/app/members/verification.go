@@ -0,0 +1,223 @@
+package members
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/mailer"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+// EmailKind identifies which of a member's addresses a verification applies to.
+type EmailKind string
+
+const (
+	EmailKindPrimary    EmailKind = "email"
+	EmailKindGroupAlias EmailKind = "groupAliasEmail"
+)
+
+// VerificationOptions configures email verification tokens.
+type VerificationOptions struct {
+	// Secret signs tokens (HMAC-SHA256). When empty a random per-process secret is used,
+	// so outstanding links stop working on restart.
+	Secret []byte
+	// TTL bounds how long a verification link stays valid (default 24h).
+	TTL time.Duration
+	// ConfirmURL is the link base; the token is appended as the `token` query parameter.
+	ConfirmURL string
+}
+
+type verificationClaims struct {
+	MemberID  string    `json:"m"`
+	Kind      EmailKind `json:"k"`
+	Address   string    `json:"a"`
+	ExpiresAt int64     `json:"e"`
+}
+
+// RequestMyEmailVerification (re)sends a verification link for one of the caller's addresses.
+func (s *Service) RequestMyEmailVerification(ctx context.Context, subject domain.SubjectID, kind EmailKind) error {
+	m, err := s.repo.GetBySubject(ctx, subject)
+	if err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return &Error{
+				Status:  404,
+				Code:    "MEMBER_NOT_PROVISIONED",
+				Message: "No member profile exists for the authenticated subject.",
+			}
+		}
+		return err
+	}
+	addr, verifiedAt, ok := emailForKind(m, kind)
+	if !ok {
+		return &Error{
+			Status:  422,
+			Code:    "VALIDATION_ERROR",
+			Message: "invalid email kind",
+			Details: map[string]any{"kind": fmt.Sprintf("must be %q or %q", EmailKindPrimary, EmailKindGroupAlias)},
+		}
+	}
+	if addr == "" {
+		return &Error{
+			Status:  422,
+			Code:    "VALIDATION_ERROR",
+			Message: "no address to verify",
+			Details: map[string]any{string(kind): "is not set"},
+		}
+	}
+	if verifiedAt != nil {
+		return &Error{
+			Status:  409,
+			Code:    "EMAIL_ALREADY_VERIFIED",
+			Message: "The address is already verified.",
+		}
+	}
+	if s.mailer == nil {
+		return errors.New("email verification requires a mailer")
+	}
+	return s.sendVerification(ctx, m, kind)
+}
+
+// ConfirmEmailVerification marks the address named in token as verified.
+//
+// Tokens are bound to the address they were issued for: once the member changes that address,
+// older links are rejected. Confirming an already verified address succeeds without changes.
+func (s *Service) ConfirmEmailVerification(ctx context.Context, token string) (domain.Member, EmailKind, error) {
+	c, reason := s.parseVerificationToken(token)
+	if reason != "" {
+		return domain.Member{}, "", verificationInvalid(reason)
+	}
+	if c.Kind != EmailKindPrimary && c.Kind != EmailKindGroupAlias {
+		return domain.Member{}, "", verificationInvalid("stale")
+	}
+	// Only the verified-at time is written, and only while the address still matches the token,
+	// so concurrent profile edits are never overwritten.
+	err := s.repo.MarkEmailVerified(ctx, domain.MemberID(c.MemberID), memberrepo.EmailField(c.Kind), c.Address, s.clk.Now().UTC())
+	if err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) || errors.Is(err, memberrepo.ErrEmailChanged) {
+			return domain.Member{}, "", verificationInvalid("stale")
+		}
+		return domain.Member{}, "", err
+	}
+	m, err := s.repo.GetByID(ctx, domain.MemberID(c.MemberID))
+	if err != nil {
+		return domain.Member{}, "", err
+	}
+	return toDomain(m), c.Kind, nil
+}
+
+// sendVerifications mails links for the given addresses. Delivery is best-effort: the profile
+// change has already been saved, and the member can request a new link.
+func (s *Service) sendVerifications(ctx context.Context, m memberrepo.Member, kinds ...EmailKind) {
+	if s.mailer == nil {
+		return
+	}
+	for _, k := range kinds {
+		_ = s.sendVerification(ctx, m, k)
+	}
+}
+
+func (s *Service) sendVerification(ctx context.Context, m memberrepo.Member, kind EmailKind) error {
+	addr, _, _ := emailForKind(m, kind)
+	token, err := s.issueVerificationToken(m.ID, kind, addr)
+	if err != nil {
+		return err
+	}
+	link := token
+	if s.verification.ConfirmURL != "" {
+		link = s.verification.ConfirmURL + "?token=" + url.QueryEscape(token)
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      addr,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm that %s belongs to you:\n\n%s\n\nThis link expires in %s. If you did not request this, ignore this email.\n",
+			m.DisplayName, addr, link, s.verification.TTL),
+	})
+}
+
+func (s *Service) issueVerificationToken(id domain.MemberID, kind EmailKind, addr string) (string, error) {
+	payload, err := json.Marshal(verificationClaims{
+		MemberID:  string(id),
+		Kind:      kind,
+		Address:   addr,
+		ExpiresAt: s.clk.Now().Add(s.verification.TTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	p := base64.RawURLEncoding.EncodeToString(payload)
+	return p + "." + base64.RawURLEncoding.EncodeToString(s.sign(p)), nil
+}
+
+// parseVerificationToken returns the claims, or a non-empty failure reason.
+func (s *Service) parseVerificationToken(token string) (verificationClaims, string) {
+	p, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return verificationClaims{}, "malformed"
+	}
+	gotSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return verificationClaims{}, "malformed"
+	}
+	if !hmac.Equal(gotSig, s.sign(p)) {
+		return verificationClaims{}, "signature"
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(p)
+	if err != nil {
+		return verificationClaims{}, "malformed"
+	}
+	var c verificationClaims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return verificationClaims{}, "malformed"
+	}
+	if !s.clk.Now().Before(time.Unix(c.ExpiresAt, 0)) {
+		return verificationClaims{}, "expired"
+	}
+	return c, ""
+}
+
+func (s *Service) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.verification.Secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func emailForKind(m memberrepo.Member, kind EmailKind) (addr string, verifiedAt *time.Time, ok bool) {
+	switch kind {
+	case EmailKindPrimary:
+		return m.Email, m.EmailVerifiedAt, true
+	case EmailKindGroupAlias:
+		if m.GroupAliasEmail == nil {
+			return "", nil, true
+		}
+		return *m.GroupAliasEmail, m.GroupAliasEmailVerifiedAt, true
+	default:
+		return "", nil, false
+	}
+}
+
+func verificationInvalid(reason string) *Error {
+	return &Error{
+		Status:  422,
+		Code:    "VERIFICATION_TOKEN_INVALID",
+		Message: "The verification link is invalid or has expired.",
+		Details: map[string]any{"reason": reason},
+	}
+}
+
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("members: generate verification secret: %v", err))
+	}
+	return b
+}
@@ -0,0 +1,483 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/notifier"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+const (
+	maxAnnouncementSubjectLen = 200
+	maxAnnouncementBodyLen    = 10000
+
+	// announcementClaimTimeout is how long a claimed (SENDING) delivery is left to its sender
+	// before another sender may take it over.
+	announcementClaimTimeout = 10 * time.Minute
+	// announcementSweepBatch bounds the announcements DeliverPendingAnnouncements loads at once.
+	announcementSweepBatch = 50
+)
+
+var (
+	errAnnouncementsDisabled = errors.New("trip announcements are not configured")
+	errNoVerifiedEmail       = errors.New("member has no verified email address")
+)
+
+// TripAnnouncementInput is a new organizer announcement.
+type TripAnnouncementInput struct {
+	Subject  string
+	Body     string
+	Audience domain.AnnouncementAudience
+}
+
+// PostTripAnnouncement stores an announcement on a published or canceled trip and notifies
+// its audience, recording each recipient's delivery. Only organizers may post; the author
+// is never a recipient. Failed deliveries do not fail the call. With background delivery
+// on, announcements to EVERYONE are returned PENDING and sent by DeliverPendingAnnouncements.
+func (s *Service) PostTripAnnouncement(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in TripAnnouncementInput) (domain.TripAnnouncementReport, error) {
+	t, err := s.announcementTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	return s.postTripAnnouncement(ctx, t, announcementrepo.Announcement{AuthorMemberID: caller}, in)
+}
+
+// PostTripAnnouncementAsServiceAccount posts an announcement for a service account (an API key
+// granted announcements:write, named serviceAccount) on any published or canceled trip. The
+// audience and delivery rules are the same as for organizers.
+func (s *Service) PostTripAnnouncementAsServiceAccount(ctx context.Context, serviceAccount string, tripID domain.TripID, in TripAnnouncementInput) (domain.TripAnnouncementReport, error) {
+	if s.announcements == nil || s.notifier == nil {
+		return domain.TripAnnouncementReport{}, errAnnouncementsDisabled
+	}
+	if strings.TrimSpace(serviceAccount) == "" {
+		return domain.TripAnnouncementReport{}, errors.New("service account name is required")
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return domain.TripAnnouncementReport{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return domain.TripAnnouncementReport{}, err
+	}
+	// Service accounts see published and canceled trips only.
+	if t.Status == triprepo.StatusDraft {
+		return domain.TripAnnouncementReport{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return s.postTripAnnouncement(ctx, t, announcementrepo.Announcement{AuthorServiceAccount: serviceAccount}, in)
+}
+
+// postTripAnnouncement validates, stores and delivers an announcement on t. author carries only
+// the author fields; the caller has already checked who may post.
+func (s *Service) postTripAnnouncement(ctx context.Context, t triprepo.Trip, author announcementrepo.Announcement, in TripAnnouncementInput) (domain.TripAnnouncementReport, error) {
+	if t.Status == triprepo.StatusDraft {
+		return domain.TripAnnouncementReport{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "announcements can only be posted to published trips"}
+	}
+	in, err := validateTripAnnouncement(in)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	recipients, err := s.announcementRecipients(ctx, t, in.Audience, author.AuthorMemberID)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+
+	a := announcementrepo.Announcement{
+		ID:                   s.newAnnouncementID(),
+		TripID:               t.ID,
+		AuthorMemberID:       author.AuthorMemberID,
+		AuthorServiceAccount: author.AuthorServiceAccount,
+		Subject:              in.Subject,
+		Body:                 in.Body,
+		Audience:             in.Audience,
+		CreatedAt:            s.clk.Now().UTC(),
+	}
+	if err := s.announcements.Create(ctx, a, recipients); err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	// Mailing the whole club can take a while; leave it to the background sweep.
+	if !(s.backgroundAnnouncements && in.Audience == domain.AudienceEveryone) {
+		if err := s.deliverAnnouncement(ctx, t, a, false); err != nil {
+			return domain.TripAnnouncementReport{}, err
+		}
+	}
+	return s.announcementReport(ctx, a)
+}
+
+// ResendTripAnnouncement retries every delivery of the announcement that has not been sent.
+// Only organizers may resend.
+func (s *Service) ResendTripAnnouncement(ctx context.Context, caller domain.MemberID, tripID domain.TripID, id domain.TripAnnouncementID) (domain.TripAnnouncementReport, error) {
+	t, a, err := s.loadTripAnnouncement(ctx, caller, tripID, id)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	if err := s.deliverAnnouncement(ctx, t, a, true); err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	return s.announcementReport(ctx, a)
+}
+
+// DeliverPendingAnnouncements sends every announcement with PENDING deliveries, and takes over
+// deliveries whose sender stopped mid-send. Failed deliveries are left for organizers to resend.
+// It returns how many announcements it processed.
+func (s *Service) DeliverPendingAnnouncements(ctx context.Context) (int, error) {
+	if s.announcements == nil || s.notifier == nil {
+		return 0, nil
+	}
+	n := 0
+	for {
+		as, err := s.announcements.ListUndelivered(ctx, s.clk.Now().Add(-announcementClaimTimeout), announcementSweepBatch)
+		if err != nil {
+			return n, err
+		}
+		for _, a := range as {
+			t, err := s.trips.GetByID(ctx, a.TripID)
+			if err != nil {
+				return n, err
+			}
+			if err := s.deliverAnnouncement(ctx, t, a, false); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(as) < announcementSweepBatch {
+			return n, nil
+		}
+	}
+}
+
+// GetTripAnnouncementReport returns an announcement with its per-recipient delivery status.
+// Only organizers may see it.
+func (s *Service) GetTripAnnouncementReport(ctx context.Context, caller domain.MemberID, tripID domain.TripID, id domain.TripAnnouncementID) (domain.TripAnnouncementReport, error) {
+	_, a, err := s.loadTripAnnouncement(ctx, caller, tripID, id)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	return s.announcementReport(ctx, a)
+}
+
+// ListTripAnnouncements returns the trip's announcements, newest first. Anyone who can see
+// the trip can read them.
+func (s *Service) ListTripAnnouncements(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.TripAnnouncement, error) {
+	if s.announcements == nil {
+		return nil, errAnnouncementsDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return nil, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return s.tripAnnouncements(ctx, tripID)
+}
+
+// tripAnnouncements lists the trip's announcements for trip details, newest first.
+func (s *Service) tripAnnouncements(ctx context.Context, tripID domain.TripID) ([]domain.TripAnnouncement, error) {
+	as, err := s.announcements.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]domain.MemberID, 0, len(as))
+	for _, a := range as {
+		if a.AuthorMemberID != "" {
+			ids = append(ids, a.AuthorMemberID)
+		}
+	}
+	authors, err := s.memberSummariesByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.TripAnnouncement, 0, len(as))
+	for _, a := range as {
+		out = append(out, tripAnnouncementFromRepo(a, authors[a.AuthorMemberID]))
+	}
+	return out, nil
+}
+
+// announcementTrip loads a trip whose announcements the caller may manage.
+func (s *Service) announcementTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	if s.announcements == nil || s.notifier == nil {
+		return triprepo.Trip{}, errAnnouncementsDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if !isOrganizer(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can manage announcements"}
+	}
+	return t, nil
+}
+
+func (s *Service) loadTripAnnouncement(ctx context.Context, caller domain.MemberID, tripID domain.TripID, id domain.TripAnnouncementID) (triprepo.Trip, announcementrepo.Announcement, error) {
+	t, err := s.announcementTrip(ctx, caller, tripID)
+	if err != nil {
+		return triprepo.Trip{}, announcementrepo.Announcement{}, err
+	}
+	a, err := s.announcements.Get(ctx, id)
+	if err != nil && !errors.Is(err, announcementrepo.ErrNotFound) {
+		return triprepo.Trip{}, announcementrepo.Announcement{}, err
+	}
+	if err != nil || a.TripID != tripID {
+		return triprepo.Trip{}, announcementrepo.Announcement{}, &Error{Status: 404, Code: "ANNOUNCEMENT_NOT_FOUND", Message: "announcement not found"}
+	}
+	return t, a, nil
+}
+
+// announcementRecipients resolves an audience to active members, leaving out the author.
+func (s *Service) announcementRecipients(ctx context.Context, t triprepo.Trip, audience domain.AnnouncementAudience, author domain.MemberID) ([]domain.MemberID, error) {
+	// One read of the active roster filters every audience, rather than a lookup per recipient.
+	active, err := s.members.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	isActive := make(map[domain.MemberID]bool, len(active))
+	for _, m := range active {
+		isActive[m.ID] = true
+	}
+
+	var ids []domain.MemberID
+	switch audience {
+	case domain.AudienceAttendees:
+		expected, err := s.expectedAttendees(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		ids = expected
+	case domain.AudienceNotAttending:
+		recs, err := s.rsvps.ListByTrip(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range recs {
+			if r.Status == rsvprepo.StatusNo {
+				ids = append(ids, r.MemberID)
+			}
+		}
+	case domain.AudienceWaitlisted:
+		waiting, err := s.waitlistedMembers(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		ids = waiting
+	case domain.AudienceEveryone:
+		for _, m := range active {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	out := make([]domain.MemberID, 0, len(ids))
+	seen := map[domain.MemberID]bool{author: true}
+	for _, id := range ids {
+		if seen[id] || !isActive[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// waitlistedMembers returns riders with a pending ride-share request who are not already
+// expected on the trip.
+func (s *Service) waitlistedMembers(ctx context.Context, t triprepo.Trip) ([]domain.MemberID, error) {
+	if s.rides == nil {
+		return nil, nil
+	}
+	expected, err := s.expectedAttendees(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	reqs, err := s.rides.ListRequestsByTrip(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	var out []domain.MemberID
+	for _, rr := range reqs {
+		if rr.Status == ridesharerepo.StatusPending && !slices.Contains(expected, rr.RiderMemberID) {
+			out = append(out, rr.RiderMemberID)
+		}
+	}
+	return out, nil
+}
+
+// deliverAnnouncement notifies every recipient not yet sent to at each of their verified
+// addresses and records the outcome; FAILED deliveries are retried only when retryFailed is
+// set. Each delivery is claimed before it is sent, so concurrent posts, resends and sweeps never
+// mail a recipient twice, and addresses already sent to are skipped on retries.
+func (s *Service) deliverAnnouncement(ctx context.Context, t triprepo.Trip, a announcementrepo.Announcement, retryFailed bool) error {
+	ds, err := s.announcements.ListDeliveries(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	tripName := "a trip"
+	if t.Name != nil && *t.Name != "" {
+		tripName = *t.Name
+	}
+	subject := fmt.Sprintf("[%s] %s", tripName, a.Subject)
+	signature := fmt.Sprintf("Sent by %s on behalf of the organizers of %s.", a.AuthorServiceAccount, tripName)
+	if a.AuthorMemberID != "" {
+		author, err := s.members.GetByID(ctx, a.AuthorMemberID)
+		if err != nil {
+			return err
+		}
+		signature = fmt.Sprintf("Sent by %s, organizer of %s.", author.DisplayName, tripName)
+	}
+	body := fmt.Sprintf("%s\n\n--\n%s\n", a.Body, signature)
+
+	for _, d := range ds {
+		if d.Status == announcementrepo.StatusSent || (d.Status == announcementrepo.StatusFailed && !retryFailed) {
+			continue
+		}
+		now := s.clk.Now().UTC()
+		d, claimed, err := s.announcements.ClaimDelivery(ctx, a.ID, d.MemberID, now, now.Add(-announcementClaimTimeout))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		m, err := s.members.GetByID(ctx, d.MemberID)
+		if err != nil {
+			return err
+		}
+		// Only verified addresses are mailed; a member without one is recorded as failed so
+		// organizers can see it, and a later resend picks them up once they verify.
+		var sendErr error
+		emails := memberNotificationEmails(m)
+		if len(emails) == 0 {
+			sendErr = errNoVerifiedEmail
+		}
+		for _, email := range emails {
+			if slices.Contains(d.SentTo, email) {
+				continue
+			}
+			if err := s.notifier.Notify(ctx, notifier.Message{
+				To:      notifier.Recipient{MemberID: m.ID, DisplayName: m.DisplayName, Email: email},
+				Subject: subject,
+				Body:    body,
+			}); err != nil {
+				sendErr = errors.Join(sendErr, err)
+				continue
+			}
+			// Record each address as it is sent, so a sender dying mid-recipient never
+			// leads to a second copy.
+			d.SentTo = append(d.SentTo, email)
+			if err := s.announcements.UpdateDelivery(ctx, d); err != nil {
+				return err
+			}
+		}
+		d.AttemptedAt = &now
+		d.Status, d.Error = announcementrepo.StatusSent, ""
+		if sendErr != nil {
+			d.Status, d.Error = announcementrepo.StatusFailed, sendErr.Error()
+		}
+		if err := s.announcements.UpdateDelivery(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memberNotificationEmails returns the member's verified addresses, primary first.
+func memberNotificationEmails(m memberrepo.Member) []string {
+	return domain.Member{
+		Email:                     m.Email,
+		EmailVerifiedAt:           m.EmailVerifiedAt,
+		GroupAliasEmail:           m.GroupAliasEmail,
+		GroupAliasEmailVerifiedAt: m.GroupAliasEmailVerifiedAt,
+	}.NotificationEmails()
+}
+
+func (s *Service) announcementReport(ctx context.Context, a announcementrepo.Announcement) (domain.TripAnnouncementReport, error) {
+	ds, err := s.announcements.ListDeliveries(ctx, a.ID)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	ids := make([]domain.MemberID, 0, len(ds)+1)
+	if a.AuthorMemberID != "" {
+		ids = append(ids, a.AuthorMemberID)
+	}
+	for _, d := range ds {
+		ids = append(ids, d.MemberID)
+	}
+	ms, err := s.memberSummariesByID(ctx, ids)
+	if err != nil {
+		return domain.TripAnnouncementReport{}, err
+	}
+	out := domain.TripAnnouncementReport{
+		TripAnnouncement: tripAnnouncementFromRepo(a, ms[a.AuthorMemberID]),
+		Deliveries:       make([]domain.AnnouncementDelivery, 0, len(ds)),
+	}
+	for _, d := range ds {
+		switch d.Status {
+		case announcementrepo.StatusSent:
+			out.Summary.Sent++
+		case announcementrepo.StatusFailed:
+			out.Summary.Failed++
+		default:
+			out.Summary.Pending++
+		}
+		out.Deliveries = append(out.Deliveries, domain.AnnouncementDelivery{
+			Recipient:   ms[d.MemberID],
+			Status:      domain.AnnouncementDeliveryStatus(d.Status),
+			Error:       d.Error,
+			AttemptedAt: d.AttemptedAt,
+		})
+	}
+	// Recipients read best by name, like other member lists.
+	sort.SliceStable(out.Deliveries, func(i, j int) bool {
+		return strings.ToLower(out.Deliveries[i].Recipient.DisplayName) < strings.ToLower(out.Deliveries[j].Recipient.DisplayName)
+	})
+	return out, nil
+}
+
+func validateTripAnnouncement(in TripAnnouncementInput) (TripAnnouncementInput, error) {
+	in.Subject = strings.TrimSpace(in.Subject)
+	if in.Subject == "" || utf8.RuneCountInString(in.Subject) > maxAnnouncementSubjectLen || !utf8.ValidString(in.Subject) || hasUnsafeRunes(in.Subject, "") {
+		return in, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid subject", Details: map[string]any{"subject": "must be a single line of 1-200 characters"}}
+	}
+	in.Body = strings.TrimSpace(strings.ReplaceAll(in.Body, "\r\n", "\n"))
+	if in.Body == "" || utf8.RuneCountInString(in.Body) > maxAnnouncementBodyLen || !utf8.ValidString(in.Body) || hasUnsafeRunes(in.Body, "\n\t") {
+		return in, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid body", Details: map[string]any{"body": "must be 1-10000 characters without control characters"}}
+	}
+	if !in.Audience.Valid() {
+		return in, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid audience", Details: map[string]any{"audience": "must be ATTENDEES, NOT_ATTENDING, WAITLISTED or EVERYONE"}}
+	}
+	return in, nil
+}
+
+func tripAnnouncementFromRepo(a announcementrepo.Announcement, author domain.MemberSummary) domain.TripAnnouncement {
+	return domain.TripAnnouncement{
+		ID:             a.ID,
+		TripID:         a.TripID,
+		Author:         author,
+		ServiceAccount: a.AuthorServiceAccount,
+		Subject:        a.Subject,
+		Body:           a.Body,
+		Audience:       a.Audience,
+		CreatedAt:      a.CreatedAt,
+	}
+}
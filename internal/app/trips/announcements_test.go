@@ -0,0 +1,348 @@
+package trips_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	memannouncementrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/announcementrepo"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memnotifier "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/notifier"
+	memridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ridesharerepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	portmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+)
+
+func newAnnouncementsService(t *testing.T) (*trips.Service, *memnotifier.Recorder, *memclock.ManualClock) {
+	t.Helper()
+	return newAnnouncementsServiceWithOptions(t, nil)
+}
+
+// newAnnouncementsServiceWithOptions is newAnnouncementsService with configure applied to the
+// service options.
+func newAnnouncementsServiceWithOptions(t *testing.T, configure func(*trips.Options)) (*trips.Service, *memnotifier.Recorder, *memclock.ManualClock) {
+	t.Helper()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	now := time.Unix(100, 0).UTC()
+	for _, m := range []portmemberrepo.Member{
+		{ID: "org", EmailVerifiedAt: &now, IsActive: true},
+		{ID: "m1", EmailVerifiedAt: &now, IsActive: true},
+		{ID: "m2", EmailVerifiedAt: &now, IsActive: true},
+		{ID: "m3", EmailVerifiedAt: &now, IsActive: true},
+		{ID: "m4", IsActive: true},
+		{ID: "gone", EmailVerifiedAt: &now},
+	} {
+		m.Subject = domain.SubjectID("sub-" + string(m.ID))
+		m.DisplayName = "Member " + string(m.ID)
+		m.Email = string(m.ID) + "@example.com"
+		m.CreatedAt, m.UpdatedAt = now, now
+		if err := membersRepo.Create(context.Background(), m); err != nil {
+			t.Fatalf("create member %s: %v", m.ID, err)
+		}
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	rec := memnotifier.NewRecorder()
+	clk := memclock.NewManualClock(time.Unix(5_000, 0).UTC())
+	opts := trips.Options{
+		Announcements: memannouncementrepo.NewRepo(),
+		RideShares:    memridesharerepo.NewRepo(),
+		Notifier:      rec,
+		Clock:         clk,
+	}
+	if configure != nil {
+		configure(&opts)
+	}
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), opts)
+	return svc, rec, clk
+}
+
+func recipientIDs(r domain.TripAnnouncementReport) []domain.MemberID {
+	var out []domain.MemberID
+	for _, d := range r.Deliveries {
+		out = append(out, d.Recipient.ID)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestService_TripAnnouncements_AudiencesAndDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, rec, clk := newAnnouncementsService(t)
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP m1: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); err != nil {
+		t.Fatalf("SetMyRSVP m2: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "org", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP org: %v", err)
+	}
+
+	// The author never receives their own announcement.
+	rep, err := svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: " Meet at 7 ", Body: "Bring recovery gear.\r\n", Audience: domain.AudienceAttendees})
+	if err != nil {
+		t.Fatalf("PostTripAnnouncement attendees: %v", err)
+	}
+	if got := recipientIDs(rep); !slices.Equal(got, []domain.MemberID{"m1"}) {
+		t.Fatalf("attendee recipients = %v", got)
+	}
+	if rep.Subject != "Meet at 7" || rep.Body != "Bring recovery gear." || rep.Summary.Sent != 1 || rep.Deliveries[0].Status != domain.DeliverySent || rep.Deliveries[0].AttemptedAt == nil {
+		t.Fatalf("report = %+v", rep)
+	}
+	sent := rec.Sent()
+	if len(sent) != 1 || sent[0].To.Email != "m1@example.com" || sent[0].Subject != "[Canyon Run] Meet at 7" {
+		t.Fatalf("sent = %+v", sent)
+	}
+
+	clk.Add(time.Minute)
+	rep, err = svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Spots open", Body: "Still room.", Audience: domain.AudienceNotAttending})
+	if err != nil || !slices.Equal(recipientIDs(rep), []domain.MemberID{"m2"}) {
+		t.Fatalf("not attending recipients = %v err=%v", recipientIDs(rep), err)
+	}
+
+	// The waitlist is riders still waiting on a seat.
+	if _, err := svc.OfferRideSeats(ctx, "m1", "tp", trips.RideOfferInput{Seats: 1}); err != nil {
+		t.Fatalf("OfferRideSeats: %v", err)
+	}
+	if _, err := svc.RequestRide(ctx, "m3", "tp", trips.RequestRideInput{DriverMemberID: "m1"}); err != nil {
+		t.Fatalf("RequestRide: %v", err)
+	}
+	clk.Add(time.Minute)
+	rep, err = svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Seats", Body: "Hang tight.", Audience: domain.AudienceWaitlisted})
+	if err != nil || !slices.Equal(recipientIDs(rep), []domain.MemberID{"m3"}) {
+		t.Fatalf("waitlisted recipients = %v err=%v", recipientIDs(rep), err)
+	}
+
+	// Everyone covers active members only; failures are recorded and can be retried.
+	// Members without a verified address are never mailed.
+	rec.FailFor("m3")
+	clk.Add(time.Minute)
+	before := len(rec.Sent())
+	rep, err = svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Club news", Body: "Hello all.", Audience: domain.AudienceEveryone})
+	if err != nil {
+		t.Fatalf("PostTripAnnouncement everyone: %v", err)
+	}
+	if got := recipientIDs(rep); !slices.Equal(got, []domain.MemberID{"m1", "m2", "m3", "m4"}) {
+		t.Fatalf("everyone recipients = %v", got)
+	}
+	if rep.Summary != (domain.AnnouncementDeliverySummary{Sent: 2, Failed: 2}) {
+		t.Fatalf("summary = %+v", rep.Summary)
+	}
+	for _, d := range rep.Deliveries {
+		if d.Recipient.ID == "m3" && (d.Status != domain.DeliveryFailed || d.Error != memnotifier.ErrUndeliverable.Error()) {
+			t.Fatalf("failed delivery = %+v", d)
+		}
+		if d.Recipient.ID == "m4" && (d.Status != domain.DeliveryFailed || d.Error != "member has no verified email address") {
+			t.Fatalf("unverified delivery = %+v", d)
+		}
+	}
+	for _, m := range rec.Sent()[before:] {
+		if m.To.Email == "m4@example.com" {
+			t.Fatalf("mailed an unverified address: %+v", m)
+		}
+	}
+	before = len(rec.Sent())
+	if _, err := svc.ResendTripAnnouncement(ctx, "org", "tp", rep.ID); err != nil {
+		t.Fatalf("ResendTripAnnouncement: %v", err)
+	}
+	if len(rec.Sent()) != before {
+		t.Fatalf("resend delivered to already-sent recipients")
+	}
+
+	_, err = svc.GetTripAnnouncementReport(ctx, "m1", "tp", rep.ID)
+	requireTripsErrorCode(t, err, "FORBIDDEN")
+	_, err = svc.GetTripAnnouncementReport(ctx, "org", "tp", "missing")
+	requireTripsErrorCode(t, err, "ANNOUNCEMENT_NOT_FOUND")
+
+	// Members read past announcements, newest first, in the trip details.
+	td, err := svc.GetTripDetails(ctx, "m2", "tp")
+	if err != nil {
+		t.Fatalf("GetTripDetails: %v", err)
+	}
+	if len(td.Announcements) != 4 || td.Announcements[0].Subject != "Club news" || td.Announcements[0].Author.ID != "org" {
+		t.Fatalf("announcements = %+v", td.Announcements)
+	}
+}
+
+func TestService_TripAnnouncements_ResendSkipsClaimedAndSentAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	announcementsRepo := memannouncementrepo.NewRepo()
+	now := time.Unix(100, 0).UTC()
+	alias := "m1-alias@example.com"
+	for _, m := range []portmemberrepo.Member{
+		{ID: "org", Email: "org@example.com", EmailVerifiedAt: &now, IsActive: true},
+		{ID: "m1", Email: "m1@example.com", EmailVerifiedAt: &now, GroupAliasEmail: &alias, GroupAliasEmailVerifiedAt: &now, IsActive: true},
+	} {
+		m.Subject = domain.SubjectID("sub-" + string(m.ID))
+		m.DisplayName = "Member " + string(m.ID)
+		m.CreatedAt, m.UpdatedAt = now, now
+		if err := membersRepo.Create(ctx, m); err != nil {
+			t.Fatalf("create member %s: %v", m.ID, err)
+		}
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	rec := memnotifier.NewRecorder()
+	clk := memclock.NewManualClock(time.Unix(5_000, 0).UTC())
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{
+		Announcements: announcementsRepo,
+		RideShares:    memridesharerepo.NewRepo(),
+		Notifier:      rec,
+		Clock:         clk,
+	})
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP m1: %v", err)
+	}
+
+	// The alias bounces; the primary address is recorded as sent.
+	rec.FailForEmail(alias)
+	rep, err := svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Meet at 7", Body: "See you there.", Audience: domain.AudienceAttendees})
+	if err != nil {
+		t.Fatalf("PostTripAnnouncement: %v", err)
+	}
+	if rep.Summary != (domain.AnnouncementDeliverySummary{Failed: 1}) {
+		t.Fatalf("summary = %+v", rep.Summary)
+	}
+	if sent := rec.Sent(); len(sent) != 1 || sent[0].To.Email != "m1@example.com" {
+		t.Fatalf("sent = %+v", sent)
+	}
+	rec.ClearFailures()
+
+	// A delivery another sender holds is left alone.
+	if _, claimed, err := announcementsRepo.ClaimDelivery(ctx, rep.ID, "m1", clk.Now(), clk.Now()); err != nil || !claimed {
+		t.Fatalf("ClaimDelivery claimed=%v err=%v", claimed, err)
+	}
+	rep, err = svc.ResendTripAnnouncement(ctx, "org", "tp", rep.ID)
+	if err != nil {
+		t.Fatalf("ResendTripAnnouncement held: %v", err)
+	}
+	if len(rec.Sent()) != 1 || rep.Summary != (domain.AnnouncementDeliverySummary{Pending: 1}) {
+		t.Fatalf("resend of a held delivery sent %d, summary = %+v", len(rec.Sent())-1, rep.Summary)
+	}
+
+	// Once the claim is stale, a resend mails only the address not yet sent to.
+	clk.Add(time.Hour)
+	rep, err = svc.ResendTripAnnouncement(ctx, "org", "tp", rep.ID)
+	if err != nil {
+		t.Fatalf("ResendTripAnnouncement stale: %v", err)
+	}
+	if sent := rec.Sent(); len(sent) != 2 || sent[1].To.Email != alias {
+		t.Fatalf("sent = %+v", sent)
+	}
+	if rep.Summary != (domain.AnnouncementDeliverySummary{Sent: 1}) {
+		t.Fatalf("summary = %+v", rep.Summary)
+	}
+}
+
+func TestService_TripAnnouncements_BackgroundDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, rec, clk := newAnnouncementsServiceWithOptions(t, func(o *trips.Options) { o.BackgroundAnnouncements = true })
+	if _, err := svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); err != nil {
+		t.Fatalf("SetMyRSVP m2: %v", err)
+	}
+
+	// Smaller audiences are still delivered while posting.
+	rep, err := svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Spots open", Body: "Still room.", Audience: domain.AudienceNotAttending})
+	if err != nil || rep.Summary != (domain.AnnouncementDeliverySummary{Sent: 1}) {
+		t.Fatalf("not attending summary = %+v err=%v", rep.Summary, err)
+	}
+
+	// The whole club is left pending for the background sweep.
+	clk.Add(time.Minute)
+	rep, err = svc.PostTripAnnouncement(ctx, "org", "tp", trips.TripAnnouncementInput{Subject: "Club news", Body: "Hello all.", Audience: domain.AudienceEveryone})
+	if err != nil {
+		t.Fatalf("PostTripAnnouncement everyone: %v", err)
+	}
+	if rep.Summary != (domain.AnnouncementDeliverySummary{Pending: 4}) || len(rec.Sent()) != 1 {
+		t.Fatalf("posted summary = %+v, sent %d", rep.Summary, len(rec.Sent()))
+	}
+
+	n, err := svc.DeliverPendingAnnouncements(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("DeliverPendingAnnouncements = %d err=%v, want 1", n, err)
+	}
+	rep, err = svc.GetTripAnnouncementReport(ctx, "org", "tp", rep.ID)
+	if err != nil {
+		t.Fatalf("GetTripAnnouncementReport: %v", err)
+	}
+	if rep.Summary != (domain.AnnouncementDeliverySummary{Sent: 3, Failed: 1}) || len(rec.Sent()) != 4 {
+		t.Fatalf("delivered summary = %+v, sent %d", rep.Summary, len(rec.Sent()))
+	}
+
+	// Failures wait for an organizer to resend rather than being retried every sweep.
+	if n, err := svc.DeliverPendingAnnouncements(ctx); err != nil || n != 0 {
+		t.Fatalf("DeliverPendingAnnouncements again = %d err=%v, want 0", n, err)
+	}
+}
+
+func TestService_TripAnnouncements_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _, _ := newAnnouncementsService(t)
+
+	_, err := svc.PostTripAnnouncement(ctx, "m1", "tp", trips.TripAnnouncementInput{Subject: "Hi", Body: "Hi", Audience: domain.AudienceEveryone})
+	requireTripsErrorCode(t, err, "FORBIDDEN")
+	for _, in := range []trips.TripAnnouncementInput{
+		{Subject: "", Body: "Hi", Audience: domain.AudienceEveryone},
+		{Subject: "Two\nlines", Body: "Hi", Audience: domain.AudienceEveryone},
+		{Subject: "Hi", Body: "  ", Audience: domain.AudienceEveryone},
+		{Subject: "Hi", Body: "Hi", Audience: "ORGANIZERS"},
+	} {
+		_, err := svc.PostTripAnnouncement(ctx, "org", "tp", in)
+		requireTripsErrorCode(t, err, "VALIDATION_ERROR")
+	}
+	as, err := svc.ListTripAnnouncements(ctx, "m1", "tp")
+	if err != nil || len(as) != 0 {
+		t.Fatalf("ListTripAnnouncements = %+v err=%v", as, err)
+	}
+}
+
+func TestService_TripAnnouncements_ServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, rec, _ := newAnnouncementsService(t)
+
+	// Service accounts have no author to leave out, so organizers are recipients too.
+	rep, err := svc.PostTripAnnouncementAsServiceAccount(ctx, "discord-bot", "tp", trips.TripAnnouncementInput{Subject: "Weather", Body: "Rain expected.", Audience: domain.AudienceEveryone})
+	if err != nil {
+		t.Fatalf("PostTripAnnouncementAsServiceAccount: %v", err)
+	}
+	if rep.ServiceAccount != "discord-bot" || rep.Author.ID != "" {
+		t.Fatalf("report author = %+v / %q", rep.Author, rep.ServiceAccount)
+	}
+	if got := recipientIDs(rep); !slices.Equal(got, []domain.MemberID{"m1", "m2", "m3", "m4", "org"}) {
+		t.Fatalf("recipients = %v", got)
+	}
+	sent := rec.Sent()
+	if len(sent) == 0 || !strings.HasSuffix(sent[0].Body, "Sent by discord-bot on behalf of the organizers of Canyon Run.\n") {
+		t.Fatalf("sent = %+v", sent)
+	}
+
+	// Organizers follow up on it like any other announcement.
+	if _, err := svc.GetTripAnnouncementReport(ctx, "org", "tp", rep.ID); err != nil {
+		t.Fatalf("GetTripAnnouncementReport: %v", err)
+	}
+	as, err := svc.ListTripAnnouncements(ctx, "m1", "tp")
+	if err != nil || len(as) != 1 || as[0].ServiceAccount != "discord-bot" {
+		t.Fatalf("ListTripAnnouncements = %+v err=%v", as, err)
+	}
+
+	_, err = svc.PostTripAnnouncementAsServiceAccount(ctx, "discord-bot", "missing", trips.TripAnnouncementInput{Subject: "Hi", Body: "Hi", Audience: domain.AudienceEveryone})
+	requireTripsErrorCode(t, err, "TRIP_NOT_FOUND")
+}
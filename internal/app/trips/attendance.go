@@ -0,0 +1,273 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+// checkInMargin widens the check-in window on both sides. Trip dates carry no time zone, so
+// this keeps the whole of each trip day open wherever the trip runs.
+const checkInMargin = 14 * time.Hour
+
+var errAttendanceDisabled = errors.New("trip attendance is not configured")
+
+// CheckInMember records whether a member showed up to a published trip. Only organizers may
+// check members in, from the trip's start date through its end date. Members without a YES
+// RSVP or an accepted ride can be checked in as walk-ups but not marked absent.
+func (s *Service) CheckInMember(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, status domain.AttendanceStatus) (domain.AttendanceRecord, error) {
+	if !status.Valid() {
+		return domain.AttendanceRecord{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid status", Details: map[string]any{"status": "must be PRESENT or ABSENT"}}
+	}
+	t, err := s.attendanceTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.AttendanceRecord{}, err
+	}
+	now := s.clk.Now()
+	if !checkInOpen(t, now) {
+		details := map[string]any{}
+		if t.StartDate != nil && t.EndDate != nil {
+			details["startDate"] = t.StartDate.UTC().Format(time.DateOnly)
+			details["endDate"] = t.EndDate.UTC().Format(time.DateOnly)
+		}
+		return domain.AttendanceRecord{}, &Error{Status: 409, Code: "CHECK_IN_CLOSED", Message: "check-in is only open during the trip's dates", Details: details}
+	}
+	if err := s.requireMember(ctx, memberID); err != nil {
+		return domain.AttendanceRecord{}, err
+	}
+
+	expected, err := s.expectedAttendees(ctx, t)
+	if err != nil {
+		return domain.AttendanceRecord{}, err
+	}
+	isExpected := slices.Contains(expected, memberID)
+	if status == domain.AttendanceAbsent && !isExpected {
+		return domain.AttendanceRecord{}, &Error{Status: 409, Code: "MEMBER_NOT_EXPECTED", Message: "only members expected on the trip can be marked absent"}
+	}
+
+	rec := attendancerepo.Record{
+		TripID:     tripID,
+		MemberID:   memberID,
+		Status:     attendancerepo.Status(status),
+		RecordedBy: caller,
+		UpdatedAt:  now,
+	}
+	if status == domain.AttendancePresent {
+		rec.WalkUp = !isExpected
+		// Re-marking someone present keeps the time they first checked in.
+		checkedIn := now
+		existing, err := s.attendance.ListByTrip(ctx, tripID)
+		if err != nil {
+			return domain.AttendanceRecord{}, err
+		}
+		for _, e := range existing {
+			if e.MemberID == memberID && e.CheckedInAt != nil {
+				checkedIn = *e.CheckedInAt
+			}
+		}
+		rec.CheckedInAt = &checkedIn
+	}
+	if err := s.attendance.Put(ctx, rec); err != nil {
+		return domain.AttendanceRecord{}, err
+	}
+	ms, err := s.loadMemberSummariesSorted(ctx, []domain.MemberID{memberID})
+	if err != nil {
+		return domain.AttendanceRecord{}, err
+	}
+	return attendanceRecordFromRepo(rec, ms[0]), nil
+}
+
+// GetTripAttendance returns a published trip's check-in sheet. Only organizers may see it.
+func (s *Service) GetTripAttendance(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.TripAttendance, error) {
+	t, err := s.attendanceTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.TripAttendance{}, err
+	}
+	recs, err := s.attendance.ListByTrip(ctx, tripID)
+	if err != nil {
+		return domain.TripAttendance{}, err
+	}
+	expected, err := s.expectedAttendees(ctx, t)
+	if err != nil {
+		return domain.TripAttendance{}, err
+	}
+
+	ids := make([]domain.MemberID, 0, len(recs))
+	byMember := make(map[domain.MemberID]attendancerepo.Record, len(recs))
+	for _, r := range recs {
+		ids = append(ids, r.MemberID)
+		byMember[r.MemberID] = r
+	}
+	ms, err := s.loadMemberSummariesSorted(ctx, ids)
+	if err != nil {
+		return domain.TripAttendance{}, err
+	}
+	out := domain.TripAttendance{
+		CheckInOpen: checkInOpen(t, s.clk.Now()),
+		Summary:     summarizeAttendance(recs, expected),
+		Records:     make([]domain.AttendanceRecord, 0, len(ms)),
+	}
+	for _, m := range ms {
+		out.Records = append(out.Records, attendanceRecordFromRepo(byMember[m.ID], m))
+	}
+	return out, nil
+}
+
+// GetTripAttendanceReliability returns attendance stats, across all trips, for everyone
+// expected on or checked in to a published trip, sorted by display name. Only the trip's
+// organizers may see them.
+func (s *Service) GetTripAttendanceReliability(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.MemberReliability, error) {
+	t, err := s.attendanceTrip(ctx, caller, tripID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.expectedAttendees(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	recs, err := s.attendance.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recs {
+		if !slices.Contains(ids, r.MemberID) {
+			ids = append(ids, r.MemberID)
+		}
+	}
+	ms, err := s.loadMemberSummariesSorted(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.MemberReliability, 0, len(ms))
+	for _, m := range ms {
+		history, err := s.attendance.ListByMember(ctx, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		rel := domain.MemberReliability{Member: m}
+		for _, r := range history {
+			switch {
+			case r.Status == attendancerepo.StatusAbsent:
+				rel.NoShows++
+			case r.WalkUp:
+				rel.WalkUps++
+			default:
+				rel.Attended++
+			}
+		}
+		if n := rel.Attended + rel.NoShows; n > 0 {
+			pct := int(math.Round(float64(rel.Attended) * 100 / float64(n)))
+			rel.ShowRatePercent = &pct
+		}
+		out = append(out, rel)
+	}
+	return out, nil
+}
+
+// attendanceTrip loads a published trip whose attendance the caller may manage.
+func (s *Service) attendanceTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	if s.attendance == nil {
+		return triprepo.Trip{}, errAttendanceDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if t.Status != triprepo.StatusPublished {
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "attendance is only tracked for published trips"}
+	}
+	if !isOrganizer(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can manage attendance"}
+	}
+	return t, nil
+}
+
+// expectedAttendees lists the members who said they would come: YES RSVPs and accepted
+// ride-share riders.
+func (s *Service) expectedAttendees(ctx context.Context, t triprepo.Trip) ([]domain.MemberID, error) {
+	recs, err := s.rsvps.ListByTrip(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.MemberID, 0, len(recs))
+	for _, r := range recs {
+		if r.Status == rsvprepo.StatusYes {
+			out = append(out, r.MemberID)
+		}
+	}
+	if s.rides != nil {
+		reqs, err := s.rides.ListRequestsByTrip(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rr := range reqs {
+			if rr.Status == ridesharerepo.StatusAccepted && !slices.Contains(out, rr.RiderMemberID) {
+				out = append(out, rr.RiderMemberID)
+			}
+		}
+	}
+	return out, nil
+}
+
+// checkInOpen reports whether now falls within the trip's dates.
+func checkInOpen(t triprepo.Trip, now time.Time) bool {
+	if t.StartDate == nil || t.EndDate == nil {
+		return false
+	}
+	opens := calendarDate(*t.StartDate).Add(-checkInMargin)
+	closes := calendarDate(*t.EndDate).AddDate(0, 0, 1).Add(checkInMargin)
+	return !now.Before(opens) && now.Before(closes)
+}
+
+func summarizeAttendance(recs []attendancerepo.Record, expected []domain.MemberID) domain.AttendanceSummary {
+	var out domain.AttendanceSummary
+	recorded := make(map[domain.MemberID]bool, len(recs))
+	for _, r := range recs {
+		recorded[r.MemberID] = true
+		switch r.Status {
+		case attendancerepo.StatusPresent:
+			out.Present++
+			if r.WalkUp {
+				out.WalkUps++
+			}
+		case attendancerepo.StatusAbsent:
+			out.Absent++
+		}
+	}
+	for _, id := range expected {
+		if !recorded[id] {
+			out.NotCheckedIn++
+		}
+	}
+	return out
+}
+
+func attendanceRecordFromRepo(r attendancerepo.Record, m domain.MemberSummary) domain.AttendanceRecord {
+	out := domain.AttendanceRecord{
+		Member:     m,
+		Status:     domain.AttendanceStatus(r.Status),
+		WalkUp:     r.WalkUp,
+		RecordedBy: r.RecordedBy,
+		UpdatedAt:  r.UpdatedAt,
+	}
+	if r.CheckedInAt != nil {
+		v := *r.CheckedInAt
+		out.CheckedInAt = &v
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memattendancerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/attendancerepo"
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+func TestService_Attendance_CheckInWindowWalkUpsAndReliability(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1", "m2", "m3"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	clk := memclock.NewManualClock(time.Date(2026, 4, 29, 12, 0, 0, 0, time.UTC))
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{
+		Attendance: memattendancerepo.NewRepo(),
+		Clock:      clk,
+	})
+	for _, id := range []domain.MemberID{"m1", "m2"} {
+		if _, err := svc.SetMyRSVP(ctx, id, "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+			t.Fatalf("SetMyRSVP(%s): %v", id, err)
+		}
+	}
+
+	var ae *trips.Error
+	if _, err := svc.CheckInMember(ctx, "org", "tp", "m1", domain.AttendancePresent); !errors.As(err, &ae) || ae.Code != "CHECK_IN_CLOSED" {
+		t.Fatalf("CheckInMember before the trip err=%v, want CHECK_IN_CLOSED", err)
+	}
+
+	// The trip runs 2026-05-01..02.
+	start := time.Date(2026, 5, 1, 7, 30, 0, 0, time.UTC)
+	clk.Set(start)
+	if _, err := svc.CheckInMember(ctx, "m1", "tp", "m2", domain.AttendancePresent); !errors.As(err, &ae) || ae.Status != 403 {
+		t.Fatalf("CheckInMember by non-organizer err=%v, want 403", err)
+	}
+	if _, err := svc.CheckInMember(ctx, "org", "tp", "m1", "LATE"); !errors.As(err, &ae) || ae.Status != 422 {
+		t.Fatalf("CheckInMember invalid status err=%v, want 422", err)
+	}
+	if _, err := svc.CheckInMember(ctx, "org", "tp", "m3", domain.AttendanceAbsent); !errors.As(err, &ae) || ae.Code != "MEMBER_NOT_EXPECTED" {
+		t.Fatalf("CheckInMember absent walk-up err=%v, want MEMBER_NOT_EXPECTED", err)
+	}
+
+	rec, err := svc.CheckInMember(ctx, "org", "tp", "m1", domain.AttendancePresent)
+	if err != nil {
+		t.Fatalf("CheckInMember(m1): %v", err)
+	}
+	if rec.Member.ID != "m1" || rec.WalkUp || rec.CheckedInAt == nil || !rec.CheckedInAt.Equal(start) || rec.RecordedBy != "org" {
+		t.Fatalf("rec=%+v", rec)
+	}
+	clk.Add(time.Hour)
+	if rec, err = svc.CheckInMember(ctx, "org", "tp", "m1", domain.AttendancePresent); err != nil || !rec.CheckedInAt.Equal(start) {
+		t.Fatalf("re-check-in rec=%+v err=%v, want the first check-in time", rec, err)
+	}
+	if rec, err = svc.CheckInMember(ctx, "org", "tp", "m3", domain.AttendancePresent); err != nil || !rec.WalkUp {
+		t.Fatalf("walk-up rec=%+v err=%v", rec, err)
+	}
+
+	sum, err := svc.GetTripRSVPSummary(ctx, "m1", "tp")
+	if err != nil {
+		t.Fatalf("GetTripRSVPSummary: %v", err)
+	}
+	if a := sum.Attendance; a == nil || *a != (domain.AttendanceSummary{Present: 2, WalkUps: 1, NotCheckedIn: 1}) {
+		t.Fatalf("Attendance=%+v", sum.Attendance)
+	}
+
+	if _, err := svc.CheckInMember(ctx, "org", "tp", "m2", domain.AttendanceAbsent); err != nil {
+		t.Fatalf("CheckInMember(m2 absent): %v", err)
+	}
+	sheet, err := svc.GetTripAttendance(ctx, "org", "tp")
+	if err != nil {
+		t.Fatalf("GetTripAttendance: %v", err)
+	}
+	if !sheet.CheckInOpen || sheet.Summary != (domain.AttendanceSummary{Present: 2, Absent: 1, WalkUps: 1}) || len(sheet.Records) != 3 {
+		t.Fatalf("sheet=%+v", sheet)
+	}
+
+	stats, err := svc.GetTripAttendanceReliability(ctx, "org", "tp")
+	if err != nil {
+		t.Fatalf("GetTripAttendanceReliability: %v", err)
+	}
+	byMember := map[domain.MemberID]domain.MemberReliability{}
+	for _, s := range stats {
+		byMember[s.Member.ID] = s
+	}
+	if s := byMember["m1"]; s.Attended != 1 || s.ShowRatePercent == nil || *s.ShowRatePercent != 100 {
+		t.Fatalf("m1 stats=%+v", s)
+	}
+	if s := byMember["m2"]; s.NoShows != 1 || s.ShowRatePercent == nil || *s.ShowRatePercent != 0 {
+		t.Fatalf("m2 stats=%+v", s)
+	}
+	if s := byMember["m3"]; s.WalkUps != 1 || s.ShowRatePercent != nil {
+		t.Fatalf("m3 stats=%+v", s)
+	}
+
+	// Check-in closes after the last trip day.
+	clk.Set(time.Date(2026, 5, 3, 18, 0, 0, 0, time.UTC))
+	if _, err := svc.CheckInMember(ctx, "org", "tp", "m2", domain.AttendancePresent); !errors.As(err, &ae) || ae.Code != "CHECK_IN_CLOSED" {
+		t.Fatalf("CheckInMember after the trip err=%v, want CHECK_IN_CLOSED", err)
+	}
+}
@@ -0,0 +1,400 @@
+package trips
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/events"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+const (
+	// maxCommentLen bounds a comment body (counted in runes); maxCommentLines bounds its lines
+	// so a single comment cannot push a thread off screen.
+	maxCommentLen   = 4000
+	maxCommentLines = 100
+
+	defaultCommentPageSize = 50
+	maxCommentPageSize     = 100
+
+	// maxPinnedComments caps how many comments a trip can pin at once.
+	maxPinnedComments = 3
+)
+
+var errCommentsDisabled = errors.New("trip comments are not configured")
+
+// PostTripCommentInput is a new comment. ParentID makes it a reply; replying to a reply
+// attaches it to the same top-level comment.
+type PostTripCommentInput struct {
+	Body     string
+	ParentID *domain.TripCommentID
+}
+
+// ListTripComments returns a page of the trip's thread, oldest first. Anyone who can see the
+// trip can read it. cursor is empty for the first page, which also carries pinned comments;
+// limit <= 0 means the default of 50.
+func (s *Service) ListTripComments(ctx context.Context, caller domain.MemberID, tripID domain.TripID, cursor string, limit int) (domain.TripCommentPage, error) {
+	if _, err := s.commentTrip(ctx, caller, tripID); err != nil {
+		return domain.TripCommentPage{}, err
+	}
+	if limit <= 0 {
+		limit = defaultCommentPageSize
+	}
+	if limit > maxCommentPageSize {
+		return domain.TripCommentPage{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid limit", Details: map[string]any{"limit": "must be at most 100"}}
+	}
+	var after *commentrepo.Cursor
+	if cursor != "" {
+		c, ok := decodeCommentCursor(cursor)
+		if !ok {
+			return domain.TripCommentPage{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid cursor", Details: map[string]any{"cursor": "must be a nextCursor from a previous page"}}
+		}
+		after = &c
+	}
+
+	// One extra row tells us whether another page follows.
+	cs, err := s.comments.ListByTrip(ctx, tripID, after, limit+1)
+	if err != nil {
+		return domain.TripCommentPage{}, err
+	}
+	var out domain.TripCommentPage
+	if len(cs) > limit {
+		cs = cs[:limit]
+		last := cs[len(cs)-1]
+		out.NextCursor = encodeCommentCursor(commentrepo.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	var pinned []commentrepo.Comment
+	if after == nil {
+		if pinned, err = s.comments.ListPinned(ctx, tripID); err != nil {
+			return domain.TripCommentPage{}, err
+		}
+	}
+	authors, err := s.commentAuthors(ctx, append(pinned, cs...))
+	if err != nil {
+		return domain.TripCommentPage{}, err
+	}
+	out.Pinned = make([]domain.TripComment, 0, len(pinned))
+	for _, c := range pinned {
+		out.Pinned = append(out.Pinned, tripCommentFromRepo(c, authors[c.AuthorMemberID]))
+	}
+	out.Comments = make([]domain.TripComment, 0, len(cs))
+	for _, c := range cs {
+		out.Comments = append(out.Comments, tripCommentFromRepo(c, authors[c.AuthorMemberID]))
+	}
+	return out, nil
+}
+
+// PostTripComment adds a comment to the trip's thread. Anyone who can see the trip can
+// post, until it is canceled.
+func (s *Service) PostTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in PostTripCommentInput) (domain.TripComment, error) {
+	t, err := s.commentTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+	if t.Status == triprepo.StatusCanceled {
+		return domain.TripComment{}, &Error{Status: 409, Code: "TRIP_CANCELED", Message: "trip is canceled and cannot be modified"}
+	}
+	body, err := validateCommentBody(in.Body)
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+
+	c := commentrepo.Comment{
+		ID:             s.newCommentID(),
+		TripID:         tripID,
+		AuthorMemberID: caller,
+		Body:           body,
+		CreatedAt:      s.clk.Now().UTC(),
+	}
+	if in.ParentID != nil {
+		parent, err := s.comments.Get(ctx, *in.ParentID)
+		if err != nil && !errors.Is(err, commentrepo.ErrNotFound) {
+			return domain.TripComment{}, err
+		}
+		if err != nil || parent.TripID != tripID {
+			return domain.TripComment{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid parent", Details: map[string]any{"parentId": "must name a comment on this trip"}}
+		}
+		if parent.DeletedAt != nil {
+			return domain.TripComment{}, &Error{Status: 409, Code: "COMMENT_DELETED", Message: "cannot reply to a deleted comment"}
+		}
+		root := parent.ID
+		if parent.ParentID != nil {
+			root = *parent.ParentID
+		}
+		c.ParentID = &root
+	}
+	if err := s.comments.Create(ctx, c); err != nil {
+		return domain.TripComment{}, err
+	}
+	s.publishCommentEvent(ctx, events.TripCommentCreated, caller, c)
+	return s.tripComment(ctx, c)
+}
+
+// EditTripComment replaces the body of the caller's own comment.
+func (s *Service) EditTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID, body string) (domain.TripComment, error) {
+	t, c, err := s.loadTripComment(ctx, caller, tripID, commentID)
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+	if c.AuthorMemberID != caller {
+		return domain.TripComment{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only the author can edit a comment"}
+	}
+	if t.Status == triprepo.StatusCanceled {
+		return domain.TripComment{}, &Error{Status: 409, Code: "TRIP_CANCELED", Message: "trip is canceled and cannot be modified"}
+	}
+	if c.DeletedAt != nil {
+		return domain.TripComment{}, &Error{Status: 409, Code: "COMMENT_DELETED", Message: "comment was deleted"}
+	}
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+	if body == c.Body {
+		return s.tripComment(ctx, c)
+	}
+	now := s.clk.Now().UTC()
+	c.Body = body
+	c.EditedAt = &now
+	if err := s.comments.Update(ctx, c); err != nil {
+		return domain.TripComment{}, err
+	}
+	s.publishCommentEvent(ctx, events.TripCommentEdited, caller, c)
+	return s.tripComment(ctx, c)
+}
+
+// DeleteTripComment removes a comment's text, leaving a placeholder in the thread. Authors
+// can delete their own comments and organizers can delete any. Deleting twice is a no-op.
+func (s *Service) DeleteTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID) error {
+	t, c, err := s.loadTripComment(ctx, caller, tripID, commentID)
+	if err != nil {
+		return err
+	}
+	if c.AuthorMemberID != caller && !isOrganizer(t, caller) {
+		return &Error{Status: 403, Code: "FORBIDDEN", Message: "only the author or an organizer can delete a comment"}
+	}
+	if c.DeletedAt != nil {
+		return nil
+	}
+	now := s.clk.Now().UTC()
+	c.Body = ""
+	c.Pinned = false
+	c.DeletedAt = &now
+	c.DeletedBy = caller
+	if err := s.comments.Update(ctx, c); err != nil {
+		return err
+	}
+	s.publishCommentEvent(ctx, events.TripCommentDeleted, caller, c)
+	return nil
+}
+
+// SetTripCommentPinned pins or unpins a top-level comment. Only organizers may pin, and at
+// most three comments per trip.
+func (s *Service) SetTripCommentPinned(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID, pinned bool) (domain.TripComment, error) {
+	t, c, err := s.loadTripComment(ctx, caller, tripID, commentID)
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+	if !isOrganizer(t, caller) {
+		return domain.TripComment{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can pin comments"}
+	}
+	if c.Pinned == pinned {
+		return s.tripComment(ctx, c)
+	}
+	if pinned {
+		if t.Status == triprepo.StatusCanceled {
+			return domain.TripComment{}, &Error{Status: 409, Code: "TRIP_CANCELED", Message: "trip is canceled and cannot be modified"}
+		}
+		if c.DeletedAt != nil {
+			return domain.TripComment{}, &Error{Status: 409, Code: "COMMENT_DELETED", Message: "comment was deleted"}
+		}
+		if c.ParentID != nil {
+			return domain.TripComment{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "replies cannot be pinned", Details: map[string]any{"commentId": "must be a top-level comment"}}
+		}
+		already, err := s.comments.ListPinned(ctx, tripID)
+		if err != nil {
+			return domain.TripComment{}, err
+		}
+		if len(already) >= maxPinnedComments {
+			return domain.TripComment{}, &Error{Status: 409, Code: "COMMENT_PIN_LIMIT", Message: "too many pinned comments", Details: map[string]any{"maxPinned": maxPinnedComments}}
+		}
+	}
+	c.Pinned = pinned
+	if err := s.comments.Update(ctx, c); err != nil {
+		return domain.TripComment{}, err
+	}
+	if pinned {
+		s.publishCommentEvent(ctx, events.TripCommentPinned, caller, c)
+	}
+	return s.tripComment(ctx, c)
+}
+
+// commentTrip loads a trip whose thread the caller may read.
+func (s *Service) commentTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	if s.comments == nil {
+		return triprepo.Trip{}, errCommentsDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return t, nil
+}
+
+// loadTripComment loads a comment on a trip whose thread the caller may read.
+func (s *Service) loadTripComment(ctx context.Context, caller domain.MemberID, tripID domain.TripID, commentID domain.TripCommentID) (triprepo.Trip, commentrepo.Comment, error) {
+	t, err := s.commentTrip(ctx, caller, tripID)
+	if err != nil {
+		return triprepo.Trip{}, commentrepo.Comment{}, err
+	}
+	c, err := s.comments.Get(ctx, commentID)
+	if err != nil && !errors.Is(err, commentrepo.ErrNotFound) {
+		return triprepo.Trip{}, commentrepo.Comment{}, err
+	}
+	if err != nil || c.TripID != tripID {
+		return triprepo.Trip{}, commentrepo.Comment{}, &Error{Status: 404, Code: "COMMENT_NOT_FOUND", Message: "comment not found"}
+	}
+	return t, c, nil
+}
+
+// publishCommentEvent tells subscribers about a comment change. Delivery is best-effort: the
+// change has already been saved.
+func (s *Service) publishCommentEvent(ctx context.Context, typ events.Type, actor domain.MemberID, c commentrepo.Comment) {
+	if s.events == nil {
+		return
+	}
+	ev := events.Event{
+		Type:          typ,
+		OccurredAt:    s.clk.Now().UTC(),
+		TripID:        c.TripID,
+		ActorMemberID: actor,
+		CommentID:     c.ID,
+	}
+	if c.ParentID != nil {
+		ev.ParentCommentID = *c.ParentID
+	}
+	_ = s.events.Publish(ctx, ev)
+}
+
+func (s *Service) tripComment(ctx context.Context, c commentrepo.Comment) (domain.TripComment, error) {
+	authors, err := s.commentAuthors(ctx, []commentrepo.Comment{c})
+	if err != nil {
+		return domain.TripComment{}, err
+	}
+	return tripCommentFromRepo(c, authors[c.AuthorMemberID]), nil
+}
+
+func (s *Service) commentAuthors(ctx context.Context, cs []commentrepo.Comment) (map[domain.MemberID]domain.MemberSummary, error) {
+	ids := make([]domain.MemberID, 0, len(cs))
+	for _, c := range cs {
+		ids = append(ids, c.AuthorMemberID)
+	}
+	return s.memberSummariesByID(ctx, ids)
+}
+
+// memberSummariesByID loads summaries for ids, which may repeat.
+func (s *Service) memberSummariesByID(ctx context.Context, ids []domain.MemberID) (map[domain.MemberID]domain.MemberSummary, error) {
+	unique := make([]domain.MemberID, 0, len(ids))
+	seen := make(map[domain.MemberID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	ms, err := s.loadMemberSummariesSorted(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[domain.MemberID]domain.MemberSummary, len(ms))
+	for _, m := range ms {
+		out[m.ID] = m
+	}
+	return out, nil
+}
+
+// validateCommentBody normalizes line endings and trims the body, then enforces limits that
+// keep Markdown source safe to store and render: valid UTF-8 with no control or bidi
+// override characters besides newlines and tabs.
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	invalid := func(reason string) error {
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid comment", Details: map[string]any{"body": reason}}
+	}
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLen {
+		return "", invalid("must be 1-4000 characters")
+	}
+	if !utf8.ValidString(body) {
+		return "", invalid("must be valid UTF-8")
+	}
+	if strings.Count(body, "\n")+1 > maxCommentLines {
+		return "", invalid("must be at most 100 lines")
+	}
+	if hasUnsafeRunes(body, "\n\t") {
+		return "", invalid("must not contain control characters")
+	}
+	return body, nil
+}
+
+// hasUnsafeRunes reports whether s contains control or bidi override characters other than
+// those in allowed.
+func hasUnsafeRunes(s, allowed string) bool {
+	for _, r := range s {
+		if strings.ContainsRune(allowed, r) {
+			continue
+		}
+		if unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cursors are opaque to clients: the last comment's creation time and ID.
+func encodeCommentCursor(c commentrepo.Cursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + string(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCommentCursor(s string) (commentrepo.Cursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return commentrepo.Cursor{}, false
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return commentrepo.Cursor{}, false
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return commentrepo.Cursor{}, false
+	}
+	return commentrepo.Cursor{CreatedAt: time.Unix(0, n).UTC(), ID: domain.TripCommentID(id)}, true
+}
+
+func tripCommentFromRepo(c commentrepo.Comment, author domain.MemberSummary) domain.TripComment {
+	return domain.TripComment{
+		ID:        c.ID,
+		TripID:    c.TripID,
+		Author:    author,
+		ParentID:  c.ParentID,
+		Body:      c.Body,
+		Pinned:    c.Pinned,
+		CreatedAt: c.CreatedAt,
+		EditedAt:  c.EditedAt,
+		DeletedAt: c.DeletedAt,
+	}
+}
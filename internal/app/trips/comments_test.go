@@ -0,0 +1,248 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memcommentrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/commentrepo"
+	memevents "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/events"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/events"
+)
+
+func newCommentsService(t *testing.T) (*trips.Service, *memevents.Bus, *memclock.ManualClock) {
+	t.Helper()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1", "m2"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	bus := memevents.NewRecordingBus()
+	clk := memclock.NewManualClock(time.Unix(5_000, 0).UTC())
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{
+		Comments: memcommentrepo.NewRepo(),
+		Events:   bus,
+		Clock:    clk,
+	})
+	return svc, bus, clk
+}
+
+func requireTripsErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var ae *trips.Error
+	if !errors.As(err, &ae) || ae.Code != code {
+		t.Fatalf("err=%v, want %s", err, code)
+	}
+}
+
+func TestService_TripComments_ThreadsAndModeration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, bus, clk := newCommentsService(t)
+
+	q, err := svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "  Is the creek crossing passable?\r\n"})
+	if err != nil {
+		t.Fatalf("PostTripComment: %v", err)
+	}
+	if q.Body != "Is the creek crossing passable?" || q.Author.ID != "m1" || q.ParentID != nil {
+		t.Fatalf("comment = %+v", q)
+	}
+	clk.Add(time.Minute)
+	a, err := svc.PostTripComment(ctx, "org", "tp", trips.PostTripCommentInput{Body: "It was low last week.", ParentID: &q.ID})
+	if err != nil {
+		t.Fatalf("PostTripComment reply: %v", err)
+	}
+	// Replying to a reply stays in the same thread.
+	clk.Add(time.Minute)
+	b, err := svc.PostTripComment(ctx, "m2", "tp", trips.PostTripCommentInput{Body: "Thanks!", ParentID: &a.ID})
+	if err != nil {
+		t.Fatalf("PostTripComment nested reply: %v", err)
+	}
+	if b.ParentID == nil || *b.ParentID != q.ID {
+		t.Fatalf("nested reply parent = %v, want %s", b.ParentID, q.ID)
+	}
+	evs := bus.Published()
+	if len(evs) != 3 || evs[1].Type != events.TripCommentCreated || evs[1].ParentCommentID != q.ID || evs[1].ActorMemberID != "org" || evs[1].TripID != "tp" {
+		t.Fatalf("events = %+v", evs)
+	}
+
+	_, err = svc.EditTripComment(ctx, "org", "tp", q.ID, "Hijacked")
+	requireTripsErrorCode(t, err, "FORBIDDEN")
+	clk.Add(time.Minute)
+	edited, err := svc.EditTripComment(ctx, "m1", "tp", q.ID, "Is the creek crossing passable after the rain?")
+	if err != nil || edited.EditedAt == nil || !edited.EditedAt.Equal(clk.Now()) {
+		t.Fatalf("EditTripComment = %+v err=%v", edited, err)
+	}
+
+	requireTripsErrorCode(t, svc.DeleteTripComment(ctx, "m2", "tp", a.ID), "FORBIDDEN")
+	if err := svc.DeleteTripComment(ctx, "org", "tp", b.ID); err != nil {
+		t.Fatalf("DeleteTripComment by organizer: %v", err)
+	}
+	if err := svc.DeleteTripComment(ctx, "org", "tp", b.ID); err != nil {
+		t.Fatalf("DeleteTripComment twice: %v", err)
+	}
+	_, err = svc.EditTripComment(ctx, "m2", "tp", b.ID, "Back again")
+	requireTripsErrorCode(t, err, "COMMENT_DELETED")
+
+	_, err = svc.SetTripCommentPinned(ctx, "m1", "tp", q.ID, true)
+	requireTripsErrorCode(t, err, "FORBIDDEN")
+	_, err = svc.SetTripCommentPinned(ctx, "org", "tp", a.ID, true)
+	requireTripsErrorCode(t, err, "VALIDATION_ERROR")
+	if pinned, err := svc.SetTripCommentPinned(ctx, "org", "tp", q.ID, true); err != nil || !pinned.Pinned {
+		t.Fatalf("SetTripCommentPinned = %+v err=%v", pinned, err)
+	}
+
+	page, err := svc.ListTripComments(ctx, "m2", "tp", "", 0)
+	if err != nil {
+		t.Fatalf("ListTripComments: %v", err)
+	}
+	if len(page.Pinned) != 1 || page.Pinned[0].ID != q.ID || len(page.Comments) != 3 || page.NextCursor != "" {
+		t.Fatalf("page = %+v", page)
+	}
+	if deleted := page.Comments[2]; deleted.ID != b.ID || deleted.DeletedAt == nil || deleted.Body != "" {
+		t.Fatalf("deleted comment = %+v", deleted)
+	}
+
+	var types []events.Type
+	for _, ev := range bus.Published() {
+		types = append(types, ev.Type)
+	}
+	want := []events.Type{events.TripCommentCreated, events.TripCommentCreated, events.TripCommentCreated, events.TripCommentEdited, events.TripCommentDeleted, events.TripCommentPinned}
+	if !slices.Equal(types, want) {
+		t.Fatalf("event types = %v, want %v", types, want)
+	}
+
+	_, err = svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "Reply to nothing", ParentID: ptrCommentID("nope")})
+	requireTripsErrorCode(t, err, "VALIDATION_ERROR")
+	_, err = svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "Reply to deleted", ParentID: &b.ID})
+	requireTripsErrorCode(t, err, "COMMENT_DELETED")
+	_, err = svc.EditTripComment(ctx, "m1", "tp", "nope", "Missing")
+	requireTripsErrorCode(t, err, "COMMENT_NOT_FOUND")
+
+	if _, err := svc.CancelTrip(ctx, "org", "tp"); err != nil {
+		t.Fatalf("CancelTrip: %v", err)
+	}
+	_, err = svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "Still on?"})
+	requireTripsErrorCode(t, err, "TRIP_CANCELED")
+	// The thread stays readable and moderatable after cancellation.
+	if err := svc.DeleteTripComment(ctx, "m1", "tp", q.ID); err != nil {
+		t.Fatalf("DeleteTripComment after cancel: %v", err)
+	}
+}
+
+func TestService_TripComments_PaginationAndLimits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _, clk := newCommentsService(t)
+
+	var ids []domain.TripCommentID
+	for i := 0; i < 5; i++ {
+		c, err := svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "Comment " + string(rune('A'+i))})
+		if err != nil {
+			t.Fatalf("PostTripComment %d: %v", i, err)
+		}
+		ids = append(ids, c.ID)
+		if i%2 == 1 {
+			clk.Add(time.Second)
+		}
+	}
+
+	var got []domain.TripCommentID
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("too many pages")
+		}
+		page, err := svc.ListTripComments(ctx, "m2", "tp", cursor, 2)
+		if err != nil {
+			t.Fatalf("ListTripComments: %v", err)
+		}
+		for _, c := range page.Comments {
+			got = append(got, c.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(got) != 5 {
+		t.Fatalf("paged through %v, want 5 comments", got)
+	}
+	seen := map[domain.TripCommentID]bool{}
+	for _, id := range got {
+		if seen[id] {
+			t.Fatalf("comment %s repeated across pages: %v", id, got)
+		}
+		seen[id] = true
+	}
+	// Comments posted in the same instant are ordered by ID; the last one posted alone.
+	if !seen[ids[0]] || !seen[ids[1]] || got[4] != ids[4] || slices.Index(got, ids[1]) > 1 {
+		t.Fatalf("paged order %v, want oldest first %v", got, ids)
+	}
+
+	_, err := svc.ListTripComments(ctx, "m2", "tp", "not-a-cursor!", 2)
+	requireTripsErrorCode(t, err, "VALIDATION_ERROR")
+	_, err = svc.ListTripComments(ctx, "m2", "tp", "", 101)
+	requireTripsErrorCode(t, err, "VALIDATION_ERROR")
+
+	for name, body := range map[string]string{
+		"empty":      "   ",
+		"too long":   strings.Repeat("a", 4001),
+		"many lines": strings.Repeat("line\n", 101),
+		"control":    "bell\x07",
+		"bidi":       "evil‮exe.txt",
+	} {
+		_, err := svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: body})
+		var ae *trips.Error
+		if !errors.As(err, &ae) || ae.Code != "VALIDATION_ERROR" {
+			t.Fatalf("%s: err=%v, want VALIDATION_ERROR", name, err)
+		}
+	}
+	if _, err := svc.PostTripComment(ctx, "m1", "tp", trips.PostTripCommentInput{Body: "**Bold**\n\n- tabs\tok"}); err != nil {
+		t.Fatalf("PostTripComment markdown: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.SetTripCommentPinned(ctx, "org", "tp", ids[i], true); err != nil {
+			t.Fatalf("pin %d: %v", i, err)
+		}
+	}
+	_, err = svc.SetTripCommentPinned(ctx, "org", "tp", ids[3], true)
+	requireTripsErrorCode(t, err, "COMMENT_PIN_LIMIT")
+
+	// Threads follow trip visibility.
+	_, err = svc.ListTripComments(ctx, "m2", "missing", "", 0)
+	requireTripsErrorCode(t, err, "TRIP_NOT_FOUND")
+}
+
+func TestService_TripComments_PrivateDraftHidden(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _, _ := newCommentsService(t)
+	created, err := svc.CreateTripDraft(ctx, "org", trips.CreateTripDraftInput{Name: "Secret Plans"})
+	if err != nil {
+		t.Fatalf("CreateTripDraft: %v", err)
+	}
+	if _, err := svc.PostTripComment(ctx, "org", created.ID, trips.PostTripCommentInput{Body: "Planning notes"}); err != nil {
+		t.Fatalf("PostTripComment by creator: %v", err)
+	}
+	_, err = svc.ListTripComments(ctx, "m1", created.ID, "", 0)
+	requireTripsErrorCode(t, err, "TRIP_NOT_FOUND")
+}
+
+func ptrCommentID(id domain.TripCommentID) *domain.TripCommentID {
+	return &id
+}
@@ -0,0 +1,119 @@
+package trips
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// defaultDifficultyScale is the top of the rating scale when Options.DifficultyScale is unset.
+const defaultDifficultyScale = 5
+
+// TripListFilter narrows ListVisibleTripsForMember. The zero value matches every trip; any
+// difficulty criterion excludes trips that have not been rated.
+type TripListFilter struct {
+	// MinRating and MaxRating match trips whose rating range overlaps [MinRating, MaxRating].
+	MinRating *int
+	MaxRating *int
+	// Terrain matches trips tagged with every listed terrain.
+	Terrain []domain.TerrainTag
+}
+
+func (f TripListFilter) isZero() bool {
+	return f.MinRating == nil && f.MaxRating == nil && len(f.Terrain) == 0
+}
+
+func (f TripListFilter) matches(d *domain.Difficulty) bool {
+	if f.isZero() {
+		return true
+	}
+	if d == nil {
+		return false
+	}
+	lo, hi := d.Range()
+	if f.MinRating != nil && hi < *f.MinRating {
+		return false
+	}
+	if f.MaxRating != nil && lo > *f.MaxRating {
+		return false
+	}
+	for _, tag := range f.Terrain {
+		if !slices.Contains(d.Terrain, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateListFilter rejects malformed filters. Ratings beyond the club scale are accepted and
+// compared as-is.
+func validateListFilter(f TripListFilter) error {
+	for _, n := range []*int{f.MinRating, f.MaxRating} {
+		if n != nil && *n < 1 {
+			return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid difficulty filter", Details: map[string]any{"difficulty": "ratings must be >= 1"}}
+		}
+	}
+	if f.MinRating != nil && f.MaxRating != nil && *f.MinRating > *f.MaxRating {
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid difficulty filter", Details: map[string]any{"difficulty": "minimum must not exceed maximum"}}
+	}
+	for _, tag := range f.Terrain {
+		if !tag.Valid() {
+			return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid difficulty filter", Details: map[string]any{"terrain": "unknown tag " + string(tag)}}
+		}
+	}
+	return nil
+}
+
+// validateDifficulty checks the ratings against the club scale and de-duplicates the terrain
+// tags into their canonical order. A MinRating or MaxRating equal to Rating is dropped.
+func (s *Service) validateDifficulty(in domain.Difficulty) (domain.Difficulty, error) {
+	invalid := func(field, msg string) error {
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid difficulty", Details: map[string]any{field: msg}}
+	}
+	scale := s.difficultyScale
+	if in.Rating < 1 || in.Rating > scale {
+		return domain.Difficulty{}, invalid("rating", fmt.Sprintf("must be between 1 and %d", scale))
+	}
+	out := domain.Difficulty{Rating: in.Rating}
+	if in.MinRating != nil {
+		n := *in.MinRating
+		if n < 1 || n > in.Rating {
+			return domain.Difficulty{}, invalid("minRating", "must be between 1 and rating")
+		}
+		if n != in.Rating {
+			out.MinRating = &n
+		}
+	}
+	if in.MaxRating != nil {
+		n := *in.MaxRating
+		if n < in.Rating || n > scale {
+			return domain.Difficulty{}, invalid("maxRating", fmt.Sprintf("must be between rating and %d", scale))
+		}
+		if n != in.Rating {
+			out.MaxRating = &n
+		}
+	}
+	for _, tag := range in.Terrain {
+		if !tag.Valid() {
+			return domain.Difficulty{}, invalid("terrain", "unknown tag "+string(tag))
+		}
+	}
+	for _, tag := range domain.TerrainTags {
+		if slices.Contains(in.Terrain, tag) {
+			out.Terrain = append(out.Terrain, tag)
+		}
+	}
+	return out, nil
+}
+
+func cloneDifficulty(d *domain.Difficulty) *domain.Difficulty {
+	if d == nil {
+		return nil
+	}
+	out := *d
+	out.MinRating = cloneIntPtr(d.MinRating)
+	out.MaxRating = cloneIntPtr(d.MaxRating)
+	out.Terrain = slices.Clone(d.Terrain)
+	return &out
+}
@@ -0,0 +1,133 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	porttriprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestService_Difficulty_ValidateAndFilterList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	provisionMember(t, membersRepo, "m1")
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, rsvpsRepo, trips.Options{DifficultyScale: 6})
+
+	now := time.Unix(900, 0).UTC()
+	for i, id := range []domain.TripID{"easy", "bypass", "hard", "unrated"} {
+		name := string(id)
+		_ = tripsRepo.Create(ctx, porttriprepo.Trip{
+			ID:                 id,
+			Status:             porttriprepo.StatusPublished,
+			Name:               &name,
+			CreatorMemberID:    "m1",
+			OrganizerMemberIDs: []domain.MemberID{"m1"},
+			CreatedAt:          now.Add(time.Duration(i) * time.Second),
+			UpdatedAt:          now,
+		})
+	}
+
+	var ae *trips.Error
+	for _, bad := range []domain.Difficulty{
+		{Rating: 0},
+		{Rating: 7},
+		{Rating: 3, MinRating: intPtr(4)},
+		{Rating: 3, MaxRating: intPtr(7)},
+		{Rating: 3, Terrain: []domain.TerrainTag{"LAVA"}},
+	} {
+		_, err := svc.UpdateTrip(ctx, "m1", "easy", trips.UpdateTripInput{Difficulty: trips.Some(bad)})
+		if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != "VALIDATION_ERROR" {
+			t.Fatalf("UpdateTrip(%+v) err=%v, want 422 VALIDATION_ERROR", bad, err)
+		}
+	}
+
+	td, err := svc.UpdateTrip(ctx, "m1", "easy", trips.UpdateTripInput{Difficulty: trips.Some(domain.Difficulty{
+		Rating:    2,
+		MinRating: intPtr(2),
+		Terrain:   []domain.TerrainTag{domain.TerrainSand, domain.TerrainRock, domain.TerrainSand},
+	})})
+	if err != nil {
+		t.Fatalf("UpdateTrip(easy): %v", err)
+	}
+	if d := td.Difficulty; d == nil || d.Rating != 2 || d.MinRating != nil || len(d.Terrain) != 2 || d.Terrain[0] != domain.TerrainRock {
+		t.Fatalf("Difficulty = %+v, want rating 2, no min, [ROCK SAND]", td.Difficulty)
+	}
+	if _, err := svc.UpdateTrip(ctx, "m1", "bypass", trips.UpdateTripInput{Difficulty: trips.Some(domain.Difficulty{
+		Rating:    5,
+		MinRating: intPtr(3),
+		Terrain:   []domain.TerrainTag{domain.TerrainRock, domain.TerrainWaterCrossing},
+	})}); err != nil {
+		t.Fatalf("UpdateTrip(bypass): %v", err)
+	}
+	if _, err := svc.UpdateTrip(ctx, "m1", "hard", trips.UpdateTripInput{Difficulty: trips.Some(domain.Difficulty{
+		Rating:  6,
+		Terrain: []domain.TerrainTag{domain.TerrainSnow},
+	})}); err != nil {
+		t.Fatalf("UpdateTrip(hard): %v", err)
+	}
+
+	ids := func(f trips.TripListFilter) []domain.TripID {
+		t.Helper()
+		ts, err := svc.ListVisibleTripsForMember(ctx, "m1", f)
+		if err != nil {
+			t.Fatalf("ListVisibleTripsForMember(%+v): %v", f, err)
+		}
+		var out []domain.TripID
+		for _, s := range ts {
+			out = append(out, s.ID)
+		}
+		return out
+	}
+	eq := func(got []domain.TripID, want ...domain.TripID) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if got := ids(trips.TripListFilter{}); !eq(got, "easy", "bypass", "hard", "unrated") {
+		t.Fatalf("unfiltered = %v", got)
+	}
+	// The bypass trip's 3..5 range overlaps a "3 or easier" search.
+	if got := ids(trips.TripListFilter{MaxRating: intPtr(3)}); !eq(got, "easy", "bypass") {
+		t.Fatalf("max 3 = %v", got)
+	}
+	if got := ids(trips.TripListFilter{MinRating: intPtr(6)}); !eq(got, "hard") {
+		t.Fatalf("min 6 = %v", got)
+	}
+	if got := ids(trips.TripListFilter{Terrain: []domain.TerrainTag{domain.TerrainRock, domain.TerrainWaterCrossing}}); !eq(got, "bypass") {
+		t.Fatalf("rock+water = %v", got)
+	}
+
+	_, err = svc.ListVisibleTripsForMember(ctx, "m1", trips.TripListFilter{MinRating: intPtr(4), MaxRating: intPtr(2)})
+	if !errors.As(err, &ae) || ae.Status != 422 {
+		t.Fatalf("inverted range err=%v, want 422", err)
+	}
+
+	// Clearing the rating drops the trip from difficulty searches.
+	if _, err := svc.UpdateTrip(ctx, "m1", "hard", trips.UpdateTripInput{Difficulty: trips.Null[domain.Difficulty]()}); err != nil {
+		t.Fatalf("UpdateTrip(clear): %v", err)
+	}
+	if got := ids(trips.TripListFilter{MinRating: intPtr(6)}); len(got) != 0 {
+		t.Fatalf("min 6 after clear = %v", got)
+	}
+}
+
+func intPtr(n int) *int { return &n }
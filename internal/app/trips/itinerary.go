@@ -0,0 +1,250 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+const (
+	// maxItineraryEntries bounds the stops and the segments of a single day.
+	maxItineraryEntries = 30
+	// maxItineraryTitleLen and maxItineraryNotesLen bound free text (counted in runes).
+	maxItineraryTitleLen = 200
+	maxItineraryNotesLen = 2000
+)
+
+var (
+	errItineraryDisabled = errors.New("trip itineraries are not configured")
+	stopTimePattern      = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// GetTripItinerary returns the per-day plan of a trip the caller can see, ordered by date.
+func (s *Service) GetTripItinerary(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.ItineraryDay, error) {
+	if s.itineraries == nil {
+		return nil, errItineraryDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return nil, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return s.tripItinerary(ctx, tripID)
+}
+
+// PutTripItineraryDay creates or replaces the plan for one date of the trip. Only organizers
+// may edit it, and the date must fall within the trip's start and end dates.
+func (s *Service) PutTripItineraryDay(ctx context.Context, caller domain.MemberID, tripID domain.TripID, date time.Time, in ItineraryDayInput) (domain.ItineraryDay, error) {
+	t, err := s.itineraryTripForEdit(ctx, caller, tripID)
+	if err != nil {
+		return domain.ItineraryDay{}, err
+	}
+	day := calendarDate(date)
+	if t.StartDate == nil || t.EndDate == nil {
+		return domain.ItineraryDay{}, &Error{Status: 409, Code: "TRIP_DATES_REQUIRED", Message: "set the trip's start and end dates before planning its itinerary"}
+	}
+	if day.Before(calendarDate(*t.StartDate)) || day.After(calendarDate(*t.EndDate)) {
+		return domain.ItineraryDay{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid itinerary date", Details: map[string]any{"date": "must be within the trip's dates"}}
+	}
+
+	out := domain.ItineraryDay{Date: day, UpdatedAt: s.clk.Now()}
+	if out.Title, err = normalizeItineraryText("title", in.Title, maxItineraryTitleLen); err != nil {
+		return domain.ItineraryDay{}, err
+	}
+	if out.Notes, err = normalizeItineraryText("notes", in.Notes, maxItineraryNotesLen); err != nil {
+		return domain.ItineraryDay{}, err
+	}
+	if out.Stops, err = normalizeItineraryStops(in.Stops); err != nil {
+		return domain.ItineraryDay{}, err
+	}
+	if out.Segments, err = normalizeRouteSegments(in.Segments); err != nil {
+		return domain.ItineraryDay{}, err
+	}
+
+	if err := s.itineraries.PutDay(ctx, itineraryrepo.Day{TripID: tripID, ItineraryDay: out}); err != nil {
+		return domain.ItineraryDay{}, err
+	}
+	return out, nil
+}
+
+// DeleteTripItineraryDay removes the plan for one date of the trip. Only organizers may edit it.
+func (s *Service) DeleteTripItineraryDay(ctx context.Context, caller domain.MemberID, tripID domain.TripID, date time.Time) error {
+	if _, err := s.itineraryTripForEdit(ctx, caller, tripID); err != nil {
+		return err
+	}
+	if err := s.itineraries.DeleteDay(ctx, tripID, calendarDate(date)); err != nil {
+		if errors.Is(err, itineraryrepo.ErrDayNotFound) {
+			return &Error{Status: 404, Code: "ITINERARY_DAY_NOT_FOUND", Message: "itinerary day not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+// itineraryTripForEdit loads a trip whose itinerary the caller may edit, following the same
+// rules as UpdateTrip: drafts by whoever may update them, published trips by organizers.
+func (s *Service) itineraryTripForEdit(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	if s.itineraries == nil {
+		return triprepo.Trip{}, errItineraryDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	switch t.Status {
+	case triprepo.StatusDraft:
+		if !isDraftVisibleToCaller(t, caller) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+	case triprepo.StatusPublished:
+		if !isOrganizer(t, caller) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+	case triprepo.StatusCanceled:
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_CANCELED", Message: "trip is canceled and cannot be modified"}
+	default:
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_INVALID_STATUS", Message: "invalid trip status"}
+	}
+	return t, nil
+}
+
+func (s *Service) tripItinerary(ctx context.Context, tripID domain.TripID) ([]domain.ItineraryDay, error) {
+	days, err := s.itineraries.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.ItineraryDay, 0, len(days))
+	for _, d := range days {
+		out = append(out, d.ItineraryDay)
+	}
+	return out, nil
+}
+
+// checkItineraryWithinDates rejects trip date changes that would leave itinerary days outside
+// the trip.
+func (s *Service) checkItineraryWithinDates(ctx context.Context, t triprepo.Trip) error {
+	if s.itineraries == nil {
+		return nil
+	}
+	days, err := s.itineraries.ListByTrip(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	var outside []string
+	for _, d := range days {
+		if t.StartDate == nil || t.EndDate == nil || d.Date.Before(calendarDate(*t.StartDate)) || d.Date.After(calendarDate(*t.EndDate)) {
+			outside = append(outside, d.Date.Format("2006-01-02"))
+		}
+	}
+	if len(outside) > 0 {
+		return &Error{
+			Status:  409,
+			Code:    "ITINERARY_OUTSIDE_TRIP_DATES",
+			Message: "the new dates leave itinerary days outside the trip; move or delete them first",
+			Details: map[string]any{"dates": outside},
+		}
+	}
+	return nil
+}
+
+func normalizeItineraryText(field string, v *string, maxLen int) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	out := strings.TrimSpace(*v)
+	if out == "" {
+		return nil, nil
+	}
+	if len([]rune(out)) > maxLen {
+		return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid " + field, Details: map[string]any{field: fmt.Sprintf("must be at most %d characters", maxLen)}}
+	}
+	return &out, nil
+}
+
+func normalizeItineraryStops(in []domain.ItineraryStop) ([]domain.ItineraryStop, error) {
+	if len(in) > maxItineraryEntries {
+		return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid stops", Details: map[string]any{"stops": fmt.Sprintf("at most %d per day", maxItineraryEntries)}}
+	}
+	out := make([]domain.ItineraryStop, 0, len(in))
+	for i, st := range in {
+		field := func(name string) string { return fmt.Sprintf("stops[%d].%s", i, name) }
+		invalid := func(name, msg string) error {
+			return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid stop", Details: map[string]any{field(name): msg}}
+		}
+		if !st.Type.Valid() {
+			return nil, invalid("type", "must be one of MEETING_POINT, CAMPSITE, FUEL, WAYPOINT")
+		}
+		st.Location.Label = strings.TrimSpace(st.Location.Label)
+		if st.Location.Label == "" {
+			return nil, invalid("location.label", "is required")
+		}
+		var err error
+		if st.Location.Address, err = normalizeItineraryText(field("location.address"), st.Location.Address, maxItineraryTitleLen); err != nil {
+			return nil, err
+		}
+		lat, lng := st.Location.Latitude, st.Location.Longitude
+		if (lat == nil) != (lng == nil) {
+			return nil, invalid("location", "latitude and longitude must be set together")
+		}
+		if lat != nil && (math.IsNaN(*lat) || *lat < -90 || *lat > 90) {
+			return nil, invalid("location.latitude", "must be between -90 and 90")
+		}
+		if lng != nil && (math.IsNaN(*lng) || *lng < -180 || *lng > 180) {
+			return nil, invalid("location.longitude", "must be between -180 and 180")
+		}
+		if st.Time != nil {
+			v := strings.TrimSpace(*st.Time)
+			if !stopTimePattern.MatchString(v) {
+				return nil, invalid("time", "must be HH:MM (24-hour)")
+			}
+			st.Time = &v
+		}
+		if st.Notes, err = normalizeItineraryText(field("notes"), st.Notes, maxItineraryNotesLen); err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func normalizeRouteSegments(in []domain.RouteSegment) ([]domain.RouteSegment, error) {
+	if len(in) > maxItineraryEntries {
+		return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid segments", Details: map[string]any{"segments": fmt.Sprintf("at most %d per day", maxItineraryEntries)}}
+	}
+	out := make([]domain.RouteSegment, 0, len(in))
+	for i, seg := range in {
+		field := func(name string) string { return fmt.Sprintf("segments[%d].%s", i, name) }
+		seg.Name = strings.TrimSpace(seg.Name)
+		if seg.Name == "" {
+			return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid segment", Details: map[string]any{field("name"): "is required"}}
+		}
+		if len([]rune(seg.Name)) > maxItineraryTitleLen {
+			return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid segment", Details: map[string]any{field("name"): fmt.Sprintf("must be at most %d characters", maxItineraryTitleLen)}}
+		}
+		if d := seg.DistanceMiles; d != nil && (math.IsNaN(*d) || math.IsInf(*d, 0) || *d < 0) {
+			return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid segment", Details: map[string]any{field("distanceMiles"): "must be >= 0"}}
+		}
+		var err error
+		if seg.Notes, err = normalizeItineraryText(field("notes"), seg.Notes, maxItineraryNotesLen); err != nil {
+			return nil, err
+		}
+		out = append(out, seg)
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	memitineraryrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/itineraryrepo"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+func TestService_TripItinerary_OrganizersEditWithinTripDates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	// Canyon Run runs 2026-05-01..2026-05-02.
+	seedPlannedTrip(t, tripsRepo, "tr", "org")
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Itineraries: memitineraryrepo.NewRepo()})
+	day1 := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	title := " Into the canyon "
+	meet := "07:30"
+	lat, lng := 38.5, -109.6
+	miles := 18.5
+	in := trips.ItineraryDayInput{
+		Title: &title,
+		Stops: []domain.ItineraryStop{
+			{Type: domain.ItineraryStopMeetingPoint, Location: domain.Location{Label: "Gas station"}, Time: &meet},
+			{Type: domain.ItineraryStopCampsite, Location: domain.Location{Label: "Mesa camp", Latitude: &lat, Longitude: &lng}},
+		},
+		Segments: []domain.RouteSegment{{Name: "Shafer Trail", DistanceMiles: &miles}},
+	}
+
+	requireCode := func(err error, status int, code string) {
+		t.Helper()
+		var ae *trips.Error
+		if !errors.As(err, &ae) || ae.Status != status || ae.Code != code {
+			t.Fatalf("err = %v, want %d %s", err, status, code)
+		}
+	}
+
+	_, err := svc.PutTripItineraryDay(ctx, "m2", "tr", day1, in)
+	requireCode(err, 404, "TRIP_NOT_FOUND")
+	_, err = svc.PutTripItineraryDay(ctx, "org", "tr", day2.AddDate(0, 0, 1), in)
+	requireCode(err, 422, "VALIDATION_ERROR")
+
+	bad := []trips.ItineraryDayInput{
+		{Stops: []domain.ItineraryStop{{Type: "PICNIC", Location: domain.Location{Label: "x"}}}},
+		{Stops: []domain.ItineraryStop{{Type: domain.ItineraryStopFuel}}},
+		{Stops: []domain.ItineraryStop{{Type: domain.ItineraryStopFuel, Location: domain.Location{Label: "x", Latitude: &lat}}}},
+		{Stops: []domain.ItineraryStop{{Type: domain.ItineraryStopFuel, Location: domain.Location{Label: "x", Latitude: &lng, Longitude: &lat}}}},
+		{Stops: []domain.ItineraryStop{{Type: domain.ItineraryStopFuel, Location: domain.Location{Label: "x"}, Time: &title}}},
+		{Segments: []domain.RouteSegment{{Name: " "}}},
+		{Segments: []domain.RouteSegment{{Name: "x", DistanceMiles: func() *float64 { v := -1.0; return &v }()}}},
+	}
+	for i, b := range bad {
+		_, err := svc.PutTripItineraryDay(ctx, "org", "tr", day1, b)
+		var ae *trips.Error
+		if !errors.As(err, &ae) || ae.Code != "VALIDATION_ERROR" {
+			t.Fatalf("bad input %d: err = %v, want VALIDATION_ERROR", i, err)
+		}
+	}
+
+	got, err := svc.PutTripItineraryDay(ctx, "org", "tr", day1.Add(15*time.Hour), in)
+	if err != nil {
+		t.Fatalf("PutTripItineraryDay: %v", err)
+	}
+	if !got.Date.Equal(day1) || got.Title == nil || *got.Title != "Into the canyon" || len(got.Stops) != 2 || len(got.Segments) != 1 {
+		t.Fatalf("PutTripItineraryDay = %+v", got)
+	}
+	if _, err := svc.PutTripItineraryDay(ctx, "org", "tr", day2, trips.ItineraryDayInput{}); err != nil {
+		t.Fatalf("PutTripItineraryDay day 2: %v", err)
+	}
+
+	// Any member who can see the trip can read the itinerary.
+	days, err := svc.GetTripItinerary(ctx, "m2", "tr")
+	if err != nil || len(days) != 2 || !days[0].Date.Equal(day1) || !days[1].Date.Equal(day2) {
+		t.Fatalf("GetTripItinerary = %+v err=%v", days, err)
+	}
+	td, err := svc.GetTripDetails(ctx, "m2", "tr")
+	if err != nil || len(td.Itinerary) != 2 || td.Itinerary[0].Stops[1].Location.Label != "Mesa camp" {
+		t.Fatalf("GetTripDetails itinerary = %+v err=%v", td.Itinerary, err)
+	}
+
+	_, copyText, err := svc.PublishTrip(ctx, "org", "tr")
+	if err != nil {
+		t.Fatalf("PublishTrip: %v", err)
+	}
+	for _, want := range []string{"Itinerary:", "Fri 2026-05-01: Into the canyon", "  Meet: Gas station at 07:30", "  Camp: Mesa camp (38.50000, -109.60000)", "  Route: Shafer Trail (18.5 mi)", "Sat 2026-05-02"} {
+		if !strings.Contains(copyText, want) {
+			t.Fatalf("announcement copy missing %q:\n%s", want, copyText)
+		}
+	}
+
+	// Trip dates cannot move away from planned days.
+	_, err = svc.UpdateTrip(ctx, "org", "tr", trips.UpdateTripInput{EndDate: trips.Some(day1)})
+	requireCode(err, 409, "ITINERARY_OUTSIDE_TRIP_DATES")
+
+	requireCode(svc.DeleteTripItineraryDay(ctx, "m2", "tr", day2), 404, "TRIP_NOT_FOUND")
+	if err := svc.DeleteTripItineraryDay(ctx, "org", "tr", day2); err != nil {
+		t.Fatalf("DeleteTripItineraryDay: %v", err)
+	}
+	requireCode(svc.DeleteTripItineraryDay(ctx, "org", "tr", day2), 404, "ITINERARY_DAY_NOT_FOUND")
+	if _, err := svc.UpdateTrip(ctx, "org", "tr", trips.UpdateTripInput{EndDate: trips.Some(day1)}); err != nil {
+		t.Fatalf("UpdateTrip after delete: %v", err)
+	}
+
+	if _, err := svc.CancelTrip(ctx, "org", "tr"); err != nil {
+		t.Fatalf("CancelTrip: %v", err)
+	}
+	_, err = svc.PutTripItineraryDay(ctx, "org", "tr", day1, in)
+	requireCode(err, 409, "TRIP_CANCELED")
+}
@@ -0,0 +1,164 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+// maxRSVPChangeReasonLen bounds the reason an organizer gives for changing an RSVP (in runes).
+const maxRSVPChangeReasonLen = 500
+
+// SetMemberRSVP sets another member's RSVP on their behalf, e.g. when they text the trip
+// leader instead of using the app. Only organizers may do this. It follows the same rules as
+// SetMyRSVP except that the RSVP deadline does not apply and in.BypassCapacity, when set,
+// admits a YES beyond the trip's capacity. The organizer and in.Reason are kept on the RSVP.
+func (s *Service) SetMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, in MemberRSVPInput) (domain.MyRSVP, error) {
+	reason, err := validateRSVPChangeReason(in.Reason)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	t, err := s.organizerRSVPTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	if err := s.requireMember(ctx, memberID); err != nil {
+		return domain.MyRSVP{}, err
+	}
+	return s.setRSVP(ctx, t, memberID, in.SetMyRSVPInput, rsvpChange{by: caller, reason: &reason, bypassCapacity: in.BypassCapacity})
+}
+
+// ClearMemberRSVP resets another member's RSVP to UNSET on their behalf. Only organizers may
+// do this; like SetMemberRSVP it ignores the RSVP deadline.
+func (s *Service) ClearMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, reason string) (domain.MyRSVP, error) {
+	return s.SetMemberRSVP(ctx, caller, tripID, memberID, MemberRSVPInput{
+		SetMyRSVPInput: SetMyRSVPInput{Response: domain.RSVPResponseUnset},
+		Reason:         reason,
+	})
+}
+
+// MoveMemberRSVP moves another member's RSVP, with its vehicle and passengers, from tripID to
+// in.ToTripID; the RSVP on tripID is cleared. The caller must organize both trips. The
+// destination trip's capacity and requirements apply unless in.BypassCapacity is set; if it
+// rejects the RSVP, nothing changes; if clearing the source RSVP fails, the destination RSVP
+// is restored. It returns the RSVP on the destination trip.
+func (s *Service) MoveMemberRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, memberID domain.MemberID, in MoveMemberRSVPInput) (domain.MyRSVP, error) {
+	reason, err := validateRSVPChangeReason(in.Reason)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	if in.ToTripID == "" || in.ToTripID == tripID {
+		return domain.MyRSVP{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid toTripId", Details: map[string]any{"toTripId": "must name a different trip"}}
+	}
+	from, err := s.organizerRSVPTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	to, err := s.organizerRSVPTrip(ctx, caller, in.ToTripID)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	if err := s.requireMember(ctx, memberID); err != nil {
+		return domain.MyRSVP{}, err
+	}
+
+	existing, err := s.rsvps.Get(ctx, tripID, memberID)
+	if err != nil && !errors.Is(err, rsvprepo.ErrNotFound) {
+		return domain.MyRSVP{}, err
+	}
+	if err != nil || existing.Status == rsvprepo.StatusUnset {
+		return domain.MyRSVP{}, &Error{Status: 409, Code: "RSVP_NOT_SET", Message: "member has no rsvp on this trip to move"}
+	}
+
+	// The destination RSVP as it was before the move, restored if clearing the source fails.
+	prior, err := s.rsvps.Get(ctx, to.ID, memberID)
+	hadPrior := err == nil
+	if err != nil && !errors.Is(err, rsvprepo.ErrNotFound) {
+		return domain.MyRSVP{}, err
+	}
+
+	change := rsvpChange{by: caller, reason: &reason, bypassCapacity: in.BypassCapacity}
+	passengers := existing.PassengerCount
+	moved, err := s.setRSVP(ctx, to, memberID, SetMyRSVPInput{
+		Response:       domain.RSVPResponse(existing.Status),
+		VehicleID:      existing.VehicleID,
+		PassengerCount: &passengers,
+		PassengerNames: existing.PassengerNames,
+	}, change)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	// Releasing a seat never fails a capacity or requirement check.
+	change.bypassCapacity = false
+	if _, err := s.setRSVP(ctx, from, memberID, SetMyRSVPInput{Response: domain.RSVPResponseUnset}, change); err != nil {
+		// Undo the destination write so a failed move leaves both trips as they were.
+		if rbErr := s.restoreRSVP(ctx, to.ID, memberID, prior, hadPrior, change); rbErr != nil {
+			return domain.MyRSVP{}, errors.Join(err, rbErr)
+		}
+		return domain.MyRSVP{}, err
+	}
+	return moved, nil
+}
+
+// restoreRSVP puts back a member's RSVP on a trip as prior (UNSET when there was none) and
+// recounts the trip's attending rigs. The restore is attributed to change like any other write.
+func (s *Service) restoreRSVP(ctx context.Context, tripID domain.TripID, memberID domain.MemberID, prior rsvprepo.RSVP, hadPrior bool, change rsvpChange) error {
+	rec := prior
+	if !hadPrior {
+		rec = rsvprepo.RSVP{TripID: tripID, MemberID: memberID, Status: rsvprepo.StatusUnset}
+	}
+	rec.UpdatedAt = s.clk.Now()
+	rec.ChangedBy = change.by
+	rec.ChangeReason = change.reason
+	if err := s.rsvps.Upsert(ctx, rec); err != nil {
+		return err
+	}
+
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	att, err := s.rsvps.CountYesByTrip(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	t.AttendingRigs = &att
+	t.UpdatedAt = s.clk.Now()
+	return s.trips.Save(ctx, t)
+}
+
+// organizerRSVPTrip loads a trip that accepts RSVPs and that the caller organizes.
+func (s *Service) organizerRSVPTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	t, err := s.rsvpTrip(ctx, caller, tripID)
+	if err != nil {
+		return triprepo.Trip{}, err
+	}
+	if !isOrganizer(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can change another member's rsvp"}
+	}
+	return t, nil
+}
+
+func (s *Service) requireMember(ctx context.Context, id domain.MemberID) error {
+	if _, err := s.members.GetByID(ctx, id); err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return &Error{Status: 404, Code: "MEMBER_NOT_FOUND", Message: "member not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+func validateRSVPChangeReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxRSVPChangeReasonLen {
+		return "", &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid reason", Details: map[string]any{"reason": "must be 1-500 characters"}}
+	}
+	return reason, nil
+}
@@ -0,0 +1,191 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	portrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+)
+
+func TestService_MemberRSVP_SetClearAndCapacityBypass(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1", "m2"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+	if _, err := svc.UpdateTrip(ctx, "org", "tp", trips.UpdateTripInput{CapacityRigs: trips.Some(1)}); err != nil {
+		t.Fatalf("UpdateTrip(capacity): %v", err)
+	}
+	yes := func(reason string, bypass bool) trips.MemberRSVPInput {
+		return trips.MemberRSVPInput{SetMyRSVPInput: trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}, Reason: reason, BypassCapacity: bypass}
+	}
+
+	var ae *trips.Error
+	if _, err := svc.SetMemberRSVP(ctx, "org", "tp", "m1", yes("  ", false)); !errors.As(err, &ae) || ae.Status != 422 {
+		t.Fatalf("SetMemberRSVP without reason err=%v, want 422", err)
+	}
+	if _, err := svc.SetMemberRSVP(ctx, "m2", "tp", "m1", yes("friend", false)); !errors.As(err, &ae) || ae.Status != 403 {
+		t.Fatalf("SetMemberRSVP by non-organizer err=%v, want 403", err)
+	}
+
+	my, err := svc.SetMemberRSVP(ctx, "org", "tp", "m1", yes(" texted me ", false))
+	if err != nil {
+		t.Fatalf("SetMemberRSVP: %v", err)
+	}
+	if my.ChangedBy != "org" || my.ChangeReason == nil || *my.ChangeReason != "texted me" || my.CapacityOverride {
+		t.Fatalf("my=%+v", my)
+	}
+
+	// The trip is full: capacity holds unless the organizer explicitly bypasses it.
+	if _, err := svc.SetMemberRSVP(ctx, "org", "tp", "m2", yes("sweep driver", false)); !errors.As(err, &ae) || ae.Code != "TRIP_AT_CAPACITY" {
+		t.Fatalf("SetMemberRSVP at capacity err=%v, want TRIP_AT_CAPACITY", err)
+	}
+	my, err = svc.SetMemberRSVP(ctx, "org", "tp", "m2", yes("sweep driver", true))
+	if err != nil {
+		t.Fatalf("SetMemberRSVP bypass: %v", err)
+	}
+	if !my.CapacityOverride {
+		t.Fatalf("my=%+v, want capacity override", my)
+	}
+	if n, _ := rsvpsRepo.CountYesByTrip(ctx, "tp"); n != 2 {
+		t.Fatalf("CountYesByTrip=%d, want 2", n)
+	}
+
+	// A self-service change is attributed to the member and clears the organizer's note.
+	my, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo})
+	if err != nil || my.ChangedBy != "m1" || my.ChangeReason != nil {
+		t.Fatalf("SetMyRSVP my=%+v err=%v", my, err)
+	}
+
+	my, err = svc.ClearMemberRSVP(ctx, "org", "tp", "m2", "plans changed")
+	if err != nil {
+		t.Fatalf("ClearMemberRSVP: %v", err)
+	}
+	if my.Response != domain.RSVPResponseUnset || my.CapacityOverride || my.ChangeReason == nil || *my.ChangeReason != "plans changed" {
+		t.Fatalf("cleared=%+v", my)
+	}
+}
+
+func TestService_MemberRSVP_MoveBetweenTrips(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "other", "m1", "m2"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "sat", "org")
+	seedPlannedTrip(t, tripsRepo, "sun", "org")
+	seedPlannedTrip(t, tripsRepo, "elsewhere", "other")
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+
+	two := 2
+	if _, err := svc.SetMyRSVP(ctx, "m1", "sat", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &two}); err != nil {
+		t.Fatalf("SetMyRSVP: %v", err)
+	}
+
+	var ae *trips.Error
+	move := trips.MoveMemberRSVPInput{ToTripID: "elsewhere", Reason: "wrong weekend"}
+	if _, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m1", move); !errors.As(err, &ae) || ae.Status != 403 {
+		t.Fatalf("MoveMemberRSVP to a trip the caller does not organize err=%v, want 403", err)
+	}
+	move.ToTripID = "sun"
+	if _, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m2", move); !errors.As(err, &ae) || ae.Code != "RSVP_NOT_SET" {
+		t.Fatalf("MoveMemberRSVP without rsvp err=%v, want RSVP_NOT_SET", err)
+	}
+
+	// A full destination rejects the move and leaves the source RSVP alone.
+	if _, err := svc.UpdateTrip(ctx, "org", "sun", trips.UpdateTripInput{CapacityPeople: trips.Some(2)}); err != nil {
+		t.Fatalf("UpdateTrip(capacityPeople): %v", err)
+	}
+	if _, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m1", move); !errors.As(err, &ae) || ae.Code != "TRIP_AT_CAPACITY" {
+		t.Fatalf("MoveMemberRSVP to full trip err=%v, want TRIP_AT_CAPACITY", err)
+	}
+	if rec, err := rsvpsRepo.Get(ctx, "sat", "m1"); err != nil || rec.Status != portrsvprepo.StatusYes {
+		t.Fatalf("source rsvp after failed move = %+v err=%v", rec, err)
+	}
+
+	move.BypassCapacity = true
+	moved, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m1", move)
+	if err != nil {
+		t.Fatalf("MoveMemberRSVP: %v", err)
+	}
+	if moved.TripID != "sun" || moved.Response != domain.RSVPResponseYes || moved.PassengerCount != 2 || moved.ChangedBy != "org" || !moved.CapacityOverride {
+		t.Fatalf("moved=%+v", moved)
+	}
+	src, err := rsvpsRepo.Get(ctx, "sat", "m1")
+	if err != nil || src.Status != portrsvprepo.StatusUnset || src.ChangedBy != "org" || src.ChangeReason == nil || *src.ChangeReason != "wrong weekend" {
+		t.Fatalf("source rsvp after move = %+v err=%v", src, err)
+	}
+}
+
+var errRSVPWrite = errors.New("rsvp write failed")
+
+// failingClearRepo fails clearing RSVPs on one trip.
+type failingClearRepo struct {
+	*memrsvprepo.Repo
+	tripID domain.TripID
+}
+
+func (r failingClearRepo) Upsert(ctx context.Context, rec portrsvprepo.RSVP) error {
+	if rec.TripID == r.tripID && rec.Status == portrsvprepo.StatusUnset {
+		return errRSVPWrite
+	}
+	return r.Repo.Upsert(ctx, rec)
+}
+
+func TestService_MemberRSVP_MoveRestoresDestinationWhenSourceFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "sat", "org")
+	seedPlannedTrip(t, tripsRepo, "sun", "org")
+	svc := trips.NewService(tripsRepo, membersRepo, failingClearRepo{Repo: rsvpsRepo, tripID: "sat"})
+
+	if _, err := svc.SetMyRSVP(ctx, "m1", "sat", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP sat: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "sun", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); err != nil {
+		t.Fatalf("SetMyRSVP sun: %v", err)
+	}
+
+	_, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m1", trips.MoveMemberRSVPInput{ToTripID: "sun", Reason: "wrong day"})
+	if !errors.Is(err, errRSVPWrite) {
+		t.Fatalf("MoveMemberRSVP err=%v, want %v", err, errRSVPWrite)
+	}
+
+	// The member is still going on Saturday only, and Sunday counts no rigs.
+	if rec, err := rsvpsRepo.Get(ctx, "sat", "m1"); err != nil || rec.Status != portrsvprepo.StatusYes {
+		t.Fatalf("source rsvp = %+v err=%v, want YES", rec, err)
+	}
+	if rec, err := rsvpsRepo.Get(ctx, "sun", "m1"); err != nil || rec.Status != portrsvprepo.StatusNo {
+		t.Fatalf("destination rsvp = %+v err=%v, want restored NO", rec, err)
+	}
+	sun, err := tripsRepo.GetByID(ctx, "sun")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if sun.AttendingRigs == nil || *sun.AttendingRigs != 0 {
+		t.Fatalf("sun attending rigs = %v, want 0", sun.AttendingRigs)
+	}
+}
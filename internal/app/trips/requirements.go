@@ -0,0 +1,116 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+// maxTireSizeInches bounds TripRequirements.MinTireSizeInches.
+const maxTireSizeInches = 60
+
+// GetTripRequirementsRoster lists each attending rig with the trip requirements it does not
+// meet. Only organizers may see it; ride-share riders are not listed because they bring no rig.
+func (s *Service) GetTripRequirementsRoster(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.RequirementsRoster, error) {
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return domain.RequirementsRoster{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return domain.RequirementsRoster{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) || !isOrganizer(t, caller) {
+		return domain.RequirementsRoster{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if t.Status == triprepo.StatusDraft {
+		return domain.RequirementsRoster{}, &Error{Status: 409, Code: "RSVP_NOT_AVAILABLE", Message: "roster is not available for draft trips"}
+	}
+
+	sum, err := s.tripRSVPSummaryForTrip(ctx, t)
+	if err != nil {
+		return domain.RequirementsRoster{}, err
+	}
+	roster := domain.RequirementsRoster{
+		TripID:       t.ID,
+		Requirements: cloneRequirements(t.Requirements),
+		Entries:      make([]domain.RequirementsRosterEntry, 0, len(sum.AttendeeRigs)),
+	}
+	for _, rig := range sum.AttendeeRigs {
+		roster.Entries = append(roster.Entries, domain.RequirementsRosterEntry{
+			Member:  rig.Member,
+			Vehicle: rig.Vehicle,
+			Unmet:   t.Requirements.Check(rig.Vehicle),
+		})
+	}
+	return roster, nil
+}
+
+// checkRSVPRequirements compares the vehicle named on a YES against the trip's requirements.
+func (s *Service) checkRSVPRequirements(ctx context.Context, caller domain.MemberID, reqs domain.TripRequirements, vehicleID *domain.VehicleID) ([]domain.UnmetRequirement, error) {
+	if reqs.IsZero() {
+		return nil, nil
+	}
+	var vehicle *domain.Vehicle
+	if vehicleID != nil {
+		v, err := s.members.GetVehicle(ctx, caller, *vehicleID)
+		switch {
+		case err == nil:
+			vehicle = &v
+		case !errors.Is(err, memberrepo.ErrVehicleNotFound):
+			return nil, err
+		}
+	}
+	return reqs.Check(vehicle), nil
+}
+
+func requirementsUnmetError(unmet []domain.UnmetRequirement) error {
+	codes := make([]string, 0, len(unmet))
+	for _, u := range unmet {
+		codes = append(codes, string(u.Code))
+	}
+	return &Error{
+		Status:  409,
+		Code:    "VEHICLE_REQUIREMENTS_UNMET",
+		Message: "your vehicle does not meet this trip's requirements",
+		Details: map[string]any{"unmet": codes},
+	}
+}
+
+// validateTripRequirements checks the minimum tire size and gear codes, and de-duplicates the
+// gear checklist into its canonical order.
+func validateTripRequirements(in domain.TripRequirements) (domain.TripRequirements, error) {
+	out := domain.TripRequirements{
+		LockersRequired:    in.LockersRequired,
+		HamLicenseRequired: in.HamLicenseRequired,
+		Strict:             in.Strict,
+	}
+	if in.MinTireSizeInches != nil {
+		n := *in.MinTireSizeInches
+		if n < 1 || n > maxTireSizeInches {
+			return domain.TripRequirements{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid minTireSizeInches", Details: map[string]any{"minTireSizeInches": "must be between 1 and 60"}}
+		}
+		out.MinTireSizeInches = &n
+	}
+	for _, g := range in.RecoveryGear {
+		if !g.Valid() {
+			return domain.TripRequirements{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid recoveryGear", Details: map[string]any{"recoveryGear": "unknown item " + string(g)}}
+		}
+	}
+	for _, g := range domain.RecoveryGearItems {
+		if slices.Contains(in.RecoveryGear, g) {
+			out.RecoveryGear = append(out.RecoveryGear, g)
+		}
+	}
+	return out, nil
+}
+
+func cloneRequirements(r domain.TripRequirements) domain.TripRequirements {
+	out := r
+	out.MinTireSizeInches = cloneIntPtr(r.MinTireSizeInches)
+	out.RecoveryGear = slices.Clone(r.RecoveryGear)
+	return out
+}
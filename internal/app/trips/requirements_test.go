@@ -0,0 +1,136 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	porttriprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestService_Requirements_WarningsStrictAndRoster(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"m1", "m2", "m3"} {
+		provisionMember(t, membersRepo, id)
+	}
+
+	now := time.Unix(800, 0).UTC()
+	small, big := 31, 35
+	for _, v := range []domain.Vehicle{
+		{ID: "v1", MemberID: "m1", Name: "Built", Specs: domain.VehicleSpecs{TireSizeInches: &big, HasLockers: true, RecoveryGear: []domain.RecoveryGearItem{domain.RecoveryGearStrap, domain.RecoveryGearShackles}}},
+		{ID: "v2", MemberID: "m2", Name: "Stock", Specs: domain.VehicleSpecs{TireSizeInches: &small, RecoveryGear: []domain.RecoveryGearItem{domain.RecoveryGearStrap}}},
+	} {
+		v.CreatedAt, v.UpdatedAt = now, now
+		if err := membersRepo.CreateVehicle(ctx, v); err != nil {
+			t.Fatalf("CreateVehicle(%s): %v", v.ID, err)
+		}
+	}
+
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+
+	name := "Rocky Trail"
+	rigs := 5
+	att0 := 0
+	_ = tripsRepo.Create(ctx, porttriprepo.Trip{
+		ID:                 "tq",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &rigs,
+		AttendingRigs:      &att0,
+		CreatorMemberID:    "m1",
+		OrganizerMemberIDs: []domain.MemberID{"m1"},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	var ae *trips.Error
+	_, err := svc.UpdateTrip(ctx, "m1", "tq", trips.UpdateTripInput{Requirements: trips.Some(domain.TripRequirements{RecoveryGear: []domain.RecoveryGearItem{"ROPE"}})})
+	if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != "VALIDATION_ERROR" {
+		t.Fatalf("err=%v, want 422 VALIDATION_ERROR", err)
+	}
+	minTires := 33
+	td, err := svc.UpdateTrip(ctx, "m1", "tq", trips.UpdateTripInput{Requirements: trips.Some(domain.TripRequirements{
+		MinTireSizeInches: &minTires,
+		LockersRequired:   true,
+		RecoveryGear:      []domain.RecoveryGearItem{domain.RecoveryGearShackles, domain.RecoveryGearStrap},
+	})})
+	if err != nil || td.Requirements.MinTireSizeInches == nil || len(td.Requirements.RecoveryGear) != 2 {
+		t.Fatalf("UpdateTrip(requirements) = %+v err=%v", td.Requirements, err)
+	}
+
+	// A vehicle that meets everything gets no warnings.
+	my, err := svc.SetMyRSVP(ctx, "m1", "tq", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if err != nil || len(my.RequirementWarnings) != 0 {
+		t.Fatalf("SetMyRSVP(m1) = %+v err=%v", my, err)
+	}
+
+	// Non-strict trips accept the RSVP and report what is unmet.
+	my, err = svc.SetMyRSVP(ctx, "m2", "tq", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if err != nil || my.Response != domain.RSVPResponseYes {
+		t.Fatalf("SetMyRSVP(m2) = %+v err=%v", my, err)
+	}
+	codes := make([]domain.RequirementCode, 0, len(my.RequirementWarnings))
+	for _, w := range my.RequirementWarnings {
+		codes = append(codes, w.Code)
+	}
+	if len(codes) != 3 || codes[0] != domain.RequirementTireSize || codes[1] != domain.RequirementLockers || codes[2] != domain.RequirementRecoveryGear ||
+		len(my.RequirementWarnings[2].MissingGear) != 1 || my.RequirementWarnings[2].MissingGear[0] != domain.RecoveryGearShackles {
+		t.Fatalf("warnings=%+v", my.RequirementWarnings)
+	}
+
+	// Strict trips reject it; a member without a vehicle is VEHICLE_REQUIRED.
+	strict := td.Requirements
+	strict.Strict = true
+	if _, err := svc.UpdateTrip(ctx, "m1", "tq", trips.UpdateTripInput{Requirements: trips.Some(strict)}); err != nil {
+		t.Fatalf("UpdateTrip(strict): %v", err)
+	}
+	_, err = svc.SetMyRSVP(ctx, "m3", "tq", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "VEHICLE_REQUIREMENTS_UNMET" {
+		t.Fatalf("err=%v, want 409 VEHICLE_REQUIREMENTS_UNMET", err)
+	}
+	if unmet, _ := ae.Details["unmet"].([]string); len(unmet) != 1 || unmet[0] != "VEHICLE_REQUIRED" {
+		t.Fatalf("details=%v", ae.Details)
+	}
+
+	// The roster is for organizers and flags each rig.
+	_, err = svc.GetTripRequirementsRoster(ctx, "m2", "tq")
+	if !errors.As(err, &ae) || ae.Status != 404 {
+		t.Fatalf("err=%v, want 404 for non-organizer", err)
+	}
+	roster, err := svc.GetTripRequirementsRoster(ctx, "m1", "tq")
+	if err != nil || !roster.Requirements.Strict || len(roster.Entries) != 2 {
+		t.Fatalf("roster = %+v err=%v", roster, err)
+	}
+	for _, e := range roster.Entries {
+		switch e.Member.ID {
+		case "m1":
+			if len(e.Unmet) != 0 || e.Vehicle == nil || e.Vehicle.ID != "v1" {
+				t.Fatalf("m1 entry = %+v", e)
+			}
+		case "m2":
+			if len(e.Unmet) != 3 {
+				t.Fatalf("m2 entry = %+v", e)
+			}
+		}
+	}
+
+	// Clearing the requirements clears the warnings.
+	if _, err := svc.UpdateTrip(ctx, "m1", "tq", trips.UpdateTripInput{Requirements: trips.Null[domain.TripRequirements]()}); err != nil {
+		t.Fatalf("UpdateTrip(clear): %v", err)
+	}
+	if my, err := svc.SetMyRSVP(ctx, "m3", "tq", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil || my.RequirementWarnings != nil {
+		t.Fatalf("SetMyRSVP(m3) = %+v err=%v", my, err)
+	}
+}
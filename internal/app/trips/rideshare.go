@@ -0,0 +1,401 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+// maxRideNoteLen bounds ride offer notes and request notes (counted in runes).
+const maxRideNoteLen = 500
+
+var errRideShareDisabled = errors.New("ride share is not configured")
+
+// GetRideShareBoard returns a published trip's ride offers and the caller's own requests
+// (as rider or driver).
+func (s *Service) GetRideShareBoard(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (domain.RideShareBoard, error) {
+	if _, err := s.rideShareTrip(ctx, caller, tripID); err != nil {
+		return domain.RideShareBoard{}, err
+	}
+	offers, err := s.rides.ListOffersByTrip(ctx, tripID)
+	if err != nil {
+		return domain.RideShareBoard{}, err
+	}
+	reqs, err := s.rides.ListRequestsByTrip(ctx, tripID)
+	if err != nil {
+		return domain.RideShareBoard{}, err
+	}
+
+	taken := make(map[domain.MemberID]int)
+	for _, r := range reqs {
+		if r.Status == ridesharerepo.StatusAccepted {
+			taken[r.DriverMemberID]++
+		}
+	}
+
+	board := domain.RideShareBoard{
+		TripID:     tripID,
+		Offers:     make([]domain.RideOffer, 0, len(offers)),
+		MyRequests: make([]domain.RideRequest, 0),
+	}
+	for _, o := range offers {
+		out, err := s.rideOfferToDomain(ctx, o, taken[o.DriverMemberID])
+		if err != nil {
+			return domain.RideShareBoard{}, err
+		}
+		board.Offers = append(board.Offers, out)
+	}
+	for _, r := range reqs {
+		if r.RiderMemberID != caller && r.DriverMemberID != caller {
+			continue
+		}
+		out, err := s.rideRequestToDomain(ctx, r)
+		if err != nil {
+			return domain.RideShareBoard{}, err
+		}
+		board.MyRequests = append(board.MyRequests, out)
+	}
+	return board, nil
+}
+
+// OfferRideSeats creates or updates the caller's seat offer. The caller must be attending with
+// their own rig (RSVP YES), and seats cannot drop below the riders already accepted.
+func (s *Service) OfferRideSeats(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in RideOfferInput) (domain.RideOffer, error) {
+	if _, err := s.rideShareTrip(ctx, caller, tripID); err != nil {
+		return domain.RideOffer{}, err
+	}
+	if in.Seats < 1 {
+		return domain.RideOffer{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid seats", Details: map[string]any{"seats": "must be >= 1"}}
+	}
+	notes, err := normalizeRideNote("notes", in.Notes)
+	if err != nil {
+		return domain.RideOffer{}, err
+	}
+	rsvp, err := s.rsvps.Get(ctx, tripID, caller)
+	if err != nil && !errors.Is(err, rsvprepo.ErrNotFound) {
+		return domain.RideOffer{}, err
+	}
+	if err != nil || rsvp.Status != rsvprepo.StatusYes {
+		return domain.RideOffer{}, &Error{Status: 409, Code: "RSVP_REQUIRED", Message: "rsvp yes with your own rig before offering seats"}
+	}
+
+	now := s.clk.Now()
+	o := ridesharerepo.Offer{
+		TripID:         tripID,
+		DriverMemberID: caller,
+		Seats:          in.Seats,
+		Notes:          notes,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.rides.PutOffer(ctx, o); err != nil {
+		return domain.RideOffer{}, rideShareError(err)
+	}
+	saved, err := s.rides.GetOffer(ctx, tripID, caller)
+	if err != nil {
+		return domain.RideOffer{}, rideShareError(err)
+	}
+	return s.rideOfferWithTaken(ctx, saved)
+}
+
+// WithdrawRideOffer removes the caller's seat offer and cancels its pending and accepted requests.
+func (s *Service) WithdrawRideOffer(ctx context.Context, caller domain.MemberID, tripID domain.TripID) error {
+	if _, err := s.rideShareTrip(ctx, caller, tripID); err != nil {
+		return err
+	}
+	if err := s.rides.WithdrawOffer(ctx, tripID, caller, s.clk.Now()); err != nil {
+		return rideShareError(err)
+	}
+	return nil
+}
+
+// RequestRide asks a driver for a seat. Riders cannot also be attending with their own rig,
+// and hold at most one open request per trip. Like SetMyRSVP, it requires the trip's waivers and
+// fails with RSVP_CLOSED once the trip's RSVP deadline has passed, unless the caller organizes
+// the trip.
+func (s *Service) RequestRide(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in RequestRideInput) (domain.RideRequest, error) {
+	t, err := s.rideShareTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	if s.rsvpClosed(t) && !isOrganizer(t, caller) {
+		return domain.RideRequest{}, rsvpClosedError(t)
+	}
+	if in.DriverMemberID == caller {
+		return domain.RideRequest{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid driverMemberId", Details: map[string]any{"driverMemberId": "must not be yourself"}}
+	}
+	note, err := normalizeRideNote("note", in.Note)
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	if rsvp, err := s.rsvps.Get(ctx, tripID, caller); err == nil && rsvp.Status == rsvprepo.StatusYes {
+		return domain.RideRequest{}, &Error{Status: 409, Code: "RIDE_SHARE_CONFLICT", Message: "members attending with their own rig cannot request a ride"}
+	} else if err != nil && !errors.Is(err, rsvprepo.ErrNotFound) {
+		return domain.RideRequest{}, err
+	}
+	if err := s.requireWaivers(ctx, tripID, caller); err != nil {
+		return domain.RideRequest{}, err
+	}
+
+	offer, err := s.rides.GetOffer(ctx, tripID, in.DriverMemberID)
+	if err != nil {
+		return domain.RideRequest{}, rideShareError(err)
+	}
+	if out, err := s.rideOfferWithTaken(ctx, offer); err != nil {
+		return domain.RideRequest{}, err
+	} else if out.SeatsTaken >= out.Seats {
+		return domain.RideRequest{}, rideShareError(ridesharerepo.ErrNoSeatsAvailable)
+	}
+
+	now := s.clk.Now()
+	req := ridesharerepo.Request{
+		ID:             s.newRideRequestID(),
+		TripID:         tripID,
+		DriverMemberID: in.DriverMemberID,
+		RiderMemberID:  caller,
+		Status:         ridesharerepo.StatusPending,
+		Note:           note,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.rides.CreateRequest(ctx, req); err != nil {
+		return domain.RideRequest{}, rideShareError(err)
+	}
+	return s.rideRequestToDomain(ctx, req)
+}
+
+// AcceptRideRequest gives the rider a seat. Only the driver may accept, and only while the offer
+// has an open seat and the trip's people capacity has room. Accepting adds the rider to the
+// roster, so the rider must have acknowledged the trip's waivers, and it closes at the RSVP
+// deadline unless the driver organizes the trip.
+func (s *Service) AcceptRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error) {
+	t, err := s.rideShareTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	if s.rsvpClosed(t) && !isOrganizer(t, caller) {
+		return domain.RideRequest{}, rsvpClosedError(t)
+	}
+	req, err := s.rideRequestFor(ctx, tripID, requestID, func(r ridesharerepo.Request) bool { return r.DriverMemberID == caller })
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	if req.Status == ridesharerepo.StatusPending {
+		// The rider may have requested before the trip required a waiver (or a new version).
+		if err := s.requireWaivers(ctx, tripID, req.RiderMemberID); err != nil {
+			return domain.RideRequest{}, err
+		}
+	}
+	if t.CapacityPeople != nil && req.Status == ridesharerepo.StatusPending {
+		curPeople, err := s.countPeople(ctx, tripID)
+		if err != nil {
+			return domain.RideRequest{}, err
+		}
+		if curPeople+1 > *t.CapacityPeople {
+			return domain.RideRequest{}, &Error{
+				Status:  409,
+				Code:    "TRIP_AT_CAPACITY",
+				Message: "trip is at people capacity",
+				Details: map[string]any{"capacityPeople": *t.CapacityPeople, "attendingPeople": curPeople},
+			}
+		}
+	}
+	if err := s.rides.AcceptRequest(ctx, requestID, s.clk.Now()); err != nil {
+		return domain.RideRequest{}, rideShareError(err)
+	}
+	return s.reloadRideRequest(ctx, requestID)
+}
+
+// DeclineRideRequest turns down a pending request, or removes an accepted rider. Only the driver may decline.
+func (s *Service) DeclineRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error) {
+	return s.closeRideRequest(ctx, caller, tripID, requestID, ridesharerepo.StatusDeclined, func(r ridesharerepo.Request) bool { return r.DriverMemberID == caller })
+}
+
+// CancelRideRequest withdraws the caller's own pending or accepted request.
+func (s *Service) CancelRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID) (domain.RideRequest, error) {
+	return s.closeRideRequest(ctx, caller, tripID, requestID, ridesharerepo.StatusCanceled, func(r ridesharerepo.Request) bool { return r.RiderMemberID == caller })
+}
+
+func (s *Service) closeRideRequest(ctx context.Context, caller domain.MemberID, tripID domain.TripID, requestID domain.RideRequestID, status ridesharerepo.Status, allowed func(ridesharerepo.Request) bool) (domain.RideRequest, error) {
+	if _, err := s.rideShareTrip(ctx, caller, tripID); err != nil {
+		return domain.RideRequest{}, err
+	}
+	if _, err := s.rideRequestFor(ctx, tripID, requestID, allowed); err != nil {
+		return domain.RideRequest{}, err
+	}
+	if err := s.rides.CloseRequest(ctx, requestID, status, s.clk.Now()); err != nil {
+		return domain.RideRequest{}, rideShareError(err)
+	}
+	return s.reloadRideRequest(ctx, requestID)
+}
+
+// rideShareTrip loads a trip the caller can see and checks that its ride-share board is open.
+func (s *Service) rideShareTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
+	if s.rides == nil {
+		return triprepo.Trip{}, errRideShareDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return triprepo.Trip{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if t.Status != triprepo.StatusPublished {
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "ride share is only available for published trips"}
+	}
+	return t, nil
+}
+
+// rideRequestFor loads a request on the trip that the caller may act on; others read as not found.
+func (s *Service) rideRequestFor(ctx context.Context, tripID domain.TripID, id domain.RideRequestID, allowed func(ridesharerepo.Request) bool) (ridesharerepo.Request, error) {
+	req, err := s.rides.GetRequest(ctx, id)
+	if err != nil {
+		return ridesharerepo.Request{}, rideShareError(err)
+	}
+	if req.TripID != tripID || !allowed(req) {
+		return ridesharerepo.Request{}, rideShareError(ridesharerepo.ErrRequestNotFound)
+	}
+	return req, nil
+}
+
+// openRideRequestForRider returns the caller's pending or accepted ride request on the trip, if any.
+func (s *Service) openRideRequestForRider(ctx context.Context, tripID domain.TripID, rider domain.MemberID) (ridesharerepo.Request, bool, error) {
+	if s.rides == nil {
+		return ridesharerepo.Request{}, false, nil
+	}
+	reqs, err := s.rides.ListRequestsByTrip(ctx, tripID)
+	if err != nil {
+		return ridesharerepo.Request{}, false, err
+	}
+	for _, r := range reqs {
+		if r.RiderMemberID == rider && (r.Status == ridesharerepo.StatusPending || r.Status == ridesharerepo.StatusAccepted) {
+			return r, true, nil
+		}
+	}
+	return ridesharerepo.Request{}, false, nil
+}
+
+// countPeople is the trip's headcount: YES members, their passengers and accepted riders.
+func (s *Service) countPeople(ctx context.Context, tripID domain.TripID) (int, error) {
+	n, err := s.rsvps.CountPeopleByTrip(ctx, tripID)
+	if err != nil {
+		return 0, err
+	}
+	if s.rides != nil {
+		riders, err := s.rides.CountAcceptedByTrip(ctx, tripID)
+		if err != nil {
+			return 0, err
+		}
+		n += riders
+	}
+	return n, nil
+}
+
+func (s *Service) reloadRideRequest(ctx context.Context, id domain.RideRequestID) (domain.RideRequest, error) {
+	req, err := s.rides.GetRequest(ctx, id)
+	if err != nil {
+		return domain.RideRequest{}, rideShareError(err)
+	}
+	return s.rideRequestToDomain(ctx, req)
+}
+
+func (s *Service) rideOfferWithTaken(ctx context.Context, o ridesharerepo.Offer) (domain.RideOffer, error) {
+	reqs, err := s.rides.ListRequestsByTrip(ctx, o.TripID)
+	if err != nil {
+		return domain.RideOffer{}, err
+	}
+	taken := 0
+	for _, r := range reqs {
+		if r.DriverMemberID == o.DriverMemberID && r.Status == ridesharerepo.StatusAccepted {
+			taken++
+		}
+	}
+	return s.rideOfferToDomain(ctx, o, taken)
+}
+
+func (s *Service) rideOfferToDomain(ctx context.Context, o ridesharerepo.Offer, taken int) (domain.RideOffer, error) {
+	driver, err := s.memberSummary(ctx, o.DriverMemberID)
+	if err != nil {
+		return domain.RideOffer{}, err
+	}
+	return domain.RideOffer{
+		TripID:     o.TripID,
+		Driver:     driver,
+		Seats:      o.Seats,
+		SeatsTaken: taken,
+		Notes:      cloneStringPtr(o.Notes),
+		CreatedAt:  o.CreatedAt,
+		UpdatedAt:  o.UpdatedAt,
+	}, nil
+}
+
+func (s *Service) rideRequestToDomain(ctx context.Context, r ridesharerepo.Request) (domain.RideRequest, error) {
+	driver, err := s.memberSummary(ctx, r.DriverMemberID)
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	rider, err := s.memberSummary(ctx, r.RiderMemberID)
+	if err != nil {
+		return domain.RideRequest{}, err
+	}
+	return domain.RideRequest{
+		ID:        r.ID,
+		TripID:    r.TripID,
+		Driver:    driver,
+		Rider:     rider,
+		Status:    r.Status,
+		Note:      cloneStringPtr(r.Note),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}, nil
+}
+
+func (s *Service) memberSummary(ctx context.Context, id domain.MemberID) (domain.MemberSummary, error) {
+	ms, err := s.loadMemberSummariesSorted(ctx, []domain.MemberID{id})
+	if err != nil {
+		return domain.MemberSummary{}, err
+	}
+	return ms[0], nil
+}
+
+func normalizeRideNote(field string, note *string) (*string, error) {
+	if note == nil {
+		return nil, nil
+	}
+	v := strings.TrimSpace(*note)
+	if v == "" {
+		return nil, nil
+	}
+	if len([]rune(v)) > maxRideNoteLen {
+		return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid " + field, Details: map[string]any{field: "must be at most 500 characters"}}
+	}
+	return &v, nil
+}
+
+func rideShareError(err error) error {
+	switch {
+	case errors.Is(err, ridesharerepo.ErrOfferNotFound):
+		return &Error{Status: 404, Code: "RIDE_OFFER_NOT_FOUND", Message: "ride offer not found"}
+	case errors.Is(err, ridesharerepo.ErrRequestNotFound):
+		return &Error{Status: 404, Code: "RIDE_REQUEST_NOT_FOUND", Message: "ride request not found"}
+	case errors.Is(err, ridesharerepo.ErrActiveRequestExists):
+		return &Error{Status: 409, Code: "RIDE_REQUEST_EXISTS", Message: "you already have an open ride request for this trip"}
+	case errors.Is(err, ridesharerepo.ErrNoSeatsAvailable):
+		return &Error{Status: 409, Code: "NO_SEATS_AVAILABLE", Message: "no seats available"}
+	case errors.Is(err, ridesharerepo.ErrSeatsBelowAccepted):
+		return &Error{Status: 409, Code: "SEATS_BELOW_ACCEPTED", Message: "seats cannot be reduced below accepted riders"}
+	case errors.Is(err, ridesharerepo.ErrInvalidTransition):
+		return &Error{Status: 409, Code: "RIDE_REQUEST_CLOSED", Message: "ride request can no longer be changed"}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,175 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ridesharerepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	porttriprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func TestService_RideShare_OfferRequestAcceptAndHeadcount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"d1", "r1", "r2"} {
+		provisionMember(t, membersRepo, id)
+	}
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, rsvpsRepo, trips.Options{RideShares: memridesharerepo.NewRepo()})
+
+	name := "Trip"
+	now := time.Unix(700, 0).UTC()
+	rigs := 5
+	att0 := 0
+	_ = tripsRepo.Create(ctx, porttriprepo.Trip{
+		ID:                 "tr",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &rigs,
+		AttendingRigs:      &att0,
+		CreatorMemberID:    "d1",
+		OrganizerMemberIDs: []domain.MemberID{"d1"},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+
+	// Only members attending with their own rig can offer seats.
+	var ae *trips.Error
+	_, err := svc.OfferRideSeats(ctx, "d1", "tr", trips.RideOfferInput{Seats: 1})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RSVP_REQUIRED" {
+		t.Fatalf("err=%v, want 409 RSVP_REQUIRED", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "d1", "tr", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP(d1): %v", err)
+	}
+	offer, err := svc.OfferRideSeats(ctx, "d1", "tr", trips.RideOfferInput{Seats: 1})
+	if err != nil || offer.Seats != 1 || offer.SeatsTaken != 0 || offer.Driver.ID != "d1" {
+		t.Fatalf("OfferRideSeats = %+v err=%v", offer, err)
+	}
+
+	// Riders request a seat; one open request each.
+	req1, err := svc.RequestRide(ctx, "r1", "tr", trips.RequestRideInput{DriverMemberID: "d1"})
+	if err != nil || req1.Status != domain.RideRequestStatusPending || req1.Rider.ID != "r1" {
+		t.Fatalf("RequestRide(r1) = %+v err=%v", req1, err)
+	}
+	_, err = svc.RequestRide(ctx, "r1", "tr", trips.RequestRideInput{DriverMemberID: "d1"})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RIDE_REQUEST_EXISTS" {
+		t.Fatalf("err=%v, want 409 RIDE_REQUEST_EXISTS", err)
+	}
+	req2, err := svc.RequestRide(ctx, "r2", "tr", trips.RequestRideInput{DriverMemberID: "d1"})
+	if err != nil {
+		t.Fatalf("RequestRide(r2): %v", err)
+	}
+
+	// Only the driver accepts, and only while seats remain.
+	_, err = svc.AcceptRideRequest(ctx, "r2", "tr", req1.ID)
+	if !errors.As(err, &ae) || ae.Status != 404 || ae.Code != "RIDE_REQUEST_NOT_FOUND" {
+		t.Fatalf("err=%v, want 404 RIDE_REQUEST_NOT_FOUND", err)
+	}
+	if got, err := svc.AcceptRideRequest(ctx, "d1", "tr", req1.ID); err != nil || got.Status != domain.RideRequestStatusAccepted {
+		t.Fatalf("AcceptRideRequest(r1) = %+v err=%v", got, err)
+	}
+	_, err = svc.AcceptRideRequest(ctx, "d1", "tr", req2.ID)
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "NO_SEATS_AVAILABLE" {
+		t.Fatalf("err=%v, want 409 NO_SEATS_AVAILABLE", err)
+	}
+
+	// Accepted riders count as attending people but not rigs.
+	sum, err := svc.GetTripRSVPSummary(ctx, "d1", "tr")
+	if err != nil {
+		t.Fatalf("GetTripRSVPSummary: %v", err)
+	}
+	if sum.AttendingRigs != 1 || sum.AttendingPeople != 2 || len(sum.AttendingMembers) != 2 {
+		t.Fatalf("sum=%+v", sum)
+	}
+	if len(sum.AttendeeRigs) != 1 || len(sum.AttendeeRigs[0].Riders) != 1 || sum.AttendeeRigs[0].Riders[0].ID != "r1" {
+		t.Fatalf("rigs=%+v", sum.AttendeeRigs)
+	}
+
+	// A rider cannot also RSVP with their own rig.
+	_, err = svc.SetMyRSVP(ctx, "r1", "tr", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RIDE_SHARE_CONFLICT" {
+		t.Fatalf("err=%v, want 409 RIDE_SHARE_CONFLICT", err)
+	}
+
+	// The people cap counts riders.
+	if _, err := svc.UpdateTrip(ctx, "d1", "tr", trips.UpdateTripInput{CapacityPeople: trips.Some(1)}); !errors.As(err, &ae) || ae.Code != "CAPACITY_BELOW_ATTENDANCE" {
+		t.Fatalf("err=%v, want CAPACITY_BELOW_ATTENDANCE", err)
+	}
+
+	// The board shows the offer with its taken seat; each member sees only their own requests.
+	board, err := svc.GetRideShareBoard(ctx, "r2", "tr")
+	if err != nil || len(board.Offers) != 1 || board.Offers[0].SeatsTaken != 1 || len(board.MyRequests) != 1 || board.MyRequests[0].ID != req2.ID {
+		t.Fatalf("GetRideShareBoard(r2) = %+v err=%v", board, err)
+	}
+
+	// Leaving the trip withdraws the driver's offer and cancels its requests.
+	if _, err := svc.SetMyRSVP(ctx, "d1", "tr", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); err != nil {
+		t.Fatalf("SetMyRSVP(d1 NO): %v", err)
+	}
+	board, err = svc.GetRideShareBoard(ctx, "r1", "tr")
+	if err != nil || len(board.Offers) != 0 || len(board.MyRequests) != 1 || board.MyRequests[0].Status != domain.RideRequestStatusCanceled {
+		t.Fatalf("GetRideShareBoard(r1) after withdraw = %+v err=%v", board, err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "r1", "tr", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP(r1 YES) after withdraw: %v", err)
+	}
+}
+
+func TestService_RideShare_ClosesAtRSVPDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "d1", "r1", "r2"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+
+	clk := memclock.NewManualClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{RideShares: memridesharerepo.NewRepo(), Clock: clk})
+
+	deadline := time.Date(2026, 4, 27, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.UpdateTrip(ctx, "org", "tp", trips.UpdateTripInput{RSVPDeadline: trips.Some(deadline)}); err != nil {
+		t.Fatalf("UpdateTrip(rsvpDeadline): %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "d1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP(d1): %v", err)
+	}
+	if _, err := svc.OfferRideSeats(ctx, "d1", "tp", trips.RideOfferInput{Seats: 3}); err != nil {
+		t.Fatalf("OfferRideSeats: %v", err)
+	}
+	req1, err := svc.RequestRide(ctx, "r1", "tp", trips.RequestRideInput{DriverMemberID: "d1"})
+	if err != nil {
+		t.Fatalf("RequestRide(r1): %v", err)
+	}
+
+	// From the deadline on, members can neither request nor accept rides.
+	clk.Set(deadline)
+	var ae *trips.Error
+	if _, err := svc.RequestRide(ctx, "r2", "tp", trips.RequestRideInput{DriverMemberID: "d1"}); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RSVP_CLOSED" {
+		t.Fatalf("RequestRide after deadline err=%v, want 409 RSVP_CLOSED", err)
+	}
+	if _, err := svc.AcceptRideRequest(ctx, "d1", "tp", req1.ID); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RSVP_CLOSED" {
+		t.Fatalf("AcceptRideRequest after deadline err=%v, want 409 RSVP_CLOSED", err)
+	}
+
+	// Organizers are not bound by the deadline.
+	if _, err := svc.RequestRide(ctx, "org", "tp", trips.RequestRideInput{DriverMemberID: "d1"}); err != nil {
+		t.Fatalf("RequestRide(org) after deadline: %v", err)
+	}
+}
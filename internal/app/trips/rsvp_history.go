@@ -0,0 +1,62 @@
+package trips
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+// GetTripRSVPHistory returns every RSVP change on a trip, oldest first, including changes
+// organizers made on members' behalf. Only organizers may see it.
+func (s *Service) GetTripRSVPHistory(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.RSVPChange, error) {
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return nil, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if !isOrganizer(t, caller) {
+		return nil, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can view a trip's rsvp history"}
+	}
+	entries, err := s.rsvps.ListHistoryByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return rsvpChangesFromHistory(entries), nil
+}
+
+// ListMyRSVPHistory returns every change to the caller's RSVPs across all trips, oldest first.
+func (s *Service) ListMyRSVPHistory(ctx context.Context, caller domain.MemberID) ([]domain.RSVPChange, error) {
+	entries, err := s.rsvps.ListHistoryByMember(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	return rsvpChangesFromHistory(entries), nil
+}
+
+func rsvpChangesFromHistory(entries []rsvprepo.HistoryEntry) []domain.RSVPChange {
+	out := make([]domain.RSVPChange, 0, len(entries))
+	for _, e := range entries {
+		c := domain.RSVPChange{
+			TripID:       e.TripID,
+			MemberID:     e.MemberID,
+			Response:     domain.RSVPResponse(e.Status),
+			ChangedBy:    e.ChangedBy,
+			ChangeReason: cloneStringPtr(e.ChangeReason),
+			ChangedAt:    e.ChangedAt,
+		}
+		if e.PreviousStatus != nil {
+			prev := domain.RSVPResponse(*e.PreviousStatus)
+			c.PreviousResponse = &prev
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+func TestService_RSVPHistory_TripTimelineAndMyHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "sat", "org")
+	seedPlannedTrip(t, tripsRepo, "sun", "org")
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+
+	if _, err := svc.SetMyRSVP(ctx, "m1", "sat", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP: %v", err)
+	}
+	if _, err := svc.MoveMemberRSVP(ctx, "org", "sat", "m1", trips.MoveMemberRSVPInput{ToTripID: "sun", Reason: "wrong day"}); err != nil {
+		t.Fatalf("MoveMemberRSVP: %v", err)
+	}
+
+	var ae *trips.Error
+	if _, err := svc.GetTripRSVPHistory(ctx, "m1", "sat"); !errors.As(err, &ae) || ae.Status != 403 {
+		t.Fatalf("GetTripRSVPHistory by non-organizer err=%v, want 403", err)
+	}
+	if _, err := svc.GetTripRSVPHistory(ctx, "org", "missing"); !errors.As(err, &ae) || ae.Code != "TRIP_NOT_FOUND" {
+		t.Fatalf("GetTripRSVPHistory missing trip err=%v, want TRIP_NOT_FOUND", err)
+	}
+
+	sat, err := svc.GetTripRSVPHistory(ctx, "org", "sat")
+	if err != nil {
+		t.Fatalf("GetTripRSVPHistory: %v", err)
+	}
+	if len(sat) != 2 {
+		t.Fatalf("sat history=%+v, want 2 changes", sat)
+	}
+	if c := sat[0]; c.PreviousResponse != nil || c.Response != domain.RSVPResponseYes || c.ChangedBy != "m1" || c.ChangeReason != nil {
+		t.Fatalf("first change=%+v", c)
+	}
+	if c := sat[1]; c.PreviousResponse == nil || *c.PreviousResponse != domain.RSVPResponseYes || c.Response != domain.RSVPResponseUnset ||
+		c.ChangedBy != "org" || c.ChangeReason == nil || *c.ChangeReason != "wrong day" {
+		t.Fatalf("move-out change=%+v", c)
+	}
+
+	mine, err := svc.ListMyRSVPHistory(ctx, "m1")
+	if err != nil {
+		t.Fatalf("ListMyRSVPHistory: %v", err)
+	}
+	if len(mine) != 3 {
+		t.Fatalf("my history=%+v, want 3 changes", mine)
+	}
+	seen := map[domain.TripID]int{}
+	for _, c := range mine {
+		if c.MemberID != "m1" {
+			t.Fatalf("my history has another member's change: %+v", c)
+		}
+		seen[c.TripID]++
+	}
+	if seen["sat"] != 2 || seen["sun"] != 1 {
+		t.Fatalf("my history trips=%v", seen)
+	}
+}
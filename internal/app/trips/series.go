@@ -0,0 +1,289 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+)
+
+const (
+	// defaultSeriesHorizonDays is how far ahead occurrences are generated when
+	// Options.SeriesHorizonDays is unset.
+	defaultSeriesHorizonDays = 60
+	// maxSeriesDurationDays bounds how many days a single occurrence may span.
+	maxSeriesDurationDays = 14
+)
+
+var errSeriesDisabled = errors.New("trip series are not configured")
+
+// CreateTripSeries starts a recurring series from a trip the caller can see and generates its
+// occurrences up to the horizon. The caller creates and organizes every occurrence.
+func (s *Service) CreateTripSeries(ctx context.Context, caller domain.MemberID, in CreateTripSeriesInput) (TripSeriesDetails, error) {
+	if s.series == nil {
+		return TripSeriesDetails{}, errSeriesDisabled
+	}
+	invalid := func(field, msg string) error {
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid trip series", Details: map[string]any{field: msg}}
+	}
+	rec, err := domain.ParseRecurrence(in.Recurrence)
+	if err != nil {
+		return TripSeriesDetails{}, invalid("recurrence", err.Error())
+	}
+	if in.StartDate.IsZero() {
+		return TripSeriesDetails{}, invalid("startDate", "is required")
+	}
+	start := calendarDate(in.StartDate)
+	var end *time.Time
+	if in.EndDate != nil {
+		d := calendarDate(*in.EndDate)
+		if d.Before(start) {
+			return TripSeriesDetails{}, invalid("endDate", "must be on or after startDate")
+		}
+		end = &d
+	}
+	duration := in.DurationDays
+	if duration == 0 {
+		duration = 1
+	}
+	if duration < 1 || duration > maxSeriesDurationDays {
+		return TripSeriesDetails{}, invalid("durationDays", "must be between 1 and 14")
+	}
+
+	src, err := s.trips.GetByID(ctx, in.TemplateTripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return TripSeriesDetails{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return TripSeriesDetails{}, err
+	}
+	if !isTripVisibleToCaller(src, caller) {
+		return TripSeriesDetails{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+
+	now := s.clk.Now()
+	series := domain.TripSeries{
+		ID:              s.newSeriesID(),
+		CreatedByMember: caller,
+		Recurrence:      rec,
+		StartDate:       start,
+		EndDate:         end,
+		DurationDays:    duration,
+		Publish:         in.Publish,
+		Plan:            planFromTrip(src),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if series.Publish {
+		// Dates come from the schedule; everything else must already be publishable.
+		probe := s.occurrenceFromSeries(series, start, now)
+		if missing := requiredPublishFieldsMissing(probe); len(missing) > 0 {
+			return TripSeriesDetails{}, &Error{
+				Status:  409,
+				Code:    "TRIP_NOT_READY_TO_PUBLISH",
+				Message: "template trip is missing required fields for publish",
+				Details: map[string]any{"missing": missing},
+			}
+		}
+	}
+	if err := s.series.Create(ctx, series); err != nil {
+		return TripSeriesDetails{}, err
+	}
+	if _, err := s.generateOccurrences(ctx, series, now); err != nil {
+		return TripSeriesDetails{}, err
+	}
+	return s.tripSeriesDetails(ctx, caller, series)
+}
+
+// GetTripSeries returns a series and the occurrences the caller can see.
+func (s *Service) GetTripSeries(ctx context.Context, caller domain.MemberID, id domain.TripSeriesID) (TripSeriesDetails, error) {
+	series, err := s.tripSeries(ctx, id)
+	if err != nil {
+		return TripSeriesDetails{}, err
+	}
+	return s.tripSeriesDetails(ctx, caller, series)
+}
+
+// GenerateTripSeriesOccurrences tops up every series with the occurrences that fall within the
+// horizon. It is safe to run repeatedly and concurrently: dates that already have an
+// occurrence, including canceled ones, are skipped. It returns how many trips it created.
+func (s *Service) GenerateTripSeriesOccurrences(ctx context.Context) (int, error) {
+	if s.series == nil {
+		return 0, errSeriesDisabled
+	}
+	all, err := s.series.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := s.clk.Now()
+	total := 0
+	var errs []error
+	for _, series := range all {
+		n, err := s.generateOccurrences(ctx, series, now)
+		total += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// UpdateTripSeriesFromOccurrence applies in to an occurrence, to every later occurrence that is
+// not canceled and to the series plan, so occurrences generated later include the edit too.
+// Dates and artifact order stay per occurrence. Later occurrences that reject the edit are
+// skipped and reported rather than failing the whole request.
+func (s *Service) UpdateTripSeriesFromOccurrence(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in UpdateTripInput) (SeriesUpdateResult, error) {
+	if in.StartDate.IsSpecified() || in.EndDate.IsSpecified() || in.RSVPDeadline.IsSpecified() || in.ArtifactIDs.IsSpecified() {
+		return SeriesUpdateResult{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid series edit", Details: map[string]any{"scope": "startDate, endDate, rsvpDeadline and artifactIds can only be changed one occurrence at a time"}}
+	}
+
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return SeriesUpdateResult{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return SeriesUpdateResult{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return SeriesUpdateResult{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if t.Series == nil {
+		return SeriesUpdateResult{}, &Error{Status: 409, Code: "TRIP_NOT_IN_SERIES", Message: "trip is not an occurrence of a series"}
+	}
+	series, err := s.tripSeries(ctx, t.Series.SeriesID)
+	if err != nil {
+		return SeriesUpdateResult{}, err
+	}
+
+	d, err := s.UpdateTrip(ctx, caller, tripID, in)
+	if err != nil {
+		return SeriesUpdateResult{}, err
+	}
+	res := SeriesUpdateResult{Trip: d, Updated: []domain.TripID{}, Skipped: []domain.TripID{}}
+
+	now := s.clk.Now()
+	plan := s.occurrenceFromSeries(series, series.StartDate, now)
+	if err := s.applyTripUpdate(ctx, &plan, in); err != nil {
+		return SeriesUpdateResult{}, err
+	}
+	series.Plan = planFromTrip(plan)
+	series.UpdatedAt = now
+	if err := s.series.Save(ctx, series); err != nil {
+		return SeriesUpdateResult{}, err
+	}
+
+	occs, err := s.trips.ListBySeries(ctx, series.ID)
+	if err != nil {
+		return SeriesUpdateResult{}, err
+	}
+	for _, o := range occs {
+		if !o.Series.Date.After(t.Series.Date) || o.Status == triprepo.StatusCanceled {
+			continue
+		}
+		if _, err := s.UpdateTrip(ctx, caller, o.ID, in); err != nil {
+			if ae := (*Error)(nil); errors.As(err, &ae) {
+				res.Skipped = append(res.Skipped, o.ID)
+				continue
+			}
+			return SeriesUpdateResult{}, err
+		}
+		res.Updated = append(res.Updated, o.ID)
+	}
+	return res, nil
+}
+
+func (s *Service) tripSeries(ctx context.Context, id domain.TripSeriesID) (domain.TripSeries, error) {
+	if s.series == nil {
+		return domain.TripSeries{}, errSeriesDisabled
+	}
+	series, err := s.series.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, tripseriesrepo.ErrNotFound) {
+			return domain.TripSeries{}, &Error{Status: 404, Code: "TRIP_SERIES_NOT_FOUND", Message: "trip series not found"}
+		}
+		return domain.TripSeries{}, err
+	}
+	return series, nil
+}
+
+func (s *Service) tripSeriesDetails(ctx context.Context, caller domain.MemberID, series domain.TripSeries) (TripSeriesDetails, error) {
+	occs, err := s.trips.ListBySeries(ctx, series.ID)
+	if err != nil {
+		return TripSeriesDetails{}, err
+	}
+	out := TripSeriesDetails{Series: series, Occurrences: make([]TripSeriesOccurrence, 0, len(occs))}
+	for _, o := range occs {
+		if !isTripVisibleToCaller(o, caller) {
+			continue
+		}
+		out.Occurrences = append(out.Occurrences, TripSeriesOccurrence{Date: o.Series.Date, Trip: toDomainSummary(o)})
+	}
+	return out, nil
+}
+
+// generateOccurrences creates the series' missing occurrences from today through the horizon
+// (or the series end date) and returns how many it created. Occurrences of a publishing
+// series are published when ready and otherwise left as public drafts for the organizer.
+func (s *Service) generateOccurrences(ctx context.Context, series domain.TripSeries, now time.Time) (int, error) {
+	today := calendarDate(now)
+	through := today.AddDate(0, 0, s.seriesHorizonDays)
+	if series.EndDate != nil && series.EndDate.Before(through) {
+		through = *series.EndDate
+	}
+	existing, err := s.trips.ListBySeries(ctx, series.ID)
+	if err != nil {
+		return 0, err
+	}
+	taken := make(map[time.Time]bool, len(existing))
+	for _, t := range existing {
+		taken[t.Series.Date] = true
+	}
+
+	created := 0
+	for _, date := range series.Recurrence.Dates(series.StartDate, today, through) {
+		if taken[date] {
+			continue
+		}
+		t := s.occurrenceFromSeries(series, date, now)
+		if series.Publish {
+			t.DraftVisibility = triprepo.DraftVisibilityPublic
+		}
+		if err := s.trips.Create(ctx, t); err != nil {
+			if errors.Is(err, triprepo.ErrOccurrenceExists) {
+				// Generated concurrently.
+				continue
+			}
+			return created, err
+		}
+		created++
+		if series.Publish && len(requiredPublishFieldsMissing(t)) == 0 {
+			z := 0
+			t.Status = triprepo.StatusPublished
+			t.AttendingRigs = &z
+			if err := s.trips.Save(ctx, t); err != nil {
+				return created, err
+			}
+		}
+	}
+	return created, nil
+}
+
+// occurrenceFromSeries builds the draft occurrence of series scheduled for date.
+func (s *Service) occurrenceFromSeries(series domain.TripSeries, date time.Time, now time.Time) triprepo.Trip {
+	t := s.tripFromPlan(series.CreatedByMember, series.Plan, now)
+	start := date
+	end := date.AddDate(0, 0, series.DurationDays-1)
+	t.StartDate = &start
+	t.EndDate = &end
+	t.Series = &triprepo.SeriesOccurrence{SeriesID: series.ID, Date: date}
+	return t
+}
+
+// calendarDate truncates t to its UTC calendar date.
+func calendarDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,240 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	memtripseriesrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/tripseriesrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	porttriprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func TestService_CreateTripSeries_GeneratesOccurrencesWithinHorizon(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	seriesRepo := memtripseriesrepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	seedPlannedTrip(t, tripsRepo, "src", "org")
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Series: seriesRepo, SeriesHorizonDays: 28})
+	start := today().AddDate(0, 0, -7)
+	d, err := svc.CreateTripSeries(ctx, "org", trips.CreateTripSeriesInput{
+		TemplateTripID: "src",
+		Recurrence:     "RRULE:FREQ=WEEKLY;BYDAY=" + [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}[start.Weekday()],
+		StartDate:      start,
+		DurationDays:   2,
+	})
+	if err != nil {
+		t.Fatalf("CreateTripSeries: %v", err)
+	}
+	// Past dates are not generated; today through today+28 holds five weekly dates.
+	if len(d.Occurrences) != 5 {
+		t.Fatalf("occurrences = %d, want 5", len(d.Occurrences))
+	}
+	for i, o := range d.Occurrences {
+		want := today().AddDate(0, 0, 7*i)
+		if !o.Date.Equal(want) || o.Trip.StartDate == nil || !o.Trip.StartDate.Equal(want) || o.Trip.EndDate == nil || !o.Trip.EndDate.Equal(want.AddDate(0, 0, 1)) {
+			t.Fatalf("occurrence %d = %+v, want %s", i, o, want)
+		}
+		if o.Trip.Status != domain.TripStatusDraft || o.Trip.Name == nil || *o.Trip.Name != "Canyon Run" {
+			t.Fatalf("occurrence %d trip = %+v", i, o.Trip)
+		}
+	}
+
+	// Private draft occurrences are only visible to the series creator.
+	other, err := svc.GetTripSeries(ctx, "m2", d.Series.ID)
+	if err != nil || len(other.Occurrences) != 0 {
+		t.Fatalf("GetTripSeries(m2) = %+v err=%v", other, err)
+	}
+	if n, err := svc.GenerateTripSeriesOccurrences(ctx); err != nil || n != 0 {
+		t.Fatalf("GenerateTripSeriesOccurrences = %d err=%v, want 0", n, err)
+	}
+
+	// A longer horizon tops the series up without duplicating existing dates.
+	wider := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Series: seriesRepo, SeriesHorizonDays: 42})
+	if n, err := wider.GenerateTripSeriesOccurrences(ctx); err != nil || n != 2 {
+		t.Fatalf("GenerateTripSeriesOccurrences(wider) = %d err=%v, want 2", n, err)
+	}
+}
+
+func TestService_CreateTripSeries_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	seedPlannedTrip(t, tripsRepo, "src", "org")
+	name := "Draft"
+	if err := tripsRepo.Create(ctx, porttriprepo.Trip{
+		ID:                 "private",
+		Status:             porttriprepo.StatusDraft,
+		Name:               &name,
+		CreatorMemberID:    "org",
+		OrganizerMemberIDs: []domain.MemberID{"org"},
+		DraftVisibility:    porttriprepo.DraftVisibilityPrivate,
+	}); err != nil {
+		t.Fatalf("create trip: %v", err)
+	}
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Series: memtripseriesrepo.NewRepo()})
+	end := today().AddDate(0, 0, -1)
+	cases := []struct {
+		name   string
+		caller domain.MemberID
+		in     trips.CreateTripSeriesInput
+		status int
+		code   string
+	}{
+		{"daily", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=DAILY", StartDate: today()}, 422, "VALIDATION_ERROR"},
+		{"two days", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=WEEKLY;BYDAY=MO,TU", StartDate: today()}, 422, "VALIDATION_ERROR"},
+		{"count", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=WEEKLY;BYDAY=MO;COUNT=3", StartDate: today()}, 422, "VALIDATION_ERROR"},
+		{"no start", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=MONTHLY;BYDAY=1SA"}, 422, "VALIDATION_ERROR"},
+		{"end before start", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=MONTHLY;BYDAY=-1SA", StartDate: today(), EndDate: &end}, 422, "VALIDATION_ERROR"},
+		{"long", "org", trips.CreateTripSeriesInput{TemplateTripID: "src", Recurrence: "FREQ=MONTHLY;BYDAY=SA;BYSETPOS=2", StartDate: today(), DurationDays: 15}, 422, "VALIDATION_ERROR"},
+		{"invisible", "m2", trips.CreateTripSeriesInput{TemplateTripID: "private", Recurrence: "FREQ=WEEKLY;BYDAY=MO", StartDate: today()}, 404, "TRIP_NOT_FOUND"},
+		{"not publishable", "org", trips.CreateTripSeriesInput{TemplateTripID: "private", Recurrence: "FREQ=WEEKLY;BYDAY=MO", StartDate: today(), Publish: true}, 409, "TRIP_NOT_READY_TO_PUBLISH"},
+	}
+	for _, tc := range cases {
+		_, err := svc.CreateTripSeries(ctx, tc.caller, tc.in)
+		var ae *trips.Error
+		if !errors.As(err, &ae) || ae.Status != tc.status || ae.Code != tc.code {
+			t.Fatalf("%s: err = %v, want %d %s", tc.name, err, tc.status, tc.code)
+		}
+	}
+	if _, err := svc.GetTripSeries(ctx, "org", "missing"); err == nil {
+		t.Fatalf("GetTripSeries missing: expected error")
+	} else if ae := (*trips.Error)(nil); !errors.As(err, &ae) || ae.Code != "TRIP_SERIES_NOT_FOUND" {
+		t.Fatalf("GetTripSeries missing err = %v", err)
+	}
+}
+
+func TestService_TripSeries_CancelAndFutureEdits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	seriesRepo := memtripseriesrepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	seedPlannedTrip(t, tripsRepo, "src", "org")
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, rsvpsRepo, trips.Options{Series: seriesRepo, SeriesHorizonDays: 28})
+	if _, err := svc.UpdateTrip(ctx, "org", "src", trips.UpdateTripInput{
+		CommsRequirementsText:       trips.Some("GMRS"),
+		RecommendedRequirementsText: trips.Some("Full-size spare"),
+	}); err != nil {
+		t.Fatalf("UpdateTrip: %v", err)
+	}
+	d, err := svc.CreateTripSeries(ctx, "org", trips.CreateTripSeriesInput{
+		TemplateTripID: "src",
+		Recurrence:     "FREQ=WEEKLY;INTERVAL=1;BYDAY=" + [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}[today().Weekday()],
+		StartDate:      today(),
+		Publish:        true,
+	})
+	if err != nil {
+		t.Fatalf("CreateTripSeries: %v", err)
+	}
+	if len(d.Occurrences) != 5 {
+		t.Fatalf("occurrences = %d, want 5", len(d.Occurrences))
+	}
+	ids := make([]domain.TripID, 0, len(d.Occurrences))
+	for _, o := range d.Occurrences {
+		if o.Trip.Status != domain.TripStatusPublished {
+			t.Fatalf("occurrence %s status = %s, want PUBLISHED", o.Trip.ID, o.Trip.Status)
+		}
+		ids = append(ids, o.Trip.ID)
+	}
+
+	// Canceling one occurrence leaves the series alone and is never regenerated.
+	if _, err := svc.CancelTrip(ctx, "org", ids[2]); err != nil {
+		t.Fatalf("CancelTrip: %v", err)
+	}
+	if n, err := svc.GenerateTripSeriesOccurrences(ctx); err != nil || n != 0 {
+		t.Fatalf("GenerateTripSeriesOccurrences = %d err=%v, want 0", n, err)
+	}
+	if _, err := seriesRepo.GetByID(ctx, d.Series.ID); err != nil {
+		t.Fatalf("series after cancel: %v", err)
+	}
+
+	// Two rigs attend the last occurrence, so capacity 1 cannot apply there.
+	if _, err := svc.SetMyRSVP(ctx, "org", ids[4], trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP org: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m2", ids[4], trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP m2: %v", err)
+	}
+
+	if _, err := svc.UpdateTripSeriesFromOccurrence(ctx, "org", ids[1], trips.UpdateTripInput{StartDate: trips.Some(today())}); err == nil {
+		t.Fatalf("expected date edits to be rejected for a series scope")
+	}
+	if _, err := svc.UpdateTripSeriesFromOccurrence(ctx, "org", "src", trips.UpdateTripInput{Name: trips.Some("x")}); err == nil {
+		t.Fatalf("expected TRIP_NOT_IN_SERIES")
+	} else if ae := (*trips.Error)(nil); !errors.As(err, &ae) || ae.Code != "TRIP_NOT_IN_SERIES" {
+		t.Fatalf("not in series err = %v", err)
+	}
+
+	res, err := svc.UpdateTripSeriesFromOccurrence(ctx, "org", ids[1], trips.UpdateTripInput{
+		Name:         trips.Some("Canyon Run (weekly)"),
+		CapacityRigs: trips.Some(1),
+	})
+	if err != nil {
+		t.Fatalf("UpdateTripSeriesFromOccurrence: %v", err)
+	}
+	if res.Trip.ID != ids[1] || res.Trip.Name == nil || *res.Trip.Name != "Canyon Run (weekly)" {
+		t.Fatalf("result trip = %+v", res.Trip)
+	}
+	if len(res.Updated) != 1 || res.Updated[0] != ids[3] || len(res.Skipped) != 1 || res.Skipped[0] != ids[4] {
+		t.Fatalf("Updated=%v Skipped=%v, want [%s] [%s]", res.Updated, res.Skipped, ids[3], ids[4])
+	}
+
+	name := func(id domain.TripID) string {
+		t.Helper()
+		tr, err := tripsRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID %s: %v", id, err)
+		}
+		return *tr.Name
+	}
+	if name(ids[0]) != "Canyon Run" || name(ids[2]) != "Canyon Run" || name(ids[4]) != "Canyon Run" || name(ids[3]) != "Canyon Run (weekly)" {
+		t.Fatalf("names = %q %q %q %q", name(ids[0]), name(ids[2]), name(ids[3]), name(ids[4]))
+	}
+	series, err := seriesRepo.GetByID(ctx, d.Series.ID)
+	if err != nil {
+		t.Fatalf("GetByID series: %v", err)
+	}
+	if series.Plan.Name == nil || *series.Plan.Name != "Canyon Run (weekly)" || series.Plan.CapacityRigs == nil || *series.Plan.CapacityRigs != 1 {
+		t.Fatalf("series plan = %+v", series.Plan)
+	}
+
+	// Occurrences generated later pick up the edit.
+	wider := trips.NewServiceWithOptions(tripsRepo, membersRepo, rsvpsRepo, trips.Options{Series: seriesRepo, SeriesHorizonDays: 35})
+	if n, err := wider.GenerateTripSeriesOccurrences(ctx); err != nil || n != 1 {
+		t.Fatalf("GenerateTripSeriesOccurrences = %d err=%v, want 1", n, err)
+	}
+	occs, err := tripsRepo.ListBySeries(ctx, d.Series.ID)
+	if err != nil || len(occs) != 6 {
+		t.Fatalf("ListBySeries = %d err=%v", len(occs), err)
+	}
+	if last := occs[5]; last.Name == nil || *last.Name != "Canyon Run (weekly)" || last.Status != porttriprepo.StatusPublished {
+		t.Fatalf("new occurrence = %+v", last)
+	}
+}
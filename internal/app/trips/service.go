@@ -4,24 +4,76 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	platformclock "github.com/BennettSmith/ebo-planner-backend/internal/platform/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/announcementrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/attendancerepo"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/commentrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/events"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/itineraryrepo"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/notifier"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/ridesharerepo"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/rsvprepo"
 	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/tripseriesrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
 )
 
-type Service struct {
-	trips   triprepo.Repository
-	members memberrepo.Repository
-	rsvps   rsvprepo.Repository
+// maxPassengerNameLen bounds passenger names on an RSVP (counted in runes).
+const maxPassengerNameLen = 100
+
+// maxPassengerCount bounds the passengers in one rig on an RSVP. Vehicles do not record their
+// seats, so this is a fixed cap that keeps people counts sane on trips without a people limit.
+const maxPassengerCount = 15
 
-	newTripID func() domain.TripID
+type Service struct {
+	trips     triprepo.Repository
+	members   memberrepo.Repository
+	rsvps     rsvprepo.Repository
+	rides     ridesharerepo.Repository
+	templates triptemplaterepo.Repository
+	series    tripseriesrepo.Repository
+	// itineraries is optional; nil disables per-day itineraries.
+	itineraries itineraryrepo.Repository
+	// attendance is optional; nil disables day-of check-in.
+	attendance attendancerepo.Repository
+	// waivers is optional; nil disables waiver checks on YES RSVPs.
+	waivers waiverrepo.Repository
+	// comments is optional; nil disables trip discussion threads.
+	comments commentrepo.Repository
+	// events is optional; nil drops domain events.
+	events events.Publisher
+	// announcements and notifier are optional; both are needed for organizer announcements.
+	announcements announcementrepo.Repository
+	notifier      notifier.Notifier
+	// backgroundAnnouncements leaves EVERYONE announcements to DeliverPendingAnnouncements.
+	backgroundAnnouncements bool
+
+	clk clockport.Clock
+
+	newTripID         func() domain.TripID
+	newRideRequestID  func() domain.RideRequestID
+	newTemplateID     func() domain.TripTemplateID
+	newSeriesID       func() domain.TripSeriesID
+	newArtifactID     func() string
+	newCommentID      func() domain.TripCommentID
+	newAnnouncementID func() domain.TripAnnouncementID
+
+	// difficultyScale is the top of the club's difficulty rating scale.
+	difficultyScale int
+	// seriesHorizonDays is how far ahead series occurrences are generated.
+	seriesHorizonDays int
 }
 
 func NewService(tripsRepo triprepo.Repository, membersRepo memberrepo.Repository, rsvpsRepo rsvprepo.Repository) *Service {
@@ -29,12 +81,105 @@ func NewService(tripsRepo triprepo.Repository, membersRepo memberrepo.Repository
 		trips:   tripsRepo,
 		members: membersRepo,
 		rsvps:   rsvpsRepo,
+		clk:     platformclock.NewSystemClock(),
 		newTripID: func() domain.TripID {
 			return domain.TripID(uuid.NewString())
 		},
+		newRideRequestID: func() domain.RideRequestID {
+			return domain.RideRequestID(uuid.NewString())
+		},
+		newTemplateID: func() domain.TripTemplateID {
+			return domain.TripTemplateID(uuid.NewString())
+		},
+		newSeriesID: func() domain.TripSeriesID {
+			return domain.TripSeriesID(uuid.NewString())
+		},
+		newCommentID: func() domain.TripCommentID {
+			return domain.TripCommentID(uuid.NewString())
+		},
+		newAnnouncementID: func() domain.TripAnnouncementID {
+			return domain.TripAnnouncementID(uuid.NewString())
+		},
+		newArtifactID:     uuid.NewString,
+		difficultyScale:   defaultDifficultyScale,
+		seriesHorizonDays: defaultSeriesHorizonDays,
 	}
 }
 
+// Options configures optional trip features.
+type Options struct {
+	// RideShares, when set, enables the ride-share board; accepted riders then count toward
+	// trip headcount.
+	RideShares ridesharerepo.Repository
+
+	// Templates, when set, enables named trip templates (SaveTripTemplate and
+	// CreateTripDraftInput.TemplateID).
+	Templates triptemplaterepo.Repository
+
+	// Series, when set, enables recurring trip series.
+	Series tripseriesrepo.Repository
+	// SeriesHorizonDays is how many days ahead series occurrences are generated. Zero means
+	// the default of 60.
+	SeriesHorizonDays int
+
+	// Itineraries, when set, enables per-day trip itineraries; they are included in trip
+	// details and announcement copy.
+	Itineraries itineraryrepo.Repository
+
+	// Attendance, when set, enables day-of check-in; RSVP summaries then count check-ins.
+	Attendance attendancerepo.Repository
+
+	// Waivers, when set, requires members to acknowledge the current version of club-wide and
+	// trip-required waivers before a YES RSVP.
+	Waivers waiverrepo.Repository
+
+	// Comments, when set, enables per-trip discussion threads.
+	Comments commentrepo.Repository
+	// Events, when set, receives domain events (e.g. new comments) for notification fan-out.
+	Events events.Publisher
+
+	// Announcements and Notifier, when both set, enable organizer announcements: they are
+	// stored with the trip, delivered through Notifier and listed in trip details.
+	Announcements announcementrepo.Repository
+	Notifier      notifier.Notifier
+	// BackgroundAnnouncements, when set, leaves announcements to the whole club (EVERYONE)
+	// PENDING when posted; the caller must run DeliverPendingAnnouncements periodically to send
+	// them. Other audiences are always delivered while posting.
+	BackgroundAnnouncements bool
+
+	// DifficultyScale is the top of the club's difficulty rating scale (ratings run 1..N).
+	// Zero means the default of 5.
+	DifficultyScale int
+
+	// Clock, when set, replaces the system clock (RSVP deadlines, timestamps).
+	Clock clockport.Clock
+}
+
+func NewServiceWithOptions(tripsRepo triprepo.Repository, membersRepo memberrepo.Repository, rsvpsRepo rsvprepo.Repository, opts Options) *Service {
+	s := NewService(tripsRepo, membersRepo, rsvpsRepo)
+	s.rides = opts.RideShares
+	s.templates = opts.Templates
+	s.series = opts.Series
+	s.itineraries = opts.Itineraries
+	s.attendance = opts.Attendance
+	s.waivers = opts.Waivers
+	s.comments = opts.Comments
+	s.events = opts.Events
+	s.announcements = opts.Announcements
+	s.notifier = opts.Notifier
+	s.backgroundAnnouncements = opts.BackgroundAnnouncements
+	if opts.DifficultyScale > 0 {
+		s.difficultyScale = opts.DifficultyScale
+	}
+	if opts.SeriesHorizonDays > 0 {
+		s.seriesHorizonDays = opts.SeriesHorizonDays
+	}
+	if opts.Clock != nil {
+		s.clk = opts.Clock
+	}
+	return s
+}
+
 // SetNewTripIDForTest overrides trip ID generation for deterministic tests.
 // It should not be used in production code.
 func (s *Service) SetNewTripIDForTest(fn func() domain.TripID) {
@@ -43,13 +188,20 @@ func (s *Service) SetNewTripIDForTest(fn func() domain.TripID) {
 	}
 }
 
-func (s *Service) ListVisibleTripsForMember(ctx context.Context, _ domain.MemberID) ([]domain.TripSummary, error) {
+// ListVisibleTripsForMember lists published and canceled trips that match filter.
+func (s *Service) ListVisibleTripsForMember(ctx context.Context, _ domain.MemberID, filter TripListFilter) ([]domain.TripSummary, error) {
+	if err := validateListFilter(filter); err != nil {
+		return nil, err
+	}
 	ts, err := s.trips.ListPublishedAndCanceled(ctx)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]domain.TripSummary, 0, len(ts))
 	for _, t := range ts {
+		if !filter.matches(t.Difficulty) {
+			continue
+		}
 		out = append(out, toDomainSummary(t))
 	}
 	return out, nil
@@ -89,7 +241,17 @@ func (s *Service) GetTripDetails(ctx context.Context, caller domain.MemberID, tr
 	d := toDomainDetails(t)
 	d.Organizers = orgs
 	d.Artifacts = append([]domain.TripArtifact(nil), t.Artifacts...)
-	d.RSVPActionsEnabled = d.Status == domain.TripStatusPublished
+	d.RSVPActionsEnabled = d.Status == domain.TripStatusPublished && !s.rsvpClosed(t)
+	if s.itineraries != nil {
+		if d.Itinerary, err = s.tripItinerary(ctx, t.ID); err != nil {
+			return domain.TripDetails{}, err
+		}
+	}
+	if s.announcements != nil {
+		if d.Announcements, err = s.tripAnnouncements(ctx, t.ID); err != nil {
+			return domain.TripDetails{}, err
+		}
+	}
 
 	// RSVP fields:
 	// - available for PUBLISHED and CANCELED (UC-12/13)
@@ -116,25 +278,67 @@ func (s *Service) GetTripDetails(ctx context.Context, caller domain.MemberID, tr
 	return d, nil
 }
 
-// SetMyRSVP sets the caller's RSVP for a published trip.
+// SetMyRSVP sets the caller's RSVP for a published trip. Once the trip's RSVP deadline has
+// passed it fails with RSVP_CLOSED; organizers can still change the RSVP with SetMemberRSVP.
 // Implements UC-11.
-func (s *Service) SetMyRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, response domain.RSVPResponse) (domain.MyRSVP, error) {
+func (s *Service) SetMyRSVP(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in SetMyRSVPInput) (domain.MyRSVP, error) {
+	t, err := s.rsvpTrip(ctx, caller, tripID)
+	if err != nil {
+		return domain.MyRSVP{}, err
+	}
+	if s.rsvpClosed(t) {
+		return domain.MyRSVP{}, rsvpClosedError(t)
+	}
+	return s.setRSVP(ctx, t, caller, in, rsvpChange{by: caller})
+}
+
+// rsvpTrip loads a trip the caller can see and that accepts RSVPs.
+func (s *Service) rsvpTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID) (triprepo.Trip, error) {
 	t, err := s.trips.GetByID(ctx, tripID)
 	if err != nil {
 		if errors.Is(err, triprepo.ErrNotFound) {
-			return domain.MyRSVP{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+			return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
 		}
-		return domain.MyRSVP{}, err
+		return triprepo.Trip{}, err
 	}
 	if !isTripVisibleToCaller(t, caller) {
-		return domain.MyRSVP{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		return triprepo.Trip{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
 	}
 	if t.Status != triprepo.StatusPublished {
-		return domain.MyRSVP{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "rsvp is only allowed for published trips"}
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_NOT_PUBLISHED", Message: "rsvp is only allowed for published trips"}
 	}
 	if t.CapacityRigs == nil || *t.CapacityRigs < 1 {
-		return domain.MyRSVP{}, &Error{Status: 409, Code: "TRIP_MISSING_CAPACITY", Message: "published trip must have capacity to accept rsvps"}
+		return triprepo.Trip{}, &Error{Status: 409, Code: "TRIP_MISSING_CAPACITY", Message: "published trip must have capacity to accept rsvps"}
 	}
+	return t, nil
+}
+
+// rsvpClosed reports whether the trip's RSVP deadline has passed.
+func (s *Service) rsvpClosed(t triprepo.Trip) bool {
+	return t.RSVPDeadline != nil && !s.clk.Now().Before(*t.RSVPDeadline)
+}
+
+func rsvpClosedError(t triprepo.Trip) *Error {
+	return &Error{
+		Status:  409,
+		Code:    "RSVP_CLOSED",
+		Message: "rsvps for this trip are closed",
+		Details: map[string]any{"rsvpDeadline": t.RSVPDeadline.UTC().Format(time.RFC3339)},
+	}
+}
+
+// rsvpChange says who is changing an RSVP and on what terms.
+type rsvpChange struct {
+	by     domain.MemberID
+	reason *string
+	// bypassCapacity skips the rig and people capacity checks for a YES.
+	bypassCapacity bool
+}
+
+// setRSVP applies in as member's RSVP on the published trip t.
+func (s *Service) setRSVP(ctx context.Context, t triprepo.Trip, member domain.MemberID, in SetMyRSVPInput, change rsvpChange) (domain.MyRSVP, error) {
+	tripID := t.ID
+	response := in.Response
 
 	var target rsvprepo.Status
 	switch response {
@@ -149,7 +353,7 @@ func (s *Service) SetMyRSVP(ctx context.Context, caller domain.MemberID, tripID
 	}
 
 	// Load existing RSVP if present.
-	existing, err := s.rsvps.Get(ctx, tripID, caller)
+	existing, err := s.rsvps.Get(ctx, tripID, member)
 	hasExisting := true
 	if err != nil {
 		if errors.Is(err, rsvprepo.ErrNotFound) {
@@ -159,14 +363,56 @@ func (s *Service) SetMyRSVP(ctx context.Context, caller domain.MemberID, tripID
 		}
 	}
 
+	var vehicleID *domain.VehicleID
+	var unmet []domain.UnmetRequirement
+	passengers, names := 0, []string(nil)
+	if target == rsvprepo.StatusYes {
+		// Waivers are checked when a member commits to the trip; a new waiver version does not
+		// block changes to an existing YES.
+		if !hasExisting || existing.Status != rsvprepo.StatusYes {
+			if err := s.requireWaivers(ctx, tripID, member); err != nil {
+				return domain.MyRSVP{}, err
+			}
+		}
+
+		// A member riding in someone else's vehicle cannot also bring their own rig.
+		if req, ok, err := s.openRideRequestForRider(ctx, tripID, member); err != nil {
+			return domain.MyRSVP{}, err
+		} else if ok {
+			return domain.MyRSVP{}, &Error{Status: 409, Code: "RIDE_SHARE_CONFLICT", Message: "cancel your ride request before rsvping with your own rig", Details: map[string]any{"rideRequestId": string(req.ID)}}
+		}
+
+		var existingVehicle *domain.VehicleID
+		if hasExisting && existing.Status == rsvprepo.StatusYes {
+			existingVehicle = existing.VehicleID
+			passengers, names = existing.PassengerCount, existing.PassengerNames
+		}
+		vehicleID, err = s.resolveRSVPVehicle(ctx, member, in.VehicleID, existingVehicle)
+		if err != nil {
+			return domain.MyRSVP{}, err
+		}
+		if in.PassengerCount != nil || in.PassengerNames != nil {
+			passengers, names, err = validatePassengers(in.PassengerCount, in.PassengerNames)
+			if err != nil {
+				return domain.MyRSVP{}, err
+			}
+		}
+		if unmet, err = s.checkRSVPRequirements(ctx, member, t.Requirements, vehicleID); err != nil {
+			return domain.MyRSVP{}, err
+		}
+	}
+
 	// UC-11 A2: setting to the same value is an idempotent no-op (no state change).
-	if hasExisting && existing.Status == target {
-		return domain.MyRSVP{
-			TripID:    tripID,
-			MemberID:  caller,
-			Response:  domain.RSVPResponse(existing.Status),
-			UpdatedAt: existing.UpdatedAt,
-		}, nil
+	if hasExisting && existing.Status == target && sameVehicle(existing.VehicleID, vehicleID) &&
+		existing.PassengerCount == passengers && slices.Equal(existing.PassengerNames, names) {
+		my := myRSVPFromRecord(existing)
+		my.RequirementWarnings = unmet
+		return my, nil
+	}
+
+	// Strict trips turn requirement warnings into a hard stop.
+	if len(unmet) > 0 && t.Requirements.Strict {
+		return domain.MyRSVP{}, requirementsUnmetError(unmet)
 	}
 
 	// Compute current attendance from RSVP records to avoid drift.
@@ -191,34 +437,153 @@ func (s *Service) SetMyRSVP(ctx context.Context, caller domain.MemberID, tripID
 	if newAtt < 0 {
 		newAtt = 0
 	}
-	if target == rsvprepo.StatusYes && newAtt > *t.CapacityRigs {
+	if target == rsvprepo.StatusYes && !change.bypassCapacity && newAtt > *t.CapacityRigs {
 		return domain.MyRSVP{}, &Error{Status: 409, Code: "TRIP_AT_CAPACITY", Message: "trip is at capacity"}
 	}
 
+	// People capacity only blocks changes that add people; shrinking is always allowed.
+	if target == rsvprepo.StatusYes && !change.bypassCapacity && t.CapacityPeople != nil {
+		oldPeople := 0
+		if hasExisting && existing.Status == rsvprepo.StatusYes {
+			oldPeople = 1 + existing.PassengerCount
+		}
+		if newPeople := 1 + passengers; newPeople > oldPeople {
+			curPeople, err := s.countPeople(ctx, tripID)
+			if err != nil {
+				return domain.MyRSVP{}, err
+			}
+			if curPeople-oldPeople+newPeople > *t.CapacityPeople {
+				return domain.MyRSVP{}, &Error{
+					Status:  409,
+					Code:    "TRIP_AT_CAPACITY",
+					Message: "trip is at people capacity",
+					Details: map[string]any{"capacityPeople": *t.CapacityPeople, "attendingPeople": curPeople},
+				}
+			}
+		}
+	}
+
 	// Update trip attending rigs (stored on trip for summary projections).
 	tAtt := newAtt
 	t.AttendingRigs = &tAtt
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = s.clk.Now()
 	if err := s.trips.Save(ctx, t); err != nil {
 		return domain.MyRSVP{}, err
 	}
 
-	now := time.Now().UTC()
+	now := s.clk.Now()
 	rec := rsvprepo.RSVP{
-		TripID:    tripID,
-		MemberID:  caller,
-		Status:    target,
-		UpdatedAt: now,
+		TripID:           tripID,
+		MemberID:         member,
+		Status:           target,
+		VehicleID:        vehicleID,
+		PassengerCount:   passengers,
+		PassengerNames:   names,
+		UpdatedAt:        now,
+		ChangedBy:        change.by,
+		ChangeReason:     change.reason,
+		CapacityOverride: target == rsvprepo.StatusYes && change.bypassCapacity,
 	}
 	if err := s.rsvps.Upsert(ctx, rec); err != nil {
 		return domain.MyRSVP{}, err
 	}
-	return domain.MyRSVP{
-		TripID:    tripID,
-		MemberID:  caller,
-		Response:  response,
-		UpdatedAt: now,
-	}, nil
+	// A driver who is no longer attending cannot take riders.
+	if hasExisting && existing.Status == rsvprepo.StatusYes && target != rsvprepo.StatusYes && s.rides != nil {
+		if err := s.rides.WithdrawOffer(ctx, tripID, member, now); err != nil && !errors.Is(err, ridesharerepo.ErrOfferNotFound) {
+			return domain.MyRSVP{}, err
+		}
+	}
+	my := myRSVPFromRecord(rec)
+	my.RequirementWarnings = unmet
+	return my, nil
+}
+
+// resolveRSVPVehicle picks the vehicle for a YES: the requested one (which must be in the
+// caller's garage), else the one already on the RSVP, else the caller's default (if any).
+func (s *Service) resolveRSVPVehicle(ctx context.Context, caller domain.MemberID, requested *domain.VehicleID, existing *domain.VehicleID) (*domain.VehicleID, error) {
+	if requested != nil {
+		v, err := s.members.GetVehicle(ctx, caller, *requested)
+		if err != nil {
+			if errors.Is(err, memberrepo.ErrVehicleNotFound) || errors.Is(err, memberrepo.ErrNotFound) {
+				return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid vehicle", Details: map[string]any{"vehicleId": "must be one of your vehicles"}}
+			}
+			return nil, err
+		}
+		return &v.ID, nil
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	vs, err := s.members.ListVehicles(ctx, caller)
+	if err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, v := range vs {
+		if v.IsDefault {
+			id := v.ID
+			return &id, nil
+		}
+	}
+	return nil, nil
+}
+
+// validatePassengers normalizes the passengers on a YES: names are trimmed, a missing count
+// defaults to the number of names, there may not be more names than passengers, and there are
+// at most maxPassengerCount passengers.
+func validatePassengers(count *int, names []string) (int, []string, error) {
+	var out []string
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			return 0, nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid passengerNames", Details: map[string]any{"passengerNames": "must be non-empty"}}
+		}
+		if len([]rune(n)) > maxPassengerNameLen {
+			return 0, nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid passengerNames", Details: map[string]any{"passengerNames": "must be at most 100 characters each"}}
+		}
+		out = append(out, n)
+	}
+	n := len(out)
+	if count != nil {
+		n = *count
+	}
+	switch {
+	case n < 0:
+		return 0, nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid passengerCount", Details: map[string]any{"passengerCount": "must be >= 0"}}
+	case n > maxPassengerCount:
+		return 0, nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid passengerCount", Details: map[string]any{"passengerCount": fmt.Sprintf("must be <= %d", maxPassengerCount)}}
+	case len(out) > n:
+		return 0, nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid passengerNames", Details: map[string]any{"passengerNames": "must not list more names than passengerCount"}}
+	}
+	return n, out, nil
+}
+
+func sameVehicle(a, b *domain.VehicleID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func myRSVPFromRecord(rec rsvprepo.RSVP) domain.MyRSVP {
+	out := domain.MyRSVP{
+		TripID:    rec.TripID,
+		MemberID:  rec.MemberID,
+		Response:  domain.RSVPResponse(rec.Status),
+		UpdatedAt: rec.UpdatedAt,
+	}
+	if rec.VehicleID != nil {
+		v := *rec.VehicleID
+		out.VehicleID = &v
+	}
+	out.PassengerCount = rec.PassengerCount
+	out.PassengerNames = slices.Clone(rec.PassengerNames)
+	out.ChangedBy = rec.ChangedBy
+	out.ChangeReason = cloneStringPtr(rec.ChangeReason)
+	out.CapacityOverride = rec.CapacityOverride
+	return out
 }
 
 // GetMyRSVPForTrip returns the caller's RSVP for a trip.
@@ -244,12 +609,7 @@ func (s *Service) GetMyRSVPForTrip(ctx context.Context, caller domain.MemberID,
 		}
 		return domain.MyRSVP{}, err
 	}
-	return domain.MyRSVP{
-		TripID:    tripID,
-		MemberID:  caller,
-		Response:  domain.RSVPResponse(rec.Status),
-		UpdatedAt: rec.UpdatedAt,
-	}, nil
+	return myRSVPFromRecord(rec), nil
 }
 
 // GetTripRSVPSummary returns the RSVP summary for a trip.
@@ -275,45 +635,27 @@ func (s *Service) GetTripRSVPSummary(ctx context.Context, caller domain.MemberID
 	return sum, nil
 }
 
+// CreateTripDraft creates a PRIVATE draft owned by the caller. With TemplateID set, the draft
+// starts from the template's plan; the given name still wins over the template's trip name.
 func (s *Service) CreateTripDraft(ctx context.Context, caller domain.MemberID, in CreateTripDraftInput) (TripCreated, error) {
-	// Validate caller exists.
-	if _, err := s.members.GetByID(ctx, caller); err != nil {
-		if errors.Is(err, memberrepo.ErrNotFound) {
-			return TripCreated{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid caller", Details: map[string]any{"memberId": "caller does not exist"}}
-		}
-		return TripCreated{}, err
-	}
-
 	name := domain.NormalizeHumanName(in.Name)
 	if name == "" {
 		return TripCreated{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be non-empty"}}
 	}
 
-	now := time.Now().UTC()
-	id := s.newTripID()
-	t := triprepo.Trip{
-		ID:                 id,
-		Status:             triprepo.StatusDraft,
-		Name:               &name,
-		CreatorMemberID:    caller,
-		OrganizerMemberIDs: []domain.MemberID{caller},
-		DraftVisibility:    triprepo.DraftVisibilityPrivate,
-		CreatedAt:          now,
-		UpdatedAt:          now,
-	}
-	if err := s.trips.Create(ctx, t); err != nil {
-		if errors.Is(err, triprepo.ErrAlreadyExists) {
-			// Extremely unlikely (UUID collision); treat as conflict.
-			return TripCreated{}, &Error{Status: 409, Code: "TRIP_ID_CONFLICT", Message: "trip id conflict"}
+	var plan domain.TripPlan
+	if in.TemplateID != nil {
+		tmpl, err := s.tripTemplate(ctx, *in.TemplateID)
+		if ae := (*Error)(nil); errors.As(err, &ae) && ae.Code == "TRIP_TEMPLATE_NOT_FOUND" {
+			return TripCreated{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid templateId", Details: map[string]any{"templateId": "unknown trip template"}}
+		}
+		if err != nil {
+			return TripCreated{}, err
 		}
-		return TripCreated{}, err
+		plan = tmpl.Plan
 	}
-
-	return TripCreated{
-		ID:              id,
-		Status:          domain.TripStatusDraft,
-		DraftVisibility: domain.DraftVisibilityPrivate,
-	}, nil
+	plan.Name = &name
+	return s.createDraftFromPlan(ctx, caller, plan)
 }
 
 func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in UpdateTripInput) (domain.TripDetails, error) {
@@ -350,13 +692,32 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 		return domain.TripDetails{}, &Error{Status: 409, Code: "TRIP_INVALID_STATUS", Message: "invalid trip status"}
 	}
 
+	if err := s.applyTripUpdate(ctx, &t, in); err != nil {
+		return domain.TripDetails{}, err
+	}
+	if in.StartDate.IsSpecified() || in.EndDate.IsSpecified() {
+		if err := s.checkItineraryWithinDates(ctx, t); err != nil {
+			return domain.TripDetails{}, err
+		}
+	}
+
+	t.UpdatedAt = s.clk.Now()
+	if err := s.trips.Save(ctx, t); err != nil {
+		return domain.TripDetails{}, err
+	}
+
+	return s.tripDetailsForTrip(ctx, t)
+}
+
+// applyTripUpdate validates in and applies it to t. Authorization is the caller's job.
+func (s *Service) applyTripUpdate(ctx context.Context, t *triprepo.Trip, in UpdateTripInput) error {
 	if in.Name.IsSpecified() {
 		if in.Name.IsNull() {
-			return domain.TripDetails{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "cannot be null"}}
+			return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "cannot be null"}}
 		}
 		name := domain.NormalizeHumanName(in.Name.Value())
 		if name == "" {
-			return domain.TripDetails{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be non-empty"}}
+			return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be non-empty"}}
 		}
 		t.Name = &name
 	}
@@ -394,6 +755,14 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 			t.EndDate = &v
 		}
 	}
+	if in.RSVPDeadline.IsSpecified() {
+		if in.RSVPDeadline.IsNull() {
+			t.RSVPDeadline = nil
+		} else {
+			v := in.RSVPDeadline.Value().UTC()
+			t.RSVPDeadline = &v
+		}
+	}
 
 	if in.CapacityRigs.IsSpecified() {
 		if in.CapacityRigs.IsNull() {
@@ -401,7 +770,7 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 		} else {
 			v := in.CapacityRigs.Value()
 			if v < 1 {
-				return domain.TripDetails{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid capacityRigs", Details: map[string]any{"capacityRigs": "must be >= 1"}}
+				return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid capacityRigs", Details: map[string]any{"capacityRigs": "must be >= 1"}}
 			}
 			// Published invariant: cannot reduce below attending rigs (UC-07).
 			if t.Status == triprepo.StatusPublished {
@@ -410,13 +779,58 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 					curAtt = *t.AttendingRigs
 				}
 				if v < curAtt {
-					return domain.TripDetails{}, &Error{Status: 409, Code: "CAPACITY_BELOW_ATTENDANCE", Message: "capacity cannot be reduced below current attendance", Details: map[string]any{"attendingRigs": curAtt}}
+					return &Error{Status: 409, Code: "CAPACITY_BELOW_ATTENDANCE", Message: "capacity cannot be reduced below current attendance", Details: map[string]any{"attendingRigs": curAtt}}
 				}
 			}
 			t.CapacityRigs = &v
 		}
 	}
 
+	if in.CapacityPeople.IsSpecified() {
+		if in.CapacityPeople.IsNull() {
+			t.CapacityPeople = nil
+		} else {
+			v := in.CapacityPeople.Value()
+			if v < 1 {
+				return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid capacityPeople", Details: map[string]any{"capacityPeople": "must be >= 1"}}
+			}
+			if t.Status == triprepo.StatusPublished {
+				curPeople, err := s.countPeople(ctx, t.ID)
+				if err != nil {
+					return err
+				}
+				if v < curPeople {
+					return &Error{Status: 409, Code: "CAPACITY_BELOW_ATTENDANCE", Message: "capacity cannot be reduced below current attendance", Details: map[string]any{"attendingPeople": curPeople}}
+				}
+			}
+			t.CapacityPeople = &v
+		}
+	}
+
+	if in.Requirements.IsSpecified() {
+		if in.Requirements.IsNull() {
+			t.Requirements = domain.TripRequirements{}
+		} else {
+			reqs, err := validateTripRequirements(in.Requirements.Value())
+			if err != nil {
+				return err
+			}
+			t.Requirements = reqs
+		}
+	}
+
+	if in.Difficulty.IsSpecified() {
+		if in.Difficulty.IsNull() {
+			t.Difficulty = nil
+		} else {
+			d, err := s.validateDifficulty(in.Difficulty.Value())
+			if err != nil {
+				return err
+			}
+			t.Difficulty = &d
+		}
+	}
+
 	if in.MeetingLocation.IsSpecified() {
 		if in.MeetingLocation.IsNull() {
 			t.MeetingLocation = nil
@@ -433,7 +847,7 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 			ids := in.ArtifactIDs.Value()
 			reordered, err := reorderArtifactsByID(t.Artifacts, ids)
 			if err != nil {
-				return domain.TripDetails{}, err
+				return err
 			}
 			t.Artifacts = reordered
 		}
@@ -441,15 +855,9 @@ func (s *Service) UpdateTrip(ctx context.Context, caller domain.MemberID, tripID
 
 	// Basic date sanity (if both set).
 	if t.StartDate != nil && t.EndDate != nil && t.EndDate.Before(*t.StartDate) {
-		return domain.TripDetails{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid date range", Details: map[string]any{"endDate": "must be on or after startDate"}}
-	}
-
-	t.UpdatedAt = time.Now().UTC()
-	if err := s.trips.Save(ctx, t); err != nil {
-		return domain.TripDetails{}, err
+		return &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid date range", Details: map[string]any{"endDate": "must be on or after startDate"}}
 	}
-
-	return s.tripDetailsForTrip(ctx, t)
+	return nil
 }
 
 func (s *Service) SetTripDraftVisibility(ctx context.Context, caller domain.MemberID, tripID domain.TripID, dv domain.DraftVisibility) (domain.TripDetails, error) {
@@ -478,7 +886,7 @@ func (s *Service) SetTripDraftVisibility(ctx context.Context, caller domain.Memb
 	default:
 		return domain.TripDetails{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid draftVisibility", Details: map[string]any{"draftVisibility": "must be PRIVATE or PUBLIC"}}
 	}
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = s.clk.Now()
 	if err := s.trips.Save(ctx, t); err != nil {
 		return domain.TripDetails{}, err
 	}
@@ -509,7 +917,7 @@ func (s *Service) AddTripOrganizer(ctx context.Context, caller domain.MemberID,
 
 	if !isOrganizerIDInSlice(t.OrganizerMemberIDs, target) {
 		t.OrganizerMemberIDs = append(t.OrganizerMemberIDs, target)
-		t.UpdatedAt = time.Now().UTC()
+		t.UpdatedAt = s.clk.Now()
 		if err := s.trips.Save(ctx, t); err != nil {
 			return domain.TripDetails{}, err
 		}
@@ -551,7 +959,7 @@ func (s *Service) RemoveTripOrganizer(ctx context.Context, caller domain.MemberI
 		return domain.TripDetails{}, &Error{Status: 409, Code: "LAST_ORGANIZER", Message: "cannot remove the last organizer"}
 	}
 	t.OrganizerMemberIDs = out
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = s.clk.Now()
 	if err := s.trips.Save(ctx, t); err != nil {
 		return domain.TripDetails{}, err
 	}
@@ -579,7 +987,7 @@ func (s *Service) CancelTrip(ctx context.Context, caller domain.MemberID, tripID
 		return domain.TripDetails{}, &Error{Status: 409, Code: "TRIP_INVALID_STATUS", Message: "invalid trip status"}
 	}
 	t.Status = triprepo.StatusCanceled
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = s.clk.Now()
 	if err := s.trips.Save(ctx, t); err != nil {
 		return domain.TripDetails{}, err
 	}
@@ -636,7 +1044,7 @@ func (s *Service) PublishTrip(ctx context.Context, caller domain.MemberID, tripI
 		z := 0
 		t.AttendingRigs = &z
 	}
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = s.clk.Now()
 	if err := s.trips.Save(ctx, t); err != nil {
 		return domain.TripDetails{}, "", err
 	}
@@ -730,7 +1138,12 @@ func (s *Service) tripDetailsForTrip(ctx context.Context, t triprepo.Trip) (doma
 	d := toDomainDetails(t)
 	d.Organizers = orgs
 	d.Artifacts = append([]domain.TripArtifact(nil), t.Artifacts...)
-	d.RSVPActionsEnabled = d.Status == domain.TripStatusPublished
+	d.RSVPActionsEnabled = d.Status == domain.TripStatusPublished && !s.rsvpClosed(t)
+	if s.itineraries != nil {
+		if d.Itinerary, err = s.tripItinerary(ctx, t.ID); err != nil {
+			return domain.TripDetails{}, err
+		}
+	}
 
 	switch d.Status {
 	case domain.TripStatusPublished, domain.TripStatusCanceled:
@@ -753,12 +1166,7 @@ func (s *Service) myRSVPForTrip(ctx context.Context, t triprepo.Trip, caller dom
 	if err != nil {
 		return domain.MyRSVP{}, err
 	}
-	return domain.MyRSVP{
-		TripID:    t.ID,
-		MemberID:  caller,
-		Response:  domain.RSVPResponse(rec.Status),
-		UpdatedAt: rec.UpdatedAt,
-	}, nil
+	return myRSVPFromRecord(rec), nil
 }
 
 func (s *Service) tripRSVPSummaryForTrip(ctx context.Context, t triprepo.Trip) (domain.TripRSVPSummary, error) {
@@ -769,10 +1177,14 @@ func (s *Service) tripRSVPSummaryForTrip(ctx context.Context, t triprepo.Trip) (
 
 	yesIDs := make([]domain.MemberID, 0)
 	noIDs := make([]domain.MemberID, 0)
+	yesByMember := make(map[domain.MemberID]rsvprepo.RSVP)
+	people := 0
 	for _, r := range recs {
 		switch r.Status {
 		case rsvprepo.StatusYes:
 			yesIDs = append(yesIDs, r.MemberID)
+			yesByMember[r.MemberID] = r
+			people += 1 + r.PassengerCount
 		case rsvprepo.StatusNo:
 			noIDs = append(noIDs, r.MemberID)
 		default:
@@ -780,20 +1192,83 @@ func (s *Service) tripRSVPSummaryForTrip(ctx context.Context, t triprepo.Trip) (
 		}
 	}
 
+	// Accepted ride-share riders attend without a rig.
+	ridersByDriver := make(map[domain.MemberID][]domain.MemberID)
+	riderIDs := make([]domain.MemberID, 0)
+	if s.rides != nil {
+		reqs, err := s.rides.ListRequestsByTrip(ctx, t.ID)
+		if err != nil {
+			return domain.TripRSVPSummary{}, err
+		}
+		for _, rr := range reqs {
+			if rr.Status == ridesharerepo.StatusAccepted {
+				ridersByDriver[rr.DriverMemberID] = append(ridersByDriver[rr.DriverMemberID], rr.RiderMemberID)
+				riderIDs = append(riderIDs, rr.RiderMemberID)
+			}
+		}
+		noIDs = slices.DeleteFunc(noIDs, func(id domain.MemberID) bool { return slices.Contains(riderIDs, id) })
+	}
+	people += len(riderIDs)
+
 	yesMembers, err := s.loadMemberSummariesSorted(ctx, yesIDs)
 	if err != nil {
 		return domain.TripRSVPSummary{}, err
 	}
+	attending, err := s.loadMemberSummariesSorted(ctx, append(slices.Clone(yesIDs), riderIDs...))
+	if err != nil {
+		return domain.TripRSVPSummary{}, err
+	}
 	noMembers, err := s.loadMemberSummariesSorted(ctx, noIDs)
 	if err != nil {
 		return domain.TripRSVPSummary{}, err
 	}
 
+	rigs := make([]domain.AttendeeRig, 0, len(yesMembers))
+	for _, m := range yesMembers {
+		r := yesByMember[m.ID]
+		riders, err := s.loadMemberSummariesSorted(ctx, ridersByDriver[m.ID])
+		if err != nil {
+			return domain.TripRSVPSummary{}, err
+		}
+		rig := domain.AttendeeRig{
+			Member:         m,
+			PassengerCount: r.PassengerCount,
+			PassengerNames: slices.Clone(r.PassengerNames),
+			Riders:         riders,
+		}
+		if r.VehicleID != nil {
+			v, err := s.members.GetVehicle(ctx, m.ID, *r.VehicleID)
+			switch {
+			case err == nil:
+				rig.Vehicle = &v
+			case !errors.Is(err, memberrepo.ErrVehicleNotFound):
+				return domain.TripRSVPSummary{}, err
+			}
+		}
+		rigs = append(rigs, rig)
+	}
+
+	var attendance *domain.AttendanceSummary
+	if s.attendance != nil {
+		recs, err := s.attendance.ListByTrip(ctx, t.ID)
+		if err != nil {
+			return domain.TripRSVPSummary{}, err
+		}
+		if len(recs) > 0 {
+			sum := summarizeAttendance(recs, append(slices.Clone(yesIDs), riderIDs...))
+			attendance = &sum
+		}
+	}
+
 	return domain.TripRSVPSummary{
 		CapacityRigs:        cloneIntPtr(t.CapacityRigs),
+		CapacityPeople:      cloneIntPtr(t.CapacityPeople),
 		AttendingRigs:       len(yesMembers),
-		AttendingMembers:    yesMembers,
+		AttendingPeople:     people,
+		AttendingMembers:    attending,
 		NotAttendingMembers: noMembers,
+		AttendeeRigs:        rigs,
+		Attendance:          attendance,
 	}, nil
 }
 
@@ -914,8 +1389,9 @@ func requiredPublishFieldsMissing(t triprepo.Trip) []string {
 	if t.CapacityRigs == nil || *t.CapacityRigs < 1 {
 		missing = append(missing, "capacityRigs")
 	}
-	if !hasText(t.DifficultyText) {
-		missing = append(missing, "difficultyText")
+	// The structured rating is required; DifficultyText is optional notes.
+	if t.Difficulty == nil {
+		missing = append(missing, "difficulty")
 	}
 	if t.MeetingLocation == nil || strings.TrimSpace(t.MeetingLocation.Label) == "" {
 		missing = append(missing, "meetingLocation")
@@ -946,6 +1422,9 @@ func announcementCopyFromTrip(t domain.TripDetails) string {
 	capLine := ""
 	if t.CapacityRigs != nil {
 		capLine = fmt.Sprintf("Capacity: %d rigs", *t.CapacityRigs)
+		if t.CapacityPeople != nil {
+			capLine = fmt.Sprintf("%s, %d people", capLine, *t.CapacityPeople)
+		}
 	}
 	locLine := ""
 	if t.MeetingLocation != nil && strings.TrimSpace(t.MeetingLocation.Label) != "" {
@@ -972,10 +1451,54 @@ func announcementCopyFromTrip(t domain.TripDetails) string {
 	if desc != "" {
 		lines = append(lines, "", desc)
 	}
+	if len(t.Itinerary) > 0 {
+		lines = append(lines, "", "Itinerary:")
+		lines = append(lines, itineraryCopyLines(t.Itinerary)...)
+	}
 	lines = append(lines, "", "RSVP in the app once you’re ready.")
 	return strings.Join(lines, "\n")
 }
 
+// itineraryCopyLines renders each day as a dated heading followed by its stops and route
+// segments, in travel order.
+func itineraryCopyLines(days []domain.ItineraryDay) []string {
+	stopLabels := map[domain.ItineraryStopType]string{
+		domain.ItineraryStopMeetingPoint: "Meet",
+		domain.ItineraryStopCampsite:     "Camp",
+		domain.ItineraryStopFuel:         "Fuel",
+		domain.ItineraryStopWaypoint:     "Stop",
+	}
+	var lines []string
+	for _, d := range days {
+		heading := d.Date.UTC().Format("Mon 2006-01-02")
+		if d.Title != nil {
+			heading = fmt.Sprintf("%s: %s", heading, *d.Title)
+		}
+		lines = append(lines, heading)
+		for _, st := range d.Stops {
+			line := fmt.Sprintf("  %s: %s", stopLabels[st.Type], st.Location.Label)
+			if st.Time != nil {
+				line = fmt.Sprintf("%s at %s", line, *st.Time)
+			}
+			if st.Location.Latitude != nil && st.Location.Longitude != nil {
+				line = fmt.Sprintf("%s (%.5f, %.5f)", line, *st.Location.Latitude, *st.Location.Longitude)
+			}
+			lines = append(lines, line)
+		}
+		for _, seg := range d.Segments {
+			line := fmt.Sprintf("  Route: %s", seg.Name)
+			if seg.DistanceMiles != nil {
+				line = fmt.Sprintf("%s (%s mi)", line, strconv.FormatFloat(*seg.DistanceMiles, 'f', -1, 64))
+			}
+			lines = append(lines, line)
+		}
+		if d.Notes != nil {
+			lines = append(lines, "  "+*d.Notes)
+		}
+	}
+	return lines
+}
+
 func toDomainSummary(t triprepo.Trip) domain.TripSummary {
 	out := domain.TripSummary{
 		ID:     t.ID,
@@ -985,7 +1508,10 @@ func toDomainSummary(t triprepo.Trip) domain.TripSummary {
 		StartDate: cloneTimePtr(t.StartDate),
 		EndDate:   cloneTimePtr(t.EndDate),
 
-		CapacityRigs: cloneIntPtr(t.CapacityRigs),
+		CapacityRigs:   cloneIntPtr(t.CapacityRigs),
+		CapacityPeople: cloneIntPtr(t.CapacityPeople),
+
+		Difficulty: cloneDifficulty(t.Difficulty),
 	}
 
 	// Attending rigs is present only for published trips per OpenAPI schema.
@@ -1012,6 +1538,8 @@ func toDomainDetails(t triprepo.Trip) domain.TripDetails {
 		MeetingLocation:             cloneLocationPtr(t.MeetingLocation),
 		CommsRequirementsText:       cloneStringPtr(t.CommsRequirementsText),
 		RecommendedRequirementsText: cloneStringPtr(t.RecommendedRequirementsText),
+		Requirements:                cloneRequirements(t.Requirements),
+		RSVPDeadline:                cloneTimePtr(t.RSVPDeadline),
 
 		Organizers: []domain.MemberSummary{},
 		Artifacts:  []domain.TripArtifact{},
@@ -3,9 +3,11 @@ package trips_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
 	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
 	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
 	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
@@ -147,6 +149,11 @@ func TestService_PublishTrip_RequiresPublicDraftAndRequiredFields(t *testing.T)
 	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "TRIP_NOT_READY_TO_PUBLISH" {
 		t.Fatalf("err=%v", err)
 	}
+	// The structured difficulty is required; the free-text notes are not.
+	missing, _ := ae.Details["missing"].([]string)
+	if !slices.Contains(missing, "difficulty") || slices.Contains(missing, "difficultyText") {
+		t.Fatalf("missing=%v, want difficulty but not difficultyText", missing)
+	}
 }
 
 func TestService_CancelTrip_IdempotentAndLocksFurtherUpdates(t *testing.T) {
@@ -276,7 +283,7 @@ func TestService_RSVP_PublishedOnly_CapacityAndIdempotency(t *testing.T) {
 	})
 
 	// First YES should succeed and consume capacity.
-	my1, err := svc.SetMyRSVP(ctx, "m1", "tp", domain.RSVPResponseYes)
+	my1, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
 	if err != nil {
 		t.Fatalf("SetMyRSVP(YES): %v", err)
 	}
@@ -285,7 +292,7 @@ func TestService_RSVP_PublishedOnly_CapacityAndIdempotency(t *testing.T) {
 	}
 
 	// Second YES should fail at capacity.
-	_, err = svc.SetMyRSVP(ctx, "m2", "tp", domain.RSVPResponseYes)
+	_, err = svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -295,19 +302,19 @@ func TestService_RSVP_PublishedOnly_CapacityAndIdempotency(t *testing.T) {
 	}
 
 	// Changing from YES -> NO releases capacity.
-	_, err = svc.SetMyRSVP(ctx, "m1", "tp", domain.RSVPResponseNo)
+	_, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo})
 	if err != nil {
 		t.Fatalf("SetMyRSVP(NO): %v", err)
 	}
 	// Now m2 can RSVP YES.
-	_, err = svc.SetMyRSVP(ctx, "m2", "tp", domain.RSVPResponseYes)
+	_, err = svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
 	if err != nil {
 		t.Fatalf("SetMyRSVP(m2 YES): %v", err)
 	}
 
 	// Idempotent no-op (same value) should preserve UpdatedAt.
 	existing, _ := rsvpsRepo.Get(ctx, "tp", "m2")
-	my2, err := svc.SetMyRSVP(ctx, "m2", "tp", domain.RSVPResponseYes)
+	my2, err := svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
 	if err != nil {
 		t.Fatalf("SetMyRSVP(idempotent): %v", err)
 	}
@@ -316,6 +323,105 @@ func TestService_RSVP_PublishedOnly_CapacityAndIdempotency(t *testing.T) {
 	}
 }
 
+func TestService_RSVP_PassengersAndPeopleCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	provisionMember(t, membersRepo, "m1")
+	provisionMember(t, membersRepo, "m2")
+
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+
+	name := "Trip"
+	now := time.Unix(650, 0).UTC()
+	rigs := 5
+	att0 := 0
+	_ = tripsRepo.Create(ctx, porttriprepo.Trip{
+		ID:                 "tp",
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		CapacityRigs:       &rigs,
+		AttendingRigs:      &att0,
+		CreatorMemberID:    "m1",
+		OrganizerMemberIDs: []domain.MemberID{"m1"},
+		DraftVisibility:    porttriprepo.DraftVisibilityPublic,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+	if _, err := svc.UpdateTrip(ctx, "m1", "tp", trips.UpdateTripInput{CapacityPeople: trips.Some(4)}); err != nil {
+		t.Fatalf("UpdateTrip(capacityPeople): %v", err)
+	}
+
+	two := 2
+	my, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &two, PassengerNames: []string{" Sam "}})
+	if err != nil {
+		t.Fatalf("SetMyRSVP(m1 +2): %v", err)
+	}
+	if my.PassengerCount != 2 || len(my.PassengerNames) != 1 || my.PassengerNames[0] != "Sam" {
+		t.Fatalf("my=%+v", my)
+	}
+
+	// More names than passengers is rejected.
+	one := 1
+	_, err = svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &one, PassengerNames: []string{"A", "B"}})
+	var ae *trips.Error
+	if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != "VALIDATION_ERROR" {
+		t.Fatalf("err=%v, want 422 VALIDATION_ERROR", err)
+	}
+
+	// 3 of 4 seats taken: m2 plus one passenger does not fit, m2 alone does.
+	_, err = svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &one})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "TRIP_AT_CAPACITY" || ae.Details["attendingPeople"] != 3 {
+		t.Fatalf("err=%v, want 409 TRIP_AT_CAPACITY", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m2", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP(m2 alone): %v", err)
+	}
+
+	// A plain YES keeps the passengers already on the RSVP.
+	my, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if err != nil || my.PassengerCount != 2 {
+		t.Fatalf("SetMyRSVP(m1 keep) = %+v err=%v", my, err)
+	}
+
+	sum, err := svc.GetTripRSVPSummary(ctx, "m1", "tp")
+	if err != nil {
+		t.Fatalf("GetTripRSVPSummary: %v", err)
+	}
+	if sum.AttendingRigs != 2 || sum.AttendingPeople != 4 || sum.CapacityPeople == nil || *sum.CapacityPeople != 4 {
+		t.Fatalf("sum=%+v", sum)
+	}
+
+	// The people cap cannot drop below the current headcount.
+	_, err = svc.UpdateTrip(ctx, "m1", "tp", trips.UpdateTripInput{CapacityPeople: trips.Some(3)})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "CAPACITY_BELOW_ATTENDANCE" {
+		t.Fatalf("err=%v, want 409 CAPACITY_BELOW_ATTENDANCE", err)
+	}
+
+	// Declining clears the passengers.
+	my, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo})
+	if err != nil || my.PassengerCount != 0 || my.PassengerNames != nil {
+		t.Fatalf("SetMyRSVP(m1 NO) = %+v err=%v", my, err)
+	}
+
+	// Without a people cap, passengers are still bounded per rig.
+	if _, err := svc.UpdateTrip(ctx, "m1", "tp", trips.UpdateTripInput{CapacityPeople: trips.Null[int]()}); err != nil {
+		t.Fatalf("UpdateTrip(clear capacityPeople): %v", err)
+	}
+	tooMany := 16
+	_, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &tooMany})
+	if !errors.As(err, &ae) || ae.Status != 422 || ae.Code != "VALIDATION_ERROR" || ae.Details["passengerCount"] != "must be <= 15" {
+		t.Fatalf("err=%v, want 422 VALIDATION_ERROR on passengerCount", err)
+	}
+	most := 15
+	if my, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes, PassengerCount: &most}); err != nil || my.PassengerCount != 15 {
+		t.Fatalf("SetMyRSVP(m1 +15) = %+v err=%v", my, err)
+	}
+}
+
 func TestService_RSVP_Summary_SortsAndOmitsUnset(t *testing.T) {
 	t.Parallel()
 
@@ -366,3 +472,62 @@ func TestService_RSVP_Summary_SortsAndOmitsUnset(t *testing.T) {
 		t.Fatalf("NotAttendingMembers=%v", sum.NotAttendingMembers)
 	}
 }
+
+func TestService_RSVP_DeadlineClosesSelfServiceButNotOrganizers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m1")
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+
+	clk := memclock.NewManualClock(time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC))
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Clock: clk})
+
+	deadline := time.Date(2026, 4, 27, 0, 0, 0, 0, time.UTC)
+	d, err := svc.UpdateTrip(ctx, "org", "tp", trips.UpdateTripInput{RSVPDeadline: trips.Some(deadline)})
+	if err != nil {
+		t.Fatalf("UpdateTrip(rsvpDeadline): %v", err)
+	}
+	if d.RSVPDeadline == nil || !d.RSVPDeadline.Equal(deadline) || !d.RSVPActionsEnabled {
+		t.Fatalf("details deadline=%v actions=%v", d.RSVPDeadline, d.RSVPActionsEnabled)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP before deadline: %v", err)
+	}
+
+	// At the deadline the roster locks for members.
+	clk.Set(deadline)
+	var ae *trips.Error
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "RSVP_CLOSED" {
+		t.Fatalf("SetMyRSVP after deadline err=%v, want 409 RSVP_CLOSED", err)
+	}
+	if d, err := svc.GetTripDetails(ctx, "m1", "tp"); err != nil || d.RSVPActionsEnabled {
+		t.Fatalf("GetTripDetails actions=%v err=%v, want disabled", d.RSVPActionsEnabled, err)
+	}
+
+	// Organizers can still change the roster on a member's behalf; members cannot.
+	if _, err := svc.SetMemberRSVP(ctx, "m1", "tp", "org", trips.MemberRSVPInput{SetMyRSVPInput: trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}, Reason: "staff"}); !errors.As(err, &ae) || ae.Status != 403 {
+		t.Fatalf("SetMemberRSVP by member err=%v, want 403", err)
+	}
+	if _, err := svc.SetMemberRSVP(ctx, "org", "tp", "ghost", trips.MemberRSVPInput{SetMyRSVPInput: trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}, Reason: "staff"}); !errors.As(err, &ae) || ae.Code != "MEMBER_NOT_FOUND" {
+		t.Fatalf("SetMemberRSVP unknown member err=%v, want MEMBER_NOT_FOUND", err)
+	}
+	my, err := svc.SetMemberRSVP(ctx, "org", "tp", "m1", trips.MemberRSVPInput{SetMyRSVPInput: trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}, Reason: "texted the leader"})
+	if err != nil {
+		t.Fatalf("SetMemberRSVP: %v", err)
+	}
+	if my.MemberID != "m1" || my.Response != domain.RSVPResponseNo || !my.UpdatedAt.Equal(deadline) {
+		t.Fatalf("my=%+v", my)
+	}
+
+	// Clearing the deadline reopens RSVPs.
+	if _, err := svc.UpdateTrip(ctx, "org", "tp", trips.UpdateTripInput{RSVPDeadline: trips.Null[time.Time]()}); err != nil {
+		t.Fatalf("UpdateTrip(clear rsvpDeadline): %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP after clearing deadline: %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/memberrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triptemplaterepo"
+)
+
+// maxTemplateNameLen bounds trip template names (counted in runes).
+const maxTemplateNameLen = 100
+
+var errTemplatesDisabled = errors.New("trip templates are not configured")
+
+// CloneTrip creates a new PRIVATE draft owned by the caller from any trip the caller can see.
+// The planning fields and artifacts are copied; dates, organizers, RSVPs and status are not.
+func (s *Service) CloneTrip(ctx context.Context, caller domain.MemberID, sourceID domain.TripID) (TripCreated, error) {
+	src, err := s.trips.GetByID(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return TripCreated{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return TripCreated{}, err
+	}
+	if !isTripVisibleToCaller(src, caller) {
+		return TripCreated{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return s.createDraftFromPlan(ctx, caller, planFromTrip(src))
+}
+
+// SaveTripTemplate saves the planning fields and artifacts of a trip the caller can see as a
+// named, club-wide template.
+func (s *Service) SaveTripTemplate(ctx context.Context, caller domain.MemberID, tripID domain.TripID, in SaveTripTemplateInput) (domain.TripTemplate, error) {
+	if s.templates == nil {
+		return domain.TripTemplate{}, errTemplatesDisabled
+	}
+	name := domain.NormalizeHumanName(in.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxTemplateNameLen {
+		return domain.TripTemplate{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid name", Details: map[string]any{"name": "must be 1-100 characters"}}
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return domain.TripTemplate{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return domain.TripTemplate{}, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return domain.TripTemplate{}, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+
+	now := s.clk.Now()
+	tmpl := domain.TripTemplate{
+		ID:              s.newTemplateID(),
+		Name:            name,
+		CreatedByMember: caller,
+		Plan:            planFromTrip(t),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.templates.Create(ctx, tmpl); err != nil {
+		if errors.Is(err, triptemplaterepo.ErrNameTaken) {
+			return domain.TripTemplate{}, &Error{Status: 409, Code: "TRIP_TEMPLATE_NAME_TAKEN", Message: "a trip template with this name already exists"}
+		}
+		return domain.TripTemplate{}, err
+	}
+	return tmpl, nil
+}
+
+// ListTripTemplates lists the club's trip templates by name.
+func (s *Service) ListTripTemplates(ctx context.Context, _ domain.MemberID) ([]domain.TripTemplate, error) {
+	if s.templates == nil {
+		return nil, errTemplatesDisabled
+	}
+	return s.templates.List(ctx)
+}
+
+// DeleteTripTemplate removes a template. Only the member who saved it may delete it; drafts
+// already created from it are unaffected.
+func (s *Service) DeleteTripTemplate(ctx context.Context, caller domain.MemberID, id domain.TripTemplateID) error {
+	tmpl, err := s.tripTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tmpl.CreatedByMember != caller {
+		return &Error{Status: 403, Code: "FORBIDDEN", Message: "only the member who saved a template can delete it"}
+	}
+	if err := s.templates.Delete(ctx, id); err != nil {
+		if errors.Is(err, triptemplaterepo.ErrNotFound) {
+			return &Error{Status: 404, Code: "TRIP_TEMPLATE_NOT_FOUND", Message: "trip template not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) tripTemplate(ctx context.Context, id domain.TripTemplateID) (domain.TripTemplate, error) {
+	if s.templates == nil {
+		return domain.TripTemplate{}, errTemplatesDisabled
+	}
+	tmpl, err := s.templates.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, triptemplaterepo.ErrNotFound) {
+			return domain.TripTemplate{}, &Error{Status: 404, Code: "TRIP_TEMPLATE_NOT_FOUND", Message: "trip template not found"}
+		}
+		return domain.TripTemplate{}, err
+	}
+	return tmpl, nil
+}
+
+// createDraftFromPlan creates a PRIVATE draft owned by caller with the plan's fields.
+func (s *Service) createDraftFromPlan(ctx context.Context, caller domain.MemberID, p domain.TripPlan) (TripCreated, error) {
+	if _, err := s.members.GetByID(ctx, caller); err != nil {
+		if errors.Is(err, memberrepo.ErrNotFound) {
+			return TripCreated{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid caller", Details: map[string]any{"memberId": "caller does not exist"}}
+		}
+		return TripCreated{}, err
+	}
+
+	t := s.tripFromPlan(caller, p, s.clk.Now())
+	if err := s.trips.Create(ctx, t); err != nil {
+		if errors.Is(err, triprepo.ErrAlreadyExists) {
+			// Extremely unlikely (UUID collision); treat as conflict.
+			return TripCreated{}, &Error{Status: 409, Code: "TRIP_ID_CONFLICT", Message: "trip id conflict"}
+		}
+		return TripCreated{}, err
+	}
+
+	return TripCreated{
+		ID:              t.ID,
+		Status:          domain.TripStatusDraft,
+		DraftVisibility: domain.DraftVisibilityPrivate,
+	}, nil
+}
+
+// tripFromPlan builds a new PRIVATE draft owned by creator with the plan's fields. Artifacts
+// get fresh IDs so the new trip never shares rows with its source.
+func (s *Service) tripFromPlan(creator domain.MemberID, p domain.TripPlan, now time.Time) triprepo.Trip {
+	t := triprepo.Trip{
+		ID:                          s.newTripID(),
+		Status:                      triprepo.StatusDraft,
+		Name:                        cloneStringPtr(p.Name),
+		Description:                 cloneStringPtr(p.Description),
+		CreatorMemberID:             creator,
+		OrganizerMemberIDs:          []domain.MemberID{creator},
+		DraftVisibility:             triprepo.DraftVisibilityPrivate,
+		CapacityRigs:                cloneIntPtr(p.CapacityRigs),
+		CapacityPeople:              cloneIntPtr(p.CapacityPeople),
+		Difficulty:                  cloneDifficulty(p.Difficulty),
+		DifficultyText:              cloneStringPtr(p.DifficultyText),
+		MeetingLocation:             cloneLocationPtr(p.MeetingLocation),
+		CommsRequirementsText:       cloneStringPtr(p.CommsRequirementsText),
+		RecommendedRequirementsText: cloneStringPtr(p.RecommendedRequirementsText),
+		Requirements:                cloneRequirements(p.Requirements),
+		Artifacts:                   make([]domain.TripArtifact, 0, len(p.Artifacts)),
+		CreatedAt:                   now,
+		UpdatedAt:                   now,
+	}
+	for _, a := range p.Artifacts {
+		a.ArtifactID = s.newArtifactID()
+		t.Artifacts = append(t.Artifacts, a)
+	}
+	return t
+}
+
+func planFromTrip(t triprepo.Trip) domain.TripPlan {
+	return domain.TripPlan{
+		Name:                        cloneStringPtr(t.Name),
+		Description:                 cloneStringPtr(t.Description),
+		CapacityRigs:                cloneIntPtr(t.CapacityRigs),
+		CapacityPeople:              cloneIntPtr(t.CapacityPeople),
+		Difficulty:                  cloneDifficulty(t.Difficulty),
+		DifficultyText:              cloneStringPtr(t.DifficultyText),
+		MeetingLocation:             cloneLocationPtr(t.MeetingLocation),
+		CommsRequirementsText:       cloneStringPtr(t.CommsRequirementsText),
+		RecommendedRequirementsText: cloneStringPtr(t.RecommendedRequirementsText),
+		Requirements:                cloneRequirements(t.Requirements),
+		Artifacts:                   slices.Clone(t.Artifacts),
+	}
+}
@@ -0,0 +1,165 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	memtriptemplaterepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triptemplaterepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	porttriprepo "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+)
+
+func seedPlannedTrip(t *testing.T, repo *memtriprepo.Repo, id domain.TripID, organizer domain.MemberID) {
+	t.Helper()
+	now := time.Unix(1_000, 0).UTC()
+	name := "Canyon Run"
+	desc := "Two days in the canyon"
+	rigs := 8
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	tire := 33
+	if err := repo.Create(context.Background(), porttriprepo.Trip{
+		ID:                 id,
+		Status:             porttriprepo.StatusPublished,
+		Name:               &name,
+		Description:        &desc,
+		CreatorMemberID:    organizer,
+		OrganizerMemberIDs: []domain.MemberID{organizer},
+		StartDate:          &start,
+		EndDate:            &end,
+		CapacityRigs:       &rigs,
+		Difficulty:         &domain.Difficulty{Rating: 3, Terrain: []domain.TerrainTag{domain.TerrainRock}},
+		MeetingLocation:    &domain.Location{Label: "Gas station"},
+		Requirements:       domain.TripRequirements{MinTireSizeInches: &tire, LockersRequired: true},
+		Artifacts: []domain.TripArtifact{
+			{ArtifactID: "art-1", Type: domain.ArtifactTypeGPX, Title: "Track", URL: "https://example.com/track.gpx"},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create trip: %v", err)
+	}
+}
+
+func TestService_CloneTrip_CopiesPlanOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	seedPlannedTrip(t, tripsRepo, "src", "org")
+
+	svc := trips.NewService(tripsRepo, membersRepo, rsvpsRepo)
+	svc.SetNewTripIDForTest(func() domain.TripID { return "copy" })
+	if _, err := svc.SetMyRSVP(ctx, "m2", "src", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP: %v", err)
+	}
+
+	created, err := svc.CloneTrip(ctx, "m2", "src")
+	if err != nil {
+		t.Fatalf("CloneTrip: %v", err)
+	}
+	if created.ID != "copy" || created.Status != domain.TripStatusDraft || created.DraftVisibility != domain.DraftVisibilityPrivate {
+		t.Fatalf("CloneTrip = %+v", created)
+	}
+
+	got, err := tripsRepo.GetByID(ctx, "copy")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.CreatorMemberID != "m2" || len(got.OrganizerMemberIDs) != 1 || got.OrganizerMemberIDs[0] != "m2" {
+		t.Fatalf("clone ownership = %s %v", got.CreatorMemberID, got.OrganizerMemberIDs)
+	}
+	if got.StartDate != nil || got.EndDate != nil {
+		t.Fatalf("clone dates = %v %v, want none", got.StartDate, got.EndDate)
+	}
+	if got.Name == nil || *got.Name != "Canyon Run" || got.CapacityRigs == nil || *got.CapacityRigs != 8 ||
+		got.Difficulty == nil || got.Difficulty.Rating != 3 || got.MeetingLocation == nil ||
+		got.Requirements.MinTireSizeInches == nil || !got.Requirements.LockersRequired {
+		t.Fatalf("clone plan fields = %+v", got)
+	}
+	if len(got.Artifacts) != 1 || got.Artifacts[0].ArtifactID == "art-1" || got.Artifacts[0].URL != "https://example.com/track.gpx" {
+		t.Fatalf("clone artifacts = %+v, want a copy with a new id", got.Artifacts)
+	}
+	rsvps, err := rsvpsRepo.ListByTrip(ctx, "copy")
+	if err != nil || len(rsvps) != 0 {
+		t.Fatalf("clone rsvps = %v err=%v, want none", rsvps, err)
+	}
+
+	// Private drafts of other members are hidden.
+	var ae *trips.Error
+	if _, err := svc.CloneTrip(ctx, "org", "copy"); !errors.As(err, &ae) || ae.Status != 404 || ae.Code != "TRIP_NOT_FOUND" {
+		t.Fatalf("CloneTrip hidden draft err=%v, want 404 TRIP_NOT_FOUND", err)
+	}
+}
+
+func TestService_TripTemplates_SaveCreateDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	rsvpsRepo := memrsvprepo.NewRepo()
+	provisionMember(t, membersRepo, "org")
+	provisionMember(t, membersRepo, "m2")
+	seedPlannedTrip(t, tripsRepo, "src", "org")
+
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, rsvpsRepo, trips.Options{Templates: memtriptemplaterepo.NewRepo()})
+	svc.SetNewTripIDForTest(func() domain.TripID { return "from-template" })
+
+	var ae *trips.Error
+	if _, err := svc.SaveTripTemplate(ctx, "org", "src", trips.SaveTripTemplateInput{Name: "  "}); !errors.As(err, &ae) || ae.Status != 422 {
+		t.Fatalf("SaveTripTemplate blank name err=%v, want 422", err)
+	}
+	tmpl, err := svc.SaveTripTemplate(ctx, "org", "src", trips.SaveTripTemplateInput{Name: " Canyon  weekend "})
+	if err != nil {
+		t.Fatalf("SaveTripTemplate: %v", err)
+	}
+	if tmpl.Name != "Canyon weekend" || tmpl.CreatedByMember != "org" || tmpl.Plan.Name == nil || *tmpl.Plan.Name != "Canyon Run" {
+		t.Fatalf("SaveTripTemplate = %+v", tmpl)
+	}
+	if _, err := svc.SaveTripTemplate(ctx, "m2", "src", trips.SaveTripTemplateInput{Name: "canyon WEEKEND"}); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "TRIP_TEMPLATE_NAME_TAKEN" {
+		t.Fatalf("SaveTripTemplate duplicate err=%v, want 409 TRIP_TEMPLATE_NAME_TAKEN", err)
+	}
+	list, err := svc.ListTripTemplates(ctx, "m2")
+	if err != nil || len(list) != 1 || list[0].ID != tmpl.ID {
+		t.Fatalf("ListTripTemplates = %+v err=%v", list, err)
+	}
+
+	// The draft takes its name from the request and everything else from the template.
+	unknown := domain.TripTemplateID("nope")
+	if _, err := svc.CreateTripDraft(ctx, "m2", trips.CreateTripDraftInput{Name: "Spring canyon", TemplateID: &unknown}); !errors.As(err, &ae) || ae.Status != 422 {
+		t.Fatalf("CreateTripDraft unknown template err=%v, want 422", err)
+	}
+	created, err := svc.CreateTripDraft(ctx, "m2", trips.CreateTripDraftInput{Name: "Spring canyon", TemplateID: &tmpl.ID})
+	if err != nil {
+		t.Fatalf("CreateTripDraft from template: %v", err)
+	}
+	got, err := tripsRepo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Name == nil || *got.Name != "Spring canyon" || got.CreatorMemberID != "m2" || got.StartDate != nil ||
+		got.Description == nil || *got.Description != "Two days in the canyon" || len(got.Artifacts) != 1 || got.Artifacts[0].ArtifactID == "art-1" {
+		t.Fatalf("draft from template = %+v", got)
+	}
+
+	if err := svc.DeleteTripTemplate(ctx, "m2", tmpl.ID); !errors.As(err, &ae) || ae.Status != 403 || ae.Code != "FORBIDDEN" {
+		t.Fatalf("DeleteTripTemplate non-creator err=%v, want 403 FORBIDDEN", err)
+	}
+	if err := svc.DeleteTripTemplate(ctx, "org", tmpl.ID); err != nil {
+		t.Fatalf("DeleteTripTemplate: %v", err)
+	}
+	if err := svc.DeleteTripTemplate(ctx, "org", tmpl.ID); !errors.As(err, &ae) || ae.Status != 404 || ae.Code != "TRIP_TEMPLATE_NOT_FOUND" {
+		t.Fatalf("DeleteTripTemplate twice err=%v, want 404 TRIP_TEMPLATE_NOT_FOUND", err)
+	}
+}
@@ -24,8 +24,48 @@ func (o Optional[T]) IsSpecified() bool { return o.specified }
 func (o Optional[T]) IsNull() bool      { return o.specified && o.isNull }
 func (o Optional[T]) Value() T          { return o.value }
 
+type SetMyRSVPInput struct {
+	Response domain.RSVPResponse
+	// VehicleID names the caller's vehicle for a YES. When nil, a YES keeps the vehicle already
+	// on the RSVP or falls back to the caller's default vehicle. Ignored for NO/UNSET.
+	VehicleID *domain.VehicleID
+
+	// PassengerCount and PassengerNames describe who rides along on a YES. When both are
+	// unspecified, a YES keeps the passengers already on the RSVP; otherwise they are replaced
+	// together. Names without a count imply one passenger per name. Ignored for NO/UNSET.
+	PassengerCount *int
+	PassengerNames []string
+}
+
+// MemberRSVPInput is an organizer's change to another member's RSVP.
+type MemberRSVPInput struct {
+	SetMyRSVPInput
+
+	// Reason says why the organizer made the change; it is required and kept on the RSVP.
+	Reason string
+	// BypassCapacity admits a YES beyond the trip's rig and people capacity, for trip staff
+	// such as a sweep driver. Capacity is enforced unless it is set.
+	BypassCapacity bool
+}
+
+// MoveMemberRSVPInput moves a member's RSVP, with its vehicle and passengers, to another trip.
+type MoveMemberRSVPInput struct {
+	ToTripID domain.TripID
+	// Reason and BypassCapacity are as for MemberRSVPInput; BypassCapacity applies to the
+	// destination trip.
+	Reason         string
+	BypassCapacity bool
+}
+
 type CreateTripDraftInput struct {
 	Name string
+	// TemplateID optionally starts the draft from a saved trip template.
+	TemplateID *domain.TripTemplateID
+}
+
+// SaveTripTemplateInput names a new trip template.
+type SaveTripTemplateInput struct {
+	Name string
 }
 
 // TripCreated is the minimal response returned when a draft trip is created.
@@ -47,15 +87,82 @@ type UpdateTripInput struct {
 	// Name is optional and cannot be null.
 	Name Optional[string]
 
-	Description  Optional[string]
-	StartDate    Optional[time.Time]
-	EndDate      Optional[time.Time]
+	Description Optional[string]
+	StartDate   Optional[time.Time]
+	EndDate     Optional[time.Time]
+	// RSVPDeadline locks self-service RSVP changes at that instant; null removes the deadline.
+	RSVPDeadline Optional[time.Time]
 	CapacityRigs Optional[int]
+	// CapacityPeople caps headcount (members plus passengers); null removes the cap.
+	CapacityPeople Optional[int]
 
+	// Difficulty replaces the structured rating as a whole; null clears it. DifficultyText is
+	// free-text notes.
+	Difficulty                  Optional[domain.Difficulty]
 	DifficultyText              Optional[string]
 	MeetingLocation             Optional[*LocationPatch] // null clears the location
 	CommsRequirementsText       Optional[string]
 	RecommendedRequirementsText Optional[string]
+	// Requirements replaces the structured vehicle requirements as a whole; null clears them.
+	Requirements Optional[domain.TripRequirements]
 
 	ArtifactIDs Optional[[]string] // null clears all artifacts; value reorders existing artifacts by ID
 }
+
+// RideOfferInput describes a driver's ride-share seat offer.
+type RideOfferInput struct {
+	Seats int
+	Notes *string
+}
+
+// RequestRideInput asks a driver for a seat.
+type RequestRideInput struct {
+	DriverMemberID domain.MemberID
+	Note           *string
+}
+
+// CreateTripSeriesInput describes a recurring trip series.
+type CreateTripSeriesInput struct {
+	// TemplateTripID is the trip whose planning fields and artifacts each occurrence copies.
+	TemplateTripID domain.TripID
+	// Recurrence is an RRULE such as "FREQ=WEEKLY;BYDAY=TU" or "FREQ=MONTHLY;BYDAY=2SA".
+	Recurrence string
+	StartDate  time.Time
+	// EndDate optionally bounds the series; no occurrence starts after it.
+	EndDate *time.Time
+	// DurationDays is how many days each occurrence spans; zero means a single day.
+	DurationDays int
+	// Publish generates published occurrences instead of private drafts.
+	Publish bool
+}
+
+// TripSeriesDetails is a series with the occurrences visible to the caller.
+type TripSeriesDetails struct {
+	Series      domain.TripSeries
+	Occurrences []TripSeriesOccurrence
+}
+
+// TripSeriesOccurrence is one generated trip of a series. Date is the date the series
+// scheduled it for, which stays fixed when the trip itself is moved.
+type TripSeriesOccurrence struct {
+	Date time.Time
+	Trip domain.TripSummary
+}
+
+// SeriesUpdateResult reports an "all future occurrences" edit. Skipped lists later
+// occurrences the edit could not be applied to (for example, ones the caller does not
+// organize); they are left unchanged.
+type SeriesUpdateResult struct {
+	Trip    domain.TripDetails
+	Updated []domain.TripID
+	Skipped []domain.TripID
+}
+
+// ItineraryDayInput is the full plan for one itinerary day; it replaces any existing plan for
+// the date. Stops and Segments are in travel order.
+type ItineraryDayInput struct {
+	Title    *string
+	Notes    *string
+	Stops    []domain.ItineraryStop
+	Segments []domain.RouteSegment
+}
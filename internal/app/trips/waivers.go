@@ -0,0 +1,160 @@
+package trips
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/triprepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
+)
+
+// maxTripWaivers caps how many trip-specific waivers a trip can require.
+const maxTripWaivers = 10
+
+var errWaiversDisabled = errors.New("waivers are not configured")
+
+// GetTripWaivers returns every waiver a YES RSVP on the trip needs (club-wide ones first, then
+// the trip's own) and whether the caller has acknowledged each one's current version.
+func (s *Service) GetTripWaivers(ctx context.Context, caller domain.MemberID, tripID domain.TripID) ([]domain.MemberWaiver, error) {
+	if s.waivers == nil {
+		return nil, errWaiversDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return nil, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	return s.tripWaiverStatus(ctx, tripID, caller)
+}
+
+// SetTripWaivers replaces the waivers the trip requires on top of club-wide ones. Only
+// organizers may change them, and not once the trip is canceled. It returns the trip's full
+// list as GetTripWaivers does.
+func (s *Service) SetTripWaivers(ctx context.Context, caller domain.MemberID, tripID domain.TripID, ids []domain.WaiverID) ([]domain.MemberWaiver, error) {
+	if s.waivers == nil {
+		return nil, errWaiversDisabled
+	}
+	t, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, triprepo.ErrNotFound) {
+			return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+		}
+		return nil, err
+	}
+	if !isTripVisibleToCaller(t, caller) {
+		return nil, &Error{Status: 404, Code: "TRIP_NOT_FOUND", Message: "trip not found"}
+	}
+	if !isOrganizer(t, caller) {
+		return nil, &Error{Status: 403, Code: "FORBIDDEN", Message: "only organizers can change a trip's waivers"}
+	}
+	if t.Status == triprepo.StatusCanceled {
+		return nil, &Error{Status: 409, Code: "TRIP_CANCELED", Message: "trip is canceled and cannot be modified"}
+	}
+
+	unique := make([]domain.WaiverID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxTripWaivers {
+		return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "too many waivers", Details: map[string]any{"waiverIds": "must list at most 10 waivers"}}
+	}
+	if err := s.waivers.SetTripRequirements(ctx, tripID, unique); err != nil {
+		if errors.Is(err, waiverrepo.ErrNotFound) {
+			return nil, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "unknown waiver", Details: map[string]any{"waiverIds": "must name existing waivers"}}
+		}
+		return nil, err
+	}
+	return s.tripWaiverStatus(ctx, tripID, caller)
+}
+
+// requireWaivers fails with WAIVER_REQUIRED, listing what is missing, unless member has
+// acknowledged the current version of every waiver the trip requires.
+func (s *Service) requireWaivers(ctx context.Context, tripID domain.TripID, member domain.MemberID) error {
+	if s.waivers == nil {
+		return nil
+	}
+	status, err := s.tripWaiverStatus(ctx, tripID, member)
+	if err != nil {
+		return err
+	}
+	missing := make([]map[string]any, 0)
+	for _, w := range status {
+		if w.AcknowledgedAt == nil {
+			missing = append(missing, map[string]any{
+				"waiverId":    string(w.ID),
+				"title":       w.Title,
+				"version":     w.Version,
+				"versionHash": w.VersionHash,
+			})
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &Error{Status: 409, Code: "WAIVER_REQUIRED", Message: "acknowledge the required waivers before joining the trip", Details: map[string]any{"missing": missing}}
+}
+
+// tripWaiverStatus lists the waivers the trip requires with member's acknowledgement of each
+// one's current version.
+func (s *Service) tripWaiverStatus(ctx context.Context, tripID domain.TripID, member domain.MemberID) ([]domain.MemberWaiver, error) {
+	all, err := s.waivers.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tripIDs, err := s.waivers.ListTripRequirements(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	required := make([]waiverrepo.Waiver, 0, len(tripIDs))
+	for _, w := range all {
+		if w.RequiredForAllTrips {
+			required = append(required, w)
+		}
+	}
+	for _, w := range all {
+		if !w.RequiredForAllTrips && slices.Contains(tripIDs, w.ID) {
+			required = append(required, w)
+		}
+	}
+	if len(required) == 0 {
+		return []domain.MemberWaiver{}, nil
+	}
+
+	acks, err := s.waivers.ListAcknowledgementsByMember(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.MemberWaiver, 0, len(required))
+	for _, w := range required {
+		v, err := s.waivers.LatestVersion(ctx, w.ID)
+		if err != nil {
+			return nil, err
+		}
+		mw := domain.MemberWaiver{Waiver: domain.Waiver{
+			ID:                  w.ID,
+			Title:               w.Title,
+			RequiredForAllTrips: w.RequiredForAllTrips,
+			Version:             v.Version,
+			VersionHash:         v.Hash,
+			Body:                v.Body,
+			PublishedAt:         v.PublishedAt,
+		}}
+		for _, a := range acks {
+			if a.WaiverID == w.ID && a.Version == v.Version {
+				at := a.AcknowledgedAt
+				mw.AcknowledgedAt = &at
+			}
+		}
+		out = append(out, mw)
+	}
+	return out, nil
+}
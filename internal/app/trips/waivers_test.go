@@ -0,0 +1,182 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memmemberrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/memberrepo"
+	memridesharerepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/ridesharerepo"
+	memrsvprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/rsvprepo"
+	memtriprepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/triprepo"
+	memwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/waiverrepo"
+	"github.com/BennettSmith/ebo-planner-backend/internal/app/trips"
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
+)
+
+func TestService_Waivers_RequiredBeforeYes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "m1"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	waivers := memwaiverrepo.NewRepo()
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Waivers: waivers})
+
+	now := time.Unix(1_000, 0).UTC()
+	for _, w := range []struct {
+		waiver waiverrepo.Waiver
+		hash   string
+	}{
+		{waiverrepo.Waiver{ID: "season", Title: "2026 Season", RequiredForAllTrips: true, CreatedAt: now}, "hash-season-1"},
+		{waiverrepo.Waiver{ID: "rock", Title: "Rock Crawling", CreatedAt: now.Add(time.Second)}, "hash-rock-1"},
+		{waiverrepo.Waiver{ID: "snow", Title: "Snow Wheeling", CreatedAt: now.Add(2 * time.Second)}, "hash-snow-1"},
+	} {
+		if err := waivers.Create(ctx, w.waiver, waiverrepo.Version{Body: w.waiver.Title, Hash: w.hash, PublishedAt: now}); err != nil {
+			t.Fatalf("Create %s: %v", w.waiver.ID, err)
+		}
+	}
+	ack := func(id domain.WaiverID, version int, hash string) {
+		t.Helper()
+		if err := waivers.Acknowledge(ctx, waiverrepo.Acknowledgement{WaiverID: id, Version: version, VersionHash: hash, MemberID: "m1", Subject: "sub-m1", AcknowledgedAt: now}); err != nil {
+			t.Fatalf("Acknowledge %s: %v", id, err)
+		}
+	}
+
+	var ae *trips.Error
+	if _, err := svc.SetTripWaivers(ctx, "m1", "tp", []domain.WaiverID{"rock"}); !errors.As(err, &ae) || ae.Code != "FORBIDDEN" {
+		t.Fatalf("SetTripWaivers by member err=%v, want FORBIDDEN", err)
+	}
+	if _, err := svc.SetTripWaivers(ctx, "org", "tp", []domain.WaiverID{"rock", "nope"}); !errors.As(err, &ae) || ae.Code != "VALIDATION_ERROR" {
+		t.Fatalf("SetTripWaivers unknown err=%v, want VALIDATION_ERROR", err)
+	}
+	required, err := svc.SetTripWaivers(ctx, "org", "tp", []domain.WaiverID{"rock", "rock"})
+	if err != nil {
+		t.Fatalf("SetTripWaivers: %v", err)
+	}
+	if len(required) != 2 || required[0].ID != "season" || required[1].ID != "rock" {
+		t.Fatalf("required = %+v, want season then rock", required)
+	}
+
+	_, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("SetMyRSVP without waivers err=%v, want 409 WAIVER_REQUIRED", err)
+	}
+	missing, _ := ae.Details["missing"].([]map[string]any)
+	if len(missing) != 2 || missing[0]["waiverId"] != "season" || missing[1]["versionHash"] != "hash-rock-1" {
+		t.Fatalf("missing = %+v", ae.Details)
+	}
+	// Declining never needs a waiver.
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseNo}); err != nil {
+		t.Fatalf("SetMyRSVP NO: %v", err)
+	}
+
+	ack("season", 1, "hash-season-1")
+	_, err = svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes})
+	if !errors.As(err, &ae) || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("SetMyRSVP missing trip waiver err=%v, want WAIVER_REQUIRED", err)
+	}
+	if missing, _ := ae.Details["missing"].([]map[string]any); len(missing) != 1 || missing[0]["waiverId"] != "rock" {
+		t.Fatalf("missing = %+v, want rock only", ae.Details)
+	}
+
+	ack("rock", 1, "hash-rock-1")
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP with waivers: %v", err)
+	}
+
+	// A new season version does not undo an existing YES, but is needed to RSVP YES again.
+	if err := waivers.AddVersion(ctx, waiverrepo.Version{WaiverID: "season", Version: 2, Body: "2026 Season v2", Hash: "hash-season-2", PublishedAt: now}); err != nil {
+		t.Fatalf("AddVersion: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP keeping YES: %v", err)
+	}
+	status, err := svc.GetTripWaivers(ctx, "m1", "tp")
+	if err != nil || len(status) != 2 || status[0].Version != 2 || status[0].AcknowledgedAt != nil || status[1].AcknowledgedAt == nil {
+		t.Fatalf("GetTripWaivers = %+v err=%v", status, err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseUnset}); err != nil {
+		t.Fatalf("SetMyRSVP UNSET: %v", err)
+	}
+	if _, err := svc.SetMyRSVP(ctx, "m1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); !errors.As(err, &ae) || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("SetMyRSVP after new version err=%v, want WAIVER_REQUIRED", err)
+	}
+
+	// Organizers setting a YES on a member's behalf cannot skip the waivers either.
+	if _, err := svc.SetMemberRSVP(ctx, "org", "tp", "m1", trips.MemberRSVPInput{
+		SetMyRSVPInput: trips.SetMyRSVPInput{Response: domain.RSVPResponseYes},
+		Reason:         "confirmed by phone",
+		BypassCapacity: true,
+	}); !errors.As(err, &ae) || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("SetMemberRSVP err=%v, want WAIVER_REQUIRED", err)
+	}
+}
+
+func TestService_Waivers_RequiredForRideShareRiders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	membersRepo := memmemberrepo.NewRepo()
+	tripsRepo := memtriprepo.NewRepo()
+	for _, id := range []domain.MemberID{"org", "d1", "r1"} {
+		provisionMember(t, membersRepo, id)
+	}
+	seedPlannedTrip(t, tripsRepo, "tp", "org")
+	waivers := memwaiverrepo.NewRepo()
+	svc := trips.NewServiceWithOptions(tripsRepo, membersRepo, memrsvprepo.NewRepo(), trips.Options{Waivers: waivers, RideShares: memridesharerepo.NewRepo()})
+
+	now := time.Unix(1_000, 0).UTC()
+	for _, w := range []waiverrepo.Waiver{
+		{ID: "season", Title: "2026 Season", RequiredForAllTrips: true, CreatedAt: now},
+		{ID: "rock", Title: "Rock Crawling", CreatedAt: now.Add(time.Second)},
+	} {
+		if err := waivers.Create(ctx, w, waiverrepo.Version{Body: w.Title, Hash: "hash-" + string(w.ID), PublishedAt: now}); err != nil {
+			t.Fatalf("Create %s: %v", w.ID, err)
+		}
+	}
+	ack := func(member domain.MemberID, id domain.WaiverID) {
+		t.Helper()
+		if err := waivers.Acknowledge(ctx, waiverrepo.Acknowledgement{WaiverID: id, Version: 1, VersionHash: "hash-" + string(id), MemberID: member, Subject: domain.SubjectID("sub-" + member), AcknowledgedAt: now}); err != nil {
+			t.Fatalf("Acknowledge %s %s: %v", member, id, err)
+		}
+	}
+
+	ack("d1", "season")
+	ack("d1", "rock")
+	if _, err := svc.SetMyRSVP(ctx, "d1", "tp", trips.SetMyRSVPInput{Response: domain.RSVPResponseYes}); err != nil {
+		t.Fatalf("SetMyRSVP(d1): %v", err)
+	}
+	if _, err := svc.OfferRideSeats(ctx, "d1", "tp", trips.RideOfferInput{Seats: 2}); err != nil {
+		t.Fatalf("OfferRideSeats: %v", err)
+	}
+
+	// Riders need the trip's waivers to request a seat.
+	var ae *trips.Error
+	if _, err := svc.RequestRide(ctx, "r1", "tp", trips.RequestRideInput{DriverMemberID: "d1"}); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("RequestRide without waivers err=%v, want 409 WAIVER_REQUIRED", err)
+	}
+	ack("r1", "season")
+	req, err := svc.RequestRide(ctx, "r1", "tp", trips.RequestRideInput{DriverMemberID: "d1"})
+	if err != nil {
+		t.Fatalf("RequestRide: %v", err)
+	}
+
+	// A waiver required after the request blocks acceptance until the rider acknowledges it.
+	if _, err := svc.SetTripWaivers(ctx, "org", "tp", []domain.WaiverID{"rock"}); err != nil {
+		t.Fatalf("SetTripWaivers: %v", err)
+	}
+	if _, err := svc.AcceptRideRequest(ctx, "d1", "tp", req.ID); !errors.As(err, &ae) || ae.Status != 409 || ae.Code != "WAIVER_REQUIRED" {
+		t.Fatalf("AcceptRideRequest without waivers err=%v, want 409 WAIVER_REQUIRED", err)
+	}
+	ack("r1", "rock")
+	if got, err := svc.AcceptRideRequest(ctx, "d1", "tp", req.ID); err != nil || got.Status != domain.RideRequestStatusAccepted {
+		t.Fatalf("AcceptRideRequest = %+v err=%v", got, err)
+	}
+}
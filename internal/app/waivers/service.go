@@ -0,0 +1,279 @@
+package waivers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+	clockport "github.com/BennettSmith/ebo-planner-backend/internal/ports/out/clock"
+	"github.com/BennettSmith/ebo-planner-backend/internal/ports/out/waiverrepo"
+)
+
+const (
+	maxTitleLen = 200
+	maxBodyLen  = 100_000
+)
+
+// Service manages liability waivers. Admins create waivers and publish new versions (see
+// cmd/waivers); members read them and acknowledge the current version. Trips check the
+// acknowledgements before accepting a YES RSVP.
+type Service struct {
+	repo waiverrepo.Repository
+	clk  clockport.Clock
+
+	newWaiverID func() domain.WaiverID
+}
+
+func NewService(repo waiverrepo.Repository, clk clockport.Clock) *Service {
+	return &Service{
+		repo: repo,
+		clk:  clk,
+		newWaiverID: func() domain.WaiverID {
+			return domain.WaiverID(uuid.NewString())
+		},
+	}
+}
+
+// CreateInput describes a new waiver and the text of its first version.
+type CreateInput struct {
+	Title               string
+	Body                string
+	RequiredForAllTrips bool
+	CreatedBy           string
+}
+
+// Create stores a new waiver at version 1.
+func (s *Service) Create(ctx context.Context, in CreateInput) (domain.Waiver, error) {
+	title := domain.NormalizeHumanName(in.Title)
+	details := map[string]any{}
+	if title == "" || utf8.RuneCountInString(title) > maxTitleLen {
+		details["title"] = fmt.Sprintf("must be 1-%d characters", maxTitleLen)
+	}
+	body, msg := normalizeBody(in.Body)
+	if msg != "" {
+		details["body"] = msg
+	}
+	if len(details) > 0 {
+		return domain.Waiver{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid waiver", Details: details}
+	}
+
+	now := s.clk.Now()
+	w := waiverrepo.Waiver{
+		ID:                  s.newWaiverID(),
+		Title:               title,
+		RequiredForAllTrips: in.RequiredForAllTrips,
+		CreatedBy:           in.CreatedBy,
+		CreatedAt:           now,
+	}
+	v := waiverrepo.Version{WaiverID: w.ID, Version: 1, Body: body, Hash: VersionHash(body), PublishedAt: now}
+	if err := s.repo.Create(ctx, w, v); err != nil {
+		return domain.Waiver{}, err
+	}
+	return waiverFromRepo(w, v), nil
+}
+
+// PublishVersion makes body the waiver's current version. Members must acknowledge it before
+// their next YES RSVP on a trip that requires the waiver.
+func (s *Service) PublishVersion(ctx context.Context, id domain.WaiverID, body string) (domain.Waiver, error) {
+	body, msg := normalizeBody(body)
+	if msg != "" {
+		return domain.Waiver{}, &Error{Status: 422, Code: "VALIDATION_ERROR", Message: "invalid waiver", Details: map[string]any{"body": msg}}
+	}
+	w, latest, err := s.load(ctx, id)
+	if err != nil {
+		return domain.Waiver{}, err
+	}
+	hash := VersionHash(body)
+	if hash == latest.Hash {
+		return domain.Waiver{}, &Error{Status: 409, Code: "WAIVER_UNCHANGED", Message: "the text matches the current version"}
+	}
+	v := waiverrepo.Version{WaiverID: id, Version: latest.Version + 1, Body: body, Hash: hash, PublishedAt: s.clk.Now()}
+	if err := s.repo.AddVersion(ctx, v); err != nil {
+		if errors.Is(err, waiverrepo.ErrVersionConflict) {
+			return domain.Waiver{}, &Error{Status: 409, Code: "WAIVER_VERSION_CONFLICT", Message: "another version was published at the same time"}
+		}
+		return domain.Waiver{}, err
+	}
+	return waiverFromRepo(w, v), nil
+}
+
+// List returns every waiver at its current version.
+func (s *Service) List(ctx context.Context) ([]domain.Waiver, error) {
+	ws, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Waiver, 0, len(ws))
+	for _, w := range ws {
+		v, err := s.repo.LatestVersion(ctx, w.ID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, waiverFromRepo(w, v))
+	}
+	return out, nil
+}
+
+// ListAcknowledgements returns every acknowledgement of the waiver, of any version, oldest first.
+func (s *Service) ListAcknowledgements(ctx context.Context, id domain.WaiverID) ([]domain.WaiverAcknowledgement, error) {
+	if _, err := s.getWaiver(ctx, id); err != nil {
+		return nil, err
+	}
+	acks, err := s.repo.ListAcknowledgementsByWaiver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.WaiverAcknowledgement, 0, len(acks))
+	for _, a := range acks {
+		out = append(out, acknowledgementFromRepo(a))
+	}
+	return out, nil
+}
+
+// ListMyWaivers returns every waiver with whether the caller has acknowledged its current version.
+func (s *Service) ListMyWaivers(ctx context.Context, caller domain.MemberID) ([]domain.MemberWaiver, error) {
+	ws, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := s.repo.ListAcknowledgementsByMember(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.MemberWaiver, 0, len(ws))
+	for _, w := range ws {
+		out = append(out, withAcknowledgement(w, acks))
+	}
+	return out, nil
+}
+
+// GetMyWaiver returns a waiver's current version and whether the caller has acknowledged it.
+func (s *Service) GetMyWaiver(ctx context.Context, caller domain.MemberID, id domain.WaiverID) (domain.MemberWaiver, error) {
+	w, v, err := s.load(ctx, id)
+	if err != nil {
+		return domain.MemberWaiver{}, err
+	}
+	acks, err := s.repo.ListAcknowledgementsByMember(ctx, caller)
+	if err != nil {
+		return domain.MemberWaiver{}, err
+	}
+	return withAcknowledgement(waiverFromRepo(w, v), acks), nil
+}
+
+// AcknowledgeWaiver records that the caller, signed in as subject, agrees to the waiver's
+// current version. versionHash must match that version, so members only agree to the text
+// they were shown; otherwise it fails with WAIVER_VERSION_CHANGED. Acknowledging again is a
+// no-op that returns the original acknowledgement.
+func (s *Service) AcknowledgeWaiver(ctx context.Context, caller domain.MemberID, subject domain.SubjectID, id domain.WaiverID, versionHash string) (domain.WaiverAcknowledgement, error) {
+	_, v, err := s.load(ctx, id)
+	if err != nil {
+		return domain.WaiverAcknowledgement{}, err
+	}
+	if strings.ToLower(strings.TrimSpace(versionHash)) != v.Hash {
+		return domain.WaiverAcknowledgement{}, &Error{
+			Status:  409,
+			Code:    "WAIVER_VERSION_CHANGED",
+			Message: "the waiver has changed; review the current version",
+			Details: map[string]any{"version": v.Version, "versionHash": v.Hash},
+		}
+	}
+	if err := s.repo.Acknowledge(ctx, waiverrepo.Acknowledgement{
+		WaiverID:       id,
+		Version:        v.Version,
+		VersionHash:    v.Hash,
+		MemberID:       caller,
+		Subject:        subject,
+		AcknowledgedAt: s.clk.Now(),
+	}); err != nil {
+		return domain.WaiverAcknowledgement{}, err
+	}
+	acks, err := s.repo.ListAcknowledgementsByMember(ctx, caller)
+	if err != nil {
+		return domain.WaiverAcknowledgement{}, err
+	}
+	for _, a := range acks {
+		if a.WaiverID == id && a.Version == v.Version {
+			return acknowledgementFromRepo(a), nil
+		}
+	}
+	return domain.WaiverAcknowledgement{}, fmt.Errorf("acknowledgement of waiver %s version %d was not stored", id, v.Version)
+}
+
+func (s *Service) getWaiver(ctx context.Context, id domain.WaiverID) (waiverrepo.Waiver, error) {
+	w, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, waiverrepo.ErrNotFound) {
+			return waiverrepo.Waiver{}, &Error{Status: 404, Code: "WAIVER_NOT_FOUND", Message: "waiver not found"}
+		}
+		return waiverrepo.Waiver{}, err
+	}
+	return w, nil
+}
+
+func (s *Service) load(ctx context.Context, id domain.WaiverID) (waiverrepo.Waiver, waiverrepo.Version, error) {
+	w, err := s.getWaiver(ctx, id)
+	if err != nil {
+		return waiverrepo.Waiver{}, waiverrepo.Version{}, err
+	}
+	v, err := s.repo.LatestVersion(ctx, id)
+	if err != nil {
+		return waiverrepo.Waiver{}, waiverrepo.Version{}, err
+	}
+	return w, v, nil
+}
+
+// VersionHash is the hex SHA-256 of a waiver version's text.
+func VersionHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeBody trims the text and reports why it is invalid, if it is.
+func normalizeBody(body string) (string, string) {
+	body = strings.TrimSpace(body)
+	if body == "" || len(body) > maxBodyLen {
+		return body, fmt.Sprintf("must be 1-%d bytes", maxBodyLen)
+	}
+	return body, ""
+}
+
+func waiverFromRepo(w waiverrepo.Waiver, v waiverrepo.Version) domain.Waiver {
+	return domain.Waiver{
+		ID:                  w.ID,
+		Title:               w.Title,
+		RequiredForAllTrips: w.RequiredForAllTrips,
+		Version:             v.Version,
+		VersionHash:         v.Hash,
+		Body:                v.Body,
+		PublishedAt:         v.PublishedAt,
+	}
+}
+
+func acknowledgementFromRepo(a waiverrepo.Acknowledgement) domain.WaiverAcknowledgement {
+	return domain.WaiverAcknowledgement{
+		WaiverID:       a.WaiverID,
+		Version:        a.Version,
+		VersionHash:    a.VersionHash,
+		MemberID:       a.MemberID,
+		Subject:        a.Subject,
+		AcknowledgedAt: a.AcknowledgedAt,
+	}
+}
+
+func withAcknowledgement(w domain.Waiver, acks []waiverrepo.Acknowledgement) domain.MemberWaiver {
+	out := domain.MemberWaiver{Waiver: w}
+	for _, a := range acks {
+		if a.WaiverID == w.ID && a.Version == w.Version {
+			at := a.AcknowledgedAt
+			out.AcknowledgedAt = &at
+		}
+	}
+	return out
+}
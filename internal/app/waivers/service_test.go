@@ -0,0 +1,116 @@
+package waivers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memclock "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/clock"
+	memwaiverrepo "github.com/BennettSmith/ebo-planner-backend/internal/adapters/memory/waiverrepo"
+)
+
+func requireAppError(t *testing.T, err error, status int, code string) {
+	t.Helper()
+	ae := (*Error)(nil)
+	if !errors.As(err, &ae) || ae.Status != status || ae.Code != code {
+		t.Fatalf("err=%v (type=%T), want %s %d", err, err, code, status)
+	}
+}
+
+func TestService_CreateAndPublishVersions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	clk := memclock.NewManualClock(time.Unix(1_000, 0).UTC())
+	svc := NewService(memwaiverrepo.NewRepo(), clk)
+
+	_, err := svc.Create(ctx, CreateInput{Title: " ", Body: ""})
+	requireAppError(t, err, 422, "VALIDATION_ERROR")
+
+	w, err := svc.Create(ctx, CreateInput{Title: " 2026  Season ", Body: "I accept the risks.\n", RequiredForAllTrips: true, CreatedBy: "ops"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if w.Title != "2026 Season" || w.Version != 1 || w.Body != "I accept the risks." || w.VersionHash != VersionHash("I accept the risks.") || !w.RequiredForAllTrips {
+		t.Fatalf("created = %+v", w)
+	}
+
+	_, err = svc.PublishVersion(ctx, w.ID, "  I accept the risks.  ")
+	requireAppError(t, err, 409, "WAIVER_UNCHANGED")
+	_, err = svc.PublishVersion(ctx, "missing", "text")
+	requireAppError(t, err, 404, "WAIVER_NOT_FOUND")
+
+	clk.Add(time.Hour)
+	v2, err := svc.PublishVersion(ctx, w.ID, "I accept the risks, including winches.")
+	if err != nil {
+		t.Fatalf("PublishVersion: %v", err)
+	}
+	if v2.Version != 2 || v2.VersionHash == w.VersionHash || !v2.PublishedAt.Equal(clk.Now()) || v2.Title != w.Title {
+		t.Fatalf("v2 = %+v", v2)
+	}
+
+	list, err := svc.List(ctx)
+	if err != nil || len(list) != 1 || list[0].Version != 2 {
+		t.Fatalf("List = %+v err=%v", list, err)
+	}
+}
+
+func TestService_AcknowledgeCurrentVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	clk := memclock.NewManualClock(time.Unix(1_000, 0).UTC())
+	svc := NewService(memwaiverrepo.NewRepo(), clk)
+
+	w, err := svc.Create(ctx, CreateInput{Title: "Rock Crawling", Body: "Rocks are hard."})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	_, err = svc.AcknowledgeWaiver(ctx, "m1", "sub-1", w.ID, "not-the-hash")
+	requireAppError(t, err, 409, "WAIVER_VERSION_CHANGED")
+	_, err = svc.AcknowledgeWaiver(ctx, "m1", "sub-1", "missing", w.VersionHash)
+	requireAppError(t, err, 404, "WAIVER_NOT_FOUND")
+
+	ack, err := svc.AcknowledgeWaiver(ctx, "m1", "sub-1", w.ID, w.VersionHash)
+	if err != nil {
+		t.Fatalf("AcknowledgeWaiver: %v", err)
+	}
+	if ack.Version != 1 || ack.VersionHash != w.VersionHash || ack.Subject != "sub-1" || !ack.AcknowledgedAt.Equal(clk.Now()) {
+		t.Fatalf("ack = %+v", ack)
+	}
+
+	// Acknowledging again keeps the original record.
+	clk.Add(time.Minute)
+	again, err := svc.AcknowledgeWaiver(ctx, "m1", "sub-linked", w.ID, w.VersionHash)
+	if err != nil {
+		t.Fatalf("AcknowledgeWaiver again: %v", err)
+	}
+	if again != ack {
+		t.Fatalf("again = %+v, want %+v", again, ack)
+	}
+
+	mine, err := svc.GetMyWaiver(ctx, "m1", w.ID)
+	if err != nil || mine.AcknowledgedAt == nil || mine.Body != "Rocks are hard." {
+		t.Fatalf("GetMyWaiver = %+v err=%v", mine, err)
+	}
+
+	// A new version needs a new acknowledgement, and the old hash no longer works.
+	v2, err := svc.PublishVersion(ctx, w.ID, "Rocks are very hard.")
+	if err != nil {
+		t.Fatalf("PublishVersion: %v", err)
+	}
+	list, err := svc.ListMyWaivers(ctx, "m1")
+	if err != nil || len(list) != 1 || list[0].Version != 2 || list[0].AcknowledgedAt != nil {
+		t.Fatalf("ListMyWaivers = %+v err=%v", list, err)
+	}
+	_, err = svc.AcknowledgeWaiver(ctx, "m1", "sub-1", w.ID, w.VersionHash)
+	requireAppError(t, err, 409, "WAIVER_VERSION_CHANGED")
+	if _, err := svc.AcknowledgeWaiver(ctx, "m1", "sub-1", w.ID, v2.VersionHash); err != nil {
+		t.Fatalf("AcknowledgeWaiver v2: %v", err)
+	}
+
+	acks, err := svc.ListAcknowledgements(ctx, w.ID)
+	if err != nil || len(acks) != 2 || acks[0].Version != 1 || acks[1].Version != 2 {
+		t.Fatalf("ListAcknowledgements = %+v err=%v", acks, err)
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// AnnouncementAudience picks who an organizer announcement is delivered to.
+type AnnouncementAudience string
+
+const (
+	// AudienceAttendees is every member with a YES RSVP.
+	AudienceAttendees AnnouncementAudience = "ATTENDEES"
+	// AudienceNotAttending is every member who answered NO.
+	AudienceNotAttending AnnouncementAudience = "NOT_ATTENDING"
+	// AudienceEveryone is every active club member.
+	AudienceEveryone AnnouncementAudience = "EVERYONE"
+	// AudienceWaitlisted is every member still waiting on a ride-share seat: a pending ride
+	// request and no place on the trip yet.
+	AudienceWaitlisted AnnouncementAudience = "WAITLISTED"
+)
+
+// Valid reports whether a is a known audience.
+func (a AnnouncementAudience) Valid() bool {
+	switch a {
+	case AudienceAttendees, AudienceNotAttending, AudienceEveryone, AudienceWaitlisted:
+		return true
+	default:
+		return false
+	}
+}
+
+// TripAnnouncement is a message organizers posted to a trip. It stays on the trip for
+// everyone who can see it, whoever it was delivered to.
+type TripAnnouncement struct {
+	ID     TripAnnouncementID
+	TripID TripID
+	// Author is the organizer who posted it; zero when a service account did.
+	Author MemberSummary
+	// ServiceAccount names the API key that posted it, if any.
+	ServiceAccount string
+	Subject        string
+	Body           string
+	Audience       AnnouncementAudience
+
+	CreatedAt time.Time
+}
+
+type AnnouncementDeliveryStatus string
+
+const (
+	DeliveryPending AnnouncementDeliveryStatus = "PENDING"
+	// DeliverySending is a delivery being sent right now.
+	DeliverySending AnnouncementDeliveryStatus = "SENDING"
+	DeliverySent    AnnouncementDeliveryStatus = "SENT"
+	DeliveryFailed  AnnouncementDeliveryStatus = "FAILED"
+)
+
+// AnnouncementDelivery is the outcome of sending an announcement to one recipient.
+type AnnouncementDelivery struct {
+	Recipient MemberSummary
+	Status    AnnouncementDeliveryStatus
+	// Error describes why a FAILED delivery failed.
+	Error string
+	// AttemptedAt is when delivery was last tried; nil while PENDING.
+	AttemptedAt *time.Time
+}
+
+// AnnouncementDeliverySummary counts an announcement's deliveries by status. Pending includes
+// deliveries being sent.
+type AnnouncementDeliverySummary struct {
+	Pending int
+	Sent    int
+	Failed  int
+}
+
+// TripAnnouncementReport is an announcement with its per-recipient delivery status, as seen
+// by organizers.
+type TripAnnouncementReport struct {
+	TripAnnouncement
+	Summary    AnnouncementDeliverySummary
+	Deliveries []AnnouncementDelivery
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// APIKeyScope grants a service account access to a class of operations.
+type APIKeyScope string
+
+const (
+	APIKeyScopeTripsRead          APIKeyScope = "trips:read"
+	APIKeyScopeRSVPSummariesRead  APIKeyScope = "rsvps:read"
+	APIKeyScopeAnnouncementsWrite APIKeyScope = "announcements:write"
+)
+
+// KnownAPIKeyScopes lists every scope that can be granted.
+var KnownAPIKeyScopes = []APIKeyScope{
+	APIKeyScopeTripsRead,
+	APIKeyScopeRSVPSummariesRead,
+	APIKeyScopeAnnouncementsWrite,
+}
+
+// APIKey describes a service-account credential (bots, scheduled jobs).
+// The secret is never part of this model; only its hash is persisted.
+type APIKey struct {
+	ID     APIKeyID
+	Name   string
+	Prefix string
+	Scopes []APIKeyScope
+
+	CreatedBy  string
+	CreatedAt  time.Time
+	RevokedBy  *string
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// HasScope reports whether the key grants scope.
+func (k APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyUsage is one entry of an API key's last-used audit trail: the requests one client made
+// to one method and path on one UTC day.
+type APIKeyUsage struct {
+	KeyID    APIKeyID
+	Day      time.Time // UTC midnight
+	Method   string
+	Path     string
+	ClientIP string
+
+	RequestCount int
+	FirstUsedAt  time.Time
+	LastUsedAt   time.Time
+}
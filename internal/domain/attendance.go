@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+type AttendanceStatus string
+
+const (
+	AttendancePresent AttendanceStatus = "PRESENT"
+	AttendanceAbsent  AttendanceStatus = "ABSENT"
+)
+
+// Valid reports whether s is a known attendance status.
+func (s AttendanceStatus) Valid() bool {
+	switch s {
+	case AttendancePresent, AttendanceAbsent:
+		return true
+	default:
+		return false
+	}
+}
+
+// AttendanceRecord is a member's day-of check-in on a trip.
+type AttendanceRecord struct {
+	Member MemberSummary
+	Status AttendanceStatus
+	// WalkUp marks a member who was checked in without being expected (no YES RSVP or
+	// accepted ride).
+	WalkUp bool
+	// CheckedInAt is when the member was first marked present; nil when absent.
+	CheckedInAt *time.Time
+	RecordedBy  MemberID
+	UpdatedAt   time.Time
+}
+
+// AttendanceSummary counts a trip's check-ins. Present includes walk-ups; NotCheckedIn counts
+// expected attendees with no record yet.
+type AttendanceSummary struct {
+	Present      int
+	Absent       int
+	WalkUps      int
+	NotCheckedIn int
+}
+
+// TripAttendance is a trip's check-in sheet.
+type TripAttendance struct {
+	// CheckInOpen reports whether organizers can check members in right now.
+	CheckInOpen bool
+	Summary     AttendanceSummary
+	// Records is sorted like TripRSVPSummary.AttendingMembers.
+	Records []AttendanceRecord
+}
+
+// MemberReliability summarizes a member's attendance across all trips with check-in records.
+// Attended counts trips they were expected on and showed up to; trips where nobody checked
+// them in are not counted.
+type MemberReliability struct {
+	Member   MemberSummary
+	Attended int
+	NoShows  int
+	WalkUps  int
+	// ShowRatePercent is Attended / (Attended + NoShows), rounded; nil without either.
+	ShowRatePercent *int
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// TripComment is a message in a trip's discussion thread. Replies hang off a top-level
+// comment; threads are one level deep.
+type TripComment struct {
+	ID       TripCommentID
+	TripID   TripID
+	Author   MemberSummary
+	ParentID *TripCommentID
+
+	// Body is Markdown source; clients render it with raw HTML disabled. It is empty once
+	// the comment is deleted.
+	Body   string
+	Pinned bool
+
+	CreatedAt time.Time
+	// EditedAt is when the author last changed Body; nil if never edited.
+	EditedAt *time.Time
+	// DeletedAt is set when the author or an organizer removed the comment. Deleted comments
+	// stay in the thread as placeholders so their replies keep their context.
+	DeletedAt *time.Time
+}
+
+// TripCommentPage is one page of a trip's thread, oldest first.
+type TripCommentPage struct {
+	// Pinned lists the trip's pinned comments, only on the first page.
+	Pinned   []TripComment
+	Comments []TripComment
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
+}
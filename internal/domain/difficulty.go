@@ -0,0 +1,54 @@
+package domain
+
+import "slices"
+
+// TerrainTag names a kind of terrain a trip crosses.
+type TerrainTag string
+
+const (
+	TerrainRock          TerrainTag = "ROCK"
+	TerrainSand          TerrainTag = "SAND"
+	TerrainMud           TerrainTag = "MUD"
+	TerrainSnow          TerrainTag = "SNOW"
+	TerrainWaterCrossing TerrainTag = "WATER_CROSSING"
+	TerrainShelfRoad     TerrainTag = "SHELF_ROAD"
+)
+
+// TerrainTags lists the terrain tags in display order.
+var TerrainTags = []TerrainTag{
+	TerrainRock,
+	TerrainSand,
+	TerrainMud,
+	TerrainSnow,
+	TerrainWaterCrossing,
+	TerrainShelfRoad,
+}
+
+func (t TerrainTag) Valid() bool {
+	return slices.Contains(TerrainTags, t)
+}
+
+// Difficulty is the structured difficulty of a trip on the club's 1..N rating scale.
+// DifficultyText remains as free-text notes alongside it.
+type Difficulty struct {
+	// Rating is the difficulty of the main line.
+	Rating int
+	// MinRating and MaxRating optionally widen the rating for routes with bypasses (easier)
+	// or optional hard lines (harder); nil means the same as Rating.
+	MinRating *int
+	MaxRating *int
+
+	Terrain []TerrainTag
+}
+
+// Range returns the easiest and hardest ratings the trip covers.
+func (d Difficulty) Range() (lo, hi int) {
+	lo, hi = d.Rating, d.Rating
+	if d.MinRating != nil {
+		lo = *d.MinRating
+	}
+	if d.MaxRating != nil {
+		hi = *d.MaxRating
+	}
+	return lo, hi
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// BloodTypes lists the accepted EmergencyInfo.BloodType values.
+var BloodTypes = []string{"A+", "A-", "B+", "B-", "AB+", "AB-", "O+", "O-"}
+
+// EmergencyInfo is who to call if something happens to a member on a trip, plus optional
+// medical notes. It is encrypted at rest and only shown to the member and, around the trip
+// dates, to organizers of trips the member is attending.
+type EmergencyInfo struct {
+	ContactName  string
+	ContactPhone string
+	MedicalNotes *string
+	BloodType    *string
+	UpdatedAt    time.Time
+}
+
+type EmergencyInfoAction string
+
+const (
+	EmergencyInfoViewed  EmergencyInfoAction = "VIEW"
+	EmergencyInfoUpdated EmergencyInfoAction = "UPDATE"
+	EmergencyInfoDeleted EmergencyInfoAction = "DELETE"
+)
+
+// EmergencyInfoAccess is one audited access to a member's emergency info.
+type EmergencyInfoAccess struct {
+	// AccessedBy is the member themself or an organizer (empty once that member is deleted).
+	AccessedBy MemberID
+	// TripID is the trip an organizer read it for; empty for the member's own accesses.
+	TripID     TripID
+	Action     EmergencyInfoAction
+	AccessedAt time.Time
+}
+
+// AttendeeEmergencyInfo pairs a trip attendee with their emergency info; Info is nil when
+// they have not provided any.
+type AttendeeEmergencyInfo struct {
+	Member MemberSummary
+	Info   *EmergencyInfo
+}
@@ -9,3 +9,30 @@ type MemberID string
 
 // TripID is an internal identifier for a trip record.
 type TripID string
+
+// APIKeyID is an internal identifier for a service-account API key.
+type APIKeyID string
+
+// InvitationID is an internal identifier for a member invitation.
+type InvitationID string
+
+// VehicleID is an internal identifier for a vehicle in a member's garage.
+type VehicleID string
+
+// RideRequestID is an internal identifier for a ride-share seat request.
+type RideRequestID string
+
+// TripTemplateID is an internal identifier for a reusable trip template.
+type TripTemplateID string
+
+// TripSeriesID is an internal identifier for a recurring trip series.
+type TripSeriesID string
+
+// WaiverID is an internal identifier for a liability waiver document.
+type WaiverID string
+
+// TripCommentID is an internal identifier for a comment in a trip's discussion thread.
+type TripCommentID string
+
+// TripAnnouncementID is an internal identifier for an organizer announcement on a trip.
+type TripAnnouncementID string
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Invitation lets a new member self-provision when membership is invite-only.
+// The invite code itself is never stored; only HashInviteCode of it is persisted.
+type Invitation struct {
+	ID InvitationID
+
+	// Email optionally binds the invitation to one address (case-insensitive); nil means anyone with the code.
+	Email *string
+
+	MaxUses  int
+	UseCount int
+
+	// Exactly one of CreatedByMemberID (member-issued) or CreatedByAdmin (operator-issued) is set.
+	CreatedByMemberID *MemberID
+	CreatedByAdmin    string
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// InvitationRedemption records that MemberID joined using InvitationID (who invited whom).
+type InvitationRedemption struct {
+	InvitationID InvitationID
+	MemberID     MemberID
+	RedeemedAt   time.Time
+}
+
+// NormalizeInviteCode uppercases the code and drops separators so "abcd-efgh" and "ABCDEFGH" match.
+func NormalizeInviteCode(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// HashInviteCode returns the hex SHA-256 of the normalized code; this is what is persisted.
+func HashInviteCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeInviteCode(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+type ItineraryStopType string
+
+const (
+	ItineraryStopMeetingPoint ItineraryStopType = "MEETING_POINT"
+	ItineraryStopCampsite     ItineraryStopType = "CAMPSITE"
+	ItineraryStopFuel         ItineraryStopType = "FUEL"
+	ItineraryStopWaypoint     ItineraryStopType = "WAYPOINT"
+)
+
+// Valid reports whether t is a known stop type.
+func (t ItineraryStopType) Valid() bool {
+	switch t {
+	case ItineraryStopMeetingPoint, ItineraryStopCampsite, ItineraryStopFuel, ItineraryStopWaypoint:
+		return true
+	default:
+		return false
+	}
+}
+
+// ItineraryStop is a place the group visits during a day.
+type ItineraryStop struct {
+	Type     ItineraryStopType
+	Location Location
+	// Time is the local wall-clock time ("HH:MM") the group is expected there, if planned.
+	Time  *string
+	Notes *string
+}
+
+// RouteSegment is a stretch of trail or road driven during a day.
+type RouteSegment struct {
+	Name          string
+	DistanceMiles *float64
+	Notes         *string
+}
+
+// ItineraryDay is the plan for one date of a (usually multi-day) trip. Stops and Segments are
+// in travel order. Date is a UTC calendar date within the trip's StartDate..EndDate.
+type ItineraryDay struct {
+	Date     time.Time
+	Title    *string
+	Notes    *string
+	Stops    []ItineraryStop
+	Segments []RouteSegment
+
+	UpdatedAt time.Time
+}
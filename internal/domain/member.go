@@ -25,8 +25,34 @@ type Member struct {
 	GroupAliasEmail *string
 	VehicleProfile  *VehicleProfile
 
+	// EmailVerifiedAt / GroupAliasEmailVerifiedAt are set once the member proves control of the
+	// address; nil means unverified. Changing an address resets its verification.
+	EmailVerifiedAt           *time.Time
+	GroupAliasEmailVerifiedAt *time.Time
+
 	IsActive bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// NotificationEmails returns the member's verified addresses, primary first.
+// Notifications must only be sent to these; unverified addresses may belong to someone else.
+func (m Member) NotificationEmails() []string {
+	var out []string
+	if m.EmailVerifiedAt != nil && m.Email != "" {
+		out = append(out, m.Email)
+	}
+	if m.GroupAliasEmailVerifiedAt != nil && m.GroupAliasEmail != nil && *m.GroupAliasEmail != "" {
+		out = append(out, *m.GroupAliasEmail)
+	}
+	return out
+}
+
+// MemberIdentity is one login (token issuer + subject) bound to a member.
+// A member may have several, e.g. after switching identity providers.
+type MemberIdentity struct {
+	Issuer   string
+	Subject  SubjectID
+	LinkedAt time.Time
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency is how often a trip series repeats.
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// Recurrence is the subset of RFC 5545 RRULEs a trip series supports: every N weeks on a
+// weekday, or every N months on the first..fourth or last weekday of the month.
+type Recurrence struct {
+	Frequency RecurrenceFrequency
+	// Interval is the number of weeks or months between occurrences (>= 1).
+	Interval int
+	Weekday  time.Weekday
+	// Week picks the weekday within the month for MONTHLY rules: 1..4, or -1 for the last.
+	// It is zero for WEEKLY rules.
+	Week int
+}
+
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRecurrence parses an RRULE such as "FREQ=WEEKLY;BYDAY=TU" or
+// "FREQ=MONTHLY;INTERVAL=1;BYDAY=2SA". Monthly rules may give the week either as a BYDAY
+// prefix or as BYSETPOS. COUNT and UNTIL are not supported; series carry their own end date.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(rule)), "RRULE:")
+	if rule == "" {
+		return Recurrence{}, errors.New("empty rule")
+	}
+	r := Recurrence{Interval: 1}
+	var byDay, bySetPos string
+	for _, part := range strings.Split(rule, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("malformed rule part %q", part)
+		}
+		switch k {
+		case "FREQ":
+			r.Frequency = RecurrenceFrequency(v)
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return Recurrence{}, errors.New("INTERVAL must be a positive integer")
+			}
+			r.Interval = n
+		case "BYDAY":
+			byDay = v
+		case "BYSETPOS":
+			bySetPos = v
+		case "WKST":
+			// Irrelevant for single-weekday rules.
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported rule part %s", k)
+		}
+	}
+	if r.Frequency != RecurrenceWeekly && r.Frequency != RecurrenceMonthly {
+		return Recurrence{}, errors.New("FREQ must be WEEKLY or MONTHLY")
+	}
+	if byDay == "" || strings.Contains(byDay, ",") || len(byDay) < 2 {
+		return Recurrence{}, errors.New("BYDAY must name exactly one weekday")
+	}
+	day := -1
+	for i, d := range rruleDays {
+		if strings.HasSuffix(byDay, d) {
+			day = i
+		}
+	}
+	if day < 0 {
+		return Recurrence{}, fmt.Errorf("unknown weekday %q", byDay)
+	}
+	r.Weekday = time.Weekday(day)
+
+	pos := strings.TrimSuffix(byDay, rruleDays[day])
+	if pos != "" && bySetPos != "" {
+		return Recurrence{}, errors.New("give the week as a BYDAY prefix or BYSETPOS, not both")
+	}
+	if pos == "" {
+		pos = bySetPos
+	}
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		if pos != "" {
+			return Recurrence{}, errors.New("weekly rules cannot pick a week of the month")
+		}
+	case RecurrenceMonthly:
+		if pos == "" {
+			return Recurrence{}, errors.New("monthly rules must pick a week of the month")
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(pos, "+"))
+		if err != nil || (n < 1 || n > 4) && n != -1 {
+			return Recurrence{}, errors.New("week of the month must be 1..4 or -1")
+		}
+		r.Week = n
+	}
+	return r, nil
+}
+
+// String returns the canonical RRULE for r.
+func (r Recurrence) String() string {
+	day := rruleDays[r.Weekday]
+	if r.Frequency == RecurrenceMonthly {
+		day = strconv.Itoa(r.Week) + day
+	}
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d;BYDAY=%s", r.Frequency, r.Interval, day)
+}
+
+// Dates returns the dates the rule produces from start (counting intervals from start's week
+// or month) that fall within [from, through]. All values are UTC calendar dates.
+func (r Recurrence) Dates(start, from, through time.Time) []time.Time {
+	start, from, through = dateOnly(start), dateOnly(from), dateOnly(through)
+	if from.Before(start) {
+		from = start
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var out []time.Time
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		d := start.AddDate(0, 0, (int(r.Weekday)-int(start.Weekday())+7)%7)
+		for ; !d.After(through); d = d.AddDate(0, 0, 7*interval) {
+			if !d.Before(from) {
+				out = append(out, d)
+			}
+		}
+	case RecurrenceMonthly:
+		month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		for ; !month.After(through); month = month.AddDate(0, interval, 0) {
+			d := r.dayInMonth(month)
+			if !d.Before(from) && !d.After(through) {
+				out = append(out, d)
+			}
+		}
+	}
+	return out
+}
+
+// dayInMonth returns the rule's weekday in the month starting at first.
+func (r Recurrence) dayInMonth(first time.Time) time.Time {
+	if r.Week == -1 {
+		last := first.AddDate(0, 1, -1)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(r.Weekday) + 7) % 7))
+	}
+	d := first.AddDate(0, 0, (int(r.Weekday)-int(first.Weekday())+7)%7)
+	return d.AddDate(0, 0, 7*(r.Week-1))
+}
+
+func dateOnly(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RecoveryGearItem is one entry of the recovery gear checklist shared by trip requirements
+// and vehicle specs.
+type RecoveryGearItem string
+
+const (
+	RecoveryGearStrap            RecoveryGearItem = "RECOVERY_STRAP"
+	RecoveryGearShackles         RecoveryGearItem = "SHACKLES"
+	RecoveryGearTractionBoards   RecoveryGearItem = "TRACTION_BOARDS"
+	RecoveryGearWinch            RecoveryGearItem = "WINCH"
+	RecoveryGearHiLiftJack       RecoveryGearItem = "HI_LIFT_JACK"
+	RecoveryGearShovel           RecoveryGearItem = "SHOVEL"
+	RecoveryGearAirCompressor    RecoveryGearItem = "AIR_COMPRESSOR"
+	RecoveryGearTireRepairKit    RecoveryGearItem = "TIRE_REPAIR_KIT"
+	RecoveryGearFirstAidKit      RecoveryGearItem = "FIRST_AID_KIT"
+	RecoveryGearFireExtinguisher RecoveryGearItem = "FIRE_EXTINGUISHER"
+)
+
+// RecoveryGearItems lists the checklist in display order.
+var RecoveryGearItems = []RecoveryGearItem{
+	RecoveryGearStrap,
+	RecoveryGearShackles,
+	RecoveryGearTractionBoards,
+	RecoveryGearWinch,
+	RecoveryGearHiLiftJack,
+	RecoveryGearShovel,
+	RecoveryGearAirCompressor,
+	RecoveryGearTireRepairKit,
+	RecoveryGearFirstAidKit,
+	RecoveryGearFireExtinguisher,
+}
+
+func (g RecoveryGearItem) Valid() bool {
+	return slices.Contains(RecoveryGearItems, g)
+}
+
+// VehicleSpecs are the structured vehicle attributes checked against trip requirements.
+// Unlike VehicleProfile (free text for people to read), they are meant for the planner.
+type VehicleSpecs struct {
+	// TireSizeInches is the tire diameter in whole inches; nil means unknown.
+	TireSizeInches *int
+	HasLockers     bool
+	RecoveryGear   []RecoveryGearItem
+	// HamLicensed records that the driver holds an amateur radio license.
+	HamLicensed bool
+}
+
+// TripRequirements are the structured vehicle requirements for a trip. The zero value
+// requires nothing.
+type TripRequirements struct {
+	MinTireSizeInches  *int
+	LockersRequired    bool
+	RecoveryGear       []RecoveryGearItem
+	HamLicenseRequired bool
+
+	// Strict blocks YES RSVPs that do not meet the requirements; otherwise unmet requirements
+	// are only reported as warnings.
+	Strict bool
+}
+
+// IsZero reports whether the trip has no vehicle requirements.
+func (r TripRequirements) IsZero() bool {
+	return r.MinTireSizeInches == nil && !r.LockersRequired && len(r.RecoveryGear) == 0 && !r.HamLicenseRequired
+}
+
+// RequirementCode identifies an unmet trip requirement.
+type RequirementCode string
+
+const (
+	RequirementVehicleRequired RequirementCode = "VEHICLE_REQUIRED"
+	RequirementTireSize        RequirementCode = "TIRE_SIZE_BELOW_MINIMUM"
+	RequirementLockers         RequirementCode = "LOCKERS_REQUIRED"
+	RequirementRecoveryGear    RequirementCode = "RECOVERY_GEAR_MISSING"
+	RequirementHamLicense      RequirementCode = "HAM_LICENSE_REQUIRED"
+)
+
+// UnmetRequirement explains one requirement a vehicle does not meet. MissingGear is only set
+// for RECOVERY_GEAR_MISSING.
+type UnmetRequirement struct {
+	Code        RequirementCode
+	Message     string
+	MissingGear []RecoveryGearItem
+}
+
+// Check returns the requirements v does not meet, in a stable order. A nil vehicle meets none
+// of them and is reported as a single VEHICLE_REQUIRED.
+func (r TripRequirements) Check(v *Vehicle) []UnmetRequirement {
+	if r.IsZero() {
+		return nil
+	}
+	if v == nil {
+		return []UnmetRequirement{{Code: RequirementVehicleRequired, Message: "no vehicle named for this trip"}}
+	}
+
+	var out []UnmetRequirement
+	specs := v.Specs
+	if r.MinTireSizeInches != nil {
+		switch {
+		case specs.TireSizeInches == nil:
+			out = append(out, UnmetRequirement{Code: RequirementTireSize, Message: fmt.Sprintf("tire size unknown; at least %d in required", *r.MinTireSizeInches)})
+		case *specs.TireSizeInches < *r.MinTireSizeInches:
+			out = append(out, UnmetRequirement{Code: RequirementTireSize, Message: fmt.Sprintf("%d in tires; at least %d in required", *specs.TireSizeInches, *r.MinTireSizeInches)})
+		}
+	}
+	if r.LockersRequired && !specs.HasLockers {
+		out = append(out, UnmetRequirement{Code: RequirementLockers, Message: "lockers required"})
+	}
+	var missing []RecoveryGearItem
+	for _, g := range r.RecoveryGear {
+		if !slices.Contains(specs.RecoveryGear, g) {
+			missing = append(missing, g)
+		}
+	}
+	if len(missing) > 0 {
+		out = append(out, UnmetRequirement{Code: RequirementRecoveryGear, Message: "missing required recovery gear", MissingGear: missing})
+	}
+	if r.HamLicenseRequired && !specs.HamLicensed {
+		out = append(out, UnmetRequirement{Code: RequirementHamLicense, Message: "ham radio license required"})
+	}
+	return out
+}
+
+// RequirementsRosterEntry is one attending rig checked against the trip's requirements.
+type RequirementsRosterEntry struct {
+	Member  MemberSummary
+	Vehicle *Vehicle
+	Unmet   []UnmetRequirement
+}
+
+// RequirementsRoster is the organizer view of which attendees meet the trip's requirements.
+type RequirementsRoster struct {
+	TripID       TripID
+	Requirements TripRequirements
+	Entries      []RequirementsRosterEntry
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+type RideRequestStatus string
+
+const (
+	RideRequestStatusPending  RideRequestStatus = "PENDING"
+	RideRequestStatusAccepted RideRequestStatus = "ACCEPTED"
+	RideRequestStatusDeclined RideRequestStatus = "DECLINED"
+	RideRequestStatusCanceled RideRequestStatus = "CANCELED"
+)
+
+// RideOffer is a driver's advertisement of open seats on a trip. SeatsTaken counts accepted riders.
+type RideOffer struct {
+	TripID     TripID
+	Driver     MemberSummary
+	Seats      int
+	SeatsTaken int
+	Notes      *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RideRequest is a rider's request for a seat in a driver's vehicle.
+type RideRequest struct {
+	ID     RideRequestID
+	TripID TripID
+	Driver MemberSummary
+	Rider  MemberSummary
+	Status RideRequestStatus
+	Note   *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RideShareBoard is a trip's ride-share board as seen by one member: every offer, plus the
+// requests the member is part of (as rider or driver).
+type RideShareBoard struct {
+	TripID     TripID
+	Offers     []RideOffer
+	MyRequests []RideRequest
+}
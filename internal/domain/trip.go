@@ -51,6 +51,12 @@ type TripSummary struct {
 
 	CapacityRigs  *int
 	AttendingRigs *int
+	// CapacityPeople optionally caps headcount (drivers plus passengers); nil means unlimited.
+	CapacityPeople *int
+
+	// Difficulty is the structured rating; nil means the trip has not been rated.
+	// TripDetails.DifficultyText holds free-text notes alongside it.
+	Difficulty *Difficulty
 }
 
 type MemberSummary struct {
@@ -69,9 +75,17 @@ type TripDetails struct {
 	MeetingLocation             *Location
 	CommsRequirementsText       *string
 	RecommendedRequirementsText *string
+	// Requirements are the structured counterpart of RecommendedRequirementsText.
+	Requirements TripRequirements
+	// RSVPDeadline is when members can no longer change their own RSVP; nil means no deadline.
+	RSVPDeadline *time.Time
 
 	Organizers []MemberSummary
 	Artifacts  []TripArtifact
+	// Itinerary is the per-day plan ordered by date; empty when none has been written.
+	Itinerary []ItineraryDay
+	// Announcements are the organizers' announcements, newest first.
+	Announcements []TripAnnouncement
 
 	// RSVP-related fields are introduced in later milestones; nil means "omitted".
 	RSVPSummary        *TripRSVPSummary
@@ -80,12 +94,22 @@ type TripDetails struct {
 }
 
 type TripRSVPSummary struct {
-	CapacityRigs *int
+	CapacityRigs   *int
+	CapacityPeople *int
 
 	AttendingRigs int
+	// AttendingPeople counts attending members plus their passengers and accepted ride-share riders.
+	AttendingPeople int
 
+	// AttendingMembers includes accepted ride-share riders, who attend without a rig.
 	AttendingMembers    []MemberSummary
 	NotAttendingMembers []MemberSummary
+
+	// AttendeeRigs lists the vehicle each member with a YES RSVP is bringing, sorted like AttendingMembers.
+	AttendeeRigs []AttendeeRig
+
+	// Attendance counts day-of check-ins; nil until the first member is checked in.
+	Attendance *AttendanceSummary
 }
 
 type RSVPResponse string
@@ -101,4 +125,37 @@ type MyRSVP struct {
 	MemberID  MemberID
 	Response  RSVPResponse
 	UpdatedAt time.Time
+
+	// VehicleID is the rig the member is bringing; only set for YES.
+	VehicleID *VehicleID
+	// PassengerCount is how many people ride along with the member (YES only).
+	// PassengerNames optionally names some or all of them.
+	PassengerCount int
+	PassengerNames []string
+
+	// ChangedBy is who made the last change: the member, or an organizer on their behalf
+	// (empty when unknown). ChangeReason is the organizer's note; CapacityOverride marks a
+	// YES an organizer admitted beyond the trip's capacity.
+	ChangedBy        MemberID
+	ChangeReason     *string
+	CapacityOverride bool
+
+	// RequirementWarnings lists the trip requirements the RSVP's vehicle does not meet. Only
+	// SetMyRSVP reports it, for an accepted YES; strict trips reject a changed YES instead.
+	RequirementWarnings []UnmetRequirement
+}
+
+// RSVPChange is one entry in an RSVP's history: a member's response moving from
+// PreviousResponse (nil when they had no RSVP yet) to Response.
+type RSVPChange struct {
+	TripID           TripID
+	MemberID         MemberID
+	PreviousResponse *RSVPResponse
+	Response         RSVPResponse
+
+	// ChangedBy is the member or organizer who made the change (empty when unknown);
+	// ChangeReason is the organizer's note.
+	ChangedBy    MemberID
+	ChangeReason *string
+	ChangedAt    time.Time
 }
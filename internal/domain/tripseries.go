@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// TripSeries generates trips on a recurring schedule. Each occurrence is an ordinary trip
+// created from Plan and linked back to the series; editing or canceling one occurrence does
+// not change the series.
+type TripSeries struct {
+	ID              TripSeriesID
+	CreatedByMember MemberID
+	Recurrence      Recurrence
+	// StartDate is the first date the recurrence is evaluated from; EndDate, when set, is the
+	// last date an occurrence may start on.
+	StartDate time.Time
+	EndDate   *time.Time
+	// DurationDays is how many days each occurrence spans (1 = a single day).
+	DurationDays int
+	// Publish generates published occurrences instead of private drafts.
+	Publish bool
+	// Plan is copied into each new occurrence. "All future occurrences" edits update it too.
+	Plan TripPlan
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
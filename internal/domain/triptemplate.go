@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// TripPlan is the reusable planning content of a trip: what CloneTrip and trip templates copy.
+// Dates, organizers, RSVPs and status are deliberately not part of it.
+type TripPlan struct {
+	Name                        *string
+	Description                 *string
+	CapacityRigs                *int
+	CapacityPeople              *int
+	Difficulty                  *Difficulty
+	DifficultyText              *string
+	MeetingLocation             *Location
+	CommsRequirementsText       *string
+	RecommendedRequirementsText *string
+	Requirements                TripRequirements
+
+	// Artifacts are copied with fresh IDs whenever the plan is turned into a trip.
+	Artifacts []TripArtifact
+}
+
+// TripTemplate is a named, club-wide TripPlan that organizers start new drafts from.
+type TripTemplate struct {
+	ID TripTemplateID
+	// Name identifies the template (unique, case-insensitive); Plan.Name is the trip name it
+	// suggests.
+	Name            string
+	CreatedByMember MemberID
+	Plan            TripPlan
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
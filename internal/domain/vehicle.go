@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Vehicle is one named rig in a member's garage.
+//
+// A member with any vehicles has exactly one default; it is the rig assumed for RSVPs that
+// don't name one, and its profile is what the member profile reports as VehicleProfile.
+type Vehicle struct {
+	ID       VehicleID
+	MemberID MemberID
+
+	Name      string
+	IsDefault bool
+	Profile   VehicleProfile
+	Specs     VehicleSpecs
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AttendeeRig pairs an attending member with the vehicle they are bringing and who rides
+// along; Vehicle is nil when the member has not named one (and has no default). Riders are
+// the ride-share members the driver accepted.
+type AttendeeRig struct {
+	Member  MemberSummary
+	Vehicle *Vehicle
+
+	PassengerCount int
+	PassengerNames []string
+	Riders         []MemberSummary
+}
+
+// DefaultVehicleName names the vehicle created when a member sets a vehicle profile without
+// using the garage (e.g. via the member profile).
+const DefaultVehicleName = "My vehicle"
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Waiver is the current version of a liability waiver document. Publishing a new version
+// (e.g. for a new season) means members must acknowledge it again.
+type Waiver struct {
+	ID    WaiverID
+	Title string
+	// RequiredForAllTrips marks a club-wide waiver every YES RSVP needs; other waivers are
+	// only required by trips that list them.
+	RequiredForAllTrips bool
+
+	Version int
+	// VersionHash is the hex SHA-256 of Body; acknowledgements name it so members agree to
+	// exactly the text they were shown.
+	VersionHash string
+	Body        string
+	PublishedAt time.Time
+}
+
+// WaiverAcknowledgement records a member agreeing to one version of a waiver.
+type WaiverAcknowledgement struct {
+	WaiverID    WaiverID
+	Version     int
+	VersionHash string
+	MemberID    MemberID
+	// Subject is the authenticated subject the member signed in as when acknowledging.
+	Subject        SubjectID
+	AcknowledgedAt time.Time
+}
+
+// MemberWaiver is a waiver as seen by a member: AcknowledgedAt is when they acknowledged its
+// current version, nil if they have not.
+type MemberWaiver struct {
+	Waiver
+	AcknowledgedAt *time.Time
+}
@@ -0,0 +1,28 @@
+// Package authctx carries the verified token issuer through request context.
+//
+// Subjects are only unique per issuer, so storage adapters that key rows by
+// (issuer, subject) read the issuer from here instead of assuming a single IdP.
+package authctx
+
+import "context"
+
+type issuerKey struct{}
+
+// WithIssuer records the verified issuer (JWT `iss`) of the request's caller.
+func WithIssuer(ctx context.Context, issuer string) context.Context {
+	return context.WithValue(ctx, issuerKey{}, issuer)
+}
+
+func IssuerFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(issuerKey{}).(string)
+	return v, ok && v != ""
+}
+
+// IssuerOr returns the request issuer, or def when the context carries none
+// (dev auth, background jobs, tooling).
+func IssuerOr(ctx context.Context, def string) string {
+	if iss, ok := IssuerFromContext(ctx); ok {
+		return iss
+	}
+	return def
+}
@@ -31,11 +31,22 @@ type realClock struct{}
 
 func (realClock) Now() time.Time { return time.Now() }
 
+// Verifier verifies RS256 JWTs from one or more trusted issuers.
+//
+// Each issuer has its own audience and JWKS; signing keys are cached per issuer so a
+// rotation (or outage) at one provider does not affect the others.
 type Verifier struct {
 	cfg    config.JWTConfig
 	client *http.Client
 	clock  Clock
 
+	issuers map[string]*issuerKeys
+}
+
+// issuerKeys is the JWKS cache for one trusted issuer.
+type issuerKeys struct {
+	cfg config.JWTIssuer
+
 	mu          sync.Mutex
 	keysByKID   map[string]*rsa.PublicKey
 	lastRefresh time.Time
@@ -43,6 +54,13 @@ type Verifier struct {
 	refreshDone chan struct{}
 }
 
+// Identity is the verified caller: the token issuer and its `sub` claim.
+// Subjects are only unique within an issuer.
+type Identity struct {
+	Issuer  string
+	Subject string
+}
+
 func New(cfg config.JWTConfig) *Verifier {
 	return NewWithOptions(cfg, nil, nil)
 }
@@ -54,11 +72,18 @@ func NewWithOptions(cfg config.JWTConfig, httpClient *http.Client, clock Clock)
 	if clock == nil {
 		clock = realClock{}
 	}
+	issuers := make(map[string]*issuerKeys)
+	for _, iss := range cfg.TrustedIssuers() {
+		issuers[iss.Issuer] = &issuerKeys{
+			cfg:       iss,
+			keysByKID: map[string]*rsa.PublicKey{},
+		}
+	}
 	return &Verifier{
-		cfg:       cfg,
-		client:    httpClient,
-		clock:     clock,
-		keysByKID: map[string]*rsa.PublicKey{},
+		cfg:     cfg,
+		client:  httpClient,
+		clock:   clock,
+		issuers: issuers,
 	}
 }
 
@@ -78,49 +103,66 @@ type jwtClaims struct {
 
 // Verify verifies a JWT and returns the authenticated subject from the `sub` claim.
 //
-// Verification:
-// - RS256 signature using keys fetched from JWKS
-// - iss, aud, exp, and nbf (when present)
+// Callers that persist or look up subjects should use VerifyIdentity, since subjects are
+// only unique per issuer.
 func (v *Verifier) Verify(ctx context.Context, token string) (string, error) {
+	id, err := v.VerifyIdentity(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return id.Subject, nil
+}
+
+// VerifyIdentity verifies a JWT and returns the issuer and subject.
+//
+// Verification:
+// - the (unverified) `iss` claim selects a trusted issuer; unknown issuers are rejected
+// - RS256 signature using keys fetched from that issuer's JWKS
+// - iss, aud (per issuer), exp, and nbf (when present)
+func (v *Verifier) VerifyIdentity(ctx context.Context, token string) (Identity, error) {
 	h, claims, signingInput, sig, err := parseJWT(token)
 	if err != nil {
-		return "", ErrUnauthorized
+		return Identity{}, ErrUnauthorized
 	}
 	if h.Alg != "RS256" || h.Kid == "" {
-		return "", ErrUnauthorized
+		return Identity{}, ErrUnauthorized
+	}
+	ik := v.issuers[claims.Iss]
+	if ik == nil {
+		return Identity{}, ErrUnauthorized
 	}
 
 	// Refresh rules:
 	// - refresh periodically (rotation), even if kid exists in cache
 	// - refresh on unknown kid, bounded by min refresh interval
-	if err := v.maybeRefresh(ctx, h.Kid); err != nil {
-		return "", ErrUnauthorized
+	if err := v.maybeRefresh(ctx, ik, h.Kid); err != nil {
+		return Identity{}, ErrUnauthorized
 	}
 
-	pub := v.getKey(h.Kid)
+	pub := ik.getKey(h.Kid)
 	if pub == nil {
-		return "", ErrUnauthorized
+		return Identity{}, ErrUnauthorized
 	}
 	if err := verifyRS256(pub, signingInput, sig); err != nil {
-		return "", ErrUnauthorized
+		return Identity{}, ErrUnauthorized
 	}
-	if err := v.validateClaims(claims); err != nil {
-		return "", ErrUnauthorized
+	if err := v.validateClaims(ik.cfg, claims); err != nil {
+		return Identity{}, ErrUnauthorized
 	}
 	if claims.Sub == "" {
-		return "", ErrUnauthorized
+		return Identity{}, ErrUnauthorized
 	}
-	return claims.Sub, nil
+	return Identity{Issuer: claims.Iss, Subject: claims.Sub}, nil
 }
 
-func (v *Verifier) validateClaims(c jwtClaims) error {
+func (v *Verifier) validateClaims(iss config.JWTIssuer, c jwtClaims) error {
 	now := v.clock.Now()
 	skew := v.cfg.ClockSkew
 
-	if c.Iss != v.cfg.Issuer {
+	if c.Iss != iss.Issuer {
 		return fmt.Errorf("iss mismatch")
 	}
-	if !audMatches(c.Aud, v.cfg.Audience) {
+	if !audMatches(c.Aud, iss.Audience) {
 		return fmt.Errorf("aud mismatch")
 	}
 	if c.Exp == nil {
@@ -135,30 +177,30 @@ func (v *Verifier) validateClaims(c jwtClaims) error {
 	return nil
 }
 
-func (v *Verifier) getKey(kid string) *rsa.PublicKey {
-	v.mu.Lock()
-	defer v.mu.Unlock()
-	return v.keysByKID[kid]
+func (ik *issuerKeys) getKey(kid string) *rsa.PublicKey {
+	ik.mu.Lock()
+	defer ik.mu.Unlock()
+	return ik.keysByKID[kid]
 }
 
-func (v *Verifier) maybeRefresh(ctx context.Context, kid string) error {
+func (v *Verifier) maybeRefresh(ctx context.Context, ik *issuerKeys, kid string) error {
 	now := v.clock.Now()
 
-	v.mu.Lock()
-	needsIntervalRefresh := !v.lastRefresh.IsZero() && v.cfg.JWKSRefreshInterval > 0 && now.Sub(v.lastRefresh) >= v.cfg.JWKSRefreshInterval
-	unknownKid := v.keysByKID[kid] == nil
-	allowedUnknownKidRefresh := v.lastRefresh.IsZero() || v.cfg.JWKSMinRefreshInterval <= 0 || now.Sub(v.lastRefresh) >= v.cfg.JWKSMinRefreshInterval
+	ik.mu.Lock()
+	needsIntervalRefresh := !ik.lastRefresh.IsZero() && v.cfg.JWKSRefreshInterval > 0 && now.Sub(ik.lastRefresh) >= v.cfg.JWKSRefreshInterval
+	unknownKid := ik.keysByKID[kid] == nil
+	allowedUnknownKidRefresh := ik.lastRefresh.IsZero() || v.cfg.JWKSMinRefreshInterval <= 0 || now.Sub(ik.lastRefresh) >= v.cfg.JWKSMinRefreshInterval
 	shouldRefresh := needsIntervalRefresh || (unknownKid && allowedUnknownKidRefresh)
 
 	if !shouldRefresh {
-		v.mu.Unlock()
+		ik.mu.Unlock()
 		return nil
 	}
 
 	// Deduplicate concurrent refresh attempts.
-	if v.refreshing {
-		ch := v.refreshDone
-		v.mu.Unlock()
+	if ik.refreshing {
+		ch := ik.refreshDone
+		ik.mu.Unlock()
 		select {
 		case <-ch:
 			return nil
@@ -167,23 +209,23 @@ func (v *Verifier) maybeRefresh(ctx context.Context, kid string) error {
 		}
 	}
 
-	v.refreshing = true
-	v.refreshDone = make(chan struct{})
-	ch := v.refreshDone
-	v.mu.Unlock()
+	ik.refreshing = true
+	ik.refreshDone = make(chan struct{})
+	ch := ik.refreshDone
+	ik.mu.Unlock()
 
-	err := v.refresh(ctx)
+	err := v.refresh(ctx, ik)
 
-	v.mu.Lock()
-	v.refreshing = false
+	ik.mu.Lock()
+	ik.refreshing = false
 	close(ch)
-	v.mu.Unlock()
+	ik.mu.Unlock()
 
 	return err
 }
 
-func (v *Verifier) refresh(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+func (v *Verifier) refresh(ctx context.Context, ik *issuerKeys) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ik.cfg.JWKSURL, nil)
 	if err != nil {
 		return err
 	}
@@ -205,10 +247,10 @@ func (v *Verifier) refresh(ctx context.Context) error {
 		return err
 	}
 
-	v.mu.Lock()
-	v.keysByKID = keys
-	v.lastRefresh = v.clock.Now()
-	v.mu.Unlock()
+	ik.mu.Lock()
+	ik.keysByKID = keys
+	ik.lastRefresh = v.clock.Now()
+	ik.mu.Unlock()
 
 	return nil
 }
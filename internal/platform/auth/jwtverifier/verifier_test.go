@@ -192,3 +192,62 @@ func TestVerifier_Verify_JWKSRotation_OldKidRejected_NewKidAccepted(t *testing.T
 		t.Fatalf("sub mismatch: got %q", sub)
 	}
 }
+
+func TestVerifier_VerifyIdentity_MultipleIssuers(t *testing.T) {
+	t.Parallel()
+
+	srvA, setA := jwks_testutil.NewRotatingJWKSServer()
+	defer srvA.Close()
+	srvB, setB := jwks_testutil.NewRotatingJWKSServer()
+	defer srvB.Close()
+
+	kpA, _ := jwks_testutil.GenerateRSAKeypair("kid-a")
+	kpB, _ := jwks_testutil.GenerateRSAKeypair("kid-b")
+	setA([]jwks_testutil.Keypair{kpA})
+	setB([]jwks_testutil.Keypair{kpB})
+
+	clk := &fakeClock{now: time.Unix(1700000000, 0)}
+	cfg := config.JWTConfig{
+		Issuer:   "iss-a",
+		Audience: "aud-a",
+		JWKSURL:  srvA.URL,
+		AdditionalIssuers: []config.JWTIssuer{
+			{Issuer: "iss-b", Audience: "aud-b", JWKSURL: srvB.URL},
+		},
+		JWKSRefreshInterval: 10 * time.Minute,
+		HTTPTimeout:         2 * time.Second,
+	}
+	v := jwtverifier.NewWithOptions(cfg, nil, clk)
+
+	for _, tc := range []struct {
+		kp  jwks_testutil.Keypair
+		iss string
+		aud string
+	}{
+		{kpA, "iss-a", "aud-a"},
+		{kpB, "iss-b", "aud-b"},
+	} {
+		jwt, _ := jwks_testutil.MintRS256JWT(tc.kp, tc.iss, tc.aud, "same-sub", clk.Now(), 5*time.Minute, nil)
+		id, err := v.VerifyIdentity(context.Background(), jwt)
+		if err != nil {
+			t.Fatalf("VerifyIdentity(%s): %v", tc.iss, err)
+		}
+		if id.Issuer != tc.iss || id.Subject != "same-sub" {
+			t.Fatalf("identity = %+v, want %s/same-sub", id, tc.iss)
+		}
+	}
+
+	// Each issuer only accepts its own audience and its own signing keys.
+	wrongAud, _ := jwks_testutil.MintRS256JWT(kpB, "iss-b", "aud-a", "same-sub", clk.Now(), 5*time.Minute, nil)
+	if _, err := v.VerifyIdentity(context.Background(), wrongAud); err == nil {
+		t.Fatalf("expected error for audience of another issuer")
+	}
+	wrongKey, _ := jwks_testutil.MintRS256JWT(kpA, "iss-b", "aud-b", "same-sub", clk.Now(), 5*time.Minute, nil)
+	if _, err := v.VerifyIdentity(context.Background(), wrongKey); err == nil {
+		t.Fatalf("expected error for token signed by another issuer's key")
+	}
+	untrusted, _ := jwks_testutil.MintRS256JWT(kpA, "iss-c", "aud-a", "same-sub", clk.Now(), 5*time.Minute, nil)
+	if _, err := v.VerifyIdentity(context.Background(), untrusted); err == nil {
+		t.Fatalf("expected error for untrusted issuer")
+	}
+}
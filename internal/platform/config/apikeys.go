@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// APIKeyConfig configures retention of the API key usage audit trail.
+type APIKeyConfig struct {
+	// UsageRetention is how long daily usage entries are kept; zero keeps them forever.
+	UsageRetention time.Duration
+	// UsageSweepInterval is how often old usage entries are purged; zero disables the sweeper.
+	UsageSweepInterval time.Duration
+}
+
+// LoadAPIKeyConfigFromEnv reads API_KEY_USAGE_RETENTION (default 2160h, i.e. 90 days) and
+// API_KEY_USAGE_SWEEP_INTERVAL (default 1h).
+func LoadAPIKeyConfigFromEnv() (APIKeyConfig, error) {
+	cfg := APIKeyConfig{
+		UsageRetention:     90 * 24 * time.Hour,
+		UsageSweepInterval: time.Hour,
+	}
+
+	if v := os.Getenv("API_KEY_USAGE_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return APIKeyConfig{}, fmt.Errorf("API_KEY_USAGE_RETENTION must be a non-negative duration (e.g. 2160h)")
+		}
+		cfg.UsageRetention = d
+	}
+	if v := os.Getenv("API_KEY_USAGE_SWEEP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return APIKeyConfig{}, fmt.Errorf("API_KEY_USAGE_SWEEP_INTERVAL must be a non-negative duration (e.g. 1h)")
+		}
+		cfg.UsageSweepInterval = d
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadAPIKeyConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv("API_KEY_USAGE_RETENTION", "")
+	t.Setenv("API_KEY_USAGE_SWEEP_INTERVAL", "")
+
+	cfg, err := LoadAPIKeyConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIKeyConfigFromEnv: %v", err)
+	}
+	if cfg.UsageRetention != 90*24*time.Hour || cfg.UsageSweepInterval != time.Hour {
+		t.Fatalf("cfg=%+v", cfg)
+	}
+}
+
+func TestLoadAPIKeyConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv("API_KEY_USAGE_RETENTION", "720h")
+	t.Setenv("API_KEY_USAGE_SWEEP_INTERVAL", "0")
+
+	cfg, err := LoadAPIKeyConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadAPIKeyConfigFromEnv: %v", err)
+	}
+	if cfg.UsageRetention != 720*time.Hour || cfg.UsageSweepInterval != 0 {
+		t.Fatalf("cfg=%+v", cfg)
+	}
+}
+
+func TestLoadAPIKeyConfigFromEnv_RejectsBadDurations(t *testing.T) {
+	for _, name := range []string{"API_KEY_USAGE_RETENTION", "API_KEY_USAGE_SWEEP_INTERVAL"} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, "-1h")
+			if _, err := LoadAPIKeyConfigFromEnv(); err == nil {
+				t.Fatalf("expected error for negative %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig configures in-application CORS handling.
+//
+// CORS is disabled when AllowedOrigins is empty (e.g. when a proxy such as Caddy handles it).
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Enabled reports whether the API should handle CORS itself.
+func (c CORSConfig) Enabled() bool { return len(c.AllowedOrigins) > 0 }
+
+// LoadCORSConfigFromEnv reads:
+//   - CORS_ALLOWED_ORIGINS: comma-separated exact origins (e.g. "https://planner.example.org")
+//   - CORS_ALLOW_CREDENTIALS: "true" to allow cookies/Authorization on cross-origin requests
+//   - CORS_MAX_AGE: preflight cache duration (default 10m)
+func LoadCORSConfigFromEnv() (CORSConfig, error) {
+	cfg := CORSConfig{MaxAge: 10 * time.Minute}
+
+	for _, o := range strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",") {
+		o = strings.TrimRight(strings.TrimSpace(o), "/")
+		if o == "" {
+			continue
+		}
+		if o != "*" && !strings.HasPrefix(o, "http://") && !strings.HasPrefix(o, "https://") {
+			return CORSConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS entry %q must be an origin like https://app.example.org", o)
+		}
+		cfg.AllowedOrigins = append(cfg.AllowedOrigins, o)
+	}
+
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return CORSConfig{}, fmt.Errorf("CORS_ALLOW_CREDENTIALS must be a boolean: %w", err)
+		}
+		cfg.AllowCredentials = b
+	}
+	if cfg.AllowCredentials {
+		for _, o := range cfg.AllowedOrigins {
+			if o == "*" {
+				return CORSConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS must not contain \"*\" when CORS_ALLOW_CREDENTIALS=true")
+			}
+		}
+	}
+
+	if v := os.Getenv("CORS_MAX_AGE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return CORSConfig{}, fmt.Errorf("CORS_MAX_AGE must be a non-negative duration (e.g. 10m)")
+		}
+		cfg.MaxAge = d
+	}
+
+	return cfg, nil
+}
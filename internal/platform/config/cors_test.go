@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadCORSConfigFromEnv(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.org/, http://localhost:5173")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	t.Setenv("CORS_MAX_AGE", "1h")
+
+	cfg, err := LoadCORSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadCORSConfigFromEnv: %v", err)
+	}
+	if len(cfg.AllowedOrigins) != 2 || cfg.AllowedOrigins[0] != "https://app.example.org" || cfg.AllowedOrigins[1] != "http://localhost:5173" {
+		t.Fatalf("origins=%v", cfg.AllowedOrigins)
+	}
+	if !cfg.AllowCredentials || cfg.MaxAge != time.Hour || !cfg.Enabled() {
+		t.Fatalf("cfg=%+v", cfg)
+	}
+}
+
+func TestLoadCORSConfigFromEnv_RejectsWildcardWithCredentials(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	if _, err := LoadCORSConfigFromEnv(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoadCORSConfigFromEnv_RejectsNonOrigin(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "app.example.org")
+
+	if _, err := LoadCORSConfigFromEnv(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
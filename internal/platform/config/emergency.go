@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EmergencyInfoConfig configures members' encrypted emergency info.
+type EmergencyInfoConfig struct {
+	// KeyFile lists the encryption keys (see package filekeys); empty means the memory backend
+	// uses a random per-process key and the postgres backend disables the feature.
+	KeyFile string
+	// AccessDays is how many days before a trip starts its organizers can read attendees' info.
+	AccessDays int
+}
+
+// LoadEmergencyInfoConfigFromEnv reads:
+//   - EMERGENCY_INFO_KEY_FILE: path to the encryption key file (optional)
+//   - EMERGENCY_INFO_ACCESS_DAYS: days before a trip organizers gain access, 0..30 (default 2)
+func LoadEmergencyInfoConfigFromEnv() (EmergencyInfoConfig, error) {
+	cfg := EmergencyInfoConfig{
+		KeyFile:    strings.TrimSpace(os.Getenv("EMERGENCY_INFO_KEY_FILE")),
+		AccessDays: 2,
+	}
+	if v := strings.TrimSpace(os.Getenv("EMERGENCY_INFO_ACCESS_DAYS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 30 {
+			return EmergencyInfoConfig{}, fmt.Errorf("EMERGENCY_INFO_ACCESS_DAYS must be an integer between 0 and 30")
+		}
+		cfg.AccessDays = n
+	}
+	return cfg, nil
+}
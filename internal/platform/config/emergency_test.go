@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestLoadEmergencyInfoConfigFromEnv(t *testing.T) {
+	t.Setenv("EMERGENCY_INFO_KEY_FILE", "")
+	t.Setenv("EMERGENCY_INFO_ACCESS_DAYS", "")
+	cfg, err := LoadEmergencyInfoConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadEmergencyInfoConfigFromEnv: %v", err)
+	}
+	if cfg.KeyFile != "" || cfg.AccessDays != 2 {
+		t.Fatalf("default cfg=%+v, want no key file, 2 days", cfg)
+	}
+
+	t.Setenv("EMERGENCY_INFO_KEY_FILE", " /etc/ebo/emergency.keys ")
+	t.Setenv("EMERGENCY_INFO_ACCESS_DAYS", "0")
+	cfg, err = LoadEmergencyInfoConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadEmergencyInfoConfigFromEnv: %v", err)
+	}
+	if cfg.KeyFile != "/etc/ebo/emergency.keys" || cfg.AccessDays != 0 {
+		t.Fatalf("cfg=%+v, want key file and 0 days", cfg)
+	}
+
+	for _, v := range []string{"-1", "31", "soon"} {
+		t.Setenv("EMERGENCY_INFO_ACCESS_DAYS", v)
+		if _, err := LoadEmergencyInfoConfigFromEnv(); err == nil {
+			t.Fatalf("EMERGENCY_INFO_ACCESS_DAYS=%q: expected error", v)
+		}
+	}
+}
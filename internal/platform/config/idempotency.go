@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// IdempotencyConfig configures idempotency record retention.
+type IdempotencyConfig struct {
+	// TTL is how long a key is remembered (and replayed); zero keeps records forever.
+	TTL time.Duration
+	// SweepInterval is how often expired records are purged; zero disables the sweeper.
+	SweepInterval time.Duration
+	// SweepBatchSize bounds rows deleted per statement.
+	SweepBatchSize int
+}
+
+// LoadIdempotencyConfigFromEnv reads IDEMPOTENCY_TTL (default 24h),
+// IDEMPOTENCY_SWEEP_INTERVAL (default 10m) and IDEMPOTENCY_SWEEP_BATCH_SIZE (default 500).
+func LoadIdempotencyConfigFromEnv() (IdempotencyConfig, error) {
+	cfg := IdempotencyConfig{
+		TTL:            24 * time.Hour,
+		SweepInterval:  10 * time.Minute,
+		SweepBatchSize: 500,
+	}
+
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return IdempotencyConfig{}, fmt.Errorf("IDEMPOTENCY_TTL must be a non-negative duration (e.g. 24h)")
+		}
+		cfg.TTL = d
+	}
+	if v := os.Getenv("IDEMPOTENCY_SWEEP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return IdempotencyConfig{}, fmt.Errorf("IDEMPOTENCY_SWEEP_INTERVAL must be a non-negative duration (e.g. 10m)")
+		}
+		cfg.SweepInterval = d
+	}
+	if v := os.Getenv("IDEMPOTENCY_SWEEP_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return IdempotencyConfig{}, fmt.Errorf("IDEMPOTENCY_SWEEP_BATCH_SIZE must be a positive integer")
+		}
+		cfg.SweepBatchSize = n
+	}
+
+	return cfg, nil
+}
@@ -3,17 +3,30 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 )
 
+// JWTIssuer is one trusted identity provider: tokens whose `iss` equals Issuer must carry
+// Audience and be signed by a key from JWKSURL.
+type JWTIssuer struct {
+	Issuer   string
+	Audience string
+	JWKSURL  string
+}
+
 // JWTConfig configures JWT verification against a JWKS endpoint.
 //
 // These values are deployment-provided (see docs/plan-service-implementation.md).
 type JWTConfig struct {
+	// Issuer, Audience and JWKSURL describe the primary issuer.
 	Issuer   string
 	Audience string
 	JWKSURL  string
 
+	// AdditionalIssuers are trusted alongside the primary issuer (e.g. a second IdP during a migration).
+	AdditionalIssuers []JWTIssuer
+
 	ClockSkew              time.Duration
 	JWKSRefreshInterval    time.Duration
 	JWKSMinRefreshInterval time.Duration
@@ -21,6 +34,15 @@ type JWTConfig struct {
 	HTTPTimeout time.Duration
 }
 
+// TrustedIssuers returns the primary issuer followed by AdditionalIssuers.
+func (c JWTConfig) TrustedIssuers() []JWTIssuer {
+	out := make([]JWTIssuer, 0, 1+len(c.AdditionalIssuers))
+	if c.Issuer != "" {
+		out = append(out, JWTIssuer{Issuer: c.Issuer, Audience: c.Audience, JWKSURL: c.JWKSURL})
+	}
+	return append(out, c.AdditionalIssuers...)
+}
+
 func LoadJWTConfigFromEnv() (JWTConfig, error) {
 	issuer := os.Getenv("JWT_ISSUER")
 	audience := os.Getenv("JWT_AUDIENCE")
@@ -63,6 +85,48 @@ func LoadJWTConfigFromEnv() (JWTConfig, error) {
 		}
 		cfg.JWKSMinRefreshInterval = d
 	}
+	if v := os.Getenv("JWT_ADDITIONAL_ISSUERS"); v != "" {
+		extra, err := ParseJWTIssuers(v)
+		if err != nil {
+			return JWTConfig{}, fmt.Errorf("JWT_ADDITIONAL_ISSUERS: %w", err)
+		}
+		for _, e := range extra {
+			if e.Issuer == issuer {
+				return JWTConfig{}, fmt.Errorf("JWT_ADDITIONAL_ISSUERS: issuer %q duplicates JWT_ISSUER", e.Issuer)
+			}
+		}
+		cfg.AdditionalIssuers = extra
+	}
 
 	return cfg, nil
 }
+
+// ParseJWTIssuers parses a comma-separated list of `issuer|audience|jwksURL` entries.
+func ParseJWTIssuers(v string) ([]JWTIssuer, error) {
+	var out []JWTIssuer
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(v, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "|")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("entry %q must be issuer|audience|jwksURL", entry)
+		}
+		iss := JWTIssuer{
+			Issuer:   strings.TrimSpace(parts[0]),
+			Audience: strings.TrimSpace(parts[1]),
+			JWKSURL:  strings.TrimSpace(parts[2]),
+		}
+		if iss.Issuer == "" || iss.Audience == "" || iss.JWKSURL == "" {
+			return nil, fmt.Errorf("entry %q must be issuer|audience|jwksURL", entry)
+		}
+		if seen[iss.Issuer] {
+			return nil, fmt.Errorf("duplicate issuer %q", iss.Issuer)
+		}
+		seen[iss.Issuer] = true
+		out = append(out, iss)
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJWTIssuers(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseJWTIssuers(" https://idp.example|ebo|https://idp.example/jwks , https://kc.example/realms/ebo|ebo-api|https://kc.example/certs")
+	if err != nil {
+		t.Fatalf("ParseJWTIssuers: %v", err)
+	}
+	want := []JWTIssuer{
+		{Issuer: "https://idp.example", Audience: "ebo", JWKSURL: "https://idp.example/jwks"},
+		{Issuer: "https://kc.example/realms/ebo", Audience: "ebo-api", JWKSURL: "https://kc.example/certs"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseJWTIssuers = %+v, want %+v", got, want)
+	}
+
+	for _, bad := range []string{"iss|aud", "iss||jwks", "a|b|c,a|d|e"} {
+		if _, err := ParseJWTIssuers(bad); err == nil {
+			t.Fatalf("ParseJWTIssuers(%q) expected error", bad)
+		}
+	}
+}
+
+func TestLoadJWTConfigFromEnv_AdditionalIssuers(t *testing.T) {
+	t.Setenv("JWT_ISSUER", "iss-a")
+	t.Setenv("JWT_AUDIENCE", "aud-a")
+	t.Setenv("JWT_JWKS_URL", "https://a.example/jwks")
+	t.Setenv("JWT_ADDITIONAL_ISSUERS", "iss-b|aud-b|https://b.example/jwks")
+
+	cfg, err := LoadJWTConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadJWTConfigFromEnv: %v", err)
+	}
+	got := cfg.TrustedIssuers()
+	if len(got) != 2 || got[0].Issuer != "iss-a" || got[1].Issuer != "iss-b" || got[1].Audience != "aud-b" {
+		t.Fatalf("TrustedIssuers = %+v", got)
+	}
+
+	t.Setenv("JWT_ADDITIONAL_ISSUERS", "iss-a|aud-x|https://x.example/jwks")
+	if _, err := LoadJWTConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for issuer duplicating JWT_ISSUER")
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// MailConfig configures outbound email and email address verification.
+type MailConfig struct {
+	// Mailer is "log" (messages are logged, not delivered; local dev) or "smtp".
+	Mailer string
+
+	SMTPAddr     string
+	SMTPFrom     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// VerificationSecret signs verification links; empty means a random per-process secret.
+	VerificationSecret string
+	// VerificationTTL is how long a verification link stays valid.
+	VerificationTTL time.Duration
+	// VerificationURL is the confirm link base; empty means derive it from PUBLIC_BASE_URL.
+	VerificationURL string
+}
+
+// LoadMailConfigFromEnv reads:
+//   - MAILER: "log" (default) or "smtp"
+//   - SMTP_ADDR, SMTP_FROM (required for smtp), SMTP_USERNAME, SMTP_PASSWORD
+//   - EMAIL_VERIFICATION_SECRET (required for smtp, so links survive restarts and replicas)
+//   - EMAIL_VERIFICATION_TTL (default 24h)
+//   - EMAIL_VERIFICATION_URL (optional confirm link base)
+func LoadMailConfigFromEnv() (MailConfig, error) {
+	cfg := MailConfig{
+		Mailer:             "log",
+		SMTPAddr:           strings.TrimSpace(os.Getenv("SMTP_ADDR")),
+		SMTPFrom:           strings.TrimSpace(os.Getenv("SMTP_FROM")),
+		SMTPUsername:       os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:       os.Getenv("SMTP_PASSWORD"),
+		VerificationSecret: os.Getenv("EMAIL_VERIFICATION_SECRET"),
+		VerificationTTL:    24 * time.Hour,
+		VerificationURL:    strings.TrimSpace(os.Getenv("EMAIL_VERIFICATION_URL")),
+	}
+
+	if v := strings.TrimSpace(os.Getenv("MAILER")); v != "" {
+		cfg.Mailer = strings.ToLower(v)
+	}
+	switch cfg.Mailer {
+	case "log":
+	case "smtp":
+		if cfg.SMTPAddr == "" || cfg.SMTPFrom == "" {
+			return MailConfig{}, fmt.Errorf("SMTP_ADDR and SMTP_FROM are required when MAILER=smtp")
+		}
+		if cfg.VerificationSecret == "" {
+			return MailConfig{}, fmt.Errorf("EMAIL_VERIFICATION_SECRET is required when MAILER=smtp")
+		}
+	default:
+		return MailConfig{}, fmt.Errorf("MAILER must be %q or %q", "log", "smtp")
+	}
+
+	if v := os.Getenv("EMAIL_VERIFICATION_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return MailConfig{}, fmt.Errorf("EMAIL_VERIFICATION_TTL must be a positive duration (e.g. 24h)")
+		}
+		cfg.VerificationTTL = d
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadMailConfigFromEnv_Defaults(t *testing.T) {
+	t.Setenv("MAILER", "")
+	t.Setenv("EMAIL_VERIFICATION_TTL", "")
+
+	cfg, err := LoadMailConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadMailConfigFromEnv: %v", err)
+	}
+	if cfg.Mailer != "log" || cfg.VerificationTTL != 24*time.Hour {
+		t.Fatalf("cfg=%+v", cfg)
+	}
+}
+
+func TestLoadMailConfigFromEnv_SMTP(t *testing.T) {
+	t.Setenv("MAILER", "smtp")
+	t.Setenv("SMTP_ADDR", "smtp.example.org:587")
+	t.Setenv("SMTP_FROM", "trips@example.org")
+	t.Setenv("EMAIL_VERIFICATION_SECRET", "")
+
+	if _, err := LoadMailConfigFromEnv(); err == nil {
+		t.Fatalf("expected error without EMAIL_VERIFICATION_SECRET")
+	}
+
+	t.Setenv("EMAIL_VERIFICATION_SECRET", "s3cret")
+	t.Setenv("EMAIL_VERIFICATION_TTL", "2h")
+	cfg, err := LoadMailConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadMailConfigFromEnv: %v", err)
+	}
+	if cfg.SMTPAddr != "smtp.example.org:587" || cfg.VerificationTTL != 2*time.Hour {
+		t.Fatalf("cfg=%+v", cfg)
+	}
+}
+
+func TestLoadMailConfigFromEnv_RejectsUnknownMailer(t *testing.T) {
+	t.Setenv("MAILER", "carrier-pigeon")
+
+	if _, err := LoadMailConfigFromEnv(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MembershipMode controls how new members may self-provision via CreateMyMember.
+type MembershipMode string
+
+const (
+	// MembershipModeOpen lets any authenticated subject create a member profile.
+	MembershipModeOpen MembershipMode = "open"
+	// MembershipModeInvite requires a valid invite code (X-Invite-Code header).
+	MembershipModeInvite MembershipMode = "invite"
+)
+
+type MembershipConfig struct {
+	Mode MembershipMode
+}
+
+func (c MembershipConfig) RequireInvite() bool { return c.Mode == MembershipModeInvite }
+
+// LoadMembershipConfigFromEnv reads:
+//   - MEMBERSHIP_MODE: "open" (default) or "invite"
+func LoadMembershipConfigFromEnv() (MembershipConfig, error) {
+	cfg := MembershipConfig{Mode: MembershipModeOpen}
+	if v := strings.TrimSpace(os.Getenv("MEMBERSHIP_MODE")); v != "" {
+		switch m := MembershipMode(strings.ToLower(v)); m {
+		case MembershipModeOpen, MembershipModeInvite:
+			cfg.Mode = m
+		default:
+			return MembershipConfig{}, fmt.Errorf("MEMBERSHIP_MODE must be %q or %q", MembershipModeOpen, MembershipModeInvite)
+		}
+	}
+	return cfg, nil
+}
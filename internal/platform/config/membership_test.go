@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestLoadMembershipConfigFromEnv(t *testing.T) {
+	t.Setenv("MEMBERSHIP_MODE", "")
+	cfg, err := LoadMembershipConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadMembershipConfigFromEnv: %v", err)
+	}
+	if cfg.Mode != MembershipModeOpen || cfg.RequireInvite() {
+		t.Fatalf("default cfg=%+v, want open", cfg)
+	}
+
+	t.Setenv("MEMBERSHIP_MODE", "Invite")
+	cfg, err = LoadMembershipConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadMembershipConfigFromEnv: %v", err)
+	}
+	if !cfg.RequireInvite() {
+		t.Fatalf("cfg=%+v, want invite", cfg)
+	}
+
+	t.Setenv("MEMBERSHIP_MODE", "closed")
+	if _, err := LoadMembershipConfigFromEnv(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is a token bucket setting: Burst requests per Per, burstable to Burst.
+// A zero value disables limiting.
+type RateLimit struct {
+	Burst int
+	Per   time.Duration
+}
+
+// RateLimitConfig configures the HTTP rate limiter.
+//
+// Operations are keyed by OpenAPI operationId (e.g. "SearchMembers"), or by method and route
+// pattern for out-of-spec routes (e.g. "GET /email-verifications/confirm").
+type RateLimitConfig struct {
+	Default      RateLimit
+	PerOperation map[string]RateLimit
+	// SweepInterval is how often idle buckets are purged; zero disables the sweeper.
+	SweepInterval time.Duration
+}
+
+// LoadRateLimitConfigFromEnv reads:
+//   - RATE_LIMIT_DEFAULT: limit applied to every operation (default "120/1m"; "off" disables)
+//   - RATE_LIMIT_OPERATIONS: comma-separated overrides, e.g. "SearchMembers=30/1m,SetMyRSVP=off"
+//   - RATE_LIMIT_SWEEP_INTERVAL: how often idle buckets are purged (default 10m; "0" disables)
+func LoadRateLimitConfigFromEnv() (RateLimitConfig, error) {
+	cfg := RateLimitConfig{
+		Default: RateLimit{Burst: 120, Per: time.Minute},
+		// Endpoints that are cheap to call in a loop but expensive for the database, and
+		// announcements, which mail members. Infra health checks are never limited.
+		PerOperation: map[string]RateLimit{
+			"SearchMembers":                      {Burst: 30, Per: time.Minute},
+			"SetMyRSVP":                          {Burst: 20, Per: time.Minute},
+			"POST /trips/{tripId}/announcements": {Burst: 10, Per: time.Hour},
+			"POST /trips/{tripId}/announcements/{announcementId}/resend": {Burst: 10, Per: time.Hour},
+			"GET /healthz": {},
+		},
+		SweepInterval: 10 * time.Minute,
+	}
+
+	if v := os.Getenv("RATE_LIMIT_DEFAULT"); v != "" {
+		l, err := ParseRateLimit(v)
+		if err != nil {
+			return RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_DEFAULT: %w", err)
+		}
+		cfg.Default = l
+	}
+	if v := os.Getenv("RATE_LIMIT_OPERATIONS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			op, spec, ok := strings.Cut(entry, "=")
+			op = strings.TrimSpace(op)
+			if !ok || op == "" {
+				return RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_OPERATIONS entry %q must be operationId=limit", entry)
+			}
+			l, err := ParseRateLimit(spec)
+			if err != nil {
+				return RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_OPERATIONS %s: %w", op, err)
+			}
+			cfg.PerOperation[op] = l
+		}
+	}
+	if v := os.Getenv("RATE_LIMIT_SWEEP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_SWEEP_INTERVAL must be a non-negative duration (e.g. 10m)")
+		}
+		cfg.SweepInterval = d
+	}
+	return cfg, nil
+}
+
+// ParseRateLimit parses "N/duration" (e.g. "30/1m", "5/10s"). "off" and "0" disable limiting.
+func ParseRateLimit(s string) (RateLimit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" || strings.EqualFold(s, "off") {
+		return RateLimit{}, nil
+	}
+	n, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("rate limit %q must be of the form N/duration (e.g. 30/1m)", s)
+	}
+	burst, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil || burst < 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q must start with a non-negative integer", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q must end with a positive duration (e.g. 1m)", s)
+	}
+	return RateLimit{Burst: burst, Per: d}, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in      string
+		want    RateLimit
+		wantErr bool
+	}{
+		{in: "30/1m", want: RateLimit{Burst: 30, Per: time.Minute}},
+		{in: " 5 / 10s ", want: RateLimit{Burst: 5, Per: 10 * time.Second}},
+		{in: "off", want: RateLimit{}},
+		{in: "0", want: RateLimit{}},
+		{in: "30", wantErr: true},
+		{in: "x/1m", wantErr: true},
+		{in: "30/0s", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := ParseRateLimit(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseRateLimit(%q) err=%v wantErr=%v", tc.in, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Fatalf("ParseRateLimit(%q)=%+v want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestLoadRateLimitConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv("RATE_LIMIT_DEFAULT", "10/1s")
+	t.Setenv("RATE_LIMIT_OPERATIONS", "SearchMembers=off, ListMembers=3/1m")
+
+	cfg, err := LoadRateLimitConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadRateLimitConfigFromEnv: %v", err)
+	}
+	if cfg.Default != (RateLimit{Burst: 10, Per: time.Second}) {
+		t.Fatalf("default=%+v", cfg.Default)
+	}
+	if cfg.PerOperation["SearchMembers"] != (RateLimit{}) {
+		t.Fatalf("SearchMembers=%+v, want disabled", cfg.PerOperation["SearchMembers"])
+	}
+	if cfg.PerOperation["ListMembers"] != (RateLimit{Burst: 3, Per: time.Minute}) {
+		t.Fatalf("ListMembers=%+v", cfg.PerOperation["ListMembers"])
+	}
+	if cfg.PerOperation["SetMyRSVP"] != (RateLimit{Burst: 20, Per: time.Minute}) {
+		t.Fatalf("SetMyRSVP default override lost: %+v", cfg.PerOperation["SetMyRSVP"])
+	}
+	if l := cfg.PerOperation["POST /trips/{tripId}/announcements"]; l != (RateLimit{Burst: 10, Per: time.Hour}) {
+		t.Fatalf("announcements=%+v, want default 10/1h", l)
+	}
+	if l, ok := cfg.PerOperation["GET /healthz"]; !ok || l != (RateLimit{}) {
+		t.Fatalf("healthz=%+v ok=%v, want disabled", l, ok)
+	}
+	if cfg.SweepInterval != 10*time.Minute {
+		t.Fatalf("SweepInterval=%v, want default 10m", cfg.SweepInterval)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TripConfig holds club-wide trip planning settings.
+type TripConfig struct {
+	// DifficultyScale is the top of the club's difficulty rating scale (ratings run 1..N).
+	DifficultyScale int
+	// SeriesHorizonDays is how many days ahead recurring series generate occurrences.
+	SeriesHorizonDays int
+	// SeriesGenerateInterval is how often series are topped up; zero disables the generator
+	// (occurrences are then only generated when a series is created).
+	SeriesGenerateInterval time.Duration
+	// AnnouncementDeliveryInterval is how often announcements to the whole club are sent in the
+	// background; zero sends them while posting instead.
+	AnnouncementDeliveryInterval time.Duration
+}
+
+// LoadTripConfigFromEnv reads:
+//   - TRIP_DIFFICULTY_SCALE: top of the difficulty rating scale, 2..10 (default 5)
+//   - TRIP_SERIES_HORIZON_DAYS: how far ahead series occurrences are generated, 7..366 (default 60)
+//   - TRIP_SERIES_GENERATE_INTERVAL: how often series are topped up (default 1h; 0 disables)
+//   - TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL: how often whole-club announcements are sent in the
+//     background (default 30s; 0 sends them while posting)
+func LoadTripConfigFromEnv() (TripConfig, error) {
+	cfg := TripConfig{
+		DifficultyScale:              5,
+		SeriesHorizonDays:            60,
+		SeriesGenerateInterval:       time.Hour,
+		AnnouncementDeliveryInterval: 30 * time.Second,
+	}
+	if v := strings.TrimSpace(os.Getenv("TRIP_DIFFICULTY_SCALE")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 2 || n > 10 {
+			return TripConfig{}, fmt.Errorf("TRIP_DIFFICULTY_SCALE must be an integer between 2 and 10")
+		}
+		cfg.DifficultyScale = n
+	}
+	if v := strings.TrimSpace(os.Getenv("TRIP_SERIES_HORIZON_DAYS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 7 || n > 366 {
+			return TripConfig{}, fmt.Errorf("TRIP_SERIES_HORIZON_DAYS must be an integer between 7 and 366")
+		}
+		cfg.SeriesHorizonDays = n
+	}
+	if v := strings.TrimSpace(os.Getenv("TRIP_SERIES_GENERATE_INTERVAL")); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return TripConfig{}, fmt.Errorf("TRIP_SERIES_GENERATE_INTERVAL must be a non-negative duration (e.g. 1h)")
+		}
+		cfg.SeriesGenerateInterval = d
+	}
+	if v := strings.TrimSpace(os.Getenv("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL")); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return TripConfig{}, fmt.Errorf("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL must be a non-negative duration (e.g. 30s)")
+		}
+		cfg.AnnouncementDeliveryInterval = d
+	}
+	return cfg, nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTripConfigFromEnv(t *testing.T) {
+	t.Setenv("TRIP_DIFFICULTY_SCALE", "")
+	t.Setenv("TRIP_SERIES_HORIZON_DAYS", "")
+	t.Setenv("TRIP_SERIES_GENERATE_INTERVAL", "")
+	t.Setenv("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL", "")
+	cfg, err := LoadTripConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadTripConfigFromEnv: %v", err)
+	}
+	if cfg.DifficultyScale != 5 || cfg.SeriesHorizonDays != 60 || cfg.SeriesGenerateInterval != time.Hour || cfg.AnnouncementDeliveryInterval != 30*time.Second {
+		t.Fatalf("default cfg=%+v, want scale 5, horizon 60, interval 1h, announcement delivery 30s", cfg)
+	}
+
+	t.Setenv("TRIP_DIFFICULTY_SCALE", " 10 ")
+	cfg, err = LoadTripConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadTripConfigFromEnv: %v", err)
+	}
+	if cfg.DifficultyScale != 10 {
+		t.Fatalf("cfg=%+v, want scale 10", cfg)
+	}
+
+	for _, v := range []string{"1", "11", "hard"} {
+		t.Setenv("TRIP_DIFFICULTY_SCALE", v)
+		if _, err := LoadTripConfigFromEnv(); err == nil {
+			t.Fatalf("TRIP_DIFFICULTY_SCALE=%q: expected error", v)
+		}
+	}
+
+	t.Setenv("TRIP_DIFFICULTY_SCALE", "")
+
+	t.Setenv("TRIP_SERIES_HORIZON_DAYS", "120")
+	t.Setenv("TRIP_SERIES_GENERATE_INTERVAL", "0")
+	cfg, err = LoadTripConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadTripConfigFromEnv: %v", err)
+	}
+	if cfg.SeriesHorizonDays != 120 || cfg.SeriesGenerateInterval != 0 {
+		t.Fatalf("cfg=%+v, want horizon 120, generator disabled", cfg)
+	}
+	for _, v := range []string{"6", "367", "soon"} {
+		t.Setenv("TRIP_SERIES_HORIZON_DAYS", v)
+		if _, err := LoadTripConfigFromEnv(); err == nil {
+			t.Fatalf("TRIP_SERIES_HORIZON_DAYS=%q: expected error", v)
+		}
+	}
+	t.Setenv("TRIP_SERIES_HORIZON_DAYS", "")
+	t.Setenv("TRIP_SERIES_GENERATE_INTERVAL", "-1h")
+	if _, err := LoadTripConfigFromEnv(); err == nil {
+		t.Fatalf("TRIP_SERIES_GENERATE_INTERVAL=-1h: expected error")
+	}
+	t.Setenv("TRIP_SERIES_GENERATE_INTERVAL", "")
+
+	t.Setenv("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL", "0")
+	cfg, err = LoadTripConfigFromEnv()
+	if err != nil || cfg.AnnouncementDeliveryInterval != 0 {
+		t.Fatalf("cfg=%+v err=%v, want announcements delivered while posting", cfg, err)
+	}
+	for _, v := range []string{"-1s", "often"} {
+		t.Setenv("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL", v)
+		if _, err := LoadTripConfigFromEnv(); err == nil {
+			t.Fatalf("TRIP_ANNOUNCEMENT_DELIVERY_INTERVAL=%q: expected error", v)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package announcementrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested announcement (or delivery) does not exist.
+	ErrNotFound = errors.New("announcement not found")
+
+	// ErrAlreadyExists indicates an announcement already exists with the provided ID.
+	ErrAlreadyExists = errors.New("announcement already exists")
+)
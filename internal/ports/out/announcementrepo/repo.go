@@ -0,0 +1,71 @@
+package announcementrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Announcement is a stored trip announcement.
+type Announcement struct {
+	ID     domain.TripAnnouncementID
+	TripID domain.TripID
+	// Exactly one author is set: the member who posted it, or the name of the service account
+	// (API key) that did.
+	AuthorMemberID       domain.MemberID
+	AuthorServiceAccount string
+	Subject              string
+	Body                 string
+	Audience             domain.AnnouncementAudience
+	CreatedAt            time.Time
+}
+
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	// StatusSending marks a delivery claimed by a sender (see ClaimDelivery).
+	StatusSending Status = "SENDING"
+	StatusSent    Status = "SENT"
+	StatusFailed  Status = "FAILED"
+)
+
+// Delivery is the delivery state of an announcement for one recipient.
+type Delivery struct {
+	AnnouncementID domain.TripAnnouncementID
+	MemberID       domain.MemberID
+	Status         Status
+	// Error is set for FAILED deliveries.
+	Error string
+	// AttemptedAt is nil while PENDING.
+	AttemptedAt *time.Time
+	// SentTo lists the recipient's addresses already sent to, so a retry skips them.
+	SentTo []string
+}
+
+// Repository provides access to trip announcements and their deliveries. Audience and
+// permission rules are the caller's job.
+type Repository interface {
+	// Create stores the announcement with a PENDING delivery for each recipient, atomically.
+	// It returns ErrAlreadyExists for a duplicate ID.
+	Create(ctx context.Context, a Announcement, recipients []domain.MemberID) error
+	Get(ctx context.Context, id domain.TripAnnouncementID) (Announcement, error)
+	// ListByTrip returns the trip's announcements, newest first.
+	ListByTrip(ctx context.Context, tripID domain.TripID) ([]Announcement, error)
+
+	// ListUndelivered returns up to limit announcements, oldest first, with a PENDING delivery
+	// or a SENDING one attempted before staleBefore.
+	ListUndelivered(ctx context.Context, staleBefore time.Time, limit int) ([]Announcement, error)
+
+	// ListDeliveries returns the announcement's deliveries ordered by member ID.
+	ListDeliveries(ctx context.Context, id domain.TripAnnouncementID) ([]Delivery, error)
+	// ClaimDelivery atomically moves a PENDING or FAILED delivery, or a SENDING one attempted
+	// before staleBefore (its sender is assumed to have died), to SENDING with AttemptedAt at and
+	// returns it. claimed is false when the delivery was sent or another sender holds it; it
+	// returns ErrNotFound if there is no delivery for (id, memberID).
+	ClaimDelivery(ctx context.Context, id domain.TripAnnouncementID, memberID domain.MemberID, at, staleBefore time.Time) (d Delivery, claimed bool, err error)
+	// UpdateDelivery replaces the Status, Error, AttemptedAt and SentTo of an existing delivery.
+	// It returns ErrNotFound if there is no delivery for (AnnouncementID, MemberID).
+	UpdateDelivery(ctx context.Context, d Delivery) error
+}
@@ -0,0 +1,11 @@
+package apikeyrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested API key does not exist.
+	ErrNotFound = errors.New("api key not found")
+
+	// ErrAlreadyExists indicates an API key already exists with the provided ID or prefix.
+	ErrAlreadyExists = errors.New("api key already exists")
+)
@@ -0,0 +1,56 @@
+package apikeyrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// APIKey is the persistence shape used by the API key repository.
+//
+// SecretHash is the hex SHA-256 of the key's secret part; the secret itself is never stored.
+type APIKey struct {
+	domain.APIKey
+	SecretHash string
+}
+
+// Repository provides access to service-account API keys and their usage audit trail.
+type Repository interface {
+	Create(ctx context.Context, k APIKey) error
+
+	GetByID(ctx context.Context, id domain.APIKeyID) (APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (APIKey, error)
+
+	// List returns all keys (including revoked ones) ordered by CreatedAt ascending.
+	List(ctx context.Context) ([]APIKey, error)
+
+	// Revoke marks the key revoked. Revoking an already revoked key is a no-op.
+	Revoke(ctx context.Context, id domain.APIKeyID, revokedBy string, at time.Time) error
+
+	// RecordUsage counts one request against the audit trail entry for its UTC day, method, path
+	// and client, and advances the key's LastUsedAt.
+	RecordUsage(ctx context.Context, u Usage) error
+
+	// ListUsage returns the most recently used entries first, up to limit.
+	ListUsage(ctx context.Context, id domain.APIKeyID, limit int) ([]domain.APIKeyUsage, error)
+
+	// DeleteUsageBefore deletes up to limit audit trail entries for UTC days before before's
+	// day and returns how many were deleted.
+	DeleteUsageBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// Usage is one authenticated request made with an API key.
+type Usage struct {
+	KeyID    domain.APIKeyID
+	UsedAt   time.Time
+	Method   string
+	Path     string
+	ClientIP string
+}
+
+// UsageDay returns the UTC day (as midnight) that t's usage is counted against.
+func UsageDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
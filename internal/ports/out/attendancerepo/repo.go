@@ -0,0 +1,42 @@
+package attendancerepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+type Status string
+
+const (
+	StatusPresent Status = "PRESENT"
+	StatusAbsent  Status = "ABSENT"
+)
+
+// Record is a member's attendance on a trip, as checked in by an organizer.
+type Record struct {
+	TripID   domain.TripID
+	MemberID domain.MemberID
+	Status   Status
+
+	// WalkUp marks a member checked in without a YES RSVP; only PRESENT records are walk-ups.
+	WalkUp bool
+	// CheckedInAt is when the member was first marked PRESENT; nil for ABSENT.
+	CheckedInAt *time.Time
+
+	// RecordedBy is the organizer who last changed the record (empty when unknown).
+	RecordedBy domain.MemberID
+	UpdatedAt  time.Time
+}
+
+// Repository provides access to trip attendance. Check-in rules (trip status, dates,
+// organizers) are the caller's job.
+type Repository interface {
+	// Put creates or replaces the record for (TripID, MemberID).
+	Put(ctx context.Context, r Record) error
+	// ListByTrip returns the trip's records ordered by member ID.
+	ListByTrip(ctx context.Context, tripID domain.TripID) ([]Record, error)
+	// ListByMember returns the member's records across all trips ordered by trip ID.
+	ListByMember(ctx context.Context, memberID domain.MemberID) ([]Record, error)
+}
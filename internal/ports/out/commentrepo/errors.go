@@ -0,0 +1,11 @@
+package commentrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested comment (or its trip, author or parent) does not exist.
+	ErrNotFound = errors.New("comment not found")
+
+	// ErrAlreadyExists indicates a comment already exists with the provided ID.
+	ErrAlreadyExists = errors.New("comment already exists")
+)
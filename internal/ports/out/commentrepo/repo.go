@@ -0,0 +1,48 @@
+package commentrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Comment is a stored trip discussion comment.
+type Comment struct {
+	ID             domain.TripCommentID
+	TripID         domain.TripID
+	AuthorMemberID domain.MemberID
+	ParentID       *domain.TripCommentID
+
+	Body   string
+	Pinned bool
+
+	CreatedAt time.Time
+	EditedAt  *time.Time
+	DeletedAt *time.Time
+	// DeletedBy is the member who deleted the comment (empty when not deleted or unknown).
+	DeletedBy domain.MemberID
+}
+
+// Cursor is a position in a trip's thread: the last comment of the previous page.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        domain.TripCommentID
+}
+
+// Repository provides access to trip comments. Permissions and content rules are the
+// caller's job.
+type Repository interface {
+	// Create stores a new comment. It returns ErrAlreadyExists for a duplicate ID and
+	// ErrNotFound if the parent comment does not exist.
+	Create(ctx context.Context, c Comment) error
+	Get(ctx context.Context, id domain.TripCommentID) (Comment, error)
+	// Update replaces the comment's Body, Pinned, EditedAt, DeletedAt and DeletedBy.
+	Update(ctx context.Context, c Comment) error
+
+	// ListByTrip returns up to limit comments on the trip ordered by (CreatedAt, ID),
+	// starting after the cursor when one is given. A limit <= 0 means no limit.
+	ListByTrip(ctx context.Context, tripID domain.TripID, after *Cursor, limit int) ([]Comment, error)
+	// ListPinned returns the trip's pinned comments ordered by (CreatedAt, ID).
+	ListPinned(ctx context.Context, tripID domain.TripID) ([]Comment, error)
+}
@@ -0,0 +1,6 @@
+package emergencyinforepo
+
+import "errors"
+
+// ErrNotFound indicates the member has no emergency info.
+var ErrNotFound = errors.New("emergency info not found")
@@ -0,0 +1,39 @@
+package emergencyinforepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Sealed is a member's encrypted emergency info. The repository never sees the plaintext.
+type Sealed struct {
+	MemberID domain.MemberID
+	// KeyID names the keyprovider key the data was encrypted with.
+	KeyID      string
+	Nonce      []byte
+	Ciphertext []byte
+	UpdatedAt  time.Time
+}
+
+// AccessEntry is one audited access to a member's emergency info.
+type AccessEntry struct {
+	MemberID domain.MemberID
+	domain.EmergencyInfoAccess
+}
+
+// Repository stores encrypted emergency info and its append-only access log.
+type Repository interface {
+	// Get returns the member's sealed info, or ErrNotFound.
+	Get(ctx context.Context, memberID domain.MemberID) (Sealed, error)
+	// Put creates or replaces the member's sealed info.
+	Put(ctx context.Context, s Sealed) error
+	// Delete removes the member's info; it fails with ErrNotFound when there is none.
+	Delete(ctx context.Context, memberID domain.MemberID) error
+
+	// AppendAccess records an access; entries are never changed afterwards.
+	AppendAccess(ctx context.Context, e AccessEntry) error
+	// ListAccess returns the accesses to the member's info, oldest first.
+	ListAccess(ctx context.Context, memberID domain.MemberID) ([]AccessEntry, error)
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Type names what happened.
+type Type string
+
+const (
+	TripCommentCreated Type = "trip.comment.created"
+	TripCommentEdited  Type = "trip.comment.edited"
+	TripCommentDeleted Type = "trip.comment.deleted"
+	TripCommentPinned  Type = "trip.comment.pinned"
+)
+
+// Event is a domain event for subscribers such as notification fan-out. Events carry IDs
+// only; subscribers load whatever they need to render a notification.
+type Event struct {
+	Type       Type
+	OccurredAt time.Time
+	TripID     domain.TripID
+	// ActorMemberID is the member whose action raised the event.
+	ActorMemberID domain.MemberID
+
+	// CommentID is set for comment events; ParentCommentID is set when the comment is a reply.
+	CommentID       domain.TripCommentID
+	ParentCommentID domain.TripCommentID
+}
+
+// Publisher hands events to subscribers. Services publish after their change is saved and
+// treat delivery as best-effort.
+type Publisher interface {
+	Publish(ctx context.Context, ev Event) error
+}
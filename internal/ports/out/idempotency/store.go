@@ -23,15 +23,42 @@ type Fingerprint struct {
 }
 
 // Record is the stored response we can replay for a duplicate request.
+//
+// Header holds the response headers written by the handler (not those added by outer middleware).
+// A zero ExpiresAt never expires; otherwise the record is treated as absent from ExpiresAt on.
 type Record struct {
 	StatusCode  int
 	ContentType string
+	Header      map[string][]string
 	Body        []byte
 	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Expired reports whether rec is expired at now.
+func (rec Record) Expired(now time.Time) bool {
+	return !rec.ExpiresAt.IsZero() && !now.Before(rec.ExpiresAt)
 }
 
 // Store persists idempotency records for replaying safe responses on retries.
+//
+// Expired records are treated as absent by Get and Reserve; DeleteExpired purges them.
 type Store interface {
 	Get(ctx context.Context, fp Fingerprint) (Record, bool, error)
 	Put(ctx context.Context, fp Fingerprint, rec Record) error
+
+	// Reserve atomically stores rec under fp unless a record already exists.
+	// When a record exists it is returned unchanged with reserved=false.
+	Reserve(ctx context.Context, fp Fingerprint, rec Record) (existing Record, reserved bool, err error)
+
+	// CompareAndSwap replaces the record under fp with rec only if the stored record is unexpired
+	// and its CreatedAt equals createdAt, so of several callers racing to take over the same
+	// record exactly one wins.
+	CompareAndSwap(ctx context.Context, fp Fingerprint, createdAt time.Time, rec Record) (swapped bool, err error)
+
+	// Delete removes the record under fp (no error if absent).
+	Delete(ctx context.Context, fp Fingerprint) error
+
+	// DeleteExpired removes up to limit records expired at before and returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
 }
@@ -0,0 +1,20 @@
+package invitationrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested invitation does not exist.
+	ErrNotFound = errors.New("invitation not found")
+
+	// ErrAlreadyExists indicates an invitation already exists with the provided ID or code.
+	ErrAlreadyExists = errors.New("invitation already exists")
+
+	// ErrRevoked indicates the invitation was revoked.
+	ErrRevoked = errors.New("invitation revoked")
+
+	// ErrExpired indicates the invitation is past its expiry.
+	ErrExpired = errors.New("invitation expired")
+
+	// ErrExhausted indicates every use of the invitation has been consumed.
+	ErrExhausted = errors.New("invitation exhausted")
+)
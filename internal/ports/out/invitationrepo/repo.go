@@ -0,0 +1,41 @@
+package invitationrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Invitation is the persistence shape used by the invitation repository.
+//
+// CodeHash is domain.HashInviteCode of the invite code; the code itself is never stored.
+type Invitation struct {
+	domain.Invitation
+	CodeHash string
+}
+
+// Repository provides access to member invitations and their redemptions.
+type Repository interface {
+	Create(ctx context.Context, inv Invitation) error
+
+	GetByID(ctx context.Context, id domain.InvitationID) (Invitation, error)
+	GetByCodeHash(ctx context.Context, codeHash string) (Invitation, error)
+
+	// List returns all invitations (including revoked/expired ones) ordered by CreatedAt ascending.
+	List(ctx context.Context) ([]Invitation, error)
+
+	// Revoke marks the invitation revoked. Revoking an already revoked invitation is a no-op.
+	Revoke(ctx context.Context, id domain.InvitationID, at time.Time) error
+
+	// Redeem atomically consumes one use on behalf of memberID, failing with ErrRevoked,
+	// ErrExpired (ExpiresAt <= at) or ErrExhausted. Redeeming twice for the same member fails with ErrAlreadyExists.
+	Redeem(ctx context.Context, id domain.InvitationID, memberID domain.MemberID, at time.Time) error
+
+	// ReleaseRedemption undoes a Redeem for memberID (e.g. provisioning the member failed).
+	// Releasing a redemption that does not exist is a no-op.
+	ReleaseRedemption(ctx context.Context, id domain.InvitationID, memberID domain.MemberID) error
+
+	// ListRedemptions returns the invitation's redemptions ordered by RedeemedAt ascending.
+	ListRedemptions(ctx context.Context, id domain.InvitationID) ([]domain.InvitationRedemption, error)
+}
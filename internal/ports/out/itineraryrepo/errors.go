@@ -0,0 +1,6 @@
+package itineraryrepo
+
+import "errors"
+
+// ErrDayNotFound indicates the trip has no itinerary entry for the date.
+var ErrDayNotFound = errors.New("itinerary day not found")
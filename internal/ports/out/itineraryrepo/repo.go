@@ -0,0 +1,25 @@
+package itineraryrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Day is the persistence shape of one itinerary day of a trip.
+type Day struct {
+	TripID domain.TripID
+	domain.ItineraryDay
+}
+
+// Repository provides access to trip itineraries. Dates are UTC calendar dates; checking them
+// against the trip's dates is the caller's job.
+type Repository interface {
+	// PutDay creates or replaces the trip's plan for d.Date, including its stops and segments.
+	PutDay(ctx context.Context, d Day) error
+	// ListByTrip returns the trip's days ordered by date.
+	ListByTrip(ctx context.Context, tripID domain.TripID) ([]Day, error)
+	// DeleteDay removes the day; it fails with ErrDayNotFound when there is none.
+	DeleteDay(ctx context.Context, tripID domain.TripID, date time.Time) error
+}
@@ -0,0 +1,27 @@
+package keyprovider
+
+import (
+	"context"
+	"errors"
+)
+
+// KeySize is the length of every key in bytes (AES-256).
+const KeySize = 32
+
+// ErrKeyNotFound indicates the provider does not know the key ID.
+var ErrKeyNotFound = errors.New("encryption key not found")
+
+// Key is a data encryption key. Its ID is stored next to each ciphertext so keys can be
+// rotated: new data uses the current key, older data keeps decrypting with its own.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Provider supplies encryption keys for data encrypted at rest.
+type Provider interface {
+	// Current returns the key new data is encrypted with.
+	Current(ctx context.Context) (Key, error)
+	// Get returns the key with the given ID, or ErrKeyNotFound.
+	Get(ctx context.Context, id string) (Key, error)
+}
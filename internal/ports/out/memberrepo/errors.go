@@ -11,4 +11,16 @@ var (
 
 	// ErrAlreadyExists indicates a member already exists with the provided ID.
 	ErrAlreadyExists = errors.New("member already exists")
+
+	// ErrIdentityNotFound indicates the member has no such linked identity.
+	ErrIdentityNotFound = errors.New("member identity not found")
+
+	// ErrLastIdentity indicates the identity is the member's only login and cannot be unlinked.
+	ErrLastIdentity = errors.New("cannot unlink last member identity")
+
+	// ErrVehicleNotFound indicates the member has no such vehicle.
+	ErrVehicleNotFound = errors.New("vehicle not found")
+
+	// ErrEmailChanged indicates the member's address no longer matches the one being verified.
+	ErrEmailChanged = errors.New("member email changed")
 )
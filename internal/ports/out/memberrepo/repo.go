@@ -13,7 +13,9 @@ import (
 // domain models and use-cases (Milestones 3+). It's used as an internal record,
 // not an HTTP DTO.
 type Member struct {
-	ID      domain.MemberID
+	ID domain.MemberID
+	// Subject is the login the member was created with; further logins are linked identities.
+	// Create registers it as the member's first identity under the issuer carried in ctx.
 	Subject domain.SubjectID
 	// DisplayName is the member's preferred display name.
 	DisplayName string
@@ -21,15 +23,29 @@ type Member struct {
 	Email string
 	// GroupAliasEmail is an optional email address used for group aliasing; nil means unset.
 	GroupAliasEmail *string
-	// VehicleProfile is optional informational member metadata; nil means unset.
+	// VehicleProfile mirrors the profile of the member's default vehicle; nil means the garage is empty.
+	// Create/Update with a non-nil value write it to the default vehicle (creating one named
+	// domain.DefaultVehicleName if needed); Update with nil leaves the garage untouched.
 	VehicleProfile *domain.VehicleProfile
 
+	// EmailVerifiedAt / GroupAliasEmailVerifiedAt record when each address was verified; nil means unverified.
+	EmailVerifiedAt           *time.Time
+	GroupAliasEmailVerifiedAt *time.Time
+
 	IsActive bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// EmailField names one of a member's addresses.
+type EmailField string
+
+const (
+	EmailFieldPrimary    EmailField = "email"
+	EmailFieldGroupAlias EmailField = "groupAliasEmail"
+)
+
 // Repository provides access to persisted members.
 //
 // Result ordering expectations:
@@ -37,10 +53,38 @@ type Member struct {
 type Repository interface {
 	Create(ctx context.Context, m Member) error
 	Update(ctx context.Context, m Member) error
+	// MarkEmailVerified sets only the verified-at time of the address in field, and only while that
+	// address still equals addr (case-insensitively); otherwise it returns ErrEmailChanged. An
+	// address that is already verified keeps its original time.
+	MarkEmailVerified(ctx context.Context, id domain.MemberID, field EmailField, addr string, at time.Time) error
 
 	GetByID(ctx context.Context, id domain.MemberID) (Member, error)
+	// GetBySubject resolves the caller's (issuer, subject) through the member's linked identities.
+	// The issuer is the verified issuer carried in ctx (see authctx).
 	GetBySubject(ctx context.Context, subject domain.SubjectID) (Member, error)
 
+	// LinkIdentity binds an additional login to the member. Linking an identity the member already
+	// has is a no-op; an identity bound to another member returns ErrSubjectAlreadyBound.
+	LinkIdentity(ctx context.Context, id domain.MemberID, identity domain.MemberIdentity) error
+	// UnlinkIdentity removes a login from the member (ErrIdentityNotFound, ErrLastIdentity).
+	UnlinkIdentity(ctx context.Context, id domain.MemberID, issuer string, subject domain.SubjectID) error
+	// ListIdentities returns the member's logins ordered by LinkedAt ascending.
+	ListIdentities(ctx context.Context, id domain.MemberID) ([]domain.MemberIdentity, error)
+
+	// ListVehicles returns the member's garage: the default vehicle first, then by CreatedAt ascending.
+	ListVehicles(ctx context.Context, id domain.MemberID) ([]domain.Vehicle, error)
+	// GetVehicle returns one of the member's vehicles (ErrVehicleNotFound).
+	GetVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) (domain.Vehicle, error)
+	// CreateVehicle adds a vehicle to v.MemberID's garage. The first vehicle always becomes the
+	// default; a later one does so only when v.IsDefault is set.
+	CreateVehicle(ctx context.Context, v domain.Vehicle) error
+	// UpdateVehicle replaces the vehicle's Name and Profile. IsDefault is ignored; see SetDefaultVehicle.
+	UpdateVehicle(ctx context.Context, v domain.Vehicle) error
+	// DeleteVehicle removes a vehicle. Deleting the default promotes the oldest remaining vehicle.
+	DeleteVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error
+	// SetDefaultVehicle makes the vehicle the member's default.
+	SetDefaultVehicle(ctx context.Context, id domain.MemberID, vehicleID domain.VehicleID) error
+
 	List(ctx context.Context, includeInactive bool) ([]Member, error)
 
 	// SearchActiveByDisplayName searches active members by a tokenized, case-insensitive match on DisplayName.
@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Recipient is the member a notification is addressed to.
+type Recipient struct {
+	MemberID    domain.MemberID
+	DisplayName string
+	Email       string
+}
+
+// Message is a plain-text notification for one member.
+type Message struct {
+	To      Recipient
+	Subject string
+	Body    string
+}
+
+// Notifier delivers notifications to members over whatever channel the deployment uses.
+// An error means this recipient was not notified; callers record it and carry on.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit configures a token bucket.
+//
+// A bucket holds at most Burst tokens and refills at Burst tokens per Per
+// (e.g. Burst=60, Per=1m is "60 requests per minute, burstable to 60").
+type Limit struct {
+	Burst int
+	Per   time.Duration
+}
+
+// Enabled reports whether the limit should be enforced.
+func (l Limit) Enabled() bool { return l.Burst > 0 && l.Per > 0 }
+
+// Bucket is the persisted token bucket state for a single key.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store atomically takes one token from the bucket identified by key.
+//
+// Implementations must be safe for concurrent use; the Postgres store is shared
+// across replicas so limits hold for the whole deployment.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error)
+
+	// Peek reports what Take would decide for key at now without taking a token or creating
+	// the bucket.
+	Peek(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error)
+
+	// DeleteIdle removes up to limit buckets last used at or before before and returns how many
+	// were removed. A bucket idle for its limit's Per has refilled, so dropping it changes no decision.
+	DeleteIdle(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// Take applies the token bucket algorithm to b at time now and tries to take one token.
+//
+// It is shared by store implementations so that every backend computes identical decisions.
+// A zero Bucket (never seen before) starts full.
+func Take(b Bucket, limit Limit, now time.Time) (Bucket, Decision) {
+	capacity := float64(limit.Burst)
+	ratePerSec := capacity / limit.Per.Seconds()
+
+	tokens := capacity
+	if !b.UpdatedAt.IsZero() {
+		tokens = b.Tokens
+		if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+			tokens = math.Min(capacity, tokens+elapsed*ratePerSec)
+		}
+	}
+
+	if tokens >= 1 {
+		tokens--
+		return Bucket{Tokens: tokens, UpdatedAt: now}, Decision{
+			Allowed:   true,
+			Remaining: int(math.Floor(tokens)),
+		}
+	}
+
+	wait := time.Duration(math.Ceil((1 - tokens) / ratePerSec * float64(time.Second)))
+	return Bucket{Tokens: tokens, UpdatedAt: now}, Decision{
+		Allowed:    false,
+		Remaining:  0,
+		RetryAfter: wait,
+	}
+}
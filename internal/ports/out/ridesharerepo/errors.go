@@ -0,0 +1,27 @@
+package ridesharerepo
+
+import "errors"
+
+var (
+	// ErrOfferNotFound indicates the driver has no ride offer on the trip.
+	ErrOfferNotFound = errors.New("ride offer not found")
+
+	// ErrRequestNotFound indicates the requested ride request does not exist.
+	ErrRequestNotFound = errors.New("ride request not found")
+
+	// ErrAlreadyExists indicates a ride request already exists with the provided ID.
+	ErrAlreadyExists = errors.New("ride request already exists")
+
+	// ErrActiveRequestExists indicates the rider already has a pending or accepted request on the trip.
+	ErrActiveRequestExists = errors.New("rider already has an open ride request")
+
+	// ErrNoSeatsAvailable indicates every seat on the offer is taken by accepted riders.
+	ErrNoSeatsAvailable = errors.New("no seats available")
+
+	// ErrSeatsBelowAccepted indicates an offer cannot shrink below its accepted riders.
+	ErrSeatsBelowAccepted = errors.New("seats below accepted riders")
+
+	// ErrInvalidTransition indicates the request is not in a state that allows the change
+	// (only PENDING requests can be accepted; only PENDING or ACCEPTED requests can be closed).
+	ErrInvalidTransition = errors.New("invalid ride request transition")
+)
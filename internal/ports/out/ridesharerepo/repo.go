@@ -0,0 +1,72 @@
+package ridesharerepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+type Status = domain.RideRequestStatus
+
+const (
+	StatusPending  = domain.RideRequestStatusPending
+	StatusAccepted = domain.RideRequestStatusAccepted
+	StatusDeclined = domain.RideRequestStatusDeclined
+	StatusCanceled = domain.RideRequestStatusCanceled
+)
+
+// Offer is the persistence shape of a driver's seat offer (one per driver per trip).
+type Offer struct {
+	TripID         domain.TripID
+	DriverMemberID domain.MemberID
+	Seats          int
+	Notes          *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Request is the persistence shape of a rider's seat request.
+type Request struct {
+	ID             domain.RideRequestID
+	TripID         domain.TripID
+	DriverMemberID domain.MemberID
+	RiderMemberID  domain.MemberID
+	Status         Status
+	Note           *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Repository provides access to trip ride-share boards.
+//
+// Seat limits are enforced atomically by the repository: AcceptRequest never lets an offer's
+// accepted riders exceed its seats, and PutOffer never shrinks seats below them.
+type Repository interface {
+	// PutOffer creates or replaces the driver's offer (keeping CreatedAt of an existing offer).
+	// Fails with ErrSeatsBelowAccepted when Seats is below the offer's accepted riders.
+	PutOffer(ctx context.Context, o Offer) error
+	GetOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID) (Offer, error)
+	// ListOffersByTrip returns the trip's offers ordered by CreatedAt ascending.
+	ListOffersByTrip(ctx context.Context, tripID domain.TripID) ([]Offer, error)
+	// WithdrawOffer deletes the driver's offer and cancels its pending and accepted requests.
+	WithdrawOffer(ctx context.Context, tripID domain.TripID, driverID domain.MemberID, at time.Time) error
+
+	// CreateRequest stores a PENDING request. Fails with ErrOfferNotFound when the driver has no
+	// offer on the trip, and ErrActiveRequestExists when the rider already has an open request.
+	CreateRequest(ctx context.Context, req Request) error
+	GetRequest(ctx context.Context, id domain.RideRequestID) (Request, error)
+	// ListRequestsByTrip returns all of the trip's requests ordered by CreatedAt ascending.
+	ListRequestsByTrip(ctx context.Context, tripID domain.TripID) ([]Request, error)
+
+	// AcceptRequest moves a PENDING request to ACCEPTED, failing with ErrNoSeatsAvailable when the
+	// offer is full and ErrInvalidTransition when the request is not pending.
+	AcceptRequest(ctx context.Context, id domain.RideRequestID, at time.Time) error
+	// CloseRequest moves a PENDING or ACCEPTED request to DECLINED or CANCELED.
+	CloseRequest(ctx context.Context, id domain.RideRequestID, status Status, at time.Time) error
+
+	// CountAcceptedByTrip counts accepted riders across all of the trip's offers.
+	CountAcceptedByTrip(ctx context.Context, tripID domain.TripID) (int, error)
+}
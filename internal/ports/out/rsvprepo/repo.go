@@ -21,13 +21,47 @@ type RSVP struct {
 
 	Status    Status
 	UpdatedAt time.Time
+
+	// VehicleID is the vehicle the member is bringing; nil when unset. A deleted vehicle
+	// reads back as nil.
+	VehicleID *domain.VehicleID
+
+	// PassengerCount is how many people ride along with the member; PassengerNames optionally
+	// names up to PassengerCount of them.
+	PassengerCount int
+	PassengerNames []string
+
+	// ChangedBy is the member who made the last change: the member themselves, or an
+	// organizer acting on their behalf. Empty for records written before it was tracked.
+	ChangedBy domain.MemberID
+	// ChangeReason is the organizer's note for a change made on the member's behalf.
+	ChangeReason *string
+	// CapacityOverride marks a YES an organizer admitted beyond the trip's capacity.
+	CapacityOverride bool
+}
+
+// HistoryEntry is one change to an RSVP. Upsert appends an entry for every write, in the
+// same transaction; entries are never changed afterwards.
+type HistoryEntry struct {
+	TripID   domain.TripID
+	MemberID domain.MemberID
+
+	// PreviousStatus is the response before the change; nil when the member had no RSVP.
+	PreviousStatus *Status
+	Status         Status
+
+	// ChangedBy and ChangeReason are copied from the RSVP that was written.
+	ChangedBy    domain.MemberID
+	ChangeReason *string
+	ChangedAt    time.Time
 }
 
 type Repository interface {
 	// Get returns the RSVP for (trip, member). If it does not exist, ErrNotFound is returned.
 	Get(ctx context.Context, tripID domain.TripID, memberID domain.MemberID) (RSVP, error)
 
-	// Upsert writes the RSVP for (trip, member) using last-write-wins semantics.
+	// Upsert writes the RSVP for (trip, member) using last-write-wins semantics, and appends
+	// a HistoryEntry (ChangedAt = r.UpdatedAt) in the same transaction.
 	Upsert(ctx context.Context, r RSVP) error
 
 	// ListByTrip returns all RSVP records for a trip.
@@ -35,4 +69,14 @@ type Repository interface {
 
 	// CountYesByTrip counts RSVP=YES for the specified trip.
 	CountYesByTrip(ctx context.Context, tripID domain.TripID) (int, error)
+
+	// CountPeopleByTrip counts people attending the trip: each RSVP=YES member plus their passengers.
+	CountPeopleByTrip(ctx context.Context, tripID domain.TripID) (int, error)
+
+	// ListHistoryByTrip returns every recorded RSVP change for a trip, oldest first.
+	ListHistoryByTrip(ctx context.Context, tripID domain.TripID) ([]HistoryEntry, error)
+
+	// ListHistoryByMember returns every recorded RSVP change for a member across trips,
+	// oldest first.
+	ListHistoryByMember(ctx context.Context, memberID domain.MemberID) ([]HistoryEntry, error)
 }
@@ -5,4 +5,7 @@ import "errors"
 var (
 	ErrNotFound      = errors.New("trip not found")
 	ErrAlreadyExists = errors.New("trip already exists")
+
+	// ErrOccurrenceExists indicates the series already has an occurrence on that date.
+	ErrOccurrenceExists = errors.New("series occurrence already exists")
 )
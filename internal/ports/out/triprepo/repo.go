@@ -41,22 +41,40 @@ type Trip struct {
 	// StartDate is used for sorting; nil means "unknown".
 	StartDate *time.Time
 	EndDate   *time.Time
+	// RSVPDeadline is when the roster locks: after it members can no longer change their own
+	// RSVP. Nil means RSVPs stay open while the trip is published.
+	RSVPDeadline *time.Time
 
 	CapacityRigs *int
+	// CapacityPeople optionally caps headcount across all YES RSVPs (members plus passengers).
+	CapacityPeople *int
 	// AttendingRigs is a read model field populated for published trips (later milestones).
 	AttendingRigs *int
 
+	// Difficulty is the structured rating; DifficultyText holds free-text notes.
+	Difficulty                  *domain.Difficulty
 	DifficultyText              *string
 	MeetingLocation             *domain.Location
 	CommsRequirementsText       *string
 	RecommendedRequirementsText *string
+	Requirements                domain.TripRequirements
 
 	Artifacts []domain.TripArtifact
 
+	// Series links an occurrence to the trip series that generated it; nil for standalone trips.
+	Series *SeriesOccurrence
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// SeriesOccurrence identifies a trip as the occurrence of a series on Date, the date the
+// series scheduled it for. Moving the trip's own dates does not change Date.
+type SeriesOccurrence struct {
+	SeriesID domain.TripSeriesID
+	Date     time.Time
+}
+
 // Repository provides access to persisted trips.
 //
 // Result ordering expectations:
@@ -74,4 +92,7 @@ type Repository interface {
 	// - PUBLIC drafts are visible to organizers (caller must be in OrganizerMemberIDs)
 	// - PRIVATE drafts are visible only to the creator (caller must equal CreatorMemberID)
 	ListDraftsVisibleTo(ctx context.Context, caller domain.MemberID) ([]Trip, error)
+
+	// ListBySeries returns every occurrence of a series, in any status, ordered by Series.Date.
+	ListBySeries(ctx context.Context, seriesID domain.TripSeriesID) ([]Trip, error)
 }
@@ -0,0 +1,11 @@
+package tripseriesrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested series does not exist.
+	ErrNotFound = errors.New("trip series not found")
+
+	// ErrAlreadyExists indicates a series already exists with the provided ID.
+	ErrAlreadyExists = errors.New("trip series already exists")
+)
@@ -0,0 +1,21 @@
+package tripseriesrepo
+
+import (
+	"context"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Series is the persistence shape of a trip series.
+type Series = domain.TripSeries
+
+// Repository provides access to recurring trip series. Occurrences are trips and live in the
+// trip repository (triprepo.Repository.ListBySeries).
+type Repository interface {
+	Create(ctx context.Context, s Series) error
+	// Save replaces a series' schedule and plan; CreatedByMember and CreatedAt are immutable.
+	Save(ctx context.Context, s Series) error
+	GetByID(ctx context.Context, id domain.TripSeriesID) (Series, error)
+	// List returns every series ordered by CreatedAt, then ID.
+	List(ctx context.Context) ([]Series, error)
+}
@@ -0,0 +1,14 @@
+package triptemplaterepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested template does not exist.
+	ErrNotFound = errors.New("trip template not found")
+
+	// ErrAlreadyExists indicates a template already exists with the provided ID.
+	ErrAlreadyExists = errors.New("trip template already exists")
+
+	// ErrNameTaken indicates another template already uses the name (compared case-insensitively).
+	ErrNameTaken = errors.New("trip template name taken")
+)
@@ -0,0 +1,21 @@
+package triptemplaterepo
+
+import (
+	"context"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Template is the persistence shape of a trip template.
+type Template = domain.TripTemplate
+
+// Repository provides access to the club's trip templates.
+type Repository interface {
+	// Create stores a new template. Fails with ErrNameTaken when another template has the same
+	// name ignoring case.
+	Create(ctx context.Context, t Template) error
+	GetByID(ctx context.Context, id domain.TripTemplateID) (Template, error)
+	// List returns every template ordered by name (case-insensitive), then ID.
+	List(ctx context.Context) ([]Template, error)
+	Delete(ctx context.Context, id domain.TripTemplateID) error
+}
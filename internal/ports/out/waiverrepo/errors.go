@@ -0,0 +1,14 @@
+package waiverrepo
+
+import "errors"
+
+var (
+	// ErrNotFound indicates the requested waiver (or waiver version) does not exist.
+	ErrNotFound = errors.New("waiver not found")
+
+	// ErrAlreadyExists indicates a waiver already exists with the provided ID.
+	ErrAlreadyExists = errors.New("waiver already exists")
+
+	// ErrVersionConflict indicates the version being added is not the next one.
+	ErrVersionConflict = errors.New("waiver version conflict")
+)
@@ -0,0 +1,70 @@
+package waiverrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/BennettSmith/ebo-planner-backend/internal/domain"
+)
+
+// Waiver is a waiver document; its text lives in its versions.
+type Waiver struct {
+	ID                  domain.WaiverID
+	Title               string
+	RequiredForAllTrips bool
+
+	// CreatedBy is the admin who created the waiver.
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Version is one published text of a waiver. Versions are numbered from 1 and never change.
+type Version struct {
+	WaiverID    domain.WaiverID
+	Version     int
+	Body        string
+	Hash        string
+	PublishedAt time.Time
+}
+
+// Acknowledgement is a member agreeing to one waiver version. Acknowledgements never change.
+type Acknowledgement struct {
+	WaiverID       domain.WaiverID
+	Version        int
+	VersionHash    string
+	MemberID       domain.MemberID
+	Subject        domain.SubjectID
+	AcknowledgedAt time.Time
+}
+
+// Repository provides access to waivers, acknowledgements and the waivers each trip requires.
+type Repository interface {
+	// Create stores a new waiver with its first version (Version 1).
+	// If the ID is taken, ErrAlreadyExists is returned.
+	Create(ctx context.Context, w Waiver, first Version) error
+	// Get returns the waiver. If it does not exist, ErrNotFound is returned.
+	Get(ctx context.Context, id domain.WaiverID) (Waiver, error)
+	// List returns all waivers ordered by creation time.
+	List(ctx context.Context) ([]Waiver, error)
+
+	// AddVersion publishes v, which must be numbered one past the latest version; otherwise
+	// ErrVersionConflict is returned. If the waiver does not exist, ErrNotFound is returned.
+	AddVersion(ctx context.Context, v Version) error
+	// LatestVersion returns the waiver's current version. If the waiver does not exist,
+	// ErrNotFound is returned.
+	LatestVersion(ctx context.Context, id domain.WaiverID) (Version, error)
+
+	// Acknowledge records a; acknowledging the same version again keeps the first record.
+	// If the waiver version does not exist, ErrNotFound is returned.
+	Acknowledge(ctx context.Context, a Acknowledgement) error
+	// ListAcknowledgementsByMember returns the member's acknowledgements, oldest first.
+	ListAcknowledgementsByMember(ctx context.Context, memberID domain.MemberID) ([]Acknowledgement, error)
+	// ListAcknowledgementsByWaiver returns every acknowledgement of the waiver, oldest first.
+	ListAcknowledgementsByWaiver(ctx context.Context, id domain.WaiverID) ([]Acknowledgement, error)
+
+	// SetTripRequirements replaces the waivers the trip requires on top of club-wide ones.
+	// If any waiver does not exist, ErrNotFound is returned and nothing changes.
+	SetTripRequirements(ctx context.Context, tripID domain.TripID, ids []domain.WaiverID) error
+	// ListTripRequirements returns the waivers the trip requires, ordered by waiver ID.
+	ListTripRequirements(ctx context.Context, tripID domain.TripID) ([]domain.WaiverID, error)
+}
@@ -0,0 +1,3 @@
+-- 000004_rate_limits.down.sql
+
+DROP TABLE IF EXISTS rate_limit_buckets;
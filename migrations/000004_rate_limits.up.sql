@@ -0,0 +1,12 @@
+-- 000004_rate_limits.up.sql
+--
+-- Shared token-bucket state for the API rate limiter so that limits hold across replicas.
+-- Keys are opaque strings built by the HTTP adapter (operation + subject or client IP).
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+  bucket_key text PRIMARY KEY,
+  tokens     double precision NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
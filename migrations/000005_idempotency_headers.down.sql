@@ -0,0 +1,4 @@
+-- 000005_idempotency_headers.down.sql
+
+ALTER TABLE idempotency_keys
+  DROP COLUMN IF EXISTS headers;
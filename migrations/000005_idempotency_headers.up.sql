@@ -0,0 +1,6 @@
+-- 000005_idempotency_headers.up.sql
+--
+-- The generic idempotency middleware replays raw responses, including handler-set headers.
+
+ALTER TABLE idempotency_keys
+  ADD COLUMN IF NOT EXISTS headers jsonb NOT NULL DEFAULT '{}'::jsonb;
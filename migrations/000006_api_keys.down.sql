@@ -0,0 +1,4 @@
+-- 000006_api_keys.down.sql
+
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_keys;
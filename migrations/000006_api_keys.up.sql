@@ -0,0 +1,32 @@
+-- 000006_api_keys.up.sql
+--
+-- Service-account credentials (bots, scheduled jobs) authenticated via `Authorization: ApiKey ...`.
+-- Only a SHA-256 hash of the secret is stored; `prefix` is the public lookup part of the key.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+  id          bigserial PRIMARY KEY,
+  external_id uuid NOT NULL UNIQUE,
+
+  name        text NOT NULL,
+  prefix      text NOT NULL UNIQUE,
+  secret_hash text NOT NULL,
+  scopes      text[] NOT NULL DEFAULT '{}',
+
+  created_by  text NOT NULL,
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  revoked_by  text NULL,
+  revoked_at  timestamptz NULL,
+  last_used_at timestamptz NULL
+);
+
+-- Last-used audit trail.
+CREATE TABLE IF NOT EXISTS api_key_usage (
+  id         bigserial PRIMARY KEY,
+  api_key_id bigint NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+  used_at    timestamptz NOT NULL,
+  method     text NOT NULL,
+  path       text NOT NULL,
+  client_ip  text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_key_usage_key_used_at ON api_key_usage(api_key_id, used_at DESC);
@@ -0,0 +1,5 @@
+-- 000007_member_identities.down.sql
+
+ALTER TABLE members ADD CONSTRAINT members_subject_unique UNIQUE (subject_iss, subject_sub);
+
+DROP TABLE IF EXISTS member_identities;
@@ -0,0 +1,27 @@
+-- 000007_member_identities.up.sql
+--
+-- A member may log in through several identities (issuer + subject), e.g. a second IdP or a
+-- recreated Keycloak account. `member_identities` becomes the authoritative subject binding;
+-- `members.subject_iss/subject_sub` keep the identity the member was created with.
+
+CREATE TABLE IF NOT EXISTS member_identities (
+  id          bigserial PRIMARY KEY,
+  member_id   bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  subject_iss text NOT NULL,
+  subject_sub text NOT NULL,
+  linked_at   timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT member_identities_subject_unique UNIQUE (subject_iss, subject_sub)
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_identities_member_id ON member_identities(member_id);
+
+-- Backfill: every existing member keeps its original login.
+INSERT INTO member_identities (member_id, subject_iss, subject_sub, linked_at)
+SELECT id, subject_iss, subject_sub, created_at
+FROM members
+ON CONFLICT (subject_iss, subject_sub) DO NOTHING;
+
+-- Uniqueness now lives on member_identities: an original login that was unlinked may be
+-- linked to (or create) another member.
+ALTER TABLE members DROP CONSTRAINT IF EXISTS members_subject_unique;
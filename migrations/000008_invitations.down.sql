@@ -0,0 +1,4 @@
+-- 000008_invitations.down.sql
+
+DROP TABLE IF EXISTS invitation_redemptions;
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,40 @@
+-- 000008_invitations.up.sql
+--
+-- Member invitations for invite-only membership (MEMBERSHIP_MODE=invite).
+-- Only a SHA-256 hash of the (normalized) invite code is stored.
+--
+-- Member references are stored as external ids without a foreign key: a redemption is recorded
+-- before the invited member row exists, so the provisioning insert and the redemption can be
+-- rolled back independently.
+
+CREATE TABLE IF NOT EXISTS invitations (
+  id          bigserial PRIMARY KEY,
+  external_id uuid NOT NULL UNIQUE,
+
+  code_hash   text NOT NULL UNIQUE,
+  email       citext NULL,
+
+  max_uses    integer NOT NULL,
+  use_count   integer NOT NULL DEFAULT 0,
+
+  created_by_member_external_id uuid NULL,
+  created_by_admin              text NOT NULL DEFAULT '',
+
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  expires_at  timestamptz NOT NULL,
+  revoked_at  timestamptz NULL,
+
+  CONSTRAINT invitations_max_uses_positive CHECK (max_uses > 0),
+  CONSTRAINT invitations_use_count_range CHECK (use_count >= 0 AND use_count <= max_uses)
+);
+
+-- Who invited whom.
+CREATE TABLE IF NOT EXISTS invitation_redemptions (
+  invitation_id      bigint NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+  member_external_id uuid NOT NULL,
+  redeemed_at        timestamptz NOT NULL,
+
+  PRIMARY KEY (invitation_id, member_external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitation_redemptions_member ON invitation_redemptions(member_external_id);
@@ -0,0 +1,5 @@
+-- 000009_email_verification.down.sql
+
+ALTER TABLE members
+  DROP COLUMN IF EXISTS group_alias_email_verified_at,
+  DROP COLUMN IF EXISTS email_verified_at;
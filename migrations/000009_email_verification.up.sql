@@ -0,0 +1,8 @@
+-- 000009_email_verification.up.sql
+--
+-- Email verification state for member addresses. NULL means unverified; existing members
+-- start unverified and receive no notifications until they confirm their addresses.
+
+ALTER TABLE members
+  ADD COLUMN IF NOT EXISTS email_verified_at timestamptz NULL,
+  ADD COLUMN IF NOT EXISTS group_alias_email_verified_at timestamptz NULL;
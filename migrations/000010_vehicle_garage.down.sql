@@ -0,0 +1,56 @@
+-- 000010_vehicle_garage.down.sql
+--
+-- Restores the single vehicle profile per member from each member's default vehicle.
+-- Non-default vehicles and RSVP vehicle choices are lost.
+
+ALTER TABLE trip_rsvps
+  DROP COLUMN IF EXISTS vehicle_id;
+
+CREATE TABLE IF NOT EXISTS member_vehicle_profiles (
+  id                  bigserial PRIMARY KEY,
+  external_id         uuid NOT NULL DEFAULT gen_random_uuid(),
+  member_id           bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+
+  make                text NULL,
+  model               text NULL,
+  tire_size           text NULL,
+  lift_lockers        text NULL,
+  fuel_range          text NULL,
+  recovery_gear       text NULL,
+  ham_radio_call_sign text NULL,
+  notes               text NULL,
+
+  updated_at          timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT member_vehicle_profiles_external_id_unique UNIQUE (external_id),
+  CONSTRAINT member_vehicle_profiles_member_unique UNIQUE (member_id)
+);
+
+INSERT INTO member_vehicle_profiles (
+  external_id, member_id,
+  make, model, tire_size, lift_lockers, fuel_range, recovery_gear, ham_radio_call_sign, notes,
+  updated_at
+)
+SELECT
+  v.external_id, v.member_id,
+  v.make, v.model, v.tire_size, v.lift_lockers, v.fuel_range, v.recovery_gear, v.ham_radio_call_sign, v.notes,
+  v.updated_at
+FROM member_vehicles v
+WHERE v.is_default;
+
+CREATE OR REPLACE FUNCTION set_vehicle_profile_updated_at()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER trg_member_vehicle_profiles_set_updated_at
+BEFORE UPDATE ON member_vehicle_profiles
+FOR EACH ROW
+EXECUTE FUNCTION set_vehicle_profile_updated_at();
+
+DROP TABLE IF EXISTS member_vehicles;
@@ -0,0 +1,50 @@
+-- 000010_vehicle_garage.up.sql
+--
+-- Members keep a garage of named vehicles instead of a single vehicle profile. Exactly one
+-- vehicle per member is the default (enforced by a partial unique index). Existing profiles
+-- move into the garage as each member's default vehicle, keeping their external ids.
+-- RSVPs may name the vehicle the member is bringing.
+
+CREATE TABLE IF NOT EXISTS member_vehicles (
+  id                  bigserial PRIMARY KEY,
+  external_id         uuid NOT NULL DEFAULT gen_random_uuid(),
+  member_id           bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+
+  name                text NOT NULL,
+  is_default          boolean NOT NULL DEFAULT false,
+
+  make                text NULL,
+  model               text NULL,
+  tire_size           text NULL,
+  lift_lockers        text NULL,
+  fuel_range          text NULL,
+  recovery_gear       text NULL,
+  ham_radio_call_sign text NULL,
+  notes               text NULL,
+
+  created_at          timestamptz NOT NULL DEFAULT now(),
+  updated_at          timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT member_vehicles_external_id_unique UNIQUE (external_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS member_vehicles_default_unique
+  ON member_vehicles(member_id) WHERE is_default;
+CREATE INDEX IF NOT EXISTS idx_member_vehicles_member ON member_vehicles(member_id, created_at);
+
+INSERT INTO member_vehicles (
+  external_id, member_id, name, is_default,
+  make, model, tire_size, lift_lockers, fuel_range, recovery_gear, ham_radio_call_sign, notes,
+  created_at, updated_at
+)
+SELECT
+  p.external_id, p.member_id, 'My vehicle', true,
+  p.make, p.model, p.tire_size, p.lift_lockers, p.fuel_range, p.recovery_gear, p.ham_radio_call_sign, p.notes,
+  p.updated_at, p.updated_at
+FROM member_vehicle_profiles p;
+
+DROP TABLE IF EXISTS member_vehicle_profiles;
+DROP FUNCTION IF EXISTS set_vehicle_profile_updated_at();
+
+ALTER TABLE trip_rsvps
+  ADD COLUMN IF NOT EXISTS vehicle_id bigint NULL REFERENCES member_vehicles(id) ON DELETE SET NULL;
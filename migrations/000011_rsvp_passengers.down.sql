@@ -0,0 +1,91 @@
+-- 000011_rsvp_passengers.down.sql
+--
+-- Drops passenger counts and the people capacity, restoring the rigs-only RSVP trigger and
+-- trip summary view.
+
+DROP VIEW IF EXISTS v_trip_summary;
+CREATE VIEW v_trip_summary AS
+SELECT
+  t.external_id AS trip_id,
+  t.name,
+  t.start_date,
+  t.end_date,
+  t.status,
+  t.draft_visibility,
+  t.capacity_rigs,
+  COALESCE(r.attending_rigs, 0) AS attending_rigs,
+  t.created_at,
+  t.updated_at
+FROM trips t
+LEFT JOIN (
+  SELECT trip_id, count(*) AS attending_rigs
+  FROM trip_rsvps
+  WHERE response = 'YES'
+  GROUP BY trip_id
+) r ON r.trip_id = t.id;
+
+CREATE OR REPLACE FUNCTION enforce_rsvp_rules()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  t_status trip_status;
+  t_capacity integer;
+  current_yes integer;
+  is_yes_transition boolean;
+BEGIN
+  SELECT status, capacity_rigs INTO t_status, t_capacity
+  FROM trips
+  WHERE id = NEW.trip_id
+  FOR UPDATE; -- serialize RSVP mutations per trip for capacity correctness
+
+  IF t_status IS NULL THEN
+    RAISE EXCEPTION 'Trip % does not exist', NEW.trip_id USING ERRCODE = '23503';
+  END IF;
+
+  IF t_status <> 'PUBLISHED' THEN
+    RAISE EXCEPTION 'RSVPs are only allowed when trip is PUBLISHED (status=%)', t_status
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Published trips must always have capacity configured (v1).
+  IF t_capacity IS NULL OR t_capacity < 1 THEN
+    RAISE EXCEPTION 'Trip capacity_rigs must be set to >= 1 for RSVPs (capacity_rigs=%)', t_capacity
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Determine if this change consumes a rig slot.
+  IF TG_OP = 'INSERT' THEN
+    is_yes_transition := (NEW.response = 'YES');
+  ELSE
+    is_yes_transition := (OLD.response <> 'YES' AND NEW.response = 'YES');
+  END IF;
+
+  IF is_yes_transition THEN
+    IF t_capacity IS NOT NULL THEN
+      SELECT count(*) INTO current_yes
+      FROM trip_rsvps
+      WHERE trip_id = NEW.trip_id
+        AND response = 'YES'
+        AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id);
+
+      IF current_yes >= t_capacity THEN
+        RAISE EXCEPTION 'Trip capacity reached (% rigs)', t_capacity
+          USING ERRCODE = '23514';
+      END IF;
+    END IF;
+  END IF;
+
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$;
+
+ALTER TABLE trip_rsvps
+  DROP CONSTRAINT IF EXISTS trip_rsvps_passengers_check,
+  DROP COLUMN IF EXISTS passenger_names,
+  DROP COLUMN IF EXISTS passenger_count;
+
+ALTER TABLE trips
+  DROP CONSTRAINT IF EXISTS trips_capacity_people_check,
+  DROP COLUMN IF EXISTS capacity_people;
@@ -0,0 +1,128 @@
+-- 000011_rsvp_passengers.up.sql
+--
+-- An RSVP is still one rig per member, but people ride along: each RSVP records how many
+-- passengers come with the member (and optionally their names). Trips may cap headcount
+-- with capacity_people in addition to capacity_rigs; the RSVP trigger enforces both.
+
+ALTER TABLE trips
+  ADD COLUMN IF NOT EXISTS capacity_people integer NULL;
+
+ALTER TABLE trips
+  DROP CONSTRAINT IF EXISTS trips_capacity_people_check;
+ALTER TABLE trips
+  ADD CONSTRAINT trips_capacity_people_check CHECK (capacity_people IS NULL OR capacity_people >= 1);
+
+ALTER TABLE trip_rsvps
+  ADD COLUMN IF NOT EXISTS passenger_count integer NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS passenger_names text[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE trip_rsvps
+  DROP CONSTRAINT IF EXISTS trip_rsvps_passengers_check;
+ALTER TABLE trip_rsvps
+  ADD CONSTRAINT trip_rsvps_passengers_check CHECK (
+    passenger_count >= 0
+    AND cardinality(passenger_names) <= passenger_count
+    AND (response = 'YES' OR passenger_count = 0)
+  );
+
+-- =========================================================================
+-- RSVP invariants:
+-- - Allowed only when trip.status = PUBLISHED
+-- - Rig capacity enforced strictly on YES (one rig per member)
+-- - People capacity (when set) enforced on YES, counting each member plus passengers
+-- =========================================================================
+CREATE OR REPLACE FUNCTION enforce_rsvp_rules()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  t_status trip_status;
+  t_capacity integer;
+  t_capacity_people integer;
+  current_yes integer;
+  current_people integer;
+  is_yes_transition boolean;
+  adds_people boolean;
+BEGIN
+  SELECT status, capacity_rigs, capacity_people INTO t_status, t_capacity, t_capacity_people
+  FROM trips
+  WHERE id = NEW.trip_id
+  FOR UPDATE; -- serialize RSVP mutations per trip for capacity correctness
+
+  IF t_status IS NULL THEN
+    RAISE EXCEPTION 'Trip % does not exist', NEW.trip_id USING ERRCODE = '23503';
+  END IF;
+
+  IF t_status <> 'PUBLISHED' THEN
+    RAISE EXCEPTION 'RSVPs are only allowed when trip is PUBLISHED (status=%)', t_status
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Published trips must always have capacity configured (v1).
+  IF t_capacity IS NULL OR t_capacity < 1 THEN
+    RAISE EXCEPTION 'Trip capacity_rigs must be set to >= 1 for RSVPs (capacity_rigs=%)', t_capacity
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Determine if this change consumes a rig slot, and whether it grows the headcount.
+  IF TG_OP = 'INSERT' THEN
+    is_yes_transition := (NEW.response = 'YES');
+    adds_people := (NEW.response = 'YES');
+  ELSE
+    is_yes_transition := (OLD.response <> 'YES' AND NEW.response = 'YES');
+    adds_people := NEW.response = 'YES'
+      AND (OLD.response <> 'YES' OR NEW.passenger_count > OLD.passenger_count);
+  END IF;
+
+  IF is_yes_transition THEN
+    SELECT count(*) INTO current_yes
+    FROM trip_rsvps
+    WHERE trip_id = NEW.trip_id
+      AND response = 'YES'
+      AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id);
+
+    IF current_yes >= t_capacity THEN
+      RAISE EXCEPTION 'Trip capacity reached (% rigs)', t_capacity
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  IF adds_people AND t_capacity_people IS NOT NULL THEN
+    SELECT COALESCE(sum(1 + passenger_count), 0) INTO current_people
+    FROM trip_rsvps
+    WHERE trip_id = NEW.trip_id
+      AND response = 'YES'
+      AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id);
+
+    IF current_people + 1 + NEW.passenger_count > t_capacity_people THEN
+      RAISE EXCEPTION 'Trip capacity reached (% people)', t_capacity_people
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$;
+
+-- Trip listing gains the people cap (column appended, so CREATE OR REPLACE works).
+CREATE OR REPLACE VIEW v_trip_summary AS
+SELECT
+  t.external_id AS trip_id,
+  t.name,
+  t.start_date,
+  t.end_date,
+  t.status,
+  t.draft_visibility,
+  t.capacity_rigs,
+  COALESCE(r.attending_rigs, 0) AS attending_rigs,
+  t.created_at,
+  t.updated_at,
+  t.capacity_people
+FROM trips t
+LEFT JOIN (
+  SELECT trip_id, count(*) AS attending_rigs
+  FROM trip_rsvps
+  WHERE response = 'YES'
+  GROUP BY trip_id
+) r ON r.trip_id = t.id;
@@ -0,0 +1,243 @@
+-- 000012_ride_share.up.sql
+--
+-- Ride-share board per trip. Drivers with a YES RSVP offer open seats; riders without a rig
+-- request a seat and the driver accepts or declines. Accepted riders count toward the trip's
+-- headcount (capacity_people) but not toward its rigs.
+--
+-- Seat limits are enforced here as well as in the application: accepting a request locks the
+-- trip row (the same lock RSVP changes take) and the offer row before counting.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'ride_request_status') THEN
+    CREATE TYPE ride_request_status AS ENUM ('PENDING', 'ACCEPTED', 'DECLINED', 'CANCELED');
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS ride_offers (
+  id               bigserial PRIMARY KEY,
+  trip_id          bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  driver_member_id bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+
+  seats            integer NOT NULL,
+  notes            text NULL,
+
+  created_at       timestamptz NOT NULL DEFAULT now(),
+  updated_at       timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT ride_offers_trip_driver_unique UNIQUE (trip_id, driver_member_id),
+  CONSTRAINT ride_offers_seats_positive CHECK (seats >= 1)
+);
+
+CREATE TABLE IF NOT EXISTS ride_requests (
+  id               bigserial PRIMARY KEY,
+  external_id      uuid NOT NULL DEFAULT gen_random_uuid(),
+  trip_id          bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  driver_member_id bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  rider_member_id  bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+
+  status           ride_request_status NOT NULL DEFAULT 'PENDING',
+  note             text NULL,
+
+  created_at       timestamptz NOT NULL DEFAULT now(),
+  updated_at       timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT ride_requests_external_id_unique UNIQUE (external_id),
+  CONSTRAINT ride_requests_not_self CHECK (driver_member_id <> rider_member_id)
+);
+
+-- A rider holds at most one open (pending or accepted) request per trip.
+CREATE UNIQUE INDEX IF NOT EXISTS ride_requests_active_rider_unique
+  ON ride_requests (trip_id, rider_member_id)
+  WHERE status IN ('PENDING', 'ACCEPTED');
+
+CREATE INDEX IF NOT EXISTS idx_ride_requests_trip_driver ON ride_requests (trip_id, driver_member_id);
+
+-- =========================================================================
+-- Ride request invariants (on transitions to ACCEPTED):
+-- - The driver must have an offer with an open seat
+-- - The trip's people capacity (when set) must have room for one more person
+-- =========================================================================
+CREATE OR REPLACE FUNCTION enforce_ride_request_rules()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  t_capacity_people integer;
+  o_seats integer;
+  taken integer;
+  current_people integer;
+BEGIN
+  IF NEW.status <> 'ACCEPTED' OR (TG_OP = 'UPDATE' AND OLD.status = 'ACCEPTED') THEN
+    RETURN NEW;
+  END IF;
+
+  SELECT capacity_people INTO t_capacity_people
+  FROM trips
+  WHERE id = NEW.trip_id
+  FOR UPDATE; -- serialize with RSVP changes for people capacity
+
+  SELECT seats INTO o_seats
+  FROM ride_offers
+  WHERE trip_id = NEW.trip_id AND driver_member_id = NEW.driver_member_id
+  FOR UPDATE;
+
+  IF o_seats IS NULL THEN
+    RAISE EXCEPTION 'Driver % has no ride offer on trip %', NEW.driver_member_id, NEW.trip_id
+      USING ERRCODE = '23514';
+  END IF;
+
+  SELECT count(*) INTO taken
+  FROM ride_requests
+  WHERE trip_id = NEW.trip_id
+    AND driver_member_id = NEW.driver_member_id
+    AND status = 'ACCEPTED'
+    AND id <> NEW.id;
+
+  IF taken >= o_seats THEN
+    RAISE EXCEPTION 'Ride offer is full (% seats)', o_seats
+      USING ERRCODE = '23514';
+  END IF;
+
+  IF t_capacity_people IS NOT NULL THEN
+    SELECT
+      COALESCE((SELECT sum(1 + passenger_count) FROM trip_rsvps WHERE trip_id = NEW.trip_id AND response = 'YES'), 0)
+      + (SELECT count(*) FROM ride_requests WHERE trip_id = NEW.trip_id AND status = 'ACCEPTED' AND id <> NEW.id)
+    INTO current_people;
+
+    IF current_people + 1 > t_capacity_people THEN
+      RAISE EXCEPTION 'Trip capacity reached (% people)', t_capacity_people
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  RETURN NEW;
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_ride_requests_enforce_rules') THEN
+    CREATE TRIGGER trg_ride_requests_enforce_rules
+    BEFORE INSERT OR UPDATE OF status ON ride_requests
+    FOR EACH ROW
+    EXECUTE FUNCTION enforce_ride_request_rules();
+  END IF;
+END $$;
+
+-- Offers cannot shrink below the riders already accepted.
+CREATE OR REPLACE FUNCTION enforce_ride_offer_seats()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  taken integer;
+BEGIN
+  SELECT count(*) INTO taken
+  FROM ride_requests
+  WHERE trip_id = NEW.trip_id
+    AND driver_member_id = NEW.driver_member_id
+    AND status = 'ACCEPTED';
+
+  IF NEW.seats < taken THEN
+    RAISE EXCEPTION 'Ride offer seats (%) cannot be below accepted riders (%)', NEW.seats, taken
+      USING ERRCODE = '23514';
+  END IF;
+  RETURN NEW;
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_ride_offers_enforce_seats') THEN
+    CREATE TRIGGER trg_ride_offers_enforce_seats
+    BEFORE UPDATE OF seats ON ride_offers
+    FOR EACH ROW
+    EXECUTE FUNCTION enforce_ride_offer_seats();
+  END IF;
+END $$;
+
+-- =========================================================================
+-- RSVP invariants (as in 000011, with accepted riders counted toward people capacity):
+-- - Allowed only when trip.status = PUBLISHED
+-- - Rig capacity enforced strictly on YES (one rig per member)
+-- - People capacity (when set) enforced on YES, counting each member plus passengers and riders
+-- =========================================================================
+CREATE OR REPLACE FUNCTION enforce_rsvp_rules()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  t_status trip_status;
+  t_capacity integer;
+  t_capacity_people integer;
+  current_yes integer;
+  current_people integer;
+  is_yes_transition boolean;
+  adds_people boolean;
+BEGIN
+  SELECT status, capacity_rigs, capacity_people INTO t_status, t_capacity, t_capacity_people
+  FROM trips
+  WHERE id = NEW.trip_id
+  FOR UPDATE; -- serialize RSVP mutations per trip for capacity correctness
+
+  IF t_status IS NULL THEN
+    RAISE EXCEPTION 'Trip % does not exist', NEW.trip_id USING ERRCODE = '23503';
+  END IF;
+
+  IF t_status <> 'PUBLISHED' THEN
+    RAISE EXCEPTION 'RSVPs are only allowed when trip is PUBLISHED (status=%)', t_status
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Published trips must always have capacity configured (v1).
+  IF t_capacity IS NULL OR t_capacity < 1 THEN
+    RAISE EXCEPTION 'Trip capacity_rigs must be set to >= 1 for RSVPs (capacity_rigs=%)', t_capacity
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Determine if this change consumes a rig slot, and whether it grows the headcount.
+  IF TG_OP = 'INSERT' THEN
+    is_yes_transition := (NEW.response = 'YES');
+    adds_people := (NEW.response = 'YES');
+  ELSE
+    is_yes_transition := (OLD.response <> 'YES' AND NEW.response = 'YES');
+    adds_people := NEW.response = 'YES'
+      AND (OLD.response <> 'YES' OR NEW.passenger_count > OLD.passenger_count);
+  END IF;
+
+  IF is_yes_transition THEN
+    SELECT count(*) INTO current_yes
+    FROM trip_rsvps
+    WHERE trip_id = NEW.trip_id
+      AND response = 'YES'
+      AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id);
+
+    IF current_yes >= t_capacity THEN
+      RAISE EXCEPTION 'Trip capacity reached (% rigs)', t_capacity
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  IF adds_people AND t_capacity_people IS NOT NULL THEN
+    SELECT
+      COALESCE((
+        SELECT sum(1 + passenger_count)
+        FROM trip_rsvps
+        WHERE trip_id = NEW.trip_id
+          AND response = 'YES'
+          AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id)
+      ), 0)
+      + (SELECT count(*) FROM ride_requests WHERE trip_id = NEW.trip_id AND status = 'ACCEPTED')
+    INTO current_people;
+
+    IF current_people + 1 + NEW.passenger_count > t_capacity_people THEN
+      RAISE EXCEPTION 'Trip capacity reached (% people)', t_capacity_people
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$;
@@ -0,0 +1,18 @@
+-- 000013_vehicle_requirements.down.sql
+--
+-- Drops the structured trip requirements and vehicle specs; the free-text fields are untouched.
+
+ALTER TABLE member_vehicles
+  DROP CONSTRAINT IF EXISTS member_vehicles_tire_size_inches_check,
+  DROP COLUMN IF EXISTS ham_licensed,
+  DROP COLUMN IF EXISTS recovery_gear_items,
+  DROP COLUMN IF EXISTS has_lockers,
+  DROP COLUMN IF EXISTS tire_size_inches;
+
+ALTER TABLE trips
+  DROP CONSTRAINT IF EXISTS trips_req_min_tire_size_check,
+  DROP COLUMN IF EXISTS req_strict,
+  DROP COLUMN IF EXISTS req_ham_license,
+  DROP COLUMN IF EXISTS req_recovery_gear,
+  DROP COLUMN IF EXISTS req_lockers,
+  DROP COLUMN IF EXISTS req_min_tire_size_inches;
@@ -0,0 +1,29 @@
+-- 000013_vehicle_requirements.up.sql
+--
+-- Structured vehicle requirements on trips and matching structured specs on garage vehicles.
+-- The free-text fields (recommended_requirements_text, tire_size, lift_lockers, recovery_gear)
+-- stay as they are; the service compares only the structured columns. Recovery gear items
+-- are checklist codes validated by the service.
+
+ALTER TABLE trips
+  ADD COLUMN IF NOT EXISTS req_min_tire_size_inches integer NULL,
+  ADD COLUMN IF NOT EXISTS req_lockers boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS req_recovery_gear text[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS req_ham_license boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS req_strict boolean NOT NULL DEFAULT false;
+
+ALTER TABLE trips
+  DROP CONSTRAINT IF EXISTS trips_req_min_tire_size_check;
+ALTER TABLE trips
+  ADD CONSTRAINT trips_req_min_tire_size_check CHECK (req_min_tire_size_inches IS NULL OR req_min_tire_size_inches >= 1);
+
+ALTER TABLE member_vehicles
+  ADD COLUMN IF NOT EXISTS tire_size_inches integer NULL,
+  ADD COLUMN IF NOT EXISTS has_lockers boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS recovery_gear_items text[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS ham_licensed boolean NOT NULL DEFAULT false;
+
+ALTER TABLE member_vehicles
+  DROP CONSTRAINT IF EXISTS member_vehicles_tire_size_inches_check;
+ALTER TABLE member_vehicles
+  ADD CONSTRAINT member_vehicles_tire_size_inches_check CHECK (tire_size_inches IS NULL OR tire_size_inches >= 1);
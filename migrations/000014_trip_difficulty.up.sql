@@ -0,0 +1,146 @@
+-- 000014_trip_difficulty.up.sql
+--
+-- Structured trip difficulty: a rating on the club's scale, an optional easier/harder range for
+-- routes with bypasses or optional hard lines, and terrain tags. difficulty_text stays as
+-- free-text notes. The upper bound of the scale is configuration, so only the ordering of the
+-- ratings is enforced here; terrain tags are validated by the service.
+
+ALTER TABLE trips
+  ADD COLUMN IF NOT EXISTS difficulty_rating integer NULL,
+  ADD COLUMN IF NOT EXISTS difficulty_min_rating integer NULL,
+  ADD COLUMN IF NOT EXISTS difficulty_max_rating integer NULL,
+  ADD COLUMN IF NOT EXISTS difficulty_terrain text[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE trips
+  DROP CONSTRAINT IF EXISTS trips_difficulty_check;
+ALTER TABLE trips
+  ADD CONSTRAINT trips_difficulty_check CHECK (
+    (difficulty_rating IS NULL
+      AND difficulty_min_rating IS NULL
+      AND difficulty_max_rating IS NULL
+      AND cardinality(difficulty_terrain) = 0)
+    OR
+    (difficulty_rating >= 1
+      AND (difficulty_min_rating IS NULL OR (difficulty_min_rating >= 1 AND difficulty_min_rating <= difficulty_rating))
+      AND (difficulty_max_rating IS NULL OR difficulty_max_rating >= difficulty_rating))
+  );
+
+-- Publishing requires the structured rating instead of difficulty_text.
+CREATE OR REPLACE FUNCTION trips_enforce_transitions()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  organizer_count integer;
+  current_yes integer;
+BEGIN
+  -- Trips cannot be un-canceled (v1).
+  IF OLD.status = 'CANCELED' AND NEW.status <> 'CANCELED' THEN
+    RAISE EXCEPTION 'Trip cannot be un-canceled (was % -> %)', OLD.status, NEW.status
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Prevent reducing capacity below current attendance for published trips.
+  -- (Drafts have no RSVP; canceled trips are read-only at the app layer but this keeps the DB consistent.)
+  IF OLD.status = 'PUBLISHED'
+     AND NEW.capacity_rigs IS NOT NULL
+     AND (NEW.capacity_rigs IS DISTINCT FROM OLD.capacity_rigs) THEN
+    SELECT count(*) INTO current_yes
+    FROM trip_rsvps
+    WHERE trip_id = OLD.id
+      AND response = 'YES';
+
+    IF NEW.capacity_rigs < current_yes THEN
+      RAISE EXCEPTION 'Trip capacity_rigs (%) cannot be less than attending_rigs (%)', NEW.capacity_rigs, current_yes
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  -- If status is changing to PUBLISHED, enforce required-at-publish fields (v1).
+  IF (OLD.status <> 'PUBLISHED' AND NEW.status = 'PUBLISHED') THEN
+    -- Must come from DRAFT
+    IF OLD.status <> 'DRAFT' THEN
+      RAISE EXCEPTION 'Trip can only be published from DRAFT (was %)', OLD.status
+        USING ERRCODE = '23514';
+    END IF;
+
+    -- Only PUBLIC drafts are publishable (v1).
+    IF OLD.draft_visibility <> 'PUBLIC' THEN
+      RAISE EXCEPTION 'Trip can only be published when draft_visibility = PUBLIC (was %)', OLD.draft_visibility
+        USING ERRCODE = '23514';
+    END IF;
+
+    -- Required fields
+    IF NEW.name IS NULL OR btrim(NEW.name) = '' THEN
+      RAISE EXCEPTION 'Trip name is required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.description IS NULL OR btrim(NEW.description) = '' THEN
+      RAISE EXCEPTION 'Trip description is required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.start_date IS NULL OR NEW.end_date IS NULL THEN
+      RAISE EXCEPTION 'Trip start_date and end_date are required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.capacity_rigs IS NULL OR NEW.capacity_rigs < 1 THEN
+      RAISE EXCEPTION 'Trip capacity_rigs is required to publish and must be >= 1' USING ERRCODE = '23514';
+    END IF;
+    -- The structured rating is required; difficulty_text is optional notes.
+    IF NEW.difficulty_rating IS NULL THEN
+      RAISE EXCEPTION 'Trip difficulty_rating is required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.meeting_location_label IS NULL OR btrim(NEW.meeting_location_label) = '' THEN
+      RAISE EXCEPTION 'Trip meeting_location.label is required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.comms_requirements_text IS NULL OR btrim(NEW.comms_requirements_text) = '' THEN
+      RAISE EXCEPTION 'Trip comms_requirements_text is required to publish' USING ERRCODE = '23514';
+    END IF;
+    IF NEW.recommended_requirements_text IS NULL OR btrim(NEW.recommended_requirements_text) = '' THEN
+      RAISE EXCEPTION 'Trip recommended_requirements_text is required to publish' USING ERRCODE = '23514';
+    END IF;
+
+    SELECT count(*) INTO organizer_count
+    FROM trip_organizers
+    WHERE trip_id = NEW.id;
+
+    IF organizer_count < 1 THEN
+      RAISE EXCEPTION 'Trip must have at least one organizer to publish' USING ERRCODE = '23514';
+    END IF;
+
+    NEW.published_at := COALESCE(NEW.published_at, now());
+    NEW.draft_visibility := NULL; -- no longer relevant after publish
+  END IF;
+
+  -- If status is changing to CANCELED, set canceled_at (idempotent allowed)
+  IF (OLD.status <> 'CANCELED' AND NEW.status = 'CANCELED') THEN
+    NEW.canceled_at := COALESCE(NEW.canceled_at, now());
+    NEW.draft_visibility := NULL;
+  END IF;
+
+  RETURN NEW;
+END;
+$$;
+
+-- Trip listing gains the difficulty so lists can be filtered (columns appended).
+CREATE OR REPLACE VIEW v_trip_summary AS
+SELECT
+  t.external_id AS trip_id,
+  t.name,
+  t.start_date,
+  t.end_date,
+  t.status,
+  t.draft_visibility,
+  t.capacity_rigs,
+  COALESCE(r.attending_rigs, 0) AS attending_rigs,
+  t.created_at,
+  t.updated_at,
+  t.capacity_people,
+  t.difficulty_rating,
+  t.difficulty_min_rating,
+  t.difficulty_max_rating,
+  t.difficulty_terrain
+FROM trips t
+LEFT JOIN (
+  SELECT trip_id, count(*) AS attending_rigs
+  FROM trip_rsvps
+  WHERE response = 'YES'
+  GROUP BY trip_id
+) r ON r.trip_id = t.id;
@@ -0,0 +1,5 @@
+-- 000015_trip_templates.down.sql
+
+DROP TABLE IF EXISTS trip_template_artifacts;
+DROP INDEX IF EXISTS trip_templates_name_unique;
+DROP TABLE IF EXISTS trip_templates;
@@ -0,0 +1,52 @@
+-- 000015_trip_templates.up.sql
+--
+-- Named, club-wide trip templates. A template holds the planning fields of a trip (no dates,
+-- organizers, RSVPs or status) and its artifacts; new drafts are created from it. Names are
+-- unique ignoring case.
+
+CREATE TABLE IF NOT EXISTS trip_templates (
+  id                            bigserial PRIMARY KEY,
+  external_id                   uuid NOT NULL DEFAULT gen_random_uuid(),
+  name                          text NOT NULL,
+  created_by_member_id          bigint NOT NULL REFERENCES members(id) ON DELETE RESTRICT,
+
+  trip_name                     text NULL,
+  description                   text NULL,
+  capacity_rigs                 integer NULL CHECK (capacity_rigs IS NULL OR capacity_rigs >= 1),
+  capacity_people               integer NULL CHECK (capacity_people IS NULL OR capacity_people >= 1),
+  difficulty_rating             integer NULL,
+  difficulty_min_rating         integer NULL,
+  difficulty_max_rating         integer NULL,
+  difficulty_terrain            text[] NOT NULL DEFAULT '{}',
+  difficulty_text               text NULL,
+  meeting_location_label        text NULL,
+  meeting_location_address      text NULL,
+  meeting_location_latitude     double precision NULL,
+  meeting_location_longitude    double precision NULL,
+  comms_requirements_text       text NULL,
+  recommended_requirements_text text NULL,
+  req_min_tire_size_inches      integer NULL CHECK (req_min_tire_size_inches IS NULL OR req_min_tire_size_inches >= 1),
+  req_lockers                   boolean NOT NULL DEFAULT false,
+  req_recovery_gear             text[] NOT NULL DEFAULT '{}',
+  req_ham_license               boolean NOT NULL DEFAULT false,
+  req_strict                    boolean NOT NULL DEFAULT false,
+
+  created_at                    timestamptz NOT NULL DEFAULT now(),
+  updated_at                    timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT trip_templates_external_id_unique UNIQUE (external_id),
+  CONSTRAINT trip_templates_name_nonempty CHECK (btrim(name) <> '')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS trip_templates_name_unique
+  ON trip_templates (lower(name));
+
+-- Template artifacts are copied (with new ids) into each trip created from the template.
+CREATE TABLE IF NOT EXISTS trip_template_artifacts (
+  template_id  bigint NOT NULL REFERENCES trip_templates(id) ON DELETE CASCADE,
+  sort_order   integer NOT NULL,
+  type         artifact_type NOT NULL,
+  title        text NOT NULL,
+  url          text NOT NULL,
+  PRIMARY KEY (template_id, sort_order)
+);
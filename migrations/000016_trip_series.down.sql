@@ -0,0 +1,10 @@
+-- 000016_trip_series.down.sql
+
+DROP INDEX IF EXISTS trips_series_date_unique;
+ALTER TABLE trips DROP CONSTRAINT IF EXISTS trips_series_check;
+ALTER TABLE trips
+  DROP COLUMN IF EXISTS series_date,
+  DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS trip_series_artifacts;
+DROP TABLE IF EXISTS trip_series;
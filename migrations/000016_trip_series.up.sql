@@ -0,0 +1,66 @@
+-- 000016_trip_series.up.sql
+--
+-- Recurring trip series. A series holds a recurrence rule (canonical RRULE text, parsed by
+-- the service), the schedule bounds and a copy of the planning fields of its template trip.
+-- Occurrences are ordinary trips that point back at the series together with the date the
+-- series scheduled them for; at most one occurrence exists per series and date, so canceled
+-- occurrences are never regenerated.
+
+CREATE TABLE IF NOT EXISTS trip_series (
+  id                            bigserial PRIMARY KEY,
+  external_id                   uuid NOT NULL DEFAULT gen_random_uuid(),
+  created_by_member_id          bigint NOT NULL REFERENCES members(id) ON DELETE RESTRICT,
+  recurrence                    text NOT NULL,
+  start_date                    date NOT NULL,
+  end_date                      date NULL,
+  duration_days                 integer NOT NULL DEFAULT 1 CHECK (duration_days >= 1),
+  publish                       boolean NOT NULL DEFAULT false,
+
+  trip_name                     text NULL,
+  description                   text NULL,
+  capacity_rigs                 integer NULL CHECK (capacity_rigs IS NULL OR capacity_rigs >= 1),
+  capacity_people               integer NULL CHECK (capacity_people IS NULL OR capacity_people >= 1),
+  difficulty_rating             integer NULL,
+  difficulty_min_rating         integer NULL,
+  difficulty_max_rating         integer NULL,
+  difficulty_terrain            text[] NOT NULL DEFAULT '{}',
+  difficulty_text               text NULL,
+  meeting_location_label        text NULL,
+  meeting_location_address      text NULL,
+  meeting_location_latitude     double precision NULL,
+  meeting_location_longitude    double precision NULL,
+  comms_requirements_text       text NULL,
+  recommended_requirements_text text NULL,
+  req_min_tire_size_inches      integer NULL CHECK (req_min_tire_size_inches IS NULL OR req_min_tire_size_inches >= 1),
+  req_lockers                   boolean NOT NULL DEFAULT false,
+  req_recovery_gear             text[] NOT NULL DEFAULT '{}',
+  req_ham_license               boolean NOT NULL DEFAULT false,
+  req_strict                    boolean NOT NULL DEFAULT false,
+
+  created_at                    timestamptz NOT NULL DEFAULT now(),
+  updated_at                    timestamptz NOT NULL DEFAULT now(),
+
+  CONSTRAINT trip_series_external_id_unique UNIQUE (external_id),
+  CONSTRAINT trip_series_dates_check CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- Series artifacts are copied (with new ids) into each generated occurrence.
+CREATE TABLE IF NOT EXISTS trip_series_artifacts (
+  series_id    bigint NOT NULL REFERENCES trip_series(id) ON DELETE CASCADE,
+  sort_order   integer NOT NULL,
+  type         artifact_type NOT NULL,
+  title        text NOT NULL,
+  url          text NOT NULL,
+  PRIMARY KEY (series_id, sort_order)
+);
+
+ALTER TABLE trips
+  ADD COLUMN IF NOT EXISTS series_id bigint NULL REFERENCES trip_series(id) ON DELETE RESTRICT,
+  ADD COLUMN IF NOT EXISTS series_date date NULL;
+
+ALTER TABLE trips
+  ADD CONSTRAINT trips_series_check CHECK ((series_id IS NULL) = (series_date IS NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS trips_series_date_unique
+  ON trips (series_id, series_date)
+  WHERE series_id IS NOT NULL;
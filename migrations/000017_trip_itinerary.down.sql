@@ -0,0 +1,6 @@
+-- 000017_trip_itinerary.down.sql
+
+DROP TABLE IF EXISTS trip_itinerary_segments;
+DROP TABLE IF EXISTS trip_itinerary_stops;
+DROP TABLE IF EXISTS trip_itinerary_days;
+DROP TYPE IF EXISTS itinerary_stop_type;
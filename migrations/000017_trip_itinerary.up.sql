@@ -0,0 +1,58 @@
+-- 000017_trip_itinerary.up.sql
+--
+-- Per-day itinerary for (multi-day) trips: a day row per date with ordered stops (meeting
+-- points, campsites, fuel stops, waypoints) and route segments. The service keeps each date
+-- within the trip's start_date..end_date; rows go away with their trip.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'itinerary_stop_type') THEN
+    CREATE TYPE itinerary_stop_type AS ENUM ('MEETING_POINT', 'CAMPSITE', 'FUEL', 'WAYPOINT');
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS trip_itinerary_days (
+  trip_id     bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  day_date    date NOT NULL,
+  title       text NULL,
+  notes       text NULL,
+
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  updated_at  timestamptz NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (trip_id, day_date)
+);
+
+CREATE TABLE IF NOT EXISTS trip_itinerary_stops (
+  trip_id     bigint NOT NULL,
+  day_date    date NOT NULL,
+  sort_order  integer NOT NULL,
+  type        itinerary_stop_type NOT NULL,
+  label       text NOT NULL,
+  address     text NULL,
+  latitude    double precision NULL,
+  longitude   double precision NULL,
+  stop_time   text NULL,
+  notes       text NULL,
+
+  PRIMARY KEY (trip_id, day_date, sort_order),
+  FOREIGN KEY (trip_id, day_date) REFERENCES trip_itinerary_days(trip_id, day_date) ON DELETE CASCADE,
+  CONSTRAINT trip_itinerary_stops_coordinates_check CHECK (
+    (latitude IS NULL) = (longitude IS NULL)
+    AND (latitude IS NULL OR latitude BETWEEN -90 AND 90)
+    AND (longitude IS NULL OR longitude BETWEEN -180 AND 180)
+  ),
+  CONSTRAINT trip_itinerary_stops_time_check CHECK (stop_time IS NULL OR stop_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$')
+);
+
+CREATE TABLE IF NOT EXISTS trip_itinerary_segments (
+  trip_id        bigint NOT NULL,
+  day_date       date NOT NULL,
+  sort_order     integer NOT NULL,
+  name           text NOT NULL,
+  distance_miles double precision NULL CHECK (distance_miles IS NULL OR distance_miles >= 0),
+  notes          text NULL,
+
+  PRIMARY KEY (trip_id, day_date, sort_order),
+  FOREIGN KEY (trip_id, day_date) REFERENCES trip_itinerary_days(trip_id, day_date) ON DELETE CASCADE
+);
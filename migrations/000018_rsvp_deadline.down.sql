@@ -0,0 +1,6 @@
+-- 000018_rsvp_deadline.down.sql
+--
+-- Drops the RSVP deadline.
+
+ALTER TABLE trips
+  DROP COLUMN IF EXISTS rsvp_deadline;
@@ -0,0 +1,8 @@
+-- 000018_rsvp_deadline.up.sql
+--
+-- Trips may lock their roster ahead of departure: after rsvp_deadline members can no longer
+-- change their own RSVP. Organizers can still change RSVPs on a member's behalf, so the
+-- deadline is enforced by the service rather than the RSVP trigger.
+
+ALTER TABLE trips
+  ADD COLUMN IF NOT EXISTS rsvp_deadline timestamptz NULL;
@@ -0,0 +1,100 @@
+-- 000019_rsvp_changes.up.sql
+--
+-- Organizers can change a member's RSVP on their behalf. Each RSVP records who made the last
+-- change and, for organizer changes, why. An organizer may explicitly admit trip staff beyond
+-- the trip's capacity; such RSVPs carry capacity_override and skip the capacity checks.
+
+ALTER TABLE trip_rsvps
+  ADD COLUMN IF NOT EXISTS changed_by_member_id bigint NULL REFERENCES members(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS change_reason text NULL,
+  ADD COLUMN IF NOT EXISTS capacity_override boolean NOT NULL DEFAULT false;
+
+ALTER TABLE trip_rsvps
+  DROP CONSTRAINT IF EXISTS trip_rsvps_capacity_override_check;
+ALTER TABLE trip_rsvps
+  ADD CONSTRAINT trip_rsvps_capacity_override_check CHECK (response = 'YES' OR NOT capacity_override);
+
+CREATE OR REPLACE FUNCTION enforce_rsvp_rules()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+  t_status trip_status;
+  t_capacity integer;
+  t_capacity_people integer;
+  current_yes integer;
+  current_people integer;
+  is_yes_transition boolean;
+  adds_people boolean;
+BEGIN
+  SELECT status, capacity_rigs, capacity_people INTO t_status, t_capacity, t_capacity_people
+  FROM trips
+  WHERE id = NEW.trip_id
+  FOR UPDATE; -- serialize RSVP mutations per trip for capacity correctness
+
+  IF t_status IS NULL THEN
+    RAISE EXCEPTION 'Trip % does not exist', NEW.trip_id USING ERRCODE = '23503';
+  END IF;
+
+  IF t_status <> 'PUBLISHED' THEN
+    RAISE EXCEPTION 'RSVPs are only allowed when trip is PUBLISHED (status=%)', t_status
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Published trips must always have capacity configured (v1).
+  IF t_capacity IS NULL OR t_capacity < 1 THEN
+    RAISE EXCEPTION 'Trip capacity_rigs must be set to >= 1 for RSVPs (capacity_rigs=%)', t_capacity
+      USING ERRCODE = '23514';
+  END IF;
+
+  -- Determine if this change consumes a rig slot, and whether it grows the headcount.
+  IF TG_OP = 'INSERT' THEN
+    is_yes_transition := (NEW.response = 'YES');
+    adds_people := (NEW.response = 'YES');
+  ELSE
+    is_yes_transition := (OLD.response <> 'YES' AND NEW.response = 'YES');
+    adds_people := NEW.response = 'YES'
+      AND (OLD.response <> 'YES' OR NEW.passenger_count > OLD.passenger_count);
+  END IF;
+
+  -- Organizers may explicitly admit trip staff beyond capacity.
+  IF NEW.capacity_override THEN
+    is_yes_transition := false;
+    adds_people := false;
+  END IF;
+
+  IF is_yes_transition THEN
+    SELECT count(*) INTO current_yes
+    FROM trip_rsvps
+    WHERE trip_id = NEW.trip_id
+      AND response = 'YES'
+      AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id);
+
+    IF current_yes >= t_capacity THEN
+      RAISE EXCEPTION 'Trip capacity reached (% rigs)', t_capacity
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  IF adds_people AND t_capacity_people IS NOT NULL THEN
+    SELECT
+      COALESCE((
+        SELECT sum(1 + passenger_count)
+        FROM trip_rsvps
+        WHERE trip_id = NEW.trip_id
+          AND response = 'YES'
+          AND NOT (TG_OP = 'UPDATE' AND member_id = NEW.member_id)
+      ), 0)
+      + (SELECT count(*) FROM ride_requests WHERE trip_id = NEW.trip_id AND status = 'ACCEPTED')
+    INTO current_people;
+
+    IF current_people + 1 + NEW.passenger_count > t_capacity_people THEN
+      RAISE EXCEPTION 'Trip capacity reached (% people)', t_capacity_people
+        USING ERRCODE = '23514';
+    END IF;
+  END IF;
+
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$;
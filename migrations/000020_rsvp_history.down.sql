@@ -0,0 +1,7 @@
+-- 000020_rsvp_history.down.sql
+--
+-- Drops the RSVP history.
+
+DROP TRIGGER IF EXISTS trg_trip_rsvp_history_append_only ON trip_rsvp_history;
+DROP FUNCTION IF EXISTS prevent_rsvp_history_change();
+DROP TABLE IF EXISTS trip_rsvp_history;
@@ -0,0 +1,45 @@
+-- 000020_rsvp_history.up.sql
+--
+-- Append-only RSVP history: the repository writes one row per RSVP write, in the same
+-- transaction, with the response it replaced. Rows cannot be edited; the only update allowed
+-- is the foreign key clearing changed_by_member_id when that member is deleted.
+
+CREATE TABLE IF NOT EXISTS trip_rsvp_history (
+  id                    bigserial PRIMARY KEY,
+  trip_id               bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  member_id             bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  previous_response     rsvp_response NULL,
+  response              rsvp_response NOT NULL,
+  changed_by_member_id  bigint NULL REFERENCES members(id) ON DELETE SET NULL,
+  change_reason         text NULL,
+  changed_at            timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_rsvp_history_trip ON trip_rsvp_history(trip_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_trip_rsvp_history_member ON trip_rsvp_history(member_id, changed_at);
+
+CREATE OR REPLACE FUNCTION prevent_rsvp_history_change()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+  RAISE EXCEPTION 'RSVP history is append-only (id=%)', OLD.id
+    USING ERRCODE = '23514';
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_trip_rsvp_history_append_only') THEN
+    CREATE TRIGGER trg_trip_rsvp_history_append_only
+    BEFORE UPDATE OF trip_id, member_id, previous_response, response, change_reason, changed_at ON trip_rsvp_history
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_rsvp_history_change();
+  END IF;
+END $$;
+
+-- Existing RSVPs start their history with their current response.
+INSERT INTO trip_rsvp_history (trip_id, member_id, previous_response, response, changed_by_member_id, change_reason, changed_at)
+SELECT trip_id, member_id, NULL, response, changed_by_member_id, change_reason, updated_at
+FROM trip_rsvps
+WHERE NOT EXISTS (SELECT 1 FROM trip_rsvp_history);
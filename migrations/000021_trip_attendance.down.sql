@@ -0,0 +1,4 @@
+-- 000021_trip_attendance.down.sql
+
+DROP TABLE IF EXISTS trip_attendance;
+DROP TYPE IF EXISTS attendance_status;
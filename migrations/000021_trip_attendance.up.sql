@@ -0,0 +1,30 @@
+-- 000021_trip_attendance.up.sql
+--
+-- Day-of attendance: organizers mark members PRESENT or ABSENT on a published trip, and check
+-- in walk-ups (PRESENT without a YES RSVP). The service limits check-in to the trip's dates;
+-- rows go away with their trip or member.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'attendance_status') THEN
+    CREATE TYPE attendance_status AS ENUM ('PRESENT', 'ABSENT');
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS trip_attendance (
+  trip_id                bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  member_id              bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  status                 attendance_status NOT NULL,
+  walk_up                boolean NOT NULL DEFAULT false,
+  checked_in_at          timestamptz NULL,
+  recorded_by_member_id  bigint NULL REFERENCES members(id) ON DELETE SET NULL,
+
+  created_at             timestamptz NOT NULL DEFAULT now(),
+  updated_at             timestamptz NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (trip_id, member_id),
+  CONSTRAINT trip_attendance_checked_in_check CHECK ((status = 'PRESENT') = (checked_in_at IS NOT NULL)),
+  CONSTRAINT trip_attendance_walk_up_check CHECK (status = 'PRESENT' OR NOT walk_up)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_attendance_member ON trip_attendance(member_id);
@@ -0,0 +1,7 @@
+-- 000022_emergency_info.down.sql
+
+DROP TRIGGER IF EXISTS trg_member_emergency_info_access_append_only ON member_emergency_info_access;
+DROP FUNCTION IF EXISTS prevent_emergency_info_access_change();
+DROP TABLE IF EXISTS member_emergency_info_access;
+DROP TABLE IF EXISTS member_emergency_info;
+DROP TYPE IF EXISTS emergency_info_action;
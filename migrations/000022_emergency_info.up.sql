@@ -0,0 +1,53 @@
+-- 000022_emergency_info.up.sql
+--
+-- Members' emergency contact and medical notes, encrypted by the application (AES-256-GCM)
+-- with a key from its key provider; the database only stores the key ID, nonce and
+-- ciphertext. Every access is written to an append-only log.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'emergency_info_action') THEN
+    CREATE TYPE emergency_info_action AS ENUM ('VIEW', 'UPDATE', 'DELETE');
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS member_emergency_info (
+  member_id   bigint PRIMARY KEY REFERENCES members(id) ON DELETE CASCADE,
+  key_id      text NOT NULL,
+  nonce       bytea NOT NULL,
+  ciphertext  bytea NOT NULL,
+
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  updated_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS member_emergency_info_access (
+  id                     bigserial PRIMARY KEY,
+  member_id              bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  accessed_by_member_id  bigint NULL REFERENCES members(id) ON DELETE SET NULL,
+  trip_id                bigint NULL REFERENCES trips(id) ON DELETE SET NULL,
+  action                 emergency_info_action NOT NULL,
+  accessed_at            timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_emergency_info_access_member ON member_emergency_info_access(member_id, accessed_at);
+
+CREATE OR REPLACE FUNCTION prevent_emergency_info_access_change()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+  RAISE EXCEPTION 'emergency info access log is append-only (id=%)', OLD.id
+    USING ERRCODE = '23514';
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_member_emergency_info_access_append_only') THEN
+    CREATE TRIGGER trg_member_emergency_info_access_append_only
+    BEFORE UPDATE OF member_id, action, accessed_at ON member_emergency_info_access
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_emergency_info_access_change();
+  END IF;
+END $$;
@@ -0,0 +1,9 @@
+-- 000023_waivers.down.sql
+
+DROP TRIGGER IF EXISTS trg_waiver_acknowledgements_immutable ON waiver_acknowledgements;
+DROP TRIGGER IF EXISTS trg_waiver_versions_immutable ON waiver_versions;
+DROP FUNCTION IF EXISTS prevent_waiver_record_change();
+DROP TABLE IF EXISTS trip_required_waivers;
+DROP TABLE IF EXISTS waiver_acknowledgements;
+DROP TABLE IF EXISTS waiver_versions;
+DROP TABLE IF EXISTS waivers;
@@ -0,0 +1,73 @@
+-- 000023_waivers.up.sql
+--
+-- Liability waivers: versioned documents, member acknowledgements of a specific version, and
+-- the waivers each trip requires on top of club-wide ones. Published versions and
+-- acknowledgements are never changed.
+
+CREATE TABLE IF NOT EXISTS waivers (
+  id                      bigserial PRIMARY KEY,
+  external_id             uuid NOT NULL UNIQUE,
+  title                   text NOT NULL,
+  required_for_all_trips  boolean NOT NULL DEFAULT false,
+  created_by              text NOT NULL DEFAULT '',
+  created_at              timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS waiver_versions (
+  waiver_id     bigint NOT NULL REFERENCES waivers(id),
+  version       int NOT NULL,
+  body          text NOT NULL,
+  version_hash  text NOT NULL,
+  published_at  timestamptz NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (waiver_id, version),
+  CONSTRAINT waiver_versions_version_check CHECK (version >= 1),
+  CONSTRAINT waiver_versions_hash_check CHECK (version_hash ~ '^[0-9a-f]{64}$')
+);
+
+CREATE TABLE IF NOT EXISTS waiver_acknowledgements (
+  waiver_id        bigint NOT NULL,
+  version          int NOT NULL,
+  member_id        bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  version_hash     text NOT NULL,
+  subject          text NOT NULL,
+  acknowledged_at  timestamptz NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (waiver_id, version, member_id),
+  FOREIGN KEY (waiver_id, version) REFERENCES waiver_versions(waiver_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waiver_acknowledgements_member ON waiver_acknowledgements(member_id, acknowledged_at);
+
+CREATE TABLE IF NOT EXISTS trip_required_waivers (
+  trip_id    bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  waiver_id  bigint NOT NULL REFERENCES waivers(id),
+
+  PRIMARY KEY (trip_id, waiver_id)
+);
+
+CREATE OR REPLACE FUNCTION prevent_waiver_record_change()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+  RAISE EXCEPTION '% rows are immutable', TG_TABLE_NAME
+    USING ERRCODE = '23514';
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_waiver_versions_immutable') THEN
+    CREATE TRIGGER trg_waiver_versions_immutable
+    BEFORE UPDATE ON waiver_versions
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_waiver_record_change();
+  END IF;
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_waiver_acknowledgements_immutable') THEN
+    CREATE TRIGGER trg_waiver_acknowledgements_immutable
+    BEFORE UPDATE ON waiver_acknowledgements
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_waiver_record_change();
+  END IF;
+END $$;
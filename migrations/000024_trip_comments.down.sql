@@ -0,0 +1,3 @@
+-- 000024_trip_comments.down.sql
+
+DROP TABLE IF EXISTS trip_comments;
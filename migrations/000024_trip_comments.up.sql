@@ -0,0 +1,27 @@
+-- 000024_trip_comments.up.sql
+--
+-- Trip discussion threads. Replies reference a comment on the same trip. Deleted comments stay
+-- as placeholders with their text removed; rows go away with their trip or author.
+
+CREATE TABLE IF NOT EXISTS trip_comments (
+  id                    bigserial PRIMARY KEY,
+  external_id           uuid NOT NULL UNIQUE,
+  trip_id               bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  author_member_id      bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  parent_id             bigint NULL,
+
+  body                  text NOT NULL,
+  pinned                boolean NOT NULL DEFAULT false,
+
+  created_at            timestamptz NOT NULL DEFAULT now(),
+  edited_at             timestamptz NULL,
+  deleted_at            timestamptz NULL,
+  deleted_by_member_id  bigint NULL REFERENCES members(id) ON DELETE SET NULL,
+
+  CONSTRAINT trip_comments_id_trip_key UNIQUE (id, trip_id),
+  CONSTRAINT trip_comments_parent_fkey FOREIGN KEY (parent_id, trip_id) REFERENCES trip_comments(id, trip_id) ON DELETE CASCADE,
+  CONSTRAINT trip_comments_deleted_check CHECK (deleted_at IS NULL OR (body = '' AND NOT pinned))
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_comments_trip_created ON trip_comments(trip_id, created_at, external_id);
+CREATE INDEX IF NOT EXISTS idx_trip_comments_pinned ON trip_comments(trip_id) WHERE pinned;
@@ -0,0 +1,6 @@
+-- 000025_trip_announcements.down.sql
+
+DROP TABLE IF EXISTS trip_announcement_deliveries;
+DROP TABLE IF EXISTS trip_announcements;
+DROP TYPE IF EXISTS announcement_delivery_status;
+DROP TYPE IF EXISTS announcement_audience;
@@ -0,0 +1,39 @@
+-- 000025_trip_announcements.up.sql
+--
+-- Organizer announcements on a trip, with one delivery row per recipient tracking whether the
+-- notification was sent. Rows go away with their trip; deliveries also with their member.
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'announcement_audience') THEN
+    CREATE TYPE announcement_audience AS ENUM ('ATTENDEES', 'NOT_ATTENDING', 'EVERYONE');
+  END IF;
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'announcement_delivery_status') THEN
+    CREATE TYPE announcement_delivery_status AS ENUM ('PENDING', 'SENT', 'FAILED');
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS trip_announcements (
+  id                bigserial PRIMARY KEY,
+  external_id       uuid NOT NULL UNIQUE,
+  trip_id           bigint NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+  author_member_id  bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  subject           text NOT NULL,
+  body              text NOT NULL,
+  audience          announcement_audience NOT NULL,
+  created_at        timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_announcements_trip ON trip_announcements(trip_id, created_at);
+
+CREATE TABLE IF NOT EXISTS trip_announcement_deliveries (
+  announcement_id  bigint NOT NULL REFERENCES trip_announcements(id) ON DELETE CASCADE,
+  member_id        bigint NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+  status           announcement_delivery_status NOT NULL DEFAULT 'PENDING',
+  error            text NULL,
+  attempted_at     timestamptz NULL,
+
+  PRIMARY KEY (announcement_id, member_id),
+  CONSTRAINT trip_announcement_deliveries_attempted_check CHECK ((status = 'PENDING') = (attempted_at IS NULL)),
+  CONSTRAINT trip_announcement_deliveries_error_check CHECK (status = 'FAILED' OR error IS NULL)
+);
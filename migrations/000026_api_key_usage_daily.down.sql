@@ -0,0 +1,25 @@
+-- 000026_api_key_usage_daily.down.sql
+--
+-- Restores the per-request audit trail; each daily row comes back as its most recent request.
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+  id         bigserial PRIMARY KEY,
+  api_key_id bigint NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+  used_at    timestamptz NOT NULL,
+  method     text NOT NULL,
+  path       text NOT NULL,
+  client_ip  text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_key_usage_key_used_at ON api_key_usage(api_key_id, used_at DESC);
+
+DO $$
+BEGIN
+  IF to_regclass('api_key_usage_daily') IS NOT NULL THEN
+    INSERT INTO api_key_usage (api_key_id, used_at, method, path, client_ip)
+    SELECT api_key_id, last_used_at, method, path, client_ip
+    FROM api_key_usage_daily;
+
+    DROP TABLE api_key_usage_daily;
+  END IF;
+END $$;
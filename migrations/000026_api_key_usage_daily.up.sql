@@ -0,0 +1,35 @@
+-- 000026_api_key_usage_daily.up.sql
+--
+-- The API key audit trail becomes one row per key, UTC day, method, path and client IP with a
+-- request counter, so usage no longer grows by a row per request. Existing per-request rows are
+-- folded into the daily rows. Days older than API_KEY_USAGE_RETENTION are purged by the server.
+
+CREATE TABLE IF NOT EXISTS api_key_usage_daily (
+  id            bigserial PRIMARY KEY,
+  api_key_id    bigint NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+  usage_date    date NOT NULL,
+  method        text NOT NULL,
+  path          text NOT NULL,
+  client_ip     text NOT NULL DEFAULT '',
+  request_count bigint NOT NULL CHECK (request_count > 0),
+  first_used_at timestamptz NOT NULL,
+  last_used_at  timestamptz NOT NULL,
+
+  UNIQUE (api_key_id, usage_date, method, path, client_ip)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_key_usage_daily_key_last_used ON api_key_usage_daily(api_key_id, last_used_at DESC);
+CREATE INDEX IF NOT EXISTS idx_api_key_usage_daily_usage_date ON api_key_usage_daily(usage_date);
+
+DO $$
+BEGIN
+  IF to_regclass('api_key_usage') IS NOT NULL THEN
+    INSERT INTO api_key_usage_daily (api_key_id, usage_date, method, path, client_ip, request_count, first_used_at, last_used_at)
+    SELECT api_key_id, (used_at AT TIME ZONE 'UTC')::date, method, path, client_ip, count(*), min(used_at), max(used_at)
+    FROM api_key_usage
+    GROUP BY api_key_id, (used_at AT TIME ZONE 'UTC')::date, method, path, client_ip
+    ON CONFLICT (api_key_id, usage_date, method, path, client_ip) DO NOTHING;
+
+    DROP TABLE api_key_usage;
+  END IF;
+END $$;
@@ -0,0 +1,4 @@
+-- 000027_rsvp_history_no_delete.down.sql
+
+DROP TRIGGER IF EXISTS trg_trip_rsvp_history_no_delete ON trip_rsvp_history;
+DROP FUNCTION IF EXISTS prevent_rsvp_history_delete();
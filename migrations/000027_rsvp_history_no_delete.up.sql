@@ -0,0 +1,28 @@
+-- 000027_rsvp_history_no_delete.up.sql
+--
+-- RSVP history rows cannot be deleted directly either. Deletes cascading from the trip or
+-- member run inside the foreign key's own trigger (trigger depth > 1) and are still allowed,
+-- so history goes away with its trip or member.
+
+CREATE OR REPLACE FUNCTION prevent_rsvp_history_delete()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+  IF pg_trigger_depth() > 1 THEN
+    RETURN OLD;
+  END IF;
+  RAISE EXCEPTION 'RSVP history is append-only (id=%)', OLD.id
+    USING ERRCODE = '23514';
+END;
+$$;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_trip_rsvp_history_no_delete') THEN
+    CREATE TRIGGER trg_trip_rsvp_history_no_delete
+    BEFORE DELETE ON trip_rsvp_history
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_rsvp_history_delete();
+  END IF;
+END $$;
@@ -0,0 +1,11 @@
+-- 000028_announcement_waitlisted_audience.down.sql
+--
+-- Enum values cannot be dropped, so the type is rebuilt. Waitlist announcements go with it.
+
+DELETE FROM trip_announcements WHERE audience = 'WAITLISTED';
+
+ALTER TYPE announcement_audience RENAME TO announcement_audience_old;
+CREATE TYPE announcement_audience AS ENUM ('ATTENDEES', 'NOT_ATTENDING', 'EVERYONE');
+ALTER TABLE trip_announcements
+  ALTER COLUMN audience TYPE announcement_audience USING audience::text::announcement_audience;
+DROP TYPE announcement_audience_old;
@@ -0,0 +1,6 @@
+-- 000028_announcement_waitlisted_audience.up.sql
+--
+-- Announcements can also go to the trip's waitlist: riders whose ride-share request is still
+-- pending.
+
+ALTER TYPE announcement_audience ADD VALUE IF NOT EXISTS 'WAITLISTED';
@@ -0,0 +1,12 @@
+-- 000029_announcement_service_authors.down.sql
+--
+-- Announcements posted by service accounts have no member author and go away.
+
+DELETE FROM trip_announcements WHERE author_member_id IS NULL;
+
+ALTER TABLE trip_announcements
+  DROP CONSTRAINT IF EXISTS trip_announcements_author_check,
+  DROP COLUMN IF EXISTS author_service_account;
+
+ALTER TABLE trip_announcements
+  ALTER COLUMN author_member_id SET NOT NULL;
@@ -0,0 +1,17 @@
+-- 000029_announcement_service_authors.up.sql
+--
+-- Service accounts holding the announcements:write scope post announcements too. Those have no
+-- member author; the API key's name is kept instead. Exactly one of the two is set.
+
+ALTER TABLE trip_announcements
+  ALTER COLUMN author_member_id DROP NOT NULL;
+
+ALTER TABLE trip_announcements
+  ADD COLUMN IF NOT EXISTS author_service_account text NULL;
+
+ALTER TABLE trip_announcements
+  DROP CONSTRAINT IF EXISTS trip_announcements_author_check;
+ALTER TABLE trip_announcements
+  ADD CONSTRAINT trip_announcements_author_check CHECK (
+    (author_member_id IS NULL) <> (author_service_account IS NULL)
+  );
@@ -0,0 +1,21 @@
+-- 000030_announcement_delivery_claims.down.sql
+--
+-- Enum values cannot be dropped, so the type is rebuilt (with the constraints that compare
+-- against it). In-flight deliveries go back to PENDING.
+
+ALTER TABLE trip_announcement_deliveries DROP COLUMN IF EXISTS sent_to;
+
+UPDATE trip_announcement_deliveries SET status = 'PENDING', attempted_at = NULL WHERE status = 'SENDING';
+
+ALTER TABLE trip_announcement_deliveries
+  DROP CONSTRAINT IF EXISTS trip_announcement_deliveries_attempted_check,
+  DROP CONSTRAINT IF EXISTS trip_announcement_deliveries_error_check,
+  ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE announcement_delivery_status RENAME TO announcement_delivery_status_old;
+CREATE TYPE announcement_delivery_status AS ENUM ('PENDING', 'SENT', 'FAILED');
+ALTER TABLE trip_announcement_deliveries
+  ALTER COLUMN status TYPE announcement_delivery_status USING status::text::announcement_delivery_status,
+  ALTER COLUMN status SET DEFAULT 'PENDING',
+  ADD CONSTRAINT trip_announcement_deliveries_attempted_check CHECK ((status = 'PENDING') = (attempted_at IS NULL)),
+  ADD CONSTRAINT trip_announcement_deliveries_error_check CHECK (status = 'FAILED' OR error IS NULL);
+DROP TYPE announcement_delivery_status_old;
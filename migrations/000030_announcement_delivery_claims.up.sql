@@ -0,0 +1,10 @@
+-- 000030_announcement_delivery_claims.up.sql
+--
+-- Senders claim a delivery (PENDING or FAILED -> SENDING) before mailing it, so concurrent
+-- sends and resends never mail a recipient twice. sent_to records each address already sent
+-- to, so a retry after a partial failure only mails the rest.
+
+ALTER TYPE announcement_delivery_status ADD VALUE IF NOT EXISTS 'SENDING';
+
+ALTER TABLE trip_announcement_deliveries
+  ADD COLUMN IF NOT EXISTS sent_to text[] NOT NULL DEFAULT '{}';
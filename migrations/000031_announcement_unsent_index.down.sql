@@ -0,0 +1,3 @@
+-- 000031_announcement_unsent_index.down.sql
+
+DROP INDEX IF EXISTS idx_trip_announcement_deliveries_unsent;
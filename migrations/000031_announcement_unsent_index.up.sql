@@ -0,0 +1,9 @@
+-- 000031_announcement_unsent_index.up.sql
+--
+-- The background delivery sweep looks for announcements with PENDING deliveries (or SENDING
+-- ones whose sender died). Most deliveries end up SENT or FAILED, so a partial index keeps
+-- that lookup small.
+
+CREATE INDEX IF NOT EXISTS idx_trip_announcement_deliveries_unsent
+  ON trip_announcement_deliveries(announcement_id)
+  WHERE status IN ('PENDING', 'SENDING');